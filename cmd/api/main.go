@@ -6,15 +6,25 @@
 package main
 
 import (
+	"context"
+	"fmt"
 	"log"
 	"os"
 	"os/signal"
 	"syscall"
+	"text/tabwriter"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/cors"
-	"github.com/gofiber/fiber/v2/middleware/logger"
 	"github.com/gofiber/fiber/v2/middleware/recover"
+	"github.com/qantesm/subflow/internal/adapter/handler"
+	"github.com/qantesm/subflow/internal/adapter/middleware"
+	"github.com/qantesm/subflow/internal/adapter/migrate"
+	"github.com/qantesm/subflow/internal/adapter/repository"
+	"github.com/qantesm/subflow/internal/config"
+	"github.com/qantesm/subflow/internal/pkg"
+	"github.com/qantesm/subflow/migrations"
 )
 
 // Application metadata - Digital fingerprint
@@ -26,50 +36,192 @@ const (
 )
 
 func main() {
+	// subflow config check [-config file] [flags]
+	if len(os.Args) > 1 && os.Args[1] == "config" {
+		os.Exit(runConfigCommand(os.Args[2:]))
+	}
+	// subflow migrate up|down|status [-config file] [flags]
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		os.Exit(runMigrateCommand(os.Args[2:]))
+	}
+
+	cfg, err := config.Load(os.Args[1:])
+	if err != nil {
+		log.Fatalf("Failed to load configuration: %v", err)
+	}
+	if err := cfg.Validate(); err != nil {
+		log.Fatalf("Invalid configuration:\n%v", err)
+	}
+	pkg.InitLogger(cfg.Logging.Level, cfg.Logging.Pretty)
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	deps, err := build(ctx, cfg)
+	if err != nil {
+		pkg.Fatal("Failed to build application", err)
+	}
+
 	// Initialize Fiber with custom config
 	app := fiber.New(fiber.Config{
 		AppName:               AppName + " v" + AppVersion,
 		DisableStartupMessage: false,
-		EnablePrintRoutes:     true,
+		EnablePrintRoutes:     cfg.Environment == config.EnvDevelopment,
+		ReadTimeout:           cfg.Server.ReadTimeout,
+		WriteTimeout:          cfg.Server.WriteTimeout,
+		BodyLimit:             cfg.Server.BodyLimit,
+		ErrorHandler:          middleware.ErrorHandler,
 	})
 
 	// Middleware stack
-	app.Use(recover.New())
-	app.Use(logger.New(logger.Config{
-		Format: "[${time}] ${status} - ${latency} ${method} ${path}\n",
+	app.Use(middleware.RequestID())
+	app.Use(middleware.Logger(middleware.LoggerConfig{
+		Logger:      pkg.GetLogger(),
+		SamplePaths: []string{"/health"},
+		SampleEvery: uint32(cfg.Logging.HealthSample),
 	}))
+	app.Use(middleware.Metrics(deps.metrics))
+	app.Use(recover.New())
+	app.Use(middleware.SecurityHeaders())
 	app.Use(cors.New(cors.Config{
-		AllowOrigins: "*",
-		AllowHeaders: "Origin, Content-Type, Accept, Authorization",
+		AllowOrigins: cfg.Server.CORSOrigins,
+		AllowHeaders: "Origin, Content-Type, Accept, Authorization, X-Tenant-ID, X-Request-ID, Last-Event-ID",
 	}))
 
-	// Health check endpoints
+	// Health check and metrics endpoints
 	setupHealthRoutes(app)
+	if cfg.Server.Metrics {
+		handler.NewMetricsHandler(deps.metrics).RegisterRoutes(app)
+	}
 
 	// API v1 routes
-	setupAPIRoutes(app)
+	setupAPIRoutes(app, deps)
+
+	deps.start(ctx)
 
 	// Graceful shutdown
 	go func() {
-		sigChan := make(chan os.Signal, 1)
-		signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
-		<-sigChan
-		log.Println("Shutting down SubFlow server...")
-		if err := app.Shutdown(); err != nil {
-			log.Printf("Error during shutdown: %v", err)
+		<-ctx.Done()
+		pkg.Info("Shutting down SubFlow server...")
+		if err := app.ShutdownWithTimeout(cfg.Server.ShutdownTimeout); err != nil {
+			pkg.Error("Error during shutdown", err)
 		}
 	}()
 
 	// Start server
-	port := os.Getenv("PORT")
-	if port == "" {
-		port = "3000"
+	pkg.Info(fmt.Sprintf("🏗️ SubFlow Enterprise Engine starting on port %d (%s)", cfg.Server.Port, cfg.Environment))
+	if err := app.Listen(cfg.Server.Addr()); err != nil {
+		deps.close()
+		pkg.Fatal("Failed to start server", err)
 	}
 
-	log.Printf("🏗️ SubFlow Enterprise Engine starting on port %s", port)
-	if err := app.Listen(":" + port); err != nil {
-		log.Fatalf("Failed to start server: %v", err)
+	// The server has drained its requests; stop the jobs and workers, then close the pools
+	deps.close()
+	pkg.Info("SubFlow server stopped")
+}
+
+// runConfigCommand prints the effective configuration with secrets masked and
+// validates it; the exit code tells deploy scripts whether it can start
+func runConfigCommand(args []string) int {
+	if len(args) == 0 || args[0] != "check" {
+		fmt.Fprintln(os.Stderr, "usage: subflow config check [-config file.yaml] [flags]")
+		return 2
+	}
+
+	cfg, err := config.Load(args[1:])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "config: %v\n", err)
+		return 1
+	}
+	if err := cfg.Describe(os.Stdout); err != nil {
+		return 1
+	}
+	if err := cfg.Validate(); err != nil {
+		fmt.Fprintf(os.Stderr, "\nconfig check failed:\n%v\n", err)
+		return 1
 	}
+	fmt.Println("\nconfig OK")
+	return 0
+}
+
+// runMigrateCommand applies, rolls back or lists the embedded migrations on
+// the configured database; status exits non-zero while the schema is behind
+func runMigrateCommand(args []string) int {
+	if len(args) == 0 || (args[0] != "up" && args[0] != "down" && args[0] != "status") {
+		fmt.Fprintln(os.Stderr, "usage: subflow migrate up|down|status [-config file.yaml] [flags]")
+		return 2
+	}
+
+	cfg, err := config.Load(args[1:])
+	if err == nil {
+		err = cfg.Validate()
+	}
+	if err == nil && cfg.Database.Backend != config.BackendPostgres {
+		err = fmt.Errorf("database.backend is %s; migrations need postgres", cfg.Database.Backend)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "config: %v\n", err)
+		return 1
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+	pool, err := repository.NewPool(ctx, poolConfig(cfg.Database))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "database: %v\n", err)
+		return 1
+	}
+	defer pool.Close()
+
+	loaded, err := migrate.Load(migrations.FS)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "migrations: %v\n", err)
+		return 1
+	}
+	migrator := migrate.New(pool, loaded)
+
+	switch args[0] {
+	case "up":
+		applied, err := migrator.Up(ctx)
+		for _, m := range applied {
+			fmt.Printf("applied %06d_%s\n", m.Version, m.Name)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "migrate up: %v\n", err)
+			return 1
+		}
+		if len(applied) == 0 {
+			fmt.Println("schema is up to date")
+		}
+	case "down":
+		m, err := migrator.Down(ctx)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "migrate down: %v\n", err)
+			return 1
+		}
+		fmt.Printf("rolled back %06d_%s\n", m.Version, m.Name)
+	case "status":
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "migrate status: %v\n", err)
+			return 1
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "VERSION\tNAME\tSTATE\tAPPLIED AT")
+		for _, s := range statuses {
+			appliedAt := "-"
+			if s.AppliedAt != nil {
+				appliedAt = s.AppliedAt.Format(time.RFC3339)
+			}
+			fmt.Fprintf(w, "%06d\t%s\t%s\t%s\n", s.Version, s.Name, s.State, appliedAt)
+		}
+		w.Flush()
+		if err := migrate.Verify(statuses); err != nil {
+			fmt.Fprintf(os.Stderr, "\n%v\n", err)
+			return 1
+		}
+	}
+	return 0
 }
 
 func setupHealthRoutes(app *fiber.App) {
@@ -94,33 +246,12 @@ func setupHealthRoutes(app *fiber.App) {
 	})
 }
 
-func setupAPIRoutes(app *fiber.App) {
-	api := app.Group("/api/v1")
-
-	// Projects endpoints (placeholder)
-	projects := api.Group("/projects")
-	projects.Get("/", func(c *fiber.Ctx) error {
-		return c.JSON(fiber.Map{"message": "List all projects"})
-	})
-	projects.Get("/:id", func(c *fiber.Ctx) error {
-		return c.JSON(fiber.Map{"message": "Get project", "id": c.Params("id")})
-	})
-	projects.Get("/:id/financials/summary", func(c *fiber.Ctx) error {
-		return c.JSON(fiber.Map{
-			"message":    "Financial summary for project",
-			"id":         c.Params("id"),
-			"total_due":  0,
-			"retainage":  0,
-			"paid":       0,
-		})
-	})
-
-	// Applications endpoints (placeholder)
-	applications := api.Group("/applications")
-	applications.Post("/:id/generate-pdf", func(c *fiber.Ctx) error {
-		return c.JSON(fiber.Map{
-			"message": "PDF generation triggered",
-			"id":      c.Params("id"),
-		})
-	})
+// setupAPIRoutes mounts the handlers behind the tenant, authentication and audit middleware
+func setupAPIRoutes(app *fiber.App, deps *container) {
+	api := app.Group("/api/v1",
+		middleware.TenantContext(),
+		middleware.AuthRequired(),
+		handler.AuditTrail(deps.audit),
+	)
+	deps.registerRoutes(api)
 }
@@ -0,0 +1,415 @@
+// Copyright (c) 2026 Muhammet Ali Büyük. All rights reserved.
+// This source code is proprietary. Confidential and private.
+// Unauthorized copying or distribution is strictly prohibited.
+// Contact: iletisim@alibuyuk.net | Website: alibuyuk.net
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/qantesm/subflow/internal/adapter/efatura"
+	"github.com/qantesm/subflow/internal/adapter/handler"
+	"github.com/qantesm/subflow/internal/adapter/mail"
+	"github.com/qantesm/subflow/internal/adapter/metrics"
+	"github.com/qantesm/subflow/internal/adapter/migrate"
+	"github.com/qantesm/subflow/internal/adapter/redisstream"
+	"github.com/qantesm/subflow/internal/adapter/repository"
+	"github.com/qantesm/subflow/internal/config"
+	"github.com/qantesm/subflow/internal/core/entity"
+	"github.com/qantesm/subflow/internal/core/service"
+	"github.com/qantesm/subflow/internal/pkg"
+	"github.com/qantesm/subflow/migrations"
+)
+
+// Intervals of the scheduled jobs
+const (
+	outboxInterval       = 2 * time.Second
+	webhookRetryInterval = 30 * time.Second
+	dailyInterval        = 24 * time.Hour // Expiry checks, recurring deductions, email digests
+)
+
+// repositories are the storage adapters behind the service ports
+type repositories struct {
+	projects      service.ProjectRepository
+	transactions  service.TransactionRepository
+	payApps       service.PayApplicationRepository
+	retainage     service.RetainageRepository
+	advances      service.AdvanceRepository
+	deductions    service.DeductionRepository
+	taxRates      service.TaxRateRepository
+	priceIndices  service.PriceIndexRepository
+	escalation    service.EscalationRepository
+	guarantees    service.GuaranteeRepository
+	compliance    service.ComplianceRepository
+	costs         service.CostRepository
+	cashFlow      service.CashFlowPlanRepository
+	statements    service.BankStatementRepository
+	einvoices     service.EInvoiceRepository
+	notifications service.NotificationRepository
+	preferences   service.NotificationPreferenceRepository
+	digests       service.EmailDigestRepository
+	webhooks      service.WebhookRepository
+	audit         service.AuditRepository
+	outbox        service.OutboxRepository
+	transactor    service.Transactor // Units of work spanning several repositories
+	liveBroker    service.LiveBroker // Nil when one process serves every live client
+}
+
+// newMemoryRepositories keeps everything in process; the repositories that
+// raise domain events share one outbox
+func newMemoryRepositories() *repositories {
+	outbox := repository.NewInMemoryOutbox()
+	projects := repository.NewInMemoryProjectRepository()
+	projects.SetOutbox(outbox)
+	transactions := repository.NewInMemoryTransactionRepository()
+	transactions.SetOutbox(outbox)
+	payApps := repository.NewInMemoryPayApplicationRepository()
+	payApps.SetOutbox(outbox)
+	retainage := repository.NewInMemoryRetainageRepository()
+	retainage.SetOutbox(outbox)
+
+	return &repositories{
+		projects:      projects,
+		transactions:  transactions,
+		payApps:       payApps,
+		retainage:     retainage,
+		advances:      repository.NewInMemoryAdvanceRepository(),
+		deductions:    repository.NewInMemoryDeductionRepository(),
+		taxRates:      repository.NewInMemoryTaxRateRepository(),
+		priceIndices:  repository.NewInMemoryPriceIndexRepository(),
+		escalation:    repository.NewInMemoryEscalationRepository(),
+		guarantees:    repository.NewInMemoryGuaranteeRepository(),
+		compliance:    repository.NewInMemoryComplianceRepository(),
+		costs:         repository.NewInMemoryCostRepository(),
+		cashFlow:      repository.NewInMemoryCashFlowPlanRepository(),
+		statements:    repository.NewInMemoryBankStatementRepository(),
+		einvoices:     repository.NewInMemoryEInvoiceRepository(),
+		notifications: repository.NewInMemoryNotificationRepository(),
+		preferences:   repository.NewInMemoryNotificationPreferenceRepository(),
+		digests:       repository.NewInMemoryEmailDigestRepository(),
+		webhooks:      repository.NewInMemoryWebhookRepository(),
+		audit:         repository.NewInMemoryAuditRepository(),
+		outbox:        outbox,
+		transactor:    repository.NewInMemoryTransactor(),
+	}
+}
+
+// poolConfig maps the database settings onto the connection pool options of the repository adapter
+func poolConfig(db config.DatabaseConfig) repository.Config {
+	return repository.Config{
+		Host:     db.Host,
+		Port:     db.Port,
+		User:     db.User,
+		Password: db.Password.Value(),
+		Database: db.Name,
+		SSLMode:  db.SSLMode,
+		MaxConns: int32(db.MaxConns),
+	}
+}
+
+// newPostgresRepositories stores everything in PostgreSQL; live messages are
+// fanned out to the other instances over LISTEN/NOTIFY
+func newPostgresRepositories(pool *repository.Pool) *repositories {
+	return &repositories{
+		projects:      repository.NewPostgresProjectRepository(pool),
+		transactions:  repository.NewPostgresTransactionRepository(pool),
+		payApps:       repository.NewPostgresPayApplicationRepository(pool),
+		retainage:     repository.NewPostgresRetainageRepository(pool),
+		advances:      repository.NewPostgresAdvanceRepository(pool),
+		deductions:    repository.NewPostgresDeductionRepository(pool),
+		taxRates:      repository.NewPostgresTaxRateRepository(pool),
+		priceIndices:  repository.NewPostgresPriceIndexRepository(pool),
+		escalation:    repository.NewPostgresEscalationRepository(pool),
+		guarantees:    repository.NewPostgresGuaranteeRepository(pool),
+		compliance:    repository.NewPostgresComplianceRepository(pool),
+		costs:         repository.NewPostgresCostRepository(pool),
+		cashFlow:      repository.NewPostgresCashFlowPlanRepository(pool),
+		statements:    repository.NewPostgresBankStatementRepository(pool),
+		einvoices:     repository.NewPostgresEInvoiceRepository(pool),
+		notifications: repository.NewPostgresNotificationRepository(pool),
+		preferences:   repository.NewPostgresNotificationPreferenceRepository(pool),
+		digests:       repository.NewPostgresEmailDigestRepository(pool),
+		webhooks:      repository.NewPostgresWebhookRepository(pool),
+		audit:         repository.NewPostgresAuditRepository(pool),
+		outbox:        repository.NewPostgresOutboxRepository(pool),
+		transactor:    pool,
+		liveBroker:    repository.NewPostgresLiveBroker(pool),
+	}
+}
+
+// routeRegistrar is implemented by every HTTP handler
+type routeRegistrar interface {
+	RegisterRoutes(router fiber.Router)
+}
+
+// scheduledJob is a job run with RunEvery
+type scheduledJob struct {
+	job      service.Job
+	interval time.Duration
+}
+
+// container is the application's dependency graph and the background work it owns
+type container struct {
+	db       *repository.Pool // Nil with the memory backend
+	redis    *redisstream.Sink
+	workers  *service.WorkerPool
+	hub      *service.LiveHub
+	audit    *service.AuditService
+	metrics  *metrics.Metrics
+	handlers []routeRegistrar
+	jobs     []scheduledJob
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// build wires repositories, services, handlers and jobs from the configuration
+func build(ctx context.Context, cfg *config.Config) (*container, error) {
+	c := &container{metrics: metrics.New()}
+
+	var repos *repositories
+	if cfg.Database.Backend == config.BackendMemory {
+		pkg.Warn("Using the in-memory backend; nothing is kept after a restart")
+		repos = newMemoryRepositories()
+	} else {
+		pool, err := repository.NewPool(ctx, poolConfig(cfg.Database))
+		if err != nil {
+			return nil, fmt.Errorf("connect to database: %w", err)
+		}
+		c.db = pool
+		c.metrics.RegisterDBPool(pool)
+		if err := checkSchema(ctx, pool); err != nil {
+			c.close()
+			return nil, err
+		}
+		repos = newPostgresRepositories(pool)
+	}
+
+	transport, err := mailTransport(cfg.Mail)
+	if err != nil {
+		c.close()
+		return nil, fmt.Errorf("mail transport: %w", err)
+	}
+
+	c.workers = service.NewWorkerPoolWithQueue(cfg.Workers.Count, cfg.Workers.QueueSize)
+	c.metrics.RegisterWorkerPool(c.workers)
+	c.hub = service.NewLiveHub(service.DefaultLiveHistory)
+	if repos.liveBroker != nil {
+		c.hub.SetBroker(repos.liveBroker)
+	}
+
+	calculator := service.NewCalculator()
+	c.audit = service.NewAuditService(repos.audit, repos.projects)
+
+	ledger := service.NewLedgerService(repos.transactions)
+	ledger.SetProjectRepository(repos.projects)
+	ledger.SetAuditor(c.audit)
+	ledger.SetTransactor(repos.transactor)
+
+	// Users manage their email preferences even while no transport is configured
+	emails := service.NewEmailNotifier(repos.projects, repos.preferences, repos.digests, transport)
+	var channels []service.Notifier
+	if transport != nil {
+		channels = append(channels, emails)
+	}
+	notifications := service.NewNotificationService(repos.notifications, channels...)
+
+	guarantees := service.NewGuaranteeService(repos.guarantees, notifications, service.DefaultGuaranteeAlertDays)
+	guarantees.SetAuditor(c.audit)
+	compliance := service.NewComplianceService(repos.compliance, repos.transactions, notifications, service.DefaultComplianceAlertDays)
+	compliance.SetAuditor(c.audit)
+	ledger.SetPaymentGate(compliance)
+
+	taxes := service.NewTaxEngine(repos.taxRates)
+	escalation := service.NewEscalationService(repos.priceIndices, repos.escalation)
+	advances := service.NewAdvanceService(repos.advances, ledger)
+	advances.SetAuditor(c.audit)
+	deductions := service.NewDeductionService(repos.deductions, repos.projects, ledger)
+	deductions.SetTransactor(repos.transactor)
+	deductions.SetAuditor(c.audit)
+
+	payApps := service.NewPayApplicationService(repos.payApps, calculator, taxes, escalation, advances, deductions, ledger)
+	payApps.SetAuditor(c.audit)
+	payApps.SetTransactor(repos.transactor)
+	payApps.SetProjectRepository(repos.projects)
+	projects := service.NewProjectService(repos.projects, repos.payApps, ledger)
+	projects.SetAuditor(c.audit)
+	projects.SetTransactor(repos.transactor)
+	retainage := service.NewRetainageService(repos.retainage, repos.projects, ledger, service.DefaultRetainageApprovals)
+	retainage.SetAuditor(c.audit)
+	retainage.SetTransactor(repos.transactor)
+
+	costs := service.NewJobCostService(repos.costs, repos.projects)
+	cashFlow := service.NewCashFlowService(repos.cashFlow, repos.projects, repos.payApps, ledger, repos.costs)
+	earnedValue := service.NewEarnedValueService(repos.cashFlow, repos.projects, repos.payApps, repos.costs)
+	reconciliation := service.NewReconciliationService(repos.statements, repos.transactions, ledger)
+	reconciliation.SetTransactor(repos.transactor)
+	reconciliation.SetAuditor(c.audit)
+	// The fake integrator stands in until a GİB integrator account is configured
+	einvoices := service.NewEInvoiceService(repos.einvoices, repos.transactions, repos.payApps, taxes, efatura.NewRenderer(), efatura.NewFakeIntegrator())
+
+	importer := service.NewImportService(repos.transactions)
+	importer.SetProjectRepository(repos.projects)
+	importer.SetPaymentGate(compliance)
+	importer.SetAuditor(c.audit)
+	importer.SetProgressReporter(c.hub)
+
+	webhooks := service.NewWebhookService(repos.webhooks, repos.projects, c.workers, nil, 0, 0)
+	webhooks.SetAuditor(c.audit)
+
+	// Outbox events reach webhooks, the in-process subscribers and, when configured, Redis
+	bus := service.NewEventBus()
+	bus.Subscribe(service.NewLedgerLiveFeed(c.hub, ledger).Handle, entity.EventTransactionCreated)
+	bus.Subscribe(notifications.HandleEvent, entity.EventPayApplicationSubmitted, entity.EventPayApplicationCertified)
+	bus.Subscribe(c.metrics.HandleEvent, entity.EventTransactionCreated, entity.EventPayApplicationCertified)
+	sinks := []service.EventPublisher{webhooks, bus}
+	if addr := cfg.Redis.Addr(); addr != "" {
+		c.redis = redisstream.NewSink(redisstream.Config{
+			Addr:     addr,
+			Password: cfg.Redis.Password.Value(),
+			DB:       cfg.Redis.DB,
+			Stream:   cfg.Redis.Stream,
+		})
+		sinks = append(sinks, c.redis)
+	}
+	dispatcher := service.NewOutboxDispatcher(repos.outbox, sinks...)
+
+	c.handlers = []routeRegistrar{
+		handler.NewProjectHandler(projects),
+		handler.NewTransactionHandler(ledger, calculator, taxes),
+		handler.NewImportHandler(importer),
+		handler.NewPayApplicationHandler(payApps),
+		handler.NewRetainageHandler(retainage),
+		handler.NewAdvanceHandler(advances),
+		handler.NewDeductionHandler(deductions),
+		handler.NewTaxHandler(taxes),
+		handler.NewEscalationHandler(escalation),
+		handler.NewGuaranteeHandler(guarantees),
+		handler.NewComplianceHandler(compliance),
+		handler.NewJobCostHandler(costs),
+		handler.NewCashFlowHandler(cashFlow),
+		handler.NewEarnedValueHandler(earnedValue),
+		handler.NewReconciliationHandler(reconciliation),
+		handler.NewEInvoiceHandler(einvoices),
+		handler.NewNotificationHandler(notifications),
+		handler.NewNotificationPreferenceHandler(emails),
+		handler.NewWebhookHandler(webhooks),
+		handler.NewAuditHandler(c.audit),
+		handler.NewLiveHandler(c.hub, repos.projects),
+	}
+
+	c.jobs = []scheduledJob{
+		{service.NewOutboxDispatchJob(dispatcher), outboxInterval},
+		{service.NewWebhookRetryJob(webhooks), webhookRetryInterval},
+		{service.NewGuaranteeExpiryJob(guarantees), dailyInterval},
+		{service.NewComplianceExpiryJob(compliance), dailyInterval},
+		{service.NewDeductionJob(deductions), dailyInterval},
+	}
+	if transport != nil {
+		c.jobs = append(c.jobs, scheduledJob{service.NewEmailDigestJob(emails), dailyInterval})
+	}
+	return c, nil
+}
+
+// checkSchema refuses to start on a database whose schema is behind this
+// build or whose applied migrations were edited
+func checkSchema(ctx context.Context, pool *repository.Pool) error {
+	loaded, err := migrate.Load(migrations.FS)
+	if err != nil {
+		return fmt.Errorf("load migrations: %w", err)
+	}
+	if err := migrate.New(pool, loaded).Check(ctx); err != nil {
+		return fmt.Errorf("database schema: %w", err)
+	}
+	return nil
+}
+
+// mailTransport returns the configured email transport, nil for none
+func mailTransport(cfg config.MailConfig) (service.MailTransport, error) {
+	switch cfg.Transport {
+	case config.MailSMTP:
+		return mail.NewSMTPTransport(mail.SMTPConfig{
+			Host:     cfg.Host,
+			Port:     cfg.Port,
+			Username: cfg.Username,
+			Password: cfg.Password.Value(),
+			From:     cfg.From,
+		}), nil
+	case config.MailFile:
+		return mail.NewFileTransport(cfg.Dir, cfg.From)
+	default:
+		return nil, nil
+	}
+}
+
+// registerRoutes mounts every handler on the router
+func (c *container) registerRoutes(router fiber.Router) {
+	for _, h := range c.handlers {
+		h.RegisterRoutes(router)
+	}
+}
+
+// start runs the worker pool, the live hub and the scheduled jobs until close
+func (c *container) start(ctx context.Context) {
+	ctx, c.cancel = context.WithCancel(ctx)
+
+	c.workers.Start()
+	// Workers block on a full results channel; nothing else reads it
+	go func() {
+		for result := range c.workers.Results() {
+			c.metrics.ObserveJob(result)
+			if result.Error != nil {
+				pkg.Error("Background job "+result.JobID+" failed", result.Error)
+			}
+		}
+	}()
+
+	c.wg.Add(1)
+	go func() {
+		defer c.wg.Done()
+		if err := c.hub.Run(ctx); err != nil && ctx.Err() == nil {
+			pkg.Error("Live event broker stopped", err)
+		}
+	}()
+
+	for _, j := range c.jobs {
+		j := j
+		// The job's queries and warnings carry its ID as requests carry theirs
+		jobCtx := pkg.WithLogger(ctx, pkg.GetLogger().With().Str("job", j.job.ID()).Logger())
+		c.wg.Add(1)
+		go func() {
+			defer c.wg.Done()
+			service.RunEvery(jobCtx, j.interval, j.job, c.reportJob)
+		}()
+	}
+}
+
+// close stops the background work, then releases the connections. Call it
+// after the HTTP server has shut down so no request submits work any more.
+func (c *container) close() {
+	if c.cancel != nil {
+		c.cancel()
+		c.wg.Wait()
+		c.workers.Stop()
+	}
+	if c.redis != nil {
+		if err := c.redis.Close(); err != nil {
+			pkg.Error("Failed to close Redis connection", err)
+		}
+	}
+	if c.db != nil {
+		c.db.Close()
+	}
+}
+
+func (c *container) reportJob(result service.JobResult) {
+	c.metrics.ObserveJob(result)
+	if result.Error != nil {
+		pkg.Error("Scheduled job "+result.JobID+" failed", result.Error)
+	}
+}
@@ -0,0 +1,131 @@
+// Copyright (c) 2026 Muhammet Ali Büyük. All rights reserved.
+// This source code is proprietary. Confidential and private.
+// Unauthorized copying or distribution is strictly prohibited.
+// Contact: iletisim@alibuyuk.net | Website: alibuyuk.net
+
+// Command import migrates historical transactions from CSV/XLSX into the ledger
+//
+// Usage:
+//
+//	go run ./cmd/import -file invoices.xlsx -project <uuid> -created-by <user uuid> -dry-run
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"log"
+	"os"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/qantesm/subflow/internal/adapter/repository"
+	"github.com/qantesm/subflow/internal/adapter/xlsx"
+	"github.com/qantesm/subflow/internal/config"
+	"github.com/qantesm/subflow/internal/core/entity"
+	"github.com/qantesm/subflow/internal/core/service"
+)
+
+func main() {
+	var (
+		filePath    = flag.String("file", "", "CSV or XLSX file to import")
+		projectFlag = flag.String("project", "", "Target project ID")
+		userFlag    = flag.String("created-by", "", "User ID recorded as the creator of imported rows")
+		mappingPath = flag.String("mapping", "", "Optional JSON file mapping source headers to fields")
+		currency    = flag.String("currency", "TRY", "Currency used when the file has no currency column")
+		dryRun      = flag.Bool("dry-run", false, "Validate and print the resulting summary without saving")
+	)
+	flag.Parse()
+
+	if *filePath == "" {
+		log.Fatal("-file is required")
+	}
+	projectID, err := uuid.Parse(*projectFlag)
+	if err != nil {
+		log.Fatalf("Invalid -project: %v", err)
+	}
+	createdBy, err := uuid.Parse(*userFlag)
+	if err != nil {
+		log.Fatalf("Invalid -created-by: %v", err)
+	}
+
+	data, err := os.ReadFile(*filePath)
+	if err != nil {
+		log.Fatalf("Failed to read file: %v", err)
+	}
+	records, err := xlsx.DecodeRecords(*filePath, data)
+	if err != nil {
+		log.Fatalf("Failed to decode file: %v", err)
+	}
+
+	var mapping service.ImportMapping
+	if *mappingPath != "" {
+		raw, err := os.ReadFile(*mappingPath)
+		if err != nil {
+			log.Fatalf("Failed to read mapping: %v", err)
+		}
+		if err := json.Unmarshal(raw, &mapping); err != nil {
+			log.Fatalf("Invalid mapping JSON: %v", err)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	// The database settings of the API: SUBFLOW_CONFIG and the DB_* variables
+	cfg, err := config.Load(nil)
+	if err != nil {
+		log.Fatalf("Failed to load configuration: %v", err)
+	}
+	pool, err := repository.NewPool(ctx, repository.Config{
+		Host:     cfg.Database.Host,
+		Port:     cfg.Database.Port,
+		User:     cfg.Database.User,
+		Password: cfg.Database.Password.Value(),
+		Database: cfg.Database.Name,
+		SSLMode:  cfg.Database.SSLMode,
+		MaxConns: 4, // A single import needs few connections
+	})
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+	defer pool.Close()
+
+	// The same checks as the API: project status, subcontractor compliance and the audit trail
+	transactions := repository.NewPostgresTransactionRepository(pool)
+	projects := repository.NewPostgresProjectRepository(pool)
+	notifications := service.NewNotificationService(repository.NewPostgresNotificationRepository(pool))
+	audit := service.NewAuditService(repository.NewPostgresAuditRepository(pool), projects)
+	compliance := service.NewComplianceService(repository.NewPostgresComplianceRepository(pool), transactions, notifications, service.DefaultComplianceAlertDays)
+	compliance.SetAuditor(audit)
+	importer := service.NewImportService(transactions)
+	importer.SetProjectRepository(projects)
+	importer.SetPaymentGate(compliance)
+	importer.SetAuditor(audit)
+	ctx = service.WithAuditActor(ctx, service.AuditActor{ActorID: &createdBy, UserAgent: "subflow-import"})
+
+	result, importErr := importer.Import(ctx, records, service.ImportOptions{
+		ProjectID:       projectID,
+		CreatedBy:       createdBy,
+		Mapping:         mapping,
+		DefaultCurrency: *currency,
+		DryRun:          *dryRun,
+	})
+
+	if result != nil {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(result); err != nil {
+			log.Fatalf("Failed to write result: %v", err)
+		}
+	}
+
+	if importErr != nil {
+		if errors.Is(importErr, entity.ErrImportRowsInvalid) {
+			log.Printf("Import rejected: %d of %d rows invalid", len(result.Errors), result.TotalRows)
+			os.Exit(2)
+		}
+		log.Fatalf("Import failed: %v", importErr)
+	}
+}
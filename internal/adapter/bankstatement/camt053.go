@@ -0,0 +1,204 @@
+// Copyright (c) 2026 Muhammet Ali Büyük. All rights reserved.
+// This source code is proprietary. Confidential and private.
+// Unauthorized copying or distribution is strictly prohibited.
+// Contact: iletisim@alibuyuk.net | Website: alibuyuk.net
+
+package bankstatement
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/qantesm/subflow/internal/core/entity"
+)
+
+// camtDocument covers the subset of camt.053.001.xx used for reconciliation
+// Element names are matched without namespace so all schema versions parse
+type camtDocument struct {
+	Statements []camtStatement `xml:"BkToCstmrStmt>Stmt"`
+}
+
+type camtStatement struct {
+	ID         string        `xml:"Id"`
+	SequenceNo string        `xml:"ElctrncSeqNb"`
+	IBAN       string        `xml:"Acct>Id>IBAN"`
+	OtherID    string        `xml:"Acct>Id>Othr>Id"`
+	Currency   string        `xml:"Acct>Ccy"`
+	Balances   []camtBalance `xml:"Bal"`
+	Entries    []camtEntry   `xml:"Ntry"`
+}
+
+type camtBalance struct {
+	Code      string     `xml:"Tp>CdOrPrtry>Cd"`
+	Amount    camtAmount `xml:"Amt"`
+	Indicator string     `xml:"CdtDbtInd"`
+	Date      camtDate   `xml:"Dt"`
+}
+
+type camtAmount struct {
+	Value    string `xml:",chardata"`
+	Currency string `xml:"Ccy,attr"`
+}
+
+type camtDate struct {
+	Date     string `xml:"Dt"`
+	DateTime string `xml:"DtTm"`
+}
+
+// camtStatus is plain text in camt.053.001.02 and a coded element from .08 onwards
+type camtStatus struct {
+	Text string `xml:",chardata"`
+	Code string `xml:"Cd"`
+}
+
+type camtEntry struct {
+	Amount        camtAmount `xml:"Amt"`
+	Indicator     string     `xml:"CdtDbtInd"`
+	Status        camtStatus `xml:"Sts"`
+	BookingDate   camtDate   `xml:"BookgDt"`
+	ValueDate     camtDate   `xml:"ValDt"`
+	ServicerRef   string     `xml:"AcctSvcrRef"`
+	AdditionalInf string     `xml:"AddtlNtryInf"`
+	Details       []struct {
+		EndToEndID   string   `xml:"Refs>EndToEndId"`
+		InstrID      string   `xml:"Refs>InstrId"`
+		Unstructured []string `xml:"RmtInf>Ustrd"`
+		CreditorRef  string   `xml:"RmtInf>Strd>CdtrRefInf>Ref"`
+		DebtorName   string   `xml:"RltdPties>Dbtr>Nm"`
+		DebtorIBAN   string   `xml:"RltdPties>DbtrAcct>Id>IBAN"`
+		CreditorName string   `xml:"RltdPties>Cdtr>Nm"`
+		CreditorIBAN string   `xml:"RltdPties>CdtrAcct>Id>IBAN"`
+	} `xml:"NtryDtls>TxDtls"`
+}
+
+// ParseCAMT053 parses an ISO 20022 camt.053 bank-to-customer statement
+// Only the first statement in the document is returned; pending entries are skipped
+func ParseCAMT053(data []byte, projectID, importedBy uuid.UUID) (*entity.BankStatement, error) {
+	var doc camtDocument
+	if err := xml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("camt.053: %w", err)
+	}
+	if len(doc.Statements) == 0 {
+		return nil, ErrUnknownFormat
+	}
+	src := doc.Statements[0]
+
+	stmt := entity.NewBankStatement(projectID, entity.StatementFormatCAMT053, importedBy)
+	stmt.AccountID = src.IBAN
+	if stmt.AccountID == "" {
+		stmt.AccountID = src.OtherID
+	}
+	stmt.StatementNo = src.SequenceNo
+	if stmt.StatementNo == "" {
+		stmt.StatementNo = src.ID
+	}
+	stmt.Currency = src.Currency
+
+	for _, bal := range src.Balances {
+		amount, err := camtSignedAmount(bal.Amount.Value, bal.Indicator == "DBIT")
+		if err != nil {
+			return nil, fmt.Errorf("camt.053 balance %s: %w", bal.Code, err)
+		}
+		if stmt.Currency == "" {
+			stmt.Currency = bal.Amount.Currency
+		}
+		switch bal.Code {
+		case "OPBD", "PRCD":
+			stmt.OpeningBalance = amount
+		case "CLBD":
+			stmt.ClosingBalance = amount
+			if date, err := bal.Date.parse(); err == nil {
+				stmt.StatementDate = date
+			}
+		}
+	}
+
+	for i, ntry := range src.Entries {
+		status := ntry.Status.Code
+		if status == "" {
+			status = strings.TrimSpace(ntry.Status.Text)
+		}
+		if status == "PDNG" || status == "INFO" {
+			continue
+		}
+
+		amount, err := parseDecimalCents(ntry.Amount.Value, '.')
+		if err != nil {
+			return nil, fmt.Errorf("camt.053 entry %d: %w", i+1, err)
+		}
+
+		direction := entity.EntryDirectionCredit
+		if ntry.Indicator == "DBIT" {
+			direction = entity.EntryDirectionDebit
+		}
+
+		line := &entity.StatementLine{
+			Direction:     direction,
+			AmountCents:   amount,
+			Currency:      ntry.Amount.Currency,
+			BankReference: ntry.ServicerRef,
+			Description:   ntry.AdditionalInf,
+		}
+		line.BookingDate, _ = ntry.BookingDate.parse()
+		line.ValueDate, err = ntry.ValueDate.parse()
+		if err != nil {
+			line.ValueDate = line.BookingDate
+		}
+
+		if len(ntry.Details) > 0 {
+			tx := ntry.Details[0]
+			line.Reference = firstNonEmpty(tx.CreditorRef, tx.EndToEndID, tx.InstrID)
+			if line.Reference == "NOTPROVIDED" {
+				line.Reference = ""
+			}
+			if len(tx.Unstructured) > 0 {
+				line.Description = strings.TrimSpace(strings.Join(tx.Unstructured, " "))
+			}
+			if direction == entity.EntryDirectionCredit {
+				line.CounterpartyName, line.CounterpartyAccount = tx.DebtorName, tx.DebtorIBAN
+			} else {
+				line.CounterpartyName, line.CounterpartyAccount = tx.CreditorName, tx.CreditorIBAN
+			}
+		}
+
+		stmt.AddLine(line)
+	}
+
+	return stmt, nil
+}
+
+func (d camtDate) parse() (time.Time, error) {
+	if d.Date != "" {
+		return time.Parse("2006-01-02", d.Date)
+	}
+	if d.DateTime != "" {
+		if t, err := time.Parse(time.RFC3339, d.DateTime); err == nil {
+			return t, nil
+		}
+		return time.Parse("2006-01-02T15:04:05", d.DateTime)
+	}
+	return time.Time{}, fmt.Errorf("missing date")
+}
+
+func camtSignedAmount(raw string, debit bool) (int64, error) {
+	amount, err := parseDecimalCents(raw, '.')
+	if err != nil {
+		return 0, err
+	}
+	if debit {
+		amount = -amount
+	}
+	return amount, nil
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if strings.TrimSpace(v) != "" {
+			return strings.TrimSpace(v)
+		}
+	}
+	return ""
+}
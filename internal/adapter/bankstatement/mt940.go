@@ -0,0 +1,200 @@
+// Copyright (c) 2026 Muhammet Ali Büyük. All rights reserved.
+// This source code is proprietary. Confidential and private.
+// Unauthorized copying or distribution is strictly prohibited.
+// Contact: iletisim@alibuyuk.net | Website: alibuyuk.net
+
+package bankstatement
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/qantesm/subflow/internal/core/entity"
+)
+
+// mt940Field is a single :TAG: value, with continuation lines joined by newlines
+type mt940Field struct {
+	tag   string
+	value string
+}
+
+// balanceRegex matches :60F:/:62F: - C/D mark, YYMMDD, currency, amount
+var balanceRegex = regexp.MustCompile(`^([CD])(\d{6})([A-Z]{3})([\d,]+)$`)
+
+// statementLineRegex matches the :61: statement line subfields
+// value date, optional entry date (MMDD), debit/credit mark (C, D, RC, RD),
+// optional funds code, amount, transaction type, customer ref and optional //bank ref
+var statementLineRegex = regexp.MustCompile(`^(\d{6})(\d{4})?(RC|RD|C|D)([A-Z])?([\d,]+)([NSF][A-Z0-9]{3})([^/\n]*)(?://([^\n]*))?(?:\n([\s\S]*))?$`)
+
+// ParseMT940 parses a SWIFT MT940 customer statement
+// Only the first statement in a multi-statement file is returned
+func ParseMT940(data []byte, projectID, importedBy uuid.UUID) (*entity.BankStatement, error) {
+	fields, err := splitMT940Fields(data)
+	if err != nil {
+		return nil, err
+	}
+
+	stmt := entity.NewBankStatement(projectID, entity.StatementFormatMT940, importedBy)
+	var current *entity.StatementLine
+	seenOpening := false
+
+	for _, f := range fields {
+		switch f.tag {
+		case "20":
+			if seenOpening {
+				// Start of a second statement in the same file
+				return finishMT940(stmt)
+			}
+		case "25":
+			stmt.AccountID = strings.TrimSpace(f.value)
+		case "28C", "28":
+			stmt.StatementNo = strings.TrimSpace(f.value)
+		case "60F", "60M":
+			amount, currency, date, err := parseMT940Balance(f.value)
+			if err != nil {
+				return nil, fmt.Errorf("mt940 :%s: %w", f.tag, err)
+			}
+			stmt.OpeningBalance, stmt.Currency = amount, currency
+			stmt.StatementDate = date
+			seenOpening = true
+		case "61":
+			line, err := parseMT940Line(f.value)
+			if err != nil {
+				return nil, fmt.Errorf("mt940 :61: %w", err)
+			}
+			stmt.AddLine(line)
+			current = line
+		case "86":
+			if current != nil {
+				current.Description = strings.TrimSpace(strings.ReplaceAll(f.value, "\n", " "))
+			}
+		case "62F", "62M":
+			amount, _, date, err := parseMT940Balance(f.value)
+			if err != nil {
+				return nil, fmt.Errorf("mt940 :%s: %w", f.tag, err)
+			}
+			stmt.ClosingBalance = amount
+			stmt.StatementDate = date
+			current = nil
+		}
+	}
+
+	return finishMT940(stmt)
+}
+
+func finishMT940(stmt *entity.BankStatement) (*entity.BankStatement, error) {
+	if stmt.Currency == "" {
+		return nil, fmt.Errorf("mt940: missing opening balance :60F:")
+	}
+	return stmt, nil
+}
+
+// splitMT940Fields tokenizes the message body into tags, ignoring SWIFT block headers
+func splitMT940Fields(data []byte) ([]mt940Field, error) {
+	var fields []mt940Field
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if line == "" || line == "-" || line == "-}" || strings.HasPrefix(line, "{") {
+			continue
+		}
+
+		if strings.HasPrefix(line, ":") {
+			end := strings.Index(line[1:], ":")
+			if end > 0 {
+				fields = append(fields, mt940Field{tag: line[1 : end+1], value: line[end+2:]})
+				continue
+			}
+		}
+
+		// Continuation of the previous field
+		if len(fields) > 0 {
+			fields[len(fields)-1].value += "\n" + line
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("mt940: %w", err)
+	}
+	if len(fields) == 0 {
+		return nil, ErrUnknownFormat
+	}
+	return fields, nil
+}
+
+func parseMT940Balance(value string) (int64, string, time.Time, error) {
+	m := balanceRegex.FindStringSubmatch(strings.TrimSpace(value))
+	if m == nil {
+		return 0, "", time.Time{}, fmt.Errorf("invalid balance %q", value)
+	}
+
+	date, err := time.Parse("060102", m[2])
+	if err != nil {
+		return 0, "", time.Time{}, fmt.Errorf("invalid balance date %q", m[2])
+	}
+	amount, err := parseDecimalCents(m[4], ',')
+	if err != nil {
+		return 0, "", time.Time{}, err
+	}
+	if m[1] == "D" {
+		amount = -amount
+	}
+	return amount, m[3], date, nil
+}
+
+func parseMT940Line(value string) (*entity.StatementLine, error) {
+	m := statementLineRegex.FindStringSubmatch(strings.TrimSpace(value))
+	if m == nil {
+		return nil, fmt.Errorf("invalid statement line %q", value)
+	}
+
+	valueDate, err := time.Parse("060102", m[1])
+	if err != nil {
+		return nil, fmt.Errorf("invalid value date %q", m[1])
+	}
+
+	bookingDate := valueDate
+	if m[2] != "" {
+		// Entry date has no year - take the value date's year, rolling over at year end
+		entry, err := time.Parse("0102", m[2])
+		if err != nil {
+			return nil, fmt.Errorf("invalid entry date %q", m[2])
+		}
+		bookingDate = time.Date(valueDate.Year(), entry.Month(), entry.Day(), 0, 0, 0, 0, time.UTC)
+		if bookingDate.Sub(valueDate) > 180*24*time.Hour {
+			bookingDate = bookingDate.AddDate(-1, 0, 0)
+		} else if valueDate.Sub(bookingDate) > 180*24*time.Hour {
+			bookingDate = bookingDate.AddDate(1, 0, 0)
+		}
+	}
+
+	amount, err := parseDecimalCents(m[5], ',')
+	if err != nil {
+		return nil, err
+	}
+
+	// RC / RD are reversals and flip the direction
+	direction := entity.EntryDirectionCredit
+	if m[3] == "D" || m[3] == "RC" {
+		direction = entity.EntryDirectionDebit
+	}
+
+	reference := strings.TrimSpace(m[7])
+	if reference == "NONREF" {
+		reference = ""
+	}
+
+	return &entity.StatementLine{
+		BookingDate:   bookingDate,
+		ValueDate:     valueDate,
+		Direction:     direction,
+		AmountCents:   amount,
+		Reference:     reference,
+		BankReference: strings.TrimSpace(m[8]),
+		Description:   strings.TrimSpace(m[9]),
+	}, nil
+}
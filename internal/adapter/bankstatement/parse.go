@@ -0,0 +1,67 @@
+// Copyright (c) 2026 Muhammet Ali Büyük. All rights reserved.
+// This source code is proprietary. Confidential and private.
+// Unauthorized copying or distribution is strictly prohibited.
+// Contact: iletisim@alibuyuk.net | Website: alibuyuk.net
+
+// Package bankstatement parses SWIFT MT940 and ISO 20022 CAMT.053 account statements
+// into entity.BankStatement values for payment reconciliation
+package bankstatement
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/qantesm/subflow/internal/core/entity"
+)
+
+// ErrUnknownFormat is returned when the data is neither MT940 nor CAMT.053
+var ErrUnknownFormat = errors.New("unrecognised bank statement format, expected MT940 or CAMT.053")
+
+// Parse detects the statement format from its content and parses it
+// XML documents are treated as CAMT.053, tagged text as MT940
+func Parse(data []byte, projectID, importedBy uuid.UUID) (*entity.BankStatement, error) {
+	trimmed := bytes.TrimSpace(bytes.TrimPrefix(data, []byte("\xef\xbb\xbf")))
+
+	switch {
+	case bytes.HasPrefix(trimmed, []byte("<")):
+		return ParseCAMT053(trimmed, projectID, importedBy)
+	case bytes.Contains(trimmed, []byte(":20:")) && bytes.Contains(trimmed, []byte(":61:")) ||
+		bytes.Contains(trimmed, []byte(":60F:")):
+		return ParseMT940(trimmed, projectID, importedBy)
+	default:
+		return nil, ErrUnknownFormat
+	}
+}
+
+// parseDecimalCents converts a decimal string with the given separator into cents
+// Statement amounts never use thousands separators
+func parseDecimalCents(raw string, sep byte) (int64, error) {
+	raw = strings.TrimSpace(raw)
+	intPart, fracPart := raw, ""
+	if i := strings.IndexByte(raw, sep); i >= 0 {
+		intPart, fracPart = raw[:i], raw[i+1:]
+	}
+	if intPart == "" {
+		intPart = "0"
+	}
+	if len(fracPart) > 2 {
+		return 0, fmt.Errorf("invalid amount %q: more than two decimal places", raw)
+	}
+	for len(fracPart) < 2 {
+		fracPart += "0"
+	}
+
+	major, err := strconv.ParseInt(intPart, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid amount %q", raw)
+	}
+	minor, err := strconv.ParseInt(fracPart, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid amount %q", raw)
+	}
+	return major*100 + minor, nil
+}
@@ -0,0 +1,186 @@
+// Copyright (c) 2026 Muhammet Ali Büyük. All rights reserved.
+// This source code is proprietary. Confidential and private.
+// Unauthorized copying or distribution is strictly prohibited.
+// Contact: iletisim@alibuyuk.net | Website: alibuyuk.net
+
+package bankstatement
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/qantesm/subflow/internal/core/entity"
+)
+
+const sampleMT940 = `{1:F01TGBATRISAXXX0000000000}{2:I940TGBATRISXXXXN}{4:
+:20:STMT260131
+:25:TR330006100519786457841326
+:28C:00012/001
+:60F:C260130TRY100000,00
+:61:2601310131C250000,00NTRFHKD-2026-004//BNK7781
+ISVEREN A.S. HAKEDIS ODEMESI
+:86:HKD-2026-004 NOLU FATURA ODEMESI
+:61:260131D1250,50NCHGNONREF//BNK7782
+:86:EFT MASRAFI
+:62F:C260131TRY348749,50
+-}`
+
+const sampleCAMT053 = `<?xml version="1.0" encoding="UTF-8"?>
+<Document xmlns="urn:iso:std:iso:20022:tech:xsd:camt.053.001.02">
+  <BkToCstmrStmt>
+    <GrpHdr><MsgId>MSG-1</MsgId><CreDtTm>2026-01-31T18:00:00</CreDtTm></GrpHdr>
+    <Stmt>
+      <Id>STMT-1</Id>
+      <ElctrncSeqNb>12</ElctrncSeqNb>
+      <Acct><Id><IBAN>TR330006100519786457841326</IBAN></Id><Ccy>TRY</Ccy></Acct>
+      <Bal>
+        <Tp><CdOrPrtry><Cd>OPBD</Cd></CdOrPrtry></Tp>
+        <Amt Ccy="TRY">1000.00</Amt><CdtDbtInd>CRDT</CdtDbtInd>
+        <Dt><Dt>2026-01-30</Dt></Dt>
+      </Bal>
+      <Bal>
+        <Tp><CdOrPrtry><Cd>CLBD</Cd></CdOrPrtry></Tp>
+        <Amt Ccy="TRY">2450.25</Amt><CdtDbtInd>CRDT</CdtDbtInd>
+        <Dt><Dt>2026-01-31</Dt></Dt>
+      </Bal>
+      <Ntry>
+        <Amt Ccy="TRY">1500.25</Amt><CdtDbtInd>CRDT</CdtDbtInd>
+        <Sts>BOOK</Sts>
+        <BookgDt><Dt>2026-01-31</Dt></BookgDt>
+        <ValDt><Dt>2026-01-31</Dt></ValDt>
+        <AcctSvcrRef>BNK-1</AcctSvcrRef>
+        <NtryDtls><TxDtls>
+          <Refs><EndToEndId>E2E-1</EndToEndId></Refs>
+          <RltdPties><Dbtr><Nm>Isveren AS</Nm></Dbtr><DbtrAcct><Id><IBAN>TR120006200000000000000001</IBAN></Id></DbtrAcct></RltdPties>
+          <RmtInf><Ustrd>HKD-2026-004</Ustrd></RmtInf>
+        </TxDtls></NtryDtls>
+      </Ntry>
+      <Ntry>
+        <Amt Ccy="TRY">50.00</Amt><CdtDbtInd>DBIT</CdtDbtInd>
+        <Sts>BOOK</Sts>
+        <BookgDt><Dt>2026-01-31</Dt></BookgDt>
+        <ValDt><Dt>2026-01-31</Dt></ValDt>
+        <AddtlNtryInf>EFT masrafi</AddtlNtryInf>
+      </Ntry>
+      <Ntry>
+        <Amt Ccy="TRY">999.00</Amt><CdtDbtInd>CRDT</CdtDbtInd>
+        <Sts>PDNG</Sts>
+        <BookgDt><Dt>2026-02-01</Dt></BookgDt>
+      </Ntry>
+    </Stmt>
+  </BkToCstmrStmt>
+</Document>`
+
+func TestParseMT940(t *testing.T) {
+	stmt, err := Parse([]byte(sampleMT940), uuid.New(), uuid.New())
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	if stmt.Format != entity.StatementFormatMT940 {
+		t.Errorf("Format = %s, want MT940", stmt.Format)
+	}
+	if stmt.AccountID != "TR330006100519786457841326" || stmt.StatementNo != "00012/001" {
+		t.Errorf("account/statement = %q/%q", stmt.AccountID, stmt.StatementNo)
+	}
+	if stmt.OpeningBalance != 10000000 || stmt.ClosingBalance != 34874950 {
+		t.Errorf("balances = %d/%d", stmt.OpeningBalance, stmt.ClosingBalance)
+	}
+	if len(stmt.Lines) != 2 {
+		t.Fatalf("lines = %d, want 2", len(stmt.Lines))
+	}
+
+	credit := stmt.Lines[0]
+	if credit.Direction != entity.EntryDirectionCredit || credit.AmountCents != 25000000 {
+		t.Errorf("credit line = %s %d", credit.Direction, credit.AmountCents)
+	}
+	if credit.Reference != "HKD-2026-004" || credit.BankReference != "BNK7781" {
+		t.Errorf("credit refs = %q/%q", credit.Reference, credit.BankReference)
+	}
+	if credit.Description != "HKD-2026-004 NOLU FATURA ODEMESI" {
+		t.Errorf("credit description = %q", credit.Description)
+	}
+	if !credit.ValueDate.Equal(time.Date(2026, 1, 31, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("value date = %v", credit.ValueDate)
+	}
+	if credit.Currency != "TRY" {
+		t.Errorf("currency = %q", credit.Currency)
+	}
+
+	fee := stmt.Lines[1]
+	if fee.Direction != entity.EntryDirectionDebit || fee.AmountCents != 125050 || fee.Reference != "" {
+		t.Errorf("fee line = %s %d %q", fee.Direction, fee.AmountCents, fee.Reference)
+	}
+
+	if err := stmt.Validate(); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+}
+
+func TestParseCAMT053(t *testing.T) {
+	stmt, err := Parse([]byte(sampleCAMT053), uuid.New(), uuid.New())
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	if stmt.Format != entity.StatementFormatCAMT053 || stmt.StatementNo != "12" {
+		t.Errorf("format/statement = %s/%q", stmt.Format, stmt.StatementNo)
+	}
+	if stmt.OpeningBalance != 100000 || stmt.ClosingBalance != 245025 {
+		t.Errorf("balances = %d/%d", stmt.OpeningBalance, stmt.ClosingBalance)
+	}
+	if len(stmt.Lines) != 2 {
+		t.Fatalf("lines = %d, want 2 (pending entry skipped)", len(stmt.Lines))
+	}
+
+	credit := stmt.Lines[0]
+	if credit.AmountCents != 150025 || credit.Reference != "E2E-1" || credit.Description != "HKD-2026-004" {
+		t.Errorf("credit line = %d %q %q", credit.AmountCents, credit.Reference, credit.Description)
+	}
+	if credit.CounterpartyName != "Isveren AS" || credit.CounterpartyAccount != "TR120006200000000000000001" {
+		t.Errorf("counterparty = %q/%q", credit.CounterpartyName, credit.CounterpartyAccount)
+	}
+	if stmt.Lines[1].Direction != entity.EntryDirectionDebit || stmt.Lines[1].Description != "EFT masrafi" {
+		t.Errorf("debit line = %+v", stmt.Lines[1])
+	}
+
+	if err := stmt.Validate(); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+}
+
+func TestParseUnknownFormat(t *testing.T) {
+	_, err := Parse([]byte("date,amount\n2026-01-01,100"), uuid.New(), uuid.New())
+	if !errors.Is(err, ErrUnknownFormat) {
+		t.Errorf("Parse() error = %v, want ErrUnknownFormat", err)
+	}
+}
+
+func TestParseDecimalCents(t *testing.T) {
+	tests := []struct {
+		raw     string
+		sep     byte
+		want    int64
+		wantErr bool
+	}{
+		{"1234,56", ',', 123456, false},
+		{"1234,5", ',', 123450, false},
+		{"1234,", ',', 123400, false},
+		{"0.01", '.', 1, false},
+		{"1.234", '.', 0, true},
+		{"x", '.', 0, true},
+	}
+
+	for _, tt := range tests {
+		got, err := parseDecimalCents(tt.raw, tt.sep)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseDecimalCents(%q) error = %v, wantErr %v", tt.raw, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("parseDecimalCents(%q) = %d, want %d", tt.raw, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,71 @@
+// Copyright (c) 2026 Muhammet Ali Büyük. All rights reserved.
+// This source code is proprietary. Confidential and private.
+// Unauthorized copying or distribution is strictly prohibited.
+// Contact: iletisim@alibuyuk.net | Website: alibuyuk.net
+
+package efatura
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/qantesm/subflow/internal/core/entity"
+)
+
+// ErrUnknownReference is returned when the fake has no document for a reference
+var ErrUnknownReference = errors.New("unknown integrator reference")
+
+// FakeIntegrator is a local stand-in for a GİB special integrator
+// It keeps sent documents in memory and accepts them immediately, so the full
+// generate/send/status flow can be exercised without provider credentials
+type FakeIntegrator struct {
+	mu        sync.Mutex
+	documents map[string][]byte
+	statuses  map[string]entity.EInvoiceStatus
+	seq       int
+}
+
+// NewFakeIntegrator creates an in-memory integrator
+func NewFakeIntegrator() *FakeIntegrator {
+	return &FakeIntegrator{
+		documents: make(map[string][]byte),
+		statuses:  make(map[string]entity.EInvoiceStatus),
+	}
+}
+
+// Send stores the document and returns a reference derived from its ETTN
+func (f *FakeIntegrator) Send(ctx context.Context, inv *entity.EInvoice) (string, error) {
+	if len(inv.XML) == 0 {
+		return "", fmt.Errorf("e-invoice %s has no XML", inv.InvoiceNo)
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.seq++
+	ref := fmt.Sprintf("FAKE-%06d-%s", f.seq, inv.ETTN)
+	f.documents[ref] = inv.XML
+	f.statuses[ref] = entity.EInvoiceStatusAccepted
+	return ref, nil
+}
+
+// Status reports every sent document as accepted
+func (f *FakeIntegrator) Status(ctx context.Context, ref string) (entity.EInvoiceStatus, string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	status, ok := f.statuses[ref]
+	if !ok {
+		return "", "", ErrUnknownReference
+	}
+	return status, "fake integrator: delivered", nil
+}
+
+// Document returns the XML received for a reference (for tests)
+func (f *FakeIntegrator) Document(ref string) []byte {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.documents[ref]
+}
@@ -0,0 +1,338 @@
+// Copyright (c) 2026 Muhammet Ali Büyük. All rights reserved.
+// This source code is proprietary. Confidential and private.
+// Unauthorized copying or distribution is strictly prohibited.
+// Contact: iletisim@alibuyuk.net | Website: alibuyuk.net
+
+// Package efatura renders UBL-TR 1.2 e-Fatura documents and talks to GİB integrators
+package efatura
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+
+	"github.com/qantesm/subflow/internal/core/entity"
+)
+
+// UBL-TR namespaces
+const (
+	NamespaceInvoice = "urn:oasis:names:specification:ubl:schema:xsd:Invoice-2"
+	NamespaceCAC     = "urn:oasis:names:specification:ubl:schema:xsd:CommonAggregateComponents-2"
+	NamespaceCBC     = "urn:oasis:names:specification:ubl:schema:xsd:CommonBasicComponents-2"
+	NamespaceEXT     = "urn:oasis:names:specification:ubl:schema:xsd:CommonExtensionComponents-2"
+)
+
+// ContentType is the MIME type of rendered documents
+const ContentType = "application/xml"
+
+// KDV tax type code in the GİB code list
+const kdvTaxTypeCode = "0015"
+
+// Element order follows the UBL 2.1 Invoice schema sequence, which GİB validates strictly
+
+type ublInvoice struct {
+	XMLName    xml.Name `xml:"Invoice"`
+	Xmlns      string   `xml:"xmlns,attr"`
+	XmlnsCAC   string   `xml:"xmlns:cac,attr"`
+	XmlnsCBC   string   `xml:"xmlns:cbc,attr"`
+	XmlnsEXT   string   `xml:"xmlns:ext,attr"`
+	Extensions struct {
+		Extension struct {
+			Content string `xml:"ext:ExtensionContent"` // Filled by the integrator's XAdES signature
+		} `xml:"ext:UBLExtension"`
+	} `xml:"ext:UBLExtensions"`
+
+	UBLVersionID         string         `xml:"cbc:UBLVersionID"`
+	CustomizationID      string         `xml:"cbc:CustomizationID"`
+	ProfileID            string         `xml:"cbc:ProfileID"`
+	ID                   string         `xml:"cbc:ID"`
+	CopyIndicator        bool           `xml:"cbc:CopyIndicator"`
+	UUID                 string         `xml:"cbc:UUID"`
+	IssueDate            string         `xml:"cbc:IssueDate"`
+	IssueTime            string         `xml:"cbc:IssueTime"`
+	InvoiceTypeCode      string         `xml:"cbc:InvoiceTypeCode"`
+	Notes                []string       `xml:"cbc:Note,omitempty"`
+	DocumentCurrencyCode string         `xml:"cbc:DocumentCurrencyCode"`
+	LineCountNumeric     int            `xml:"cbc:LineCountNumeric"`
+	Signature            ublSignature   `xml:"cac:Signature"`
+	Supplier             ublPartyHolder `xml:"cac:AccountingSupplierParty"`
+	Customer             ublPartyHolder `xml:"cac:AccountingCustomerParty"`
+	TaxTotal             ublTaxTotal    `xml:"cac:TaxTotal"`
+	WithholdingTaxTotal  *ublTaxTotal   `xml:"cac:WithholdingTaxTotal,omitempty"`
+	LegalMonetaryTotal   ublMonetary    `xml:"cac:LegalMonetaryTotal"`
+	Lines                []ublLine      `xml:"cac:InvoiceLine"`
+}
+
+type ublSignature struct {
+	ID             ublIdentifier `xml:"cbc:ID"`
+	SignatoryParty ublParty      `xml:"cac:SignatoryParty"`
+	Attachment     struct {
+		URI string `xml:"cac:ExternalReference>cbc:URI"`
+	} `xml:"cac:DigitalSignatureAttachment"`
+}
+
+type ublIdentifier struct {
+	SchemeID string `xml:"schemeID,attr"`
+	Value    string `xml:",chardata"`
+}
+
+type ublPartyHolder struct {
+	Party ublParty `xml:"cac:Party"`
+}
+
+type ublParty struct {
+	Identification ublIdentifier  `xml:"cac:PartyIdentification>cbc:ID"`
+	Name           string         `xml:"cac:PartyName>cbc:Name,omitempty"`
+	Address        ublAddress     `xml:"cac:PostalAddress"`
+	TaxScheme      *ublTaxScheme  `xml:"cac:PartyTaxScheme>cac:TaxScheme,omitempty"`
+	Email          string         `xml:"cac:Contact>cbc:ElectronicMail,omitempty"`
+	Person         *ublPersonName `xml:"cac:Person,omitempty"`
+}
+
+type ublAddress struct {
+	StreetName  string `xml:"cbc:StreetName,omitempty"`
+	District    string `xml:"cbc:CitySubdivisionName"`
+	City        string `xml:"cbc:CityName"`
+	PostalZone  string `xml:"cbc:PostalZone,omitempty"`
+	CountryName string `xml:"cac:Country>cbc:Name"`
+}
+
+type ublTaxScheme struct {
+	Name        string `xml:"cbc:Name,omitempty"`
+	TaxTypeCode string `xml:"cbc:TaxTypeCode,omitempty"`
+}
+
+type ublPersonName struct {
+	FirstName  string `xml:"cbc:FirstName"`
+	FamilyName string `xml:"cbc:FamilyName"`
+}
+
+type ublAmount struct {
+	CurrencyID string `xml:"currencyID,attr"`
+	Value      string `xml:",chardata"`
+}
+
+type ublTaxTotal struct {
+	TaxAmount ublAmount        `xml:"cbc:TaxAmount"`
+	Subtotals []ublTaxSubtotal `xml:"cac:TaxSubtotal"`
+}
+
+type ublTaxSubtotal struct {
+	TaxableAmount ublAmount    `xml:"cbc:TaxableAmount"`
+	TaxAmount     ublAmount    `xml:"cbc:TaxAmount"`
+	Percent       string       `xml:"cbc:Percent"`
+	TaxScheme     ublTaxScheme `xml:"cac:TaxCategory>cac:TaxScheme"`
+}
+
+type ublMonetary struct {
+	LineExtensionAmount ublAmount `xml:"cbc:LineExtensionAmount"`
+	TaxExclusiveAmount  ublAmount `xml:"cbc:TaxExclusiveAmount"`
+	TaxInclusiveAmount  ublAmount `xml:"cbc:TaxInclusiveAmount"`
+	AllowanceTotal      ublAmount `xml:"cbc:AllowanceTotalAmount"`
+	PayableAmount       ublAmount `xml:"cbc:PayableAmount"`
+}
+
+type ublQuantity struct {
+	UnitCode string `xml:"unitCode,attr"`
+	Value    int64  `xml:",chardata"`
+}
+
+type ublLine struct {
+	ID                  int          `xml:"cbc:ID"`
+	InvoicedQuantity    ublQuantity  `xml:"cbc:InvoicedQuantity"`
+	LineExtensionAmount ublAmount    `xml:"cbc:LineExtensionAmount"`
+	TaxTotal            ublTaxTotal  `xml:"cac:TaxTotal"`
+	WithholdingTaxTotal *ublTaxTotal `xml:"cac:WithholdingTaxTotal,omitempty"`
+	ItemName            string       `xml:"cac:Item>cbc:Name"`
+	PriceAmount         ublAmount    `xml:"cac:Price>cbc:PriceAmount"`
+}
+
+// Renderer produces UBL-TR 1.2 XML; it satisfies service.EInvoiceRenderer
+type Renderer struct{}
+
+// NewRenderer creates a UBL-TR renderer
+func NewRenderer() *Renderer {
+	return &Renderer{}
+}
+
+// Render marshals the e-Fatura into an unsigned UBL-TR 1.2 document
+// The integrator applies the XAdES signature into ext:ExtensionContent
+func (r *Renderer) Render(inv *entity.EInvoice) ([]byte, error) {
+	cur := inv.Currency
+	amount := func(cents int64) ublAmount {
+		return ublAmount{CurrencyID: cur, Value: formatAmount(cents)}
+	}
+
+	doc := ublInvoice{
+		Xmlns:                NamespaceInvoice,
+		XmlnsCAC:             NamespaceCAC,
+		XmlnsCBC:             NamespaceCBC,
+		XmlnsEXT:             NamespaceEXT,
+		UBLVersionID:         "2.1",
+		CustomizationID:      "TR1.2",
+		ProfileID:            string(inv.Profile),
+		ID:                   inv.InvoiceNo,
+		UUID:                 inv.ETTN.String(),
+		IssueDate:            inv.IssueDate.Format("2006-01-02"),
+		IssueTime:            inv.IssueDate.Format("15:04:05"),
+		InvoiceTypeCode:      string(inv.Type),
+		DocumentCurrencyCode: cur,
+		LineCountNumeric:     len(inv.Lines),
+		Supplier:             ublPartyHolder{Party: toParty(inv.Seller)},
+		Customer:             ublPartyHolder{Party: toParty(inv.Buyer)},
+		LegalMonetaryTotal: ublMonetary{
+			LineExtensionAmount: amount(inv.LineExtensionTotal),
+			TaxExclusiveAmount:  amount(inv.LineExtensionTotal),
+			TaxInclusiveAmount:  amount(inv.TaxInclusiveTotal),
+			AllowanceTotal:      amount(0),
+			PayableAmount:       amount(inv.PayableAmount),
+		},
+	}
+	if inv.Note != "" {
+		doc.Notes = append(doc.Notes, inv.Note)
+	}
+
+	doc.Signature.ID = ublIdentifier{SchemeID: "VKN_TCKN", Value: inv.Seller.TaxID}
+	doc.Signature.SignatoryParty = toParty(inv.Seller)
+	doc.Signature.SignatoryParty.Name = ""
+	doc.Signature.SignatoryParty.TaxScheme = nil
+	doc.Signature.SignatoryParty.Email = ""
+	doc.Signature.SignatoryParty.Person = nil
+	doc.Signature.Attachment.URI = "#Signature_" + inv.InvoiceNo
+
+	// Invoice level KDV is grouped by rate, withholding by code
+	type taxGroup struct{ base, tax, rate int64 }
+	kdvByRate := make(map[int64]*taxGroup)
+	var kdvOrder []int64
+	whByCode := make(map[string]*taxGroup)
+	var whOrder []string
+
+	for _, l := range inv.Lines {
+		line := ublLine{
+			ID:                  l.LineNo,
+			InvoicedQuantity:    ublQuantity{UnitCode: l.UnitCode, Value: l.Quantity},
+			LineExtensionAmount: amount(l.LineExtension),
+			TaxTotal: ublTaxTotal{
+				TaxAmount: amount(l.KDVAmount),
+				Subtotals: []ublTaxSubtotal{taxSubtotal(l.LineExtension, l.KDVAmount, l.KDVRate, "KDV", kdvTaxTypeCode, amount)},
+			},
+			ItemName:    l.Name,
+			PriceAmount: amount(l.UnitPrice),
+		}
+
+		if _, ok := kdvByRate[l.KDVRate]; !ok {
+			kdvByRate[l.KDVRate] = &taxGroup{rate: l.KDVRate}
+			kdvOrder = append(kdvOrder, l.KDVRate)
+		}
+		kdvByRate[l.KDVRate].base += l.LineExtension
+		kdvByRate[l.KDVRate].tax += l.KDVAmount
+
+		if l.WithholdingCode != "" {
+			line.WithholdingTaxTotal = &ublTaxTotal{
+				TaxAmount: amount(l.WithholdingAmount),
+				Subtotals: []ublTaxSubtotal{taxSubtotal(l.KDVAmount, l.WithholdingAmount, l.WithholdingRate, withholdingName(l.WithholdingCode), l.WithholdingCode, amount)},
+			}
+
+			if _, ok := whByCode[l.WithholdingCode]; !ok {
+				whByCode[l.WithholdingCode] = &taxGroup{rate: l.WithholdingRate}
+				whOrder = append(whOrder, l.WithholdingCode)
+			}
+			whByCode[l.WithholdingCode].base += l.KDVAmount
+			whByCode[l.WithholdingCode].tax += l.WithholdingAmount
+		}
+
+		doc.Lines = append(doc.Lines, line)
+	}
+
+	doc.TaxTotal.TaxAmount = amount(inv.KDVTotal)
+	for _, rate := range kdvOrder {
+		g := kdvByRate[rate]
+		doc.TaxTotal.Subtotals = append(doc.TaxTotal.Subtotals, taxSubtotal(g.base, g.tax, g.rate, "KDV", kdvTaxTypeCode, amount))
+	}
+	if len(whOrder) > 0 {
+		doc.WithholdingTaxTotal = &ublTaxTotal{TaxAmount: amount(inv.WithholdingTotal)}
+		for _, code := range whOrder {
+			g := whByCode[code]
+			doc.WithholdingTaxTotal.Subtotals = append(doc.WithholdingTaxTotal.Subtotals, taxSubtotal(g.base, g.tax, g.rate, withholdingName(code), code, amount))
+		}
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(xml.Header)
+	enc := xml.NewEncoder(&buf)
+	enc.Indent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return nil, fmt.Errorf("encode ubl-tr: %w", err)
+	}
+	buf.WriteByte('\n')
+	return buf.Bytes(), nil
+}
+
+func toParty(p entity.EInvoiceParty) ublParty {
+	party := ublParty{
+		Identification: ublIdentifier{SchemeID: p.SchemeID(), Value: p.TaxID},
+		Address: ublAddress{
+			StreetName:  p.Street,
+			District:    p.District,
+			City:        p.City,
+			PostalZone:  p.PostalCode,
+			CountryName: p.Country,
+		},
+		Email: p.Email,
+	}
+
+	if p.IsPerson() {
+		// Real persons carry Person instead of PartyName
+		first, family := splitPersonName(p.Name)
+		party.Person = &ublPersonName{FirstName: first, FamilyName: family}
+	} else {
+		party.Name = p.Name
+	}
+	if p.TaxOffice != "" {
+		party.TaxScheme = &ublTaxScheme{Name: p.TaxOffice}
+	}
+	return party
+}
+
+func taxSubtotal(base, tax, rate int64, name, typeCode string, amount func(int64) ublAmount) ublTaxSubtotal {
+	return ublTaxSubtotal{
+		TaxableAmount: amount(base),
+		TaxAmount:     amount(tax),
+		Percent:       formatPercent(rate),
+		TaxScheme:     ublTaxScheme{Name: name, TaxTypeCode: typeCode},
+	}
+}
+
+// withholdingName returns the GİB description of a tevkifat code
+func withholdingName(code string) string {
+	if wc, ok := entity.WithholdingCodes[code]; ok {
+		return wc.Name
+	}
+	return code
+}
+
+// formatAmount renders cents with two decimals and a dot separator
+func formatAmount(cents int64) string {
+	sign := ""
+	if cents < 0 {
+		sign, cents = "-", -cents
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, cents/100, cents%100)
+}
+
+// formatPercent renders basis points as a percentage without trailing zeros (2000 -> 20)
+func formatPercent(bp int64) string {
+	if bp%100 == 0 {
+		return fmt.Sprintf("%d", bp/100)
+	}
+	return fmt.Sprintf("%d.%02d", bp/100, bp%100)
+}
+
+func splitPersonName(name string) (string, string) {
+	for i := len(name) - 1; i >= 0; i-- {
+		if name[i] == ' ' {
+			return name[:i], name[i+1:]
+		}
+	}
+	return name, name
+}
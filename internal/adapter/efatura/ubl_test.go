@@ -0,0 +1,261 @@
+// Copyright (c) 2026 Muhammet Ali Büyük. All rights reserved.
+// This source code is proprietary. Confidential and private.
+// Unauthorized copying or distribution is strictly prohibited.
+// Contact: iletisim@alibuyuk.net | Website: alibuyuk.net
+
+package efatura
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/qantesm/subflow/internal/core/entity"
+)
+
+// invoiceSequence is the UBL 2.1 Invoice xsd:sequence for the elements UBL-TR uses
+// Each entry is element name and whether it is mandatory in UBL-TR 1.2
+var invoiceSequence = []struct {
+	name     string
+	required bool
+}{
+	{"UBLExtensions", true},
+	{"UBLVersionID", true},
+	{"CustomizationID", true},
+	{"ProfileID", true},
+	{"ID", true},
+	{"CopyIndicator", true},
+	{"UUID", true},
+	{"IssueDate", true},
+	{"IssueTime", false},
+	{"InvoiceTypeCode", true},
+	{"Note", false},
+	{"DocumentCurrencyCode", true},
+	{"LineCountNumeric", true},
+	{"Signature", true},
+	{"AccountingSupplierParty", true},
+	{"AccountingCustomerParty", true},
+	{"TaxTotal", true},
+	{"WithholdingTaxTotal", false},
+	{"LegalMonetaryTotal", true},
+	{"InvoiceLine", true},
+}
+
+// validateInvoiceShape checks namespaces and the top-level element order against the schema sequence
+func validateInvoiceShape(t *testing.T, doc []byte) {
+	t.Helper()
+
+	dec := xml.NewDecoder(bytes.NewReader(doc))
+	depth := 0
+	var children []xml.Name
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("document is not well-formed: %v", err)
+		}
+		switch el := tok.(type) {
+		case xml.StartElement:
+			depth++
+			if depth == 1 && (el.Name.Space != NamespaceInvoice || el.Name.Local != "Invoice") {
+				t.Fatalf("root = %v, want {%s}Invoice", el.Name, NamespaceInvoice)
+			}
+			if depth == 2 {
+				children = append(children, el.Name)
+			}
+		case xml.EndElement:
+			depth--
+		}
+	}
+
+	pos := 0
+	seen := make(map[string]bool)
+	for _, child := range children {
+		for pos < len(invoiceSequence) && invoiceSequence[pos].name != child.Local {
+			pos++
+		}
+		if pos == len(invoiceSequence) {
+			t.Fatalf("element %s is unexpected or out of schema order", child.Local)
+		}
+		wantNS := NamespaceCBC
+		switch child.Local {
+		case "UBLExtensions":
+			wantNS = NamespaceEXT
+		case "Signature", "AccountingSupplierParty", "AccountingCustomerParty", "TaxTotal",
+			"WithholdingTaxTotal", "LegalMonetaryTotal", "InvoiceLine":
+			wantNS = NamespaceCAC
+		}
+		if child.Space != wantNS {
+			t.Errorf("element %s namespace = %s, want %s", child.Local, child.Space, wantNS)
+		}
+		seen[child.Local] = true
+	}
+
+	for _, el := range invoiceSequence {
+		if el.required && !seen[el.name] {
+			t.Errorf("required element %s missing", el.name)
+		}
+	}
+}
+
+// parsedInvoice reads back the fields the tests assert on
+type parsedInvoice struct {
+	ProfileID       string `xml:"ProfileID"`
+	ID              string `xml:"ID"`
+	InvoiceTypeCode string `xml:"InvoiceTypeCode"`
+	Supplier        struct {
+		ID struct {
+			Scheme string `xml:"schemeID,attr"`
+			Value  string `xml:",chardata"`
+		} `xml:"Party>PartyIdentification>ID"`
+		TaxOffice string `xml:"Party>PartyTaxScheme>TaxScheme>Name"`
+	} `xml:"AccountingSupplierParty"`
+	Customer struct {
+		ID struct {
+			Scheme string `xml:"schemeID,attr"`
+		} `xml:"Party>PartyIdentification>ID"`
+		FirstName string `xml:"Party>Person>FirstName"`
+	} `xml:"AccountingCustomerParty"`
+	TaxAmount         string `xml:"TaxTotal>TaxAmount"`
+	TaxPercent        string `xml:"TaxTotal>TaxSubtotal>Percent"`
+	TaxTypeCode       string `xml:"TaxTotal>TaxSubtotal>TaxCategory>TaxScheme>TaxTypeCode"`
+	WithholdingAmount string `xml:"WithholdingTaxTotal>TaxAmount"`
+	WithholdingCode   string `xml:"WithholdingTaxTotal>TaxSubtotal>TaxCategory>TaxScheme>TaxTypeCode"`
+	Monetary          struct {
+		LineExtension string `xml:"LineExtensionAmount"`
+		TaxInclusive  string `xml:"TaxInclusiveAmount"`
+		Payable       string `xml:"PayableAmount"`
+	} `xml:"LegalMonetaryTotal"`
+	Lines []struct {
+		ID       string `xml:"ID"`
+		Quantity struct {
+			UnitCode string `xml:"unitCode,attr"`
+		} `xml:"InvoicedQuantity"`
+		Amount struct {
+			Currency string `xml:"currencyID,attr"`
+		} `xml:"LineExtensionAmount"`
+	} `xml:"InvoiceLine"`
+}
+
+func sampleInvoice(withholding bool) *entity.EInvoice {
+	inv := entity.NewEInvoice(uuid.New(), uuid.New(), "SUB2026000000007", uuid.New())
+	inv.IssueDate = time.Date(2026, 2, 5, 10, 30, 0, 0, time.UTC)
+	inv.Currency = "TRY"
+	inv.Note = "1 No'lu hakediş"
+	inv.Seller = entity.EInvoiceParty{
+		Name: "Yapı Taşeron A.Ş.", TaxID: "1234567890", TaxOffice: "Kadıköy",
+		Street: "Moda Cad. 1", District: "Kadıköy", City: "İstanbul", Country: "Türkiye",
+	}
+	inv.Buyer = entity.EInvoiceParty{
+		Name: "Ayşe Yılmaz", TaxID: "12345678901",
+		District: "Çankaya", City: "Ankara", Country: "Türkiye",
+	}
+
+	line := entity.EInvoiceLine{
+		Name: "Hakediş bedeli", Quantity: 1, UnitCode: "C62",
+		UnitPrice: 10000000, LineExtension: 10000000, KDVRate: 2000, KDVAmount: 2000000,
+	}
+	if withholding {
+		line.WithholdingCode = "601"
+		line.WithholdingRate = 4000
+		line.WithholdingAmount = 800000
+	}
+	inv.AddLine(line)
+	return inv
+}
+
+func TestRenderSaleInvoice(t *testing.T) {
+	doc, err := NewRenderer().Render(sampleInvoice(false))
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	validateInvoiceShape(t, doc)
+
+	var got parsedInvoice
+	if err := xml.Unmarshal(doc, &got); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	if got.ProfileID != "TICARIFATURA" || got.ID != "SUB2026000000007" || got.InvoiceTypeCode != "SATIS" {
+		t.Errorf("header = %s/%s/%s", got.ProfileID, got.ID, got.InvoiceTypeCode)
+	}
+	if got.Supplier.ID.Scheme != "VKN" || got.Supplier.ID.Value != "1234567890" || got.Supplier.TaxOffice != "Kadıköy" {
+		t.Errorf("supplier = %+v", got.Supplier)
+	}
+	if got.Customer.ID.Scheme != "TCKN" || got.Customer.FirstName != "Ayşe" {
+		t.Errorf("customer = %+v", got.Customer)
+	}
+	if got.TaxAmount != "20000.00" || got.TaxPercent != "20" || got.TaxTypeCode != "0015" {
+		t.Errorf("tax = %s %s%% code %s", got.TaxAmount, got.TaxPercent, got.TaxTypeCode)
+	}
+	if got.WithholdingAmount != "" {
+		t.Errorf("sale invoice must not carry withholding, got %s", got.WithholdingAmount)
+	}
+	if got.Monetary.LineExtension != "100000.00" || got.Monetary.TaxInclusive != "120000.00" || got.Monetary.Payable != "120000.00" {
+		t.Errorf("monetary totals = %+v", got.Monetary)
+	}
+	if len(got.Lines) != 1 || got.Lines[0].Quantity.UnitCode != "C62" || got.Lines[0].Amount.Currency != "TRY" {
+		t.Errorf("lines = %+v", got.Lines)
+	}
+}
+
+func TestRenderWithholdingInvoice(t *testing.T) {
+	doc, err := NewRenderer().Render(sampleInvoice(true))
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	validateInvoiceShape(t, doc)
+
+	var got parsedInvoice
+	if err := xml.Unmarshal(doc, &got); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	if got.InvoiceTypeCode != "TEVKIFAT" {
+		t.Errorf("InvoiceTypeCode = %s, want TEVKIFAT", got.InvoiceTypeCode)
+	}
+	if got.WithholdingAmount != "8000.00" || got.WithholdingCode != "601" {
+		t.Errorf("withholding = %s code %s", got.WithholdingAmount, got.WithholdingCode)
+	}
+	// 100.000 + 20.000 KDV - 8.000 withheld (4/10)
+	if got.Monetary.Payable != "112000.00" {
+		t.Errorf("PayableAmount = %s, want 112000.00", got.Monetary.Payable)
+	}
+	if !strings.Contains(string(doc), "YAPIM İŞLERİ") {
+		t.Error("withholding tax scheme name should be the GİB description")
+	}
+}
+
+func TestFakeIntegrator(t *testing.T) {
+	ctx := context.Background()
+	fake := NewFakeIntegrator()
+	inv := sampleInvoice(false)
+
+	if _, err := fake.Send(ctx, inv); err == nil {
+		t.Error("Send() without XML should fail")
+	}
+
+	inv.XML, _ = NewRenderer().Render(inv)
+	ref, err := fake.Send(ctx, inv)
+	if err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+	if !bytes.Equal(fake.Document(ref), inv.XML) {
+		t.Error("fake should keep the submitted document")
+	}
+
+	status, _, err := fake.Status(ctx, ref)
+	if err != nil || status != entity.EInvoiceStatusAccepted {
+		t.Errorf("Status() = %s, %v", status, err)
+	}
+	if _, _, err := fake.Status(ctx, "missing"); err != ErrUnknownReference {
+		t.Errorf("Status(missing) error = %v", err)
+	}
+}
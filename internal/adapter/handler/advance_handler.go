@@ -0,0 +1,207 @@
+// Copyright (c) 2026 Muhammet Ali Büyük. All rights reserved.
+// This source code is proprietary. Confidential and private.
+// Unauthorized copying or distribution is strictly prohibited.
+// Contact: iletisim@alibuyuk.net | Website: alibuyuk.net
+
+package handler
+
+import (
+	"errors"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/qantesm/subflow/internal/core/entity"
+	"github.com/qantesm/subflow/internal/core/service"
+)
+
+// AdvanceHandler handles advance payments (avans) and their recovery rules
+type AdvanceHandler struct {
+	advances *service.AdvanceService
+}
+
+// NewAdvanceHandler creates a new advance handler
+func NewAdvanceHandler(advances *service.AdvanceService) *AdvanceHandler {
+	return &AdvanceHandler{
+		advances: advances,
+	}
+}
+
+// RegisterRoutes registers all advance payment routes
+func (h *AdvanceHandler) RegisterRoutes(router fiber.Router) {
+	advances := router.Group("/advances")
+
+	advances.Post("/", h.Issue)
+	advances.Get("/project/:projectId", h.Report)
+	advances.Get("/project/:projectId/rule", h.GetRule)
+	advances.Put("/project/:projectId/rule", h.SetRule)
+}
+
+// IssueAdvanceRequest represents the request body for an advance payment
+type IssueAdvanceRequest struct {
+	ProjectID     string `json:"project_id" validate:"required,uuid"`
+	Amount        int64  `json:"amount" validate:"required,gt=0"`
+	Currency      string `json:"currency" validate:"required,len=3"`
+	ReferenceNo   string `json:"reference_no"`
+	EffectiveDate string `json:"effective_date"` // YYYY-MM-DD, defaults to today
+}
+
+// SetAdvanceRuleRequest represents the request body for a recovery rule
+type SetAdvanceRuleRequest struct {
+	Method       string `json:"method" validate:"required"` // PERCENTAGE or COMPLETION_RANGE
+	Rate         int64  `json:"rate"`                       // Basis points of work completed
+	StartPercent int64  `json:"start_percent"`              // Basis points of the contract sum
+	EndPercent   int64  `json:"end_percent"`                // Basis points of the contract sum
+}
+
+// Issue records an advance payment in the ledger
+// @Summary Issue advance payment
+// @Tags Advances
+// @Accept json
+// @Produce json
+// @Param request body IssueAdvanceRequest true "Advance details"
+// @Success 201 {object} TransactionResponse
+// @Router /advances [post]
+func (h *AdvanceHandler) Issue(c *fiber.Ctx) error {
+	var req IssueAdvanceRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	projectID, err := uuid.Parse(req.ProjectID)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid project ID",
+		})
+	}
+
+	var effectiveDate time.Time
+	if req.EffectiveDate != "" {
+		if effectiveDate, err = time.Parse("2006-01-02", req.EffectiveDate); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid effective_date",
+			})
+		}
+	}
+
+	// TODO: Get actual user ID from auth context
+	userID := uuid.New() // Placeholder
+
+	tx, err := h.advances.Issue(c.Context(), projectID, req.Amount, req.Currency, req.ReferenceNo, effectiveDate, userID)
+	if err != nil {
+		return advanceError(c, err)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(TransactionResponse{
+		ID:          tx.ID.String(),
+		ProjectID:   tx.ProjectID.String(),
+		Type:        string(tx.Type),
+		AmountCents: tx.AmountCents,
+		Currency:    tx.Currency,
+		Message:     "Advance payment recorded successfully",
+	})
+}
+
+// Report returns the advance paid, recovered and outstanding for a project
+// @Summary Outstanding advance report
+// @Tags Advances
+// @Produce json
+// @Param projectId path string true "Project ID"
+// @Success 200 {object} service.AdvanceReport
+// @Router /advances/project/{projectId} [get]
+func (h *AdvanceHandler) Report(c *fiber.Ctx) error {
+	projectID, err := uuid.Parse(c.Params("projectId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid project ID",
+		})
+	}
+
+	report, err := h.advances.Report(c.Context(), projectID)
+	if err != nil {
+		return advanceError(c, err)
+	}
+	return c.JSON(report)
+}
+
+// GetRule returns the recovery rule of a project
+// @Summary Get advance recovery rule
+// @Tags Advances
+// @Produce json
+// @Param projectId path string true "Project ID"
+// @Success 200 {object} entity.AdvanceRecoveryRule
+// @Router /advances/project/{projectId}/rule [get]
+func (h *AdvanceHandler) GetRule(c *fiber.Ctx) error {
+	projectID, err := uuid.Parse(c.Params("projectId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid project ID",
+		})
+	}
+
+	rule, err := h.advances.Rule(c.Context(), projectID)
+	if err != nil {
+		return advanceError(c, err)
+	}
+	return c.JSON(rule)
+}
+
+// SetRule stores the recovery rule applied to the project's pay applications
+// @Summary Set advance recovery rule
+// @Tags Advances
+// @Accept json
+// @Produce json
+// @Param projectId path string true "Project ID"
+// @Param request body SetAdvanceRuleRequest true "Recovery rule"
+// @Success 200 {object} entity.AdvanceRecoveryRule
+// @Router /advances/project/{projectId}/rule [put]
+func (h *AdvanceHandler) SetRule(c *fiber.Ctx) error {
+	projectID, err := uuid.Parse(c.Params("projectId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid project ID",
+		})
+	}
+
+	var req SetAdvanceRuleRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	// TODO: Get actual user ID from auth context
+	userID := uuid.New() // Placeholder
+
+	rule := entity.NewAdvanceRecoveryRule(projectID, entity.AdvanceRecoveryMethod(req.Method), userID)
+	rule.Rate = req.Rate
+	rule.StartPercent = req.StartPercent
+	rule.EndPercent = req.EndPercent
+
+	if err := h.advances.SetRule(c.Context(), rule); err != nil {
+		return advanceError(c, err)
+	}
+	return c.JSON(rule)
+}
+
+// advanceError maps advance payment domain errors to HTTP responses
+func advanceError(c *fiber.Ctx, err error) error {
+	status := fiber.StatusInternalServerError
+	switch {
+	case errors.Is(err, entity.ErrAdvanceRuleNotFound),
+		errors.Is(err, entity.ErrProjectNotFound):
+		status = fiber.StatusNotFound
+	case errors.Is(err, entity.ErrProjectNotModifiable):
+		status = fiber.StatusConflict
+	case errors.Is(err, entity.ErrInvalidAmount):
+		status = fiber.StatusBadRequest
+	case errors.Is(err, entity.ErrInvalidAdvanceRule):
+		status = fiber.StatusUnprocessableEntity
+	}
+
+	return c.Status(status).JSON(fiber.Map{
+		"error": err.Error(),
+	})
+}
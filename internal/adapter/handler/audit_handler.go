@@ -0,0 +1,282 @@
+// Copyright (c) 2026 Muhammet Ali Büyük. All rights reserved.
+// This source code is proprietary. Confidential and private.
+// Unauthorized copying or distribution is strictly prohibited.
+// Contact: iletisim@alibuyuk.net | Website: alibuyuk.net
+
+package handler
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/qantesm/subflow/internal/adapter/xlsx"
+	"github.com/qantesm/subflow/internal/core/entity"
+	"github.com/qantesm/subflow/internal/core/service"
+	"github.com/qantesm/subflow/internal/pkg"
+)
+
+// maxAuditedBody is the largest JSON request body copied into the audit trail
+const maxAuditedBody = 64 << 10
+
+// AuditHandler serves the audit trail query and export API
+type AuditHandler struct {
+	audit *service.AuditService
+}
+
+// NewAuditHandler creates a new audit handler
+func NewAuditHandler(audit *service.AuditService) *AuditHandler {
+	return &AuditHandler{
+		audit: audit,
+	}
+}
+
+// RegisterRoutes registers all audit routes
+func (h *AuditHandler) RegisterRoutes(router fiber.Router) {
+	audit := router.Group("/audit-logs")
+
+	audit.Get("/", h.List)
+	audit.Get("/export", h.Export)
+}
+
+// List returns a page of the tenant's audit trail, newest first
+// @Summary List audit logs
+// @Tags Audit
+// @Produce json
+// @Param entity_type query string false "Entity type, e.g. pay_application or pay-applications for API calls"
+// @Param entity_id query string false "Entity ID"
+// @Param actor_id query string false "Actor ID"
+// @Param action query string false "Action, e.g. pay_application.certify or http.post"
+// @Param from query string false "Start date (YYYY-MM-DD or RFC 3339), inclusive"
+// @Param to query string false "End date (YYYY-MM-DD inclusive, or RFC 3339 exclusive)"
+// @Param limit query int false "Page size (default 100, max 1000)"
+// @Param offset query int false "Entries to skip"
+// @Success 200 {array} entity.AuditLog
+// @Router /audit-logs [get]
+func (h *AuditHandler) List(c *fiber.Ctx) error {
+	filter, err := auditFilter(c)
+	if err != nil {
+		return auditError(c, err)
+	}
+
+	logs, err := h.audit.Query(c.Context(), filter)
+	if err != nil {
+		return auditError(c, err)
+	}
+
+	return c.JSON(fiber.Map{
+		"data":  logs,
+		"count": len(logs),
+	})
+}
+
+// Export downloads the matching audit trail as CSV, or XLSX when asked for
+// @Summary Export audit logs
+// @Tags Audit
+// @Produce text/csv
+// @Produce application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param format query string false "csv (default) or xlsx"
+// @Param entity_type query string false "Entity type"
+// @Param entity_id query string false "Entity ID"
+// @Param actor_id query string false "Actor ID"
+// @Param action query string false "Action"
+// @Param from query string false "Start date (YYYY-MM-DD or RFC 3339), inclusive"
+// @Param to query string false "End date (YYYY-MM-DD inclusive, or RFC 3339 exclusive)"
+// @Success 200 {file} file
+// @Router /audit-logs/export [get]
+func (h *AuditHandler) Export(c *fiber.Ctx) error {
+	filter, err := auditFilter(c)
+	if err != nil {
+		return auditError(c, err)
+	}
+
+	logs, err := h.audit.Export(c.Context(), filter)
+	if err != nil {
+		return auditError(c, err)
+	}
+
+	if c.Query("format") == "xlsx" || wantsXLSX(c) {
+		return sendWorkbook(c, xlsx.AuditLogWorkbook(logs), "audit-log.xlsx")
+	}
+
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	_ = w.Write([]string{"time", "action", "entity_type", "entity_id", "actor_id", "actor_email", "ip_address", "user_agent", "old_value", "new_value"})
+	for _, l := range logs {
+		_ = w.Write([]string{
+			l.CreatedAt.UTC().Format(time.RFC3339),
+			l.Action,
+			l.EntityType,
+			idString(l.EntityID),
+			idString(l.ActorID),
+			l.ActorEmail,
+			l.IPAddress,
+			l.UserAgent,
+			string(l.OldValue),
+			string(l.NewValue),
+		})
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return auditError(c, err)
+	}
+
+	c.Set(fiber.HeaderContentType, "text/csv; charset=utf-8")
+	c.Attachment("audit-log.csv")
+	return c.Send(buf.Bytes())
+}
+
+// AuditTrail puts the request's actor in the context for the services' audit
+// entries and records every mutating API call with its outcome. Mount it
+// after the tenant middleware; requests without a tenant are not recorded.
+func AuditTrail(audit *service.AuditService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		actor := service.AuditActor{
+			TenantID:  tenantFromContext(c),
+			IPAddress: c.IP(),
+			UserAgent: c.Get(fiber.HeaderUserAgent),
+		}
+		if id, ok := c.Locals("userID").(uuid.UUID); ok {
+			actor.ActorID = &id
+		}
+		if email, ok := c.Locals("userEmail").(string); ok {
+			actor.Email = email
+		}
+		c.Locals(service.AuditActorKey, actor)
+
+		err := c.Next()
+
+		switch c.Method() {
+		case fiber.MethodPost, fiber.MethodPut, fiber.MethodPatch, fiber.MethodDelete:
+		default:
+			return err
+		}
+		if actor.TenantID == uuid.Nil {
+			return err
+		}
+
+		route := c.Route().Path
+		status := c.Response().StatusCode()
+		if err != nil {
+			status = fiber.StatusInternalServerError
+			var fiberErr *fiber.Error
+			if errors.As(err, &fiberErr) {
+				status = fiberErr.Code
+			}
+		}
+		call := fiber.Map{
+			"route":  route,
+			"path":   c.Path(),
+			"status": status,
+		}
+		if body := c.Body(); len(body) <= maxAuditedBody && json.Valid(body) {
+			call["request"] = json.RawMessage(body)
+		}
+
+		log := entity.NewAuditLog(actor.TenantID, "http."+strings.ToLower(c.Method()), auditEntityType(route), auditEntityID(c))
+		if snapshotErr := log.SetSnapshots(nil, call); snapshotErr == nil {
+			// The call has been answered; a failed audit write does not change the response
+			if logErr := audit.Log(c.Context(), log); logErr != nil {
+				pkg.Ctx(c.Context()).Warn().Err(logErr).Str("route", route).Msg("audit trail write failed")
+			}
+		}
+		return err
+	}
+}
+
+var apiVersionSegment = regexp.MustCompile(`^v[0-9]+$`)
+
+// auditEntityType is the first route segment below the API prefix, e.g. "pay-applications"
+func auditEntityType(route string) string {
+	for _, segment := range strings.Split(route, "/") {
+		if segment == "" || segment == "api" || apiVersionSegment.MatchString(segment) {
+			continue
+		}
+		return segment
+	}
+	return "api"
+}
+
+// auditEntityID is the route's entity ID parameter when there is one
+func auditEntityID(c *fiber.Ctx) *uuid.UUID {
+	for _, param := range []string{"id", "projectId"} {
+		if id, err := uuid.Parse(c.Params(param)); err == nil {
+			return &id
+		}
+	}
+	return nil
+}
+
+// auditFilter reads the tenant and the query filters of an audit request
+func auditFilter(c *fiber.Ctx) (service.AuditFilter, error) {
+	filter := service.AuditFilter{
+		TenantID:   tenantFromContext(c),
+		EntityType: c.Query("entity_type"),
+		Action:     c.Query("action"),
+		Limit:      c.QueryInt("limit", service.DefaultAuditPageSize),
+		Offset:     c.QueryInt("offset", 0),
+	}
+
+	var err error
+	if filter.EntityID, err = optionalUUID(c.Query("entity_id")); err != nil {
+		return filter, fmt.Errorf("%w: %v", entity.ErrInvalidAuditFilter, err)
+	}
+	if filter.ActorID, err = optionalUUID(c.Query("actor_id")); err != nil {
+		return filter, fmt.Errorf("%w: %v", entity.ErrInvalidAuditFilter, err)
+	}
+	if filter.From, err = auditTime(c.Query("from"), false); err != nil {
+		return filter, fmt.Errorf("%w: %v", entity.ErrInvalidAuditFilter, err)
+	}
+	if filter.To, err = auditTime(c.Query("to"), true); err != nil {
+		return filter, fmt.Errorf("%w: %v", entity.ErrInvalidAuditFilter, err)
+	}
+	return filter, nil
+}
+
+// auditTime parses an RFC 3339 time or a date; an end date covers the whole day
+func auditTime(raw string, end bool) (*time.Time, error) {
+	if raw == "" {
+		return nil, nil
+	}
+	if t, err := time.Parse(time.RFC3339, raw); err == nil {
+		return &t, nil
+	}
+	day, err := optionalDate(raw)
+	if err != nil {
+		return nil, err
+	}
+	if end {
+		next := day.AddDate(0, 0, 1)
+		return &next, nil
+	}
+	return day, nil
+}
+
+func idString(id *uuid.UUID) string {
+	if id == nil {
+		return ""
+	}
+	return id.String()
+}
+
+// auditError maps audit errors to HTTP responses
+func auditError(c *fiber.Ctx, err error) error {
+	status := fiber.StatusInternalServerError
+	switch {
+	case errors.Is(err, entity.ErrAuditTenantRequired):
+		status = fiber.StatusBadRequest
+	case errors.Is(err, entity.ErrInvalidAuditFilter):
+		status = fiber.StatusUnprocessableEntity
+	}
+
+	return c.Status(status).JSON(fiber.Map{
+		"error": err.Error(),
+	})
+}
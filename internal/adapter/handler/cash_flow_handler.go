@@ -0,0 +1,234 @@
+// Copyright (c) 2026 Muhammet Ali Büyük. All rights reserved.
+// This source code is proprietary. Confidential and private.
+// Unauthorized copying or distribution is strictly prohibited.
+// Contact: iletisim@alibuyuk.net | Website: alibuyuk.net
+
+package handler
+
+import (
+	"errors"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/qantesm/subflow/internal/core/entity"
+	"github.com/qantesm/subflow/internal/core/service"
+)
+
+// maxForecastMonths caps the forecast horizon of a single request
+const maxForecastMonths = 60
+
+// CashFlowHandler handles cash flow plans and forecasts
+type CashFlowHandler struct {
+	cashFlow *service.CashFlowService
+}
+
+// NewCashFlowHandler creates a new cash flow handler
+func NewCashFlowHandler(cashFlow *service.CashFlowService) *CashFlowHandler {
+	return &CashFlowHandler{
+		cashFlow: cashFlow,
+	}
+}
+
+// RegisterRoutes registers all cash flow routes
+func (h *CashFlowHandler) RegisterRoutes(router fiber.Router) {
+	cashFlow := router.Group("/cash-flow")
+
+	cashFlow.Put("/plans/:projectId", h.SavePlan)
+	cashFlow.Get("/plans/:projectId", h.GetPlan)
+	cashFlow.Get("/project/:projectId", h.ProjectForecast)
+	cashFlow.Get("/portfolio", h.PortfolioForecast)
+}
+
+// SaveCashFlowPlanRequest represents the request body for a project's cash flow plan
+type SaveCashFlowPlanRequest struct {
+	Curve         string                        `json:"curve"`                          // LINEAR (default), S_CURVE
+	StartDate     string                        `json:"start_date" validate:"required"` // YYYY-MM-DD
+	EndDate       string                        `json:"end_date" validate:"required"`   // YYYY-MM-DD
+	Schedule      []entity.CashFlowScheduleLine `json:"schedule"`                       // Empty uses the contract amount
+	NetDays       *int                          `json:"net_days"`                       // Defaults to 30
+	RetainageRate int64                         `json:"retainage_rate"`                 // Basis points, 0 uses the project rate
+	RetainageDays int                           `json:"retainage_days"`                 // After the end date
+	PayableDays   *int                          `json:"payable_days"`                   // Defaults to 30
+}
+
+// SavePlan creates or replaces the cash flow plan of a project
+// @Summary Save cash flow plan
+// @Tags Cash Flow
+// @Accept json
+// @Produce json
+// @Param projectId path string true "Project ID"
+// @Param request body SaveCashFlowPlanRequest true "Plan"
+// @Success 200 {object} entity.CashFlowPlan
+// @Router /cash-flow/plans/{projectId} [put]
+func (h *CashFlowHandler) SavePlan(c *fiber.Ctx) error {
+	projectID, err := uuid.Parse(c.Params("projectId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid project ID",
+		})
+	}
+
+	var req SaveCashFlowPlanRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	start, err := time.Parse("2006-01-02", req.StartDate)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid start date",
+		})
+	}
+	end, err := time.Parse("2006-01-02", req.EndDate)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid end date",
+		})
+	}
+
+	// TODO: Get actual user ID from auth context
+	userID := uuid.New() // Placeholder
+
+	plan := entity.NewCashFlowPlan(projectID, start, end, userID)
+	if req.Curve != "" {
+		plan.Curve = entity.ProgressCurve(req.Curve)
+	}
+	if req.Schedule != nil {
+		plan.Schedule = req.Schedule
+	}
+	if req.NetDays != nil {
+		plan.NetDays = *req.NetDays
+	}
+	if req.PayableDays != nil {
+		plan.PayableDays = *req.PayableDays
+	}
+	plan.RetainageRate = req.RetainageRate
+	plan.RetainageDays = req.RetainageDays
+
+	if err := h.cashFlow.SavePlan(c.Context(), plan); err != nil {
+		return cashFlowError(c, err)
+	}
+	return c.JSON(plan)
+}
+
+// GetPlan returns the cash flow plan of a project
+// @Summary Get cash flow plan
+// @Tags Cash Flow
+// @Produce json
+// @Param projectId path string true "Project ID"
+// @Success 200 {object} entity.CashFlowPlan
+// @Router /cash-flow/plans/{projectId} [get]
+func (h *CashFlowHandler) GetPlan(c *fiber.Ctx) error {
+	projectID, err := uuid.Parse(c.Params("projectId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid project ID",
+		})
+	}
+
+	plan, err := h.cashFlow.GetPlan(c.Context(), projectID)
+	if err != nil {
+		return cashFlowError(c, err)
+	}
+	return c.JSON(plan)
+}
+
+// ProjectForecast returns the monthly cash flow forecast of a project
+// @Summary Get project cash flow forecast
+// @Tags Cash Flow
+// @Produce json
+// @Param projectId path string true "Project ID"
+// @Param from query string false "First month (YYYY-MM), defaults to the current month"
+// @Param months query int false "Number of months (default 12, max 60)"
+// @Success 200 {object} service.CashFlowForecast
+// @Router /cash-flow/project/{projectId} [get]
+func (h *CashFlowHandler) ProjectForecast(c *fiber.Ctx) error {
+	projectID, err := uuid.Parse(c.Params("projectId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid project ID",
+		})
+	}
+
+	now := time.Now()
+	from, months, err := forecastWindow(c, now)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	forecast, err := h.cashFlow.ProjectForecast(c.Context(), projectID, from, months, now)
+	if err != nil {
+		return cashFlowError(c, err)
+	}
+	return c.JSON(forecast)
+}
+
+// PortfolioForecast returns the cash flow forecast of the tenant's projects, one per currency
+// @Summary Get portfolio cash flow forecast
+// @Tags Cash Flow
+// @Produce json
+// @Param from query string false "First month (YYYY-MM), defaults to the current month"
+// @Param months query int false "Number of months (default 12, max 60)"
+// @Success 200 {object} service.CashFlowPortfolio
+// @Router /cash-flow/portfolio [get]
+func (h *CashFlowHandler) PortfolioForecast(c *fiber.Ctx) error {
+	tenantID := tenantFromContext(c)
+	if tenantID == uuid.Nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Tenant required",
+		})
+	}
+
+	now := time.Now()
+	from, months, err := forecastWindow(c, now)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	portfolio, err := h.cashFlow.PortfolioForecast(c.Context(), tenantID, from, months, now)
+	if err != nil {
+		return cashFlowError(c, err)
+	}
+	return c.JSON(portfolio)
+}
+
+// forecastWindow reads the from and months query parameters
+func forecastWindow(c *fiber.Ctx, now time.Time) (time.Time, int, error) {
+	from := now
+	if raw := c.Query("from"); raw != "" {
+		parsed, err := time.Parse("2006-01", raw)
+		if err != nil {
+			return time.Time{}, 0, errors.New("Invalid from month")
+		}
+		from = parsed
+	}
+
+	months := c.QueryInt("months", service.DefaultForecastMonths)
+	if months <= 0 || months > maxForecastMonths {
+		return time.Time{}, 0, errors.New("Invalid months")
+	}
+	return from, months, nil
+}
+
+// cashFlowError maps cash flow domain errors to HTTP responses
+func cashFlowError(c *fiber.Ctx, err error) error {
+	status := fiber.StatusInternalServerError
+	switch {
+	case errors.Is(err, entity.ErrProjectNotFound),
+		errors.Is(err, entity.ErrCashFlowPlanNotFound):
+		status = fiber.StatusNotFound
+	case errors.Is(err, entity.ErrInvalidCashFlowPlan):
+		status = fiber.StatusUnprocessableEntity
+	}
+
+	return c.Status(status).JSON(fiber.Map{
+		"error": err.Error(),
+	})
+}
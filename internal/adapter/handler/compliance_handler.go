@@ -0,0 +1,391 @@
+// Copyright (c) 2026 Muhammet Ali Büyük. All rights reserved.
+// This source code is proprietary. Confidential and private.
+// Unauthorized copying or distribution is strictly prohibited.
+// Contact: iletisim@alibuyuk.net | Website: alibuyuk.net
+
+package handler
+
+import (
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/qantesm/subflow/internal/core/entity"
+	"github.com/qantesm/subflow/internal/core/service"
+)
+
+// ComplianceHandler handles subcontractor compliance documents and requirements
+type ComplianceHandler struct {
+	compliance *service.ComplianceService
+}
+
+// NewComplianceHandler creates a new compliance handler
+func NewComplianceHandler(compliance *service.ComplianceService) *ComplianceHandler {
+	return &ComplianceHandler{
+		compliance: compliance,
+	}
+}
+
+// RegisterRoutes registers all compliance routes
+func (h *ComplianceHandler) RegisterRoutes(router fiber.Router) {
+	compliance := router.Group("/compliance")
+
+	compliance.Get("/check", h.Check)
+
+	compliance.Post("/documents", h.AddDocument)
+	compliance.Get("/documents/expiring", h.Expiring)
+	compliance.Get("/documents/contract/:contractId", h.ListDocuments)
+	compliance.Get("/documents/:id", h.GetDocument)
+	compliance.Post("/documents/:id/revoke", h.RevokeDocument)
+
+	compliance.Post("/requirements", h.AddRequirement)
+	compliance.Get("/requirements/project/:projectId", h.ListRequirements)
+	compliance.Delete("/requirements/:id", h.RemoveRequirement)
+}
+
+// AddComplianceDocumentRequest represents the request body for a received document
+type AddComplianceDocumentRequest struct {
+	ProjectID   string `json:"project_id" validate:"required,uuid"`
+	ContractID  string `json:"contract_id" validate:"required,uuid"`
+	Type        string `json:"type" validate:"required"` // CONDITIONAL_LIEN_WAIVER, UNCONDITIONAL_LIEN_WAIVER, INSURANCE_CERTIFICATE, TAX_CLEARANCE, SGK_CLEARANCE
+	DocumentNo  string `json:"document_no"`
+	Issuer      string `json:"issuer"`
+	IssueDate   string `json:"issue_date" validate:"required"` // YYYY-MM-DD
+	ExpiryDate  string `json:"expiry_date"`                    // YYYY-MM-DD, empty when the document does not expire
+	Amount      int64  `json:"amount"`                         // Conditional waivers: amount waived in cents
+	ThroughDate string `json:"through_date"`                   // Unconditional waivers: YYYY-MM-DD
+}
+
+// AddComplianceRequirementRequest represents the request body for a new requirement
+type AddComplianceRequirementRequest struct {
+	ProjectID  string `json:"project_id" validate:"required,uuid"`
+	ContractID string `json:"contract_id"` // Empty applies to every contract of the project
+	Type       string `json:"type" validate:"required"`
+}
+
+// AddDocument stores a document received from a subcontractor
+// @Summary Add compliance document
+// @Tags Compliance
+// @Accept json
+// @Produce json
+// @Param request body AddComplianceDocumentRequest true "Compliance document"
+// @Success 201 {object} entity.ComplianceDocument
+// @Router /compliance/documents [post]
+func (h *ComplianceHandler) AddDocument(c *fiber.Ctx) error {
+	var req AddComplianceDocumentRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	projectID, err := uuid.Parse(req.ProjectID)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid project ID",
+		})
+	}
+
+	contractID, err := uuid.Parse(req.ContractID)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid contract ID",
+		})
+	}
+
+	issueDate, err := time.Parse("2006-01-02", req.IssueDate)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid issue_date",
+		})
+	}
+
+	expiryDate, err := optionalDate(req.ExpiryDate)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid expiry_date",
+		})
+	}
+
+	throughDate, err := optionalDate(req.ThroughDate)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid through_date",
+		})
+	}
+
+	// TODO: Get actual user ID from auth context
+	userID := uuid.New() // Placeholder
+
+	d := entity.NewComplianceDocument(projectID, contractID, entity.ComplianceDocumentType(req.Type), req.DocumentNo, req.Issuer, issueDate, userID)
+	d.ExpiryDate = expiryDate
+	d.Amount = req.Amount
+	d.ThroughDate = throughDate
+
+	if err := h.compliance.AddDocument(c.Context(), d); err != nil {
+		return complianceError(c, err)
+	}
+	return c.Status(fiber.StatusCreated).JSON(d)
+}
+
+// GetDocument returns a single compliance document
+// @Summary Get compliance document
+// @Tags Compliance
+// @Produce json
+// @Param id path string true "Document ID"
+// @Success 200 {object} entity.ComplianceDocument
+// @Router /compliance/documents/{id} [get]
+func (h *ComplianceHandler) GetDocument(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid document ID",
+		})
+	}
+
+	d, err := h.compliance.GetDocument(c.Context(), id)
+	if err != nil {
+		return complianceError(c, err)
+	}
+	return c.JSON(d)
+}
+
+// ListDocuments returns the documents on file for a contract
+// @Summary List compliance documents by contract
+// @Tags Compliance
+// @Produce json
+// @Param contractId path string true "Contract ID"
+// @Success 200 {array} entity.ComplianceDocument
+// @Router /compliance/documents/contract/{contractId} [get]
+func (h *ComplianceHandler) ListDocuments(c *fiber.Ctx) error {
+	contractID, err := uuid.Parse(c.Params("contractId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid contract ID",
+		})
+	}
+
+	documents, err := h.compliance.ListDocuments(c.Context(), contractID)
+	if err != nil {
+		return complianceError(c, err)
+	}
+
+	return c.JSON(fiber.Map{
+		"data":  documents,
+		"count": len(documents),
+	})
+}
+
+// RevokeDocument withdraws a document, e.g. a cancelled insurance policy
+// @Summary Revoke compliance document
+// @Tags Compliance
+// @Produce json
+// @Param id path string true "Document ID"
+// @Success 200 {object} entity.ComplianceDocument
+// @Router /compliance/documents/{id}/revoke [post]
+func (h *ComplianceHandler) RevokeDocument(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid document ID",
+		})
+	}
+
+	d, err := h.compliance.RevokeDocument(c.Context(), id, time.Now())
+	if err != nil {
+		return complianceError(c, err)
+	}
+	return c.JSON(d)
+}
+
+// Expiring returns documents expiring within ?days= days (default 15), soonest first
+// @Summary List expiring compliance documents
+// @Tags Compliance
+// @Produce json
+// @Param days query int false "Days ahead"
+// @Success 200 {array} entity.ComplianceDocument
+// @Router /compliance/documents/expiring [get]
+func (h *ComplianceHandler) Expiring(c *fiber.Ctx) error {
+	days := service.DefaultComplianceAlertDays
+	if raw := c.Query("days"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed < 0 {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid days",
+			})
+		}
+		days = parsed
+	}
+
+	documents, err := h.compliance.Expiring(c.Context(), time.Now(), days)
+	if err != nil {
+		return complianceError(c, err)
+	}
+
+	return c.JSON(fiber.Map{
+		"data":  documents,
+		"count": len(documents),
+	})
+}
+
+// AddRequirement makes a document type mandatory before paying subcontractors
+// @Summary Add compliance requirement
+// @Tags Compliance
+// @Accept json
+// @Produce json
+// @Param request body AddComplianceRequirementRequest true "Requirement"
+// @Success 201 {object} entity.ComplianceRequirement
+// @Router /compliance/requirements [post]
+func (h *ComplianceHandler) AddRequirement(c *fiber.Ctx) error {
+	var req AddComplianceRequirementRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	projectID, err := uuid.Parse(req.ProjectID)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid project ID",
+		})
+	}
+
+	contractID, err := optionalUUID(req.ContractID)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid contract ID",
+		})
+	}
+
+	// TODO: Get actual user ID from auth context
+	userID := uuid.New() // Placeholder
+
+	r := entity.NewComplianceRequirement(projectID, entity.ComplianceDocumentType(req.Type), userID)
+	r.ContractID = contractID
+
+	if err := h.compliance.AddRequirement(c.Context(), r); err != nil {
+		return complianceError(c, err)
+	}
+	return c.Status(fiber.StatusCreated).JSON(r)
+}
+
+// ListRequirements returns the requirements of a project
+// @Summary List compliance requirements by project
+// @Tags Compliance
+// @Produce json
+// @Param projectId path string true "Project ID"
+// @Success 200 {array} entity.ComplianceRequirement
+// @Router /compliance/requirements/project/{projectId} [get]
+func (h *ComplianceHandler) ListRequirements(c *fiber.Ctx) error {
+	projectID, err := uuid.Parse(c.Params("projectId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid project ID",
+		})
+	}
+
+	requirements, err := h.compliance.ListRequirements(c.Context(), projectID)
+	if err != nil {
+		return complianceError(c, err)
+	}
+
+	return c.JSON(fiber.Map{
+		"data":  requirements,
+		"count": len(requirements),
+	})
+}
+
+// RemoveRequirement drops a requirement
+// @Summary Remove compliance requirement
+// @Tags Compliance
+// @Param id path string true "Requirement ID"
+// @Success 204
+// @Router /compliance/requirements/{id} [delete]
+func (h *ComplianceHandler) RemoveRequirement(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid requirement ID",
+		})
+	}
+
+	if err := h.compliance.RemoveRequirement(c.Context(), id); err != nil {
+		return complianceError(c, err)
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// Check reports whether a payment to a subcontractor would pass the compliance gate
+// @Summary Check subcontractor compliance
+// @Tags Compliance
+// @Produce json
+// @Param project_id query string true "Project ID"
+// @Param contract_id query string true "Contract ID"
+// @Param amount query int false "Payment amount in cents"
+// @Success 200 {object} entity.ComplianceCheck
+// @Router /compliance/check [get]
+func (h *ComplianceHandler) Check(c *fiber.Ctx) error {
+	projectID, err := uuid.Parse(c.Query("project_id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid project ID",
+		})
+	}
+
+	contractID, err := uuid.Parse(c.Query("contract_id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid contract ID",
+		})
+	}
+
+	amount := int64(0)
+	if raw := c.Query("amount"); raw != "" {
+		amount, err = strconv.ParseInt(raw, 10, 64)
+		if err != nil || amount < 0 {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid amount",
+			})
+		}
+	}
+
+	check, err := h.compliance.CheckPayment(c.Context(), projectID, contractID, amount, time.Now())
+	if err != nil {
+		return complianceError(c, err)
+	}
+	return c.JSON(check)
+}
+
+func optionalDate(raw string) (*time.Time, error) {
+	if raw == "" {
+		return nil, nil
+	}
+	parsed, err := time.Parse("2006-01-02", raw)
+	if err != nil {
+		return nil, err
+	}
+	return &parsed, nil
+}
+
+// complianceError maps compliance domain errors to HTTP responses
+func complianceError(c *fiber.Ctx, err error) error {
+	status := fiber.StatusInternalServerError
+	switch {
+	case errors.Is(err, entity.ErrComplianceDocumentNotFound),
+		errors.Is(err, entity.ErrComplianceRequirementNotFound):
+		status = fiber.StatusNotFound
+	case errors.Is(err, entity.ErrComplianceRequirementExists),
+		errors.Is(err, entity.ErrComplianceDocumentRevoked):
+		status = fiber.StatusConflict
+	case errors.Is(err, entity.ErrInvalidComplianceDocument),
+		errors.Is(err, entity.ErrInvalidComplianceRequirement):
+		status = fiber.StatusUnprocessableEntity
+	}
+
+	return c.Status(status).JSON(fiber.Map{
+		"error": err.Error(),
+	})
+}
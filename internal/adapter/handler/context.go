@@ -0,0 +1,30 @@
+// Copyright (c) 2026 Muhammet Ali Büyük. All rights reserved.
+// This source code is proprietary. Confidential and private.
+// Unauthorized copying or distribution is strictly prohibited.
+// Contact: iletisim@alibuyuk.net | Website: alibuyuk.net
+
+package handler
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// tenantFromContext returns the tenant set by the TenantContext middleware
+// uuid.Nil selects the statutory defaults when the middleware is not mounted
+func tenantFromContext(c *fiber.Ctx) uuid.UUID {
+	if id, ok := c.Locals("tenantID").(uuid.UUID); ok {
+		return id
+	}
+	return uuid.Nil
+}
+
+// userFromContext returns the user the auth middleware stored
+// AuthRequired does not verify tokens yet, so until it stores the user a placeholder stands in
+func userFromContext(c *fiber.Ctx) uuid.UUID {
+	if id, ok := c.Locals("userID").(uuid.UUID); ok && id != uuid.Nil {
+		return id
+	}
+	// TODO: Require the user once AuthRequired resolves it
+	return uuid.New() // Placeholder
+}
@@ -0,0 +1,423 @@
+// Copyright (c) 2026 Muhammet Ali Büyük. All rights reserved.
+// This source code is proprietary. Confidential and private.
+// Unauthorized copying or distribution is strictly prohibited.
+// Contact: iletisim@alibuyuk.net | Website: alibuyuk.net
+
+package handler
+
+import (
+	"errors"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/qantesm/subflow/internal/core/entity"
+	"github.com/qantesm/subflow/internal/core/service"
+)
+
+// DeductionHandler handles deductions (kesintiler), recurring schedules and late penalties
+type DeductionHandler struct {
+	deductions *service.DeductionService
+}
+
+// NewDeductionHandler creates a new deduction handler
+func NewDeductionHandler(deductions *service.DeductionService) *DeductionHandler {
+	return &DeductionHandler{
+		deductions: deductions,
+	}
+}
+
+// RegisterRoutes registers all deduction routes
+func (h *DeductionHandler) RegisterRoutes(router fiber.Router) {
+	deductions := router.Group("/deductions")
+
+	deductions.Post("/", h.Create)
+	deductions.Get("/project/:projectId", h.ListByProject)
+	deductions.Post("/schedules", h.CreateSchedule)
+	deductions.Get("/schedules/project/:projectId", h.ListSchedules)
+	deductions.Post("/schedules/:id/stop", h.StopSchedule)
+	deductions.Get("/penalty/project/:projectId", h.GetPenaltyRule)
+	deductions.Put("/penalty/project/:projectId", h.SetPenaltyRule)
+	deductions.Post("/penalty/project/:projectId/assess", h.AssessPenalty)
+	deductions.Get("/:id", h.Get)
+}
+
+// CreateDeductionRequest represents the request body for a one-off deduction
+type CreateDeductionRequest struct {
+	ProjectID  string `json:"project_id" validate:"required,uuid"`
+	ContractID string `json:"contract_id"`
+	Category   string `json:"category" validate:"required"` // BACK_CHARGE, PENALTY, OWNER_MATERIAL, SGK, UTILITY, OTHER
+	Reason     string `json:"reason" validate:"required"`
+	Amount     int64  `json:"amount" validate:"required,gt=0"`
+	Currency   string `json:"currency" validate:"required,len=3"`
+	Date       string `json:"date"` // YYYY-MM-DD, defaults to today
+}
+
+// CreateDeductionScheduleRequest represents the request body for a recurring deduction
+type CreateDeductionScheduleRequest struct {
+	ProjectID      string `json:"project_id" validate:"required,uuid"`
+	ContractID     string `json:"contract_id"`
+	Category       string `json:"category" validate:"required"`
+	Reason         string `json:"reason" validate:"required"`
+	Amount         int64  `json:"amount" validate:"required,gt=0"` // Per occurrence
+	Currency       string `json:"currency" validate:"required,len=3"`
+	IntervalMonths int    `json:"interval_months"`                // Defaults to monthly
+	StartDate      string `json:"start_date" validate:"required"` // YYYY-MM-DD, first occurrence
+	EndDate        string `json:"end_date"`                       // YYYY-MM-DD, empty while open-ended
+}
+
+// SetPenaltyRuleRequest represents the request body for a late completion penalty
+type SetPenaltyRuleRequest struct {
+	DailyRate int64 `json:"daily_rate" validate:"required,gt=0"` // Parts per million of the contract amount per day
+	CapRate   int64 `json:"cap_rate"`                            // Basis points of the contract amount, 0 for no cap
+}
+
+// AssessPenaltyRequest represents the request body for a penalty assessment
+type AssessPenaltyRequest struct {
+	AsOf string `json:"as_of"` // YYYY-MM-DD, defaults to today
+}
+
+// Create records a one-off deduction in the ledger
+// @Summary Create deduction
+// @Tags Deductions
+// @Accept json
+// @Produce json
+// @Param request body CreateDeductionRequest true "Deduction"
+// @Success 201 {object} entity.Deduction
+// @Router /deductions [post]
+func (h *DeductionHandler) Create(c *fiber.Ctx) error {
+	var req CreateDeductionRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	projectID, err := uuid.Parse(req.ProjectID)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid project ID",
+		})
+	}
+	contractID, err := optionalUUID(req.ContractID)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid contract ID",
+		})
+	}
+
+	date := time.Now()
+	if req.Date != "" {
+		if date, err = time.Parse("2006-01-02", req.Date); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid date",
+			})
+		}
+	}
+
+	// TODO: Get actual user ID from auth context
+	userID := uuid.New() // Placeholder
+
+	d := entity.NewDeduction(projectID, entity.DeductionCategory(req.Category), req.Reason, req.Amount, req.Currency, date, userID)
+	d.ContractID = contractID
+	if err := h.deductions.Create(c.Context(), d); err != nil {
+		return deductionError(c, err)
+	}
+	return c.Status(fiber.StatusCreated).JSON(d)
+}
+
+// Get returns a single deduction
+// @Summary Get deduction
+// @Tags Deductions
+// @Produce json
+// @Param id path string true "Deduction ID"
+// @Success 200 {object} entity.Deduction
+// @Router /deductions/{id} [get]
+func (h *DeductionHandler) Get(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid deduction ID",
+		})
+	}
+
+	d, err := h.deductions.Get(c.Context(), id)
+	if err != nil {
+		return deductionError(c, err)
+	}
+	return c.JSON(d)
+}
+
+// ListByProject returns the deductions of a project
+// @Summary List project deductions
+// @Tags Deductions
+// @Produce json
+// @Param projectId path string true "Project ID"
+// @Success 200 {array} entity.Deduction
+// @Router /deductions/project/{projectId} [get]
+func (h *DeductionHandler) ListByProject(c *fiber.Ctx) error {
+	projectID, err := uuid.Parse(c.Params("projectId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid project ID",
+		})
+	}
+
+	deductions, err := h.deductions.ListByProject(c.Context(), projectID)
+	if err != nil {
+		return deductionError(c, err)
+	}
+
+	return c.JSON(fiber.Map{
+		"data":  deductions,
+		"count": len(deductions),
+	})
+}
+
+// CreateSchedule stores a recurring deduction
+// @Summary Create recurring deduction
+// @Tags Deductions
+// @Accept json
+// @Produce json
+// @Param request body CreateDeductionScheduleRequest true "Schedule"
+// @Success 201 {object} entity.DeductionSchedule
+// @Router /deductions/schedules [post]
+func (h *DeductionHandler) CreateSchedule(c *fiber.Ctx) error {
+	var req CreateDeductionScheduleRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	projectID, err := uuid.Parse(req.ProjectID)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid project ID",
+		})
+	}
+	contractID, err := optionalUUID(req.ContractID)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid contract ID",
+		})
+	}
+	startDate, err := time.Parse("2006-01-02", req.StartDate)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid start_date",
+		})
+	}
+
+	// TODO: Get actual user ID from auth context
+	userID := uuid.New() // Placeholder
+
+	schedule := entity.NewDeductionSchedule(projectID, entity.DeductionCategory(req.Category), req.Reason, req.Amount, req.Currency, startDate, userID)
+	schedule.ContractID = contractID
+	if req.IntervalMonths != 0 {
+		schedule.IntervalMonths = req.IntervalMonths
+	}
+	if req.EndDate != "" {
+		endDate, err := time.Parse("2006-01-02", req.EndDate)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid end_date",
+			})
+		}
+		schedule.EndDate = &endDate
+	}
+
+	if err := h.deductions.CreateSchedule(c.Context(), schedule); err != nil {
+		return deductionError(c, err)
+	}
+	return c.Status(fiber.StatusCreated).JSON(schedule)
+}
+
+// ListSchedules returns the recurring deductions of a project
+// @Summary List recurring deductions
+// @Tags Deductions
+// @Produce json
+// @Param projectId path string true "Project ID"
+// @Success 200 {array} entity.DeductionSchedule
+// @Router /deductions/schedules/project/{projectId} [get]
+func (h *DeductionHandler) ListSchedules(c *fiber.Ctx) error {
+	projectID, err := uuid.Parse(c.Params("projectId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid project ID",
+		})
+	}
+
+	schedules, err := h.deductions.ListSchedules(c.Context(), projectID)
+	if err != nil {
+		return deductionError(c, err)
+	}
+
+	return c.JSON(fiber.Map{
+		"data":  schedules,
+		"count": len(schedules),
+	})
+}
+
+// StopSchedule ends a recurring deduction
+// @Summary Stop recurring deduction
+// @Tags Deductions
+// @Produce json
+// @Param id path string true "Schedule ID"
+// @Success 200 {object} entity.DeductionSchedule
+// @Router /deductions/schedules/{id}/stop [post]
+func (h *DeductionHandler) StopSchedule(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid schedule ID",
+		})
+	}
+
+	schedule, err := h.deductions.StopSchedule(c.Context(), id)
+	if err != nil {
+		return deductionError(c, err)
+	}
+	return c.JSON(schedule)
+}
+
+// GetPenaltyRule returns the late completion penalty of a project
+// @Summary Get late penalty rule
+// @Tags Deductions
+// @Produce json
+// @Param projectId path string true "Project ID"
+// @Success 200 {object} entity.LatePenaltyRule
+// @Router /deductions/penalty/project/{projectId} [get]
+func (h *DeductionHandler) GetPenaltyRule(c *fiber.Ctx) error {
+	projectID, err := uuid.Parse(c.Params("projectId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid project ID",
+		})
+	}
+
+	rule, err := h.deductions.PenaltyRule(c.Context(), projectID)
+	if err != nil {
+		return deductionError(c, err)
+	}
+	return c.JSON(rule)
+}
+
+// SetPenaltyRule stores the late completion penalty of a project
+// @Summary Set late penalty rule
+// @Tags Deductions
+// @Accept json
+// @Produce json
+// @Param projectId path string true "Project ID"
+// @Param request body SetPenaltyRuleRequest true "Penalty rule"
+// @Success 200 {object} entity.LatePenaltyRule
+// @Router /deductions/penalty/project/{projectId} [put]
+func (h *DeductionHandler) SetPenaltyRule(c *fiber.Ctx) error {
+	projectID, err := uuid.Parse(c.Params("projectId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid project ID",
+		})
+	}
+
+	var req SetPenaltyRuleRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	// TODO: Get actual user ID from auth context
+	userID := uuid.New() // Placeholder
+
+	rule := entity.NewLatePenaltyRule(projectID, req.DailyRate, req.CapRate, userID)
+	if err := h.deductions.SetPenaltyRule(c.Context(), rule); err != nil {
+		return deductionError(c, err)
+	}
+	return c.JSON(rule)
+}
+
+// AssessPenalty raises the late completion penalty accrued since the last assessment
+// @Summary Assess late penalty
+// @Tags Deductions
+// @Accept json
+// @Produce json
+// @Param projectId path string true "Project ID"
+// @Param request body AssessPenaltyRequest false "Assessment date"
+// @Success 201 {object} entity.Deduction
+// @Success 204 "Nothing due"
+// @Router /deductions/penalty/project/{projectId}/assess [post]
+func (h *DeductionHandler) AssessPenalty(c *fiber.Ctx) error {
+	projectID, err := uuid.Parse(c.Params("projectId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid project ID",
+		})
+	}
+
+	var req AssessPenaltyRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid request body",
+			})
+		}
+	}
+
+	asOf := time.Now()
+	if req.AsOf != "" {
+		if asOf, err = time.Parse("2006-01-02", req.AsOf); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid as_of date",
+			})
+		}
+	}
+
+	// TODO: Get actual user ID from auth context
+	userID := uuid.New() // Placeholder
+
+	d, err := h.deductions.AssessLatePenalty(c.Context(), projectID, asOf, userID)
+	if err != nil {
+		return deductionError(c, err)
+	}
+	if d == nil {
+		return c.SendStatus(fiber.StatusNoContent)
+	}
+	return c.Status(fiber.StatusCreated).JSON(d)
+}
+
+// optionalUUID parses an optional ID field, returning nil when empty
+func optionalUUID(raw string) (*uuid.UUID, error) {
+	if raw == "" {
+		return nil, nil
+	}
+	id, err := uuid.Parse(raw)
+	if err != nil {
+		return nil, err
+	}
+	return &id, nil
+}
+
+// deductionError maps deduction domain errors to HTTP responses
+func deductionError(c *fiber.Ctx, err error) error {
+	status := fiber.StatusInternalServerError
+	switch {
+	case errors.Is(err, entity.ErrDeductionNotFound),
+		errors.Is(err, entity.ErrDeductionScheduleNotFound),
+		errors.Is(err, entity.ErrPenaltyRuleNotFound),
+		errors.Is(err, entity.ErrProjectNotFound):
+		status = fiber.StatusNotFound
+	case errors.Is(err, entity.ErrProjectNotModifiable):
+		status = fiber.StatusConflict
+	case errors.Is(err, entity.ErrInvalidAmount):
+		status = fiber.StatusBadRequest
+	case errors.Is(err, entity.ErrInvalidDeduction),
+		errors.Is(err, entity.ErrInvalidDeductionSchedule),
+		errors.Is(err, entity.ErrInvalidPenaltyRule):
+		status = fiber.StatusUnprocessableEntity
+	}
+
+	return c.Status(status).JSON(fiber.Map{
+		"error": err.Error(),
+	})
+}
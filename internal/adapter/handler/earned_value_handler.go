@@ -0,0 +1,72 @@
+// Copyright (c) 2026 Muhammet Ali Büyük. All rights reserved.
+// This source code is proprietary. Confidential and private.
+// Unauthorized copying or distribution is strictly prohibited.
+// Contact: iletisim@alibuyuk.net | Website: alibuyuk.net
+
+package handler
+
+import (
+	"errors"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/qantesm/subflow/internal/core/entity"
+	"github.com/qantesm/subflow/internal/core/service"
+)
+
+// EarnedValueHandler handles earned value reporting
+type EarnedValueHandler struct {
+	earnedValue *service.EarnedValueService
+}
+
+// NewEarnedValueHandler creates a new earned value handler
+func NewEarnedValueHandler(earnedValue *service.EarnedValueService) *EarnedValueHandler {
+	return &EarnedValueHandler{
+		earnedValue: earnedValue,
+	}
+}
+
+// RegisterRoutes registers all earned value routes
+func (h *EarnedValueHandler) RegisterRoutes(router fiber.Router) {
+	earnedValue := router.Group("/earned-value")
+
+	earnedValue.Get("/project/:projectId", h.Report)
+}
+
+// Report returns CPI, SPI, EAC, ETC and VAC of a project, per cost code and per certified period
+// @Summary Get earned value report
+// @Tags Earned Value
+// @Produce json
+// @Param projectId path string true "Project ID"
+// @Success 200 {object} service.EarnedValueReport
+// @Router /earned-value/project/{projectId} [get]
+func (h *EarnedValueHandler) Report(c *fiber.Ctx) error {
+	projectID, err := uuid.Parse(c.Params("projectId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid project ID",
+		})
+	}
+
+	report, err := h.earnedValue.Report(c.Context(), projectID, time.Now())
+	if err != nil {
+		return earnedValueError(c, err)
+	}
+	return c.JSON(report)
+}
+
+// earnedValueError maps earned value errors to HTTP responses
+// A project needs a cash flow plan as its baseline before it can be measured.
+func earnedValueError(c *fiber.Ctx, err error) error {
+	status := fiber.StatusInternalServerError
+	switch {
+	case errors.Is(err, entity.ErrProjectNotFound),
+		errors.Is(err, entity.ErrCashFlowPlanNotFound):
+		status = fiber.StatusNotFound
+	}
+
+	return c.Status(status).JSON(fiber.Map{
+		"error": err.Error(),
+	})
+}
@@ -0,0 +1,222 @@
+// Copyright (c) 2026 Muhammet Ali Büyük. All rights reserved.
+// This source code is proprietary. Confidential and private.
+// Unauthorized copying or distribution is strictly prohibited.
+// Contact: iletisim@alibuyuk.net | Website: alibuyuk.net
+
+package handler
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/qantesm/subflow/internal/adapter/efatura"
+	"github.com/qantesm/subflow/internal/core/entity"
+	"github.com/qantesm/subflow/internal/core/service"
+)
+
+// EInvoiceHandler handles e-Fatura generation, download and submission
+type EInvoiceHandler struct {
+	einvoices *service.EInvoiceService
+}
+
+// NewEInvoiceHandler creates a new e-invoice handler
+func NewEInvoiceHandler(einvoices *service.EInvoiceService) *EInvoiceHandler {
+	return &EInvoiceHandler{
+		einvoices: einvoices,
+	}
+}
+
+// RegisterRoutes registers all e-invoice routes
+func (h *EInvoiceHandler) RegisterRoutes(router fiber.Router) {
+	einvoices := router.Group("/einvoices")
+
+	einvoices.Post("/transactions/:transactionId", h.GenerateForTransaction)
+	einvoices.Post("/pay-applications/:payApplicationId", h.GenerateForPayApplication)
+	einvoices.Get("/:id", h.Get)
+	einvoices.Get("/:id/xml", h.DownloadXML)
+	einvoices.Post("/:id/send", h.Send)
+	einvoices.Post("/:id/status", h.RefreshStatus)
+}
+
+// GenerateForTransaction creates the UBL-TR e-Fatura for an invoice transaction
+// @Summary Generate e-Fatura from invoice
+// @Tags EInvoices
+// @Accept json
+// @Produce json
+// @Param transactionId path string true "Invoice transaction ID"
+// @Param request body service.EInvoiceRequest true "Parties and tax options"
+// @Success 201 {object} entity.EInvoice
+// @Router /einvoices/transactions/{transactionId} [post]
+func (h *EInvoiceHandler) GenerateForTransaction(c *fiber.Ctx) error {
+	transactionID, err := uuid.Parse(c.Params("transactionId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid transaction ID",
+		})
+	}
+
+	var req service.EInvoiceRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	// TODO: Get actual user ID from auth context
+	userID := uuid.New() // Placeholder
+
+	inv, err := h.einvoices.GenerateForTransaction(c.Context(), tenantFromContext(c), transactionID, req, userID)
+	if err != nil {
+		return eInvoiceError(c, err)
+	}
+	return c.Status(fiber.StatusCreated).JSON(inv)
+}
+
+// GenerateForPayApplication creates the e-Fatura for a certified pay application
+// @Summary Generate e-Fatura from pay application
+// @Tags EInvoices
+// @Accept json
+// @Produce json
+// @Param payApplicationId path string true "Pay application ID"
+// @Param request body service.EInvoiceRequest true "Parties and tax options"
+// @Success 201 {object} entity.EInvoice
+// @Router /einvoices/pay-applications/{payApplicationId} [post]
+func (h *EInvoiceHandler) GenerateForPayApplication(c *fiber.Ctx) error {
+	payApplicationID, err := uuid.Parse(c.Params("payApplicationId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid pay application ID",
+		})
+	}
+
+	var req service.EInvoiceRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	// TODO: Get actual user ID from auth context
+	userID := uuid.New() // Placeholder
+
+	inv, err := h.einvoices.GenerateForPayApplication(c.Context(), tenantFromContext(c), payApplicationID, req, userID)
+	if err != nil {
+		return eInvoiceError(c, err)
+	}
+	return c.Status(fiber.StatusCreated).JSON(inv)
+}
+
+// Get returns e-Fatura metadata and totals
+// @Summary Get e-Fatura
+// @Tags EInvoices
+// @Produce json
+// @Param id path string true "E-invoice ID"
+// @Success 200 {object} entity.EInvoice
+// @Router /einvoices/{id} [get]
+func (h *EInvoiceHandler) Get(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid e-invoice ID",
+		})
+	}
+
+	inv, err := h.einvoices.Get(c.Context(), id)
+	if err != nil {
+		return eInvoiceError(c, err)
+	}
+	return c.JSON(inv)
+}
+
+// DownloadXML returns the stored UBL-TR document as an attachment
+// @Summary Download e-Fatura XML
+// @Tags EInvoices
+// @Produce xml
+// @Param id path string true "E-invoice ID"
+// @Router /einvoices/{id}/xml [get]
+func (h *EInvoiceHandler) DownloadXML(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid e-invoice ID",
+		})
+	}
+
+	inv, err := h.einvoices.Get(c.Context(), id)
+	if err != nil {
+		return eInvoiceError(c, err)
+	}
+
+	c.Set(fiber.HeaderContentType, efatura.ContentType)
+	c.Attachment(inv.InvoiceNo + ".xml")
+	return c.Send(inv.XML)
+}
+
+// Send submits the e-Fatura through the configured GİB integrator
+// @Summary Send e-Fatura
+// @Tags EInvoices
+// @Param id path string true "E-invoice ID"
+// @Router /einvoices/{id}/send [post]
+func (h *EInvoiceHandler) Send(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid e-invoice ID",
+		})
+	}
+
+	inv, err := h.einvoices.Send(c.Context(), id)
+	if err != nil {
+		return eInvoiceError(c, err)
+	}
+	return c.JSON(inv)
+}
+
+// RefreshStatus polls the integrator for the delivery status
+// @Summary Refresh e-Fatura status
+// @Tags EInvoices
+// @Param id path string true "E-invoice ID"
+// @Router /einvoices/{id}/status [post]
+func (h *EInvoiceHandler) RefreshStatus(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid e-invoice ID",
+		})
+	}
+
+	inv, err := h.einvoices.RefreshStatus(c.Context(), id)
+	if err != nil {
+		return eInvoiceError(c, err)
+	}
+	return c.JSON(inv)
+}
+
+// eInvoiceError maps e-invoice domain errors to HTTP responses
+func eInvoiceError(c *fiber.Ctx, err error) error {
+	status := fiber.StatusInternalServerError
+	switch {
+	case errors.Is(err, entity.ErrEInvoiceNotFound),
+		errors.Is(err, entity.ErrTransactionNotFound),
+		errors.Is(err, entity.ErrPayApplicationNotFound):
+		status = fiber.StatusNotFound
+	case errors.Is(err, entity.ErrEInvoiceExists),
+		errors.Is(err, entity.ErrEInvoiceAlreadySent):
+		status = fiber.StatusConflict
+	case errors.Is(err, entity.ErrEInvoiceNotInvoice),
+		errors.Is(err, entity.ErrPayApplicationNotCertified),
+		errors.Is(err, entity.ErrEInvoicePartyIncomplete),
+		errors.Is(err, entity.ErrInvalidTaxID),
+		errors.Is(err, entity.ErrInvalidEInvoiceNo),
+		errors.Is(err, entity.ErrInvalidWithholdingCode),
+		errors.Is(err, entity.ErrTaxRateNotFound),
+		errors.Is(err, entity.ErrEInvoiceNoLines),
+		errors.Is(err, entity.ErrInvalidAmount):
+		status = fiber.StatusUnprocessableEntity
+	}
+
+	return c.Status(status).JSON(fiber.Map{
+		"error": err.Error(),
+	})
+}
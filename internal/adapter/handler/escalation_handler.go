@@ -0,0 +1,319 @@
+// Copyright (c) 2026 Muhammet Ali Büyük. All rights reserved.
+// This source code is proprietary. Confidential and private.
+// Unauthorized copying or distribution is strictly prohibited.
+// Contact: iletisim@alibuyuk.net | Website: alibuyuk.net
+
+package handler
+
+import (
+	"errors"
+	"io"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/qantesm/subflow/internal/adapter/xlsx"
+	"github.com/qantesm/subflow/internal/core/entity"
+	"github.com/qantesm/subflow/internal/core/service"
+)
+
+// EscalationHandler handles price index tables and fiyat farkı formulas
+type EscalationHandler struct {
+	escalation *service.EscalationService
+}
+
+// NewEscalationHandler creates a new escalation handler
+func NewEscalationHandler(escalation *service.EscalationService) *EscalationHandler {
+	return &EscalationHandler{
+		escalation: escalation,
+	}
+}
+
+// RegisterRoutes registers all price escalation routes
+func (h *EscalationHandler) RegisterRoutes(router fiber.Router) {
+	esc := router.Group("/escalation")
+
+	esc.Post("/indices/import", h.ImportIndices)
+	esc.Get("/indices/:code", h.ListIndices)
+	esc.Post("/formulas", h.CreateFormula)
+	esc.Get("/formulas/project/:projectId", h.ListFormulas)
+	esc.Get("/formulas/:id", h.GetFormula)
+	esc.Post("/formulas/:id/preview", h.Preview)
+	esc.Get("/results/project/:projectId", h.ListResults)
+}
+
+// CreateFormulaRequest represents the request body for a contract escalation formula
+type CreateFormulaRequest struct {
+	ProjectID      string                       `json:"project_id" validate:"required,uuid"`
+	Name           string                       `json:"name"`
+	BaseMonth      string                       `json:"base_month" validate:"required"` // YYYY-MM
+	Coefficient    *int64                       `json:"coefficient"`                    // Basis points, defaults to 9000
+	IndexLagMonths *int                         `json:"index_lag_months"`               // Defaults to 1
+	Components     []entity.EscalationComponent `json:"components" validate:"required"`
+}
+
+// PreviewEscalationRequest represents the request body for an escalation preview
+type PreviewEscalationRequest struct {
+	PeriodEnd  string `json:"period_end" validate:"required"` // YYYY-MM-DD
+	WorkAmount int64  `json:"work_amount"`                    // An in cents at contract prices
+}
+
+// ImportIndices imports a CSV or XLSX table of monthly index values
+// Multipart form fields: file and an optional source label
+// @Summary Import price indices
+// @Tags Escalation
+// @Accept multipart/form-data
+// @Produce json
+// @Param file formData file true "CSV or XLSX file, month column followed by one column per index"
+// @Param source formData string false "Source label, e.g. TÜİK 2026-03"
+// @Success 201 {object} service.IndexImportResult
+// @Failure 422 {object} service.IndexImportResult
+// @Router /escalation/indices/import [post]
+func (h *EscalationHandler) ImportIndices(c *fiber.Ctx) error {
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "File is required",
+		})
+	}
+	if fileHeader.Size > maxImportFileSize {
+		return c.Status(fiber.StatusRequestEntityTooLarge).JSON(fiber.Map{
+			"error": "File exceeds 10 MB limit",
+		})
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Unable to read file",
+		})
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(io.LimitReader(file, maxImportFileSize))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Unable to read file",
+		})
+	}
+
+	records, err := xlsx.DecodeRecords(fileHeader.Filename, data)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	result, err := h.escalation.ImportIndices(c.Context(), records, c.FormValue("source"))
+	if err != nil {
+		switch {
+		case errors.Is(err, entity.ErrImportRowsInvalid):
+			return c.Status(fiber.StatusUnprocessableEntity).JSON(result)
+		case errors.Is(err, entity.ErrImportEmpty), errors.Is(err, entity.ErrImportMissingColumn):
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+		return escalationError(c, err)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(result)
+}
+
+// ListIndices returns the stored monthly values of an index
+// @Summary List price index values
+// @Tags Escalation
+// @Produce json
+// @Param code path string true "Index code"
+// @Success 200 {array} entity.PriceIndex
+// @Router /escalation/indices/{code} [get]
+func (h *EscalationHandler) ListIndices(c *fiber.Ctx) error {
+	indices, err := h.escalation.ListIndices(c.Context(), c.Params("code"))
+	if err != nil {
+		return escalationError(c, err)
+	}
+
+	return c.JSON(fiber.Map{
+		"data":  indices,
+		"count": len(indices),
+	})
+}
+
+// CreateFormula stores a contract escalation formula
+// @Summary Create escalation formula
+// @Tags Escalation
+// @Accept json
+// @Produce json
+// @Param request body CreateFormulaRequest true "Formula"
+// @Success 201 {object} entity.EscalationFormula
+// @Router /escalation/formulas [post]
+func (h *EscalationHandler) CreateFormula(c *fiber.Ctx) error {
+	var req CreateFormulaRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	projectID, err := uuid.Parse(req.ProjectID)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid project ID",
+		})
+	}
+
+	baseMonth, err := service.ParseIndexMonth(req.BaseMonth)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid base_month",
+		})
+	}
+
+	// TODO: Get actual user ID from auth context
+	userID := uuid.New() // Placeholder
+
+	formula := entity.NewEscalationFormula(projectID, req.Name, baseMonth, req.Components, userID)
+	if req.Coefficient != nil {
+		formula.Coefficient = *req.Coefficient
+	}
+	if req.IndexLagMonths != nil {
+		formula.IndexLagMonths = *req.IndexLagMonths
+	}
+
+	if err := h.escalation.CreateFormula(c.Context(), formula); err != nil {
+		return escalationError(c, err)
+	}
+	return c.Status(fiber.StatusCreated).JSON(formula)
+}
+
+// ListFormulas returns the formulas of a project
+// @Summary List escalation formulas
+// @Tags Escalation
+// @Produce json
+// @Param projectId path string true "Project ID"
+// @Success 200 {array} entity.EscalationFormula
+// @Router /escalation/formulas/project/{projectId} [get]
+func (h *EscalationHandler) ListFormulas(c *fiber.Ctx) error {
+	projectID, err := uuid.Parse(c.Params("projectId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid project ID",
+		})
+	}
+
+	formulas, err := h.escalation.ListFormulas(c.Context(), projectID)
+	if err != nil {
+		return escalationError(c, err)
+	}
+
+	return c.JSON(fiber.Map{
+		"data":  formulas,
+		"count": len(formulas),
+	})
+}
+
+// GetFormula returns a single formula
+// @Summary Get escalation formula
+// @Tags Escalation
+// @Produce json
+// @Param id path string true "Formula ID"
+// @Success 200 {object} entity.EscalationFormula
+// @Router /escalation/formulas/{id} [get]
+func (h *EscalationHandler) GetFormula(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid formula ID",
+		})
+	}
+
+	formula, err := h.escalation.GetFormula(c.Context(), id)
+	if err != nil {
+		return escalationError(c, err)
+	}
+	return c.JSON(formula)
+}
+
+// Preview calculates the escalation for an amount without recording it
+// @Summary Preview escalation
+// @Tags Escalation
+// @Accept json
+// @Produce json
+// @Param id path string true "Formula ID"
+// @Param request body PreviewEscalationRequest true "Period end and work amount"
+// @Success 200 {object} entity.EscalationResult
+// @Router /escalation/formulas/{id}/preview [post]
+func (h *EscalationHandler) Preview(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid formula ID",
+		})
+	}
+
+	var req PreviewEscalationRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	periodEnd, err := time.Parse("2006-01-02", req.PeriodEnd)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid period_end date",
+		})
+	}
+
+	// TODO: Get actual user ID from auth context
+	userID := uuid.New() // Placeholder
+
+	result, err := h.escalation.Calculate(c.Context(), id, periodEnd, req.WorkAmount, userID)
+	if err != nil {
+		return escalationError(c, err)
+	}
+	return c.JSON(result)
+}
+
+// ListResults returns every recorded escalation of a project with the indices used
+// @Summary List escalation audit trail
+// @Tags Escalation
+// @Produce json
+// @Param projectId path string true "Project ID"
+// @Success 200 {array} entity.EscalationResult
+// @Router /escalation/results/project/{projectId} [get]
+func (h *EscalationHandler) ListResults(c *fiber.Ctx) error {
+	projectID, err := uuid.Parse(c.Params("projectId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid project ID",
+		})
+	}
+
+	results, err := h.escalation.ResultsByProject(c.Context(), projectID)
+	if err != nil {
+		return escalationError(c, err)
+	}
+
+	return c.JSON(fiber.Map{
+		"data":  results,
+		"count": len(results),
+	})
+}
+
+// escalationError maps escalation domain errors to HTTP responses
+func escalationError(c *fiber.Ctx, err error) error {
+	status := fiber.StatusInternalServerError
+	switch {
+	case errors.Is(err, entity.ErrEscalationFormulaNotFound):
+		status = fiber.StatusNotFound
+	case errors.Is(err, entity.ErrInvalidEscalationFormula),
+		errors.Is(err, entity.ErrEscalationWeights),
+		errors.Is(err, entity.ErrPriceIndexNotFound):
+		status = fiber.StatusUnprocessableEntity
+	}
+
+	return c.Status(status).JSON(fiber.Map{
+		"error": err.Error(),
+	})
+}
@@ -0,0 +1,318 @@
+// Copyright (c) 2026 Muhammet Ali Büyük. All rights reserved.
+// This source code is proprietary. Confidential and private.
+// Unauthorized copying or distribution is strictly prohibited.
+// Contact: iletisim@alibuyuk.net | Website: alibuyuk.net
+
+package handler
+
+import (
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/qantesm/subflow/internal/core/entity"
+	"github.com/qantesm/subflow/internal/core/service"
+)
+
+// GuaranteeHandler handles the bank guarantee letter register
+type GuaranteeHandler struct {
+	guarantees *service.GuaranteeService
+}
+
+// NewGuaranteeHandler creates a new guarantee handler
+func NewGuaranteeHandler(guarantees *service.GuaranteeService) *GuaranteeHandler {
+	return &GuaranteeHandler{
+		guarantees: guarantees,
+	}
+}
+
+// RegisterRoutes registers all guarantee routes
+func (h *GuaranteeHandler) RegisterRoutes(router fiber.Router) {
+	guarantees := router.Group("/guarantees")
+
+	guarantees.Post("/", h.Register)
+	guarantees.Get("/expiring", h.Expiring)
+	guarantees.Get("/project/:projectId", h.ListByProject)
+	guarantees.Get("/:id", h.Get)
+	guarantees.Post("/:id/reduce", h.Reduce)
+	guarantees.Post("/:id/return", h.Return)
+	guarantees.Post("/:id/extend", h.Extend)
+}
+
+// RegisterGuaranteeRequest represents the request body for a new guarantee letter
+type RegisterGuaranteeRequest struct {
+	ProjectID  string `json:"project_id" validate:"required,uuid"`
+	ContractID string `json:"contract_id"`
+	Bank       string `json:"bank" validate:"required"`
+	LetterNo   string `json:"letter_no" validate:"required"`
+	Type       string `json:"type" validate:"required"` // BID, PERFORMANCE, ADVANCE
+	Amount     int64  `json:"amount" validate:"required,gt=0"`
+	Currency   string `json:"currency" validate:"required,len=3"`
+	IssueDate  string `json:"issue_date" validate:"required"` // YYYY-MM-DD
+	ExpiryDate string `json:"expiry_date"`                    // YYYY-MM-DD, empty for open-ended letters
+}
+
+// ReduceGuaranteeRequest represents the request body for a guarantee reduction
+type ReduceGuaranteeRequest struct {
+	Amount int64  `json:"amount" validate:"required,gt=0"` // New amount in cents
+	Reason string `json:"reason"`
+}
+
+// ExtendGuaranteeRequest represents the request body for a renewed expiry date
+type ExtendGuaranteeRequest struct {
+	ExpiryDate string `json:"expiry_date" validate:"required"` // YYYY-MM-DD
+}
+
+// Register adds a guarantee letter to the register
+// @Summary Register bank guarantee
+// @Tags Guarantees
+// @Accept json
+// @Produce json
+// @Param request body RegisterGuaranteeRequest true "Guarantee letter"
+// @Success 201 {object} entity.BankGuarantee
+// @Router /guarantees [post]
+func (h *GuaranteeHandler) Register(c *fiber.Ctx) error {
+	var req RegisterGuaranteeRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	projectID, err := uuid.Parse(req.ProjectID)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid project ID",
+		})
+	}
+
+	issueDate, err := time.Parse("2006-01-02", req.IssueDate)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid issue_date",
+		})
+	}
+
+	var expiryDate *time.Time
+	if req.ExpiryDate != "" {
+		parsed, err := time.Parse("2006-01-02", req.ExpiryDate)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid expiry_date",
+			})
+		}
+		expiryDate = &parsed
+	}
+
+	// TODO: Get actual user ID from auth context
+	userID := uuid.New() // Placeholder
+
+	g := entity.NewBankGuarantee(projectID, entity.GuaranteeType(req.Type), req.Bank, req.LetterNo, req.Amount, req.Currency, issueDate, expiryDate, userID)
+	if req.ContractID != "" {
+		contractID, err := uuid.Parse(req.ContractID)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid contract ID",
+			})
+		}
+		g.ContractID = &contractID
+	}
+
+	if err := h.guarantees.Register(c.Context(), g); err != nil {
+		return guaranteeError(c, err)
+	}
+	return c.Status(fiber.StatusCreated).JSON(g)
+}
+
+// Get returns a single guarantee letter
+// @Summary Get bank guarantee
+// @Tags Guarantees
+// @Produce json
+// @Param id path string true "Guarantee ID"
+// @Success 200 {object} entity.BankGuarantee
+// @Router /guarantees/{id} [get]
+func (h *GuaranteeHandler) Get(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid guarantee ID",
+		})
+	}
+
+	g, err := h.guarantees.Get(c.Context(), id)
+	if err != nil {
+		return guaranteeError(c, err)
+	}
+	return c.JSON(g)
+}
+
+// ListByProject returns the guarantee letters of a project
+// @Summary List bank guarantees by project
+// @Tags Guarantees
+// @Produce json
+// @Param projectId path string true "Project ID"
+// @Success 200 {array} entity.BankGuarantee
+// @Router /guarantees/project/{projectId} [get]
+func (h *GuaranteeHandler) ListByProject(c *fiber.Ctx) error {
+	projectID, err := uuid.Parse(c.Params("projectId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid project ID",
+		})
+	}
+
+	guarantees, err := h.guarantees.ListByProject(c.Context(), projectID)
+	if err != nil {
+		return guaranteeError(c, err)
+	}
+
+	return c.JSON(fiber.Map{
+		"data":  guarantees,
+		"count": len(guarantees),
+	})
+}
+
+// Expiring returns active letters expiring within ?days= days (default 30), soonest first
+// @Summary List expiring bank guarantees
+// @Tags Guarantees
+// @Produce json
+// @Param days query int false "Days ahead"
+// @Success 200 {array} entity.BankGuarantee
+// @Router /guarantees/expiring [get]
+func (h *GuaranteeHandler) Expiring(c *fiber.Ctx) error {
+	days := service.DefaultGuaranteeAlertDays
+	if raw := c.Query("days"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed < 0 {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid days",
+			})
+		}
+		days = parsed
+	}
+
+	guarantees, err := h.guarantees.Expiring(c.Context(), time.Now(), days)
+	if err != nil {
+		return guaranteeError(c, err)
+	}
+
+	return c.JSON(fiber.Map{
+		"data":  guarantees,
+		"count": len(guarantees),
+	})
+}
+
+// Reduce lowers the amount of a guarantee letter
+// @Summary Reduce bank guarantee
+// @Tags Guarantees
+// @Accept json
+// @Produce json
+// @Param id path string true "Guarantee ID"
+// @Param request body ReduceGuaranteeRequest true "New amount"
+// @Success 200 {object} entity.BankGuarantee
+// @Router /guarantees/{id}/reduce [post]
+func (h *GuaranteeHandler) Reduce(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid guarantee ID",
+		})
+	}
+
+	var req ReduceGuaranteeRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	// TODO: Get actual user ID from auth context
+	userID := uuid.New() // Placeholder
+
+	g, err := h.guarantees.Reduce(c.Context(), id, req.Amount, req.Reason, userID)
+	if err != nil {
+		return guaranteeError(c, err)
+	}
+	return c.JSON(g)
+}
+
+// Return marks a guarantee letter as returned to the bank
+// @Summary Return bank guarantee
+// @Tags Guarantees
+// @Produce json
+// @Param id path string true "Guarantee ID"
+// @Success 200 {object} entity.BankGuarantee
+// @Router /guarantees/{id}/return [post]
+func (h *GuaranteeHandler) Return(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid guarantee ID",
+		})
+	}
+
+	g, err := h.guarantees.Return(c.Context(), id)
+	if err != nil {
+		return guaranteeError(c, err)
+	}
+	return c.JSON(g)
+}
+
+// Extend records a renewed expiry date for a guarantee letter
+// @Summary Extend bank guarantee
+// @Tags Guarantees
+// @Accept json
+// @Produce json
+// @Param id path string true "Guarantee ID"
+// @Param request body ExtendGuaranteeRequest true "New expiry date"
+// @Success 200 {object} entity.BankGuarantee
+// @Router /guarantees/{id}/extend [post]
+func (h *GuaranteeHandler) Extend(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid guarantee ID",
+		})
+	}
+
+	var req ExtendGuaranteeRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	expiryDate, err := time.Parse("2006-01-02", req.ExpiryDate)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid expiry_date",
+		})
+	}
+
+	g, err := h.guarantees.Extend(c.Context(), id, expiryDate)
+	if err != nil {
+		return guaranteeError(c, err)
+	}
+	return c.JSON(g)
+}
+
+// guaranteeError maps guarantee domain errors to HTTP responses
+func guaranteeError(c *fiber.Ctx, err error) error {
+	status := fiber.StatusInternalServerError
+	switch {
+	case errors.Is(err, entity.ErrGuaranteeNotFound):
+		status = fiber.StatusNotFound
+	case errors.Is(err, entity.ErrGuaranteeExists),
+		errors.Is(err, entity.ErrGuaranteeNotActive):
+		status = fiber.StatusConflict
+	case errors.Is(err, entity.ErrInvalidGuarantee),
+		errors.Is(err, entity.ErrInvalidGuaranteeReduction):
+		status = fiber.StatusUnprocessableEntity
+	}
+
+	return c.Status(status).JSON(fiber.Map{
+		"error": err.Error(),
+	})
+}
@@ -0,0 +1,136 @@
+// Copyright (c) 2026 Muhammet Ali Büyük. All rights reserved.
+// This source code is proprietary. Confidential and private.
+// Unauthorized copying or distribution is strictly prohibited.
+// Contact: iletisim@alibuyuk.net | Website: alibuyuk.net
+
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/qantesm/subflow/internal/adapter/xlsx"
+	"github.com/qantesm/subflow/internal/core/entity"
+	"github.com/qantesm/subflow/internal/core/service"
+)
+
+// maxImportFileSize caps uploaded import files (10 MB)
+const maxImportFileSize = 10 << 20
+
+// ImportHandler handles bulk import of historical transactions
+type ImportHandler struct {
+	importer *service.ImportService
+}
+
+// NewImportHandler creates a new import handler
+func NewImportHandler(importer *service.ImportService) *ImportHandler {
+	return &ImportHandler{
+		importer: importer,
+	}
+}
+
+// RegisterRoutes registers all import-related routes
+func (h *ImportHandler) RegisterRoutes(router fiber.Router) {
+	router.Post("/transactions/import", h.ImportTransactions)
+}
+
+// ImportTransactions imports a CSV or XLSX file of historical transactions
+// Multipart form fields: file, project_id, dry_run, default_currency and an
+// optional mapping JSON object of {"source header": "target field"}
+// @Summary Bulk import transactions
+// @Tags Transactions
+// @Accept multipart/form-data
+// @Produce json
+// @Param file formData file true "CSV or XLSX file"
+// @Param project_id formData string true "Project ID"
+// @Param dry_run formData bool false "Validate and preview without saving"
+// @Param mapping formData string false "Column mapping JSON"
+// @Success 200 {object} service.ImportResult
+// @Success 201 {object} service.ImportResult
+// @Failure 422 {object} service.ImportResult
+// @Router /transactions/import [post]
+func (h *ImportHandler) ImportTransactions(c *fiber.Ctx) error {
+	projectID, err := uuid.Parse(c.FormValue("project_id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid project ID",
+		})
+	}
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "File is required",
+		})
+	}
+	if fileHeader.Size > maxImportFileSize {
+		return c.Status(fiber.StatusRequestEntityTooLarge).JSON(fiber.Map{
+			"error": "File exceeds 10 MB limit",
+		})
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Unable to read file",
+		})
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(io.LimitReader(file, maxImportFileSize))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Unable to read file",
+		})
+	}
+
+	records, err := xlsx.DecodeRecords(fileHeader.Filename, data)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	var mapping service.ImportMapping
+	if raw := c.FormValue("mapping"); raw != "" {
+		if err := json.Unmarshal([]byte(raw), &mapping); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid mapping JSON",
+			})
+		}
+	}
+
+	// TODO: Get actual user ID from auth context
+	userID := uuid.New() // Placeholder
+
+	dryRun := c.FormValue("dry_run") == "true" || c.FormValue("dry_run") == "1"
+
+	result, err := h.importer.Import(c.Context(), records, service.ImportOptions{
+		ProjectID:       projectID,
+		CreatedBy:       userID,
+		Mapping:         mapping,
+		DefaultCurrency: c.FormValue("default_currency"),
+		DryRun:          dryRun,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, entity.ErrImportRowsInvalid):
+			return c.Status(fiber.StatusUnprocessableEntity).JSON(result)
+		case errors.Is(err, entity.ErrImportEmpty), errors.Is(err, entity.ErrImportMissingColumn):
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	if dryRun {
+		return c.JSON(result)
+	}
+	return c.Status(fiber.StatusCreated).JSON(result)
+}
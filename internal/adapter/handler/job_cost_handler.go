@@ -0,0 +1,444 @@
+// Copyright (c) 2026 Muhammet Ali Büyük. All rights reserved.
+// This source code is proprietary. Confidential and private.
+// Unauthorized copying or distribution is strictly prohibited.
+// Contact: iletisim@alibuyuk.net | Website: alibuyuk.net
+
+package handler
+
+import (
+	"errors"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/qantesm/subflow/internal/adapter/xlsx"
+	"github.com/qantesm/subflow/internal/core/entity"
+	"github.com/qantesm/subflow/internal/core/service"
+)
+
+// JobCostHandler handles cost codes, budget, commitments, vendor invoices and the job cost report
+type JobCostHandler struct {
+	costs *service.JobCostService
+}
+
+// NewJobCostHandler creates a new job cost handler
+func NewJobCostHandler(costs *service.JobCostService) *JobCostHandler {
+	return &JobCostHandler{
+		costs: costs,
+	}
+}
+
+// RegisterRoutes registers all job cost routes
+func (h *JobCostHandler) RegisterRoutes(router fiber.Router) {
+	costs := router.Group("/job-costs")
+
+	costs.Post("/codes", h.AddCostCode)
+	costs.Get("/codes/project/:projectId", h.ListCostCodes)
+	costs.Post("/budget", h.AddBudgetLine)
+	costs.Get("/budget/project/:projectId", h.ListBudgetLines)
+	costs.Post("/commitments", h.AddCommitment)
+	costs.Get("/commitments/project/:projectId", h.ListCommitments)
+	costs.Post("/invoices", h.RecordVendorInvoice)
+	costs.Get("/invoices/project/:projectId", h.ListVendorInvoices)
+	costs.Get("/report/project/:projectId", h.Report)
+}
+
+// AddCostCodeRequest represents the request body for a new cost code
+type AddCostCodeRequest struct {
+	ProjectID string `json:"project_id" validate:"required,uuid"`
+	ParentID  string `json:"parent_id"` // Empty for a top-level code
+	Code      string `json:"code" validate:"required"`
+	Name      string `json:"name" validate:"required"`
+}
+
+// AddBudgetLineRequest represents the request body for a budget line
+type AddBudgetLineRequest struct {
+	ProjectID   string `json:"project_id" validate:"required,uuid"`
+	CostCodeID  string `json:"cost_code_id" validate:"required,uuid"`
+	Description string `json:"description"`
+	Amount      int64  `json:"amount" validate:"required"` // Cents, negative to reduce the budget
+	Currency    string `json:"currency"`                   // Defaults to the project currency
+}
+
+// AddCommitmentRequest represents the request body for a subcontract or purchase order commitment
+type AddCommitmentRequest struct {
+	ProjectID   string `json:"project_id" validate:"required,uuid"`
+	CostCodeID  string `json:"cost_code_id" validate:"required,uuid"`
+	Source      string `json:"source" validate:"required"` // SUBCONTRACT, PURCHASE_ORDER
+	ContractID  string `json:"contract_id"`                // Required for SUBCONTRACT
+	ReferenceNo string `json:"reference_no"`               // Required for PURCHASE_ORDER
+	Vendor      string `json:"vendor"`
+	Description string `json:"description"`
+	Amount      int64  `json:"amount" validate:"required"` // Cents, negative for a deductive change
+	Currency    string `json:"currency"`
+	Date        string `json:"date"` // YYYY-MM-DD, defaults to today
+}
+
+// RecordVendorInvoiceRequest represents the request body for a vendor invoice
+type RecordVendorInvoiceRequest struct {
+	ProjectID    string `json:"project_id" validate:"required,uuid"`
+	CostCodeID   string `json:"cost_code_id"`  // Defaults to the commitment's cost code
+	CommitmentID string `json:"commitment_id"` // Subcontract or purchase order invoiced against
+	Vendor       string `json:"vendor"`
+	InvoiceNo    string `json:"invoice_no" validate:"required"`
+	InvoiceDate  string `json:"invoice_date" validate:"required"` // YYYY-MM-DD
+	Amount       int64  `json:"amount" validate:"required"`       // Cents, excluding KDV
+	Currency     string `json:"currency"`
+}
+
+// AddCostCode stores a cost code
+// @Summary Add cost code
+// @Tags Job Costs
+// @Accept json
+// @Produce json
+// @Param request body AddCostCodeRequest true "Cost code"
+// @Success 201 {object} entity.CostCode
+// @Router /job-costs/codes [post]
+func (h *JobCostHandler) AddCostCode(c *fiber.Ctx) error {
+	var req AddCostCodeRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	projectID, err := uuid.Parse(req.ProjectID)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid project ID",
+		})
+	}
+
+	parentID, err := optionalUUID(req.ParentID)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid parent ID",
+		})
+	}
+
+	// TODO: Get actual user ID from auth context
+	userID := uuid.New() // Placeholder
+
+	code := entity.NewCostCode(projectID, req.Code, req.Name, userID)
+	code.ParentID = parentID
+
+	if err := h.costs.AddCostCode(c.Context(), code); err != nil {
+		return jobCostError(c, err)
+	}
+	return c.Status(fiber.StatusCreated).JSON(code)
+}
+
+// ListCostCodes returns the cost codes of a project
+// @Summary List cost codes by project
+// @Tags Job Costs
+// @Produce json
+// @Param projectId path string true "Project ID"
+// @Success 200 {array} entity.CostCode
+// @Router /job-costs/codes/project/{projectId} [get]
+func (h *JobCostHandler) ListCostCodes(c *fiber.Ctx) error {
+	projectID, err := uuid.Parse(c.Params("projectId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid project ID",
+		})
+	}
+
+	codes, err := h.costs.ListCostCodes(c.Context(), projectID)
+	if err != nil {
+		return jobCostError(c, err)
+	}
+
+	return c.JSON(fiber.Map{
+		"data":  codes,
+		"count": len(codes),
+	})
+}
+
+// AddBudgetLine stores a budget line for a cost code
+// @Summary Add budget line
+// @Tags Job Costs
+// @Accept json
+// @Produce json
+// @Param request body AddBudgetLineRequest true "Budget line"
+// @Success 201 {object} entity.BudgetLine
+// @Router /job-costs/budget [post]
+func (h *JobCostHandler) AddBudgetLine(c *fiber.Ctx) error {
+	var req AddBudgetLineRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	projectID, err := uuid.Parse(req.ProjectID)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid project ID",
+		})
+	}
+
+	costCodeID, err := uuid.Parse(req.CostCodeID)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid cost code ID",
+		})
+	}
+
+	// TODO: Get actual user ID from auth context
+	userID := uuid.New() // Placeholder
+
+	line := entity.NewBudgetLine(projectID, costCodeID, req.Description, req.Amount, req.Currency, userID)
+	if err := h.costs.AddBudgetLine(c.Context(), line); err != nil {
+		return jobCostError(c, err)
+	}
+	return c.Status(fiber.StatusCreated).JSON(line)
+}
+
+// ListBudgetLines returns the budget lines of a project
+// @Summary List budget lines by project
+// @Tags Job Costs
+// @Produce json
+// @Param projectId path string true "Project ID"
+// @Success 200 {array} entity.BudgetLine
+// @Router /job-costs/budget/project/{projectId} [get]
+func (h *JobCostHandler) ListBudgetLines(c *fiber.Ctx) error {
+	projectID, err := uuid.Parse(c.Params("projectId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid project ID",
+		})
+	}
+
+	lines, err := h.costs.ListBudgetLines(c.Context(), projectID)
+	if err != nil {
+		return jobCostError(c, err)
+	}
+
+	return c.JSON(fiber.Map{
+		"data":  lines,
+		"count": len(lines),
+	})
+}
+
+// AddCommitment stores a subcontract or purchase order commitment
+// @Summary Add cost commitment
+// @Tags Job Costs
+// @Accept json
+// @Produce json
+// @Param request body AddCommitmentRequest true "Commitment"
+// @Success 201 {object} entity.CostCommitment
+// @Router /job-costs/commitments [post]
+func (h *JobCostHandler) AddCommitment(c *fiber.Ctx) error {
+	var req AddCommitmentRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	projectID, err := uuid.Parse(req.ProjectID)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid project ID",
+		})
+	}
+
+	costCodeID, err := uuid.Parse(req.CostCodeID)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid cost code ID",
+		})
+	}
+
+	contractID, err := optionalUUID(req.ContractID)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid contract ID",
+		})
+	}
+
+	date, err := optionalDate(req.Date)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid date",
+		})
+	}
+
+	// TODO: Get actual user ID from auth context
+	userID := uuid.New() // Placeholder
+
+	commitment := entity.NewCostCommitment(projectID, costCodeID, entity.CommitmentSource(req.Source), req.ReferenceNo, req.Vendor, req.Amount, req.Currency, userID)
+	commitment.ContractID = contractID
+	commitment.Description = req.Description
+	if date != nil {
+		commitment.Date = *date
+	}
+
+	if err := h.costs.AddCommitment(c.Context(), commitment); err != nil {
+		return jobCostError(c, err)
+	}
+	return c.Status(fiber.StatusCreated).JSON(commitment)
+}
+
+// ListCommitments returns the commitments of a project
+// @Summary List cost commitments by project
+// @Tags Job Costs
+// @Produce json
+// @Param projectId path string true "Project ID"
+// @Success 200 {array} entity.CostCommitment
+// @Router /job-costs/commitments/project/{projectId} [get]
+func (h *JobCostHandler) ListCommitments(c *fiber.Ctx) error {
+	projectID, err := uuid.Parse(c.Params("projectId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid project ID",
+		})
+	}
+
+	commitments, err := h.costs.ListCommitments(c.Context(), projectID)
+	if err != nil {
+		return jobCostError(c, err)
+	}
+
+	return c.JSON(fiber.Map{
+		"data":  commitments,
+		"count": len(commitments),
+	})
+}
+
+// RecordVendorInvoice stores an invoice received from a subcontractor or supplier
+// @Summary Record vendor invoice
+// @Tags Job Costs
+// @Accept json
+// @Produce json
+// @Param request body RecordVendorInvoiceRequest true "Vendor invoice"
+// @Success 201 {object} entity.VendorInvoice
+// @Router /job-costs/invoices [post]
+func (h *JobCostHandler) RecordVendorInvoice(c *fiber.Ctx) error {
+	var req RecordVendorInvoiceRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	projectID, err := uuid.Parse(req.ProjectID)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid project ID",
+		})
+	}
+
+	costCodeID, err := optionalUUID(req.CostCodeID)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid cost code ID",
+		})
+	}
+
+	commitmentID, err := optionalUUID(req.CommitmentID)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid commitment ID",
+		})
+	}
+
+	invoiceDate, err := time.Parse("2006-01-02", req.InvoiceDate)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid invoice_date",
+		})
+	}
+
+	// TODO: Get actual user ID from auth context
+	userID := uuid.New() // Placeholder
+
+	invoice := entity.NewVendorInvoice(projectID, uuid.Nil, req.Vendor, req.InvoiceNo, invoiceDate, req.Amount, req.Currency, userID)
+	if costCodeID != nil {
+		invoice.CostCodeID = *costCodeID
+	}
+	invoice.CommitmentID = commitmentID
+
+	if err := h.costs.RecordVendorInvoice(c.Context(), invoice); err != nil {
+		return jobCostError(c, err)
+	}
+	return c.Status(fiber.StatusCreated).JSON(invoice)
+}
+
+// ListVendorInvoices returns the vendor invoices of a project
+// @Summary List vendor invoices by project
+// @Tags Job Costs
+// @Produce json
+// @Param projectId path string true "Project ID"
+// @Success 200 {array} entity.VendorInvoice
+// @Router /job-costs/invoices/project/{projectId} [get]
+func (h *JobCostHandler) ListVendorInvoices(c *fiber.Ctx) error {
+	projectID, err := uuid.Parse(c.Params("projectId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid project ID",
+		})
+	}
+
+	invoices, err := h.costs.ListVendorInvoices(c.Context(), projectID)
+	if err != nil {
+		return jobCostError(c, err)
+	}
+
+	return c.JSON(fiber.Map{
+		"data":  invoices,
+		"count": len(invoices),
+	})
+}
+
+// Report returns the job cost report as JSON, or XLSX when negotiated via Accept
+// @Summary Get job cost report
+// @Tags Job Costs
+// @Produce json
+// @Produce application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param projectId path string true "Project ID"
+// @Success 200 {object} service.JobCostReport
+// @Router /job-costs/report/project/{projectId} [get]
+func (h *JobCostHandler) Report(c *fiber.Ctx) error {
+	projectID, err := uuid.Parse(c.Params("projectId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid project ID",
+		})
+	}
+
+	report, err := h.costs.Report(c.Context(), projectID, time.Now())
+	if err != nil {
+		return jobCostError(c, err)
+	}
+
+	if wantsXLSX(c) {
+		return sendWorkbook(c, xlsx.JobCostWorkbook(report), "job-cost-"+report.ProjectCode+".xlsx")
+	}
+	return c.JSON(report)
+}
+
+// jobCostError maps job cost domain errors to HTTP responses
+func jobCostError(c *fiber.Ctx, err error) error {
+	status := fiber.StatusInternalServerError
+	switch {
+	case errors.Is(err, entity.ErrProjectNotFound),
+		errors.Is(err, entity.ErrCostCodeNotFound),
+		errors.Is(err, entity.ErrCostCommitmentNotFound):
+		status = fiber.StatusNotFound
+	case errors.Is(err, entity.ErrCostCodeExists),
+		errors.Is(err, entity.ErrVendorInvoiceExists):
+		status = fiber.StatusConflict
+	case errors.Is(err, entity.ErrInvalidCostCode),
+		errors.Is(err, entity.ErrCostCodeProjectMismatch),
+		errors.Is(err, entity.ErrInvalidBudgetLine),
+		errors.Is(err, entity.ErrInvalidCostCommitment),
+		errors.Is(err, entity.ErrInvalidVendorInvoice),
+		errors.Is(err, entity.ErrCurrencyMismatch):
+		status = fiber.StatusUnprocessableEntity
+	}
+
+	return c.Status(status).JSON(fiber.Map{
+		"error": err.Error(),
+	})
+}
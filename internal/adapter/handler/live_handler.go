@@ -0,0 +1,143 @@
+// Copyright (c) 2026 Muhammet Ali Büyük. All rights reserved.
+// This source code is proprietary. Confidential and private.
+// Unauthorized copying or distribution is strictly prohibited.
+// Contact: iletisim@alibuyuk.net | Website: alibuyuk.net
+
+package handler
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/qantesm/subflow/internal/core/entity"
+	"github.com/qantesm/subflow/internal/core/service"
+)
+
+const (
+	liveHeartbeat  = 15 * time.Second // Keeps proxies from closing an idle stream
+	liveRetryDelay = 3000             // Milliseconds a client waits before reconnecting
+)
+
+// LiveHandler streams a project's ledger changes and job progress as server-sent events
+type LiveHandler struct {
+	hub      *service.LiveHub
+	projects service.ProjectRepository
+}
+
+// NewLiveHandler creates a new live events handler
+func NewLiveHandler(hub *service.LiveHub, projects service.ProjectRepository) *LiveHandler {
+	return &LiveHandler{
+		hub:      hub,
+		projects: projects,
+	}
+}
+
+// RegisterRoutes registers the live event routes
+func (h *LiveHandler) RegisterRoutes(router fiber.Router) {
+	router.Get("/projects/:id/events", h.Stream)
+}
+
+// Stream pushes new transactions, summary changes and job progress of a project
+// @Summary Stream live project events
+// @Description Server-sent events of kind transaction, summary and job. Reconnect with the
+// @Description Last-Event-ID header to receive what was missed; a resync event means the
+// @Description gap is too long and the client should reload the project.
+// @Tags Projects
+// @Produce text/event-stream
+// @Param id path string true "Project ID"
+// @Param Last-Event-ID header string false "ID of the last event received"
+// @Param lastEventId query string false "Same as Last-Event-ID, for clients that cannot set headers"
+// @Success 200 {string} string "event stream"
+// @Router /projects/{id}/events [get]
+func (h *LiveHandler) Stream(c *fiber.Ctx) error {
+	tenantID := tenantFromContext(c)
+	if tenantID == uuid.Nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Tenant required",
+		})
+	}
+
+	projectID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid project ID",
+		})
+	}
+
+	project, err := h.projects.FindByID(c.Context(), projectID)
+	if err == nil && project.TenantID != tenantID {
+		err = entity.ErrProjectNotFound // Another tenant's project is not disclosed
+	}
+	if err != nil {
+		return liveError(c, err)
+	}
+
+	lastEventID := c.Get("Last-Event-ID")
+	if lastEventID == "" {
+		lastEventID = c.Query("lastEventId")
+	}
+	sub, backlog, resync := h.hub.Subscribe(projectID, lastEventID)
+
+	c.Set(fiber.HeaderContentType, "text/event-stream")
+	c.Set(fiber.HeaderCacheControl, "no-cache")
+	c.Set(fiber.HeaderConnection, "keep-alive")
+	c.Set("X-Accel-Buffering", "no")
+
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		defer sub.Close()
+
+		fmt.Fprintf(w, "retry: %d\n\n", liveRetryDelay)
+		if resync {
+			fmt.Fprint(w, "event: resync\ndata: {}\n\n")
+		}
+		for _, msg := range backlog {
+			writeLiveMessage(w, msg)
+		}
+		if w.Flush() != nil {
+			return
+		}
+
+		heartbeat := time.NewTicker(liveHeartbeat)
+		defer heartbeat.Stop()
+		for {
+			select {
+			case msg, ok := <-sub.C:
+				if !ok {
+					return // Dropped as too slow; the client resumes with Last-Event-ID
+				}
+				writeLiveMessage(w, msg)
+			case <-heartbeat.C:
+				fmt.Fprint(w, ": ping\n\n")
+			}
+			if w.Flush() != nil {
+				return // Client disconnected
+			}
+		}
+	})
+	return nil
+}
+
+// writeLiveMessage writes one message in the event stream format
+func writeLiveMessage(w *bufio.Writer, msg *service.LiveMessage) {
+	data := msg.Data
+	if len(data) == 0 {
+		data = []byte("{}")
+	}
+	fmt.Fprintf(w, "id: %s\nevent: %s\ndata: %s\n\n", msg.ID, msg.Kind, data)
+}
+
+// liveError maps live stream errors to HTTP responses
+func liveError(c *fiber.Ctx, err error) error {
+	status := fiber.StatusInternalServerError
+	if errors.Is(err, entity.ErrProjectNotFound) {
+		status = fiber.StatusNotFound
+	}
+
+	return c.Status(status).JSON(fiber.Map{
+		"error": err.Error(),
+	})
+}
@@ -0,0 +1,46 @@
+// Copyright (c) 2026 Muhammet Ali Büyük. All rights reserved.
+// This source code is proprietary. Confidential and private.
+// Unauthorized copying or distribution is strictly prohibited.
+// Contact: iletisim@alibuyuk.net | Website: alibuyuk.net
+
+package handler
+
+import (
+	"bytes"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/qantesm/subflow/internal/adapter/metrics"
+)
+
+// MetricsHandler serves the metrics to Prometheus
+type MetricsHandler struct {
+	metrics *metrics.Metrics
+}
+
+// NewMetricsHandler creates a new metrics handler
+func NewMetricsHandler(m *metrics.Metrics) *MetricsHandler {
+	return &MetricsHandler{
+		metrics: m,
+	}
+}
+
+// RegisterRoutes registers the scrape endpoint; mount it outside the tenant
+// and authentication middleware, next to the health check
+func (h *MetricsHandler) RegisterRoutes(router fiber.Router) {
+	router.Get("/metrics", h.Scrape)
+}
+
+// Scrape writes every metric in the Prometheus text format
+// @Summary Prometheus metrics
+// @Tags System
+// @Produce plain
+// @Success 200 {string} string
+// @Router /metrics [get]
+func (h *MetricsHandler) Scrape(c *fiber.Ctx) error {
+	var buf bytes.Buffer
+	if _, err := h.metrics.WriteTo(&buf); err != nil {
+		return err
+	}
+	c.Set(fiber.HeaderContentType, metrics.ContentType)
+	return c.Send(buf.Bytes())
+}
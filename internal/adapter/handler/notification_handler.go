@@ -0,0 +1,90 @@
+// Copyright (c) 2026 Muhammet Ali Büyük. All rights reserved.
+// This source code is proprietary. Confidential and private.
+// Unauthorized copying or distribution is strictly prohibited.
+// Contact: iletisim@alibuyuk.net | Website: alibuyuk.net
+
+package handler
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/qantesm/subflow/internal/core/entity"
+	"github.com/qantesm/subflow/internal/core/service"
+)
+
+// NotificationHandler handles the project notification inbox
+type NotificationHandler struct {
+	notifications *service.NotificationService
+}
+
+// NewNotificationHandler creates a new notification handler
+func NewNotificationHandler(notifications *service.NotificationService) *NotificationHandler {
+	return &NotificationHandler{
+		notifications: notifications,
+	}
+}
+
+// RegisterRoutes registers all notification routes
+func (h *NotificationHandler) RegisterRoutes(router fiber.Router) {
+	notifications := router.Group("/notifications")
+
+	notifications.Get("/project/:projectId", h.ListByProject)
+	notifications.Post("/:id/read", h.MarkRead)
+}
+
+// ListByProject returns a project's notifications, newest first; ?unread=true filters read ones
+// @Summary List notifications by project
+// @Tags Notifications
+// @Produce json
+// @Param projectId path string true "Project ID"
+// @Param unread query bool false "Only unread notifications"
+// @Success 200 {array} entity.Notification
+// @Router /notifications/project/{projectId} [get]
+func (h *NotificationHandler) ListByProject(c *fiber.Ctx) error {
+	projectID, err := uuid.Parse(c.Params("projectId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid project ID",
+		})
+	}
+
+	notifications, err := h.notifications.ListByProject(c.Context(), projectID, c.QueryBool("unread"))
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"data":  notifications,
+		"count": len(notifications),
+	})
+}
+
+// MarkRead marks a notification as read
+// @Summary Mark notification read
+// @Tags Notifications
+// @Param id path string true "Notification ID"
+// @Success 204
+// @Router /notifications/{id}/read [post]
+func (h *NotificationHandler) MarkRead(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid notification ID",
+		})
+	}
+
+	if err := h.notifications.MarkRead(c.Context(), id); err != nil {
+		status := fiber.StatusInternalServerError
+		if errors.Is(err, entity.ErrNotificationNotFound) {
+			status = fiber.StatusNotFound
+		}
+		return c.Status(status).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
@@ -0,0 +1,146 @@
+// Copyright (c) 2026 Muhammet Ali Büyük. All rights reserved.
+// This source code is proprietary. Confidential and private.
+// Unauthorized copying or distribution is strictly prohibited.
+// Contact: iletisim@alibuyuk.net | Website: alibuyuk.net
+
+package handler
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/qantesm/subflow/internal/core/entity"
+	"github.com/qantesm/subflow/internal/core/service"
+)
+
+// NotificationPreferenceHandler manages how users are emailed about notifications
+type NotificationPreferenceHandler struct {
+	emails *service.EmailNotifier
+}
+
+// NewNotificationPreferenceHandler creates a new notification preference handler
+func NewNotificationPreferenceHandler(emails *service.EmailNotifier) *NotificationPreferenceHandler {
+	return &NotificationPreferenceHandler{
+		emails: emails,
+	}
+}
+
+// RegisterRoutes registers all notification preference routes
+func (h *NotificationPreferenceHandler) RegisterRoutes(router fiber.Router) {
+	preferences := router.Group("/notification-preferences")
+
+	preferences.Get("/:userId", h.Get)
+	preferences.Put("/:userId", h.Save)
+}
+
+// SaveNotificationPreferenceRequest represents the request body for a user's email preference
+type SaveNotificationPreferenceRequest struct {
+	Email    string      `json:"email" validate:"required,email"`
+	Locale   string      `json:"locale"`   // tr (default) or en
+	Digest   string      `json:"digest"`   // IMMEDIATE (default), DAILY or OFF
+	Kinds    []string    `json:"kinds"`    // Notification kinds to be emailed about; every kind when empty
+	Projects []uuid.UUID `json:"projects"` // Limits emails to these projects; every project when empty
+}
+
+// Get returns a user's email preference
+// @Summary Get notification preference
+// @Tags Notifications
+// @Produce json
+// @Param userId path string true "User ID"
+// @Success 200 {object} entity.NotificationPreference
+// @Router /notification-preferences/{userId} [get]
+func (h *NotificationPreferenceHandler) Get(c *fiber.Ctx) error {
+	tenantID := tenantFromContext(c)
+	if tenantID == uuid.Nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Tenant required",
+		})
+	}
+	userID, err := uuid.Parse(c.Params("userId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid user ID",
+		})
+	}
+
+	preference, err := h.emails.Preference(c.Context(), tenantID, userID)
+	if err != nil {
+		return notificationPreferenceError(c, err)
+	}
+	return c.JSON(preference)
+}
+
+// Save creates or replaces a user's email preference
+// @Summary Save notification preference
+// @Tags Notifications
+// @Accept json
+// @Produce json
+// @Param userId path string true "User ID"
+// @Param preference body SaveNotificationPreferenceRequest true "Preference"
+// @Success 200 {object} entity.NotificationPreference
+// @Router /notification-preferences/{userId} [put]
+func (h *NotificationPreferenceHandler) Save(c *fiber.Ctx) error {
+	tenantID := tenantFromContext(c)
+	if tenantID == uuid.Nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Tenant required",
+		})
+	}
+	userID, err := uuid.Parse(c.Params("userId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid user ID",
+		})
+	}
+
+	var req SaveNotificationPreferenceRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	preference := &entity.NotificationPreference{
+		UserID:   userID,
+		TenantID: tenantID,
+		Email:    req.Email,
+		Locale:   entity.NotificationLocale(req.Locale),
+		Digest:   entity.DigestMode(req.Digest),
+		Kinds:    append([]entity.NotificationKind(nil), entity.NotificationKinds...),
+		Projects: req.Projects,
+	}
+	if preference.Locale == "" {
+		preference.Locale = entity.LocaleTurkish
+	}
+	if preference.Digest == "" {
+		preference.Digest = entity.DigestImmediate
+	}
+	if len(req.Kinds) > 0 {
+		preference.Kinds = make([]entity.NotificationKind, len(req.Kinds))
+		for i, k := range req.Kinds {
+			preference.Kinds[i] = entity.NotificationKind(k)
+		}
+	}
+
+	if err := h.emails.SavePreference(c.Context(), preference); err != nil {
+		return notificationPreferenceError(c, err)
+	}
+	return c.JSON(preference)
+}
+
+// notificationPreferenceError maps preference errors to HTTP responses
+func notificationPreferenceError(c *fiber.Ctx, err error) error {
+	status := fiber.StatusInternalServerError
+	switch {
+	case errors.Is(err, entity.ErrNotificationPreferenceNotFound):
+		status = fiber.StatusNotFound
+	case errors.Is(err, entity.ErrInvalidEmail),
+		errors.Is(err, entity.ErrInvalidNotificationPreference):
+		status = fiber.StatusUnprocessableEntity
+	}
+
+	return c.Status(status).JSON(fiber.Map{
+		"error": err.Error(),
+	})
+}
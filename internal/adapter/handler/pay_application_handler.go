@@ -0,0 +1,287 @@
+// Copyright (c) 2026 Muhammet Ali Büyük. All rights reserved.
+// This source code is proprietary. Confidential and private.
+// Unauthorized copying or distribution is strictly prohibited.
+// Contact: iletisim@alibuyuk.net | Website: alibuyuk.net
+
+package handler
+
+import (
+	"errors"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/qantesm/subflow/internal/core/entity"
+	"github.com/qantesm/subflow/internal/core/service"
+)
+
+// PayApplicationHandler handles HTTP requests for pay applications (hakediş)
+type PayApplicationHandler struct {
+	payApps *service.PayApplicationService
+}
+
+// NewPayApplicationHandler creates a new pay application handler
+func NewPayApplicationHandler(payApps *service.PayApplicationService) *PayApplicationHandler {
+	return &PayApplicationHandler{
+		payApps: payApps,
+	}
+}
+
+// RegisterRoutes registers all pay application routes
+func (h *PayApplicationHandler) RegisterRoutes(router fiber.Router) {
+	apps := router.Group("/pay-applications")
+
+	apps.Post("/", h.Create)
+	apps.Get("/project/:projectId", h.ListByProject)
+	apps.Get("/:id", h.Get)
+	apps.Post("/:id/submit", h.Submit)
+	apps.Post("/:id/certify", h.Certify)
+	apps.Post("/:id/reject", h.Reject)
+}
+
+// CreatePayApplicationRequest represents the request body for a new pay application
+type CreatePayApplicationRequest struct {
+	ProjectID   string `json:"project_id" validate:"required,uuid"`
+	PeriodStart string `json:"period_start" validate:"required"` // YYYY-MM-DD
+	PeriodEnd   string `json:"period_end" validate:"required"`   // YYYY-MM-DD
+	Currency    string `json:"currency" validate:"required,len=3"`
+
+	OriginalContractSum   int64 `json:"original_contract_sum"`
+	ApprovedChangeOrders  int64 `json:"approved_change_orders"`
+	PreviousWorkCompleted int64 `json:"previous_work_completed"`
+	CurrentWorkCompleted  int64 `json:"current_work_completed"`
+	StoredMaterials       int64 `json:"stored_materials"`
+	PreviousCertificates  int64 `json:"previous_certificates"`
+	LaborRetainageRate    int64 `json:"labor_retainage_rate"`    // Basis points (1000 = 10%)
+	MaterialRetainageRate int64 `json:"material_retainage_rate"` // Basis points
+
+	// KDV, tevkifat and stamp duty selection; rates come from the tenant table
+	Tax service.TaxOptions `json:"tax"`
+
+	// Optional fiyat farkı formula applied to this period's work
+	EscalationFormulaID string `json:"escalation_formula_id"`
+}
+
+// RejectPayApplicationRequest represents the request body for rejecting an application
+type RejectPayApplicationRequest struct {
+	Reason string `json:"reason" validate:"required"`
+}
+
+// Create calculates and stores a draft pay application
+// @Summary Create pay application
+// @Tags PayApplications
+// @Accept json
+// @Produce json
+// @Param request body CreatePayApplicationRequest true "Period, G702 input and tax options"
+// @Success 201 {object} entity.PayApplication
+// @Router /pay-applications [post]
+func (h *PayApplicationHandler) Create(c *fiber.Ctx) error {
+	var req CreatePayApplicationRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	projectID, err := uuid.Parse(req.ProjectID)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid project ID",
+		})
+	}
+
+	periodStart, err1 := time.Parse("2006-01-02", req.PeriodStart)
+	periodEnd, err2 := time.Parse("2006-01-02", req.PeriodEnd)
+	if err1 != nil || err2 != nil || periodEnd.Before(periodStart) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid billing period",
+		})
+	}
+
+	if req.Currency == "" {
+		req.Currency = "TRY"
+	}
+
+	// TODO: Get actual user ID from auth context
+	userID := uuid.New() // Placeholder
+
+	appReq := service.PayApplicationRequest{
+		PeriodStart: periodStart,
+		PeriodEnd:   periodEnd,
+		Currency:    req.Currency,
+		Billing: service.AIABillingInput{
+			OriginalContractSum:   req.OriginalContractSum,
+			ApprovedChangeOrders:  req.ApprovedChangeOrders,
+			PreviousWorkCompleted: req.PreviousWorkCompleted,
+			CurrentWorkCompleted:  req.CurrentWorkCompleted,
+			StoredMaterials:       req.StoredMaterials,
+			PreviousCertificates:  req.PreviousCertificates,
+			LaborRetainageRate:    req.LaborRetainageRate,
+			MaterialRetainageRate: req.MaterialRetainageRate,
+		},
+		Tax: req.Tax,
+	}
+	if req.EscalationFormulaID != "" {
+		formulaID, err := uuid.Parse(req.EscalationFormulaID)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid escalation formula ID",
+			})
+		}
+		appReq.EscalationFormulaID = &formulaID
+	}
+
+	app, err := h.payApps.Create(c.Context(), tenantFromContext(c), projectID, appReq, userID)
+	if err != nil {
+		return payApplicationError(c, err)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(app)
+}
+
+// Get returns a single pay application
+// @Summary Get pay application
+// @Tags PayApplications
+// @Produce json
+// @Param id path string true "Pay application ID"
+// @Success 200 {object} entity.PayApplication
+// @Router /pay-applications/{id} [get]
+func (h *PayApplicationHandler) Get(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid pay application ID",
+		})
+	}
+
+	app, err := h.payApps.Get(c.Context(), id)
+	if err != nil {
+		return payApplicationError(c, err)
+	}
+	return c.JSON(app)
+}
+
+// ListByProject returns all pay applications for a project
+// @Summary List pay applications by project
+// @Tags PayApplications
+// @Produce json
+// @Param projectId path string true "Project ID"
+// @Success 200 {array} entity.PayApplication
+// @Router /pay-applications/project/{projectId} [get]
+func (h *PayApplicationHandler) ListByProject(c *fiber.Ctx) error {
+	projectID, err := uuid.Parse(c.Params("projectId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid project ID",
+		})
+	}
+
+	apps, err := h.payApps.ListByProject(c.Context(), projectID)
+	if err != nil {
+		return payApplicationError(c, err)
+	}
+
+	return c.JSON(fiber.Map{
+		"data":  apps,
+		"count": len(apps),
+	})
+}
+
+// Submit sends a draft pay application for approval
+// @Summary Submit pay application
+// @Tags PayApplications
+// @Param id path string true "Pay application ID"
+// @Router /pay-applications/{id}/submit [post]
+func (h *PayApplicationHandler) Submit(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid pay application ID",
+		})
+	}
+
+	app, err := h.payApps.Submit(c.Context(), id)
+	if err != nil {
+		return payApplicationError(c, err)
+	}
+	return c.JSON(app)
+}
+
+// Certify approves a submitted pay application and records its invoice
+// @Summary Certify pay application
+// @Tags PayApplications
+// @Param id path string true "Pay application ID"
+// @Router /pay-applications/{id}/certify [post]
+func (h *PayApplicationHandler) Certify(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid pay application ID",
+		})
+	}
+
+	// TODO: Get actual user ID from auth context
+	userID := uuid.New() // Placeholder
+
+	app, err := h.payApps.Certify(c.Context(), id, userID)
+	if err != nil {
+		return payApplicationError(c, err)
+	}
+	return c.JSON(app)
+}
+
+// Reject returns a submitted pay application with a reason
+// @Summary Reject pay application
+// @Tags PayApplications
+// @Accept json
+// @Param id path string true "Pay application ID"
+// @Param request body RejectPayApplicationRequest true "Rejection reason"
+// @Router /pay-applications/{id}/reject [post]
+func (h *PayApplicationHandler) Reject(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid pay application ID",
+		})
+	}
+
+	var req RejectPayApplicationRequest
+	if err := c.BodyParser(&req); err != nil || req.Reason == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Rejection reason is required",
+		})
+	}
+
+	app, err := h.payApps.Reject(c.Context(), id, req.Reason)
+	if err != nil {
+		return payApplicationError(c, err)
+	}
+	return c.JSON(app)
+}
+
+// payApplicationError maps pay application domain errors to HTTP responses
+func payApplicationError(c *fiber.Ctx, err error) error {
+	status := fiber.StatusInternalServerError
+	switch {
+	case errors.Is(err, entity.ErrPayApplicationNotFound),
+		errors.Is(err, entity.ErrEscalationFormulaNotFound),
+		errors.Is(err, entity.ErrProjectNotFound):
+		status = fiber.StatusNotFound
+	case errors.Is(err, entity.ErrPayApplicationNotEditable),
+		errors.Is(err, entity.ErrPayApplicationNotSubmitted),
+		errors.Is(err, entity.ErrProjectNotModifiable):
+		status = fiber.StatusConflict
+	case errors.Is(err, entity.ErrInvalidContractAmount),
+		errors.Is(err, entity.ErrInvalidAmount):
+		status = fiber.StatusBadRequest
+	case errors.Is(err, entity.ErrTaxRateNotFound),
+		errors.Is(err, entity.ErrInvalidWithholdingCode),
+		errors.Is(err, entity.ErrPriceIndexNotFound),
+		errors.Is(err, entity.ErrEscalationProjectMismatch):
+		status = fiber.StatusUnprocessableEntity
+	}
+
+	return c.Status(status).JSON(fiber.Map{
+		"error": err.Error(),
+	})
+}
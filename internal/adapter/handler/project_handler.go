@@ -6,46 +6,102 @@
 package handler
 
 import (
+	"errors"
+	"strings"
+	"time"
+
 	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/qantesm/subflow/internal/core/entity"
 	"github.com/qantesm/subflow/internal/core/service"
 )
 
+// defaultProjectPageSize is how many projects a list returns unless asked otherwise
+const defaultProjectPageSize = 50
+
 // ProjectHandler handles HTTP requests for project operations
 type ProjectHandler struct {
-	// Services would be injected here
-	calculator *service.Calculator
+	projects *service.ProjectService
 }
 
 // NewProjectHandler creates a new project handler
-func NewProjectHandler(calc *service.Calculator) *ProjectHandler {
+func NewProjectHandler(projects *service.ProjectService) *ProjectHandler {
 	return &ProjectHandler{
-		calculator: calc,
+		projects: projects,
 	}
 }
 
 // RegisterRoutes registers all project-related routes
 func (h *ProjectHandler) RegisterRoutes(router fiber.Router) {
 	projects := router.Group("/projects")
-	
+
 	projects.Get("/", h.ListProjects)
 	projects.Post("/", h.CreateProject)
 	projects.Get("/:id", h.GetProject)
 	projects.Put("/:id", h.UpdateProject)
 	projects.Delete("/:id", h.DeleteProject)
 	projects.Get("/:id/financials/summary", h.GetFinancialSummary)
+	projects.Post("/:id/transition", h.Transition)
+	projects.Get("/:id/status-history", h.StatusHistory)
+}
+
+// CreateProjectRequest represents the request body for creating a project
+type CreateProjectRequest struct {
+	Name                  string   `json:"name" validate:"required"`
+	Code                  string   `json:"code" validate:"required"`
+	Description           string   `json:"description"`
+	ContractAmount        int64    `json:"contract_amount"`    // In cents
+	Currency              string   `json:"currency"`           // Defaults to TRY
+	StartDate             string   `json:"start_date"`         // YYYY-MM-DD
+	EstimatedEndDate      string   `json:"estimated_end_date"` // YYYY-MM-DD
+	LaborRetainageRate    *float64 `json:"labor_retainage_rate"`
+	MaterialRetainageRate *float64 `json:"material_retainage_rate"`
+}
+
+// UpdateProjectRequest represents the request body for updating a project
+// Omitted fields are left as they are
+type UpdateProjectRequest struct {
+	Name                  *string  `json:"name"`
+	Description           *string  `json:"description"`
+	ContractAmount        *int64   `json:"contract_amount"` // In cents
+	Currency              *string  `json:"currency"`
+	StartDate             string   `json:"start_date"`         // YYYY-MM-DD
+	EstimatedEndDate      string   `json:"estimated_end_date"` // YYYY-MM-DD
+	LaborRetainageRate    *float64 `json:"labor_retainage_rate"`
+	MaterialRetainageRate *float64 `json:"material_retainage_rate"`
 }
 
 // ListProjects returns all projects for the current tenant
 // @Summary List all projects
 // @Tags Projects
 // @Produce json
+// @Param limit query int false "Page size"
+// @Param offset query int false "Projects to skip"
 // @Success 200 {object} map[string]interface{}
 // @Router /projects [get]
 func (h *ProjectHandler) ListProjects(c *fiber.Ctx) error {
-	// TODO: Implement with repository
+	tenantID := tenantFromContext(c)
+	if tenantID == uuid.Nil {
+		return tenantRequired(c)
+	}
+
+	limit := c.QueryInt("limit", defaultProjectPageSize)
+	if limit <= 0 {
+		limit = defaultProjectPageSize
+	}
+	offset := c.QueryInt("offset", 0)
+	if offset < 0 {
+		offset = 0
+	}
+
+	projects, err := h.projects.List(c.Context(), tenantID, limit, offset)
+	if err != nil {
+		return projectError(c, err)
+	}
+
 	return c.JSON(fiber.Map{
-		"data":    []interface{}{},
-		"message": "Projects retrieved successfully",
+		"data":  projects,
+		"count": len(projects),
 	})
 }
 
@@ -54,13 +110,54 @@ func (h *ProjectHandler) ListProjects(c *fiber.Ctx) error {
 // @Tags Projects
 // @Accept json
 // @Produce json
-// @Success 201 {object} map[string]interface{}
+// @Param request body CreateProjectRequest true "Project"
+// @Success 201 {object} entity.Project
 // @Router /projects [post]
 func (h *ProjectHandler) CreateProject(c *fiber.Ctx) error {
-	// TODO: Implement with validation and repository
-	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
-		"message": "Project created successfully",
-	})
+	tenantID := tenantFromContext(c)
+	if tenantID == uuid.Nil {
+		return tenantRequired(c)
+	}
+
+	var req CreateProjectRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	startDate, err := optionalDate(req.StartDate)
+	if err != nil {
+		return invalidDate(c)
+	}
+	endDate, err := optionalDate(req.EstimatedEndDate)
+	if err != nil {
+		return invalidDate(c)
+	}
+
+	project := entity.NewProject(tenantID, req.Name, req.Code)
+	project.Description = req.Description
+	project.ContractAmount = req.ContractAmount
+	if req.Currency != "" {
+		project.Currency = strings.ToUpper(req.Currency)
+	}
+	if startDate != nil {
+		project.StartDate = *startDate
+	}
+	if endDate != nil {
+		project.EstimatedEndDate = *endDate
+	}
+	if req.LaborRetainageRate != nil {
+		project.LaborRetainageRate = *req.LaborRetainageRate
+	}
+	if req.MaterialRetainageRate != nil {
+		project.MaterialRetainageRate = *req.MaterialRetainageRate
+	}
+
+	if err := h.projects.Create(c.Context(), project); err != nil {
+		return projectError(c, err)
+	}
+	return c.Status(fiber.StatusCreated).JSON(project)
 }
 
 // GetProject retrieves a single project by ID
@@ -68,88 +165,256 @@ func (h *ProjectHandler) CreateProject(c *fiber.Ctx) error {
 // @Tags Projects
 // @Produce json
 // @Param id path string true "Project ID"
-// @Success 200 {object} map[string]interface{}
+// @Success 200 {object} entity.Project
 // @Router /projects/{id} [get]
 func (h *ProjectHandler) GetProject(c *fiber.Ctx) error {
-	id := c.Params("id")
-	return c.JSON(fiber.Map{
-		"id":      id,
-		"message": "Project retrieved",
-	})
+	tenantID := tenantFromContext(c)
+	if tenantID == uuid.Nil {
+		return tenantRequired(c)
+	}
+
+	projectID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return invalidProjectID(c)
+	}
+
+	project, err := h.projects.Get(c.Context(), tenantID, projectID)
+	if err != nil {
+		return projectError(c, err)
+	}
+	return c.JSON(project)
 }
 
 // UpdateProject updates an existing project
+// The status is changed through the transition endpoint.
 // @Summary Update project
 // @Tags Projects
 // @Accept json
 // @Produce json
 // @Param id path string true "Project ID"
-// @Success 200 {object} map[string]interface{}
+// @Param request body UpdateProjectRequest true "Changed fields"
+// @Success 200 {object} entity.Project
 // @Router /projects/{id} [put]
 func (h *ProjectHandler) UpdateProject(c *fiber.Ctx) error {
-	id := c.Params("id")
-	return c.JSON(fiber.Map{
-		"id":      id,
-		"message": "Project updated",
-	})
+	tenantID := tenantFromContext(c)
+	if tenantID == uuid.Nil {
+		return tenantRequired(c)
+	}
+
+	projectID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return invalidProjectID(c)
+	}
+
+	var req UpdateProjectRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	changes := service.ProjectChanges{
+		Name:                  req.Name,
+		Description:           req.Description,
+		ContractAmount:        req.ContractAmount,
+		Currency:              req.Currency,
+		LaborRetainageRate:    req.LaborRetainageRate,
+		MaterialRetainageRate: req.MaterialRetainageRate,
+	}
+	if changes.StartDate, err = optionalDate(req.StartDate); err != nil {
+		return invalidDate(c)
+	}
+	if changes.EstimatedEndDate, err = optionalDate(req.EstimatedEndDate); err != nil {
+		return invalidDate(c)
+	}
+
+	project, err := h.projects.Update(c.Context(), tenantID, projectID, changes)
+	if err != nil {
+		return projectError(c, err)
+	}
+	return c.JSON(project)
 }
 
 // DeleteProject soft-deletes a project
+// Only draft and cancelled projects can be deleted.
 // @Summary Delete project
 // @Tags Projects
 // @Param id path string true "Project ID"
 // @Success 204
 // @Router /projects/{id} [delete]
 func (h *ProjectHandler) DeleteProject(c *fiber.Ctx) error {
+	tenantID := tenantFromContext(c)
+	if tenantID == uuid.Nil {
+		return tenantRequired(c)
+	}
+
+	projectID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return invalidProjectID(c)
+	}
+
+	if err := h.projects.Delete(c.Context(), tenantID, projectID); err != nil {
+		return projectError(c, err)
+	}
 	return c.SendStatus(fiber.StatusNoContent)
 }
 
-// CalculateBillingRequest represents the input for AIA billing calculation
-type CalculateBillingRequest struct {
-	OriginalContractSum   int64 `json:"original_contract_sum"`
-	ApprovedChangeOrders  int64 `json:"approved_change_orders"`
-	PreviousWorkCompleted int64 `json:"previous_work_completed"`
-	CurrentWorkCompleted  int64 `json:"current_work_completed"`
-	StoredMaterials       int64 `json:"stored_materials"`
-	PreviousCertificates  int64 `json:"previous_certificates"`
-	LaborRetainageRate    int64 `json:"labor_retainage_rate"`    // Basis points
-	MaterialRetainageRate int64 `json:"material_retainage_rate"` // Basis points
+// ProjectTransitionRequest represents the request body for a status change
+type ProjectTransitionRequest struct {
+	Status string `json:"status" validate:"required"` // ACTIVE, ON_HOLD, COMPLETED, CANCELLED
+	Reason string `json:"reason"`
+}
+
+// Transition moves a project to a new status
+// @Summary Change project status
+// @Tags Projects
+// @Accept json
+// @Produce json
+// @Param id path string true "Project ID"
+// @Param request body ProjectTransitionRequest true "Target status"
+// @Success 200 {object} entity.ProjectStatusChange
+// @Router /projects/{id}/transition [post]
+func (h *ProjectHandler) Transition(c *fiber.Ctx) error {
+	projectID, err := h.tenantProject(c)
+	if err != nil {
+		return err
+	}
+
+	var req ProjectTransitionRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	userID := userFromContext(c)
+
+	change, err := h.projects.Transition(c.Context(), projectID, entity.ProjectStatus(req.Status), req.Reason, userID, time.Now())
+	if err != nil {
+		return projectError(c, err)
+	}
+	return c.JSON(change)
 }
 
-// GetFinancialSummary returns the financial snapshot of a project
+// StatusHistory returns the status changes of a project
+// @Summary Get project status history
+// @Tags Projects
+// @Produce json
+// @Param id path string true "Project ID"
+// @Success 200 {array} entity.ProjectStatusChange
+// @Router /projects/{id}/status-history [get]
+func (h *ProjectHandler) StatusHistory(c *fiber.Ctx) error {
+	projectID, err := h.tenantProject(c)
+	if err != nil {
+		return err
+	}
+
+	history, err := h.projects.History(c.Context(), projectID)
+	if err != nil {
+		return projectError(c, err)
+	}
+
+	return c.JSON(fiber.Map{
+		"data":  history,
+		"count": len(history),
+	})
+}
+
+// GetFinancialSummary returns the ledger summary of a project, one per currency
 // @Summary Get project financial summary
 // @Tags Projects
 // @Produce json
 // @Param id path string true "Project ID"
-// @Success 200 {object} service.AIABillingResult
+// @Success 200 {array} service.LedgerSummary
 // @Router /projects/{id}/financials/summary [get]
 func (h *ProjectHandler) GetFinancialSummary(c *fiber.Ctx) error {
-	// Example calculation with sample data
-	input := service.AIABillingInput{
-		OriginalContractSum:   100000000, // $1,000,000.00
-		ApprovedChangeOrders:  5000000,   // $50,000.00
-		PreviousWorkCompleted: 30000000,  // $300,000.00
-		CurrentWorkCompleted:  15000000,  // $150,000.00
-		StoredMaterials:       5000000,   // $50,000.00
-		PreviousCertificates:  25000000,  // $250,000.00
-		LaborRetainageRate:    1000,      // 10%
-		MaterialRetainageRate: 500,       // 5%
-	}
-
-	result, err := h.calculator.Calculate(input)
+	tenantID := tenantFromContext(c)
+	if tenantID == uuid.Nil {
+		return tenantRequired(c)
+	}
+
+	projectID, err := uuid.Parse(c.Params("id"))
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": err.Error(),
-		})
+		return invalidProjectID(c)
+	}
+
+	summaries, err := h.projects.Financials(c.Context(), tenantID, projectID)
+	if err != nil {
+		return projectError(c, err)
+	}
+
+	formatted := make([]fiber.Map, len(summaries))
+	for i, summary := range summaries {
+		formatted[i] = fiber.Map{
+			"currency":        summary.Currency,
+			"total_invoiced":  service.FormatCurrency(summary.TotalInvoiced, summary.Currency),
+			"total_paid":      service.FormatCurrency(summary.TotalPaid, summary.Currency),
+			"total_retained":  service.FormatCurrency(summary.TotalRetained, summary.Currency),
+			"current_balance": service.FormatCurrency(summary.CurrentBalance, summary.Currency),
+		}
 	}
 
 	return c.JSON(fiber.Map{
-		"project_id": c.Params("id"),
-		"summary":    result,
-		"formatted": fiber.Map{
-			"contract_sum":        service.FormatCurrency(result.ContractSum, "USD"),
-			"current_payment_due": service.FormatCurrency(result.CurrentPaymentDue, "USD"),
-			"total_retainage":     service.FormatCurrency(result.TotalRetainage, "USD"),
-		},
+		"project_id": projectID,
+		"summaries":  summaries,
+		"formatted":  formatted,
+	})
+}
+
+// tenantProject parses the project in the path and checks that it belongs to the request tenant
+// On failure the response is already written and returned as the error.
+func (h *ProjectHandler) tenantProject(c *fiber.Ctx) (uuid.UUID, error) {
+	tenantID := tenantFromContext(c)
+	if tenantID == uuid.Nil {
+		return uuid.Nil, tenantRequired(c)
+	}
+
+	projectID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return uuid.Nil, invalidProjectID(c)
+	}
+	if _, err := h.projects.Get(c.Context(), tenantID, projectID); err != nil {
+		return uuid.Nil, projectError(c, err)
+	}
+	return projectID, nil
+}
+
+func tenantRequired(c *fiber.Ctx) error {
+	return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+		"error": "Tenant required",
+	})
+}
+
+func invalidProjectID(c *fiber.Ctx) error {
+	return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+		"error": "Invalid project ID",
+	})
+}
+
+func invalidDate(c *fiber.Ctx) error {
+	return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+		"error": "Invalid date, expected YYYY-MM-DD",
+	})
+}
+
+// projectError maps project domain errors to HTTP responses
+func projectError(c *fiber.Ctx, err error) error {
+	status := fiber.StatusInternalServerError
+	switch {
+	case errors.Is(err, entity.ErrProjectNotFound):
+		status = fiber.StatusNotFound
+	case errors.Is(err, entity.ErrInvalidStatusTransition),
+		errors.Is(err, entity.ErrProjectNotModifiable),
+		errors.Is(err, entity.ErrProjectHasOpenPayApplications),
+		errors.Is(err, entity.ErrProjectHasOpenBalance):
+		status = fiber.StatusConflict
+	case errors.Is(err, entity.ErrProjectNameRequired),
+		errors.Is(err, entity.ErrProjectCodeRequired),
+		errors.Is(err, entity.ErrInvalidContractAmount):
+		status = fiber.StatusUnprocessableEntity
+	}
+
+	return c.Status(status).JSON(fiber.Map{
+		"error": err.Error(),
 	})
 }
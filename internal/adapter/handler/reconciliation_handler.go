@@ -0,0 +1,264 @@
+// Copyright (c) 2026 Muhammet Ali Büyük. All rights reserved.
+// This source code is proprietary. Confidential and private.
+// Unauthorized copying or distribution is strictly prohibited.
+// Contact: iletisim@alibuyuk.net | Website: alibuyuk.net
+
+package handler
+
+import (
+	"errors"
+	"io"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/qantesm/subflow/internal/adapter/bankstatement"
+	"github.com/qantesm/subflow/internal/core/entity"
+	"github.com/qantesm/subflow/internal/core/service"
+)
+
+// ReconciliationHandler handles bank statement import and payment matching
+type ReconciliationHandler struct {
+	reconciliation *service.ReconciliationService
+}
+
+// NewReconciliationHandler creates a new reconciliation handler
+func NewReconciliationHandler(reconciliation *service.ReconciliationService) *ReconciliationHandler {
+	return &ReconciliationHandler{
+		reconciliation: reconciliation,
+	}
+}
+
+// RegisterRoutes registers all reconciliation-related routes
+func (h *ReconciliationHandler) RegisterRoutes(router fiber.Router) {
+	reconciliation := router.Group("/reconciliation")
+
+	reconciliation.Post("/statements", h.ImportStatement)
+	reconciliation.Get("/statements/:id", h.GetStatement)
+	reconciliation.Get("/statements/:id/suggestions", h.Suggest)
+	reconciliation.Post("/lines/:id/accept", h.Accept)
+	reconciliation.Post("/lines/:id/ignore", h.Ignore)
+}
+
+// AcceptMatchRequest represents the request body for accepting a match
+type AcceptMatchRequest struct {
+	TransactionID        string `json:"transaction_id" validate:"required,uuid"`
+	AmountToleranceCents int64  `json:"amount_tolerance_cents"` // Accepted difference from an invoice for bank charges
+}
+
+// ImportStatement uploads an MT940 or CAMT.053 statement for a project
+// @Summary Import bank statement
+// @Tags Reconciliation
+// @Accept multipart/form-data
+// @Produce json
+// @Param file formData file true "MT940 or CAMT.053 file"
+// @Param project_id formData string true "Project ID"
+// @Success 201 {object} entity.BankStatement
+// @Router /reconciliation/statements [post]
+func (h *ReconciliationHandler) ImportStatement(c *fiber.Ctx) error {
+	projectID, err := uuid.Parse(c.FormValue("project_id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid project ID",
+		})
+	}
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "File is required",
+		})
+	}
+	if fileHeader.Size > maxImportFileSize {
+		return c.Status(fiber.StatusRequestEntityTooLarge).JSON(fiber.Map{
+			"error": "File exceeds 10 MB limit",
+		})
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Unable to read file",
+		})
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(io.LimitReader(file, maxImportFileSize))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Unable to read file",
+		})
+	}
+
+	// TODO: Get actual user ID from auth context
+	userID := uuid.New() // Placeholder
+
+	stmt, err := bankstatement.Parse(data, projectID, userID)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	if err := h.reconciliation.ImportStatement(c.Context(), stmt); err != nil {
+		return reconciliationError(c, err)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(stmt)
+}
+
+// GetStatement returns a statement with its lines and their reconciliation state
+// @Summary Get bank statement
+// @Tags Reconciliation
+// @Produce json
+// @Param id path string true "Statement ID"
+// @Success 200 {object} entity.BankStatement
+// @Router /reconciliation/statements/{id} [get]
+func (h *ReconciliationHandler) GetStatement(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid statement ID",
+		})
+	}
+
+	stmt, err := h.reconciliation.GetStatement(c.Context(), id)
+	if err != nil {
+		return reconciliationError(c, err)
+	}
+
+	return c.JSON(stmt)
+}
+
+// Suggest returns ranked ledger matches for the open credit lines of a statement
+// Query parameters override the default match rules
+// @Summary Suggest statement matches
+// @Tags Reconciliation
+// @Produce json
+// @Param id path string true "Statement ID"
+// @Param date_window_days query int false "Payment date window"
+// @Param invoice_window_days query int false "Open invoice age window"
+// @Param amount_tolerance_cents query int false "Accepted amount difference"
+// @Param min_score query int false "Minimum score"
+// @Success 200 {array} service.MatchSuggestion
+// @Router /reconciliation/statements/{id}/suggestions [get]
+func (h *ReconciliationHandler) Suggest(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid statement ID",
+		})
+	}
+
+	rules := service.MatchRules{
+		DateWindowDays:       c.QueryInt("date_window_days"),
+		InvoiceWindowDays:    c.QueryInt("invoice_window_days"),
+		AmountToleranceCents: int64(c.QueryInt("amount_tolerance_cents")),
+		MinScore:             c.QueryInt("min_score"),
+	}
+
+	suggestions, err := h.reconciliation.Suggest(c.Context(), id, rules)
+	if err != nil {
+		return reconciliationError(c, err)
+	}
+
+	return c.JSON(fiber.Map{
+		"statement_id": id,
+		"suggestions":  suggestions,
+	})
+}
+
+// Accept reconciles a statement line with an invoice or payment
+// @Summary Accept statement match
+// @Tags Reconciliation
+// @Accept json
+// @Produce json
+// @Param id path string true "Statement line ID"
+// @Param request body AcceptMatchRequest true "Matched transaction"
+// @Router /reconciliation/lines/{id}/accept [post]
+func (h *ReconciliationHandler) Accept(c *fiber.Ctx) error {
+	lineID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid statement line ID",
+		})
+	}
+
+	var req AcceptMatchRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	transactionID, err := uuid.Parse(req.TransactionID)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid transaction ID",
+		})
+	}
+
+	// TODO: Get actual user ID from auth context
+	userID := uuid.New() // Placeholder
+
+	rules := service.MatchRules{AmountToleranceCents: req.AmountToleranceCents}
+
+	line, payment, err := h.reconciliation.Accept(c.Context(), lineID, transactionID, rules, userID)
+	if err != nil {
+		return reconciliationError(c, err)
+	}
+
+	return c.JSON(fiber.Map{
+		"line":    line,
+		"payment": payment,
+	})
+}
+
+// Ignore excludes a statement line from reconciliation
+// @Summary Ignore statement line
+// @Tags Reconciliation
+// @Produce json
+// @Param id path string true "Statement line ID"
+// @Success 200 {object} entity.StatementLine
+// @Router /reconciliation/lines/{id}/ignore [post]
+func (h *ReconciliationHandler) Ignore(c *fiber.Ctx) error {
+	lineID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid statement line ID",
+		})
+	}
+
+	line, err := h.reconciliation.Ignore(c.Context(), lineID)
+	if err != nil {
+		return reconciliationError(c, err)
+	}
+
+	return c.JSON(line)
+}
+
+// reconciliationError maps reconciliation domain errors to HTTP responses
+func reconciliationError(c *fiber.Ctx, err error) error {
+	status := fiber.StatusInternalServerError
+	switch {
+	case errors.Is(err, entity.ErrStatementNotFound),
+		errors.Is(err, entity.ErrStatementLineNotFound),
+		errors.Is(err, entity.ErrTransactionNotFound),
+		errors.Is(err, entity.ErrProjectNotFound):
+		status = fiber.StatusNotFound
+	case errors.Is(err, entity.ErrStatementLineReconciled),
+		errors.Is(err, entity.ErrTransactionReconciled),
+		errors.Is(err, entity.ErrInvoiceAlreadyPaid),
+		errors.Is(err, entity.ErrProjectNotModifiable):
+		status = fiber.StatusConflict
+	case errors.Is(err, entity.ErrStatementBalanceMismatch),
+		errors.Is(err, entity.ErrInvalidMatchTarget),
+		errors.Is(err, entity.ErrMatchAmountMismatch),
+		errors.Is(err, entity.ErrCurrencyMismatch),
+		errors.Is(err, entity.ErrInvalidAmount):
+		status = fiber.StatusUnprocessableEntity
+	}
+
+	return c.Status(status).JSON(fiber.Map{
+		"error": err.Error(),
+	})
+}
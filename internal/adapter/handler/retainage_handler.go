@@ -0,0 +1,344 @@
+// Copyright (c) 2026 Muhammet Ali Büyük. All rights reserved.
+// This source code is proprietary. Confidential and private.
+// Unauthorized copying or distribution is strictly prohibited.
+// Contact: iletisim@alibuyuk.net | Website: alibuyuk.net
+
+package handler
+
+import (
+	"errors"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/qantesm/subflow/internal/adapter/xlsx"
+	"github.com/qantesm/subflow/internal/core/entity"
+	"github.com/qantesm/subflow/internal/core/service"
+)
+
+// RetainageHandler handles the retainage release workflow
+type RetainageHandler struct {
+	retainage *service.RetainageService
+}
+
+// NewRetainageHandler creates a new retainage handler
+func NewRetainageHandler(retainage *service.RetainageService) *RetainageHandler {
+	return &RetainageHandler{
+		retainage: retainage,
+	}
+}
+
+// RegisterRoutes registers all retainage routes
+func (h *RetainageHandler) RegisterRoutes(router fiber.Router) {
+	retainage := router.Group("/retainage")
+
+	retainage.Get("/project/:projectId", h.Position)
+	retainage.Put("/project/:projectId/substantial-completion", h.SubstantialCompletion)
+
+	retainage.Post("/releases", h.RequestRelease)
+	retainage.Get("/releases/project/:projectId", h.ListByProject)
+	retainage.Get("/releases/:id", h.Get)
+	retainage.Post("/releases/:id/approve", h.Approve)
+	retainage.Post("/releases/:id/reject", h.Reject)
+	retainage.Get("/releases/:id/invoice", h.Invoice)
+}
+
+// RetainageReleaseRequest represents the request body for a release request
+type RetainageReleaseRequest struct {
+	ProjectID  string `json:"project_id" validate:"required,uuid"`
+	ContractID string `json:"contract_id"`
+	Kind       string `json:"kind" validate:"required"` // PARTIAL, FINAL
+	Amount     int64  `json:"amount"`                   // Cents; ignored for FINAL, which releases everything held
+	Currency   string `json:"currency"`                 // Defaults to the project currency
+	Reason     string `json:"reason"`
+}
+
+// RetainageDecisionRequest represents the request body for approving or rejecting a release
+type RetainageDecisionRequest struct {
+	Note string `json:"note"`
+}
+
+// SubstantialCompletionRequest represents the request body for the geçici kabul date
+type SubstantialCompletionRequest struct {
+	Date string `json:"date" validate:"required"` // YYYY-MM-DD
+}
+
+// Position returns the retainage held for a project, or one contract with ?contract_id=
+// @Summary Get retainage position
+// @Tags Retainage
+// @Produce json
+// @Param projectId path string true "Project ID"
+// @Param contract_id query string false "Contract ID"
+// @Success 200 {object} service.RetainagePosition
+// @Router /retainage/project/{projectId} [get]
+func (h *RetainageHandler) Position(c *fiber.Ctx) error {
+	projectID, err := uuid.Parse(c.Params("projectId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid project ID",
+		})
+	}
+
+	contractID, err := optionalUUID(c.Query("contract_id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid contract ID",
+		})
+	}
+
+	pos, err := h.retainage.Position(c.Context(), projectID, contractID)
+	if err != nil {
+		return retainageError(c, err)
+	}
+	return c.JSON(pos)
+}
+
+// SubstantialCompletion records the substantial completion (geçici kabul) date of a project
+// @Summary Record substantial completion
+// @Tags Retainage
+// @Accept json
+// @Produce json
+// @Param projectId path string true "Project ID"
+// @Param request body SubstantialCompletionRequest true "Completion date"
+// @Success 200 {object} entity.Project
+// @Router /retainage/project/{projectId}/substantial-completion [put]
+func (h *RetainageHandler) SubstantialCompletion(c *fiber.Ctx) error {
+	projectID, err := uuid.Parse(c.Params("projectId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid project ID",
+		})
+	}
+
+	var req SubstantialCompletionRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	date, err := time.Parse("2006-01-02", req.Date)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid date",
+		})
+	}
+
+	project, err := h.retainage.RecordSubstantialCompletion(c.Context(), projectID, date)
+	if err != nil {
+		return retainageError(c, err)
+	}
+	return c.JSON(project)
+}
+
+// RequestRelease opens a retainage release request for approval
+// @Summary Request retainage release
+// @Tags Retainage
+// @Accept json
+// @Produce json
+// @Param request body RetainageReleaseRequest true "Release request"
+// @Success 201 {object} entity.RetainageRelease
+// @Router /retainage/releases [post]
+func (h *RetainageHandler) RequestRelease(c *fiber.Ctx) error {
+	var req RetainageReleaseRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	projectID, err := uuid.Parse(req.ProjectID)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid project ID",
+		})
+	}
+
+	contractID, err := optionalUUID(req.ContractID)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid contract ID",
+		})
+	}
+
+	userID := userFromContext(c)
+	r := entity.NewRetainageRelease(projectID, entity.RetainageReleaseKind(req.Kind), req.Amount, req.Currency, req.Reason, userID)
+	r.ContractID = contractID
+
+	if err := h.retainage.RequestRelease(c.Context(), r, time.Now()); err != nil {
+		return retainageError(c, err)
+	}
+	return c.Status(fiber.StatusCreated).JSON(r)
+}
+
+// Get returns a single release request
+// @Summary Get retainage release
+// @Tags Retainage
+// @Produce json
+// @Param id path string true "Release ID"
+// @Success 200 {object} entity.RetainageRelease
+// @Router /retainage/releases/{id} [get]
+func (h *RetainageHandler) Get(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid release ID",
+		})
+	}
+
+	r, err := h.retainage.Get(c.Context(), id)
+	if err != nil {
+		return retainageError(c, err)
+	}
+	return c.JSON(r)
+}
+
+// ListByProject returns the release requests of a project
+// @Summary List retainage releases by project
+// @Tags Retainage
+// @Produce json
+// @Param projectId path string true "Project ID"
+// @Success 200 {array} entity.RetainageRelease
+// @Router /retainage/releases/project/{projectId} [get]
+func (h *RetainageHandler) ListByProject(c *fiber.Ctx) error {
+	projectID, err := uuid.Parse(c.Params("projectId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid project ID",
+		})
+	}
+
+	releases, err := h.retainage.ListByProject(c.Context(), projectID)
+	if err != nil {
+		return retainageError(c, err)
+	}
+
+	return c.JSON(fiber.Map{
+		"data":  releases,
+		"count": len(releases),
+	})
+}
+
+// Approve signs off a release; the retainage is paid out once enough approvals are in
+// @Summary Approve retainage release
+// @Tags Retainage
+// @Accept json
+// @Produce json
+// @Param id path string true "Release ID"
+// @Param request body RetainageDecisionRequest false "Approval note"
+// @Success 200 {object} entity.RetainageRelease
+// @Router /retainage/releases/{id}/approve [post]
+func (h *RetainageHandler) Approve(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid release ID",
+		})
+	}
+
+	var req RetainageDecisionRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid request body",
+			})
+		}
+	}
+
+	userID := userFromContext(c)
+	r, err := h.retainage.Approve(c.Context(), id, userID, req.Note, time.Now())
+	if err != nil {
+		return retainageError(c, err)
+	}
+	return c.JSON(r)
+}
+
+// Reject closes a pending release request
+// @Summary Reject retainage release
+// @Tags Retainage
+// @Accept json
+// @Produce json
+// @Param id path string true "Release ID"
+// @Param request body RetainageDecisionRequest false "Rejection reason"
+// @Success 200 {object} entity.RetainageRelease
+// @Router /retainage/releases/{id}/reject [post]
+func (h *RetainageHandler) Reject(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid release ID",
+		})
+	}
+
+	var req RetainageDecisionRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid request body",
+			})
+		}
+	}
+
+	userID := userFromContext(c)
+	r, err := h.retainage.Reject(c.Context(), id, userID, req.Note, time.Now())
+	if err != nil {
+		return retainageError(c, err)
+	}
+	return c.JSON(r)
+}
+
+// Invoice returns the release invoice as JSON, or XLSX when negotiated via Accept
+// @Summary Get retainage release invoice
+// @Tags Retainage
+// @Produce json
+// @Produce application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param id path string true "Release ID"
+// @Success 200 {object} entity.RetainageInvoice
+// @Router /retainage/releases/{id}/invoice [get]
+func (h *RetainageHandler) Invoice(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid release ID",
+		})
+	}
+
+	r, err := h.retainage.Get(c.Context(), id)
+	if err != nil {
+		return retainageError(c, err)
+	}
+	if r.Invoice == nil {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error": "retainage release has not been paid out yet",
+		})
+	}
+
+	if wantsXLSX(c) {
+		return sendWorkbook(c, xlsx.RetainageInvoiceWorkbook(r.Invoice), r.Invoice.InvoiceNo+".xlsx")
+	}
+	return c.JSON(r.Invoice)
+}
+
+// retainageError maps retainage domain errors to HTTP responses
+func retainageError(c *fiber.Ctx, err error) error {
+	status := fiber.StatusInternalServerError
+	switch {
+	case errors.Is(err, entity.ErrRetainageReleaseNotFound),
+		errors.Is(err, entity.ErrProjectNotFound):
+		status = fiber.StatusNotFound
+	case errors.Is(err, entity.ErrRetainageReleaseNotPending),
+		errors.Is(err, entity.ErrRetainageDuplicateApproval),
+		errors.Is(err, entity.ErrRetainageSelfApproval),
+		errors.Is(err, entity.ErrProjectNotModifiable):
+		status = fiber.StatusConflict
+	case errors.Is(err, entity.ErrInvalidRetainageRelease),
+		errors.Is(err, entity.ErrRetainageExceedsTotal),
+		errors.Is(err, entity.ErrCurrencyMismatch),
+		errors.Is(err, entity.ErrProjectNotSubstantiallyComplete):
+		status = fiber.StatusUnprocessableEntity
+	}
+
+	return c.Status(status).JSON(fiber.Map{
+		"error": err.Error(),
+	})
+}
@@ -0,0 +1,163 @@
+// Copyright (c) 2026 Muhammet Ali Büyük. All rights reserved.
+// This source code is proprietary. Confidential and private.
+// Unauthorized copying or distribution is strictly prohibited.
+// Contact: iletisim@alibuyuk.net | Website: alibuyuk.net
+
+package handler
+
+import (
+	"errors"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/qantesm/subflow/internal/core/entity"
+	"github.com/qantesm/subflow/internal/core/service"
+)
+
+// TaxHandler handles the tenant tax rate table and ad-hoc tax computation
+type TaxHandler struct {
+	taxes *service.TaxEngine
+}
+
+// NewTaxHandler creates a new tax handler
+func NewTaxHandler(taxes *service.TaxEngine) *TaxHandler {
+	return &TaxHandler{
+		taxes: taxes,
+	}
+}
+
+// RegisterRoutes registers all tax routes
+func (h *TaxHandler) RegisterRoutes(router fiber.Router) {
+	taxes := router.Group("/taxes")
+
+	taxes.Get("/rates", h.ListRates)
+	taxes.Post("/rates", h.AddRate)
+	taxes.Post("/compute", h.Compute)
+}
+
+// AddTaxRateRequest represents the request body for a tenant tax rate
+type AddTaxRateRequest struct {
+	Kind      string `json:"kind" validate:"required"` // KDV, WITHHOLDING, STAMP_DUTY
+	Code      string `json:"code" validate:"required"`
+	Name      string `json:"name"`
+	Rate      int64  `json:"rate"`                           // Parts per million (200000 = 20%)
+	ValidFrom string `json:"valid_from" validate:"required"` // YYYY-MM-DD
+	ValidTo   string `json:"valid_to"`                       // YYYY-MM-DD, empty while in force
+}
+
+// ComputeTaxRequest represents the request body for an ad-hoc tax computation
+type ComputeTaxRequest struct {
+	Base int64  `json:"base"` // Cents
+	Date string `json:"date"` // YYYY-MM-DD, defaults to today
+	service.TaxOptions
+}
+
+// ListRates returns the effective rate table for the tenant, defaults included
+// @Summary List tax rates
+// @Tags Taxes
+// @Produce json
+// @Success 200 {array} entity.TaxRate
+// @Router /taxes/rates [get]
+func (h *TaxHandler) ListRates(c *fiber.Ctx) error {
+	rates, err := h.taxes.Rates(c.Context(), tenantFromContext(c))
+	if err != nil {
+		return taxError(c, err)
+	}
+
+	return c.JSON(fiber.Map{
+		"data":  rates,
+		"count": len(rates),
+	})
+}
+
+// AddRate adds a rate to the tenant's table, overriding the default for that code
+// @Summary Add tax rate
+// @Tags Taxes
+// @Accept json
+// @Produce json
+// @Param request body AddTaxRateRequest true "Rate"
+// @Success 201 {object} entity.TaxRate
+// @Router /taxes/rates [post]
+func (h *TaxHandler) AddRate(c *fiber.Ctx) error {
+	var req AddTaxRateRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	validFrom, err := time.Parse("2006-01-02", req.ValidFrom)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid valid_from date",
+		})
+	}
+
+	tenantID := tenantFromContext(c)
+	rate := entity.NewTaxRate(tenantID, entity.TaxKind(req.Kind), req.Code, req.Name, req.Rate, validFrom)
+	if req.ValidTo != "" {
+		validTo, err := time.Parse("2006-01-02", req.ValidTo)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid valid_to date",
+			})
+		}
+		rate.ValidTo = &validTo
+	}
+
+	if err := h.taxes.AddRate(c.Context(), tenantID, rate); err != nil {
+		return taxError(c, err)
+	}
+	return c.Status(fiber.StatusCreated).JSON(rate)
+}
+
+// Compute returns the tax breakdown for an amount using the tenant's rates
+// @Summary Compute taxes
+// @Tags Taxes
+// @Accept json
+// @Produce json
+// @Param request body ComputeTaxRequest true "Base amount and tax options"
+// @Success 200 {object} entity.TaxBreakdown
+// @Router /taxes/compute [post]
+func (h *TaxHandler) Compute(c *fiber.Ctx) error {
+	var req ComputeTaxRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	date := time.Now()
+	if req.Date != "" {
+		parsed, err := time.Parse("2006-01-02", req.Date)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid date",
+			})
+		}
+		date = parsed
+	}
+
+	breakdown, err := h.taxes.Compute(c.Context(), tenantFromContext(c), date, req.Base, req.TaxOptions)
+	if err != nil {
+		return taxError(c, err)
+	}
+	return c.JSON(breakdown)
+}
+
+// taxError maps tax domain errors to HTTP responses
+func taxError(c *fiber.Ctx, err error) error {
+	status := fiber.StatusInternalServerError
+	switch {
+	case errors.Is(err, entity.ErrTaxRateOverlap):
+		status = fiber.StatusConflict
+	case errors.Is(err, entity.ErrInvalidTaxRate),
+		errors.Is(err, entity.ErrTaxRateNotFound),
+		errors.Is(err, entity.ErrInvalidWithholdingCode):
+		status = fiber.StatusUnprocessableEntity
+	}
+
+	return c.Status(status).JSON(fiber.Map{
+		"error": err.Error(),
+	})
+}
@@ -6,8 +6,13 @@
 package handler
 
 import (
+	"errors"
+	"fmt"
+	"time"
+
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
+	"github.com/qantesm/subflow/internal/adapter/xlsx"
 	"github.com/qantesm/subflow/internal/core/entity"
 	"github.com/qantesm/subflow/internal/core/service"
 )
@@ -16,13 +21,15 @@ import (
 type TransactionHandler struct {
 	ledgerService *service.LedgerService
 	calculator    *service.Calculator
+	taxes         *service.TaxEngine
 }
 
 // NewTransactionHandler creates a new transaction handler
-func NewTransactionHandler(ledger *service.LedgerService, calc *service.Calculator) *TransactionHandler {
+func NewTransactionHandler(ledger *service.LedgerService, calc *service.Calculator, taxes *service.TaxEngine) *TransactionHandler {
 	return &TransactionHandler{
 		ledgerService: ledger,
 		calculator:    calc,
+		taxes:         taxes,
 	}
 }
 
@@ -56,14 +63,17 @@ type CreatePaymentRequest struct {
 	Currency      string `json:"currency" validate:"required,len=3"`
 	BankReceiptNo string `json:"bank_receipt_no" validate:"required"`
 	Description   string `json:"description"`
+	ContractID    string `json:"contract_id"`         // Subcontractor payments are checked for compliance documents
+	Override      string `json:"compliance_override"` // Reason to pay a non-compliant subcontractor anyway
 }
 
 // RetainageRequest represents the request body for retainage operations
 type RetainageRequest struct {
-	ProjectID string  `json:"project_id" validate:"required,uuid"`
-	Amount    int64   `json:"amount" validate:"required,gt=0"`
-	Currency  string  `json:"currency" validate:"required,len=3"`
-	Rate      float64 `json:"rate,omitempty"`
+	ProjectID  string  `json:"project_id" validate:"required,uuid"`
+	ContractID string  `json:"contract_id"` // Optional: retainage tracked per subcontract
+	Amount     int64   `json:"amount" validate:"required,gt=0"`
+	Currency   string  `json:"currency" validate:"required,len=3"`
+	Rate       float64 `json:"rate,omitempty"`
 }
 
 // TransactionResponse is the standard response for transaction operations
@@ -77,9 +87,12 @@ type TransactionResponse struct {
 }
 
 // ListByProject returns all transactions for a project
+// Clients sending Accept: application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// receive the ledger history with running balance as an XLSX download
 // @Summary List transactions by project
 // @Tags Transactions
 // @Produce json
+// @Produce application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
 // @Param projectId path string true "Project ID"
 // @Success 200 {array} entity.Transaction
 // @Router /transactions/project/{projectId} [get]
@@ -98,6 +111,11 @@ func (h *TransactionHandler) ListByProject(c *fiber.Ctx) error {
 		})
 	}
 
+	if wantsXLSX(c) {
+		wb := xlsx.LedgerHistoryWorkbook(projectID, transactions)
+		return sendWorkbook(c, wb, fmt.Sprintf("ledger-%s.xlsx", projectID))
+	}
+
 	return c.JSON(fiber.Map{
 		"data":  transactions,
 		"count": len(transactions),
@@ -132,7 +150,7 @@ func (h *TransactionHandler) CreateInvoice(c *fiber.Ctx) error {
 
 	tx, err := h.ledgerService.RecordInvoice(c.Context(), projectID, req.Amount, req.Currency, req.InvoiceNo, userID)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+		return c.Status(ledgerErrorStatus(err)).JSON(fiber.Map{
 			"error": err.Error(),
 		})
 	}
@@ -170,11 +188,22 @@ func (h *TransactionHandler) CreatePayment(c *fiber.Ctx) error {
 		})
 	}
 
+	contractID, err := optionalUUID(req.ContractID)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid contract ID",
+		})
+	}
+
 	userID := uuid.New() // Placeholder
 
-	tx, err := h.ledgerService.RecordPayment(c.Context(), projectID, req.Amount, req.Currency, req.BankReceiptNo, userID)
+	tx, err := h.ledgerService.RecordPaymentWithMetadata(c.Context(), projectID, contractID, req.Amount, req.Currency, time.Now(), entity.TransactionMetadata{
+		BankReceiptNo:      req.BankReceiptNo,
+		Notes:              req.Description,
+		ComplianceOverride: req.Override,
+	}, userID)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+		return c.Status(ledgerErrorStatus(err)).JSON(fiber.Map{
 			"error": err.Error(),
 		})
 	}
@@ -212,11 +241,18 @@ func (h *TransactionHandler) HoldRetainage(c *fiber.Ctx) error {
 		})
 	}
 
+	contractID, err := optionalUUID(req.ContractID)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid contract ID",
+		})
+	}
+
 	userID := uuid.New()
 
-	tx, err := h.ledgerService.RecordRetainageHeld(c.Context(), projectID, req.Amount, req.Currency, req.Rate, userID)
+	tx, err := h.ledgerService.RecordRetainageHeld(c.Context(), projectID, contractID, req.Amount, req.Currency, req.Rate, userID)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+		return c.Status(ledgerErrorStatus(err)).JSON(fiber.Map{
 			"error": err.Error(),
 		})
 	}
@@ -254,11 +290,18 @@ func (h *TransactionHandler) ReleaseRetainage(c *fiber.Ctx) error {
 		})
 	}
 
+	contractID, err := optionalUUID(req.ContractID)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid contract ID",
+		})
+	}
+
 	userID := uuid.New()
 
-	tx, err := h.ledgerService.RecordRetainageRelease(c.Context(), projectID, req.Amount, req.Currency, userID)
+	tx, err := h.ledgerService.RecordRetainageRelease(c.Context(), projectID, contractID, req.Amount, req.Currency, "", userID)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+		return c.Status(ledgerErrorStatus(err)).JSON(fiber.Map{
 			"error": err.Error(),
 		})
 	}
@@ -273,6 +316,21 @@ func (h *TransactionHandler) ReleaseRetainage(c *fiber.Ctx) error {
 	})
 }
 
+// ledgerErrorStatus maps errors from ledger writes to HTTP status codes
+func ledgerErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, entity.ErrProjectNotFound):
+		return fiber.StatusNotFound
+	case errors.Is(err, entity.ErrProjectNotModifiable):
+		return fiber.StatusConflict
+	case errors.Is(err, entity.ErrSubcontractorNonCompliant),
+		errors.Is(err, entity.ErrRetainageExceedsTotal),
+		errors.Is(err, entity.ErrCurrencyMismatch):
+		return fiber.StatusUnprocessableEntity
+	}
+	return fiber.StatusInternalServerError
+}
+
 // AIACalculateRequest represents the request body for AIA calculation
 type AIACalculateRequest struct {
 	OriginalContractSum   int64 `json:"original_contract_sum"`
@@ -283,13 +341,28 @@ type AIACalculateRequest struct {
 	PreviousCertificates  int64 `json:"previous_certificates"`
 	LaborRetainageRate    int64 `json:"labor_retainage_rate"`    // Basis points (1000 = 10%)
 	MaterialRetainageRate int64 `json:"material_retainage_rate"` // Basis points
+	PriceEscalation       int64 `json:"price_escalation"`        // Fiyat farkı to date, cents
+	AdvancePaid           int64 `json:"advance_paid"`            // Avans paid to date, cents
+	Deductions            int64 `json:"deductions"`              // Kesintiler this period, cents
+
+	// Optional avans mahsubu rule applied to AdvancePaid
+	AdvanceRecovery *entity.AdvanceRecoveryRule `json:"advance_recovery,omitempty"`
+
+	// Optional G703 schedule of values - when present, work totals are taken from it
+	LineItems []service.G703LineItem `json:"line_items,omitempty"`
+
+	// Optional taxes on the current payment due, resolved for TaxDate (YYYY-MM-DD, default today)
+	Tax     *service.TaxOptions `json:"tax,omitempty"`
+	TaxDate string              `json:"tax_date,omitempty"`
 }
 
 // CalculateAIA performs AIA G702/G703 billing calculation
+// XLSX clients receive the G702 summary and G703 continuation sheet with formulas
 // @Summary Calculate AIA billing
 // @Tags Calculator
 // @Accept json
 // @Produce json
+// @Produce application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
 // @Param request body AIACalculateRequest true "Billing input"
 // @Success 200 {object} service.AIABillingResult
 // @Router /calculate/aia [post]
@@ -310,11 +383,31 @@ func (h *TransactionHandler) CalculateAIA(c *fiber.Ctx) error {
 		PreviousCertificates:  req.PreviousCertificates,
 		LaborRetainageRate:    req.LaborRetainageRate,
 		MaterialRetainageRate: req.MaterialRetainageRate,
+		PriceEscalation:       req.PriceEscalation,
+		AdvancePaid:           req.AdvancePaid,
+		AdvanceRecovery:       req.AdvanceRecovery,
+		Deductions:            req.Deductions,
+	}
+
+	var continuation *service.G703ContinuationSheet
+	if len(req.LineItems) > 0 {
+		sheet, err := h.calculator.CalculateContinuation(req.LineItems, req.LaborRetainageRate, req.MaterialRetainageRate)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+		continuation = sheet
+
+		// G702 line 4 is carried over from the G703 grand total
+		input.PreviousWorkCompleted = sheet.GrandTotal.PreviousWork
+		input.CurrentWorkCompleted = sheet.GrandTotal.CurrentWork
+		input.StoredMaterials = sheet.GrandTotal.StoredMaterials
 	}
 
 	result, err := h.calculator.Calculate(input)
 	if err != nil {
-		if err == entity.ErrInvalidContractAmount || err == entity.ErrInvalidAmount {
+		if err == entity.ErrInvalidContractAmount || err == entity.ErrInvalidAmount || err == entity.ErrInvalidAdvanceRule {
 			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
 				"error": err.Error(),
 			})
@@ -324,8 +417,30 @@ func (h *TransactionHandler) CalculateAIA(c *fiber.Ctx) error {
 		})
 	}
 
+	var tax *entity.TaxBreakdown
+	if req.Tax != nil {
+		date := time.Now()
+		if req.TaxDate != "" {
+			if date, err = time.Parse("2006-01-02", req.TaxDate); err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"error": "Invalid tax date",
+				})
+			}
+		}
+		tax, err = h.taxes.ComputeForBilling(c.Context(), tenantFromContext(c), date, result, *req.Tax)
+		if err != nil {
+			return taxError(c, err)
+		}
+	}
+
+	if wantsXLSX(c) {
+		return sendWorkbook(c, xlsx.AIABillingWorkbook(input, result, tax, continuation), "aia-g702-g703.xlsx")
+	}
+
 	return c.JSON(fiber.Map{
-		"result": result,
+		"result":       result,
+		"tax":          tax,
+		"continuation": continuation,
 		"formatted": fiber.Map{
 			"contract_sum":         service.FormatCurrency(result.ContractSum, "TRY"),
 			"total_completed":      service.FormatCurrency(result.TotalCompletedAndStored, "TRY"),
@@ -337,3 +452,23 @@ func (h *TransactionHandler) CalculateAIA(c *fiber.Ctx) error {
 		"_architect": "Muhammet-Ali-Buyuk",
 	})
 }
+
+// wantsXLSX reports whether the client negotiated a spreadsheet download
+// JSON stays the default when no Accept header is sent
+func wantsXLSX(c *fiber.Ctx) bool {
+	return c.Accepts(fiber.MIMEApplicationJSON, xlsx.ContentType) == xlsx.ContentType
+}
+
+// sendWorkbook renders a workbook as an attachment download
+func sendWorkbook(c *fiber.Ctx, wb *xlsx.Workbook, filename string) error {
+	data, err := wb.Bytes()
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	c.Set(fiber.HeaderContentType, xlsx.ContentType)
+	c.Attachment(filename)
+	return c.Send(data)
+}
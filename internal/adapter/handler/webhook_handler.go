@@ -0,0 +1,245 @@
+// Copyright (c) 2026 Muhammet Ali Büyük. All rights reserved.
+// This source code is proprietary. Confidential and private.
+// Unauthorized copying or distribution is strictly prohibited.
+// Contact: iletisim@alibuyuk.net | Website: alibuyuk.net
+
+package handler
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/qantesm/subflow/internal/core/entity"
+	"github.com/qantesm/subflow/internal/core/service"
+)
+
+// defaultDeliveryLogLimit is how many deliveries the log returns unless asked otherwise
+const defaultDeliveryLogLimit = 50
+
+// WebhookHandler handles webhook endpoints, the delivery log and replays
+type WebhookHandler struct {
+	webhooks *service.WebhookService
+}
+
+// NewWebhookHandler creates a new webhook handler
+func NewWebhookHandler(webhooks *service.WebhookService) *WebhookHandler {
+	return &WebhookHandler{
+		webhooks: webhooks,
+	}
+}
+
+// RegisterRoutes registers all webhook routes
+func (h *WebhookHandler) RegisterRoutes(router fiber.Router) {
+	webhooks := router.Group("/webhooks")
+
+	webhooks.Post("/endpoints", h.CreateEndpoint)
+	webhooks.Get("/endpoints", h.ListEndpoints)
+	webhooks.Get("/endpoints/:id", h.GetEndpoint)
+	webhooks.Post("/endpoints/:id/enable", h.EnableEndpoint)
+	webhooks.Post("/endpoints/:id/disable", h.DisableEndpoint)
+	webhooks.Get("/endpoints/:id/deliveries", h.ListDeliveries)
+	webhooks.Post("/deliveries/:id/replay", h.Replay)
+}
+
+// CreateWebhookEndpointRequest represents the request body for a new webhook endpoint
+type CreateWebhookEndpointRequest struct {
+	URL         string   `json:"url" validate:"required,url"`
+	Description string   `json:"description"`
+	Events      []string `json:"events" validate:"required"` // transaction.created, pay_application.submitted, pay_application.certified, retainage.released, project.status_changed
+}
+
+// CreateEndpoint registers a webhook endpoint for the tenant
+// The signing secret is only returned here.
+// @Summary Create webhook endpoint
+// @Tags Webhooks
+// @Accept json
+// @Produce json
+// @Param request body CreateWebhookEndpointRequest true "Endpoint"
+// @Success 201 {object} map[string]interface{}
+// @Router /webhooks/endpoints [post]
+func (h *WebhookHandler) CreateEndpoint(c *fiber.Ctx) error {
+	tenantID := tenantFromContext(c)
+	if tenantID == uuid.Nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Tenant required",
+		})
+	}
+
+	var req CreateWebhookEndpointRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	events := make([]entity.EventType, len(req.Events))
+	for i, e := range req.Events {
+		events[i] = entity.EventType(e)
+	}
+
+	// TODO: Get actual user ID from auth context
+	userID := uuid.New() // Placeholder
+
+	endpoint, err := entity.NewWebhookEndpoint(tenantID, req.URL, events, userID)
+	if err != nil {
+		return webhookError(c, err)
+	}
+	endpoint.Description = req.Description
+
+	if err := h.webhooks.CreateEndpoint(c.Context(), endpoint); err != nil {
+		return webhookError(c, err)
+	}
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"endpoint": endpoint,
+		"secret":   endpoint.Secret,
+	})
+}
+
+// ListEndpoints returns the tenant's webhook endpoints
+// @Summary List webhook endpoints
+// @Tags Webhooks
+// @Produce json
+// @Success 200 {array} entity.WebhookEndpoint
+// @Router /webhooks/endpoints [get]
+func (h *WebhookHandler) ListEndpoints(c *fiber.Ctx) error {
+	endpoints, err := h.webhooks.ListEndpoints(c.Context(), tenantFromContext(c))
+	if err != nil {
+		return webhookError(c, err)
+	}
+
+	return c.JSON(fiber.Map{
+		"data":  endpoints,
+		"count": len(endpoints),
+	})
+}
+
+// GetEndpoint returns a single webhook endpoint
+// @Summary Get webhook endpoint
+// @Tags Webhooks
+// @Produce json
+// @Param id path string true "Endpoint ID"
+// @Success 200 {object} entity.WebhookEndpoint
+// @Router /webhooks/endpoints/{id} [get]
+func (h *WebhookHandler) GetEndpoint(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid endpoint ID",
+		})
+	}
+
+	endpoint, err := h.webhooks.GetEndpoint(c.Context(), tenantFromContext(c), id)
+	if err != nil {
+		return webhookError(c, err)
+	}
+	return c.JSON(endpoint)
+}
+
+// EnableEndpoint resumes deliveries to an endpoint
+// @Summary Enable webhook endpoint
+// @Tags Webhooks
+// @Produce json
+// @Param id path string true "Endpoint ID"
+// @Success 200 {object} entity.WebhookEndpoint
+// @Router /webhooks/endpoints/{id}/enable [post]
+func (h *WebhookHandler) EnableEndpoint(c *fiber.Ctx) error {
+	return h.setActive(c, true)
+}
+
+// DisableEndpoint stops deliveries to an endpoint; pending retries fail
+// @Summary Disable webhook endpoint
+// @Tags Webhooks
+// @Produce json
+// @Param id path string true "Endpoint ID"
+// @Success 200 {object} entity.WebhookEndpoint
+// @Router /webhooks/endpoints/{id}/disable [post]
+func (h *WebhookHandler) DisableEndpoint(c *fiber.Ctx) error {
+	return h.setActive(c, false)
+}
+
+func (h *WebhookHandler) setActive(c *fiber.Ctx, active bool) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid endpoint ID",
+		})
+	}
+
+	endpoint, err := h.webhooks.SetEndpointActive(c.Context(), tenantFromContext(c), id, active)
+	if err != nil {
+		return webhookError(c, err)
+	}
+	return c.JSON(endpoint)
+}
+
+// ListDeliveries returns the delivery log of an endpoint, newest first
+// @Summary List webhook deliveries
+// @Tags Webhooks
+// @Produce json
+// @Param id path string true "Endpoint ID"
+// @Param limit query int false "Maximum deliveries (default 50)"
+// @Success 200 {array} entity.WebhookDelivery
+// @Router /webhooks/endpoints/{id}/deliveries [get]
+func (h *WebhookHandler) ListDeliveries(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid endpoint ID",
+		})
+	}
+
+	limit := c.QueryInt("limit", defaultDeliveryLogLimit)
+	if limit <= 0 {
+		limit = defaultDeliveryLogLimit
+	}
+
+	deliveries, err := h.webhooks.ListDeliveries(c.Context(), tenantFromContext(c), id, limit)
+	if err != nil {
+		return webhookError(c, err)
+	}
+
+	return c.JSON(fiber.Map{
+		"data":  deliveries,
+		"count": len(deliveries),
+	})
+}
+
+// Replay sends a delivery's payload again as a new delivery
+// @Summary Replay webhook delivery
+// @Tags Webhooks
+// @Produce json
+// @Param id path string true "Delivery ID"
+// @Success 202 {object} entity.WebhookDelivery
+// @Router /webhooks/deliveries/{id}/replay [post]
+func (h *WebhookHandler) Replay(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid delivery ID",
+		})
+	}
+
+	delivery, err := h.webhooks.Replay(c.Context(), tenantFromContext(c), id)
+	if err != nil {
+		return webhookError(c, err)
+	}
+	return c.Status(fiber.StatusAccepted).JSON(delivery)
+}
+
+// webhookError maps webhook domain errors to HTTP responses
+func webhookError(c *fiber.Ctx, err error) error {
+	status := fiber.StatusInternalServerError
+	switch {
+	case errors.Is(err, entity.ErrWebhookEndpointNotFound),
+		errors.Is(err, entity.ErrWebhookDeliveryNotFound):
+		status = fiber.StatusNotFound
+	case errors.Is(err, entity.ErrInvalidWebhookEndpoint),
+		errors.Is(err, entity.ErrWebhookAddressNotAllowed):
+		status = fiber.StatusUnprocessableEntity
+	}
+
+	return c.Status(status).JSON(fiber.Map{
+		"error": err.Error(),
+	})
+}
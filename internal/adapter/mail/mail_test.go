@@ -0,0 +1,180 @@
+// Copyright (c) 2026 Muhammet Ali Büyük. All rights reserved.
+// This source code is proprietary. Confidential and private.
+// Unauthorized copying or distribution is strictly prohibited.
+// Contact: iletisim@alibuyuk.net | Website: alibuyuk.net
+
+package mail
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"mime"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/qantesm/subflow/internal/core/service"
+)
+
+var testMessage = &service.EmailMessage{
+	To:      "şantiye@example.com",
+	Subject: "[PRJ-1] Teminat mektubu süresi doldu",
+	Body:    "Merhaba,\n\nİş Bankası mektubunun süresi doldu.",
+}
+
+// fakeSMTP accepts one session without STARTTLS and keeps the envelope and data
+type fakeSMTP struct {
+	ln   net.Listener
+	mu   sync.Mutex
+	from string
+	to   string
+	data string
+}
+
+func newFakeSMTP(t *testing.T) *fakeSMTP {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	f := &fakeSMTP{ln: ln}
+	go f.serve()
+	t.Cleanup(func() { _ = ln.Close() })
+	return f
+}
+
+func (f *fakeSMTP) serve() {
+	conn, err := f.ln.Accept()
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+	rd := bufio.NewReader(conn)
+	reply := func(line string) { _, _ = conn.Write([]byte(line + "\r\n")) }
+
+	reply("220 fake ESMTP")
+	for {
+		line, err := rd.ReadString('\n')
+		if err != nil {
+			return
+		}
+		cmd := strings.TrimRight(line, "\r\n")
+		switch verb := strings.ToUpper(strings.SplitN(cmd, " ", 2)[0]); verb {
+		case "EHLO", "HELO":
+			reply("250 fake")
+		case "MAIL":
+			f.mu.Lock()
+			f.from = cmd
+			f.mu.Unlock()
+			reply("250 OK")
+		case "RCPT":
+			f.mu.Lock()
+			f.to = cmd
+			f.mu.Unlock()
+			reply("250 OK")
+		case "DATA":
+			reply("354 Go ahead")
+			var data strings.Builder
+			for {
+				line, err := rd.ReadString('\n')
+				if err != nil {
+					return
+				}
+				if line == ".\r\n" {
+					break
+				}
+				data.WriteString(line)
+			}
+			f.mu.Lock()
+			f.data = data.String()
+			f.mu.Unlock()
+			reply("250 Queued")
+		case "QUIT":
+			reply("221 Bye")
+			return
+		default:
+			reply("502 Not implemented")
+		}
+	}
+}
+
+func TestComposeEncodesTurkish(t *testing.T) {
+	raw, err := Compose("Subflow <bildirim@example.com>", testMessage, time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("Compose returned error: %v", err)
+	}
+
+	msg, err := mail.ReadMessage(strings.NewReader(string(raw)))
+	if err != nil {
+		t.Fatalf("composed message does not parse: %v", err)
+	}
+	subject, err := new(mime.WordDecoder).DecodeHeader(msg.Header.Get("Subject"))
+	if err != nil || subject != testMessage.Subject {
+		t.Errorf("Subject = %q (%v), want %q", subject, err, testMessage.Subject)
+	}
+	if !strings.HasSuffix(msg.Header.Get("Message-ID"), "@example.com>") {
+		t.Errorf("Message-ID = %q, want the sender's domain", msg.Header.Get("Message-ID"))
+	}
+	body, _ := io.ReadAll(quotedprintable.NewReader(msg.Body))
+	if got := strings.ReplaceAll(string(body), "\r\n", "\n"); got != testMessage.Body {
+		t.Errorf("body = %q, want %q", got, testMessage.Body)
+	}
+}
+
+func TestSMTPTransport(t *testing.T) {
+	server := newFakeSMTP(t)
+	host, port, _ := net.SplitHostPort(server.ln.Addr().String())
+	portNo, _ := strconv.Atoi(port)
+
+	transport := NewSMTPTransport(SMTPConfig{Host: host, Port: portNo, From: "Subflow <bildirim@example.com>", Timeout: 5 * time.Second})
+	msg := *testMessage
+	msg.To = "Saha Şefi <sef@example.com>"
+	if err := transport.Send(context.Background(), &msg); err != nil {
+		t.Fatalf("Send returned error: %v", err)
+	}
+
+	server.mu.Lock()
+	defer server.mu.Unlock()
+	if server.from != "MAIL FROM:<bildirim@example.com>" || !strings.HasPrefix(server.to, "RCPT TO:<sef@example.com>") {
+		t.Errorf("envelope = %q / %q", server.from, server.to)
+	}
+	if !strings.Contains(server.data, "Content-Transfer-Encoding: quoted-printable") {
+		t.Errorf("data lacks the encoding header:\n%s", server.data)
+	}
+}
+
+func TestFileAndMemoryTransports(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "outbox")
+	file, err := NewFileTransport(dir, "bildirim@example.com")
+	if err != nil {
+		t.Fatalf("NewFileTransport returned error: %v", err)
+	}
+	if err := file.Send(context.Background(), testMessage); err != nil {
+		t.Fatalf("Send returned error: %v", err)
+	}
+	files, _ := filepath.Glob(filepath.Join(dir, "*.eml"))
+	if len(files) != 1 {
+		t.Fatalf("wrote %d files, want 1", len(files))
+	}
+	raw, _ := os.ReadFile(files[0])
+	if _, err := mail.ReadMessage(strings.NewReader(string(raw))); err != nil {
+		t.Errorf("written file is not a message: %v", err)
+	}
+
+	memory := NewMemoryTransport()
+	_ = memory.Send(context.Background(), testMessage)
+	if got := memory.Messages(); len(got) != 1 || got[0].To != testMessage.To {
+		t.Errorf("Messages() = %+v", got)
+	}
+	memory.Reset()
+	if len(memory.Messages()) != 0 {
+		t.Error("Reset kept messages")
+	}
+}
@@ -0,0 +1,80 @@
+// Copyright (c) 2026 Muhammet Ali Büyük. All rights reserved.
+// This source code is proprietary. Confidential and private.
+// Unauthorized copying or distribution is strictly prohibited.
+// Contact: iletisim@alibuyuk.net | Website: alibuyuk.net
+
+package mail
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/qantesm/subflow/internal/core/service"
+)
+
+// FileTransport writes each email as an .eml file into a directory, for
+// local development: open the files in any mail client instead of sending
+type FileTransport struct {
+	dir  string
+	from string
+}
+
+// NewFileTransport creates a transport writing into dir, creating it if needed
+func NewFileTransport(dir, from string) (*FileTransport, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	return &FileTransport{dir: dir, from: from}, nil
+}
+
+// Send writes the email to a new file named after the time it was sent
+func (t *FileTransport) Send(ctx context.Context, msg *service.EmailMessage) error {
+	now := time.Now()
+	data, err := Compose(t.from, msg, now)
+	if err != nil {
+		return err
+	}
+	name := fmt.Sprintf("%s-%s.eml", now.UTC().Format("20060102T150405.000000000"), uuid.NewString()[:8])
+	return os.WriteFile(filepath.Join(t.dir, name), data, 0o644)
+}
+
+// MemoryTransport keeps sent emails in memory for tests
+type MemoryTransport struct {
+	mu       sync.Mutex
+	messages []service.EmailMessage
+}
+
+// NewMemoryTransport creates an empty memory transport
+func NewMemoryTransport() *MemoryTransport {
+	return &MemoryTransport{}
+}
+
+// Send records the email
+func (t *MemoryTransport) Send(ctx context.Context, msg *service.EmailMessage) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.messages = append(t.messages, *msg)
+	return nil
+}
+
+// Messages returns the emails sent so far, oldest first
+func (t *MemoryTransport) Messages() []service.EmailMessage {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return append([]service.EmailMessage(nil), t.messages...)
+}
+
+// Reset forgets the emails sent so far
+func (t *MemoryTransport) Reset() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.messages = nil
+}
@@ -0,0 +1,108 @@
+// Copyright (c) 2026 Muhammet Ali Büyük. All rights reserved.
+// This source code is proprietary. Confidential and private.
+// Unauthorized copying or distribution is strictly prohibited.
+// Contact: iletisim@alibuyuk.net | Website: alibuyuk.net
+
+package mail
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"strconv"
+	"time"
+
+	"github.com/qantesm/subflow/internal/core/service"
+)
+
+// DefaultSMTPTimeout bounds one delivery, from dial to QUIT
+const DefaultSMTPTimeout = 30 * time.Second
+
+// SMTPConfig holds the settings of the outgoing mail server
+type SMTPConfig struct {
+	Host     string
+	Port     int // 587 with STARTTLS, 25 for a local relay
+	Username string
+	Password string
+	From     string // e.g. "Subflow <bildirim@example.com>"
+	Timeout  time.Duration
+}
+
+// SMTPTransport sends each email in its own SMTP session. STARTTLS is used
+// whenever the server offers it and required before authenticating.
+type SMTPTransport struct {
+	config SMTPConfig
+}
+
+// NewSMTPTransport creates an SMTP transport
+func NewSMTPTransport(config SMTPConfig) *SMTPTransport {
+	if config.Timeout <= 0 {
+		config.Timeout = DefaultSMTPTimeout
+	}
+	return &SMTPTransport{config: config}
+}
+
+// Send delivers the email to the server
+func (t *SMTPTransport) Send(ctx context.Context, msg *service.EmailMessage) error {
+	data, err := Compose(t.config.From, msg, time.Now())
+	if err != nil {
+		return err
+	}
+	from, err := mail.ParseAddress(t.config.From)
+	if err != nil {
+		return err
+	}
+	to, err := mail.ParseAddress(msg.To)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, t.config.Timeout)
+	defer cancel()
+	addr := net.JoinHostPort(t.config.Host, strconv.Itoa(t.config.Port))
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return err
+	}
+	deadline, _ := ctx.Deadline()
+	_ = conn.SetDeadline(deadline)
+
+	client, err := smtp.NewClient(conn, t.config.Host)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(&tls.Config{ServerName: t.config.Host}); err != nil {
+			return err
+		}
+	}
+	if t.config.Username != "" {
+		// PlainAuth refuses to send the password over an unencrypted remote connection
+		if err := client.Auth(smtp.PlainAuth("", t.config.Username, t.config.Password, t.config.Host)); err != nil {
+			return err
+		}
+	}
+	if err := client.Mail(from.Address); err != nil {
+		return err
+	}
+	if err := client.Rcpt(to.Address); err != nil {
+		return err
+	}
+	w, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(data); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return client.Quit()
+}
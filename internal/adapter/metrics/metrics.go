@@ -0,0 +1,171 @@
+// Copyright (c) 2026 Muhammet Ali Büyük. All rights reserved.
+// This source code is proprietary. Confidential and private.
+// Unauthorized copying or distribution is strictly prohibited.
+// Contact: iletisim@alibuyuk.net | Website: alibuyuk.net
+
+package metrics
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"runtime"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/qantesm/subflow/internal/core/entity"
+	"github.com/qantesm/subflow/internal/core/service"
+)
+
+// seenEvents is how many event IDs are remembered to skip redeliveries
+const seenEvents = 4096
+
+// Metrics are the application's metrics
+type Metrics struct {
+	registry     *Registry
+	httpDuration *HistogramVec
+	jobDuration  *HistogramVec
+	jobFailures  *CounterVec
+	transactions *CounterVec
+	amounts      *CounterVec
+	certified    *CounterVec
+
+	// The outbox delivers at least once; a redelivered event is counted once
+	mu      sync.Mutex
+	seen    map[uuid.UUID]struct{}
+	seenLog []uuid.UUID
+	next    int
+
+	architect string
+}
+
+// New creates the metrics; register the pools once they exist
+func New() *Metrics {
+	r := NewRegistry()
+	m := &Metrics{
+		registry: r,
+		httpDuration: r.NewHistogramVec("subflow_http_request_duration_seconds",
+			"Time to handle HTTP requests, by route pattern.", RequestBuckets, "method", "route", "status"),
+		jobDuration: r.NewHistogramVec("subflow_job_duration_seconds",
+			"Time background jobs ran, failed runs included.", JobBuckets, "job"),
+		jobFailures: r.NewCounterVec("subflow_job_failures_total",
+			"Background job runs that returned an error.", "job"),
+		transactions: r.NewCounterVec("subflow_transactions_recorded_total",
+			"Ledger transactions recorded.", "type", "currency"),
+		amounts: r.NewCounterVec("subflow_transaction_amount_cents_total",
+			"Sum of recorded transaction amounts in minor units.", "type", "currency"),
+		certified: r.NewCounterVec("subflow_pay_applications_certified_total",
+			"Pay applications certified.", "currency"),
+		seen:      make(map[uuid.UUID]struct{}, seenEvents),
+		seenLog:   make([]uuid.UUID, seenEvents),
+		architect: "Muhammet-Ali-Buyuk",
+	}
+	r.NewGaugeFunc("go_goroutines", "Number of goroutines that currently exist.", func() float64 {
+		return float64(runtime.NumGoroutine())
+	})
+	return m
+}
+
+// WriteTo writes every metric in the text exposition format
+func (m *Metrics) WriteTo(w io.Writer) (int64, error) {
+	return m.registry.WriteTo(w)
+}
+
+// ObserveRequest records a finished HTTP request. route is the pattern the
+// request matched, such as /api/v1/projects/:id, so IDs do not become labels.
+func (m *Metrics) ObserveRequest(method, route string, status int, duration time.Duration) {
+	m.httpDuration.Observe(duration.Seconds(), method, route, strconv.Itoa(status))
+}
+
+// ObserveJob records a background job run
+func (m *Metrics) ObserveJob(result service.JobResult) {
+	kind := result.Kind
+	if kind == "" {
+		kind = result.JobID
+	}
+	m.jobDuration.Observe(result.Duration.Seconds(), kind)
+	if result.Error != nil {
+		m.jobFailures.Inc(kind)
+	}
+}
+
+// RegisterWorkerPool exposes the worker pool's queue
+func (m *Metrics) RegisterWorkerPool(pool *service.WorkerPool) {
+	m.registry.NewGaugeFunc("subflow_worker_queue_depth", "Jobs waiting for a worker.", func() float64 {
+		return float64(pool.QueueDepth())
+	})
+	m.registry.NewGaugeFunc("subflow_worker_queue_capacity", "Jobs that can wait before submitting blocks.", func() float64 {
+		return float64(pool.QueueCapacity())
+	})
+}
+
+// RegisterDBPool exposes the PostgreSQL connection pool statistics
+func (m *Metrics) RegisterDBPool(pool interface{ Stat() *pgxpool.Stat }) {
+	gauge := func(name, help string, fn func(*pgxpool.Stat) float64) {
+		m.registry.NewGaugeFunc(name, help, func() float64 { return fn(pool.Stat()) })
+	}
+	counter := func(name, help string, fn func(*pgxpool.Stat) float64) {
+		m.registry.NewCounterFunc(name, help, func() float64 { return fn(pool.Stat()) })
+	}
+
+	gauge("subflow_db_pool_acquired_connections", "Connections checked out of the pool.",
+		func(s *pgxpool.Stat) float64 { return float64(s.AcquiredConns()) })
+	gauge("subflow_db_pool_idle_connections", "Idle connections in the pool.",
+		func(s *pgxpool.Stat) float64 { return float64(s.IdleConns()) })
+	gauge("subflow_db_pool_total_connections", "Open connections, including those being opened.",
+		func(s *pgxpool.Stat) float64 { return float64(s.TotalConns()) })
+	gauge("subflow_db_pool_max_connections", "Largest size the pool may grow to.",
+		func(s *pgxpool.Stat) float64 { return float64(s.MaxConns()) })
+	counter("subflow_db_pool_acquires_total", "Connections acquired from the pool.",
+		func(s *pgxpool.Stat) float64 { return float64(s.AcquireCount()) })
+	counter("subflow_db_pool_acquire_seconds_total", "Time spent acquiring connections.",
+		func(s *pgxpool.Stat) float64 { return s.AcquireDuration().Seconds() })
+	counter("subflow_db_pool_empty_acquires_total", "Acquires that waited because no connection was idle.",
+		func(s *pgxpool.Stat) float64 { return float64(s.EmptyAcquireCount()) })
+	counter("subflow_db_pool_canceled_acquires_total", "Acquires cancelled by their context.",
+		func(s *pgxpool.Stat) float64 { return float64(s.CanceledAcquireCount()) })
+}
+
+// HandleEvent counts recorded transactions and certified pay applications;
+// subscribe it to the event bus for those two types
+func (m *Metrics) HandleEvent(ctx context.Context, event *entity.Event) error {
+	switch event.Type {
+	case entity.EventTransactionCreated:
+		var tx entity.Transaction
+		if err := json.Unmarshal(event.Data, &tx); err != nil {
+			return err
+		}
+		if m.firstDelivery(event.ID) {
+			m.transactions.Inc(string(tx.Type), tx.Currency)
+			m.amounts.Add(float64(tx.AmountCents), string(tx.Type), tx.Currency)
+		}
+	case entity.EventPayApplicationCertified:
+		var app entity.PayApplication
+		if err := json.Unmarshal(event.Data, &app); err != nil {
+			return err
+		}
+		if m.firstDelivery(event.ID) {
+			m.certified.Inc(app.Currency)
+		}
+	}
+	return nil
+}
+
+// firstDelivery reports whether the event ID was not among the recent ones
+func (m *Metrics) firstDelivery(id uuid.UUID) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.seen[id]; ok {
+		return false
+	}
+	if old := m.seenLog[m.next]; old != uuid.Nil {
+		delete(m.seen, old)
+	}
+	m.seenLog[m.next] = id
+	m.next = (m.next + 1) % len(m.seenLog)
+	m.seen[id] = struct{}{}
+	return true
+}
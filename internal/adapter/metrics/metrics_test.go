@@ -0,0 +1,127 @@
+// Copyright (c) 2026 Muhammet Ali Büyük. All rights reserved.
+// This source code is proprietary. Confidential and private.
+// Unauthorized copying or distribution is strictly prohibited.
+// Contact: iletisim@alibuyuk.net | Website: alibuyuk.net
+
+package metrics
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/qantesm/subflow/internal/core/entity"
+	"github.com/qantesm/subflow/internal/core/service"
+)
+
+func TestRegistryText(t *testing.T) {
+	r := NewRegistry()
+	requests := r.NewCounterVec("requests_total", "Requests.\nBy path.", "path")
+	requests.Inc(`/a"b`)
+	requests.Add(2, "/")
+	latency := r.NewHistogramVec("latency_seconds", "Latency.", []float64{0.1, 1})
+	latency.Observe(0.1)
+	latency.Observe(0.5)
+	latency.Observe(3)
+	r.NewGaugeFunc("answer", "The answer.", func() float64 { return 42 })
+
+	var b strings.Builder
+	if _, err := r.WriteTo(&b); err != nil {
+		t.Fatal(err)
+	}
+	want := `# HELP answer The answer.
+# TYPE answer gauge
+answer 42
+# HELP latency_seconds Latency.
+# TYPE latency_seconds histogram
+latency_seconds_bucket{le="0.1"} 1
+latency_seconds_bucket{le="1"} 2
+latency_seconds_bucket{le="+Inf"} 3
+latency_seconds_sum 3.6
+latency_seconds_count 3
+# HELP requests_total Requests.\nBy path.
+# TYPE requests_total counter
+requests_total{path="/"} 2
+requests_total{path="/a\"b"} 1
+`
+	if got := b.String(); got != want {
+		t.Errorf("WriteTo =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestRegistryRejectsMisuse(t *testing.T) {
+	r := NewRegistry()
+	c := r.NewCounterVec("c_total", "C.", "a", "b")
+	for name, fn := range map[string]func(){
+		"wrong label count": func() { c.Inc("only-one") },
+		"negative add":      func() { c.Add(-1, "a", "b") },
+		"duplicate name":    func() { r.NewCounterVec("c_total", "Again.") },
+		"unsorted buckets":  func() { r.NewHistogramVec("h", "H.", []float64{1, 0.5}) },
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s: no panic", name)
+				}
+			}()
+			fn()
+		}()
+	}
+}
+
+func TestMetrics(t *testing.T) {
+	m := New()
+	m.ObserveRequest("GET", "/api/v1/projects/:id", 200, 30*time.Millisecond)
+	m.ObserveRequest("GET", "/api/v1/projects/:id", 200, 2*time.Second)
+	m.ObserveJob(service.JobResult{JobID: "webhook-delivery-1", Kind: "webhook-delivery", Duration: time.Second, Error: errors.New("timeout")})
+	m.ObserveJob(service.JobResult{JobID: "outbox", Duration: time.Millisecond})
+
+	event := func(eventType entity.EventType, data any) *entity.Event {
+		e, err := entity.NewEvent(eventType, uuid.New(), data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return e
+	}
+	ctx := context.Background()
+	invoice := event(entity.EventTransactionCreated, &entity.Transaction{Type: entity.TransactionTypeInvoice, AmountCents: 150000, Currency: "TRY"})
+	for _, e := range []*entity.Event{
+		invoice,
+		invoice, // Redelivered by the outbox
+		event(entity.EventTransactionCreated, &entity.Transaction{Type: entity.TransactionTypePayment, AmountCents: 5000, Currency: "EUR"}),
+		event(entity.EventPayApplicationCertified, &entity.PayApplication{Currency: "TRY"}),
+		event(entity.EventPayApplicationSubmitted, &entity.PayApplication{Currency: "TRY"}),
+	} {
+		if err := m.HandleEvent(ctx, e); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var b strings.Builder
+	if _, err := m.WriteTo(&b); err != nil {
+		t.Fatal(err)
+	}
+	text := b.String()
+	for _, line := range []string{
+		`subflow_http_request_duration_seconds_bucket{method="GET",route="/api/v1/projects/:id",status="200",le="0.05"} 1`,
+		`subflow_http_request_duration_seconds_count{method="GET",route="/api/v1/projects/:id",status="200"} 2`,
+		`subflow_job_duration_seconds_count{job="webhook-delivery"} 1`,
+		`subflow_job_duration_seconds_count{job="outbox"} 1`,
+		`subflow_job_failures_total{job="webhook-delivery"} 1`,
+		`subflow_transactions_recorded_total{type="INVOICE",currency="TRY"} 1`,
+		`subflow_transactions_recorded_total{type="PAYMENT",currency="EUR"} 1`,
+		`subflow_transaction_amount_cents_total{type="INVOICE",currency="TRY"} 150000`,
+		`subflow_pay_applications_certified_total{currency="TRY"} 1`,
+		`# TYPE go_goroutines gauge`,
+	} {
+		if !strings.Contains(text, line+"\n") {
+			t.Errorf("scrape lacks %q", line)
+		}
+	}
+	if strings.Contains(text, `subflow_job_failures_total{job="outbox"}`) {
+		t.Error("successful job counted as a failure")
+	}
+}
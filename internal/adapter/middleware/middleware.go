@@ -10,6 +10,8 @@ import (
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
+	"github.com/qantesm/subflow/internal/pkg"
+	"github.com/rs/zerolog"
 )
 
 // RequestID middleware adds a unique request ID to each request
@@ -29,28 +31,96 @@ func RequestID() fiber.Handler {
 	}
 }
 
-// Logger middleware logs request details
-func Logger() fiber.Handler {
+// LoggerConfig configures the access log
+type LoggerConfig struct {
+	Logger zerolog.Logger
+	// SamplePaths are logged one request in SampleEvery while they succeed:
+	// health checks and probes. SampleEvery 0 leaves them out entirely.
+	SamplePaths []string
+	SampleEvery uint32
+}
+
+// Logger middleware writes a structured access log entry per request and puts
+// a logger tagged with the request ID in the context for services and
+// repositories. Mount it after RequestID and before recover.
+func Logger(cfg LoggerConfig) fiber.Handler {
+	sampled := make(map[string]bool, len(cfg.SamplePaths))
+	for _, path := range cfg.SamplePaths {
+		sampled[path] = true
+	}
+	sampler := &zerolog.BasicSampler{N: cfg.SampleEvery}
+
 	return func(c *fiber.Ctx) error {
 		start := time.Now()
 
-		// Process request
-		err := c.Next()
+		requestID, _ := c.Locals("requestID").(string)
+		reqLogger := cfg.Logger.With().Str("request_id", requestID).Logger()
+		c.Locals(pkg.LoggerKey, reqLogger)
 
-		// Calculate duration
-		duration := time.Since(start)
+		// Process request; errors are answered here so the entry has the final status
+		handleError(c, c.Next())
 
-		// Log format: [RequestID] STATUS METHOD PATH DURATION
-		requestID := c.Locals("requestID")
-		if requestID == nil {
-			requestID = "-"
+		status := c.Response().StatusCode()
+		if sampled[c.Path()] && status < fiber.StatusBadRequest {
+			if cfg.SampleEvery == 0 || !sampler.Sample(zerolog.InfoLevel) {
+				return nil
+			}
 		}
 
-		// You can integrate with zerolog here
-		// For now, we use the default logger
-		_ = duration // Use for logging
+		level := zerolog.InfoLevel
+		switch {
+		case status >= fiber.StatusInternalServerError:
+			level = zerolog.ErrorLevel
+		case status >= fiber.StatusBadRequest:
+			level = zerolog.WarnLevel
+		}
 
-		return err
+		entry := reqLogger.WithLevel(level).
+			Str("method", c.Method()).
+			Str("route", c.Route().Path).
+			Str("path", c.Path()).
+			Int("status", status).
+			Dur("latency_ms", time.Since(start)).
+			Str("ip", c.IP())
+		// A streamed body (live events) has no size yet; reading it would consume the stream
+		if !c.Response().IsBodyStream() {
+			entry = entry.Int("bytes", len(c.Response().Body()))
+		}
+		if tenantID, ok := c.Locals("tenantID").(uuid.UUID); ok {
+			entry = entry.Str("tenant_id", tenantID.String())
+		}
+		if userID, ok := c.Locals("userID").(uuid.UUID); ok {
+			entry = entry.Str("user_id", userID.String())
+		}
+		entry.Msg("request")
+		return nil
+	}
+}
+
+// RequestObserver records finished requests; route is the matched pattern
+type RequestObserver interface {
+	ObserveRequest(method, route string, status int, duration time.Duration)
+}
+
+// Metrics middleware reports each request's latency to observer. Mount it
+// after Logger so both see the status the error handler sets.
+func Metrics(observer RequestObserver) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		start := time.Now()
+		handleError(c, c.Next())
+		observer.ObserveRequest(c.Method(), c.Route().Path, c.Response().StatusCode(), time.Since(start))
+		return nil
+	}
+}
+
+// handleError answers err with the app's error handler, so middleware that
+// runs after the handler sees the final status
+func handleError(c *fiber.Ctx, err error) {
+	if err == nil {
+		return
+	}
+	if handlerErr := c.App().ErrorHandler(c, err); handlerErr != nil {
+		_ = c.SendStatus(fiber.StatusInternalServerError)
 	}
 }
 
@@ -0,0 +1,332 @@
+// Copyright (c) 2026 Muhammet Ali Büyük. All rights reserved.
+// This source code is proprietary. Confidential and private.
+// Unauthorized copying or distribution is strictly prohibited.
+// Contact: iletisim@alibuyuk.net | Website: alibuyuk.net
+
+package migrate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/qantesm/subflow/internal/adapter/repository"
+)
+
+// Errors reported by the runner and the startup check
+var (
+	ErrSchemaBehind     = errors.New("database schema is behind")
+	ErrChecksumMismatch = errors.New("applied migration was edited")
+	ErrUnknownVersion   = errors.New("applied migration is not in this build")
+	ErrNoDown           = errors.New("migration has no down section")
+	ErrNothingApplied   = errors.New("no migration to roll back")
+)
+
+// lockKey is the advisory lock held while migrating; any constant works as
+// long as nothing else in the database uses it
+const lockKey int64 = 0x73756266_6c6f77 // "subflow"
+
+const createTable = `
+CREATE TABLE IF NOT EXISTS schema_migrations (
+    version BIGINT PRIMARY KEY,
+    name TEXT NOT NULL,
+    checksum TEXT NOT NULL,
+    applied_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+)`
+
+// State is where a migration stands in the database
+type State string
+
+const (
+	StateApplied State = "applied"
+	StatePending State = "pending"
+	StateChanged State = "changed" // Applied, but the SQL in this build differs
+	StateUnknown State = "unknown" // Applied by another build; this one lacks the file
+)
+
+// Applied is a row of schema_migrations
+type Applied struct {
+	Version   int64
+	Name      string
+	Checksum  string
+	AppliedAt time.Time
+}
+
+// Status is one migration's state
+type Status struct {
+	Version   int64
+	Name      string
+	State     State
+	AppliedAt *time.Time
+}
+
+// Plan compares the build's migrations with the applied ones, by version
+func Plan(migrations []*Migration, applied []Applied) []Status {
+	byVersion := make(map[int64]Applied, len(applied))
+	for _, a := range applied {
+		byVersion[a.Version] = a
+	}
+
+	statuses := make([]Status, 0, len(migrations)+len(applied))
+	for _, m := range migrations {
+		s := Status{Version: m.Version, Name: m.Name, State: StatePending}
+		if a, ok := byVersion[m.Version]; ok {
+			delete(byVersion, m.Version)
+			at := a.AppliedAt
+			s.AppliedAt = &at
+			s.State = StateApplied
+			if a.Checksum != m.Checksum {
+				s.State = StateChanged
+			}
+		}
+		statuses = append(statuses, s)
+	}
+	for _, a := range byVersion {
+		at := a.AppliedAt
+		statuses = append(statuses, Status{Version: a.Version, Name: a.Name, State: StateUnknown, AppliedAt: &at})
+	}
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].Version < statuses[j].Version })
+	return statuses
+}
+
+// Verify reports edited migrations and migrations still to apply. Versions
+// applied by a newer build are allowed so that a release can be rolled back.
+func Verify(statuses []Status) error {
+	var changed, pending []int64
+	var current int64
+	for _, s := range statuses {
+		switch s.State {
+		case StateChanged:
+			changed = append(changed, s.Version)
+		case StatePending:
+			pending = append(pending, s.Version)
+		}
+		if s.State != StatePending && s.Version > current {
+			current = s.Version
+		}
+	}
+
+	var errs []error
+	if len(changed) > 0 {
+		errs = append(errs, fmt.Errorf("%w: versions %v", ErrChecksumMismatch, changed))
+	}
+	if len(pending) > 0 {
+		errs = append(errs, fmt.Errorf("%w: at version %d, %d pending up to %d; run `subflow migrate up`",
+			ErrSchemaBehind, current, len(pending), pending[len(pending)-1]))
+	}
+	return errors.Join(errs...)
+}
+
+// Migrator applies and rolls back migrations
+type Migrator struct {
+	pool       *repository.Pool
+	migrations []*Migration
+	architect  string
+}
+
+// New creates a migrator for the given migrations, as returned by Load
+func New(pool *repository.Pool, migrations []*Migration) *Migrator {
+	return &Migrator{
+		pool:       pool,
+		migrations: migrations,
+		architect:  "Muhammet-Ali-Buyuk",
+	}
+}
+
+// Status returns the state of every migration without changing the database
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	var exists bool
+	if err := m.pool.QueryRow(ctx, `SELECT to_regclass('schema_migrations') IS NOT NULL`).Scan(&exists); err != nil {
+		return nil, err
+	}
+	var applied []Applied
+	if exists {
+		var err error
+		if applied, err = readApplied(ctx, m.pool); err != nil {
+			return nil, err
+		}
+	}
+	return Plan(m.migrations, applied), nil
+}
+
+// Check returns an error when the database is not ready for this build
+func (m *Migrator) Check(ctx context.Context) error {
+	statuses, err := m.Status(ctx)
+	if err != nil {
+		return err
+	}
+	return Verify(statuses)
+}
+
+// Up applies the pending migrations in order, each in its own transaction,
+// and returns them. It refuses to run while an applied migration was edited.
+func (m *Migrator) Up(ctx context.Context) ([]*Migration, error) {
+	var done []*Migration
+	err := m.locked(ctx, func(conn *pgx.Conn) error {
+		if err := m.prepare(ctx, conn); err != nil {
+			return err
+		}
+		applied, err := readApplied(ctx, conn)
+		if err != nil {
+			return err
+		}
+		statuses := Plan(m.migrations, applied)
+		for _, s := range statuses {
+			if s.State == StateChanged {
+				return fmt.Errorf("%w: version %d %s", ErrChecksumMismatch, s.Version, s.Name)
+			}
+		}
+
+		for _, s := range statuses {
+			if s.State != StatePending {
+				continue
+			}
+			migration := m.find(s.Version)
+			if err := apply(ctx, conn, migration); err != nil {
+				return fmt.Errorf("migration %d %s: %w", migration.Version, migration.Name, err)
+			}
+			done = append(done, migration)
+		}
+		return nil
+	})
+	return done, err
+}
+
+// Down rolls back the most recently applied migration and returns it
+func (m *Migrator) Down(ctx context.Context) (*Migration, error) {
+	var migration *Migration
+	err := m.locked(ctx, func(conn *pgx.Conn) error {
+		if err := m.prepare(ctx, conn); err != nil {
+			return err
+		}
+		applied, err := readApplied(ctx, conn)
+		if err != nil {
+			return err
+		}
+		if len(applied) == 0 {
+			return ErrNothingApplied
+		}
+		last := applied[len(applied)-1]
+		if migration = m.find(last.Version); migration == nil {
+			return fmt.Errorf("%w: version %d %s", ErrUnknownVersion, last.Version, last.Name)
+		}
+		if migration.Down == "" {
+			return fmt.Errorf("%w: version %d %s", ErrNoDown, migration.Version, migration.Name)
+		}
+
+		return pgx.BeginFunc(ctx, conn, func(tx pgx.Tx) error {
+			if _, err := tx.Exec(ctx, migration.Down); err != nil {
+				return fmt.Errorf("migration %d %s down: %w", migration.Version, migration.Name, err)
+			}
+			_, err := tx.Exec(ctx, `DELETE FROM schema_migrations WHERE version = $1`, migration.Version)
+			return err
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return migration, nil
+}
+
+// locked runs fn on one connection holding the migration advisory lock; a
+// second runner waits and then finds nothing left to apply
+func (m *Migrator) locked(ctx context.Context, fn func(conn *pgx.Conn) error) error {
+	conn, err := m.pool.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Release()
+
+	if _, err := conn.Exec(ctx, `SELECT pg_advisory_lock($1)`, lockKey); err != nil {
+		return fmt.Errorf("acquire migration lock: %w", err)
+	}
+	defer func() {
+		// The session lock must go even when ctx was cancelled mid-run
+		unlockCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if _, err := conn.Exec(unlockCtx, `SELECT pg_advisory_unlock($1)`, lockKey); err != nil {
+			conn.Conn().Close(unlockCtx) // Closing the session releases the lock
+		}
+	}()
+
+	return fn(conn.Conn())
+}
+
+// prepare creates schema_migrations. A database goose migrated before gets
+// its applied versions recorded with this build's checksums.
+func (m *Migrator) prepare(ctx context.Context, conn *pgx.Conn) error {
+	var existed bool
+	if err := conn.QueryRow(ctx, `SELECT to_regclass('schema_migrations') IS NOT NULL`).Scan(&existed); err != nil {
+		return err
+	}
+	if _, err := conn.Exec(ctx, createTable); err != nil {
+		return err
+	}
+	if existed {
+		return nil
+	}
+
+	var goose bool
+	if err := conn.QueryRow(ctx, `SELECT to_regclass('goose_db_version') IS NOT NULL`).Scan(&goose); err != nil || !goose {
+		return err
+	}
+	// goose appends a row per up and down; a version is applied when its latest row says so
+	rows, err := conn.Query(ctx, `
+		SELECT version_id FROM goose_db_version
+		WHERE version_id > 0
+		GROUP BY version_id
+		HAVING (ARRAY_AGG(is_applied ORDER BY id DESC))[1]`)
+	if err != nil {
+		return err
+	}
+	versions, err := pgx.CollectRows(rows, pgx.RowTo[int64])
+	if err != nil {
+		return err
+	}
+	for _, version := range versions {
+		if migration := m.find(version); migration != nil {
+			if _, err := conn.Exec(ctx, `INSERT INTO schema_migrations (version, name, checksum) VALUES ($1, $2, $3)`,
+				migration.Version, migration.Name, migration.Checksum); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (m *Migrator) find(version int64) *Migration {
+	for _, migration := range m.migrations {
+		if migration.Version == version {
+			return migration
+		}
+	}
+	return nil
+}
+
+// apply runs a migration and records it in one transaction
+func apply(ctx context.Context, conn *pgx.Conn, migration *Migration) error {
+	return pgx.BeginFunc(ctx, conn, func(tx pgx.Tx) error {
+		if _, err := tx.Exec(ctx, migration.Up); err != nil {
+			return err
+		}
+		_, err := tx.Exec(ctx, `INSERT INTO schema_migrations (version, name, checksum) VALUES ($1, $2, $3)`,
+			migration.Version, migration.Name, migration.Checksum)
+		return err
+	})
+}
+
+// readApplied returns the applied migrations, oldest version first
+func readApplied(ctx context.Context, q repository.DBTX) ([]Applied, error) {
+	rows, err := q.Query(ctx, `SELECT version, name, checksum, applied_at FROM schema_migrations ORDER BY version`)
+	if err != nil {
+		return nil, err
+	}
+	return pgx.CollectRows(rows, func(row pgx.CollectableRow) (Applied, error) {
+		var a Applied
+		err := row.Scan(&a.Version, &a.Name, &a.Checksum, &a.AppliedAt)
+		return a, err
+	})
+}
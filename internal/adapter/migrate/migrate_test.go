@@ -0,0 +1,122 @@
+// Copyright (c) 2026 Muhammet Ali Büyük. All rights reserved.
+// This source code is proprietary. Confidential and private.
+// Unauthorized copying or distribution is strictly prohibited.
+// Contact: iletisim@alibuyuk.net | Website: alibuyuk.net
+
+package migrate
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/qantesm/subflow/migrations"
+)
+
+func TestLoad(t *testing.T) {
+	fsys := fstest.MapFS{
+		"000002_second.sql": {Data: []byte("-- Migration: 000002_second\n\n-- +goose Up\nCREATE TABLE b (id INT);\n\n-- +goose Down\nDROP TABLE b;\n")},
+		"000001_first.sql":  {Data: []byte("-- +goose Up\r\nCREATE TABLE a (id INT);\r\n")},
+		"README.md":         {Data: []byte("not a migration")},
+		"seed.sql":          {Data: []byte("INSERT INTO a VALUES (1);")},
+	}
+	loaded, err := Load(fsys)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(loaded) != 2 || loaded[0].Version != 1 || loaded[1].Version != 2 {
+		t.Fatalf("Load = %+v, want versions 1 and 2 in order", loaded)
+	}
+	first, second := loaded[0], loaded[1]
+	if first.Name != "first" || first.Up != "CREATE TABLE a (id INT);" || first.Down != "" {
+		t.Errorf("first = %+v", first)
+	}
+	if second.Up != "CREATE TABLE b (id INT);" || second.Down != "DROP TABLE b;" {
+		t.Errorf("second sections = %q / %q", second.Up, second.Down)
+	}
+
+	// Only the Up section counts: header and Down edits keep the checksum
+	edited := fstest.MapFS{"000002_second.sql": {Data: []byte("-- Reworded header\n-- +goose Up\nCREATE TABLE b (id INT);\n-- +goose Down\nDROP TABLE IF EXISTS b;\n")}}
+	again, err := Load(edited)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if again[0].Checksum != second.Checksum {
+		t.Error("checksum changed without a change to the Up section")
+	}
+	edited["000002_second.sql"] = &fstest.MapFile{Data: []byte("-- +goose Up\nCREATE TABLE b (id BIGINT);\n")}
+	if again, _ = Load(edited); again[0].Checksum == second.Checksum {
+		t.Error("checksum kept after the Up section changed")
+	}
+
+	for name, files := range map[string]fstest.MapFS{
+		"no up section":     {"000001_a.sql": {Data: []byte("CREATE TABLE a (id INT);")}},
+		"empty up section":  {"000001_a.sql": {Data: []byte("-- +goose Up\n-- +goose Down\nDROP TABLE a;")}},
+		"duplicate version": {"000001_a.sql": {Data: []byte("-- +goose Up\nSELECT 1;")}, "1_b.sql": {Data: []byte("-- +goose Up\nSELECT 1;")}},
+	} {
+		if _, err := Load(files); err == nil {
+			t.Errorf("%s: Load accepted it", name)
+		}
+	}
+}
+
+func TestEmbeddedMigrations(t *testing.T) {
+	loaded, err := Load(migrations.FS)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(loaded) == 0 {
+		t.Fatal("no migrations embedded")
+	}
+	for i, m := range loaded {
+		if m.Version != int64(i+1) {
+			t.Errorf("version %d %s out of sequence, want %d", m.Version, m.Name, i+1)
+		}
+		if m.Down == "" {
+			t.Errorf("version %d %s has no down section", m.Version, m.Name)
+		}
+	}
+}
+
+func TestPlanAndVerify(t *testing.T) {
+	build := []*Migration{
+		{Version: 1, Name: "init", Checksum: "c1"},
+		{Version: 2, Name: "ledger", Checksum: "c2"},
+		{Version: 3, Name: "outbox", Checksum: "c3"},
+	}
+	at := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	statuses := Plan(build, []Applied{{Version: 1, Checksum: "c1", AppliedAt: at}})
+	if statuses[0].State != StateApplied || statuses[1].State != StatePending || statuses[2].State != StatePending {
+		t.Fatalf("Plan = %+v", statuses)
+	}
+	err := Verify(statuses)
+	if !errors.Is(err, ErrSchemaBehind) || !strings.Contains(err.Error(), "at version 1, 2 pending up to 3") {
+		t.Errorf("Verify behind = %v", err)
+	}
+
+	applied := []Applied{
+		{Version: 1, Checksum: "c1", AppliedAt: at},
+		{Version: 2, Checksum: "edited", AppliedAt: at},
+		{Version: 3, Checksum: "c3", AppliedAt: at},
+	}
+	statuses = Plan(build, applied)
+	if statuses[1].State != StateChanged {
+		t.Errorf("edited migration state = %s", statuses[1].State)
+	}
+	if err := Verify(statuses); !errors.Is(err, ErrChecksumMismatch) || errors.Is(err, ErrSchemaBehind) {
+		t.Errorf("Verify edited = %v", err)
+	}
+
+	// A newer build's migration does not stop an older build from starting
+	applied[1].Checksum = "c2"
+	statuses = Plan(build, append(applied, Applied{Version: 4, Name: "newer", Checksum: "c4", AppliedAt: at}))
+	if len(statuses) != 4 || statuses[3].State != StateUnknown || statuses[3].Name != "newer" {
+		t.Fatalf("Plan with a newer version = %+v", statuses)
+	}
+	if err := Verify(statuses); err != nil {
+		t.Errorf("Verify rolled back release = %v", err)
+	}
+}
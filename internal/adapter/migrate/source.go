@@ -0,0 +1,110 @@
+// Copyright (c) 2026 Muhammet Ali Büyük. All rights reserved.
+// This source code is proprietary. Confidential and private.
+// Unauthorized copying or distribution is strictly prohibited.
+// Contact: iletisim@alibuyuk.net | Website: alibuyuk.net
+
+// Package migrate applies the embedded SQL migrations to PostgreSQL. Applied
+// versions are recorded in schema_migrations with the checksum of the SQL
+// that ran, so a migration edited after it was applied is detected, and an
+// advisory lock keeps concurrent runs from applying the same migration twice.
+package migrate
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Section markers, compatible with the goose files the migrations started as
+const (
+	upMarker   = "-- +goose Up"
+	downMarker = "-- +goose Down"
+)
+
+var fileName = regexp.MustCompile(`^([0-9]+)_([a-z0-9_]+)\.sql$`)
+
+// Migration is one versioned schema change
+type Migration struct {
+	Version  int64
+	Name     string
+	Up       string
+	Down     string // Empty when the migration cannot be rolled back
+	Checksum string // SHA-256 of the Up section
+}
+
+// Load reads the migrations in the root of fsys, ordered by version. Other
+// files are ignored; a numbered file without an Up section or a version used
+// twice is an error.
+func Load(fsys fs.FS) ([]*Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, err
+	}
+
+	var migrations []*Migration
+	seen := make(map[int64]string)
+	for _, entry := range entries {
+		match := fileName.FindStringSubmatch(entry.Name())
+		if entry.IsDir() || match == nil {
+			continue
+		}
+		version, err := strconv.ParseInt(match[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", entry.Name(), err)
+		}
+		if other, ok := seen[version]; ok {
+			return nil, fmt.Errorf("%s: version %d is also used by %s", entry.Name(), version, other)
+		}
+		seen[version] = entry.Name()
+
+		data, err := fs.ReadFile(fsys, entry.Name())
+		if err != nil {
+			return nil, err
+		}
+		m, err := parse(version, match[2], string(data))
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", entry.Name(), err)
+		}
+		migrations = append(migrations, m)
+	}
+
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	return migrations, nil
+}
+
+// parse splits a file into its Up and Down sections; text before the Up
+// marker is the file header and is not part of either
+func parse(version int64, name, text string) (*Migration, error) {
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	up := strings.Index(text, upMarker)
+	if up < 0 {
+		return nil, fmt.Errorf("no %q section", upMarker)
+	}
+	body := text[up+len(upMarker):]
+
+	var down string
+	if i := strings.Index(body, downMarker); i >= 0 {
+		body, down = body[:i], body[i+len(downMarker):]
+	}
+	if strings.Contains(down, upMarker) {
+		return nil, fmt.Errorf("%q after %q", upMarker, downMarker)
+	}
+	body, down = strings.TrimSpace(body), strings.TrimSpace(down)
+	if body == "" {
+		return nil, fmt.Errorf("empty %q section", upMarker)
+	}
+
+	sum := sha256.Sum256([]byte(body))
+	return &Migration{
+		Version:  version,
+		Name:     name,
+		Up:       body,
+		Down:     down,
+		Checksum: hex.EncodeToString(sum[:]),
+	}, nil
+}
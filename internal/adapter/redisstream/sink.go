@@ -0,0 +1,231 @@
+// Copyright (c) 2026 Muhammet Ali Büyük. All rights reserved.
+// This source code is proprietary. Confidential and private.
+// Unauthorized copying or distribution is strictly prohibited.
+// Contact: iletisim@alibuyuk.net | Website: alibuyuk.net
+
+// Package redisstream publishes domain events to a Redis stream with XADD.
+// It speaks just enough RESP for AUTH, SELECT and XADD over a single connection.
+package redisstream
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/qantesm/subflow/internal/core/entity"
+)
+
+const (
+	DefaultStream  = "subflow:events"
+	DefaultTimeout = 5 * time.Second
+)
+
+// Config holds the Redis connection and stream settings
+type Config struct {
+	Addr     string // host:port
+	Password string // Empty skips AUTH
+	DB       int    // Zero skips SELECT
+	Stream   string
+	MaxLen   int64         // Approximate stream length kept with MAXLEN ~, zero keeps every entry
+	Timeout  time.Duration // Dial and per-command limit when the context has no deadline
+}
+
+// Error is an error reply from Redis; the connection stays usable
+type Error string
+
+func (e Error) Error() string {
+	return "redis: " + string(e)
+}
+
+// Sink appends every event to the stream as one entry with the fields
+// event_id, type, project_id, occurred_at and data. Consumers dedupe on
+// event_id since the outbox delivers at least once.
+type Sink struct {
+	cfg  Config
+	mu   sync.Mutex
+	conn net.Conn
+	rd   *bufio.Reader
+}
+
+// NewSink creates a sink; the connection is opened on the first publish
+func NewSink(cfg Config) *Sink {
+	if cfg.Stream == "" {
+		cfg.Stream = DefaultStream
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = DefaultTimeout
+	}
+	return &Sink{cfg: cfg}
+}
+
+// Publish appends the event to the stream.
+// A broken connection is dropped and dialled again.
+func (s *Sink) Publish(ctx context.Context, event *entity.Event) error {
+	args := []string{"XADD", s.cfg.Stream}
+	if s.cfg.MaxLen > 0 {
+		args = append(args, "MAXLEN", "~", strconv.FormatInt(s.cfg.MaxLen, 10))
+	}
+	args = append(args, "*",
+		"event_id", event.ID.String(),
+		"type", string(event.Type),
+		"project_id", event.ProjectID.String(),
+		"occurred_at", event.OccurredAt.UTC().Format(time.RFC3339Nano),
+		"data", string(event.Data),
+	)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	reused := s.conn != nil
+	if err := s.connect(ctx); err != nil {
+		return err
+	}
+	_, err := s.do(ctx, args...)
+	if err != nil && reused && s.conn == nil {
+		// The server may have closed the idle connection; try once on a fresh one
+		if err = s.connect(ctx); err != nil {
+			return err
+		}
+		_, err = s.do(ctx, args...)
+	}
+	if err != nil {
+		return fmt.Errorf("xadd %s: %w", s.cfg.Stream, err)
+	}
+	return nil
+}
+
+// Close closes the connection
+func (s *Sink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.conn == nil {
+		return nil
+	}
+	err := s.conn.Close()
+	s.conn, s.rd = nil, nil
+	return err
+}
+
+// connect dials and authenticates unless a connection is open
+func (s *Sink) connect(ctx context.Context) error {
+	if s.conn != nil {
+		return nil
+	}
+
+	dialer := net.Dialer{Timeout: s.cfg.Timeout}
+	conn, err := dialer.DialContext(ctx, "tcp", s.cfg.Addr)
+	if err != nil {
+		return fmt.Errorf("redis dial %s: %w", s.cfg.Addr, err)
+	}
+	s.conn, s.rd = conn, bufio.NewReader(conn)
+
+	if s.cfg.Password != "" {
+		if _, err := s.do(ctx, "AUTH", s.cfg.Password); err != nil {
+			s.drop()
+			return fmt.Errorf("redis auth: %w", err)
+		}
+	}
+	if s.cfg.DB != 0 {
+		if _, err := s.do(ctx, "SELECT", strconv.Itoa(s.cfg.DB)); err != nil {
+			s.drop()
+			return fmt.Errorf("redis select %d: %w", s.cfg.DB, err)
+		}
+	}
+	return nil
+}
+
+// do sends a command and reads its reply. Anything but an error reply from
+// Redis leaves the stream of replies in an unknown state, so the connection is dropped.
+func (s *Sink) do(ctx context.Context, args ...string) (string, error) {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = time.Now().Add(s.cfg.Timeout)
+	}
+	if err := s.conn.SetDeadline(deadline); err != nil {
+		s.drop()
+		return "", err
+	}
+
+	if _, err := s.conn.Write(encodeCommand(args)); err != nil {
+		s.drop()
+		return "", err
+	}
+	reply, err := readReply(s.rd)
+	var redisErr Error
+	if err != nil && !errors.As(err, &redisErr) {
+		s.drop()
+	}
+	return reply, err
+}
+
+func (s *Sink) drop() {
+	_ = s.conn.Close()
+	s.conn, s.rd = nil, nil
+}
+
+// encodeCommand writes args as a RESP array of bulk strings
+func encodeCommand(args []string) []byte {
+	buf := make([]byte, 0, 64)
+	buf = append(buf, '*')
+	buf = strconv.AppendInt(buf, int64(len(args)), 10)
+	buf = append(buf, '\r', '\n')
+	for _, arg := range args {
+		buf = append(buf, '$')
+		buf = strconv.AppendInt(buf, int64(len(arg)), 10)
+		buf = append(buf, '\r', '\n')
+		buf = append(buf, arg...)
+		buf = append(buf, '\r', '\n')
+	}
+	return buf
+}
+
+// readReply reads a simple string, error, integer or bulk string reply
+func readReply(rd *bufio.Reader) (string, error) {
+	line, err := readLine(rd)
+	if err != nil {
+		return "", err
+	}
+	if line == "" {
+		return "", errors.New("redis: empty reply")
+	}
+
+	switch line[0] {
+	case '+', ':':
+		return line[1:], nil
+	case '-':
+		return "", Error(line[1:])
+	case '$':
+		n, err := strconv.Atoi(line[1:])
+		if err != nil {
+			return "", fmt.Errorf("redis: bad bulk length %q", line)
+		}
+		if n < 0 {
+			return "", nil // Nil reply
+		}
+		buf := make([]byte, n+2)
+		if _, err := io.ReadFull(rd, buf); err != nil {
+			return "", err
+		}
+		return string(buf[:n]), nil
+	default:
+		return "", fmt.Errorf("redis: unexpected reply %q", line)
+	}
+}
+
+func readLine(rd *bufio.Reader) (string, error) {
+	line, err := rd.ReadString('\n')
+	if err != nil {
+		return "", err
+	}
+	if len(line) < 2 || line[len(line)-2] != '\r' {
+		return "", fmt.Errorf("redis: malformed line %q", line)
+	}
+	return line[:len(line)-2], nil
+}
@@ -0,0 +1,163 @@
+// Copyright (c) 2026 Muhammet Ali Büyük. All rights reserved.
+// This source code is proprietary. Confidential and private.
+// Unauthorized copying or distribution is strictly prohibited.
+// Contact: iletisim@alibuyuk.net | Website: alibuyuk.net
+
+package redisstream
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/qantesm/subflow/internal/core/entity"
+)
+
+// fakeRedis records the commands it receives and answers them like Redis would.
+// XADD fails with an error reply while failXAdd is set; the connection is
+// closed after every XADD when hangUp is set.
+type fakeRedis struct {
+	ln       net.Listener
+	mu       sync.Mutex
+	commands [][]string
+	conns    int
+	failXAdd bool
+	hangUp   bool
+}
+
+func newFakeRedis(t *testing.T) *fakeRedis {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	f := &fakeRedis{ln: ln}
+	go f.serve()
+	t.Cleanup(func() { _ = ln.Close() })
+	return f
+}
+
+func (f *fakeRedis) serve() {
+	for {
+		conn, err := f.ln.Accept()
+		if err != nil {
+			return
+		}
+		f.mu.Lock()
+		f.conns++
+		f.mu.Unlock()
+		go f.handle(conn)
+	}
+}
+
+func (f *fakeRedis) handle(conn net.Conn) {
+	defer conn.Close()
+	rd := bufio.NewReader(conn)
+	for {
+		args, err := readCommand(rd)
+		if err != nil {
+			return
+		}
+		f.mu.Lock()
+		f.commands = append(f.commands, args)
+		failXAdd, hangUp := f.failXAdd, f.hangUp
+		f.mu.Unlock()
+
+		reply := "+OK\r\n"
+		if args[0] == "XADD" {
+			reply = "$15\r\n1700000000000-0\r\n"
+			if failXAdd {
+				reply = "-WRONGTYPE Operation against a key holding the wrong kind of value\r\n"
+			}
+		}
+		if _, err := conn.Write([]byte(reply)); err != nil || (hangUp && args[0] == "XADD") {
+			return
+		}
+	}
+}
+
+func (f *fakeRedis) last() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.commands[len(f.commands)-1]
+}
+
+func readCommand(rd *bufio.Reader) ([]string, error) {
+	line, err := readLine(rd)
+	if err != nil {
+		return nil, err
+	}
+	n, err := strconv.Atoi(strings.TrimPrefix(line, "*"))
+	if err != nil {
+		return nil, err
+	}
+	args := make([]string, n)
+	for i := range args {
+		if args[i], err = readReply(rd); err != nil {
+			return nil, err
+		}
+	}
+	return args, nil
+}
+
+func TestSinkPublish(t *testing.T) {
+	ctx := context.Background()
+	redis := newFakeRedis(t)
+	sink := NewSink(Config{Addr: redis.ln.Addr().String(), Password: "s3cret", DB: 2, MaxLen: 10000})
+	defer sink.Close()
+
+	event, _ := entity.NewEvent(entity.EventTransactionCreated, uuid.New(), map[string]any{"amount_cents": 150000, "note": "line\r\nbreak"})
+	if err := sink.Publish(ctx, event); err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+
+	redis.mu.Lock()
+	commands := redis.commands
+	redis.mu.Unlock()
+	if len(commands) != 3 || commands[0][0] != "AUTH" || commands[0][1] != "s3cret" || commands[1][0] != "SELECT" || commands[1][1] != "2" {
+		t.Fatalf("commands = %q", commands)
+	}
+	xadd := commands[2]
+	want := []string{"XADD", DefaultStream, "MAXLEN", "~", "10000", "*", "event_id", event.ID.String(), "type", "transaction.created"}
+	for i, w := range want {
+		if xadd[i] != w {
+			t.Fatalf("XADD = %q, want prefix %q", xadd, want)
+		}
+	}
+	if xadd[len(xadd)-2] != "data" || xadd[len(xadd)-1] != string(event.Data) {
+		t.Errorf("data field = %q", xadd[len(xadd)-2:])
+	}
+
+	// An error reply is returned and the connection is kept
+	redis.mu.Lock()
+	redis.failXAdd = true
+	redis.mu.Unlock()
+	var redisErr Error
+	if err := sink.Publish(ctx, event); !errors.As(err, &redisErr) || !strings.HasPrefix(string(redisErr), "WRONGTYPE") {
+		t.Fatalf("Publish() to a wrong key error = %v", err)
+	}
+
+	// A connection closed by the server is dialled again
+	redis.mu.Lock()
+	redis.failXAdd, redis.hangUp = false, true
+	redis.mu.Unlock()
+	for i := 0; i < 2; i++ {
+		if err := sink.Publish(ctx, event); err != nil {
+			t.Fatalf("Publish() %d after hang-up error = %v", i, err)
+		}
+	}
+	redis.mu.Lock()
+	conns := redis.conns
+	redis.mu.Unlock()
+	if conns != 2 {
+		t.Errorf("connections = %d, want 2", conns)
+	}
+	if cmd := redis.last(); cmd[0] != "XADD" {
+		t.Errorf("last command = %q", cmd)
+	}
+}
@@ -0,0 +1,48 @@
+// Copyright (c) 2026 Muhammet Ali Büyük. All rights reserved.
+// This source code is proprietary. Confidential and private.
+// Unauthorized copying or distribution is strictly prohibited.
+// Contact: iletisim@alibuyuk.net | Website: alibuyuk.net
+
+package repository
+
+import (
+	"context"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/qantesm/subflow/internal/core/entity"
+)
+
+// InMemoryAdvanceRepository keeps advance recovery rules in memory
+type InMemoryAdvanceRepository struct {
+	mu    sync.RWMutex
+	rules map[uuid.UUID]*entity.AdvanceRecoveryRule // By project
+}
+
+// NewInMemoryAdvanceRepository creates a new in-memory advance repository
+func NewInMemoryAdvanceRepository() *InMemoryAdvanceRepository {
+	return &InMemoryAdvanceRepository{
+		rules: make(map[uuid.UUID]*entity.AdvanceRecoveryRule),
+	}
+}
+
+// SaveRule stores the recovery rule of a project, replacing any previous rule
+func (r *InMemoryAdvanceRepository) SaveRule(ctx context.Context, rule *entity.AdvanceRecoveryRule) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.rules[rule.ProjectID] = rule
+	return nil
+}
+
+// FindRule returns the recovery rule of a project
+func (r *InMemoryAdvanceRepository) FindRule(ctx context.Context, projectID uuid.UUID) (*entity.AdvanceRecoveryRule, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	rule, ok := r.rules[projectID]
+	if !ok {
+		return nil, entity.ErrAdvanceRuleNotFound
+	}
+	return rule, nil
+}
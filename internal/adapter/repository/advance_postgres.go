@@ -0,0 +1,77 @@
+// Copyright (c) 2026 Muhammet Ali Büyük. All rights reserved.
+// This source code is proprietary. Confidential and private.
+// Unauthorized copying or distribution is strictly prohibited.
+// Contact: iletisim@alibuyuk.net | Website: alibuyuk.net
+
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/qantesm/subflow/internal/core/entity"
+)
+
+// PostgresAdvanceRepository implements AdvanceRepository for PostgreSQL
+type PostgresAdvanceRepository struct {
+	pool      *Pool
+	architect string
+}
+
+// NewPostgresAdvanceRepository creates a new PostgreSQL advance repository
+func NewPostgresAdvanceRepository(pool *Pool) *PostgresAdvanceRepository {
+	return &PostgresAdvanceRepository{
+		pool:      pool,
+		architect: "Muhammet-Ali-Buyuk",
+	}
+}
+
+// SaveRule stores the recovery rule of a project, replacing any previous rule
+func (r *PostgresAdvanceRepository) SaveRule(ctx context.Context, rule *entity.AdvanceRecoveryRule) error {
+	_, err := r.pool.Exec(ctx, `
+		INSERT INTO advance_recovery_rules (project_id, method, rate_bp, start_percent_bp, end_percent_bp, updated_at, updated_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (project_id) DO UPDATE SET
+			method = EXCLUDED.method,
+			rate_bp = EXCLUDED.rate_bp,
+			start_percent_bp = EXCLUDED.start_percent_bp,
+			end_percent_bp = EXCLUDED.end_percent_bp,
+			updated_at = EXCLUDED.updated_at,
+			updated_by = EXCLUDED.updated_by
+	`,
+		rule.ProjectID,
+		rule.Method,
+		rule.Rate,
+		rule.StartPercent,
+		rule.EndPercent,
+		rule.UpdatedAt,
+		rule.UpdatedBy,
+	)
+	return err
+}
+
+// FindRule returns the recovery rule of a project
+func (r *PostgresAdvanceRepository) FindRule(ctx context.Context, projectID uuid.UUID) (*entity.AdvanceRecoveryRule, error) {
+	rule := &entity.AdvanceRecoveryRule{}
+	err := r.pool.QueryRow(ctx, `
+		SELECT project_id, method, rate_bp, start_percent_bp, end_percent_bp, updated_at, updated_by
+		FROM advance_recovery_rules
+		WHERE project_id = $1
+	`, projectID).Scan(
+		&rule.ProjectID,
+		&rule.Method,
+		&rule.Rate,
+		&rule.StartPercent,
+		&rule.EndPercent,
+		&rule.UpdatedAt,
+		&rule.UpdatedBy,
+	)
+	if err == pgx.ErrNoRows {
+		return nil, entity.ErrAdvanceRuleNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return rule, nil
+}
@@ -0,0 +1,76 @@
+// Copyright (c) 2026 Muhammet Ali Büyük. All rights reserved.
+// This source code is proprietary. Confidential and private.
+// Unauthorized copying or distribution is strictly prohibited.
+// Contact: iletisim@alibuyuk.net | Website: alibuyuk.net
+
+package repository
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/qantesm/subflow/internal/core/entity"
+	"github.com/qantesm/subflow/internal/core/service"
+)
+
+// InMemoryAuditRepository keeps the audit trail in memory
+type InMemoryAuditRepository struct {
+	mu   sync.RWMutex
+	logs []*entity.AuditLog
+}
+
+// NewInMemoryAuditRepository creates a new in-memory audit repository
+func NewInMemoryAuditRepository() *InMemoryAuditRepository {
+	return &InMemoryAuditRepository{}
+}
+
+// Save appends an audit entry
+func (r *InMemoryAuditRepository) Save(ctx context.Context, log *entity.AuditLog) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	copied := *log
+	r.logs = append(r.logs, &copied)
+	return nil
+}
+
+// Find returns the entries matching the filter, newest first
+func (r *InMemoryAuditRepository) Find(ctx context.Context, filter service.AuditFilter) ([]*entity.AuditLog, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var logs []*entity.AuditLog
+	for _, l := range r.logs {
+		if matchesAuditFilter(l, filter) {
+			copied := *l
+			logs = append(logs, &copied)
+		}
+	}
+	sort.SliceStable(logs, func(i, j int) bool {
+		return logs[i].CreatedAt.After(logs[j].CreatedAt)
+	})
+
+	if filter.Offset >= len(logs) {
+		return []*entity.AuditLog{}, nil
+	}
+	logs = logs[filter.Offset:]
+	if filter.Limit > 0 && len(logs) > filter.Limit {
+		logs = logs[:filter.Limit]
+	}
+	return logs, nil
+}
+
+func matchesAuditFilter(l *entity.AuditLog, f service.AuditFilter) bool {
+	switch {
+	case l.TenantID != f.TenantID,
+		f.EntityType != "" && l.EntityType != f.EntityType,
+		f.Action != "" && l.Action != f.Action,
+		f.EntityID != nil && (l.EntityID == nil || *l.EntityID != *f.EntityID),
+		f.ActorID != nil && (l.ActorID == nil || *l.ActorID != *f.ActorID),
+		f.From != nil && l.CreatedAt.Before(*f.From),
+		f.To != nil && !l.CreatedAt.Before(*f.To):
+		return false
+	}
+	return true
+}
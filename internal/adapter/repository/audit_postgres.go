@@ -0,0 +1,127 @@
+// Copyright (c) 2026 Muhammet Ali Büyük. All rights reserved.
+// This source code is proprietary. Confidential and private.
+// Unauthorized copying or distribution is strictly prohibited.
+// Contact: iletisim@alibuyuk.net | Website: alibuyuk.net
+
+package repository
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/qantesm/subflow/internal/core/entity"
+	"github.com/qantesm/subflow/internal/core/service"
+)
+
+// PostgresAuditRepository implements AuditRepository for PostgreSQL
+type PostgresAuditRepository struct {
+	pool      *Pool
+	architect string
+}
+
+// NewPostgresAuditRepository creates a new PostgreSQL audit repository
+func NewPostgresAuditRepository(pool *Pool) *PostgresAuditRepository {
+	return &PostgresAuditRepository{
+		pool:      pool,
+		architect: "Muhammet-Ali-Buyuk",
+	}
+}
+
+const auditLogColumns = `
+	id, tenant_id, actor_id, COALESCE(actor_email, ''), action, entity_type, entity_id,
+	old_value, new_value, COALESCE(host(ip_address), ''), COALESCE(user_agent, ''), created_at
+`
+
+// Save appends an audit entry
+func (r *PostgresAuditRepository) Save(ctx context.Context, log *entity.AuditLog) error {
+	// Inside a unit of work the insert runs in a savepoint: a failed audit
+	// entry must not abort the transaction of the operation it records
+	return r.pool.WithTx(ctx, func(tx pgx.Tx) error {
+		return r.insert(ctx, tx, log)
+	})
+}
+
+func (r *PostgresAuditRepository) insert(ctx context.Context, db DBTX, log *entity.AuditLog) error {
+	_, err := db.Exec(ctx, `
+		INSERT INTO audit_logs (
+			id, tenant_id, actor_id, actor_email, action, entity_type, entity_id,
+			old_value, new_value, ip_address, user_agent, created_at
+		) VALUES ($1, $2, $3, NULLIF($4, ''), $5, $6, $7, $8, $9, NULLIF($10, '')::inet, NULLIF($11, ''), $12)
+	`,
+		log.ID,
+		log.TenantID,
+		log.ActorID,
+		log.ActorEmail,
+		log.Action,
+		log.EntityType,
+		log.EntityID,
+		nullableJSON(log.OldValue),
+		nullableJSON(log.NewValue),
+		log.IPAddress,
+		log.UserAgent,
+		log.CreatedAt,
+	)
+	return err
+}
+
+// Find returns the entries matching the filter, newest first
+func (r *PostgresAuditRepository) Find(ctx context.Context, filter service.AuditFilter) ([]*entity.AuditLog, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT `+auditLogColumns+`
+		FROM audit_logs
+		WHERE tenant_id = $1
+			AND ($2 = '' OR entity_type = $2)
+			AND ($3::uuid IS NULL OR entity_id = $3)
+			AND ($4::uuid IS NULL OR actor_id = $4)
+			AND ($5 = '' OR action = $5)
+			AND ($6::timestamptz IS NULL OR created_at >= $6)
+			AND ($7::timestamptz IS NULL OR created_at < $7)
+		ORDER BY created_at DESC, id
+		LIMIT $8 OFFSET $9
+	`,
+		filter.TenantID,
+		filter.EntityType,
+		filter.EntityID,
+		filter.ActorID,
+		filter.Action,
+		filter.From,
+		filter.To,
+		filter.Limit,
+		filter.Offset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	logs := []*entity.AuditLog{}
+	for rows.Next() {
+		l := &entity.AuditLog{}
+		if err := rows.Scan(
+			&l.ID,
+			&l.TenantID,
+			&l.ActorID,
+			&l.ActorEmail,
+			&l.Action,
+			&l.EntityType,
+			&l.EntityID,
+			&l.OldValue,
+			&l.NewValue,
+			&l.IPAddress,
+			&l.UserAgent,
+			&l.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		logs = append(logs, l)
+	}
+	return logs, rows.Err()
+}
+
+// nullableJSON stores an empty snapshot as NULL rather than invalid JSON
+func nullableJSON(raw []byte) []byte {
+	if len(raw) == 0 {
+		return nil
+	}
+	return raw
+}
@@ -0,0 +1,103 @@
+// Copyright (c) 2026 Muhammet Ali Büyük. All rights reserved.
+// This source code is proprietary. Confidential and private.
+// Unauthorized copying or distribution is strictly prohibited.
+// Contact: iletisim@alibuyuk.net | Website: alibuyuk.net
+
+package repository
+
+import (
+	"context"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/qantesm/subflow/internal/core/entity"
+)
+
+// InMemoryBankStatementRepository keeps imported statements in memory
+// Used for testing and development before PostgreSQL is set up
+type InMemoryBankStatementRepository struct {
+	mu         sync.RWMutex
+	statements map[uuid.UUID]*entity.BankStatement
+	lines      map[uuid.UUID]*entity.StatementLine
+}
+
+// NewInMemoryBankStatementRepository creates a new in-memory statement repository
+func NewInMemoryBankStatementRepository() *InMemoryBankStatementRepository {
+	return &InMemoryBankStatementRepository{
+		statements: make(map[uuid.UUID]*entity.BankStatement),
+		lines:      make(map[uuid.UUID]*entity.StatementLine),
+	}
+}
+
+// SaveStatement stores a statement and all of its lines
+func (r *InMemoryBankStatementRepository) SaveStatement(ctx context.Context, stmt *entity.BankStatement) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.statements[stmt.ID] = stmt
+	for _, line := range stmt.Lines {
+		r.lines[line.ID] = line
+	}
+	return nil
+}
+
+// FindStatementByID retrieves a statement with its lines
+func (r *InMemoryBankStatementRepository) FindStatementByID(ctx context.Context, id uuid.UUID) (*entity.BankStatement, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	stmt, exists := r.statements[id]
+	if !exists {
+		return nil, entity.ErrStatementNotFound
+	}
+	return stmt, nil
+}
+
+// FindLineByID retrieves a single statement line
+func (r *InMemoryBankStatementRepository) FindLineByID(ctx context.Context, id uuid.UUID) (*entity.StatementLine, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	line, exists := r.lines[id]
+	if !exists {
+		return nil, entity.ErrStatementLineNotFound
+	}
+	return line, nil
+}
+
+// LockLine retrieves a statement line; InMemoryTransactor provides the isolation
+func (r *InMemoryBankStatementRepository) LockLine(ctx context.Context, id uuid.UUID) (*entity.StatementLine, error) {
+	return r.FindLineByID(ctx, id)
+}
+
+// UpdateLine stores the reconciliation state of a line
+func (r *InMemoryBankStatementRepository) UpdateLine(ctx context.Context, line *entity.StatementLine) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existing, exists := r.lines[line.ID]
+	if !exists {
+		return entity.ErrStatementLineNotFound
+	}
+	*existing = *line
+	return nil
+}
+
+// FindMatchedTransactionIDs returns the ledger transactions already linked to a line
+func (r *InMemoryBankStatementRepository) FindMatchedTransactionIDs(ctx context.Context, projectID uuid.UUID) (map[uuid.UUID]bool, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	matched := make(map[uuid.UUID]bool)
+	for _, stmt := range r.statements {
+		if stmt.ProjectID != projectID {
+			continue
+		}
+		for _, line := range stmt.Lines {
+			if line.MatchedTransactionID != nil {
+				matched[*line.MatchedTransactionID] = true
+			}
+		}
+	}
+	return matched, nil
+}
@@ -0,0 +1,239 @@
+// Copyright (c) 2026 Muhammet Ali Büyük. All rights reserved.
+// This source code is proprietary. Confidential and private.
+// Unauthorized copying or distribution is strictly prohibited.
+// Contact: iletisim@alibuyuk.net | Website: alibuyuk.net
+
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/qantesm/subflow/internal/core/entity"
+)
+
+// PostgresBankStatementRepository implements BankStatementRepository for PostgreSQL
+type PostgresBankStatementRepository struct {
+	pool      *Pool
+	architect string
+}
+
+// NewPostgresBankStatementRepository creates a new PostgreSQL statement repository
+func NewPostgresBankStatementRepository(pool *Pool) *PostgresBankStatementRepository {
+	return &PostgresBankStatementRepository{
+		pool:      pool,
+		architect: "Muhammet-Ali-Buyuk",
+	}
+}
+
+const statementLineColumns = `
+	id, statement_id, line_no, booking_date, value_date, direction, amount_cents, currency,
+	reference, bank_reference, description, counterparty_name, counterparty_account,
+	status, matched_transaction_id, matched_at
+`
+
+// SaveStatement stores the statement header and its lines in one database transaction
+func (r *PostgresBankStatementRepository) SaveStatement(ctx context.Context, stmt *entity.BankStatement) error {
+	return r.pool.WithTx(ctx, func(dbTx pgx.Tx) error {
+		_, err := dbTx.Exec(ctx, `
+			INSERT INTO bank_statements (
+				id, project_id, format, account_id, statement_no, currency,
+				opening_balance_cents, closing_balance_cents, statement_date, imported_at, imported_by
+			) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		`,
+			stmt.ID,
+			stmt.ProjectID,
+			stmt.Format,
+			stmt.AccountID,
+			stmt.StatementNo,
+			stmt.Currency,
+			stmt.OpeningBalance,
+			stmt.ClosingBalance,
+			stmt.StatementDate,
+			stmt.ImportedAt,
+			stmt.ImportedBy,
+		)
+		if err != nil {
+			return err
+		}
+
+		for _, line := range stmt.Lines {
+			_, err := dbTx.Exec(ctx, `
+				INSERT INTO bank_statement_lines (`+statementLineColumns+`)
+				VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16)
+			`,
+				line.ID,
+				line.StatementID,
+				line.LineNo,
+				line.BookingDate,
+				line.ValueDate,
+				line.Direction,
+				line.AmountCents,
+				line.Currency,
+				line.Reference,
+				line.BankReference,
+				line.Description,
+				line.CounterpartyName,
+				line.CounterpartyAccount,
+				line.Status,
+				line.MatchedTransactionID,
+				line.MatchedAt,
+			)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// FindStatementByID retrieves a statement with its lines ordered by line number
+func (r *PostgresBankStatementRepository) FindStatementByID(ctx context.Context, id uuid.UUID) (*entity.BankStatement, error) {
+	stmt := &entity.BankStatement{}
+	err := r.pool.QueryRow(ctx, `
+		SELECT id, project_id, format, account_id, statement_no, currency,
+			   opening_balance_cents, closing_balance_cents, statement_date, imported_at, imported_by
+		FROM bank_statements
+		WHERE id = $1
+	`, id).Scan(
+		&stmt.ID,
+		&stmt.ProjectID,
+		&stmt.Format,
+		&stmt.AccountID,
+		&stmt.StatementNo,
+		&stmt.Currency,
+		&stmt.OpeningBalance,
+		&stmt.ClosingBalance,
+		&stmt.StatementDate,
+		&stmt.ImportedAt,
+		&stmt.ImportedBy,
+	)
+	if err == pgx.ErrNoRows {
+		return nil, entity.ErrStatementNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.pool.Query(ctx, `
+		SELECT `+statementLineColumns+`
+		FROM bank_statement_lines
+		WHERE statement_id = $1
+		ORDER BY line_no
+	`, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		line, err := scanStatementLine(rows)
+		if err != nil {
+			return nil, err
+		}
+		stmt.Lines = append(stmt.Lines, line)
+	}
+
+	return stmt, rows.Err()
+}
+
+// FindLineByID retrieves a single statement line
+func (r *PostgresBankStatementRepository) FindLineByID(ctx context.Context, id uuid.UUID) (*entity.StatementLine, error) {
+	row := r.pool.QueryRow(ctx, `
+		SELECT `+statementLineColumns+`
+		FROM bank_statement_lines
+		WHERE id = $1
+	`, id)
+
+	line, err := scanStatementLine(row)
+	if err == pgx.ErrNoRows {
+		return nil, entity.ErrStatementLineNotFound
+	}
+	return line, err
+}
+
+// LockLine retrieves a statement line and locks it until the transaction ends
+func (r *PostgresBankStatementRepository) LockLine(ctx context.Context, id uuid.UUID) (*entity.StatementLine, error) {
+	row := r.pool.QueryRow(ctx, `
+		SELECT `+statementLineColumns+`
+		FROM bank_statement_lines
+		WHERE id = $1
+		FOR UPDATE
+	`, id)
+
+	line, err := scanStatementLine(row)
+	if err == pgx.ErrNoRows {
+		return nil, entity.ErrStatementLineNotFound
+	}
+	return line, err
+}
+
+// UpdateLine stores the reconciliation state of a line
+// Only status and match columns are mutable; the booked data is immutable
+func (r *PostgresBankStatementRepository) UpdateLine(ctx context.Context, line *entity.StatementLine) error {
+	tag, err := r.pool.Exec(ctx, `
+		UPDATE bank_statement_lines SET
+			status = $2,
+			matched_transaction_id = $3,
+			matched_at = $4
+		WHERE id = $1
+	`, line.ID, line.Status, line.MatchedTransactionID, line.MatchedAt)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return entity.ErrStatementLineNotFound
+	}
+	return nil
+}
+
+// FindMatchedTransactionIDs returns the ledger transactions already linked to a line
+func (r *PostgresBankStatementRepository) FindMatchedTransactionIDs(ctx context.Context, projectID uuid.UUID) (map[uuid.UUID]bool, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT l.matched_transaction_id
+		FROM bank_statement_lines l
+		JOIN bank_statements s ON s.id = l.statement_id
+		WHERE s.project_id = $1 AND l.matched_transaction_id IS NOT NULL
+	`, projectID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	matched := make(map[uuid.UUID]bool)
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		matched[id] = true
+	}
+	return matched, rows.Err()
+}
+
+func scanStatementLine(row pgx.Row) (*entity.StatementLine, error) {
+	line := &entity.StatementLine{}
+	err := row.Scan(
+		&line.ID,
+		&line.StatementID,
+		&line.LineNo,
+		&line.BookingDate,
+		&line.ValueDate,
+		&line.Direction,
+		&line.AmountCents,
+		&line.Currency,
+		&line.Reference,
+		&line.BankReference,
+		&line.Description,
+		&line.CounterpartyName,
+		&line.CounterpartyAccount,
+		&line.Status,
+		&line.MatchedTransactionID,
+		&line.MatchedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return line, nil
+}
@@ -0,0 +1,48 @@
+// Copyright (c) 2026 Muhammet Ali Büyük. All rights reserved.
+// This source code is proprietary. Confidential and private.
+// Unauthorized copying or distribution is strictly prohibited.
+// Contact: iletisim@alibuyuk.net | Website: alibuyuk.net
+
+package repository
+
+import (
+	"context"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/qantesm/subflow/internal/core/entity"
+)
+
+// InMemoryCashFlowPlanRepository keeps cash flow plans in memory
+type InMemoryCashFlowPlanRepository struct {
+	mu    sync.RWMutex
+	plans map[uuid.UUID]*entity.CashFlowPlan // By project ID
+}
+
+// NewInMemoryCashFlowPlanRepository creates a new in-memory cash flow plan repository
+func NewInMemoryCashFlowPlanRepository() *InMemoryCashFlowPlanRepository {
+	return &InMemoryCashFlowPlanRepository{
+		plans: make(map[uuid.UUID]*entity.CashFlowPlan),
+	}
+}
+
+// Save inserts or replaces the plan of a project
+func (r *InMemoryCashFlowPlanRepository) Save(ctx context.Context, plan *entity.CashFlowPlan) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.plans[plan.ProjectID] = plan
+	return nil
+}
+
+// FindByProjectID retrieves the plan of a project
+func (r *InMemoryCashFlowPlanRepository) FindByProjectID(ctx context.Context, projectID uuid.UUID) (*entity.CashFlowPlan, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	plan, ok := r.plans[projectID]
+	if !ok {
+		return nil, entity.ErrCashFlowPlanNotFound
+	}
+	return plan, nil
+}
@@ -0,0 +1,100 @@
+// Copyright (c) 2026 Muhammet Ali Büyük. All rights reserved.
+// This source code is proprietary. Confidential and private.
+// Unauthorized copying or distribution is strictly prohibited.
+// Contact: iletisim@alibuyuk.net | Website: alibuyuk.net
+
+package repository
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/qantesm/subflow/internal/core/entity"
+)
+
+// PostgresCashFlowPlanRepository implements CashFlowPlanRepository for PostgreSQL
+type PostgresCashFlowPlanRepository struct {
+	pool      *Pool
+	architect string
+}
+
+// NewPostgresCashFlowPlanRepository creates a new PostgreSQL cash flow plan repository
+func NewPostgresCashFlowPlanRepository(pool *Pool) *PostgresCashFlowPlanRepository {
+	return &PostgresCashFlowPlanRepository{
+		pool:      pool,
+		architect: "Muhammet-Ali-Buyuk",
+	}
+}
+
+const cashFlowPlanColumns = `
+	project_id, curve, start_date, end_date, schedule, net_days, retainage_rate_bp,
+	retainage_days, payable_days, updated_at, updated_by
+`
+
+// Save inserts or replaces the plan of a project
+func (r *PostgresCashFlowPlanRepository) Save(ctx context.Context, plan *entity.CashFlowPlan) error {
+	schedule, err := json.Marshal(plan.Schedule)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.pool.Exec(ctx, `
+		INSERT INTO cash_flow_plans (`+cashFlowPlanColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		ON CONFLICT (project_id) DO UPDATE SET
+			curve = EXCLUDED.curve,
+			start_date = EXCLUDED.start_date,
+			end_date = EXCLUDED.end_date,
+			schedule = EXCLUDED.schedule,
+			net_days = EXCLUDED.net_days,
+			retainage_rate_bp = EXCLUDED.retainage_rate_bp,
+			retainage_days = EXCLUDED.retainage_days,
+			payable_days = EXCLUDED.payable_days,
+			updated_at = EXCLUDED.updated_at,
+			updated_by = EXCLUDED.updated_by
+	`,
+		plan.ProjectID,
+		plan.Curve,
+		plan.StartDate,
+		plan.EndDate,
+		schedule,
+		plan.NetDays,
+		plan.RetainageRate,
+		plan.RetainageDays,
+		plan.PayableDays,
+		plan.UpdatedAt,
+		plan.UpdatedBy,
+	)
+	return err
+}
+
+// FindByProjectID retrieves the plan of a project
+func (r *PostgresCashFlowPlanRepository) FindByProjectID(ctx context.Context, projectID uuid.UUID) (*entity.CashFlowPlan, error) {
+	plan := &entity.CashFlowPlan{}
+	var schedule []byte
+	err := r.pool.QueryRow(ctx, `SELECT `+cashFlowPlanColumns+` FROM cash_flow_plans WHERE project_id = $1`, projectID).Scan(
+		&plan.ProjectID,
+		&plan.Curve,
+		&plan.StartDate,
+		&plan.EndDate,
+		&schedule,
+		&plan.NetDays,
+		&plan.RetainageRate,
+		&plan.RetainageDays,
+		&plan.PayableDays,
+		&plan.UpdatedAt,
+		&plan.UpdatedBy,
+	)
+	if err == pgx.ErrNoRows {
+		return nil, entity.ErrCashFlowPlanNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(schedule, &plan.Schedule); err != nil {
+		return nil, err
+	}
+	return plan, nil
+}
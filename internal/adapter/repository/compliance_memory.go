@@ -0,0 +1,149 @@
+// Copyright (c) 2026 Muhammet Ali Büyük. All rights reserved.
+// This source code is proprietary. Confidential and private.
+// Unauthorized copying or distribution is strictly prohibited.
+// Contact: iletisim@alibuyuk.net | Website: alibuyuk.net
+
+package repository
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/qantesm/subflow/internal/core/entity"
+)
+
+// InMemoryComplianceRepository keeps compliance documents and requirements in memory
+type InMemoryComplianceRepository struct {
+	mu           sync.RWMutex
+	documents    map[uuid.UUID]*entity.ComplianceDocument
+	requirements map[uuid.UUID]*entity.ComplianceRequirement
+}
+
+// NewInMemoryComplianceRepository creates a new in-memory compliance repository
+func NewInMemoryComplianceRepository() *InMemoryComplianceRepository {
+	return &InMemoryComplianceRepository{
+		documents:    make(map[uuid.UUID]*entity.ComplianceDocument),
+		requirements: make(map[uuid.UUID]*entity.ComplianceRequirement),
+	}
+}
+
+// SaveDocument stores a new document
+func (r *InMemoryComplianceRepository) SaveDocument(ctx context.Context, d *entity.ComplianceDocument) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.documents[d.ID] = d
+	return nil
+}
+
+// UpdateDocument replaces a stored document
+func (r *InMemoryComplianceRepository) UpdateDocument(ctx context.Context, d *entity.ComplianceDocument) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.documents[d.ID]; !ok {
+		return entity.ErrComplianceDocumentNotFound
+	}
+	r.documents[d.ID] = d
+	return nil
+}
+
+// FindDocumentByID retrieves a document by its ID
+func (r *InMemoryComplianceRepository) FindDocumentByID(ctx context.Context, id uuid.UUID) (*entity.ComplianceDocument, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	d, ok := r.documents[id]
+	if !ok {
+		return nil, entity.ErrComplianceDocumentNotFound
+	}
+	return d, nil
+}
+
+// FindDocumentsByContract retrieves the documents on file for a contract, newest first
+func (r *InMemoryComplianceRepository) FindDocumentsByContract(ctx context.Context, contractID uuid.UUID) ([]*entity.ComplianceDocument, error) {
+	documents := r.filter(func(d *entity.ComplianceDocument) bool {
+		return d.ContractID == contractID
+	})
+	sort.Slice(documents, func(i, j int) bool {
+		return documents[i].IssueDate.After(documents[j].IssueDate)
+	})
+	return documents, nil
+}
+
+// FindExpiringDocuments retrieves unrevoked documents of all projects expiring before the date
+func (r *InMemoryComplianceRepository) FindExpiringDocuments(ctx context.Context, before time.Time) ([]*entity.ComplianceDocument, error) {
+	documents := r.filter(func(d *entity.ComplianceDocument) bool {
+		return d.RevokedAt == nil && d.ExpiryDate != nil && d.ExpiryDate.Before(before)
+	})
+	sort.Slice(documents, func(i, j int) bool {
+		return documents[i].ExpiryDate.Before(*documents[j].ExpiryDate)
+	})
+	return documents, nil
+}
+
+// SaveRequirement stores a new requirement
+func (r *InMemoryComplianceRepository) SaveRequirement(ctx context.Context, req *entity.ComplianceRequirement) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, existing := range r.requirements {
+		if existing.ProjectID == req.ProjectID && existing.Type == req.Type && sameContract(existing.ContractID, req.ContractID) {
+			return entity.ErrComplianceRequirementExists
+		}
+	}
+	r.requirements[req.ID] = req
+	return nil
+}
+
+// DeleteRequirement removes a requirement
+func (r *InMemoryComplianceRepository) DeleteRequirement(ctx context.Context, id uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.requirements[id]; !ok {
+		return entity.ErrComplianceRequirementNotFound
+	}
+	delete(r.requirements, id)
+	return nil
+}
+
+// FindRequirementsByProject retrieves the requirements of a project
+func (r *InMemoryComplianceRepository) FindRequirementsByProject(ctx context.Context, projectID uuid.UUID) ([]*entity.ComplianceRequirement, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var requirements []*entity.ComplianceRequirement
+	for _, req := range r.requirements {
+		if req.ProjectID == projectID {
+			requirements = append(requirements, req)
+		}
+	}
+	sort.Slice(requirements, func(i, j int) bool {
+		return requirements[i].CreatedAt.Before(requirements[j].CreatedAt)
+	})
+	return requirements, nil
+}
+
+func (r *InMemoryComplianceRepository) filter(match func(*entity.ComplianceDocument) bool) []*entity.ComplianceDocument {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var documents []*entity.ComplianceDocument
+	for _, d := range r.documents {
+		if match(d) {
+			documents = append(documents, d)
+		}
+	}
+	return documents
+}
+
+func sameContract(a, b *uuid.UUID) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
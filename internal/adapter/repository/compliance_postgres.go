@@ -0,0 +1,218 @@
+// Copyright (c) 2026 Muhammet Ali Büyük. All rights reserved.
+// This source code is proprietary. Confidential and private.
+// Unauthorized copying or distribution is strictly prohibited.
+// Contact: iletisim@alibuyuk.net | Website: alibuyuk.net
+
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/qantesm/subflow/internal/core/entity"
+)
+
+// PostgresComplianceRepository implements ComplianceRepository for PostgreSQL
+type PostgresComplianceRepository struct {
+	pool      *Pool
+	architect string
+}
+
+// NewPostgresComplianceRepository creates a new PostgreSQL compliance repository
+func NewPostgresComplianceRepository(pool *Pool) *PostgresComplianceRepository {
+	return &PostgresComplianceRepository{
+		pool:      pool,
+		architect: "Muhammet-Ali-Buyuk",
+	}
+}
+
+const complianceDocumentColumns = `
+	id, project_id, contract_id, type, document_no, issuer, issue_date, expiry_date, amount_cents,
+	through_date, payment_id, revoked_at, expiry_alerted_at, created_at, created_by
+`
+
+const complianceRequirementColumns = `id, project_id, contract_id, type, created_at, created_by`
+
+// SaveDocument stores a new document
+func (r *PostgresComplianceRepository) SaveDocument(ctx context.Context, d *entity.ComplianceDocument) error {
+	_, err := r.pool.Exec(ctx, `
+		INSERT INTO compliance_documents (`+complianceDocumentColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
+	`,
+		d.ID,
+		d.ProjectID,
+		d.ContractID,
+		d.Type,
+		d.DocumentNo,
+		d.Issuer,
+		d.IssueDate,
+		d.ExpiryDate,
+		d.Amount,
+		d.ThroughDate,
+		d.PaymentID,
+		d.RevokedAt,
+		d.ExpiryAlertedAt,
+		d.CreatedAt,
+		d.CreatedBy,
+	)
+	return err
+}
+
+// UpdateDocument stores the changed state of a document
+// The document details are immutable; only its use, revocation and alert state change
+func (r *PostgresComplianceRepository) UpdateDocument(ctx context.Context, d *entity.ComplianceDocument) error {
+	tag, err := r.pool.Exec(ctx, `
+		UPDATE compliance_documents
+		SET payment_id = $2, revoked_at = $3, expiry_alerted_at = $4
+		WHERE id = $1
+	`, d.ID, d.PaymentID, d.RevokedAt, d.ExpiryAlertedAt)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return entity.ErrComplianceDocumentNotFound
+	}
+	return nil
+}
+
+// FindDocumentByID retrieves a document by its ID
+func (r *PostgresComplianceRepository) FindDocumentByID(ctx context.Context, id uuid.UUID) (*entity.ComplianceDocument, error) {
+	row := r.pool.QueryRow(ctx, `SELECT `+complianceDocumentColumns+` FROM compliance_documents WHERE id = $1`, id)
+
+	d, err := scanComplianceDocument(row)
+	if err == pgx.ErrNoRows {
+		return nil, entity.ErrComplianceDocumentNotFound
+	}
+	return d, err
+}
+
+// FindDocumentsByContract retrieves the documents on file for a contract, newest first
+func (r *PostgresComplianceRepository) FindDocumentsByContract(ctx context.Context, contractID uuid.UUID) ([]*entity.ComplianceDocument, error) {
+	return r.queryDocuments(ctx, `
+		SELECT `+complianceDocumentColumns+`
+		FROM compliance_documents
+		WHERE contract_id = $1
+		ORDER BY issue_date DESC, created_at DESC
+	`, contractID)
+}
+
+// FindExpiringDocuments retrieves unrevoked documents of all projects expiring before the date
+func (r *PostgresComplianceRepository) FindExpiringDocuments(ctx context.Context, before time.Time) ([]*entity.ComplianceDocument, error) {
+	return r.queryDocuments(ctx, `
+		SELECT `+complianceDocumentColumns+`
+		FROM compliance_documents
+		WHERE revoked_at IS NULL AND expiry_date IS NOT NULL AND expiry_date < $1
+		ORDER BY expiry_date
+	`, before)
+}
+
+// SaveRequirement stores a new requirement
+func (r *PostgresComplianceRepository) SaveRequirement(ctx context.Context, req *entity.ComplianceRequirement) error {
+	_, err := r.pool.Exec(ctx, `
+		INSERT INTO compliance_requirements (`+complianceRequirementColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`,
+		req.ID,
+		req.ProjectID,
+		req.ContractID,
+		req.Type,
+		req.CreatedAt,
+		req.CreatedBy,
+	)
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == "23505" && pgErr.ConstraintName == "compliance_requirements_scope_key" {
+		return entity.ErrComplianceRequirementExists
+	}
+	return err
+}
+
+// DeleteRequirement removes a requirement
+func (r *PostgresComplianceRepository) DeleteRequirement(ctx context.Context, id uuid.UUID) error {
+	tag, err := r.pool.Exec(ctx, `DELETE FROM compliance_requirements WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return entity.ErrComplianceRequirementNotFound
+	}
+	return nil
+}
+
+// FindRequirementsByProject retrieves the requirements of a project
+func (r *PostgresComplianceRepository) FindRequirementsByProject(ctx context.Context, projectID uuid.UUID) ([]*entity.ComplianceRequirement, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT `+complianceRequirementColumns+`
+		FROM compliance_requirements
+		WHERE project_id = $1
+		ORDER BY created_at
+	`, projectID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var requirements []*entity.ComplianceRequirement
+	for rows.Next() {
+		req := &entity.ComplianceRequirement{}
+		if err := rows.Scan(
+			&req.ID,
+			&req.ProjectID,
+			&req.ContractID,
+			&req.Type,
+			&req.CreatedAt,
+			&req.CreatedBy,
+		); err != nil {
+			return nil, err
+		}
+		requirements = append(requirements, req)
+	}
+	return requirements, rows.Err()
+}
+
+func (r *PostgresComplianceRepository) queryDocuments(ctx context.Context, sql string, args ...interface{}) ([]*entity.ComplianceDocument, error) {
+	rows, err := r.pool.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var documents []*entity.ComplianceDocument
+	for rows.Next() {
+		d, err := scanComplianceDocument(rows)
+		if err != nil {
+			return nil, err
+		}
+		documents = append(documents, d)
+	}
+	return documents, rows.Err()
+}
+
+func scanComplianceDocument(row pgx.Row) (*entity.ComplianceDocument, error) {
+	d := &entity.ComplianceDocument{}
+	err := row.Scan(
+		&d.ID,
+		&d.ProjectID,
+		&d.ContractID,
+		&d.Type,
+		&d.DocumentNo,
+		&d.Issuer,
+		&d.IssueDate,
+		&d.ExpiryDate,
+		&d.Amount,
+		&d.ThroughDate,
+		&d.PaymentID,
+		&d.RevokedAt,
+		&d.ExpiryAlertedAt,
+		&d.CreatedAt,
+		&d.CreatedBy,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return d, nil
+}
@@ -0,0 +1,161 @@
+// Copyright (c) 2026 Muhammet Ali Büyük. All rights reserved.
+// This source code is proprietary. Confidential and private.
+// Unauthorized copying or distribution is strictly prohibited.
+// Contact: iletisim@alibuyuk.net | Website: alibuyuk.net
+
+package repository
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/qantesm/subflow/internal/core/entity"
+)
+
+// InMemoryCostRepository keeps cost codes, budget, commitments and vendor invoices in memory
+type InMemoryCostRepository struct {
+	mu          sync.RWMutex
+	codes       map[uuid.UUID]*entity.CostCode
+	budget      []*entity.BudgetLine
+	commitments map[uuid.UUID]*entity.CostCommitment
+	invoices    []*entity.VendorInvoice
+}
+
+// NewInMemoryCostRepository creates a new in-memory cost repository
+func NewInMemoryCostRepository() *InMemoryCostRepository {
+	return &InMemoryCostRepository{
+		codes:       make(map[uuid.UUID]*entity.CostCode),
+		commitments: make(map[uuid.UUID]*entity.CostCommitment),
+	}
+}
+
+// SaveCostCode stores a new cost code
+func (r *InMemoryCostRepository) SaveCostCode(ctx context.Context, c *entity.CostCode) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, existing := range r.codes {
+		if existing.ProjectID == c.ProjectID && existing.Code == c.Code {
+			return entity.ErrCostCodeExists
+		}
+	}
+	r.codes[c.ID] = c
+	return nil
+}
+
+// FindCostCodeByID retrieves a cost code by its ID
+func (r *InMemoryCostRepository) FindCostCodeByID(ctx context.Context, id uuid.UUID) (*entity.CostCode, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	c, ok := r.codes[id]
+	if !ok {
+		return nil, entity.ErrCostCodeNotFound
+	}
+	return c, nil
+}
+
+// FindCostCodesByProject retrieves the cost codes of a project ordered by code
+func (r *InMemoryCostRepository) FindCostCodesByProject(ctx context.Context, projectID uuid.UUID) ([]*entity.CostCode, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var codes []*entity.CostCode
+	for _, c := range r.codes {
+		if c.ProjectID == projectID {
+			codes = append(codes, c)
+		}
+	}
+	sort.Slice(codes, func(i, j int) bool { return codes[i].Code < codes[j].Code })
+	return codes, nil
+}
+
+// SaveBudgetLine stores a new budget line
+func (r *InMemoryCostRepository) SaveBudgetLine(ctx context.Context, b *entity.BudgetLine) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.budget = append(r.budget, b)
+	return nil
+}
+
+// FindBudgetLinesByProject retrieves the budget lines of a project, oldest first
+func (r *InMemoryCostRepository) FindBudgetLinesByProject(ctx context.Context, projectID uuid.UUID) ([]*entity.BudgetLine, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var lines []*entity.BudgetLine
+	for _, b := range r.budget {
+		if b.ProjectID == projectID {
+			lines = append(lines, b)
+		}
+	}
+	return lines, nil
+}
+
+// SaveCommitment stores a new commitment
+func (r *InMemoryCostRepository) SaveCommitment(ctx context.Context, c *entity.CostCommitment) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.commitments[c.ID] = c
+	return nil
+}
+
+// FindCommitmentByID retrieves a commitment by its ID
+func (r *InMemoryCostRepository) FindCommitmentByID(ctx context.Context, id uuid.UUID) (*entity.CostCommitment, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	c, ok := r.commitments[id]
+	if !ok {
+		return nil, entity.ErrCostCommitmentNotFound
+	}
+	return c, nil
+}
+
+// FindCommitmentsByProject retrieves the commitments of a project ordered by date
+func (r *InMemoryCostRepository) FindCommitmentsByProject(ctx context.Context, projectID uuid.UUID) ([]*entity.CostCommitment, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var commitments []*entity.CostCommitment
+	for _, c := range r.commitments {
+		if c.ProjectID == projectID {
+			commitments = append(commitments, c)
+		}
+	}
+	sort.Slice(commitments, func(i, j int) bool { return commitments[i].Date.Before(commitments[j].Date) })
+	return commitments, nil
+}
+
+// SaveVendorInvoice stores a new vendor invoice
+func (r *InMemoryCostRepository) SaveVendorInvoice(ctx context.Context, v *entity.VendorInvoice) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, existing := range r.invoices {
+		if existing.ProjectID == v.ProjectID && existing.Vendor == v.Vendor && existing.InvoiceNo == v.InvoiceNo {
+			return entity.ErrVendorInvoiceExists
+		}
+	}
+	r.invoices = append(r.invoices, v)
+	return nil
+}
+
+// FindVendorInvoicesByProject retrieves the vendor invoices of a project ordered by invoice date
+func (r *InMemoryCostRepository) FindVendorInvoicesByProject(ctx context.Context, projectID uuid.UUID) ([]*entity.VendorInvoice, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var invoices []*entity.VendorInvoice
+	for _, v := range r.invoices {
+		if v.ProjectID == projectID {
+			invoices = append(invoices, v)
+		}
+	}
+	sort.SliceStable(invoices, func(i, j int) bool { return invoices[i].InvoiceDate.Before(invoices[j].InvoiceDate) })
+	return invoices, nil
+}
@@ -0,0 +1,313 @@
+// Copyright (c) 2026 Muhammet Ali Büyük. All rights reserved.
+// This source code is proprietary. Confidential and private.
+// Unauthorized copying or distribution is strictly prohibited.
+// Contact: iletisim@alibuyuk.net | Website: alibuyuk.net
+
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/qantesm/subflow/internal/core/entity"
+)
+
+// PostgresCostRepository implements CostRepository for PostgreSQL
+type PostgresCostRepository struct {
+	pool      *Pool
+	architect string
+}
+
+// NewPostgresCostRepository creates a new PostgreSQL cost repository
+func NewPostgresCostRepository(pool *Pool) *PostgresCostRepository {
+	return &PostgresCostRepository{
+		pool:      pool,
+		architect: "Muhammet-Ali-Buyuk",
+	}
+}
+
+const costCodeColumns = `id, project_id, parent_id, code, name, created_at, created_by`
+
+const budgetLineColumns = `id, project_id, cost_code_id, description, amount_cents, currency, created_at, created_by`
+
+const costCommitmentColumns = `
+	id, project_id, cost_code_id, source, contract_id, reference_no, vendor, description,
+	amount_cents, currency, commitment_date, created_at, created_by
+`
+
+const vendorInvoiceColumns = `
+	id, project_id, cost_code_id, commitment_id, vendor, invoice_no, invoice_date,
+	amount_cents, currency, created_at, created_by
+`
+
+// SaveCostCode stores a new cost code
+func (r *PostgresCostRepository) SaveCostCode(ctx context.Context, c *entity.CostCode) error {
+	_, err := r.pool.Exec(ctx, `
+		INSERT INTO cost_codes (`+costCodeColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`,
+		c.ID,
+		c.ProjectID,
+		c.ParentID,
+		c.Code,
+		c.Name,
+		c.CreatedAt,
+		c.CreatedBy,
+	)
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == "23505" && pgErr.ConstraintName == "cost_codes_project_id_code_key" {
+		return entity.ErrCostCodeExists
+	}
+	return err
+}
+
+// FindCostCodeByID retrieves a cost code by its ID
+func (r *PostgresCostRepository) FindCostCodeByID(ctx context.Context, id uuid.UUID) (*entity.CostCode, error) {
+	c := &entity.CostCode{}
+	err := r.pool.QueryRow(ctx, `SELECT `+costCodeColumns+` FROM cost_codes WHERE id = $1`, id).Scan(
+		&c.ID,
+		&c.ProjectID,
+		&c.ParentID,
+		&c.Code,
+		&c.Name,
+		&c.CreatedAt,
+		&c.CreatedBy,
+	)
+	if err == pgx.ErrNoRows {
+		return nil, entity.ErrCostCodeNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// FindCostCodesByProject retrieves the cost codes of a project ordered by code
+func (r *PostgresCostRepository) FindCostCodesByProject(ctx context.Context, projectID uuid.UUID) ([]*entity.CostCode, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT `+costCodeColumns+`
+		FROM cost_codes
+		WHERE project_id = $1
+		ORDER BY code
+	`, projectID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var codes []*entity.CostCode
+	for rows.Next() {
+		c := &entity.CostCode{}
+		if err := rows.Scan(
+			&c.ID,
+			&c.ProjectID,
+			&c.ParentID,
+			&c.Code,
+			&c.Name,
+			&c.CreatedAt,
+			&c.CreatedBy,
+		); err != nil {
+			return nil, err
+		}
+		codes = append(codes, c)
+	}
+	return codes, rows.Err()
+}
+
+// SaveBudgetLine stores a new budget line
+func (r *PostgresCostRepository) SaveBudgetLine(ctx context.Context, b *entity.BudgetLine) error {
+	_, err := r.pool.Exec(ctx, `
+		INSERT INTO budget_lines (`+budgetLineColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`,
+		b.ID,
+		b.ProjectID,
+		b.CostCodeID,
+		b.Description,
+		b.Amount,
+		b.Currency,
+		b.CreatedAt,
+		b.CreatedBy,
+	)
+	return err
+}
+
+// FindBudgetLinesByProject retrieves the budget lines of a project, oldest first
+func (r *PostgresCostRepository) FindBudgetLinesByProject(ctx context.Context, projectID uuid.UUID) ([]*entity.BudgetLine, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT `+budgetLineColumns+`
+		FROM budget_lines
+		WHERE project_id = $1
+		ORDER BY created_at
+	`, projectID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var lines []*entity.BudgetLine
+	for rows.Next() {
+		b := &entity.BudgetLine{}
+		if err := rows.Scan(
+			&b.ID,
+			&b.ProjectID,
+			&b.CostCodeID,
+			&b.Description,
+			&b.Amount,
+			&b.Currency,
+			&b.CreatedAt,
+			&b.CreatedBy,
+		); err != nil {
+			return nil, err
+		}
+		lines = append(lines, b)
+	}
+	return lines, rows.Err()
+}
+
+// SaveCommitment stores a new commitment
+func (r *PostgresCostRepository) SaveCommitment(ctx context.Context, c *entity.CostCommitment) error {
+	_, err := r.pool.Exec(ctx, `
+		INSERT INTO cost_commitments (`+costCommitmentColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+	`,
+		c.ID,
+		c.ProjectID,
+		c.CostCodeID,
+		c.Source,
+		c.ContractID,
+		c.ReferenceNo,
+		c.Vendor,
+		c.Description,
+		c.Amount,
+		c.Currency,
+		c.Date,
+		c.CreatedAt,
+		c.CreatedBy,
+	)
+	return err
+}
+
+// FindCommitmentByID retrieves a commitment by its ID
+func (r *PostgresCostRepository) FindCommitmentByID(ctx context.Context, id uuid.UUID) (*entity.CostCommitment, error) {
+	row := r.pool.QueryRow(ctx, `SELECT `+costCommitmentColumns+` FROM cost_commitments WHERE id = $1`, id)
+
+	c, err := scanCostCommitment(row)
+	if err == pgx.ErrNoRows {
+		return nil, entity.ErrCostCommitmentNotFound
+	}
+	return c, err
+}
+
+// FindCommitmentsByProject retrieves the commitments of a project ordered by date
+func (r *PostgresCostRepository) FindCommitmentsByProject(ctx context.Context, projectID uuid.UUID) ([]*entity.CostCommitment, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT `+costCommitmentColumns+`
+		FROM cost_commitments
+		WHERE project_id = $1
+		ORDER BY commitment_date, created_at
+	`, projectID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var commitments []*entity.CostCommitment
+	for rows.Next() {
+		c, err := scanCostCommitment(rows)
+		if err != nil {
+			return nil, err
+		}
+		commitments = append(commitments, c)
+	}
+	return commitments, rows.Err()
+}
+
+// SaveVendorInvoice stores a new vendor invoice
+func (r *PostgresCostRepository) SaveVendorInvoice(ctx context.Context, v *entity.VendorInvoice) error {
+	_, err := r.pool.Exec(ctx, `
+		INSERT INTO vendor_invoices (`+vendorInvoiceColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+	`,
+		v.ID,
+		v.ProjectID,
+		v.CostCodeID,
+		v.CommitmentID,
+		v.Vendor,
+		v.InvoiceNo,
+		v.InvoiceDate,
+		v.Amount,
+		v.Currency,
+		v.CreatedAt,
+		v.CreatedBy,
+	)
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == "23505" && pgErr.ConstraintName == "vendor_invoices_project_id_vendor_invoice_no_key" {
+		return entity.ErrVendorInvoiceExists
+	}
+	return err
+}
+
+// FindVendorInvoicesByProject retrieves the vendor invoices of a project ordered by invoice date
+func (r *PostgresCostRepository) FindVendorInvoicesByProject(ctx context.Context, projectID uuid.UUID) ([]*entity.VendorInvoice, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT `+vendorInvoiceColumns+`
+		FROM vendor_invoices
+		WHERE project_id = $1
+		ORDER BY invoice_date, created_at
+	`, projectID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var invoices []*entity.VendorInvoice
+	for rows.Next() {
+		v := &entity.VendorInvoice{}
+		if err := rows.Scan(
+			&v.ID,
+			&v.ProjectID,
+			&v.CostCodeID,
+			&v.CommitmentID,
+			&v.Vendor,
+			&v.InvoiceNo,
+			&v.InvoiceDate,
+			&v.Amount,
+			&v.Currency,
+			&v.CreatedAt,
+			&v.CreatedBy,
+		); err != nil {
+			return nil, err
+		}
+		invoices = append(invoices, v)
+	}
+	return invoices, rows.Err()
+}
+
+func scanCostCommitment(row pgx.Row) (*entity.CostCommitment, error) {
+	c := &entity.CostCommitment{}
+	err := row.Scan(
+		&c.ID,
+		&c.ProjectID,
+		&c.CostCodeID,
+		&c.Source,
+		&c.ContractID,
+		&c.ReferenceNo,
+		&c.Vendor,
+		&c.Description,
+		&c.Amount,
+		&c.Currency,
+		&c.Date,
+		&c.CreatedAt,
+		&c.CreatedBy,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return c, nil
+}
@@ -0,0 +1,195 @@
+// Copyright (c) 2026 Muhammet Ali Büyük. All rights reserved.
+// This source code is proprietary. Confidential and private.
+// Unauthorized copying or distribution is strictly prohibited.
+// Contact: iletisim@alibuyuk.net | Website: alibuyuk.net
+
+package repository
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/qantesm/subflow/internal/core/entity"
+)
+
+// InMemoryDeductionRepository keeps deductions, schedules and penalty rules in memory
+type InMemoryDeductionRepository struct {
+	mu         sync.RWMutex
+	deductions map[uuid.UUID]*entity.Deduction
+	schedules  map[uuid.UUID]*entity.DeductionSchedule
+	rules      map[uuid.UUID]*entity.LatePenaltyRule // By project
+}
+
+// NewInMemoryDeductionRepository creates a new in-memory deduction repository
+func NewInMemoryDeductionRepository() *InMemoryDeductionRepository {
+	return &InMemoryDeductionRepository{
+		deductions: make(map[uuid.UUID]*entity.Deduction),
+		schedules:  make(map[uuid.UUID]*entity.DeductionSchedule),
+		rules:      make(map[uuid.UUID]*entity.LatePenaltyRule),
+	}
+}
+
+// Save stores a new deduction
+func (r *InMemoryDeductionRepository) Save(ctx context.Context, d *entity.Deduction) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.deductions[d.ID] = d
+	return nil
+}
+
+// Update replaces a stored deduction
+func (r *InMemoryDeductionRepository) Update(ctx context.Context, d *entity.Deduction) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.deductions[d.ID]; !ok {
+		return entity.ErrDeductionNotFound
+	}
+	r.deductions[d.ID] = d
+	return nil
+}
+
+// FindByID retrieves a deduction by its ID
+func (r *InMemoryDeductionRepository) FindByID(ctx context.Context, id uuid.UUID) (*entity.Deduction, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	d, ok := r.deductions[id]
+	if !ok {
+		return nil, entity.ErrDeductionNotFound
+	}
+	return d, nil
+}
+
+// FindByProjectID retrieves the deductions of a project
+func (r *InMemoryDeductionRepository) FindByProjectID(ctx context.Context, projectID uuid.UUID) ([]*entity.Deduction, error) {
+	return r.filter(func(d *entity.Deduction) bool {
+		return d.ProjectID == projectID
+	}), nil
+}
+
+// FindByPayApplication retrieves the deductions taken from a pay application
+func (r *InMemoryDeductionRepository) FindByPayApplication(ctx context.Context, payApplicationID uuid.UUID) ([]*entity.Deduction, error) {
+	return r.filter(func(d *entity.Deduction) bool {
+		return d.PayApplicationID != nil && *d.PayApplicationID == payApplicationID
+	}), nil
+}
+
+// SaveSchedule stores a new recurring deduction
+func (r *InMemoryDeductionRepository) SaveSchedule(ctx context.Context, s *entity.DeductionSchedule) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.schedules[s.ID] = s
+	return nil
+}
+
+// UpdateSchedule replaces a stored schedule
+func (r *InMemoryDeductionRepository) UpdateSchedule(ctx context.Context, s *entity.DeductionSchedule) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.schedules[s.ID]; !ok {
+		return entity.ErrDeductionScheduleNotFound
+	}
+	r.schedules[s.ID] = s
+	return nil
+}
+
+// FindScheduleByID retrieves a schedule by its ID
+func (r *InMemoryDeductionRepository) FindScheduleByID(ctx context.Context, id uuid.UUID) (*entity.DeductionSchedule, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	s, ok := r.schedules[id]
+	if !ok {
+		return nil, entity.ErrDeductionScheduleNotFound
+	}
+	return s, nil
+}
+
+// FindSchedulesByProject retrieves the schedules of a project
+func (r *InMemoryDeductionRepository) FindSchedulesByProject(ctx context.Context, projectID uuid.UUID) ([]*entity.DeductionSchedule, error) {
+	return r.filterSchedules(func(s *entity.DeductionSchedule) bool {
+		return s.ProjectID == projectID
+	}), nil
+}
+
+// FindDueSchedules retrieves the active schedules with an occurrence on or before asOf
+func (r *InMemoryDeductionRepository) FindDueSchedules(ctx context.Context, asOf time.Time) ([]*entity.DeductionSchedule, error) {
+	return r.filterSchedules(func(s *entity.DeductionSchedule) bool {
+		return s.Active && !s.NextDate.After(asOf)
+	}), nil
+}
+
+// SavePenaltyRule stores the penalty rule of a project, replacing any previous rule
+func (r *InMemoryDeductionRepository) SavePenaltyRule(ctx context.Context, rule *entity.LatePenaltyRule) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.rules[rule.ProjectID] = rule
+	return nil
+}
+
+// FindPenaltyRule returns the penalty rule of a project
+func (r *InMemoryDeductionRepository) FindPenaltyRule(ctx context.Context, projectID uuid.UUID) (*entity.LatePenaltyRule, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	rule, ok := r.rules[projectID]
+	if !ok {
+		return nil, entity.ErrPenaltyRuleNotFound
+	}
+	return rule, nil
+}
+
+// FindPenaltyRules returns the penalty rules of all projects
+func (r *InMemoryDeductionRepository) FindPenaltyRules(ctx context.Context) ([]*entity.LatePenaltyRule, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	rules := make([]*entity.LatePenaltyRule, 0, len(r.rules))
+	for _, rule := range r.rules {
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+func (r *InMemoryDeductionRepository) filter(match func(*entity.Deduction) bool) []*entity.Deduction {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var deductions []*entity.Deduction
+	for _, d := range r.deductions {
+		if match(d) {
+			deductions = append(deductions, d)
+		}
+	}
+	sort.Slice(deductions, func(i, j int) bool {
+		if deductions[i].Date.Equal(deductions[j].Date) {
+			return deductions[i].CreatedAt.Before(deductions[j].CreatedAt)
+		}
+		return deductions[i].Date.Before(deductions[j].Date)
+	})
+	return deductions
+}
+
+func (r *InMemoryDeductionRepository) filterSchedules(match func(*entity.DeductionSchedule) bool) []*entity.DeductionSchedule {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var schedules []*entity.DeductionSchedule
+	for _, s := range r.schedules {
+		if match(s) {
+			schedules = append(schedules, s)
+		}
+	}
+	sort.Slice(schedules, func(i, j int) bool {
+		return schedules[i].StartDate.Before(schedules[j].StartDate)
+	})
+	return schedules
+}
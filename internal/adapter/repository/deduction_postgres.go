@@ -0,0 +1,325 @@
+// Copyright (c) 2026 Muhammet Ali Büyük. All rights reserved.
+// This source code is proprietary. Confidential and private.
+// Unauthorized copying or distribution is strictly prohibited.
+// Contact: iletisim@alibuyuk.net | Website: alibuyuk.net
+
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/qantesm/subflow/internal/core/entity"
+)
+
+// PostgresDeductionRepository implements DeductionRepository for PostgreSQL
+type PostgresDeductionRepository struct {
+	pool      *Pool
+	architect string
+}
+
+// NewPostgresDeductionRepository creates a new PostgreSQL deduction repository
+func NewPostgresDeductionRepository(pool *Pool) *PostgresDeductionRepository {
+	return &PostgresDeductionRepository{
+		pool:      pool,
+		architect: "Muhammet-Ali-Buyuk",
+	}
+}
+
+const deductionColumns = `
+	id, project_id, contract_id, category, reason, amount_cents, currency, deduction_date,
+	schedule_id, penalty_days, transaction_id, pay_application_id, created_at, created_by
+`
+
+const deductionScheduleColumns = `
+	id, project_id, contract_id, category, reason, amount_cents, currency, interval_months,
+	start_date, end_date, next_date, active, created_at, created_by
+`
+
+const penaltyRuleColumns = `project_id, daily_rate_ppm, cap_rate_bp, updated_at, updated_by`
+
+// Save stores a new deduction
+func (r *PostgresDeductionRepository) Save(ctx context.Context, d *entity.Deduction) error {
+	_, err := r.pool.Exec(ctx, `
+		INSERT INTO deductions (`+deductionColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+	`,
+		d.ID,
+		d.ProjectID,
+		d.ContractID,
+		d.Category,
+		d.Reason,
+		d.Amount,
+		d.Currency,
+		d.Date,
+		d.ScheduleID,
+		d.PenaltyDays,
+		d.TransactionID,
+		d.PayApplicationID,
+		d.CreatedAt,
+		d.CreatedBy,
+	)
+	return err
+}
+
+// Update stores the pay application a deduction was taken from
+// The amount and ledger entry are immutable once recorded
+func (r *PostgresDeductionRepository) Update(ctx context.Context, d *entity.Deduction) error {
+	tag, err := r.pool.Exec(ctx, `UPDATE deductions SET pay_application_id = $2 WHERE id = $1`, d.ID, d.PayApplicationID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return entity.ErrDeductionNotFound
+	}
+	return nil
+}
+
+// FindByID retrieves a deduction by its ID
+func (r *PostgresDeductionRepository) FindByID(ctx context.Context, id uuid.UUID) (*entity.Deduction, error) {
+	row := r.pool.QueryRow(ctx, `SELECT `+deductionColumns+` FROM deductions WHERE id = $1`, id)
+
+	d, err := scanDeduction(row)
+	if err == pgx.ErrNoRows {
+		return nil, entity.ErrDeductionNotFound
+	}
+	return d, err
+}
+
+// FindByProjectID retrieves the deductions of a project
+func (r *PostgresDeductionRepository) FindByProjectID(ctx context.Context, projectID uuid.UUID) ([]*entity.Deduction, error) {
+	return r.queryDeductions(ctx, `
+		SELECT `+deductionColumns+`
+		FROM deductions
+		WHERE project_id = $1
+		ORDER BY deduction_date, created_at
+	`, projectID)
+}
+
+// FindByPayApplication retrieves the deductions taken from a pay application
+func (r *PostgresDeductionRepository) FindByPayApplication(ctx context.Context, payApplicationID uuid.UUID) ([]*entity.Deduction, error) {
+	return r.queryDeductions(ctx, `
+		SELECT `+deductionColumns+`
+		FROM deductions
+		WHERE pay_application_id = $1
+		ORDER BY deduction_date, created_at
+	`, payApplicationID)
+}
+
+// SaveSchedule stores a new recurring deduction
+func (r *PostgresDeductionRepository) SaveSchedule(ctx context.Context, s *entity.DeductionSchedule) error {
+	_, err := r.pool.Exec(ctx, `
+		INSERT INTO deduction_schedules (`+deductionScheduleColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+	`,
+		s.ID,
+		s.ProjectID,
+		s.ContractID,
+		s.Category,
+		s.Reason,
+		s.Amount,
+		s.Currency,
+		s.IntervalMonths,
+		s.StartDate,
+		s.EndDate,
+		s.NextDate,
+		s.Active,
+		s.CreatedAt,
+		s.CreatedBy,
+	)
+	return err
+}
+
+// UpdateSchedule stores the next occurrence and state of a schedule
+func (r *PostgresDeductionRepository) UpdateSchedule(ctx context.Context, s *entity.DeductionSchedule) error {
+	tag, err := r.pool.Exec(ctx, `UPDATE deduction_schedules SET next_date = $2, active = $3 WHERE id = $1`, s.ID, s.NextDate, s.Active)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return entity.ErrDeductionScheduleNotFound
+	}
+	return nil
+}
+
+// FindScheduleByID retrieves a schedule by its ID
+func (r *PostgresDeductionRepository) FindScheduleByID(ctx context.Context, id uuid.UUID) (*entity.DeductionSchedule, error) {
+	row := r.pool.QueryRow(ctx, `SELECT `+deductionScheduleColumns+` FROM deduction_schedules WHERE id = $1`, id)
+
+	s, err := scanDeductionSchedule(row)
+	if err == pgx.ErrNoRows {
+		return nil, entity.ErrDeductionScheduleNotFound
+	}
+	return s, err
+}
+
+// FindSchedulesByProject retrieves the schedules of a project
+func (r *PostgresDeductionRepository) FindSchedulesByProject(ctx context.Context, projectID uuid.UUID) ([]*entity.DeductionSchedule, error) {
+	return r.querySchedules(ctx, `
+		SELECT `+deductionScheduleColumns+`
+		FROM deduction_schedules
+		WHERE project_id = $1
+		ORDER BY start_date
+	`, projectID)
+}
+
+// FindDueSchedules retrieves the active schedules with an occurrence on or before asOf
+func (r *PostgresDeductionRepository) FindDueSchedules(ctx context.Context, asOf time.Time) ([]*entity.DeductionSchedule, error) {
+	return r.querySchedules(ctx, `
+		SELECT `+deductionScheduleColumns+`
+		FROM deduction_schedules
+		WHERE active AND next_date <= $1
+		ORDER BY next_date
+	`, asOf)
+}
+
+// SavePenaltyRule stores the penalty rule of a project, replacing any previous rule
+func (r *PostgresDeductionRepository) SavePenaltyRule(ctx context.Context, rule *entity.LatePenaltyRule) error {
+	_, err := r.pool.Exec(ctx, `
+		INSERT INTO late_penalty_rules (`+penaltyRuleColumns+`)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (project_id) DO UPDATE SET
+			daily_rate_ppm = EXCLUDED.daily_rate_ppm,
+			cap_rate_bp = EXCLUDED.cap_rate_bp,
+			updated_at = EXCLUDED.updated_at,
+			updated_by = EXCLUDED.updated_by
+	`,
+		rule.ProjectID,
+		rule.DailyRate,
+		rule.CapRate,
+		rule.UpdatedAt,
+		rule.UpdatedBy,
+	)
+	return err
+}
+
+// FindPenaltyRule returns the penalty rule of a project
+func (r *PostgresDeductionRepository) FindPenaltyRule(ctx context.Context, projectID uuid.UUID) (*entity.LatePenaltyRule, error) {
+	row := r.pool.QueryRow(ctx, `SELECT `+penaltyRuleColumns+` FROM late_penalty_rules WHERE project_id = $1`, projectID)
+
+	rule, err := scanPenaltyRule(row)
+	if err == pgx.ErrNoRows {
+		return nil, entity.ErrPenaltyRuleNotFound
+	}
+	return rule, err
+}
+
+// FindPenaltyRules returns the penalty rules of all projects
+func (r *PostgresDeductionRepository) FindPenaltyRules(ctx context.Context) ([]*entity.LatePenaltyRule, error) {
+	rows, err := r.pool.Query(ctx, `SELECT `+penaltyRuleColumns+` FROM late_penalty_rules`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var rules []*entity.LatePenaltyRule
+	for rows.Next() {
+		rule, err := scanPenaltyRule(rows)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+	return rules, rows.Err()
+}
+
+func (r *PostgresDeductionRepository) queryDeductions(ctx context.Context, sql string, args ...interface{}) ([]*entity.Deduction, error) {
+	rows, err := r.pool.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var deductions []*entity.Deduction
+	for rows.Next() {
+		d, err := scanDeduction(rows)
+		if err != nil {
+			return nil, err
+		}
+		deductions = append(deductions, d)
+	}
+	return deductions, rows.Err()
+}
+
+func (r *PostgresDeductionRepository) querySchedules(ctx context.Context, sql string, args ...interface{}) ([]*entity.DeductionSchedule, error) {
+	rows, err := r.pool.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var schedules []*entity.DeductionSchedule
+	for rows.Next() {
+		s, err := scanDeductionSchedule(rows)
+		if err != nil {
+			return nil, err
+		}
+		schedules = append(schedules, s)
+	}
+	return schedules, rows.Err()
+}
+
+func scanDeduction(row pgx.Row) (*entity.Deduction, error) {
+	d := &entity.Deduction{}
+	err := row.Scan(
+		&d.ID,
+		&d.ProjectID,
+		&d.ContractID,
+		&d.Category,
+		&d.Reason,
+		&d.Amount,
+		&d.Currency,
+		&d.Date,
+		&d.ScheduleID,
+		&d.PenaltyDays,
+		&d.TransactionID,
+		&d.PayApplicationID,
+		&d.CreatedAt,
+		&d.CreatedBy,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+func scanDeductionSchedule(row pgx.Row) (*entity.DeductionSchedule, error) {
+	s := &entity.DeductionSchedule{}
+	err := row.Scan(
+		&s.ID,
+		&s.ProjectID,
+		&s.ContractID,
+		&s.Category,
+		&s.Reason,
+		&s.Amount,
+		&s.Currency,
+		&s.IntervalMonths,
+		&s.StartDate,
+		&s.EndDate,
+		&s.NextDate,
+		&s.Active,
+		&s.CreatedAt,
+		&s.CreatedBy,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func scanPenaltyRule(row pgx.Row) (*entity.LatePenaltyRule, error) {
+	rule := &entity.LatePenaltyRule{}
+	err := row.Scan(
+		&rule.ProjectID,
+		&rule.DailyRate,
+		&rule.CapRate,
+		&rule.UpdatedAt,
+		&rule.UpdatedBy,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return rule, nil
+}
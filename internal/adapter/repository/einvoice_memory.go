@@ -0,0 +1,91 @@
+// Copyright (c) 2026 Muhammet Ali Büyük. All rights reserved.
+// This source code is proprietary. Confidential and private.
+// Unauthorized copying or distribution is strictly prohibited.
+// Contact: iletisim@alibuyuk.net | Website: alibuyuk.net
+
+package repository
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/qantesm/subflow/internal/core/entity"
+)
+
+// InMemoryEInvoiceRepository keeps generated e-Fatura documents in memory
+type InMemoryEInvoiceRepository struct {
+	mu        sync.RWMutex
+	invoices  map[uuid.UUID]*entity.EInvoice
+	sequences map[string]int64
+}
+
+// NewInMemoryEInvoiceRepository creates a new in-memory e-invoice repository
+func NewInMemoryEInvoiceRepository() *InMemoryEInvoiceRepository {
+	return &InMemoryEInvoiceRepository{
+		invoices:  make(map[uuid.UUID]*entity.EInvoice),
+		sequences: make(map[string]int64),
+	}
+}
+
+// Save stores a new e-invoice, enforcing one document per transaction
+func (r *InMemoryEInvoiceRepository) Save(ctx context.Context, inv *entity.EInvoice) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, existing := range r.invoices {
+		if existing.TransactionID == inv.TransactionID {
+			return entity.ErrEInvoiceExists
+		}
+	}
+	r.invoices[inv.ID] = inv
+	return nil
+}
+
+// Update replaces a stored e-invoice
+func (r *InMemoryEInvoiceRepository) Update(ctx context.Context, inv *entity.EInvoice) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.invoices[inv.ID]; !exists {
+		return entity.ErrEInvoiceNotFound
+	}
+	r.invoices[inv.ID] = inv
+	return nil
+}
+
+// FindByID retrieves an e-invoice by its ID
+func (r *InMemoryEInvoiceRepository) FindByID(ctx context.Context, id uuid.UUID) (*entity.EInvoice, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	inv, exists := r.invoices[id]
+	if !exists {
+		return nil, entity.ErrEInvoiceNotFound
+	}
+	return inv, nil
+}
+
+// FindByTransactionID retrieves the e-invoice generated for a ledger invoice
+func (r *InMemoryEInvoiceRepository) FindByTransactionID(ctx context.Context, transactionID uuid.UUID) (*entity.EInvoice, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, inv := range r.invoices {
+		if inv.TransactionID == transactionID {
+			return inv, nil
+		}
+	}
+	return nil, entity.ErrEInvoiceNotFound
+}
+
+// NextSequence returns the next invoice sequence for a prefix and year
+func (r *InMemoryEInvoiceRepository) NextSequence(ctx context.Context, prefix string, year int) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := fmt.Sprintf("%s%d", prefix, year)
+	r.sequences[key]++
+	return r.sequences[key], nil
+}
@@ -0,0 +1,189 @@
+// Copyright (c) 2026 Muhammet Ali Büyük. All rights reserved.
+// This source code is proprietary. Confidential and private.
+// Unauthorized copying or distribution is strictly prohibited.
+// Contact: iletisim@alibuyuk.net | Website: alibuyuk.net
+
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/qantesm/subflow/internal/core/entity"
+)
+
+// PostgresEInvoiceRepository implements EInvoiceRepository for PostgreSQL
+// Parties and lines are stored as JSONB next to the rendered XML
+type PostgresEInvoiceRepository struct {
+	pool      *Pool
+	architect string
+}
+
+// NewPostgresEInvoiceRepository creates a new PostgreSQL e-invoice repository
+func NewPostgresEInvoiceRepository(pool *Pool) *PostgresEInvoiceRepository {
+	return &PostgresEInvoiceRepository{
+		pool:      pool,
+		architect: "Muhammet-Ali-Buyuk",
+	}
+}
+
+const eInvoiceColumns = `
+	id, project_id, transaction_id, pay_application_id, invoice_no, ettn, profile, type,
+	issue_date, currency, note, seller, buyer, lines, line_extension_cents, kdv_cents,
+	withholding_cents, tax_inclusive_cents, payable_cents, xml, status, integrator_ref,
+	status_message, created_at, updated_at, created_by
+`
+
+// Save stores a new e-invoice
+func (r *PostgresEInvoiceRepository) Save(ctx context.Context, inv *entity.EInvoice) error {
+	seller, err := json.Marshal(inv.Seller)
+	if err != nil {
+		return err
+	}
+	buyer, err := json.Marshal(inv.Buyer)
+	if err != nil {
+		return err
+	}
+	lines, err := json.Marshal(inv.Lines)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.pool.Exec(ctx, `
+		INSERT INTO e_invoices (`+eInvoiceColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23, $24, $25, $26)
+	`,
+		inv.ID,
+		inv.ProjectID,
+		inv.TransactionID,
+		inv.PayApplicationID,
+		inv.InvoiceNo,
+		inv.ETTN,
+		inv.Profile,
+		inv.Type,
+		inv.IssueDate,
+		inv.Currency,
+		inv.Note,
+		seller,
+		buyer,
+		lines,
+		inv.LineExtensionTotal,
+		inv.KDVTotal,
+		inv.WithholdingTotal,
+		inv.TaxInclusiveTotal,
+		inv.PayableAmount,
+		inv.XML,
+		inv.Status,
+		inv.IntegratorRef,
+		inv.StatusMessage,
+		inv.CreatedAt,
+		inv.UpdatedAt,
+		inv.CreatedBy,
+	)
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == "23505" && pgErr.ConstraintName == "e_invoices_transaction_id_key" {
+		return entity.ErrEInvoiceExists
+	}
+	return err
+}
+
+// Update stores the integrator state of an e-invoice; the document itself is immutable
+func (r *PostgresEInvoiceRepository) Update(ctx context.Context, inv *entity.EInvoice) error {
+	inv.UpdatedAt = time.Now()
+	tag, err := r.pool.Exec(ctx, `
+		UPDATE e_invoices SET
+			status = $2,
+			integrator_ref = $3,
+			status_message = $4,
+			updated_at = $5
+		WHERE id = $1
+	`, inv.ID, inv.Status, inv.IntegratorRef, inv.StatusMessage, inv.UpdatedAt)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return entity.ErrEInvoiceNotFound
+	}
+	return nil
+}
+
+// FindByID retrieves an e-invoice by its ID
+func (r *PostgresEInvoiceRepository) FindByID(ctx context.Context, id uuid.UUID) (*entity.EInvoice, error) {
+	row := r.pool.QueryRow(ctx, `SELECT `+eInvoiceColumns+` FROM e_invoices WHERE id = $1`, id)
+	return scanEInvoice(row)
+}
+
+// FindByTransactionID retrieves the e-invoice generated for a ledger invoice
+func (r *PostgresEInvoiceRepository) FindByTransactionID(ctx context.Context, transactionID uuid.UUID) (*entity.EInvoice, error) {
+	row := r.pool.QueryRow(ctx, `SELECT `+eInvoiceColumns+` FROM e_invoices WHERE transaction_id = $1`, transactionID)
+	return scanEInvoice(row)
+}
+
+// NextSequence atomically increments the invoice counter for a prefix and year
+func (r *PostgresEInvoiceRepository) NextSequence(ctx context.Context, prefix string, year int) (int64, error) {
+	var seq int64
+	err := r.pool.QueryRow(ctx, `
+		INSERT INTO e_invoice_sequences (prefix, year, last_value)
+		VALUES ($1, $2, 1)
+		ON CONFLICT (prefix, year) DO UPDATE SET last_value = e_invoice_sequences.last_value + 1
+		RETURNING last_value
+	`, prefix, year).Scan(&seq)
+	return seq, err
+}
+
+func scanEInvoice(row pgx.Row) (*entity.EInvoice, error) {
+	inv := &entity.EInvoice{}
+	var seller, buyer, lines []byte
+
+	err := row.Scan(
+		&inv.ID,
+		&inv.ProjectID,
+		&inv.TransactionID,
+		&inv.PayApplicationID,
+		&inv.InvoiceNo,
+		&inv.ETTN,
+		&inv.Profile,
+		&inv.Type,
+		&inv.IssueDate,
+		&inv.Currency,
+		&inv.Note,
+		&seller,
+		&buyer,
+		&lines,
+		&inv.LineExtensionTotal,
+		&inv.KDVTotal,
+		&inv.WithholdingTotal,
+		&inv.TaxInclusiveTotal,
+		&inv.PayableAmount,
+		&inv.XML,
+		&inv.Status,
+		&inv.IntegratorRef,
+		&inv.StatusMessage,
+		&inv.CreatedAt,
+		&inv.UpdatedAt,
+		&inv.CreatedBy,
+	)
+	if err == pgx.ErrNoRows {
+		return nil, entity.ErrEInvoiceNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(seller, &inv.Seller); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(buyer, &inv.Buyer); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(lines, &inv.Lines); err != nil {
+		return nil, err
+	}
+	return inv, nil
+}
@@ -0,0 +1,122 @@
+// Copyright (c) 2026 Muhammet Ali Büyük. All rights reserved.
+// This source code is proprietary. Confidential and private.
+// Unauthorized copying or distribution is strictly prohibited.
+// Contact: iletisim@alibuyuk.net | Website: alibuyuk.net
+
+package repository
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/qantesm/subflow/internal/core/entity"
+)
+
+// InMemoryNotificationPreferenceRepository keeps users' email preferences in memory
+type InMemoryNotificationPreferenceRepository struct {
+	mu          sync.RWMutex
+	preferences map[uuid.UUID]*entity.NotificationPreference // By user ID
+}
+
+// NewInMemoryNotificationPreferenceRepository creates a new in-memory preference repository
+func NewInMemoryNotificationPreferenceRepository() *InMemoryNotificationPreferenceRepository {
+	return &InMemoryNotificationPreferenceRepository{
+		preferences: make(map[uuid.UUID]*entity.NotificationPreference),
+	}
+}
+
+// Save inserts or replaces the preference of a user
+func (r *InMemoryNotificationPreferenceRepository) Save(ctx context.Context, p *entity.NotificationPreference) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.preferences[p.UserID] = p
+	return nil
+}
+
+// FindByUser retrieves the preference of a user of the tenant
+func (r *InMemoryNotificationPreferenceRepository) FindByUser(ctx context.Context, tenantID, userID uuid.UUID) (*entity.NotificationPreference, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	p, ok := r.preferences[userID]
+	if !ok || p.TenantID != tenantID {
+		return nil, entity.ErrNotificationPreferenceNotFound
+	}
+	return p, nil
+}
+
+// FindByTenant retrieves the preferences of the tenant's users
+func (r *InMemoryNotificationPreferenceRepository) FindByTenant(ctx context.Context, tenantID uuid.UUID) ([]*entity.NotificationPreference, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var result []*entity.NotificationPreference
+	for _, p := range r.preferences {
+		if p.TenantID == tenantID {
+			result = append(result, p)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Email < result[j].Email
+	})
+	return result, nil
+}
+
+// InMemoryEmailDigestRepository keeps emails held for digests in memory
+type InMemoryEmailDigestRepository struct {
+	mu      sync.RWMutex
+	entries []*entity.EmailDigestEntry
+}
+
+// NewInMemoryEmailDigestRepository creates a new in-memory digest repository
+func NewInMemoryEmailDigestRepository() *InMemoryEmailDigestRepository {
+	return &InMemoryEmailDigestRepository{}
+}
+
+// Add holds an email for the recipient's next digest
+func (r *InMemoryEmailDigestRepository) Add(ctx context.Context, entry *entity.EmailDigestEntry) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.entries = append(r.entries, entry)
+	return nil
+}
+
+// FindPending retrieves the emails not yet sent, oldest first
+func (r *InMemoryEmailDigestRepository) FindPending(ctx context.Context) ([]*entity.EmailDigestEntry, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var result []*entity.EmailDigestEntry
+	for _, entry := range r.entries {
+		if entry.SentAt == nil {
+			result = append(result, entry)
+		}
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].CreatedAt.Before(result[j].CreatedAt)
+	})
+	return result, nil
+}
+
+// MarkSent records that the emails went out in a digest
+func (r *InMemoryEmailDigestRepository) MarkSent(ctx context.Context, ids []uuid.UUID, sentAt time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	sent := make(map[uuid.UUID]bool, len(ids))
+	for _, id := range ids {
+		sent[id] = true
+	}
+	for _, entry := range r.entries {
+		if sent[entry.ID] && entry.SentAt == nil {
+			at := sentAt
+			entry.SentAt = &at
+		}
+	}
+	return nil
+}
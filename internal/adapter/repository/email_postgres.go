@@ -0,0 +1,201 @@
+// Copyright (c) 2026 Muhammet Ali Büyük. All rights reserved.
+// This source code is proprietary. Confidential and private.
+// Unauthorized copying or distribution is strictly prohibited.
+// Contact: iletisim@alibuyuk.net | Website: alibuyuk.net
+
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/qantesm/subflow/internal/core/entity"
+)
+
+const notificationPreferenceColumns = `user_id, tenant_id, email, locale, digest, kinds, projects, updated_at`
+
+// PostgresNotificationPreferenceRepository implements NotificationPreferenceRepository for PostgreSQL
+type PostgresNotificationPreferenceRepository struct {
+	pool      *Pool
+	architect string
+}
+
+// NewPostgresNotificationPreferenceRepository creates a new PostgreSQL preference repository
+func NewPostgresNotificationPreferenceRepository(pool *Pool) *PostgresNotificationPreferenceRepository {
+	return &PostgresNotificationPreferenceRepository{
+		pool:      pool,
+		architect: "Muhammet-Ali-Buyuk",
+	}
+}
+
+// Save inserts or replaces the preference of a user
+func (r *PostgresNotificationPreferenceRepository) Save(ctx context.Context, p *entity.NotificationPreference) error {
+	kinds := make([]string, len(p.Kinds))
+	for i, k := range p.Kinds {
+		kinds[i] = string(k)
+	}
+	projects := p.Projects
+	if projects == nil {
+		projects = []uuid.UUID{}
+	}
+
+	_, err := r.pool.Exec(ctx, `
+		INSERT INTO notification_preferences (`+notificationPreferenceColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (user_id) DO UPDATE SET
+			email = EXCLUDED.email,
+			locale = EXCLUDED.locale,
+			digest = EXCLUDED.digest,
+			kinds = EXCLUDED.kinds,
+			projects = EXCLUDED.projects,
+			updated_at = EXCLUDED.updated_at
+	`,
+		p.UserID,
+		p.TenantID,
+		p.Email,
+		p.Locale,
+		p.Digest,
+		kinds,
+		projects,
+		p.UpdatedAt,
+	)
+	return err
+}
+
+// FindByUser retrieves the preference of a user of the tenant
+func (r *PostgresNotificationPreferenceRepository) FindByUser(ctx context.Context, tenantID, userID uuid.UUID) (*entity.NotificationPreference, error) {
+	row := r.pool.QueryRow(ctx, `
+		SELECT `+notificationPreferenceColumns+`
+		FROM notification_preferences
+		WHERE user_id = $1 AND tenant_id = $2
+	`, userID, tenantID)
+
+	p, err := scanNotificationPreference(row)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, entity.ErrNotificationPreferenceNotFound
+	}
+	return p, err
+}
+
+// FindByTenant retrieves the preferences of the tenant's users
+func (r *PostgresNotificationPreferenceRepository) FindByTenant(ctx context.Context, tenantID uuid.UUID) ([]*entity.NotificationPreference, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT `+notificationPreferenceColumns+`
+		FROM notification_preferences
+		WHERE tenant_id = $1
+		ORDER BY email
+	`, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var preferences []*entity.NotificationPreference
+	for rows.Next() {
+		p, err := scanNotificationPreference(rows)
+		if err != nil {
+			return nil, err
+		}
+		preferences = append(preferences, p)
+	}
+	return preferences, rows.Err()
+}
+
+func scanNotificationPreference(row pgx.Row) (*entity.NotificationPreference, error) {
+	p := &entity.NotificationPreference{}
+	var kinds []string
+	if err := row.Scan(
+		&p.UserID,
+		&p.TenantID,
+		&p.Email,
+		&p.Locale,
+		&p.Digest,
+		&kinds,
+		&p.Projects,
+		&p.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+	for _, k := range kinds {
+		p.Kinds = append(p.Kinds, entity.NotificationKind(k))
+	}
+	return p, nil
+}
+
+// PostgresEmailDigestRepository implements EmailDigestRepository for PostgreSQL
+type PostgresEmailDigestRepository struct {
+	pool      *Pool
+	architect string
+}
+
+// NewPostgresEmailDigestRepository creates a new PostgreSQL digest repository
+func NewPostgresEmailDigestRepository(pool *Pool) *PostgresEmailDigestRepository {
+	return &PostgresEmailDigestRepository{
+		pool:      pool,
+		architect: "Muhammet-Ali-Buyuk",
+	}
+}
+
+// Add holds an email for the recipient's next digest
+func (r *PostgresEmailDigestRepository) Add(ctx context.Context, entry *entity.EmailDigestEntry) error {
+	_, err := r.pool.Exec(ctx, `
+		INSERT INTO email_digest_entries (id, user_id, email, locale, notification_id, subject, body, created_at, sent_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`,
+		entry.ID,
+		entry.UserID,
+		entry.Email,
+		entry.Locale,
+		entry.NotificationID,
+		entry.Subject,
+		entry.Body,
+		entry.CreatedAt,
+		entry.SentAt,
+	)
+	return err
+}
+
+// FindPending retrieves the emails not yet sent, oldest first
+func (r *PostgresEmailDigestRepository) FindPending(ctx context.Context) ([]*entity.EmailDigestEntry, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT id, user_id, email, locale, notification_id, subject, body, created_at, sent_at
+		FROM email_digest_entries
+		WHERE sent_at IS NULL
+		ORDER BY created_at
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var entries []*entity.EmailDigestEntry
+	for rows.Next() {
+		entry := &entity.EmailDigestEntry{}
+		if err := rows.Scan(
+			&entry.ID,
+			&entry.UserID,
+			&entry.Email,
+			&entry.Locale,
+			&entry.NotificationID,
+			&entry.Subject,
+			&entry.Body,
+			&entry.CreatedAt,
+			&entry.SentAt,
+		); err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+	return entries, rows.Err()
+}
+
+// MarkSent records that the emails went out in a digest
+func (r *PostgresEmailDigestRepository) MarkSent(ctx context.Context, ids []uuid.UUID, sentAt time.Time) error {
+	_, err := r.pool.Exec(ctx, `
+		UPDATE email_digest_entries SET sent_at = $2 WHERE id = ANY($1) AND sent_at IS NULL
+	`, ids, sentAt)
+	return err
+}
@@ -0,0 +1,86 @@
+// Copyright (c) 2026 Muhammet Ali Büyük. All rights reserved.
+// This source code is proprietary. Confidential and private.
+// Unauthorized copying or distribution is strictly prohibited.
+// Contact: iletisim@alibuyuk.net | Website: alibuyuk.net
+
+package repository
+
+import (
+	"context"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/qantesm/subflow/internal/core/entity"
+)
+
+// InMemoryEscalationRepository keeps escalation formulas and results in memory
+type InMemoryEscalationRepository struct {
+	mu       sync.RWMutex
+	formulas map[uuid.UUID]*entity.EscalationFormula
+	results  []*entity.EscalationResult
+}
+
+// NewInMemoryEscalationRepository creates a new in-memory escalation repository
+func NewInMemoryEscalationRepository() *InMemoryEscalationRepository {
+	return &InMemoryEscalationRepository{
+		formulas: make(map[uuid.UUID]*entity.EscalationFormula),
+	}
+}
+
+// SaveFormula stores a contract formula
+func (r *InMemoryEscalationRepository) SaveFormula(ctx context.Context, formula *entity.EscalationFormula) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.formulas[formula.ID] = formula
+	return nil
+}
+
+// FindFormulaByID retrieves a formula by its ID
+func (r *InMemoryEscalationRepository) FindFormulaByID(ctx context.Context, id uuid.UUID) (*entity.EscalationFormula, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	formula, ok := r.formulas[id]
+	if !ok {
+		return nil, entity.ErrEscalationFormulaNotFound
+	}
+	return formula, nil
+}
+
+// FindFormulasByProject retrieves the formulas of a project
+func (r *InMemoryEscalationRepository) FindFormulasByProject(ctx context.Context, projectID uuid.UUID) ([]*entity.EscalationFormula, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var formulas []*entity.EscalationFormula
+	for _, formula := range r.formulas {
+		if formula.ProjectID == projectID {
+			formulas = append(formulas, formula)
+		}
+	}
+	return formulas, nil
+}
+
+// SaveResult stores a calculated escalation
+func (r *InMemoryEscalationRepository) SaveResult(ctx context.Context, result *entity.EscalationResult) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.results = append(r.results, result)
+	return nil
+}
+
+// FindResultsByProject retrieves the escalations recorded for a project in calculation order
+func (r *InMemoryEscalationRepository) FindResultsByProject(ctx context.Context, projectID uuid.UUID) ([]*entity.EscalationResult, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var results []*entity.EscalationResult
+	for _, result := range r.results {
+		if result.ProjectID == projectID {
+			results = append(results, result)
+		}
+	}
+	return results, nil
+}
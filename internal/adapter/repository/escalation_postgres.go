@@ -0,0 +1,188 @@
+// Copyright (c) 2026 Muhammet Ali Büyük. All rights reserved.
+// This source code is proprietary. Confidential and private.
+// Unauthorized copying or distribution is strictly prohibited.
+// Contact: iletisim@alibuyuk.net | Website: alibuyuk.net
+
+package repository
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/qantesm/subflow/internal/core/entity"
+)
+
+// PostgresEscalationRepository implements EscalationRepository for PostgreSQL
+type PostgresEscalationRepository struct {
+	pool      *Pool
+	architect string
+}
+
+// NewPostgresEscalationRepository creates a new PostgreSQL escalation repository
+func NewPostgresEscalationRepository(pool *Pool) *PostgresEscalationRepository {
+	return &PostgresEscalationRepository{
+		pool:      pool,
+		architect: "Muhammet-Ali-Buyuk",
+	}
+}
+
+const escalationFormulaColumns = `
+	id, project_id, name, base_month, coefficient_bp, index_lag_months, components, created_at, created_by
+`
+
+const escalationResultColumns = `
+	id, project_id, pay_application_id, formula_id, work_amount_cents, coefficient_bp,
+	index_month, pn, amount_cents, indices, calculated_at, created_by
+`
+
+// SaveFormula stores a contract formula
+func (r *PostgresEscalationRepository) SaveFormula(ctx context.Context, formula *entity.EscalationFormula) error {
+	components, err := json.Marshal(formula.Components)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.pool.Exec(ctx, `
+		INSERT INTO escalation_formulas (`+escalationFormulaColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`,
+		formula.ID,
+		formula.ProjectID,
+		formula.Name,
+		formula.BaseMonth,
+		formula.Coefficient,
+		formula.IndexLagMonths,
+		components,
+		formula.CreatedAt,
+		formula.CreatedBy,
+	)
+	return err
+}
+
+// FindFormulaByID retrieves a formula by its ID
+func (r *PostgresEscalationRepository) FindFormulaByID(ctx context.Context, id uuid.UUID) (*entity.EscalationFormula, error) {
+	row := r.pool.QueryRow(ctx, `SELECT `+escalationFormulaColumns+` FROM escalation_formulas WHERE id = $1`, id)
+
+	formula, err := scanEscalationFormula(row)
+	if err == pgx.ErrNoRows {
+		return nil, entity.ErrEscalationFormulaNotFound
+	}
+	return formula, err
+}
+
+// FindFormulasByProject retrieves the formulas of a project
+func (r *PostgresEscalationRepository) FindFormulasByProject(ctx context.Context, projectID uuid.UUID) ([]*entity.EscalationFormula, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT `+escalationFormulaColumns+`
+		FROM escalation_formulas
+		WHERE project_id = $1
+		ORDER BY created_at
+	`, projectID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var formulas []*entity.EscalationFormula
+	for rows.Next() {
+		formula, err := scanEscalationFormula(rows)
+		if err != nil {
+			return nil, err
+		}
+		formulas = append(formulas, formula)
+	}
+	return formulas, rows.Err()
+}
+
+// SaveResult stores a calculated escalation with the indices used
+func (r *PostgresEscalationRepository) SaveResult(ctx context.Context, result *entity.EscalationResult) error {
+	indices, err := json.Marshal(result.Indices)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.pool.Exec(ctx, `
+		INSERT INTO escalation_results (`+escalationResultColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+	`,
+		result.ID,
+		result.ProjectID,
+		result.PayApplicationID,
+		result.FormulaID,
+		result.WorkAmount,
+		result.Coefficient,
+		result.IndexMonth,
+		result.Pn,
+		result.Amount,
+		indices,
+		result.CalculatedAt,
+		result.CreatedBy,
+	)
+	return err
+}
+
+// FindResultsByProject retrieves the escalations recorded for a project in calculation order
+func (r *PostgresEscalationRepository) FindResultsByProject(ctx context.Context, projectID uuid.UUID) ([]*entity.EscalationResult, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT `+escalationResultColumns+`
+		FROM escalation_results
+		WHERE project_id = $1
+		ORDER BY calculated_at
+	`, projectID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []*entity.EscalationResult
+	for rows.Next() {
+		result := &entity.EscalationResult{}
+		var indices []byte
+		if err := rows.Scan(
+			&result.ID,
+			&result.ProjectID,
+			&result.PayApplicationID,
+			&result.FormulaID,
+			&result.WorkAmount,
+			&result.Coefficient,
+			&result.IndexMonth,
+			&result.Pn,
+			&result.Amount,
+			&indices,
+			&result.CalculatedAt,
+			&result.CreatedBy,
+		); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(indices, &result.Indices); err != nil {
+			return nil, err
+		}
+		results = append(results, result)
+	}
+	return results, rows.Err()
+}
+
+func scanEscalationFormula(row pgx.Row) (*entity.EscalationFormula, error) {
+	formula := &entity.EscalationFormula{}
+	var components []byte
+	err := row.Scan(
+		&formula.ID,
+		&formula.ProjectID,
+		&formula.Name,
+		&formula.BaseMonth,
+		&formula.Coefficient,
+		&formula.IndexLagMonths,
+		&components,
+		&formula.CreatedAt,
+		&formula.CreatedBy,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(components, &formula.Components); err != nil {
+		return nil, err
+	}
+	return formula, nil
+}
@@ -0,0 +1,93 @@
+// Copyright (c) 2026 Muhammet Ali Büyük. All rights reserved.
+// This source code is proprietary. Confidential and private.
+// Unauthorized copying or distribution is strictly prohibited.
+// Contact: iletisim@alibuyuk.net | Website: alibuyuk.net
+
+package repository
+
+import (
+	"context"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/qantesm/subflow/internal/core/entity"
+)
+
+// InMemoryGuaranteeRepository keeps bank guarantee letters in memory
+type InMemoryGuaranteeRepository struct {
+	mu         sync.RWMutex
+	guarantees map[uuid.UUID]*entity.BankGuarantee
+}
+
+// NewInMemoryGuaranteeRepository creates a new in-memory guarantee repository
+func NewInMemoryGuaranteeRepository() *InMemoryGuaranteeRepository {
+	return &InMemoryGuaranteeRepository{
+		guarantees: make(map[uuid.UUID]*entity.BankGuarantee),
+	}
+}
+
+// Save stores a new guarantee letter
+func (r *InMemoryGuaranteeRepository) Save(ctx context.Context, g *entity.BankGuarantee) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, existing := range r.guarantees {
+		if existing.Bank == g.Bank && existing.LetterNo == g.LetterNo {
+			return entity.ErrGuaranteeExists
+		}
+	}
+	r.guarantees[g.ID] = g
+	return nil
+}
+
+// Update stores the changed state of a guarantee letter
+func (r *InMemoryGuaranteeRepository) Update(ctx context.Context, g *entity.BankGuarantee) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.guarantees[g.ID]; !ok {
+		return entity.ErrGuaranteeNotFound
+	}
+	r.guarantees[g.ID] = g
+	return nil
+}
+
+// FindByID retrieves a guarantee by its ID
+func (r *InMemoryGuaranteeRepository) FindByID(ctx context.Context, id uuid.UUID) (*entity.BankGuarantee, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	g, ok := r.guarantees[id]
+	if !ok {
+		return nil, entity.ErrGuaranteeNotFound
+	}
+	return g, nil
+}
+
+// FindByProjectID retrieves the guarantees of a project
+func (r *InMemoryGuaranteeRepository) FindByProjectID(ctx context.Context, projectID uuid.UUID) ([]*entity.BankGuarantee, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var result []*entity.BankGuarantee
+	for _, g := range r.guarantees {
+		if g.ProjectID == projectID {
+			result = append(result, g)
+		}
+	}
+	return result, nil
+}
+
+// FindActive retrieves the active guarantees of all projects
+func (r *InMemoryGuaranteeRepository) FindActive(ctx context.Context) ([]*entity.BankGuarantee, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var result []*entity.BankGuarantee
+	for _, g := range r.guarantees {
+		if g.IsActive() {
+			result = append(result, g)
+		}
+	}
+	return result, nil
+}
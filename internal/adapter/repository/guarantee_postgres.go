@@ -0,0 +1,191 @@
+// Copyright (c) 2026 Muhammet Ali Büyük. All rights reserved.
+// This source code is proprietary. Confidential and private.
+// Unauthorized copying or distribution is strictly prohibited.
+// Contact: iletisim@alibuyuk.net | Website: alibuyuk.net
+
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/qantesm/subflow/internal/core/entity"
+)
+
+// PostgresGuaranteeRepository implements GuaranteeRepository for PostgreSQL
+type PostgresGuaranteeRepository struct {
+	pool      *Pool
+	architect string
+}
+
+// NewPostgresGuaranteeRepository creates a new PostgreSQL guarantee repository
+func NewPostgresGuaranteeRepository(pool *Pool) *PostgresGuaranteeRepository {
+	return &PostgresGuaranteeRepository{
+		pool:      pool,
+		architect: "Muhammet-Ali-Buyuk",
+	}
+}
+
+const guaranteeColumns = `
+	id, project_id, contract_id, bank, letter_no, type, original_amount_cents, amount_cents, currency,
+	issue_date, expiry_date, status, reductions, returned_at, expiry_alerted_at, created_at, updated_at, created_by
+`
+
+// Save stores a new guarantee letter
+func (r *PostgresGuaranteeRepository) Save(ctx context.Context, g *entity.BankGuarantee) error {
+	reductions, err := json.Marshal(g.Reductions)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.pool.Exec(ctx, `
+		INSERT INTO bank_guarantees (`+guaranteeColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18)
+	`,
+		g.ID,
+		g.ProjectID,
+		g.ContractID,
+		g.Bank,
+		g.LetterNo,
+		g.Type,
+		g.OriginalAmount,
+		g.Amount,
+		g.Currency,
+		g.IssueDate,
+		g.ExpiryDate,
+		g.Status,
+		reductions,
+		g.ReturnedAt,
+		g.ExpiryAlertedAt,
+		g.CreatedAt,
+		g.UpdatedAt,
+		g.CreatedBy,
+	)
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == "23505" && pgErr.ConstraintName == "bank_guarantees_bank_letter_no_key" {
+		return entity.ErrGuaranteeExists
+	}
+	return err
+}
+
+// Update stores the changed state of a guarantee letter
+func (r *PostgresGuaranteeRepository) Update(ctx context.Context, g *entity.BankGuarantee) error {
+	reductions, err := json.Marshal(g.Reductions)
+	if err != nil {
+		return err
+	}
+
+	tag, err := r.pool.Exec(ctx, `
+		UPDATE bank_guarantees SET
+			amount_cents = $2,
+			expiry_date = $3,
+			status = $4,
+			reductions = $5,
+			returned_at = $6,
+			expiry_alerted_at = $7,
+			updated_at = $8
+		WHERE id = $1
+	`,
+		g.ID,
+		g.Amount,
+		g.ExpiryDate,
+		g.Status,
+		reductions,
+		g.ReturnedAt,
+		g.ExpiryAlertedAt,
+		g.UpdatedAt,
+	)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return entity.ErrGuaranteeNotFound
+	}
+	return nil
+}
+
+// FindByID retrieves a guarantee by its ID
+func (r *PostgresGuaranteeRepository) FindByID(ctx context.Context, id uuid.UUID) (*entity.BankGuarantee, error) {
+	row := r.pool.QueryRow(ctx, `SELECT `+guaranteeColumns+` FROM bank_guarantees WHERE id = $1`, id)
+
+	g, err := scanGuarantee(row)
+	if err == pgx.ErrNoRows {
+		return nil, entity.ErrGuaranteeNotFound
+	}
+	return g, err
+}
+
+// FindByProjectID retrieves the guarantees of a project
+func (r *PostgresGuaranteeRepository) FindByProjectID(ctx context.Context, projectID uuid.UUID) ([]*entity.BankGuarantee, error) {
+	return r.query(ctx, `
+		SELECT `+guaranteeColumns+`
+		FROM bank_guarantees
+		WHERE project_id = $1
+		ORDER BY issue_date
+	`, projectID)
+}
+
+// FindActive retrieves the active guarantees of all projects
+func (r *PostgresGuaranteeRepository) FindActive(ctx context.Context) ([]*entity.BankGuarantee, error) {
+	return r.query(ctx, `
+		SELECT `+guaranteeColumns+`
+		FROM bank_guarantees
+		WHERE status = 'ACTIVE'
+		ORDER BY expiry_date NULLS LAST
+	`)
+}
+
+func (r *PostgresGuaranteeRepository) query(ctx context.Context, sql string, args ...interface{}) ([]*entity.BankGuarantee, error) {
+	rows, err := r.pool.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var guarantees []*entity.BankGuarantee
+	for rows.Next() {
+		g, err := scanGuarantee(rows)
+		if err != nil {
+			return nil, err
+		}
+		guarantees = append(guarantees, g)
+	}
+	return guarantees, rows.Err()
+}
+
+func scanGuarantee(row pgx.Row) (*entity.BankGuarantee, error) {
+	g := &entity.BankGuarantee{}
+	var reductions []byte
+	err := row.Scan(
+		&g.ID,
+		&g.ProjectID,
+		&g.ContractID,
+		&g.Bank,
+		&g.LetterNo,
+		&g.Type,
+		&g.OriginalAmount,
+		&g.Amount,
+		&g.Currency,
+		&g.IssueDate,
+		&g.ExpiryDate,
+		&g.Status,
+		&reductions,
+		&g.ReturnedAt,
+		&g.ExpiryAlertedAt,
+		&g.CreatedAt,
+		&g.UpdatedAt,
+		&g.CreatedBy,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(reductions, &g.Reductions); err != nil {
+		return nil, err
+	}
+	return g, nil
+}
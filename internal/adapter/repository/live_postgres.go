@@ -0,0 +1,92 @@
+// Copyright (c) 2026 Muhammet Ali Büyük. All rights reserved.
+// This source code is proprietary. Confidential and private.
+// Unauthorized copying or distribution is strictly prohibited.
+// Contact: iletisim@alibuyuk.net | Website: alibuyuk.net
+
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/qantesm/subflow/internal/core/service"
+)
+
+const (
+	liveChannel        = "subflow_live"
+	maxNotifyPayload   = 7900 // Postgres rejects NOTIFY payloads of 8000 bytes and more
+	liveReconnectDelay = 2 * time.Second
+)
+
+// PostgresLiveBroker carries live messages between API instances with LISTEN/NOTIFY
+type PostgresLiveBroker struct {
+	pool      *Pool
+	architect string
+}
+
+// NewPostgresLiveBroker creates a new PostgreSQL live message broker
+func NewPostgresLiveBroker(pool *Pool) *PostgresLiveBroker {
+	return &PostgresLiveBroker{
+		pool:      pool,
+		architect: "Muhammet-Ali-Buyuk",
+	}
+}
+
+// Publish notifies every listening instance. A message too large for NOTIFY
+// is sent without its data and clients fetch the change themselves.
+func (b *PostgresLiveBroker) Publish(ctx context.Context, msg *service.LiveMessage) error {
+	payload, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	if len(payload) > maxNotifyPayload {
+		stripped := *msg
+		stripped.Data = nil
+		if payload, err = json.Marshal(&stripped); err != nil {
+			return err
+		}
+	}
+
+	_, err = b.pool.Exec(ctx, `SELECT pg_notify($1, $2)`, liveChannel, string(payload))
+	return err
+}
+
+// Listen delivers notifications until ctx is done. A lost connection is
+// re-established; clients fill the gap by resuming with Last-Event-ID.
+func (b *PostgresLiveBroker) Listen(ctx context.Context, deliver func(*service.LiveMessage)) error {
+	for {
+		_ = b.listen(ctx, deliver)
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(liveReconnectDelay):
+		}
+	}
+}
+
+// listen holds one connection out of the pool for LISTEN until it fails
+func (b *PostgresLiveBroker) listen(ctx context.Context, deliver func(*service.LiveMessage)) error {
+	pooled, err := b.pool.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	conn := pooled.Hijack()
+	defer conn.Close(context.Background())
+
+	if _, err := conn.Exec(ctx, `LISTEN `+pgx.Identifier{liveChannel}.Sanitize()); err != nil {
+		return err
+	}
+	for {
+		n, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+		var msg service.LiveMessage
+		if err := json.Unmarshal([]byte(n.Payload), &msg); err != nil {
+			continue
+		}
+		deliver(&msg)
+	}
+}
@@ -0,0 +1,73 @@
+// Copyright (c) 2026 Muhammet Ali Büyük. All rights reserved.
+// This source code is proprietary. Confidential and private.
+// Unauthorized copying or distribution is strictly prohibited.
+// Contact: iletisim@alibuyuk.net | Website: alibuyuk.net
+
+package repository
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/qantesm/subflow/internal/core/entity"
+)
+
+// InMemoryNotificationRepository keeps the notification inbox in memory
+type InMemoryNotificationRepository struct {
+	mu            sync.RWMutex
+	notifications map[uuid.UUID]*entity.Notification
+}
+
+// NewInMemoryNotificationRepository creates a new in-memory notification repository
+func NewInMemoryNotificationRepository() *InMemoryNotificationRepository {
+	return &InMemoryNotificationRepository{
+		notifications: make(map[uuid.UUID]*entity.Notification),
+	}
+}
+
+// Save stores a notification
+func (r *InMemoryNotificationRepository) Save(ctx context.Context, n *entity.Notification) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.notifications[n.ID]; ok {
+		return entity.ErrNotificationExists
+	}
+	r.notifications[n.ID] = n
+	return nil
+}
+
+// MarkRead sets the read time of a notification
+func (r *InMemoryNotificationRepository) MarkRead(ctx context.Context, id uuid.UUID, readAt time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	n, ok := r.notifications[id]
+	if !ok {
+		return entity.ErrNotificationNotFound
+	}
+	if n.ReadAt == nil {
+		n.ReadAt = &readAt
+	}
+	return nil
+}
+
+// FindByProjectID retrieves the notifications of a project, newest first
+func (r *InMemoryNotificationRepository) FindByProjectID(ctx context.Context, projectID uuid.UUID, unreadOnly bool) ([]*entity.Notification, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var result []*entity.Notification
+	for _, n := range r.notifications {
+		if n.ProjectID == projectID && (!unreadOnly || n.ReadAt == nil) {
+			result = append(result, n)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].CreatedAt.After(result[j].CreatedAt)
+	})
+	return result, nil
+}
@@ -0,0 +1,104 @@
+// Copyright (c) 2026 Muhammet Ali Büyük. All rights reserved.
+// This source code is proprietary. Confidential and private.
+// Unauthorized copying or distribution is strictly prohibited.
+// Contact: iletisim@alibuyuk.net | Website: alibuyuk.net
+
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/qantesm/subflow/internal/core/entity"
+)
+
+// PostgresNotificationRepository implements NotificationRepository for PostgreSQL
+type PostgresNotificationRepository struct {
+	pool      *Pool
+	architect string
+}
+
+// NewPostgresNotificationRepository creates a new PostgreSQL notification repository
+func NewPostgresNotificationRepository(pool *Pool) *PostgresNotificationRepository {
+	return &PostgresNotificationRepository{
+		pool:      pool,
+		architect: "Muhammet-Ali-Buyuk",
+	}
+}
+
+// Save stores a notification
+func (r *PostgresNotificationRepository) Save(ctx context.Context, n *entity.Notification) error {
+	_, err := r.pool.Exec(ctx, `
+		INSERT INTO notifications (id, project_id, kind, severity, subject, body, reference_id, params, created_at, read_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+	`,
+		n.ID,
+		n.ProjectID,
+		n.Kind,
+		n.Severity,
+		n.Subject,
+		n.Body,
+		n.ReferenceID,
+		n.Params,
+		n.CreatedAt,
+		n.ReadAt,
+	)
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == "23505" && pgErr.ConstraintName == "notifications_pkey" {
+		return entity.ErrNotificationExists
+	}
+	return err
+}
+
+// MarkRead sets the read time of a notification
+func (r *PostgresNotificationRepository) MarkRead(ctx context.Context, id uuid.UUID, readAt time.Time) error {
+	tag, err := r.pool.Exec(ctx, `
+		UPDATE notifications SET read_at = COALESCE(read_at, $2) WHERE id = $1
+	`, id, readAt)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return entity.ErrNotificationNotFound
+	}
+	return nil
+}
+
+// FindByProjectID retrieves the notifications of a project, newest first
+func (r *PostgresNotificationRepository) FindByProjectID(ctx context.Context, projectID uuid.UUID, unreadOnly bool) ([]*entity.Notification, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT id, project_id, kind, severity, subject, body, reference_id, params, created_at, read_at
+		FROM notifications
+		WHERE project_id = $1 AND ($2 = FALSE OR read_at IS NULL)
+		ORDER BY created_at DESC
+	`, projectID, unreadOnly)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var notifications []*entity.Notification
+	for rows.Next() {
+		n := &entity.Notification{}
+		if err := rows.Scan(
+			&n.ID,
+			&n.ProjectID,
+			&n.Kind,
+			&n.Severity,
+			&n.Subject,
+			&n.Body,
+			&n.ReferenceID,
+			&n.Params,
+			&n.CreatedAt,
+			&n.ReadAt,
+		); err != nil {
+			return nil, err
+		}
+		notifications = append(notifications, n)
+	}
+	return notifications, rows.Err()
+}
@@ -0,0 +1,107 @@
+// Copyright (c) 2026 Muhammet Ali Büyük. All rights reserved.
+// This source code is proprietary. Confidential and private.
+// Unauthorized copying or distribution is strictly prohibited.
+// Contact: iletisim@alibuyuk.net | Website: alibuyuk.net
+
+package repository
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/qantesm/subflow/internal/core/entity"
+)
+
+// InMemoryOutbox is the transactional outbox of the in-memory repositories.
+// Repositories given the outbox with SetOutbox append their events while they
+// still hold their own lock, so the change and its events land together.
+type InMemoryOutbox struct {
+	mu      sync.RWMutex
+	entries map[uuid.UUID]*entity.OutboxEntry
+}
+
+// NewInMemoryOutbox creates an empty in-memory outbox
+func NewInMemoryOutbox() *InMemoryOutbox {
+	return &InMemoryOutbox{
+		entries: make(map[uuid.UUID]*entity.OutboxEntry),
+	}
+}
+
+// Append queues events; an event already in the outbox is not queued twice
+func (o *InMemoryOutbox) Append(events ...*entity.Event) {
+	if o == nil || len(events) == 0 {
+		return
+	}
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	for _, event := range events {
+		if _, exists := o.entries[event.ID]; !exists {
+			o.entries[event.ID] = entity.NewOutboxEntry(event)
+		}
+	}
+}
+
+// ClaimPending returns the unpublished entries due at now, oldest first, and leases them
+func (o *InMemoryOutbox) ClaimPending(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*entity.OutboxEntry, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	var due []*entity.OutboxEntry
+	for _, e := range o.entries {
+		if e.PublishedAt == nil && !e.NextAttemptAt.After(now) {
+			due = append(due, e)
+		}
+	}
+	sortOutboxEntries(due)
+	if limit > 0 && len(due) > limit {
+		due = due[:limit]
+	}
+
+	claimed := make([]*entity.OutboxEntry, len(due))
+	for i, e := range due {
+		e.Attempts++
+		e.NextAttemptAt = now.Add(lease)
+		c := *e
+		claimed[i] = &c
+	}
+	return claimed, nil
+}
+
+// MarkPublished settles an entry
+func (o *InMemoryOutbox) MarkPublished(ctx context.Context, id uuid.UUID, at time.Time) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	e, ok := o.entries[id]
+	if !ok {
+		return entity.ErrOutboxEntryNotFound
+	}
+	e.PublishedAt = &at
+	e.LastError = ""
+	return nil
+}
+
+// MarkFailed records a failed attempt and when to try again
+func (o *InMemoryOutbox) MarkFailed(ctx context.Context, id uuid.UUID, reason string, next time.Time) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	e, ok := o.entries[id]
+	if !ok {
+		return entity.ErrOutboxEntryNotFound
+	}
+	e.LastError = reason
+	e.NextAttemptAt = next
+	return nil
+}
+
+// sortOutboxEntries orders entries oldest first
+func sortOutboxEntries(entries []*entity.OutboxEntry) {
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].OccurredAt.Before(entries[j].OccurredAt)
+	})
+}
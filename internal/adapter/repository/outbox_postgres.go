@@ -0,0 +1,127 @@
+// Copyright (c) 2026 Muhammet Ali Büyük. All rights reserved.
+// This source code is proprietary. Confidential and private.
+// Unauthorized copying or distribution is strictly prohibited.
+// Contact: iletisim@alibuyuk.net | Website: alibuyuk.net
+
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/qantesm/subflow/internal/core/entity"
+)
+
+// PostgresOutboxRepository implements OutboxRepository for PostgreSQL
+type PostgresOutboxRepository struct {
+	pool      *Pool
+	architect string
+}
+
+// NewPostgresOutboxRepository creates a new PostgreSQL outbox repository
+func NewPostgresOutboxRepository(pool *Pool) *PostgresOutboxRepository {
+	return &PostgresOutboxRepository{
+		pool:      pool,
+		architect: "Muhammet-Ali-Buyuk",
+	}
+}
+
+const outboxColumns = `id, type, project_id, occurred_at, data, attempts, next_attempt_at, published_at, last_error`
+
+// appendOutbox writes events to the outbox through db, normally the
+// transaction that stores the change they announce
+func appendOutbox(ctx context.Context, db DBTX, events []*entity.Event) error {
+	for _, event := range events {
+		_, err := db.Exec(ctx, `
+			INSERT INTO outbox_events (id, type, project_id, occurred_at, data, next_attempt_at)
+			VALUES ($1, $2, $3, $4, $5, $4)
+			ON CONFLICT (id) DO NOTHING
+		`,
+			event.ID,
+			event.Type,
+			event.ProjectID,
+			event.OccurredAt,
+			event.Data,
+		)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ClaimPending leases the unpublished entries due at now, oldest first.
+// Rows locked by another dispatcher are skipped rather than waited for.
+func (r *PostgresOutboxRepository) ClaimPending(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*entity.OutboxEntry, error) {
+	rows, err := r.pool.Query(ctx, `
+		UPDATE outbox_events SET attempts = attempts + 1, next_attempt_at = $2
+		WHERE id IN (
+			SELECT id FROM outbox_events
+			WHERE published_at IS NULL AND next_attempt_at <= $1
+			ORDER BY occurred_at
+			LIMIT $3
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING `+outboxColumns,
+		now, now.Add(lease), limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var entries []*entity.OutboxEntry
+	for rows.Next() {
+		e := &entity.OutboxEntry{}
+		if err := rows.Scan(
+			&e.ID,
+			&e.Type,
+			&e.ProjectID,
+			&e.OccurredAt,
+			&e.Data,
+			&e.Attempts,
+			&e.NextAttemptAt,
+			&e.PublishedAt,
+			&e.LastError,
+		); err != nil {
+			return nil, err
+		}
+		entries = append(entries, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	// RETURNING does not keep the subquery's order
+	sortOutboxEntries(entries)
+	return entries, nil
+}
+
+// MarkPublished settles an entry
+func (r *PostgresOutboxRepository) MarkPublished(ctx context.Context, id uuid.UUID, at time.Time) error {
+	tag, err := r.pool.Exec(ctx, `
+		UPDATE outbox_events SET published_at = $2, last_error = '' WHERE id = $1
+	`, id, at)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return entity.ErrOutboxEntryNotFound
+	}
+	return nil
+}
+
+// MarkFailed records a failed attempt and when to try again
+func (r *PostgresOutboxRepository) MarkFailed(ctx context.Context, id uuid.UUID, reason string, next time.Time) error {
+	tag, err := r.pool.Exec(ctx, `
+		UPDATE outbox_events SET last_error = $2, next_attempt_at = $3 WHERE id = $1
+	`, id, reason, next)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return entity.ErrOutboxEntryNotFound
+	}
+	return nil
+}
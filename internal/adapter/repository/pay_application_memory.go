@@ -0,0 +1,85 @@
+// Copyright (c) 2026 Muhammet Ali Büyük. All rights reserved.
+// This source code is proprietary. Confidential and private.
+// Unauthorized copying or distribution is strictly prohibited.
+// Contact: iletisim@alibuyuk.net | Website: alibuyuk.net
+
+package repository
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/qantesm/subflow/internal/core/entity"
+)
+
+// InMemoryPayApplicationRepository keeps pay applications in memory
+type InMemoryPayApplicationRepository struct {
+	mu     sync.RWMutex
+	apps   map[uuid.UUID]*entity.PayApplication
+	outbox *InMemoryOutbox // Optional: receives the events of every update
+}
+
+// NewInMemoryPayApplicationRepository creates a new in-memory pay application repository
+func NewInMemoryPayApplicationRepository() *InMemoryPayApplicationRepository {
+	return &InMemoryPayApplicationRepository{
+		apps: make(map[uuid.UUID]*entity.PayApplication),
+	}
+}
+
+// Save stores a new pay application
+func (r *InMemoryPayApplicationRepository) Save(ctx context.Context, app *entity.PayApplication) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.apps[app.ID] = app
+	return nil
+}
+
+// SetOutbox makes updates append their events to the outbox; without one they are dropped
+func (r *InMemoryPayApplicationRepository) SetOutbox(outbox *InMemoryOutbox) {
+	r.outbox = outbox
+}
+
+// Update replaces a stored pay application
+func (r *InMemoryPayApplicationRepository) Update(ctx context.Context, app *entity.PayApplication, events ...*entity.Event) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.apps[app.ID]; !exists {
+		return entity.ErrPayApplicationNotFound
+	}
+	r.apps[app.ID] = app
+	r.outbox.Append(events...)
+	return nil
+}
+
+// FindByID retrieves a pay application by its ID
+func (r *InMemoryPayApplicationRepository) FindByID(ctx context.Context, id uuid.UUID) (*entity.PayApplication, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	app, exists := r.apps[id]
+	if !exists {
+		return nil, entity.ErrPayApplicationNotFound
+	}
+	return app, nil
+}
+
+// FindByProjectID retrieves all applications of a project ordered by number
+func (r *InMemoryPayApplicationRepository) FindByProjectID(ctx context.Context, projectID uuid.UUID) ([]*entity.PayApplication, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var result []*entity.PayApplication
+	for _, app := range r.apps {
+		if app.ProjectID == projectID {
+			result = append(result, app)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].ApplicationNo < result[j].ApplicationNo
+	})
+	return result, nil
+}
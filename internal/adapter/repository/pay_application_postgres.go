@@ -0,0 +1,205 @@
+// Copyright (c) 2026 Muhammet Ali Büyük. All rights reserved.
+// This source code is proprietary. Confidential and private.
+// Unauthorized copying or distribution is strictly prohibited.
+// Contact: iletisim@alibuyuk.net | Website: alibuyuk.net
+
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/qantesm/subflow/internal/core/entity"
+)
+
+// PostgresPayApplicationRepository implements PayApplicationRepository for PostgreSQL
+type PostgresPayApplicationRepository struct {
+	pool      *Pool
+	architect string
+}
+
+// NewPostgresPayApplicationRepository creates a new PostgreSQL pay application repository
+func NewPostgresPayApplicationRepository(pool *Pool) *PostgresPayApplicationRepository {
+	return &PostgresPayApplicationRepository{
+		pool:      pool,
+		architect: "Muhammet-Ali-Buyuk",
+	}
+}
+
+const payApplicationColumns = `
+	id, project_id, application_no, period_start, period_end, currency, status,
+	contract_sum_cents, previous_work_cents, current_work_cents, stored_materials_cents,
+	total_completed_cents, total_retainage_cents, total_earned_cents, previous_certificates_cents,
+	current_payment_due_cents, price_escalation_cents, advance_recovery_cents, deductions_cents, tax, invoice_transaction_id, submitted_at, certified_at, certified_by,
+	rejection_reason, created_at, updated_at, created_by
+`
+
+// Save stores a new pay application
+func (r *PostgresPayApplicationRepository) Save(ctx context.Context, app *entity.PayApplication) error {
+	var tax []byte
+	if app.Tax != nil {
+		var err error
+		if tax, err = json.Marshal(app.Tax); err != nil {
+			return err
+		}
+	}
+
+	query := `
+		INSERT INTO pay_applications (` + payApplicationColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23, $24, $25, $26, $27, $28)
+	`
+
+	_, err := r.pool.Exec(ctx, query,
+		app.ID,
+		app.ProjectID,
+		app.ApplicationNo,
+		app.PeriodStart,
+		app.PeriodEnd,
+		app.Currency,
+		app.Status,
+		app.ContractSum,
+		app.PreviousWorkCompleted,
+		app.CurrentWorkCompleted,
+		app.StoredMaterials,
+		app.TotalCompletedAndStored,
+		app.TotalRetainage,
+		app.TotalEarned,
+		app.PreviousCertificates,
+		app.CurrentPaymentDue,
+		app.PriceEscalation,
+		app.AdvanceRecovery,
+		app.Deductions,
+		tax,
+		app.InvoiceTransactionID,
+		app.SubmittedAt,
+		app.CertifiedAt,
+		app.CertifiedBy,
+		app.RejectionReason,
+		app.CreatedAt,
+		app.UpdatedAt,
+		app.CreatedBy,
+	)
+	return err
+}
+
+// Update stores the workflow state of a pay application and its outbox events in one transaction
+// The G702 snapshot is immutable once created
+func (r *PostgresPayApplicationRepository) Update(ctx context.Context, app *entity.PayApplication, events ...*entity.Event) error {
+	query := `
+		UPDATE pay_applications SET
+			status = $2,
+			invoice_transaction_id = $3,
+			submitted_at = $4,
+			certified_at = $5,
+			certified_by = $6,
+			rejection_reason = $7,
+			updated_at = $8
+		WHERE id = $1
+	`
+
+	app.UpdatedAt = time.Now()
+	return r.pool.WithTx(ctx, func(tx pgx.Tx) error {
+		tag, err := tx.Exec(ctx, query,
+			app.ID,
+			app.Status,
+			app.InvoiceTransactionID,
+			app.SubmittedAt,
+			app.CertifiedAt,
+			app.CertifiedBy,
+			app.RejectionReason,
+			app.UpdatedAt,
+		)
+		if err != nil {
+			return err
+		}
+		if tag.RowsAffected() == 0 {
+			return entity.ErrPayApplicationNotFound
+		}
+		return appendOutbox(ctx, tx, events)
+	})
+}
+
+// FindByID retrieves a pay application by its ID
+func (r *PostgresPayApplicationRepository) FindByID(ctx context.Context, id uuid.UUID) (*entity.PayApplication, error) {
+	row := r.pool.QueryRow(ctx, `SELECT `+payApplicationColumns+` FROM pay_applications WHERE id = $1`, id)
+
+	app, err := scanPayApplication(row)
+	if err == pgx.ErrNoRows {
+		return nil, entity.ErrPayApplicationNotFound
+	}
+	return app, err
+}
+
+// FindByProjectID retrieves all applications of a project ordered by number
+func (r *PostgresPayApplicationRepository) FindByProjectID(ctx context.Context, projectID uuid.UUID) ([]*entity.PayApplication, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT `+payApplicationColumns+`
+		FROM pay_applications
+		WHERE project_id = $1
+		ORDER BY application_no
+	`, projectID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var apps []*entity.PayApplication
+	for rows.Next() {
+		app, err := scanPayApplication(rows)
+		if err != nil {
+			return nil, err
+		}
+		apps = append(apps, app)
+	}
+	return apps, rows.Err()
+}
+
+func scanPayApplication(row pgx.Row) (*entity.PayApplication, error) {
+	app := &entity.PayApplication{}
+	var tax []byte
+	err := row.Scan(
+		&app.ID,
+		&app.ProjectID,
+		&app.ApplicationNo,
+		&app.PeriodStart,
+		&app.PeriodEnd,
+		&app.Currency,
+		&app.Status,
+		&app.ContractSum,
+		&app.PreviousWorkCompleted,
+		&app.CurrentWorkCompleted,
+		&app.StoredMaterials,
+		&app.TotalCompletedAndStored,
+		&app.TotalRetainage,
+		&app.TotalEarned,
+		&app.PreviousCertificates,
+		&app.CurrentPaymentDue,
+		&app.PriceEscalation,
+		&app.AdvanceRecovery,
+		&app.Deductions,
+		&tax,
+		&app.InvoiceTransactionID,
+		&app.SubmittedAt,
+		&app.CertifiedAt,
+		&app.CertifiedBy,
+		&app.RejectionReason,
+		&app.CreatedAt,
+		&app.UpdatedAt,
+		&app.CreatedBy,
+	)
+	if err != nil {
+		return nil, err
+	}
+	app.NetPaymentDue = app.CurrentPaymentDue - app.Deductions
+
+	if tax != nil {
+		app.Tax = &entity.TaxBreakdown{}
+		if err := json.Unmarshal(tax, app.Tax); err != nil {
+			return nil, err
+		}
+	}
+	return app, nil
+}
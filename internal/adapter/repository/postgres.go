@@ -11,6 +11,7 @@ import (
 	"fmt"
 
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
@@ -42,6 +43,7 @@ func NewPool(ctx context.Context, cfg Config) (*Pool, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse config: %w", err)
 	}
+	config.ConnConfig.Tracer = newQueryTracer()
 
 	pool, err := pgxpool.NewWithConfig(ctx, config)
 	if err != nil {
@@ -65,8 +67,10 @@ func (p *Pool) Close() {
 }
 
 // WithTx executes a function within a transaction
+// Inside InTx the function runs in a savepoint of the surrounding transaction,
+// so its failure rolls back only its own statements
 func (p *Pool) WithTx(ctx context.Context, fn func(tx pgx.Tx) error) error {
-	tx, err := p.Begin(ctx)
+	tx, err := p.begin(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to begin transaction: %w", err)
 	}
@@ -88,6 +92,56 @@ func (p *Pool) WithTx(ctx context.Context, fn func(tx pgx.Tx) error) error {
 	return tx.Commit(ctx)
 }
 
+// txKey is the context key of the transaction opened by InTx
+type txKey struct{}
+
+// InTx runs fn in one transaction; every repository call made with the context
+// handed to fn joins it. It implements service.Transactor.
+func (p *Pool) InTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return p.WithTx(ctx, func(tx pgx.Tx) error {
+		return fn(context.WithValue(ctx, txKey{}, tx))
+	})
+}
+
+// begin starts a transaction, or a savepoint when the context carries one
+func (p *Pool) begin(ctx context.Context) (pgx.Tx, error) {
+	if tx, ok := ctx.Value(txKey{}).(pgx.Tx); ok {
+		return tx.Begin(ctx)
+	}
+	return p.Pool.Begin(ctx)
+}
+
+// Exec runs a statement in the context's transaction, if any
+func (p *Pool) Exec(ctx context.Context, sql string, args ...interface{}) (pgconn.CommandTag, error) {
+	if tx, ok := ctx.Value(txKey{}).(pgx.Tx); ok {
+		return tx.Exec(ctx, sql, args...)
+	}
+	return p.Pool.Exec(ctx, sql, args...)
+}
+
+// Query runs a query in the context's transaction, if any
+func (p *Pool) Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error) {
+	if tx, ok := ctx.Value(txKey{}).(pgx.Tx); ok {
+		return tx.Query(ctx, sql, args...)
+	}
+	return p.Pool.Query(ctx, sql, args...)
+}
+
+// QueryRow runs a single-row query in the context's transaction, if any
+func (p *Pool) QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row {
+	if tx, ok := ctx.Value(txKey{}).(pgx.Tx); ok {
+		return tx.QueryRow(ctx, sql, args...)
+	}
+	return p.Pool.QueryRow(ctx, sql, args...)
+}
+
+// DBTX is satisfied by both the pool and a pgx.Tx so queries can run inside WithTx
+type DBTX interface {
+	Exec(ctx context.Context, sql string, args ...interface{}) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row
+}
+
 // ErrNotFound is returned when a record is not found
 var ErrNotFound = errors.New("record not found")
 
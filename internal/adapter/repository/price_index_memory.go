@@ -0,0 +1,74 @@
+// Copyright (c) 2026 Muhammet Ali Büyük. All rights reserved.
+// This source code is proprietary. Confidential and private.
+// Unauthorized copying or distribution is strictly prohibited.
+// Contact: iletisim@alibuyuk.net | Website: alibuyuk.net
+
+package repository
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/qantesm/subflow/internal/core/entity"
+)
+
+// priceIndexKey identifies one monthly value of an index
+type priceIndexKey struct {
+	code   string
+	period time.Time
+}
+
+// InMemoryPriceIndexRepository keeps price index tables in memory
+type InMemoryPriceIndexRepository struct {
+	mu      sync.RWMutex
+	indices map[priceIndexKey]*entity.PriceIndex
+}
+
+// NewInMemoryPriceIndexRepository creates a new in-memory price index repository
+func NewInMemoryPriceIndexRepository() *InMemoryPriceIndexRepository {
+	return &InMemoryPriceIndexRepository{
+		indices: make(map[priceIndexKey]*entity.PriceIndex),
+	}
+}
+
+// SaveIndices stores index values, replacing any value already held for the same month
+func (r *InMemoryPriceIndexRepository) SaveIndices(ctx context.Context, indices []*entity.PriceIndex) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, index := range indices {
+		r.indices[priceIndexKey{index.Code, index.Period}] = index
+	}
+	return nil
+}
+
+// FindIndex returns the value of an index for a month
+func (r *InMemoryPriceIndexRepository) FindIndex(ctx context.Context, code string, period time.Time) (*entity.PriceIndex, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	index, ok := r.indices[priceIndexKey{code, entity.MonthOf(period)}]
+	if !ok {
+		return nil, entity.ErrPriceIndexNotFound
+	}
+	return index, nil
+}
+
+// ListIndices returns every stored value of an index ordered by month
+func (r *InMemoryPriceIndexRepository) ListIndices(ctx context.Context, code string) ([]*entity.PriceIndex, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var indices []*entity.PriceIndex
+	for key, index := range r.indices {
+		if key.code == code {
+			indices = append(indices, index)
+		}
+	}
+	sort.Slice(indices, func(i, j int) bool {
+		return indices[i].Period.Before(indices[j].Period)
+	})
+	return indices, nil
+}
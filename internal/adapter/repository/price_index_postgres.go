@@ -0,0 +1,111 @@
+// Copyright (c) 2026 Muhammet Ali Büyük. All rights reserved.
+// This source code is proprietary. Confidential and private.
+// Unauthorized copying or distribution is strictly prohibited.
+// Contact: iletisim@alibuyuk.net | Website: alibuyuk.net
+
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/qantesm/subflow/internal/core/entity"
+)
+
+// PostgresPriceIndexRepository implements PriceIndexRepository for PostgreSQL
+type PostgresPriceIndexRepository struct {
+	pool      *Pool
+	architect string
+}
+
+// NewPostgresPriceIndexRepository creates a new PostgreSQL price index repository
+func NewPostgresPriceIndexRepository(pool *Pool) *PostgresPriceIndexRepository {
+	return &PostgresPriceIndexRepository{
+		pool:      pool,
+		architect: "Muhammet-Ali-Buyuk",
+	}
+}
+
+// SaveIndices upserts index values in a single transaction
+func (r *PostgresPriceIndexRepository) SaveIndices(ctx context.Context, indices []*entity.PriceIndex) error {
+	return r.pool.WithTx(ctx, func(tx pgx.Tx) error {
+		for _, index := range indices {
+			_, err := tx.Exec(ctx, `
+				INSERT INTO price_indices (id, code, period, value, source, imported_at)
+				VALUES ($1, $2, $3, $4, $5, $6)
+				ON CONFLICT (code, period) DO UPDATE SET
+					value = EXCLUDED.value,
+					source = EXCLUDED.source,
+					imported_at = EXCLUDED.imported_at
+			`,
+				index.ID,
+				index.Code,
+				index.Period,
+				index.Value,
+				index.Source,
+				index.ImportedAt,
+			)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// FindIndex returns the value of an index for a month
+func (r *PostgresPriceIndexRepository) FindIndex(ctx context.Context, code string, period time.Time) (*entity.PriceIndex, error) {
+	row := r.pool.QueryRow(ctx, `
+		SELECT id, code, period, value, source, imported_at
+		FROM price_indices
+		WHERE code = $1 AND period = $2
+	`, code, entity.MonthOf(period))
+
+	index, err := scanPriceIndex(row)
+	if err == pgx.ErrNoRows {
+		return nil, entity.ErrPriceIndexNotFound
+	}
+	return index, err
+}
+
+// ListIndices returns every stored value of an index ordered by month
+func (r *PostgresPriceIndexRepository) ListIndices(ctx context.Context, code string) ([]*entity.PriceIndex, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT id, code, period, value, source, imported_at
+		FROM price_indices
+		WHERE code = $1
+		ORDER BY period
+	`, code)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var indices []*entity.PriceIndex
+	for rows.Next() {
+		index, err := scanPriceIndex(rows)
+		if err != nil {
+			return nil, err
+		}
+		indices = append(indices, index)
+	}
+	return indices, rows.Err()
+}
+
+func scanPriceIndex(row pgx.Row) (*entity.PriceIndex, error) {
+	index := &entity.PriceIndex{}
+	err := row.Scan(
+		&index.ID,
+		&index.Code,
+		&index.Period,
+		&index.Value,
+		&index.Source,
+		&index.ImportedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	index.Period = entity.MonthOf(index.Period)
+	return index, nil
+}
@@ -0,0 +1,143 @@
+// Copyright (c) 2026 Muhammet Ali Büyük. All rights reserved.
+// This source code is proprietary. Confidential and private.
+// Unauthorized copying or distribution is strictly prohibited.
+// Contact: iletisim@alibuyuk.net | Website: alibuyuk.net
+
+package repository
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/qantesm/subflow/internal/core/entity"
+)
+
+// InMemoryProjectRepository keeps projects in memory
+type InMemoryProjectRepository struct {
+	mu       sync.RWMutex
+	projects map[uuid.UUID]*entity.Project
+	history  map[uuid.UUID][]*entity.ProjectStatusChange
+	outbox   *InMemoryOutbox // Optional: receives the events of every status change
+}
+
+// NewInMemoryProjectRepository creates a new in-memory project repository
+func NewInMemoryProjectRepository() *InMemoryProjectRepository {
+	return &InMemoryProjectRepository{
+		projects: make(map[uuid.UUID]*entity.Project),
+		history:  make(map[uuid.UUID][]*entity.ProjectStatusChange),
+	}
+}
+
+// Create stores a new project
+func (r *InMemoryProjectRepository) Create(ctx context.Context, project *entity.Project) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.projects[project.ID] = project
+	return nil
+}
+
+// FindByID retrieves a project by its ID
+func (r *InMemoryProjectRepository) FindByID(ctx context.Context, id uuid.UUID) (*entity.Project, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	project, ok := r.projects[id]
+	if !ok || project.DeletedAt != nil {
+		return nil, entity.ErrProjectNotFound
+	}
+	return project, nil
+}
+
+// Lock retrieves a project; InMemoryTransactor provides the isolation
+func (r *InMemoryProjectRepository) Lock(ctx context.Context, id uuid.UUID) (*entity.Project, error) {
+	return r.FindByID(ctx, id)
+}
+
+// FindByTenant retrieves the projects of a tenant, newest first
+func (r *InMemoryProjectRepository) FindByTenant(ctx context.Context, tenantID uuid.UUID, limit, offset int) ([]*entity.Project, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var projects []*entity.Project
+	for _, project := range r.projects {
+		if project.TenantID == tenantID && project.DeletedAt == nil {
+			projects = append(projects, project)
+		}
+	}
+	sort.Slice(projects, func(i, j int) bool {
+		return projects[i].CreatedAt.After(projects[j].CreatedAt)
+	})
+
+	if offset >= len(projects) {
+		return nil, nil
+	}
+	projects = projects[offset:]
+	if limit > 0 && limit < len(projects) {
+		projects = projects[:limit]
+	}
+	return projects, nil
+}
+
+// Update replaces a stored project, keeping its status
+func (r *InMemoryProjectRepository) Update(ctx context.Context, project *entity.Project) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existing, ok := r.projects[project.ID]
+	if !ok || existing.DeletedAt != nil {
+		return entity.ErrProjectNotFound
+	}
+	project.Status = existing.Status
+	project.UpdatedAt = time.Now()
+	r.projects[project.ID] = project
+	return nil
+}
+
+// SoftDelete marks a project as deleted
+func (r *InMemoryProjectRepository) SoftDelete(ctx context.Context, id uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if project, ok := r.projects[id]; ok {
+		now := time.Now()
+		project.DeletedAt = &now
+	}
+	return nil
+}
+
+// SetOutbox makes status changes append their events to the outbox; without one they are dropped
+func (r *InMemoryProjectRepository) SetOutbox(outbox *InMemoryOutbox) {
+	r.outbox = outbox
+}
+
+// ChangeStatus moves a project to a new status and records the change
+func (r *InMemoryProjectRepository) ChangeStatus(ctx context.Context, change *entity.ProjectStatusChange, events ...*entity.Event) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	project, ok := r.projects[change.ProjectID]
+	if !ok || project.DeletedAt != nil {
+		return entity.ErrProjectNotFound
+	}
+	// The caller may hold the stored pointer and have moved it already
+	if project.Status != change.FromStatus && project.Status != change.ToStatus {
+		return entity.ErrInvalidStatusTransition
+	}
+	project.Status = change.ToStatus
+	project.UpdatedAt = change.ChangedAt
+	r.history[change.ProjectID] = append(r.history[change.ProjectID], change)
+	r.outbox.Append(events...)
+	return nil
+}
+
+// FindStatusHistory retrieves the status changes of a project, oldest first
+func (r *InMemoryProjectRepository) FindStatusHistory(ctx context.Context, projectID uuid.UUID) ([]*entity.ProjectStatusChange, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return append([]*entity.ProjectStatusChange(nil), r.history[projectID]...), nil
+}
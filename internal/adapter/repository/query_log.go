@@ -0,0 +1,45 @@
+// Copyright (c) 2026 Muhammet Ali Büyük. All rights reserved.
+// This source code is proprietary. Confidential and private.
+// Unauthorized copying or distribution is strictly prohibited.
+// Contact: iletisim@alibuyuk.net | Website: alibuyuk.net
+
+package repository
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/tracelog"
+	"github.com/qantesm/subflow/internal/pkg"
+	"github.com/rs/zerolog"
+)
+
+// newQueryTracer logs failed queries, and every query at debug level, with
+// the logger of the request that ran them
+func newQueryTracer() *tracelog.TraceLog {
+	level := tracelog.LogLevelWarn
+	if zerolog.GlobalLevel() <= zerolog.DebugLevel {
+		level = tracelog.LogLevelInfo
+	}
+	return &tracelog.TraceLog{Logger: queryLogger{}, LogLevel: level}
+}
+
+// queryLogger writes pgx trace events to the context's logger
+type queryLogger struct{}
+
+func (queryLogger) Log(ctx context.Context, level tracelog.LogLevel, msg string, data map[string]any) {
+	// Arguments carry secrets such as webhook signing keys
+	delete(data, "args")
+
+	var zl zerolog.Level
+	switch level {
+	case tracelog.LogLevelError:
+		zl = zerolog.ErrorLevel
+	case tracelog.LogLevelWarn:
+		zl = zerolog.WarnLevel
+	case tracelog.LogLevelInfo:
+		zl = zerolog.DebugLevel // Successful queries are detail
+	default:
+		zl = zerolog.TraceLevel
+	}
+	pkg.Ctx(ctx).WithLevel(zl).Fields(data).Msg("sql: " + msg)
+}
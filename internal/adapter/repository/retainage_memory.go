@@ -0,0 +1,96 @@
+// Copyright (c) 2026 Muhammet Ali Büyük. All rights reserved.
+// This source code is proprietary. Confidential and private.
+// Unauthorized copying or distribution is strictly prohibited.
+// Contact: iletisim@alibuyuk.net | Website: alibuyuk.net
+
+package repository
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/qantesm/subflow/internal/core/entity"
+)
+
+// InMemoryRetainageRepository keeps retainage release requests in memory
+type InMemoryRetainageRepository struct {
+	mu        sync.RWMutex
+	releases  map[uuid.UUID]*entity.RetainageRelease
+	sequences map[int]int64   // Invoice counter by year
+	outbox    *InMemoryOutbox // Optional: receives the events of every update
+}
+
+// NewInMemoryRetainageRepository creates a new in-memory retainage repository
+func NewInMemoryRetainageRepository() *InMemoryRetainageRepository {
+	return &InMemoryRetainageRepository{
+		releases:  make(map[uuid.UUID]*entity.RetainageRelease),
+		sequences: make(map[int]int64),
+	}
+}
+
+// Save stores a new release request
+func (r *InMemoryRetainageRepository) Save(ctx context.Context, release *entity.RetainageRelease) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.releases[release.ID] = release
+	return nil
+}
+
+// SetOutbox makes updates append their events to the outbox; without one they are dropped
+func (r *InMemoryRetainageRepository) SetOutbox(outbox *InMemoryOutbox) {
+	r.outbox = outbox
+}
+
+// Update replaces a stored release request
+func (r *InMemoryRetainageRepository) Update(ctx context.Context, release *entity.RetainageRelease, events ...*entity.Event) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.releases[release.ID]; !ok {
+		return entity.ErrRetainageReleaseNotFound
+	}
+	r.releases[release.ID] = release
+	r.outbox.Append(events...)
+	return nil
+}
+
+// FindByID retrieves a release request by its ID
+func (r *InMemoryRetainageRepository) FindByID(ctx context.Context, id uuid.UUID) (*entity.RetainageRelease, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	release, ok := r.releases[id]
+	if !ok {
+		return nil, entity.ErrRetainageReleaseNotFound
+	}
+	return release, nil
+}
+
+// FindByProjectID retrieves the release requests of a project, oldest first
+func (r *InMemoryRetainageRepository) FindByProjectID(ctx context.Context, projectID uuid.UUID) ([]*entity.RetainageRelease, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var releases []*entity.RetainageRelease
+	for _, release := range r.releases {
+		if release.ProjectID == projectID {
+			releases = append(releases, release)
+		}
+	}
+	sort.Slice(releases, func(i, j int) bool {
+		return releases[i].RequestedAt.Before(releases[j].RequestedAt)
+	})
+	return releases, nil
+}
+
+// NextInvoiceSequence increments the release invoice counter for a year
+func (r *InMemoryRetainageRepository) NextInvoiceSequence(ctx context.Context, year int) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.sequences[year]++
+	return r.sequences[year], nil
+}
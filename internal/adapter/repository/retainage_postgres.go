@@ -0,0 +1,203 @@
+// Copyright (c) 2026 Muhammet Ali Büyük. All rights reserved.
+// This source code is proprietary. Confidential and private.
+// Unauthorized copying or distribution is strictly prohibited.
+// Contact: iletisim@alibuyuk.net | Website: alibuyuk.net
+
+package repository
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/qantesm/subflow/internal/core/entity"
+)
+
+// PostgresRetainageRepository implements RetainageRepository for PostgreSQL
+type PostgresRetainageRepository struct {
+	pool      *Pool
+	architect string
+}
+
+// NewPostgresRetainageRepository creates a new PostgreSQL retainage repository
+func NewPostgresRetainageRepository(pool *Pool) *PostgresRetainageRepository {
+	return &PostgresRetainageRepository{
+		pool:      pool,
+		architect: "Muhammet-Ali-Buyuk",
+	}
+}
+
+const retainageReleaseColumns = `
+	id, project_id, contract_id, kind, amount_cents, currency, reason, status, required_approvals,
+	approvals, rejected_by, rejection_reason, transaction_id, invoice, requested_at, requested_by, closed_at
+`
+
+// Save stores a new release request
+func (r *PostgresRetainageRepository) Save(ctx context.Context, release *entity.RetainageRelease) error {
+	approvals, invoice, err := marshalRetainageRelease(release)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.pool.Exec(ctx, `
+		INSERT INTO retainage_releases (`+retainageReleaseColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17)
+	`,
+		release.ID,
+		release.ProjectID,
+		release.ContractID,
+		release.Kind,
+		release.Amount,
+		release.Currency,
+		release.Reason,
+		release.Status,
+		release.RequiredApprovals,
+		approvals,
+		release.RejectedBy,
+		release.RejectionReason,
+		release.TransactionID,
+		invoice,
+		release.RequestedAt,
+		release.RequestedBy,
+		release.ClosedAt,
+	)
+	return err
+}
+
+// Update stores the approval state, ledger entry and invoice of a release request
+// together with its outbox events in one transaction
+func (r *PostgresRetainageRepository) Update(ctx context.Context, release *entity.RetainageRelease, events ...*entity.Event) error {
+	approvals, invoice, err := marshalRetainageRelease(release)
+	if err != nil {
+		return err
+	}
+
+	return r.pool.WithTx(ctx, func(tx pgx.Tx) error {
+		tag, err := tx.Exec(ctx, `
+			UPDATE retainage_releases SET
+				status = $2,
+				approvals = $3,
+				rejected_by = $4,
+				rejection_reason = $5,
+				transaction_id = $6,
+				invoice = $7,
+				closed_at = $8
+			WHERE id = $1
+		`,
+			release.ID,
+			release.Status,
+			approvals,
+			release.RejectedBy,
+			release.RejectionReason,
+			release.TransactionID,
+			invoice,
+			release.ClosedAt,
+		)
+		if err != nil {
+			return err
+		}
+		if tag.RowsAffected() == 0 {
+			return entity.ErrRetainageReleaseNotFound
+		}
+		return appendOutbox(ctx, tx, events)
+	})
+}
+
+// FindByID retrieves a release request by its ID
+func (r *PostgresRetainageRepository) FindByID(ctx context.Context, id uuid.UUID) (*entity.RetainageRelease, error) {
+	row := r.pool.QueryRow(ctx, `SELECT `+retainageReleaseColumns+` FROM retainage_releases WHERE id = $1`, id)
+
+	release, err := scanRetainageRelease(row)
+	if err == pgx.ErrNoRows {
+		return nil, entity.ErrRetainageReleaseNotFound
+	}
+	return release, err
+}
+
+// FindByProjectID retrieves the release requests of a project, oldest first
+func (r *PostgresRetainageRepository) FindByProjectID(ctx context.Context, projectID uuid.UUID) ([]*entity.RetainageRelease, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT `+retainageReleaseColumns+`
+		FROM retainage_releases
+		WHERE project_id = $1
+		ORDER BY requested_at
+	`, projectID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var releases []*entity.RetainageRelease
+	for rows.Next() {
+		release, err := scanRetainageRelease(rows)
+		if err != nil {
+			return nil, err
+		}
+		releases = append(releases, release)
+	}
+	return releases, rows.Err()
+}
+
+// NextInvoiceSequence atomically increments the release invoice counter for a year
+func (r *PostgresRetainageRepository) NextInvoiceSequence(ctx context.Context, year int) (int64, error) {
+	var seq int64
+	err := r.pool.QueryRow(ctx, `
+		INSERT INTO retainage_invoice_sequences (year, last_value)
+		VALUES ($1, 1)
+		ON CONFLICT (year) DO UPDATE SET last_value = retainage_invoice_sequences.last_value + 1
+		RETURNING last_value
+	`, year).Scan(&seq)
+	return seq, err
+}
+
+func marshalRetainageRelease(release *entity.RetainageRelease) (approvals, invoice []byte, err error) {
+	approvals, err = json.Marshal(release.Approvals)
+	if err != nil {
+		return nil, nil, err
+	}
+	if release.Invoice != nil {
+		invoice, err = json.Marshal(release.Invoice)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	return approvals, invoice, nil
+}
+
+func scanRetainageRelease(row pgx.Row) (*entity.RetainageRelease, error) {
+	release := &entity.RetainageRelease{}
+	var approvals, invoice []byte
+	err := row.Scan(
+		&release.ID,
+		&release.ProjectID,
+		&release.ContractID,
+		&release.Kind,
+		&release.Amount,
+		&release.Currency,
+		&release.Reason,
+		&release.Status,
+		&release.RequiredApprovals,
+		&approvals,
+		&release.RejectedBy,
+		&release.RejectionReason,
+		&release.TransactionID,
+		&invoice,
+		&release.RequestedAt,
+		&release.RequestedBy,
+		&release.ClosedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(approvals, &release.Approvals); err != nil {
+		return nil, err
+	}
+	if invoice != nil {
+		release.Invoice = &entity.RetainageInvoice{}
+		if err := json.Unmarshal(invoice, release.Invoice); err != nil {
+			return nil, err
+		}
+	}
+	return release, nil
+}
@@ -0,0 +1,44 @@
+// Copyright (c) 2026 Muhammet Ali Büyük. All rights reserved.
+// This source code is proprietary. Confidential and private.
+// Unauthorized copying or distribution is strictly prohibited.
+// Contact: iletisim@alibuyuk.net | Website: alibuyuk.net
+
+package repository
+
+import (
+	"context"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/qantesm/subflow/internal/core/entity"
+)
+
+// InMemoryTaxRateRepository keeps tenant tax rate tables in memory
+type InMemoryTaxRateRepository struct {
+	mu    sync.RWMutex
+	rates map[uuid.UUID][]*entity.TaxRate // By tenant
+}
+
+// NewInMemoryTaxRateRepository creates a new in-memory tax rate repository
+func NewInMemoryTaxRateRepository() *InMemoryTaxRateRepository {
+	return &InMemoryTaxRateRepository{
+		rates: make(map[uuid.UUID][]*entity.TaxRate),
+	}
+}
+
+// Save stores a tenant rate
+func (r *InMemoryTaxRateRepository) Save(ctx context.Context, rate *entity.TaxRate) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.rates[rate.TenantID] = append(r.rates[rate.TenantID], rate)
+	return nil
+}
+
+// FindByTenant returns the rates a tenant has defined
+func (r *InMemoryTaxRateRepository) FindByTenant(ctx context.Context, tenantID uuid.UUID) ([]*entity.TaxRate, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return append([]*entity.TaxRate{}, r.rates[tenantID]...), nil
+}
@@ -0,0 +1,80 @@
+// Copyright (c) 2026 Muhammet Ali Büyük. All rights reserved.
+// This source code is proprietary. Confidential and private.
+// Unauthorized copying or distribution is strictly prohibited.
+// Contact: iletisim@alibuyuk.net | Website: alibuyuk.net
+
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/qantesm/subflow/internal/core/entity"
+)
+
+// PostgresTaxRateRepository implements TaxRateRepository for PostgreSQL
+type PostgresTaxRateRepository struct {
+	pool      *Pool
+	architect string
+}
+
+// NewPostgresTaxRateRepository creates a new PostgreSQL tax rate repository
+func NewPostgresTaxRateRepository(pool *Pool) *PostgresTaxRateRepository {
+	return &PostgresTaxRateRepository{
+		pool:      pool,
+		architect: "Muhammet-Ali-Buyuk",
+	}
+}
+
+// Save stores a tenant rate
+func (r *PostgresTaxRateRepository) Save(ctx context.Context, rate *entity.TaxRate) error {
+	_, err := r.pool.Exec(ctx, `
+		INSERT INTO tax_rates (id, tenant_id, kind, code, name, rate_ppm, valid_from, valid_to, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`,
+		rate.ID,
+		rate.TenantID,
+		rate.Kind,
+		rate.Code,
+		rate.Name,
+		rate.Rate,
+		rate.ValidFrom,
+		rate.ValidTo,
+		rate.CreatedAt,
+	)
+	return err
+}
+
+// FindByTenant returns the rates a tenant has defined
+func (r *PostgresTaxRateRepository) FindByTenant(ctx context.Context, tenantID uuid.UUID) ([]*entity.TaxRate, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT id, tenant_id, kind, code, name, rate_ppm, valid_from, valid_to, created_at
+		FROM tax_rates
+		WHERE tenant_id = $1
+		ORDER BY kind, code, valid_from
+	`, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var rates []*entity.TaxRate
+	for rows.Next() {
+		rate := &entity.TaxRate{}
+		if err := rows.Scan(
+			&rate.ID,
+			&rate.TenantID,
+			&rate.Kind,
+			&rate.Code,
+			&rate.Name,
+			&rate.Rate,
+			&rate.ValidFrom,
+			&rate.ValidTo,
+			&rate.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		rates = append(rates, rate)
+	}
+	return rates, rows.Err()
+}
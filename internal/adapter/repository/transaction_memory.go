@@ -19,6 +19,7 @@ import (
 type InMemoryTransactionRepository struct {
 	mu           sync.RWMutex
 	transactions map[uuid.UUID]*entity.Transaction
+	outbox       *InMemoryOutbox // Optional: receives the events of every save
 	architect    string
 }
 
@@ -30,12 +31,31 @@ func NewInMemoryTransactionRepository() *InMemoryTransactionRepository {
 	}
 }
 
+// SetOutbox makes saves append their events to the outbox; without one they are dropped
+func (r *InMemoryTransactionRepository) SetOutbox(outbox *InMemoryOutbox) {
+	r.outbox = outbox
+}
+
 // Save stores a transaction in memory
-func (r *InMemoryTransactionRepository) Save(ctx context.Context, tx *entity.Transaction) error {
+func (r *InMemoryTransactionRepository) Save(ctx context.Context, tx *entity.Transaction, events ...*entity.Event) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 	
 	r.transactions[tx.ID] = tx
+	r.outbox.Append(events...)
+	return nil
+}
+
+// SaveBatch stores several transactions under a single lock
+// Rows are validated by the caller, so the batch either fully lands or not at all
+func (r *InMemoryTransactionRepository) SaveBatch(ctx context.Context, txs []*entity.Transaction, events ...*entity.Event) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, tx := range txs {
+		r.transactions[tx.ID] = tx
+	}
+	r.outbox.Append(events...)
 	return nil
 }
 
@@ -65,36 +85,14 @@ func (r *InMemoryTransactionRepository) FindByProjectID(ctx context.Context, pro
 	return result, nil
 }
 
-// GetProjectSummary calculates the financial summary for a project
-func (r *InMemoryTransactionRepository) GetProjectSummary(ctx context.Context, projectID uuid.UUID) (*service.LedgerSummary, error) {
+// GetProjectSummaries calculates the financial summary of a project per currency
+func (r *InMemoryTransactionRepository) GetProjectSummaries(ctx context.Context, projectID uuid.UUID) ([]*service.LedgerSummary, error) {
 	transactions, err := r.FindByProjectID(ctx, projectID)
 	if err != nil {
 		return nil, err
 	}
 
-	summary := &service.LedgerSummary{
-		ProjectID:        projectID,
-		TransactionCount: len(transactions),
-	}
-
-	for _, tx := range transactions {
-		summary.Currency = tx.Currency // Use the last found currency
-		
-		switch tx.Type {
-		case entity.TransactionTypeInvoice:
-			summary.TotalInvoiced += tx.AmountCents
-		case entity.TransactionTypePayment:
-			summary.TotalPaid += tx.AmountCents
-		case entity.TransactionTypeRetainageHeld:
-			summary.TotalRetained += tx.AmountCents
-		case entity.TransactionTypeRetainageRelease:
-			summary.TotalRetained -= tx.AmountCents
-		}
-	}
-
-	summary.CurrentBalance = summary.TotalInvoiced - summary.TotalPaid
-
-	return summary, nil
+	return service.SummarizeTransactions(projectID, transactions), nil
 }
 
 // Clear removes all transactions (for testing)
@@ -12,27 +12,51 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
-	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/qantesm/subflow/internal/core/entity"
 	"github.com/qantesm/subflow/internal/core/service"
 )
 
 // PostgresTransactionRepository implements TransactionRepository for PostgreSQL
 type PostgresTransactionRepository struct {
-	pool      *pgxpool.Pool
+	pool      *Pool
 	architect string
 }
 
 // NewPostgresTransactionRepository creates a new PostgreSQL transaction repository
-func NewPostgresTransactionRepository(pool *pgxpool.Pool) *PostgresTransactionRepository {
+func NewPostgresTransactionRepository(pool *Pool) *PostgresTransactionRepository {
 	return &PostgresTransactionRepository{
 		pool:      pool,
 		architect: "Muhammet-Ali-Buyuk",
 	}
 }
 
-// Save stores a transaction in the database
-func (r *PostgresTransactionRepository) Save(ctx context.Context, tx *entity.Transaction) error {
+// Save stores a transaction and its outbox events in one database transaction
+func (r *PostgresTransactionRepository) Save(ctx context.Context, tx *entity.Transaction, events ...*entity.Event) error {
+	if len(events) == 0 {
+		return r.insert(ctx, r.pool, tx)
+	}
+	return r.pool.WithTx(ctx, func(dbTx pgx.Tx) error {
+		if err := r.insert(ctx, dbTx, tx); err != nil {
+			return err
+		}
+		return appendOutbox(ctx, dbTx, events)
+	})
+}
+
+// SaveBatch stores all transactions and their outbox events in one database transaction
+// Any failing row rolls back the whole batch (used by bulk imports)
+func (r *PostgresTransactionRepository) SaveBatch(ctx context.Context, txs []*entity.Transaction, events ...*entity.Event) error {
+	return r.pool.WithTx(ctx, func(dbTx pgx.Tx) error {
+		for _, tx := range txs {
+			if err := r.insert(ctx, dbTx, tx); err != nil {
+				return err
+			}
+		}
+		return appendOutbox(ctx, dbTx, events)
+	})
+}
+
+func (r *PostgresTransactionRepository) insert(ctx context.Context, db DBTX, tx *entity.Transaction) error {
 	query := `
 		INSERT INTO transactions (
 			id, project_id, contract_id, type, amount_cents, currency,
@@ -40,7 +64,7 @@ func (r *PostgresTransactionRepository) Save(ctx context.Context, tx *entity.Tra
 		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
 	`
 
-	_, err := r.pool.Exec(ctx, query,
+	_, err := db.Exec(ctx, query,
 		tx.ID,
 		tx.ProjectID,
 		tx.ContractID,
@@ -99,55 +123,54 @@ func (r *PostgresTransactionRepository) FindByProjectID(ctx context.Context, pro
 	return transactions, rows.Err()
 }
 
-// GetProjectSummary calculates the financial summary for a project
-func (r *PostgresTransactionRepository) GetProjectSummary(ctx context.Context, projectID uuid.UUID) (*service.LedgerSummary, error) {
+// GetProjectSummaries calculates the financial summary of a project per currency
+func (r *PostgresTransactionRepository) GetProjectSummaries(ctx context.Context, projectID uuid.UUID) ([]*service.LedgerSummary, error) {
+	// Totals per currency and type; the balance itself is derived in Go so it matches BalanceEffect
 	query := `
-		SELECT 
-			project_id,
-			COUNT(*) as transaction_count,
-			COALESCE(SUM(CASE WHEN type = 'INVOICE' THEN amount_cents ELSE 0 END), 0) as total_invoiced,
-			COALESCE(SUM(CASE WHEN type = 'PAYMENT' THEN amount_cents ELSE 0 END), 0) as total_paid,
-			COALESCE(SUM(CASE WHEN type = 'RETAINAGE_HELD' THEN amount_cents ELSE 0 END), 0) as retainage_held,
-			COALESCE(SUM(CASE WHEN type = 'RETAINAGE_RELEASE' THEN amount_cents ELSE 0 END), 0) as retainage_released,
-			COALESCE(MAX(currency), 'TRY') as currency
+		SELECT currency, type, COUNT(*), COALESCE(SUM(amount_cents), 0)
 		FROM transactions
 		WHERE project_id = $1
-		GROUP BY project_id
+		GROUP BY currency, type
+		ORDER BY currency
 	`
 
-	row := r.pool.QueryRow(ctx, query, projectID)
+	rows, err := r.pool.Query(ctx, query, projectID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
 
 	var (
-		pID              uuid.UUID
-		txCount          int
-		totalInvoiced    int64
-		totalPaid        int64
-		retainageHeld    int64
-		retainageRelease int64
-		currency         string
+		currencies []string
+		totals     = make(map[string]map[entity.TransactionType]int64)
+		counts     = make(map[string]int)
 	)
-
-	err := row.Scan(&pID, &txCount, &totalInvoiced, &totalPaid, &retainageHeld, &retainageRelease, &currency)
-	if err == pgx.ErrNoRows {
-		// No transactions yet, return empty summary
-		return &service.LedgerSummary{
-			ProjectID: projectID,
-			Currency:  "TRY",
-		}, nil
+	for rows.Next() {
+		var (
+			currency string
+			txType   entity.TransactionType
+			count    int
+			amount   int64
+		)
+		if err := rows.Scan(&currency, &txType, &count, &amount); err != nil {
+			return nil, err
+		}
+		if totals[currency] == nil {
+			totals[currency] = make(map[entity.TransactionType]int64)
+			currencies = append(currencies, currency)
+		}
+		totals[currency][txType] = amount
+		counts[currency] += count
 	}
-	if err != nil {
+	if err := rows.Err(); err != nil {
 		return nil, err
 	}
 
-	return &service.LedgerSummary{
-		ProjectID:        pID,
-		TotalInvoiced:    totalInvoiced,
-		TotalPaid:        totalPaid,
-		TotalRetained:    retainageHeld - retainageRelease,
-		CurrentBalance:   totalInvoiced - totalPaid,
-		Currency:         currency,
-		TransactionCount: txCount,
-	}, nil
+	summaries := make([]*service.LedgerSummary, 0, len(currencies))
+	for _, currency := range currencies {
+		summaries = append(summaries, service.SummarizeTotals(projectID, totals[currency], counts[currency], currency))
+	}
+	return summaries, nil
 }
 
 // Helper function to scan a transaction from a row
@@ -217,12 +240,12 @@ func (r *PostgresTransactionRepository) scanTransactionFromRows(rows pgx.Rows) (
 
 // PostgresProjectRepository implements project persistence for PostgreSQL
 type PostgresProjectRepository struct {
-	pool      *pgxpool.Pool
+	pool      *Pool
 	architect string
 }
 
 // NewPostgresProjectRepository creates a new PostgreSQL project repository
-func NewPostgresProjectRepository(pool *pgxpool.Pool) *PostgresProjectRepository {
+func NewPostgresProjectRepository(pool *Pool) *PostgresProjectRepository {
 	return &PostgresProjectRepository{
 		pool:      pool,
 		architect: "Muhammet-Ali-Buyuk",
@@ -235,8 +258,9 @@ func (r *PostgresProjectRepository) Create(ctx context.Context, p *entity.Projec
 		INSERT INTO projects (
 			id, tenant_id, name, code, description, status,
 			contract_amount_cents, currency, start_date, estimated_end_date,
-			labor_retainage_rate, material_retainage_rate, created_at, updated_at
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+			labor_retainage_rate, material_retainage_rate, created_at, updated_at,
+			substantial_completion_date
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
 	`
 
 	_, err := r.pool.Exec(ctx, query,
@@ -254,6 +278,7 @@ func (r *PostgresProjectRepository) Create(ctx context.Context, p *entity.Projec
 		p.MaterialRetainageRate,
 		p.CreatedAt,
 		p.UpdatedAt,
+		p.SubstantialCompletionDate,
 	)
 
 	return err
@@ -264,7 +289,8 @@ func (r *PostgresProjectRepository) FindByID(ctx context.Context, id uuid.UUID)
 	query := `
 		SELECT id, tenant_id, name, code, description, status,
 			   contract_amount_cents, currency, start_date, estimated_end_date,
-			   labor_retainage_rate, material_retainage_rate, created_at, updated_at, deleted_at
+			   labor_retainage_rate, material_retainage_rate, created_at, updated_at, deleted_at,
+			   substantial_completion_date
 		FROM projects
 		WHERE id = $1 AND deleted_at IS NULL
 	`
@@ -273,12 +299,31 @@ func (r *PostgresProjectRepository) FindByID(ctx context.Context, id uuid.UUID)
 	return r.scanProject(row)
 }
 
+// Lock retrieves a project and locks its row until the transaction ends
+// Writes that check or number per project, such as retainage releases and
+// pay applications, take it first so they run one after the other
+func (r *PostgresProjectRepository) Lock(ctx context.Context, id uuid.UUID) (*entity.Project, error) {
+	query := `
+		SELECT id, tenant_id, name, code, description, status,
+			   contract_amount_cents, currency, start_date, estimated_end_date,
+			   labor_retainage_rate, material_retainage_rate, created_at, updated_at, deleted_at,
+			   substantial_completion_date
+		FROM projects
+		WHERE id = $1 AND deleted_at IS NULL
+		FOR UPDATE
+	`
+
+	row := r.pool.QueryRow(ctx, query, id)
+	return r.scanProject(row)
+}
+
 // FindByTenant retrieves all projects for a tenant
 func (r *PostgresProjectRepository) FindByTenant(ctx context.Context, tenantID uuid.UUID, limit, offset int) ([]*entity.Project, error) {
 	query := `
 		SELECT id, tenant_id, name, code, description, status,
 			   contract_amount_cents, currency, start_date, estimated_end_date,
-			   labor_retainage_rate, material_retainage_rate, created_at, updated_at, deleted_at
+			   labor_retainage_rate, material_retainage_rate, created_at, updated_at, deleted_at,
+			   substantial_completion_date
 		FROM projects
 		WHERE tenant_id = $1 AND deleted_at IS NULL
 		ORDER BY created_at DESC
@@ -304,18 +349,19 @@ func (r *PostgresProjectRepository) FindByTenant(ctx context.Context, tenantID u
 }
 
 // Update modifies an existing project
+// The status only changes through ChangeStatus
 func (r *PostgresProjectRepository) Update(ctx context.Context, p *entity.Project) error {
 	query := `
 		UPDATE projects SET
 			name = $2,
 			description = $3,
-			status = $4,
-			contract_amount_cents = $5,
-			start_date = $6,
-			estimated_end_date = $7,
-			labor_retainage_rate = $8,
-			material_retainage_rate = $9,
-			updated_at = $10
+			contract_amount_cents = $4,
+			start_date = $5,
+			estimated_end_date = $6,
+			labor_retainage_rate = $7,
+			material_retainage_rate = $8,
+			updated_at = $9,
+			substantial_completion_date = $10
 		WHERE id = $1 AND deleted_at IS NULL
 	`
 
@@ -324,18 +370,90 @@ func (r *PostgresProjectRepository) Update(ctx context.Context, p *entity.Projec
 		p.ID,
 		p.Name,
 		p.Description,
-		p.Status,
 		p.ContractAmount,
 		p.StartDate,
 		p.EstimatedEndDate,
 		p.LaborRetainageRate,
 		p.MaterialRetainageRate,
 		p.UpdatedAt,
+		p.SubstantialCompletionDate,
 	)
 
 	return err
 }
 
+// ChangeStatus moves a project to a new status and appends the history row and outbox events in one transaction
+func (r *PostgresProjectRepository) ChangeStatus(ctx context.Context, change *entity.ProjectStatusChange, events ...*entity.Event) error {
+	return r.pool.WithTx(ctx, func(tx pgx.Tx) error {
+		tag, err := tx.Exec(ctx, `
+			UPDATE projects SET status = $2, updated_at = $3
+			WHERE id = $1 AND status = $4 AND deleted_at IS NULL
+		`, change.ProjectID, change.ToStatus, change.ChangedAt, change.FromStatus)
+		if err != nil {
+			return err
+		}
+		if tag.RowsAffected() == 0 {
+			var exists bool
+			if err := tx.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM projects WHERE id = $1 AND deleted_at IS NULL)`, change.ProjectID).Scan(&exists); err != nil {
+				return err
+			}
+			if !exists {
+				return entity.ErrProjectNotFound
+			}
+			return entity.ErrInvalidStatusTransition // Changed concurrently
+		}
+
+		_, err = tx.Exec(ctx, `
+			INSERT INTO project_status_history (id, project_id, from_status, to_status, reason, changed_at, changed_by)
+			VALUES ($1, $2, $3, $4, $5, $6, $7)
+		`,
+			change.ID,
+			change.ProjectID,
+			change.FromStatus,
+			change.ToStatus,
+			change.Reason,
+			change.ChangedAt,
+			change.ChangedBy,
+		)
+		if err != nil {
+			return err
+		}
+		return appendOutbox(ctx, tx, events)
+	})
+}
+
+// FindStatusHistory retrieves the status changes of a project, oldest first
+func (r *PostgresProjectRepository) FindStatusHistory(ctx context.Context, projectID uuid.UUID) ([]*entity.ProjectStatusChange, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT id, project_id, from_status, to_status, reason, changed_at, changed_by
+		FROM project_status_history
+		WHERE project_id = $1
+		ORDER BY changed_at
+	`, projectID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var history []*entity.ProjectStatusChange
+	for rows.Next() {
+		change := &entity.ProjectStatusChange{}
+		if err := rows.Scan(
+			&change.ID,
+			&change.ProjectID,
+			&change.FromStatus,
+			&change.ToStatus,
+			&change.Reason,
+			&change.ChangedAt,
+			&change.ChangedBy,
+		); err != nil {
+			return nil, err
+		}
+		history = append(history, change)
+	}
+	return history, rows.Err()
+}
+
 // SoftDelete marks a project as deleted
 func (r *PostgresProjectRepository) SoftDelete(ctx context.Context, id uuid.UUID) error {
 	query := `UPDATE projects SET deleted_at = NOW() WHERE id = $1`
@@ -362,6 +480,7 @@ func (r *PostgresProjectRepository) scanProject(row pgx.Row) (*entity.Project, e
 		&p.CreatedAt,
 		&p.UpdatedAt,
 		&p.DeletedAt,
+		&p.SubstantialCompletionDate,
 	)
 
 	if err == pgx.ErrNoRows {
@@ -393,6 +512,7 @@ func (r *PostgresProjectRepository) scanProjectFromRows(rows pgx.Rows) (*entity.
 		&p.CreatedAt,
 		&p.UpdatedAt,
 		&p.DeletedAt,
+		&p.SubstantialCompletionDate,
 	)
 
 	if err != nil {
@@ -0,0 +1,36 @@
+// Copyright (c) 2026 Muhammet Ali Büyük. All rights reserved.
+// This source code is proprietary. Confidential and private.
+// Unauthorized copying or distribution is strictly prohibited.
+// Contact: iletisim@alibuyuk.net | Website: alibuyuk.net
+
+package repository
+
+import (
+	"context"
+	"sync"
+)
+
+// InMemoryTransactor serializes units of work over the in-memory repositories
+// It gives the isolation of a transaction but cannot roll back; the memory
+// backend is for development only
+type InMemoryTransactor struct {
+	mu sync.Mutex
+}
+
+// NewInMemoryTransactor creates a new in-memory transactor
+func NewInMemoryTransactor() *InMemoryTransactor {
+	return &InMemoryTransactor{}
+}
+
+// memoryTxKey marks a context that already holds the transactor's lock
+type memoryTxKey struct{}
+
+// InTx runs fn while no other unit of work runs; nested calls join the outer one
+func (t *InMemoryTransactor) InTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	if ctx.Value(memoryTxKey{}) != nil {
+		return fn(ctx)
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return fn(context.WithValue(ctx, memoryTxKey{}, t))
+}
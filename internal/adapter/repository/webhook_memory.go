@@ -0,0 +1,169 @@
+// Copyright (c) 2026 Muhammet Ali Büyük. All rights reserved.
+// This source code is proprietary. Confidential and private.
+// Unauthorized copying or distribution is strictly prohibited.
+// Contact: iletisim@alibuyuk.net | Website: alibuyuk.net
+
+package repository
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/qantesm/subflow/internal/core/entity"
+)
+
+// InMemoryWebhookRepository keeps webhook endpoints and deliveries in memory
+// Entries are copied in and out because deliveries are updated from worker goroutines.
+type InMemoryWebhookRepository struct {
+	mu         sync.RWMutex
+	endpoints  map[uuid.UUID]*entity.WebhookEndpoint
+	deliveries map[uuid.UUID]*entity.WebhookDelivery
+}
+
+// NewInMemoryWebhookRepository creates a new in-memory webhook repository
+func NewInMemoryWebhookRepository() *InMemoryWebhookRepository {
+	return &InMemoryWebhookRepository{
+		endpoints:  make(map[uuid.UUID]*entity.WebhookEndpoint),
+		deliveries: make(map[uuid.UUID]*entity.WebhookDelivery),
+	}
+}
+
+// SaveEndpoint stores a new endpoint
+func (r *InMemoryWebhookRepository) SaveEndpoint(ctx context.Context, e *entity.WebhookEndpoint) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.endpoints[e.ID] = e
+	return nil
+}
+
+// UpdateEndpoint replaces a stored endpoint
+func (r *InMemoryWebhookRepository) UpdateEndpoint(ctx context.Context, e *entity.WebhookEndpoint) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.endpoints[e.ID]; !ok {
+		return entity.ErrWebhookEndpointNotFound
+	}
+	r.endpoints[e.ID] = e
+	return nil
+}
+
+// FindEndpointByID retrieves an endpoint by its ID
+func (r *InMemoryWebhookRepository) FindEndpointByID(ctx context.Context, id uuid.UUID) (*entity.WebhookEndpoint, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	e, ok := r.endpoints[id]
+	if !ok {
+		return nil, entity.ErrWebhookEndpointNotFound
+	}
+	copied := *e
+	return &copied, nil
+}
+
+// FindEndpointsByTenant retrieves the endpoints of a tenant, oldest first
+func (r *InMemoryWebhookRepository) FindEndpointsByTenant(ctx context.Context, tenantID uuid.UUID) ([]*entity.WebhookEndpoint, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var endpoints []*entity.WebhookEndpoint
+	for _, e := range r.endpoints {
+		if e.TenantID == tenantID {
+			copied := *e
+			endpoints = append(endpoints, &copied)
+		}
+	}
+	sort.Slice(endpoints, func(i, j int) bool {
+		return endpoints[i].CreatedAt.Before(endpoints[j].CreatedAt)
+	})
+	return endpoints, nil
+}
+
+// SaveDelivery stores a new delivery; an endpoint gets each event once, replays aside
+func (r *InMemoryWebhookRepository) SaveDelivery(ctx context.Context, d *entity.WebhookDelivery) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if d.ReplayOf == nil {
+		for _, existing := range r.deliveries {
+			if existing.EndpointID == d.EndpointID && existing.EventID == d.EventID && existing.ReplayOf == nil {
+				return entity.ErrWebhookDeliveryExists
+			}
+		}
+	}
+	copied := *d
+	r.deliveries[d.ID] = &copied
+	return nil
+}
+
+// UpdateDelivery replaces a stored delivery
+func (r *InMemoryWebhookRepository) UpdateDelivery(ctx context.Context, d *entity.WebhookDelivery) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.deliveries[d.ID]; !ok {
+		return entity.ErrWebhookDeliveryNotFound
+	}
+	copied := *d
+	r.deliveries[d.ID] = &copied
+	return nil
+}
+
+// FindDeliveryByID retrieves a delivery by its ID
+func (r *InMemoryWebhookRepository) FindDeliveryByID(ctx context.Context, id uuid.UUID) (*entity.WebhookDelivery, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	d, ok := r.deliveries[id]
+	if !ok {
+		return nil, entity.ErrWebhookDeliveryNotFound
+	}
+	copied := *d
+	return &copied, nil
+}
+
+// FindDeliveriesByEndpoint retrieves the latest deliveries of an endpoint, newest first
+func (r *InMemoryWebhookRepository) FindDeliveriesByEndpoint(ctx context.Context, endpointID uuid.UUID, limit int) ([]*entity.WebhookDelivery, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var deliveries []*entity.WebhookDelivery
+	for _, d := range r.deliveries {
+		if d.EndpointID == endpointID {
+			copied := *d
+			deliveries = append(deliveries, &copied)
+		}
+	}
+	sort.Slice(deliveries, func(i, j int) bool {
+		return deliveries[i].CreatedAt.After(deliveries[j].CreatedAt)
+	})
+	if limit > 0 && len(deliveries) > limit {
+		deliveries = deliveries[:limit]
+	}
+	return deliveries, nil
+}
+
+// FindDueDeliveries retrieves pending deliveries whose next attempt is due, oldest first
+func (r *InMemoryWebhookRepository) FindDueDeliveries(ctx context.Context, now time.Time, limit int) ([]*entity.WebhookDelivery, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var due []*entity.WebhookDelivery
+	for _, d := range r.deliveries {
+		if d.Status == entity.WebhookDeliveryPending && d.NextAttemptAt != nil && !d.NextAttemptAt.After(now) {
+			copied := *d
+			due = append(due, &copied)
+		}
+	}
+	sort.Slice(due, func(i, j int) bool {
+		return due[i].NextAttemptAt.Before(*due[j].NextAttemptAt)
+	})
+	if limit > 0 && len(due) > limit {
+		due = due[:limit]
+	}
+	return due, nil
+}
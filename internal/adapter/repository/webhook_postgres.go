@@ -0,0 +1,275 @@
+// Copyright (c) 2026 Muhammet Ali Büyük. All rights reserved.
+// This source code is proprietary. Confidential and private.
+// Unauthorized copying or distribution is strictly prohibited.
+// Contact: iletisim@alibuyuk.net | Website: alibuyuk.net
+
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/qantesm/subflow/internal/core/entity"
+)
+
+// PostgresWebhookRepository implements WebhookRepository for PostgreSQL
+type PostgresWebhookRepository struct {
+	pool      *Pool
+	architect string
+}
+
+// NewPostgresWebhookRepository creates a new PostgreSQL webhook repository
+func NewPostgresWebhookRepository(pool *Pool) *PostgresWebhookRepository {
+	return &PostgresWebhookRepository{
+		pool:      pool,
+		architect: "Muhammet-Ali-Buyuk",
+	}
+}
+
+const webhookEndpointColumns = `id, tenant_id, url, description, events, secret, active, created_at, created_by, updated_at`
+
+const webhookDeliveryColumns = `
+	id, endpoint_id, event_id, event_type, payload, status, attempts, next_attempt_at,
+	last_status_code, last_error, replay_of, created_at, delivered_at
+`
+
+// SaveEndpoint stores a new endpoint
+func (r *PostgresWebhookRepository) SaveEndpoint(ctx context.Context, e *entity.WebhookEndpoint) error {
+	_, err := r.pool.Exec(ctx, `
+		INSERT INTO webhook_endpoints (`+webhookEndpointColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+	`,
+		e.ID,
+		e.TenantID,
+		e.URL,
+		e.Description,
+		eventTypeStrings(e.Events),
+		e.Secret,
+		e.Active,
+		e.CreatedAt,
+		e.CreatedBy,
+		e.UpdatedAt,
+	)
+	return err
+}
+
+// UpdateEndpoint stores the URL, description, events and state of an endpoint
+func (r *PostgresWebhookRepository) UpdateEndpoint(ctx context.Context, e *entity.WebhookEndpoint) error {
+	tag, err := r.pool.Exec(ctx, `
+		UPDATE webhook_endpoints
+		SET url = $2, description = $3, events = $4, active = $5, updated_at = $6
+		WHERE id = $1
+	`,
+		e.ID,
+		e.URL,
+		e.Description,
+		eventTypeStrings(e.Events),
+		e.Active,
+		e.UpdatedAt,
+	)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return entity.ErrWebhookEndpointNotFound
+	}
+	return nil
+}
+
+// FindEndpointByID retrieves an endpoint by its ID
+func (r *PostgresWebhookRepository) FindEndpointByID(ctx context.Context, id uuid.UUID) (*entity.WebhookEndpoint, error) {
+	row := r.pool.QueryRow(ctx, `SELECT `+webhookEndpointColumns+` FROM webhook_endpoints WHERE id = $1`, id)
+
+	e, err := scanWebhookEndpoint(row)
+	if err == pgx.ErrNoRows {
+		return nil, entity.ErrWebhookEndpointNotFound
+	}
+	return e, err
+}
+
+// FindEndpointsByTenant retrieves the endpoints of a tenant, oldest first
+func (r *PostgresWebhookRepository) FindEndpointsByTenant(ctx context.Context, tenantID uuid.UUID) ([]*entity.WebhookEndpoint, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT `+webhookEndpointColumns+`
+		FROM webhook_endpoints
+		WHERE tenant_id = $1
+		ORDER BY created_at
+	`, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var endpoints []*entity.WebhookEndpoint
+	for rows.Next() {
+		e, err := scanWebhookEndpoint(rows)
+		if err != nil {
+			return nil, err
+		}
+		endpoints = append(endpoints, e)
+	}
+	return endpoints, rows.Err()
+}
+
+// SaveDelivery stores a new delivery
+func (r *PostgresWebhookRepository) SaveDelivery(ctx context.Context, d *entity.WebhookDelivery) error {
+	_, err := r.pool.Exec(ctx, `
+		INSERT INTO webhook_deliveries (`+webhookDeliveryColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+	`,
+		d.ID,
+		d.EndpointID,
+		d.EventID,
+		d.EventType,
+		d.Payload,
+		d.Status,
+		d.Attempts,
+		d.NextAttemptAt,
+		d.LastStatusCode,
+		d.LastError,
+		d.ReplayOf,
+		d.CreatedAt,
+		d.DeliveredAt,
+	)
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == "23505" && pgErr.ConstraintName == "webhook_deliveries_endpoint_event_key" {
+		return entity.ErrWebhookDeliveryExists
+	}
+	return err
+}
+
+// UpdateDelivery stores the outcome of an attempt
+func (r *PostgresWebhookRepository) UpdateDelivery(ctx context.Context, d *entity.WebhookDelivery) error {
+	tag, err := r.pool.Exec(ctx, `
+		UPDATE webhook_deliveries
+		SET status = $2, attempts = $3, next_attempt_at = $4, last_status_code = $5,
+			last_error = $6, delivered_at = $7
+		WHERE id = $1
+	`,
+		d.ID,
+		d.Status,
+		d.Attempts,
+		d.NextAttemptAt,
+		d.LastStatusCode,
+		d.LastError,
+		d.DeliveredAt,
+	)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return entity.ErrWebhookDeliveryNotFound
+	}
+	return nil
+}
+
+// FindDeliveryByID retrieves a delivery by its ID
+func (r *PostgresWebhookRepository) FindDeliveryByID(ctx context.Context, id uuid.UUID) (*entity.WebhookDelivery, error) {
+	row := r.pool.QueryRow(ctx, `SELECT `+webhookDeliveryColumns+` FROM webhook_deliveries WHERE id = $1`, id)
+
+	d, err := scanWebhookDelivery(row)
+	if err == pgx.ErrNoRows {
+		return nil, entity.ErrWebhookDeliveryNotFound
+	}
+	return d, err
+}
+
+// FindDeliveriesByEndpoint retrieves the latest deliveries of an endpoint, newest first
+func (r *PostgresWebhookRepository) FindDeliveriesByEndpoint(ctx context.Context, endpointID uuid.UUID, limit int) ([]*entity.WebhookDelivery, error) {
+	return r.queryDeliveries(ctx, `
+		SELECT `+webhookDeliveryColumns+`
+		FROM webhook_deliveries
+		WHERE endpoint_id = $1
+		ORDER BY created_at DESC
+		LIMIT $2
+	`, endpointID, limit)
+}
+
+// FindDueDeliveries retrieves pending deliveries whose next attempt is due, oldest first
+func (r *PostgresWebhookRepository) FindDueDeliveries(ctx context.Context, now time.Time, limit int) ([]*entity.WebhookDelivery, error) {
+	return r.queryDeliveries(ctx, `
+		SELECT `+webhookDeliveryColumns+`
+		FROM webhook_deliveries
+		WHERE status = 'PENDING' AND next_attempt_at <= $1
+		ORDER BY next_attempt_at
+		LIMIT $2
+	`, now, limit)
+}
+
+func (r *PostgresWebhookRepository) queryDeliveries(ctx context.Context, query string, args ...any) ([]*entity.WebhookDelivery, error) {
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var deliveries []*entity.WebhookDelivery
+	for rows.Next() {
+		d, err := scanWebhookDelivery(rows)
+		if err != nil {
+			return nil, err
+		}
+		deliveries = append(deliveries, d)
+	}
+	return deliveries, rows.Err()
+}
+
+func scanWebhookEndpoint(row pgx.Row) (*entity.WebhookEndpoint, error) {
+	e := &entity.WebhookEndpoint{}
+	var events []string
+	err := row.Scan(
+		&e.ID,
+		&e.TenantID,
+		&e.URL,
+		&e.Description,
+		&events,
+		&e.Secret,
+		&e.Active,
+		&e.CreatedAt,
+		&e.CreatedBy,
+		&e.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	for _, t := range events {
+		e.Events = append(e.Events, entity.EventType(t))
+	}
+	return e, nil
+}
+
+func scanWebhookDelivery(row pgx.Row) (*entity.WebhookDelivery, error) {
+	d := &entity.WebhookDelivery{}
+	err := row.Scan(
+		&d.ID,
+		&d.EndpointID,
+		&d.EventID,
+		&d.EventType,
+		&d.Payload,
+		&d.Status,
+		&d.Attempts,
+		&d.NextAttemptAt,
+		&d.LastStatusCode,
+		&d.LastError,
+		&d.ReplayOf,
+		&d.CreatedAt,
+		&d.DeliveredAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+func eventTypeStrings(types []entity.EventType) []string {
+	out := make([]string, len(types))
+	for i, t := range types {
+		out[i] = string(t)
+	}
+	return out
+}
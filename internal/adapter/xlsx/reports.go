@@ -0,0 +1,392 @@
+// Copyright (c) 2026 Muhammet Ali Büyük. All rights reserved.
+// This source code is proprietary. Confidential and private.
+// Unauthorized copying or distribution is strictly prohibited.
+// Contact: iletisim@alibuyuk.net | Website: alibuyuk.net
+
+package xlsx
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/qantesm/subflow/internal/core/entity"
+	"github.com/qantesm/subflow/internal/core/service"
+)
+
+// LedgerHistoryWorkbook exports a project's transaction history with a running balance
+// The balance column is a live formula so accountants can append rows in Excel.
+// Amounts in different currencies are never added up: each currency gets its own sheet.
+func LedgerHistoryWorkbook(projectID uuid.UUID, transactions []*entity.Transaction) *Workbook {
+	byCurrency := make(map[string][]*entity.Transaction)
+	var currencies []string
+	for _, tx := range transactions {
+		if _, ok := byCurrency[tx.Currency]; !ok {
+			currencies = append(currencies, tx.Currency)
+		}
+		byCurrency[tx.Currency] = append(byCurrency[tx.Currency], tx)
+	}
+	sort.Strings(currencies)
+
+	wb := NewWorkbook()
+	if len(currencies) <= 1 {
+		writeLedgerSheet(wb.AddSheet("Ledger"), projectID, transactions)
+		return wb
+	}
+	for _, currency := range currencies {
+		writeLedgerSheet(wb.AddSheet("Ledger "+currency), projectID, byCurrency[currency])
+	}
+	return wb
+}
+
+// writeLedgerSheet writes the history of transactions that share one currency
+func writeLedgerSheet(sheet *Sheet, projectID uuid.UUID, transactions []*entity.Transaction) {
+	sheet.SetColumnWidths(12, 20, 20, 40, 10, 16, 16, 16, 18)
+
+	sheet.AddRow(Header("Project"), Text(projectID.String()))
+	sheet.AddRow()
+	headerRow := sheet.AddRow(
+		Header("Date"),
+		Header("Type"),
+		Header("Reference"),
+		Header("Description"),
+		Header("Currency"),
+		Header("Amount"),
+		Header("Debit"),
+		Header("Credit"),
+		Header("Balance"),
+	)
+
+	// Ledger reads chronologically regardless of repository ordering
+	ordered := make([]*entity.Transaction, len(transactions))
+	copy(ordered, transactions)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		if ordered[i].EffectiveDate.Equal(ordered[j].EffectiveDate) {
+			return ordered[i].CreatedAt.Before(ordered[j].CreatedAt)
+		}
+		return ordered[i].EffectiveDate.Before(ordered[j].EffectiveDate)
+	})
+
+	var balance int64
+	for _, tx := range ordered {
+		effect := service.BalanceEffect(tx)
+		balance += effect
+
+		debit, credit := Empty(), Empty()
+		if effect > 0 {
+			debit = Money(effect)
+		} else if effect < 0 {
+			credit = Money(-effect)
+		}
+
+		row := sheet.NextRow()
+		previous := "0"
+		if row-1 > headerRow {
+			previous = Ref(8, row-1)
+		}
+		balanceFormula := fmt.Sprintf("%s+%s-%s", previous, Ref(6, row), Ref(7, row))
+
+		sheet.AddRow(
+			Date(tx.EffectiveDate),
+			Text(string(tx.Type)),
+			Text(tx.ReferenceNo),
+			Text(tx.Description),
+			Text(tx.Currency),
+			Money(tx.AmountCents),
+			debit,
+			credit,
+			Formula(balanceFormula, CentsToMajor(balance), StyleMoney),
+		)
+	}
+
+	if len(ordered) > 0 {
+		first, last := headerRow+1, sheet.NextRow()-1
+		sheet.AddRow()
+		sheet.AddRow(
+			Header("Total"),
+			Empty(), Empty(), Empty(), Empty(), Empty(),
+			sumFormula(6, first, last, ordered, func(tx *entity.Transaction) int64 {
+				if e := service.BalanceEffect(tx); e > 0 {
+					return e
+				}
+				return 0
+			}),
+			sumFormula(7, first, last, ordered, func(tx *entity.Transaction) int64 {
+				if e := service.BalanceEffect(tx); e < 0 {
+					return -e
+				}
+				return 0
+			}),
+			Formula(Ref(8, last), CentsToMajor(balance), StyleMoneyBold),
+		)
+	}
+}
+
+// AIABillingWorkbook exports the G702 application summary and, when provided,
+// the tax breakdown and G703 continuation sheet. Totals, retainage and taxes are written as formulas.
+func AIABillingWorkbook(input service.AIABillingInput, result *service.AIABillingResult, tax *entity.TaxBreakdown, continuation *service.G703ContinuationSheet) *Workbook {
+	wb := NewWorkbook()
+	sheet := wb.AddSheet("G702")
+	writeG702(sheet, input, result)
+	if tax != nil {
+		writeTaxes(sheet, tax)
+	}
+	if continuation != nil {
+		writeG703(wb.AddSheet("G703"), continuation)
+	}
+	return wb
+}
+
+func writeG702(sheet *Sheet, input service.AIABillingInput, result *service.AIABillingResult) {
+	sheet.SetColumnWidths(48, 20)
+	sheet.AddRow(Header("AIA G702 - Application and Certificate for Payment"))
+	sheet.AddRow()
+
+	// Rows 3-23 are fixed so the formulas can reference column B directly
+	sheet.AddRow(Text("1. Original Contract Sum"), Money(input.OriginalContractSum))
+	sheet.AddRow(Text("2. Net Change by Change Orders"), Money(input.ApprovedChangeOrders))
+	sheet.AddRow(Text("3. Contract Sum to Date"), Formula("B3+B4", CentsToMajor(result.ContractSum), StyleMoney))
+	sheet.AddRow(Text("   Work Completed (Previous)"), Money(input.PreviousWorkCompleted))
+	sheet.AddRow(Text("   Work Completed (This Period)"), Money(input.CurrentWorkCompleted))
+	sheet.AddRow(Text("   Materials Presently Stored"), Money(input.StoredMaterials))
+	sheet.AddRow(Text("4. Total Completed & Stored to Date"), Formula("B6+B7+B8", CentsToMajor(result.TotalCompletedAndStored), StyleMoney))
+	sheet.AddRow(Text("   Labor Retainage Rate"), Percent(input.LaborRetainageRate))
+	sheet.AddRow(Text("   Material Retainage Rate"), Percent(input.MaterialRetainageRate))
+	sheet.AddRow(Text("   a. Retainage on Completed Work"), Formula("ROUNDDOWN((B6+B7)*B10,2)", CentsToMajor(result.LaborRetainage), StyleMoney))
+	sheet.AddRow(Text("   b. Retainage on Stored Material"), Formula("ROUNDDOWN(B8*B11,2)", CentsToMajor(result.MaterialRetainage), StyleMoney))
+	sheet.AddRow(Text("   Total Retainage"), Formula("B12+B13", CentsToMajor(result.TotalRetainage), StyleMoney))
+	sheet.AddRow(Text("5. Total Earned Less Retainage"), Formula("B9-B14+B20-B21", CentsToMajor(result.TotalEarned), StyleMoney))
+	sheet.AddRow(Text("6. Less Previous Certificates for Payment"), Money(input.PreviousCertificates))
+	sheet.AddRow(Header("7. Current Payment Due"), Formula("B15-B16", CentsToMajor(result.CurrentPaymentDue), StyleMoneyBold))
+	sheet.AddRow(Text("8. Balance to Finish, Including Retainage"), Formula("B5-B9+B14", CentsToMajor(result.ContractSum-result.TotalCompletedAndStored+result.TotalRetainage), StyleMoney))
+	sheet.AddRow(Text("   Percent Complete"), Formula("IF(B5>0,B9/B5,0)", float64(result.PercentComplete)/10000, StylePercent))
+	sheet.AddRow(Text("   Price Escalation to Date (Fiyat Farkı)"), Money(input.PriceEscalation))
+	sheet.AddRow(Text("   Less Advance Recovered to Date (Avans Mahsubu)"), Money(result.AdvanceRecovered))
+	sheet.AddRow(Text("   Less Deductions This Period (Kesintiler)"), Money(input.Deductions))
+	sheet.AddRow(Text("   Payment Due Less Deductions"), Formula("B17-B22", CentsToMajor(result.NetPaymentDue), StyleMoney))
+}
+
+// writeTaxes appends the KDV, tevkifat and stamp duty rows below the G702 summary (rows 25-34)
+func writeTaxes(sheet *Sheet, tax *entity.TaxBreakdown) {
+	withholdingLabel := "   Less KDV Withholding"
+	if tax.WithholdingCode != "" {
+		withholdingLabel += " (" + tax.WithholdingCode + ")"
+	}
+
+	sheet.AddRow()
+	sheet.AddRow(Header("Taxes"))
+	sheet.AddRow(Text("   Tax Base (Current Payment Due)"), Formula("B17", CentsToMajor(tax.Base), StyleMoney))
+	sheet.AddRow(Text("   KDV Rate"), taxRate(tax.KDVRate))
+	sheet.AddRow(Text("   KDV"), Formula("ROUND(B26*B27,2)", CentsToMajor(tax.KDVAmount), StyleMoney))
+	sheet.AddRow(Text("   Withholding Rate (share of KDV)"), taxRate(tax.WithholdingRate))
+	sheet.AddRow(Text(withholdingLabel), Formula("ROUND(B28*B29,2)", CentsToMajor(tax.WithholdingAmount), StyleMoney))
+	sheet.AddRow(Text("   Stamp Duty Rate"), taxRate(tax.StampDutyRate))
+	sheet.AddRow(Text("   Less Stamp Duty"), Formula("ROUND(B26*B31,2)", CentsToMajor(tax.StampDutyAmount), StyleMoney))
+	sheet.AddRow(Text("   Invoice Total incl. KDV"), Formula("B26+B28", CentsToMajor(tax.GrossAmount), StyleMoney))
+	sheet.AddRow(Header("Net Payable"), Formula("B33-B30-B32", CentsToMajor(tax.NetPayable), StyleMoneyBold))
+}
+
+// RetainageInvoiceWorkbook exports a retainage release invoice (teminat kesintisi iade faturası)
+// Remaining retainage is a formula over the held, previously released and released rows
+func RetainageInvoiceWorkbook(inv *entity.RetainageInvoice) *Workbook {
+	wb := NewWorkbook()
+	sheet := wb.AddSheet("Retainage Release")
+	sheet.SetColumnWidths(40, 24)
+
+	sheet.AddRow(Header("Retainage Release Invoice"))
+	sheet.AddRow()
+	sheet.AddRow(Text("Invoice No"), Text(inv.InvoiceNo))
+	sheet.AddRow(Text("Issue Date"), Date(inv.IssueDate))
+	sheet.AddRow(Text("Project"), Text(inv.ProjectCode+" "+inv.ProjectName))
+	sheet.AddRow(Text("Description"), Text(inv.Description))
+	sheet.AddRow(Text("Currency"), Text(inv.Currency))
+	sheet.AddRow()
+
+	// Rows 9-12 are fixed so the remaining formula can reference column B directly
+	sheet.AddRow(Text("Total Retainage Held"), Money(inv.TotalHeld))
+	sheet.AddRow(Text("Less Previously Released"), Money(inv.PreviouslyReleased))
+	sheet.AddRow(Header("This Release"), Cell{Number: CentsToMajor(inv.ThisRelease), Style: StyleMoneyBold})
+	sheet.AddRow(Text("Retainage Remaining"), Formula("B9-B10-B11", CentsToMajor(inv.Remaining), StyleMoney))
+	return wb
+}
+
+// JobCostWorkbook exports the job cost report (maliyet raporu) with one row per cost code
+// Forecast to complete, variance and percent spent are formulas over each row; parent
+// rows already include their children, so the total row is written as values.
+func JobCostWorkbook(report *service.JobCostReport) *Workbook {
+	wb := NewWorkbook()
+	sheet := wb.AddSheet("Job Cost")
+	sheet.SetColumnWidths(12, 40, 16, 16, 16, 18, 18, 16, 10)
+
+	sheet.AddRow(Header("Job Cost Report"))
+	sheet.AddRow(Text("Project"), Text(report.ProjectCode+" "+report.ProjectName))
+	sheet.AddRow(Text("As Of"), Date(report.AsOf))
+	sheet.AddRow(Text("Currency"), Text(report.Currency))
+	sheet.AddRow()
+	sheet.AddRow(
+		Header("Cost Code"),
+		Header("Description"),
+		Header("Budget"),
+		Header("Committed"),
+		Header("Actual"),
+		Header("Forecast at Completion"),
+		Header("Forecast to Complete"),
+		Header("Variance"),
+		Header("% Spent"),
+	)
+
+	for _, line := range report.Lines {
+		r := sheet.NextRow()
+		sheet.AddRow(
+			Text(line.Code),
+			Text(strings.Repeat("  ", line.Level)+line.Name),
+			Money(line.Budget),
+			Money(line.Committed),
+			Money(line.Actual),
+			Money(line.ForecastAtCompletion),
+			Formula(fmt.Sprintf("F%d-E%d", r, r), CentsToMajor(line.ForecastToComplete), StyleMoney),
+			Formula(fmt.Sprintf("C%d-F%d", r, r), CentsToMajor(line.Variance), StyleMoney),
+			Formula(fmt.Sprintf("IF(C%d<>0,E%d/C%d,0)", r, r, r), float64(line.PercentSpent)/10000, StylePercent),
+		)
+	}
+
+	total := report.Total
+	bold := func(cents int64) Cell { return Cell{Number: CentsToMajor(cents), Style: StyleMoneyBold} }
+	sheet.AddRow(
+		Empty(),
+		Header("TOTAL"),
+		bold(total.Budget),
+		bold(total.Committed),
+		bold(total.Actual),
+		bold(total.ForecastAtCompletion),
+		bold(total.ForecastToComplete),
+		bold(total.Variance),
+		Percent(total.PercentSpent),
+	)
+
+	sheet.AddRow()
+	contractRow := sheet.AddRow(Text("Contract Amount"), Money(report.ContractAmount))
+	costRow := sheet.AddRow(Text("Forecast Cost at Completion"), Money(total.ForecastAtCompletion))
+	marginRow := sheet.AddRow(Header("Projected Margin"), Formula(fmt.Sprintf("B%d-B%d", contractRow, costRow), CentsToMajor(report.ProjectedMargin), StyleMoneyBold))
+	sheet.AddRow(Text("Projected Margin %"), Formula(fmt.Sprintf("IF(B%d<>0,B%d/B%d,0)", contractRow, marginRow, contractRow), float64(report.ProjectedMarginRate)/10000, StylePercent))
+	return wb
+}
+
+// AuditLogWorkbook exports audit trail entries for auditors, newest first
+// Timestamps are written as RFC 3339 text in UTC so the seconds survive.
+func AuditLogWorkbook(logs []*entity.AuditLog) *Workbook {
+	wb := NewWorkbook()
+	sheet := wb.AddSheet("Audit Log")
+	sheet.SetColumnWidths(22, 26, 18, 38, 38, 28, 16, 30, 50, 50)
+
+	sheet.AddRow(
+		Header("Time (UTC)"),
+		Header("Action"),
+		Header("Entity Type"),
+		Header("Entity ID"),
+		Header("Actor ID"),
+		Header("Actor Email"),
+		Header("IP Address"),
+		Header("User Agent"),
+		Header("Old Value"),
+		Header("New Value"),
+	)
+	for _, l := range logs {
+		sheet.AddRow(
+			Text(l.CreatedAt.UTC().Format(time.RFC3339)),
+			Text(l.Action),
+			Text(l.EntityType),
+			Text(optionalID(l.EntityID)),
+			Text(optionalID(l.ActorID)),
+			Text(l.ActorEmail),
+			Text(l.IPAddress),
+			Text(l.UserAgent),
+			Text(string(l.OldValue)),
+			Text(string(l.NewValue)),
+		)
+	}
+	return wb
+}
+
+func optionalID(id *uuid.UUID) string {
+	if id == nil {
+		return ""
+	}
+	return id.String()
+}
+
+// taxRate creates a percentage cell from a parts-per-million tax rate
+func taxRate(rate int64) Cell {
+	return Cell{Number: float64(rate) / float64(entity.TaxRateScale), Style: StylePercent}
+}
+
+func writeG703(sheet *Sheet, continuation *service.G703ContinuationSheet) {
+	sheet.SetColumnWidths(8, 40, 16, 16, 16, 16, 16, 10, 16, 16)
+	sheet.AddRow(Header("AIA G703 - Continuation Sheet"))
+	sheet.AddRow(
+		Header("Labor Retainage"), Percent(continuation.LaborRetainageRate),
+		Header("Material Retainage"), Percent(continuation.MaterialRetainageRate),
+	)
+	laborRate, materialRate := "$B$2", "$D$2"
+	sheet.AddRow()
+	headerRow := sheet.AddRow(
+		Header("A. Item No"),
+		Header("B. Description of Work"),
+		Header("C. Scheduled Value"),
+		Header("D. From Previous Application"),
+		Header("E. This Period"),
+		Header("F. Materials Presently Stored"),
+		Header("G. Total Completed and Stored"),
+		Header("G/C %"),
+		Header("H. Balance to Finish"),
+		Header("I. Retainage"),
+	)
+
+	for _, line := range continuation.Lines {
+		r := sheet.NextRow()
+		sheet.AddRow(
+			Text(line.ItemNo),
+			Text(line.Description),
+			Money(line.ScheduledValue),
+			Money(line.PreviousWork),
+			Money(line.CurrentWork),
+			Money(line.StoredMaterials),
+			Formula(fmt.Sprintf("D%d+E%d+F%d", r, r, r), CentsToMajor(line.TotalCompletedAndStored), StyleMoney),
+			Formula(fmt.Sprintf("IF(C%d>0,G%d/C%d,0)", r, r, r), float64(line.PercentComplete)/10000, StylePercent),
+			Formula(fmt.Sprintf("C%d-G%d", r, r), CentsToMajor(line.BalanceToFinish), StyleMoney),
+			Formula(fmt.Sprintf("ROUNDDOWN((D%d+E%d)*%s,2)+ROUNDDOWN(F%d*%s,2)", r, r, laborRate, r, materialRate), CentsToMajor(line.Retainage), StyleMoney),
+		)
+	}
+
+	first, last := headerRow+1, sheet.NextRow()-1
+	total := continuation.GrandTotal
+	sum := func(col string, cents int64) Cell {
+		if last < first {
+			return Money(cents)
+		}
+		return Formula(fmt.Sprintf("SUM(%s%d:%s%d)", col, first, col, last), CentsToMajor(cents), StyleMoneyBold)
+	}
+	r := sheet.NextRow()
+	sheet.AddRow(
+		Empty(),
+		Header("GRAND TOTAL"),
+		sum("C", total.ScheduledValue),
+		sum("D", total.PreviousWork),
+		sum("E", total.CurrentWork),
+		sum("F", total.StoredMaterials),
+		sum("G", total.TotalCompletedAndStored),
+		Formula(fmt.Sprintf("IF(C%d>0,G%d/C%d,0)", r, r, r), float64(total.PercentComplete)/10000, StylePercent),
+		sum("H", total.BalanceToFinish),
+		sum("I", total.Retainage),
+	)
+}
+
+func sumFormula(col, first, last int, txs []*entity.Transaction, value func(*entity.Transaction) int64) Cell {
+	var total int64
+	for _, tx := range txs {
+		total += value(tx)
+	}
+	return Formula(fmt.Sprintf("SUM(%s:%s)", Ref(col, first), Ref(col, last)), CentsToMajor(total), StyleMoneyBold)
+}
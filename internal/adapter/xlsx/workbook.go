@@ -0,0 +1,330 @@
+// Copyright (c) 2026 Muhammet Ali Büyük. All rights reserved.
+// This source code is proprietary. Confidential and private.
+// Unauthorized copying or distribution is strictly prohibited.
+// Contact: iletisim@alibuyuk.net | Website: alibuyuk.net
+
+// Package xlsx is a minimal native Office Open XML spreadsheet writer
+// No external services or libraries - workbooks are assembled with archive/zip
+package xlsx
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ContentType is the MIME type used for Accept negotiation and downloads
+const ContentType = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+
+// Style is an index into the fixed cellXfs table written to styles.xml
+type Style int
+
+const (
+	StyleDefault   Style = iota
+	StyleHeader          // Bold
+	StyleMoney           // #,##0.00
+	StylePercent         // 0.00%
+	StyleDate            // yyyy-mm-dd
+	StyleMoneyBold       // Bold #,##0.00 for total rows
+)
+
+// Cell is a single spreadsheet cell
+// A cell with a Formula keeps Number as its cached value so viewers
+// that do not recalculate still show the correct figure
+type Cell struct {
+	Text    string
+	Number  float64
+	Formula string
+	Style   Style
+	isText  bool
+	isEmpty bool
+}
+
+// Text creates an inline string cell
+func Text(s string) Cell {
+	return Cell{Text: s, isText: true}
+}
+
+// Header creates a bold inline string cell
+func Header(s string) Cell {
+	return Cell{Text: s, Style: StyleHeader, isText: true}
+}
+
+// Number creates a plain numeric cell
+func Number(n float64) Cell {
+	return Cell{Number: n}
+}
+
+// Money creates a currency cell from cents (BigInt) - stored as major units
+func Money(cents int64) Cell {
+	return Cell{Number: CentsToMajor(cents), Style: StyleMoney}
+}
+
+// Percent creates a percentage cell from basis points (5000 = 50%)
+func Percent(basisPoints int64) Cell {
+	return Cell{Number: float64(basisPoints) / 10000, Style: StylePercent}
+}
+
+// Date creates a date cell using the Excel 1900 date system
+func Date(t time.Time) Cell {
+	return Cell{Number: excelSerial(t), Style: StyleDate}
+}
+
+// Formula creates a formula cell with its cached result
+func Formula(expr string, cached float64, style Style) Cell {
+	return Cell{Formula: expr, Number: cached, Style: style}
+}
+
+// Empty creates a blank cell (used to skip columns)
+func Empty() Cell {
+	return Cell{isEmpty: true}
+}
+
+// CentsToMajor converts cents to major currency units for display
+func CentsToMajor(cents int64) float64 {
+	return float64(cents) / 100
+}
+
+// Sheet is a single worksheet
+type Sheet struct {
+	Name   string
+	rows   [][]Cell
+	widths []float64
+}
+
+// AddRow appends a row of cells and returns its 1-based row number
+func (s *Sheet) AddRow(cells ...Cell) int {
+	s.rows = append(s.rows, cells)
+	return len(s.rows)
+}
+
+// NextRow returns the 1-based number the next AddRow call will use
+func (s *Sheet) NextRow() int {
+	return len(s.rows) + 1
+}
+
+// SetColumnWidths sets column widths in characters, starting at column A
+func (s *Sheet) SetColumnWidths(widths ...float64) {
+	s.widths = widths
+}
+
+// Workbook is an in-memory XLSX document
+type Workbook struct {
+	sheets []*Sheet
+}
+
+// NewWorkbook creates an empty workbook
+func NewWorkbook() *Workbook {
+	return &Workbook{}
+}
+
+// AddSheet appends a worksheet to the workbook
+func (wb *Workbook) AddSheet(name string) *Sheet {
+	sheet := &Sheet{Name: sanitizeSheetName(name)}
+	wb.sheets = append(wb.sheets, sheet)
+	return sheet
+}
+
+// Bytes renders the workbook to a byte slice
+func (wb *Workbook) Bytes() ([]byte, error) {
+	var buf bytes.Buffer
+	if err := wb.Write(&buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Write renders the workbook as a zip package to w
+func (wb *Workbook) Write(w io.Writer) error {
+	if len(wb.sheets) == 0 {
+		wb.AddSheet("Sheet1")
+	}
+
+	zw := zip.NewWriter(w)
+
+	parts := []struct {
+		name string
+		body string
+	}{
+		{"[Content_Types].xml", wb.contentTypes()},
+		{"_rels/.rels", rootRels},
+		{"xl/workbook.xml", wb.workbookXML()},
+		{"xl/_rels/workbook.xml.rels", wb.workbookRels()},
+		{"xl/styles.xml", stylesXML},
+	}
+	for i, sheet := range wb.sheets {
+		parts = append(parts, struct {
+			name string
+			body string
+		}{fmt.Sprintf("xl/worksheets/sheet%d.xml", i+1), sheet.xml()})
+	}
+
+	for _, part := range parts {
+		f, err := zw.Create(part.name)
+		if err != nil {
+			return fmt.Errorf("failed to create %s: %w", part.name, err)
+		}
+		if _, err := io.WriteString(f, part.body); err != nil {
+			return fmt.Errorf("failed to write %s: %w", part.name, err)
+		}
+	}
+
+	return zw.Close()
+}
+
+// ColumnName converts a 0-based column index to spreadsheet letters (0 = A, 26 = AA)
+func ColumnName(index int) string {
+	name := ""
+	for index >= 0 {
+		name = string(rune('A'+index%26)) + name
+		index = index/26 - 1
+	}
+	return name
+}
+
+// Ref builds an A1-style reference from a 0-based column and 1-based row
+func Ref(col, row int) string {
+	return ColumnName(col) + strconv.Itoa(row)
+}
+
+func (wb *Workbook) contentTypes() string {
+	var b strings.Builder
+	b.WriteString(xml.Header)
+	b.WriteString(`<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">`)
+	b.WriteString(`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>`)
+	b.WriteString(`<Default Extension="xml" ContentType="application/xml"/>`)
+	b.WriteString(`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>`)
+	b.WriteString(`<Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/>`)
+	for i := range wb.sheets {
+		fmt.Fprintf(&b, `<Override PartName="/xl/worksheets/sheet%d.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>`, i+1)
+	}
+	b.WriteString(`</Types>`)
+	return b.String()
+}
+
+func (wb *Workbook) workbookXML() string {
+	var b strings.Builder
+	b.WriteString(xml.Header)
+	b.WriteString(`<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">`)
+	b.WriteString(`<sheets>`)
+	for i, sheet := range wb.sheets {
+		fmt.Fprintf(&b, `<sheet name="%s" sheetId="%d" r:id="rId%d"/>`, escape(sheet.Name), i+1, i+1)
+	}
+	b.WriteString(`</sheets>`)
+	// Ask Excel to recalculate formulas on open (calcPr must follow sheets)
+	b.WriteString(`<calcPr fullCalcOnLoad="1"/>`)
+	b.WriteString(`</workbook>`)
+	return b.String()
+}
+
+func (wb *Workbook) workbookRels() string {
+	var b strings.Builder
+	b.WriteString(xml.Header)
+	b.WriteString(`<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">`)
+	for i := range wb.sheets {
+		fmt.Fprintf(&b, `<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet%d.xml"/>`, i+1, i+1)
+	}
+	fmt.Fprintf(&b, `<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/>`, len(wb.sheets)+1)
+	b.WriteString(`</Relationships>`)
+	return b.String()
+}
+
+func (s *Sheet) xml() string {
+	var b strings.Builder
+	b.WriteString(xml.Header)
+	b.WriteString(`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">`)
+
+	if len(s.widths) > 0 {
+		b.WriteString(`<cols>`)
+		for i, width := range s.widths {
+			fmt.Fprintf(&b, `<col min="%d" max="%d" width="%s" customWidth="1"/>`, i+1, i+1, formatFloat(width))
+		}
+		b.WriteString(`</cols>`)
+	}
+
+	b.WriteString(`<sheetData>`)
+	for r, row := range s.rows {
+		fmt.Fprintf(&b, `<row r="%d">`, r+1)
+		for c, cell := range row {
+			if cell.isEmpty {
+				continue
+			}
+			writeCell(&b, Ref(c, r+1), cell)
+		}
+		b.WriteString(`</row>`)
+	}
+	b.WriteString(`</sheetData></worksheet>`)
+	return b.String()
+}
+
+func writeCell(b *strings.Builder, ref string, cell Cell) {
+	switch {
+	case cell.Formula != "":
+		fmt.Fprintf(b, `<c r="%s" s="%d"><f>%s</f><v>%s</v></c>`, ref, cell.Style, escape(cell.Formula), formatFloat(cell.Number))
+	case cell.isText:
+		fmt.Fprintf(b, `<c r="%s" s="%d" t="inlineStr"><is><t xml:space="preserve">%s</t></is></c>`, ref, cell.Style, escape(cell.Text))
+	default:
+		fmt.Fprintf(b, `<c r="%s" s="%d"><v>%s</v></c>`, ref, cell.Style, formatFloat(cell.Number))
+	}
+}
+
+// excelSerial converts a time to an Excel serial date (days since 1899-12-30)
+func excelSerial(t time.Time) float64 {
+	epoch := time.Date(1899, 12, 30, 0, 0, 0, 0, time.UTC)
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	return float64(day.Sub(epoch) / (24 * time.Hour))
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+func escape(s string) string {
+	var buf bytes.Buffer
+	_ = xml.EscapeText(&buf, []byte(s))
+	return buf.String()
+}
+
+// sanitizeSheetName enforces Excel's 31-character limit and forbidden characters
+func sanitizeSheetName(name string) string {
+	name = strings.Map(func(r rune) rune {
+		if strings.ContainsRune(`[]:*?/\`, r) {
+			return '_'
+		}
+		return r
+	}, name)
+	if runes := []rune(name); len(runes) > 31 {
+		name = string(runes[:31])
+	}
+	if name == "" {
+		name = "Sheet"
+	}
+	return name
+}
+
+const rootRels = xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+	`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+	`</Relationships>`
+
+// stylesXML defines the cellXfs table in the same order as the Style constants
+const stylesXML = xml.Header + `<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">` +
+	`<numFmts count="2"><numFmt numFmtId="164" formatCode="#,##0.00"/><numFmt numFmtId="165" formatCode="yyyy-mm-dd"/></numFmts>` +
+	`<fonts count="2"><font><sz val="11"/><name val="Calibri"/></font><font><b/><sz val="11"/><name val="Calibri"/></font></fonts>` +
+	`<fills count="2"><fill><patternFill patternType="none"/></fill><fill><patternFill patternType="gray125"/></fill></fills>` +
+	`<borders count="1"><border><left/><right/><top/><bottom/><diagonal/></border></borders>` +
+	`<cellStyleXfs count="1"><xf numFmtId="0" fontId="0" fillId="0" borderId="0"/></cellStyleXfs>` +
+	`<cellXfs count="6">` +
+	`<xf numFmtId="0" fontId="0" fillId="0" borderId="0" xfId="0"/>` +
+	`<xf numFmtId="0" fontId="1" fillId="0" borderId="0" xfId="0" applyFont="1"/>` +
+	`<xf numFmtId="164" fontId="0" fillId="0" borderId="0" xfId="0" applyNumberFormat="1"/>` +
+	`<xf numFmtId="10" fontId="0" fillId="0" borderId="0" xfId="0" applyNumberFormat="1"/>` +
+	`<xf numFmtId="165" fontId="0" fillId="0" borderId="0" xfId="0" applyNumberFormat="1"/>` +
+	`<xf numFmtId="164" fontId="1" fillId="0" borderId="0" xfId="0" applyFont="1" applyNumberFormat="1"/>` +
+	`</cellXfs>` +
+	`<cellStyles count="1"><cellStyle name="Normal" xfId="0" builtinId="0"/></cellStyles>` +
+	`</styleSheet>`
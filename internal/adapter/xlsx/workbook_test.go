@@ -0,0 +1,181 @@
+// Copyright (c) 2026 Muhammet Ali Büyük. All rights reserved.
+// This source code is proprietary. Confidential and private.
+// Unauthorized copying or distribution is strictly prohibited.
+// Contact: iletisim@alibuyuk.net | Website: alibuyuk.net
+
+package xlsx
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/qantesm/subflow/internal/core/entity"
+	"github.com/qantesm/subflow/internal/core/service"
+)
+
+// readPart extracts a single part from a rendered workbook
+func readPart(t *testing.T, data []byte, name string) string {
+	t.Helper()
+
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatalf("workbook is not a valid zip: %v", err)
+	}
+	for _, f := range zr.File {
+		if f.Name != name {
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			t.Fatalf("open %s: %v", name, err)
+		}
+		defer rc.Close()
+		body, err := io.ReadAll(rc)
+		if err != nil {
+			t.Fatalf("read %s: %v", name, err)
+		}
+		// Every part must be well-formed XML
+		if err := xml.Unmarshal(body, new(interface{})); err != nil {
+			t.Fatalf("%s is not well-formed XML: %v", name, err)
+		}
+		return string(body)
+	}
+	t.Fatalf("part %s missing from workbook", name)
+	return ""
+}
+
+func TestColumnName(t *testing.T) {
+	tests := []struct {
+		index int
+		want  string
+	}{
+		{0, "A"},
+		{25, "Z"},
+		{26, "AA"},
+		{51, "AZ"},
+		{52, "BA"},
+		{701, "ZZ"},
+		{702, "AAA"},
+	}
+
+	for _, tt := range tests {
+		if got := ColumnName(tt.index); got != tt.want {
+			t.Errorf("ColumnName(%d) = %s, want %s", tt.index, got, tt.want)
+		}
+	}
+}
+
+func TestLedgerHistoryWorkbook(t *testing.T) {
+	projectID := uuid.New()
+	userID := uuid.New()
+
+	invoice := entity.NewTransaction(projectID, entity.TransactionTypeInvoice, 100000, "TRY", userID)
+	invoice.EffectiveDate = time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC)
+	invoice.ReferenceNo = "INV-<001>"
+	payment := entity.NewTransaction(projectID, entity.TransactionTypePayment, 40000, "TRY", userID)
+	payment.EffectiveDate = time.Date(2026, 2, 5, 0, 0, 0, 0, time.UTC)
+
+	// Repository order is newest first - the export must be chronological
+	data, err := LedgerHistoryWorkbook(projectID, []*entity.Transaction{payment, invoice}).Bytes()
+	if err != nil {
+		t.Fatalf("Bytes returned error: %v", err)
+	}
+
+	readPart(t, data, "[Content_Types].xml")
+	readPart(t, data, "xl/workbook.xml")
+	readPart(t, data, "xl/styles.xml")
+	sheet := readPart(t, data, "xl/worksheets/sheet1.xml")
+
+	if !strings.Contains(sheet, "INV-&lt;001&gt;") {
+		t.Error("reference number should be XML-escaped")
+	}
+	if !strings.Contains(sheet, `<c r="I4" s="2"><f>0+G4-H4</f><v>1000</v></c>`) {
+		t.Errorf("first balance row should open from zero with cached 1000, got:\n%s", sheet)
+	}
+	if !strings.Contains(sheet, `<f>I4+G5-H5</f><v>600</v>`) {
+		t.Error("second balance row should chain the running balance formula")
+	}
+}
+
+func TestLedgerHistoryWorkbookSplitsCurrencies(t *testing.T) {
+	projectID := uuid.New()
+	userID := uuid.New()
+
+	lira := entity.NewTransaction(projectID, entity.TransactionTypeInvoice, 100000, "TRY", userID)
+	euro := entity.NewTransaction(projectID, entity.TransactionTypeInvoice, 5000, "EUR", userID)
+	data, err := LedgerHistoryWorkbook(projectID, []*entity.Transaction{lira, euro}).Bytes()
+	if err != nil {
+		t.Fatalf("Bytes returned error: %v", err)
+	}
+
+	workbook := readPart(t, data, "xl/workbook.xml")
+	if !strings.Contains(workbook, `name="Ledger EUR"`) || !strings.Contains(workbook, `name="Ledger TRY"`) {
+		t.Errorf("expected one sheet per currency, got:\n%s", workbook)
+	}
+	eur := readPart(t, data, "xl/worksheets/sheet1.xml")
+	if !strings.Contains(eur, `<f>0+G4-H4</f><v>50</v>`) || strings.Contains(eur, "TRY") {
+		t.Errorf("EUR sheet should only hold the EUR invoice, got:\n%s", eur)
+	}
+	try := readPart(t, data, "xl/worksheets/sheet2.xml")
+	if !strings.Contains(try, `<f>0+G4-H4</f><v>1000</v>`) {
+		t.Errorf("TRY sheet should open its own running balance, got:\n%s", try)
+	}
+}
+
+func TestAIABillingWorkbook(t *testing.T) {
+	calc := service.NewCalculator()
+
+	continuation, err := calc.CalculateContinuation([]service.G703LineItem{
+		{ItemNo: "1", Description: "Excavation", ScheduledValue: 20000000, CurrentWork: 5000000},
+	}, 1000, 500)
+	if err != nil {
+		t.Fatalf("CalculateContinuation returned error: %v", err)
+	}
+
+	input := service.AIABillingInput{
+		OriginalContractSum:   20000000,
+		CurrentWorkCompleted:  5000000,
+		LaborRetainageRate:    1000,
+		MaterialRetainageRate: 500,
+	}
+	result, err := calc.Calculate(input)
+	if err != nil {
+		t.Fatalf("Calculate returned error: %v", err)
+	}
+
+	tax := &entity.TaxBreakdown{
+		Base: result.CurrentPaymentDue, KDVCode: "GENEL", KDVRate: 200000, KDVAmount: 900000,
+		WithholdingCode: "601", WithholdingRate: 400000, WithholdingAmount: 360000,
+		GrossAmount: 5400000, NetPayable: 5040000,
+	}
+
+	data, err := AIABillingWorkbook(input, result, tax, continuation).Bytes()
+	if err != nil {
+		t.Fatalf("Bytes returned error: %v", err)
+	}
+
+	g702 := readPart(t, data, "xl/worksheets/sheet1.xml")
+	if !strings.Contains(g702, "<f>B15-B16</f><v>45000</v>") {
+		t.Error("G702 current payment due should be a formula with cached value")
+	}
+	if !strings.Contains(g702, "<f>ROUND(B28*B29,2)</f><v>3600</v>") {
+		t.Error("G702 withholding should be a formula on the KDV row")
+	}
+	if !strings.Contains(g702, "<f>B33-B30-B32</f><v>50400</v>") {
+		t.Error("G702 net payable should deduct withholding and stamp duty")
+	}
+
+	g703 := readPart(t, data, "xl/worksheets/sheet2.xml")
+	if !strings.Contains(g703, "<f>D5+E5+F5</f>") {
+		t.Error("G703 column G should be a formula")
+	}
+	if !strings.Contains(g703, "<f>SUM(I5:I5)</f><v>5000</v>") {
+		t.Error("G703 grand total retainage should sum the line formulas")
+	}
+}
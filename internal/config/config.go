@@ -0,0 +1,392 @@
+// Copyright (c) 2026 Muhammet Ali Büyük. All rights reserved.
+// This source code is proprietary. Confidential and private.
+// Unauthorized copying or distribution is strictly prohibited.
+// Contact: iletisim@alibuyuk.net | Website: alibuyuk.net
+
+// Package config loads the typed application configuration from defaults,
+// an optional YAML file, environment variables and command line flags, in
+// increasing order of precedence.
+package config
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// FileEnv names the variable holding the YAML file path when -config is not given
+const FileEnv = "SUBFLOW_CONFIG"
+
+// Environments the application runs in
+const (
+	EnvDevelopment = "development"
+	EnvStaging     = "staging"
+	EnvProduction  = "production"
+)
+
+// Storage backends
+const (
+	BackendPostgres = "postgres"
+	BackendMemory   = "memory" // Nothing survives a restart; development only
+)
+
+// Mail transports
+const (
+	MailNone = "none" // Notifications stay in the inbox
+	MailSMTP = "smtp"
+	MailFile = "file" // .eml files in Mail.Dir, for local development
+)
+
+// minSecretLength is the shortest JWT secret accepted outside development
+const minSecretLength = 32
+
+// Secret is a configuration value that never appears in output: printing,
+// formatting or encoding it shows a mask. Value returns the real string.
+type Secret string
+
+const secretMask = "********"
+
+// Value returns the secret itself
+func (s Secret) Value() string {
+	return string(s)
+}
+
+// String masks the secret; an unset secret prints empty so it can be spotted
+func (s Secret) String() string {
+	if s == "" {
+		return ""
+	}
+	return secretMask
+}
+
+// GoString masks the secret for %#v
+func (s Secret) GoString() string {
+	return `"` + s.String() + `"`
+}
+
+// MarshalText masks the secret in JSON, YAML and log fields
+func (s Secret) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// Config is the complete application configuration
+type Config struct {
+	Environment string
+	Server      ServerConfig
+	Database    DatabaseConfig
+	Redis       RedisConfig
+	Auth        AuthConfig
+	Workers     WorkerConfig
+	Logging     LoggingConfig
+	Mail        MailConfig
+
+	sources map[string]string // Where each key's value came from, for Describe
+}
+
+// ServerConfig holds the HTTP server settings
+type ServerConfig struct {
+	Port            int
+	ReadTimeout     time.Duration
+	WriteTimeout    time.Duration // Zero for none; server-sent event streams stay open
+	ShutdownTimeout time.Duration
+	BodyLimit       int    // Bytes; imports upload spreadsheets
+	CORSOrigins     string // Comma separated, "*" for any
+	Metrics         bool   // Serve Prometheus metrics at /metrics
+}
+
+// DatabaseConfig holds the PostgreSQL connection pool settings
+type DatabaseConfig struct {
+	Backend  string
+	Host     string
+	Port     int
+	User     string
+	Password Secret
+	Name     string
+	SSLMode  string
+	MaxConns int
+}
+
+// RedisConfig holds the optional Redis event stream settings; empty Host disables it
+type RedisConfig struct {
+	Host     string
+	Port     int
+	Password Secret
+	DB       int
+	Stream   string
+}
+
+// AuthConfig holds the authentication secrets
+type AuthConfig struct {
+	JWTSecret Secret
+	TokenTTL  time.Duration
+}
+
+// WorkerConfig sizes the background worker pool
+type WorkerConfig struct {
+	Count     int
+	QueueSize int
+}
+
+// LoggingConfig holds the logger settings
+type LoggingConfig struct {
+	Level        string
+	Pretty       bool // Human readable console output instead of JSON
+	HealthSample int  // Log one in N successful health checks, 0 for none
+}
+
+// MailConfig selects and configures the notification email transport
+type MailConfig struct {
+	Transport string
+	From      string
+	Host      string
+	Port      int
+	Username  string
+	Password  Secret
+	Dir       string
+}
+
+// Default returns the configuration used when nothing is set
+func Default() *Config {
+	return &Config{
+		Environment: EnvDevelopment,
+		Server: ServerConfig{
+			Port:            3000,
+			ReadTimeout:     30 * time.Second,
+			ShutdownTimeout: 15 * time.Second,
+			BodyLimit:       16 << 20,
+			CORSOrigins:     "*",
+			Metrics:         true,
+		},
+		Database: DatabaseConfig{
+			Backend:  BackendPostgres,
+			Host:     "localhost",
+			Port:     5432,
+			User:     "subflow",
+			Name:     "subflow",
+			SSLMode:  "disable",
+			MaxConns: 10,
+		},
+		Redis: RedisConfig{
+			Port:   6379,
+			Stream: "subflow:events",
+		},
+		Auth: AuthConfig{
+			TokenTTL: 24 * time.Hour,
+		},
+		Workers: WorkerConfig{
+			Count:     4,
+			QueueSize: 100,
+		},
+		Logging: LoggingConfig{
+			Level:        "info",
+			HealthSample: 10,
+		},
+		Mail: MailConfig{
+			Transport: MailNone,
+			Port:      587,
+			Dir:       "mail",
+		},
+		sources: make(map[string]string),
+	}
+}
+
+// Load reads the configuration for the given command line arguments (without
+// the program name): defaults, then the YAML file named by -config or
+// SUBFLOW_CONFIG, then environment variables, then flags. It does not validate.
+func Load(args []string) (*Config, error) {
+	return load(args, os.LookupEnv, os.ReadFile)
+}
+
+func load(args []string, lookupEnv func(string) (string, bool), readFile func(string) ([]byte, error)) (*Config, error) {
+	cfg := Default()
+	fields := cfg.fields()
+
+	// Flags are only recorded here and applied last, above the file and the environment
+	fs := flag.NewFlagSet("subflow", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	path := fs.String("config", "", "YAML configuration file (or "+FileEnv+")")
+	flagged := make(map[string]string)
+	for _, f := range fields {
+		_, isBool := f.value.(*boolValue)
+		fs.Var(&recorder{key: f.key, values: flagged, boolFlag: isBool}, flagName(f.key), f.usage)
+	}
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+	if fs.NArg() > 0 {
+		return nil, fmt.Errorf("unexpected argument %q", fs.Arg(0))
+	}
+
+	if *path == "" {
+		*path, _ = lookupEnv(FileEnv)
+	}
+	if *path != "" {
+		data, err := readFile(*path)
+		if err != nil {
+			return nil, err
+		}
+		values, err := parseYAML(data)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", *path, err)
+		}
+		known := make(map[string]bool, len(fields))
+		for _, f := range fields {
+			known[f.key] = true
+		}
+		for key := range values {
+			if !known[key] {
+				return nil, fmt.Errorf("%s: unknown key %q", *path, key)
+			}
+		}
+		for _, f := range fields {
+			if raw, ok := values[f.key]; ok {
+				if err := cfg.set(f, raw, "file"); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+
+	for _, f := range fields {
+		if raw, ok := lookupEnv(f.env); ok && raw != "" {
+			if err := cfg.set(f, raw, "env "+f.env); err != nil {
+				return nil, err
+			}
+		}
+	}
+	for _, f := range fields {
+		if raw, ok := flagged[f.key]; ok {
+			if err := cfg.set(f, raw, "flag -"+flagName(f.key)); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return cfg, nil
+}
+
+func (c *Config) set(f field, raw, source string) error {
+	if err := f.value.Set(raw); err != nil {
+		return fmt.Errorf("%s (%s): %w", f.key, source, err)
+	}
+	c.sources[f.key] = source
+	return nil
+}
+
+// Validate reports every problem with the configuration at once
+func (c *Config) Validate() error {
+	var errs []error
+	check := func(ok bool, format string, args ...any) {
+		if !ok {
+			errs = append(errs, fmt.Errorf(format, args...))
+		}
+	}
+
+	check(oneOf(c.Environment, EnvDevelopment, EnvStaging, EnvProduction),
+		"environment must be development, staging or production, not %q", c.Environment)
+
+	check(validPort(c.Server.Port), "server.port %d is not a TCP port", c.Server.Port)
+	check(c.Server.ReadTimeout >= 0 && c.Server.WriteTimeout >= 0 && c.Server.ShutdownTimeout > 0,
+		"server timeouts must not be negative and the shutdown timeout must be set")
+	check(c.Server.BodyLimit > 0, "server.body_limit must be positive")
+
+	switch c.Database.Backend {
+	case BackendPostgres:
+		check(c.Database.Host != "", "database.host is required")
+		check(validPort(c.Database.Port), "database.port %d is not a TCP port", c.Database.Port)
+		check(c.Database.User != "" && c.Database.Name != "", "database.user and database.name are required")
+		check(oneOf(c.Database.SSLMode, "disable", "allow", "prefer", "require", "verify-ca", "verify-full"),
+			"database.sslmode %q is not a libpq sslmode", c.Database.SSLMode)
+		check(c.Database.MaxConns >= 1, "database.max_conns must be at least 1")
+	case BackendMemory:
+		check(c.Environment == EnvDevelopment, "database.backend memory is only allowed in development")
+	default:
+		check(false, "database.backend must be postgres or memory, not %q", c.Database.Backend)
+	}
+
+	if c.Redis.Host != "" {
+		check(validPort(c.Redis.Port), "redis.port %d is not a TCP port", c.Redis.Port)
+		check(c.Redis.DB >= 0, "redis.db must not be negative")
+		check(c.Redis.Stream != "", "redis.stream is required with redis.host")
+	}
+
+	check(c.Auth.TokenTTL > 0, "auth.token_ttl must be positive")
+	if c.Environment != EnvDevelopment {
+		check(len(c.Auth.JWTSecret) >= minSecretLength,
+			"auth.jwt_secret must be at least %d characters outside development", minSecretLength)
+		check(c.Database.Backend != BackendPostgres || c.Database.Password != "",
+			"database.password is required outside development")
+	}
+
+	check(c.Workers.Count >= 1, "workers.count must be at least 1")
+	check(c.Workers.QueueSize >= 0, "workers.queue_size must not be negative")
+
+	_, err := zerolog.ParseLevel(c.Logging.Level)
+	check(err == nil && c.Logging.Level != "", "logging.level %q is not a log level", c.Logging.Level)
+	check(c.Logging.HealthSample >= 0, "logging.health_sample must not be negative")
+
+	switch c.Mail.Transport {
+	case MailNone:
+	case MailSMTP:
+		check(c.Mail.Host != "" && validPort(c.Mail.Port), "mail.host and mail.port are required for smtp")
+		check(c.Mail.From != "", "mail.from is required for smtp")
+	case MailFile:
+		check(c.Mail.Dir != "", "mail.dir is required for file")
+	default:
+		check(false, "mail.transport must be none, smtp or file, not %q", c.Mail.Transport)
+	}
+
+	return errors.Join(errs...)
+}
+
+// Describe writes every setting with where it came from; secrets are masked
+func (c *Config) Describe(w io.Writer) error {
+	for _, f := range c.fields() {
+		source := c.sources[f.key]
+		if source == "" {
+			source = "default"
+		}
+		if _, err := fmt.Fprintf(w, "%-24s = %-28s (%s)\n", f.key, f.value.String(), source); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// String describes the configuration with secrets masked
+func (c *Config) String() string {
+	var sb strings.Builder
+	_ = c.Describe(&sb)
+	return sb.String()
+}
+
+// Addr is the address the HTTP server listens on
+func (s ServerConfig) Addr() string {
+	return fmt.Sprintf(":%d", s.Port)
+}
+
+// Addr is the Redis host:port, empty when Redis is not configured
+func (r RedisConfig) Addr() string {
+	if r.Host == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s:%d", r.Host, r.Port)
+}
+
+func validPort(port int) bool {
+	return port > 0 && port < 65536
+}
+
+func oneOf(value string, allowed ...string) bool {
+	for _, a := range allowed {
+		if value == a {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,175 @@
+// Copyright (c) 2026 Muhammet Ali Büyük. All rights reserved.
+// This source code is proprietary. Confidential and private.
+// Unauthorized copying or distribution is strictly prohibited.
+// Contact: iletisim@alibuyuk.net | Website: alibuyuk.net
+
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testYAML = `# SubFlow configuration
+environment: staging
+server:
+  port: 8080
+  shutdown_timeout: 5s   # Enough for the worker pool
+database:
+  host: db.internal
+  password: "p@ss: # not a comment"
+  max_conns: 20
+logging:
+  level: debug
+mail:
+  transport: 'file'
+  dir: 'it''s mail'
+`
+
+func testEnv(vars map[string]string) func(string) (string, bool) {
+	return func(key string) (string, bool) {
+		v, ok := vars[key]
+		return v, ok
+	}
+}
+
+func testFiles(files map[string]string) func(string) ([]byte, error) {
+	return func(path string) ([]byte, error) {
+		data, ok := files[path]
+		if !ok {
+			return nil, os.ErrNotExist
+		}
+		return []byte(data), nil
+	}
+}
+
+func TestLoadPrecedence(t *testing.T) {
+	env := map[string]string{
+		FileEnv:        "/etc/subflow.yaml",
+		"DB_HOST":      "db.env",
+		"DB_MAX_CONNS": "30",
+		"LOG_PRETTY":   "true",
+		"JWT_SECRET":   strings.Repeat("s", 40),
+	}
+	cfg, err := load([]string{"-database-max-conns", "40", "-server-port=9090"}, testEnv(env), testFiles(map[string]string{"/etc/subflow.yaml": testYAML}))
+	if err != nil {
+		t.Fatalf("load returned error: %v", err)
+	}
+
+	if cfg.Environment != EnvStaging || cfg.Server.ShutdownTimeout != 5*time.Second || cfg.Logging.Level != "debug" {
+		t.Errorf("file values not applied: %+v", cfg)
+	}
+	if cfg.Database.Host != "db.env" || !cfg.Logging.Pretty {
+		t.Errorf("environment does not override the file: host %s, pretty %v", cfg.Database.Host, cfg.Logging.Pretty)
+	}
+	if cfg.Database.MaxConns != 40 || cfg.Server.Port != 9090 {
+		t.Errorf("flags do not override the environment: max_conns %d, port %d", cfg.Database.MaxConns, cfg.Server.Port)
+	}
+	if cfg.Database.Password.Value() != "p@ss: # not a comment" || cfg.Mail.Dir != "it's mail" {
+		t.Errorf("quoted values = %q, %q", cfg.Database.Password.Value(), cfg.Mail.Dir)
+	}
+	if cfg.Database.Port != 5432 || cfg.Workers.Count != 4 {
+		t.Errorf("defaults lost: db port %d, workers %d", cfg.Database.Port, cfg.Workers.Count)
+	}
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("Validate returned error: %v", err)
+	}
+
+	var out strings.Builder
+	cfg.Describe(&out)
+	for _, want := range []string{"(flag -server-port)", "(env DB_HOST)", "(file)", "(default)"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("Describe lacks %q:\n%s", want, out.String())
+		}
+	}
+
+	// -config wins over the environment's file
+	if _, err := load([]string{"-config", "/missing.yaml"}, testEnv(env), testFiles(nil)); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("load with a missing -config file error = %v", err)
+	}
+}
+
+func TestLoadRejectsBadInput(t *testing.T) {
+	tests := map[string]string{
+		"unknown key":   "server:\n  prot: 3000\n",
+		"bad value":     "server:\n  port: three\n",
+		"tab indent":    "server:\n\tport: 3000\n",
+		"list":          "server:\n  - 3000\n",
+		"stray indent":  "environment: staging\n  port: 3000\n",
+		"unterminated":  "database:\n  password: \"abc\n",
+		"flow mapping":  "server: {port: 3000}\n",
+		"trailing junk": "database:\n  password: 'abc' def\n",
+	}
+	for name, yaml := range tests {
+		files := testFiles(map[string]string{"c.yaml": yaml})
+		if _, err := load([]string{"-config", "c.yaml"}, testEnv(nil), files); err == nil {
+			t.Errorf("%s: load accepted %q", name, yaml)
+		}
+	}
+
+	if _, err := load([]string{"-no-such-flag"}, testEnv(nil), testFiles(nil)); err == nil {
+		t.Error("load accepted an unknown flag")
+	}
+	if _, err := load(nil, testEnv(map[string]string{"DB_PORT": "x"}), testFiles(nil)); err == nil || !strings.Contains(err.Error(), "env DB_PORT") {
+		t.Errorf("invalid DB_PORT error = %v, want it to name the variable", err)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	if err := Default().Validate(); err != nil {
+		t.Errorf("default configuration is invalid: %v", err)
+	}
+
+	cfg := Default()
+	cfg.Environment = EnvProduction
+	cfg.Auth.JWTSecret = "short"
+	cfg.Server.Port = 70000
+	cfg.Mail.Transport = MailSMTP
+	cfg.Logging.Level = "loud"
+	err := cfg.Validate()
+	if err == nil {
+		t.Fatal("Validate accepted an invalid production configuration")
+	}
+	for _, want := range []string{"auth.jwt_secret", "database.password", "server.port", "mail.host", "logging.level"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Validate error lacks %s:\n%v", want, err)
+		}
+	}
+
+	cfg = Default()
+	cfg.Database.Backend = BackendMemory
+	cfg.Database.Host = ""
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("memory backend needs no database settings: %v", err)
+	}
+	cfg.Environment = EnvStaging
+	cfg.Auth.JWTSecret = Secret(strings.Repeat("s", minSecretLength))
+	if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "database.backend") {
+		t.Errorf("memory backend accepted outside development: %v", err)
+	}
+}
+
+func TestSecretsAreRedacted(t *testing.T) {
+	cfg := Default()
+	cfg.Database.Password = "hunter2-db"
+	cfg.Auth.JWTSecret = "hunter2-jwt"
+	cfg.Mail.Password = "hunter2-smtp"
+
+	encoded, _ := json.Marshal(cfg)
+	for _, out := range []string{cfg.String(), fmt.Sprintf("%v", cfg.Database), fmt.Sprintf("%+v", cfg.Auth), fmt.Sprintf("%#v", cfg.Mail), string(encoded)} {
+		if strings.Contains(out, "hunter2") {
+			t.Errorf("secret printed: %s", out)
+		}
+	}
+	if !strings.Contains(cfg.String(), secretMask) {
+		t.Error("String does not show that secrets are set")
+	}
+	if cfg.Database.Password.Value() != "hunter2-db" {
+		t.Error("Secret lost the database password")
+	}
+}
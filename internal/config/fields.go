@@ -0,0 +1,149 @@
+// Copyright (c) 2026 Muhammet Ali Büyük. All rights reserved.
+// This source code is proprietary. Confidential and private.
+// Unauthorized copying or distribution is strictly prohibited.
+// Contact: iletisim@alibuyuk.net | Website: alibuyuk.net
+
+package config
+
+import (
+	"flag"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// field binds one setting to its YAML key, environment variable and flag.
+// The flag name is the key with dots and underscores as dashes.
+type field struct {
+	key   string
+	env   string
+	usage string
+	value flag.Value
+}
+
+// fields lists every setting of c; the environment variable names are the
+// ones docker-compose and CI already use
+func (c *Config) fields() []field {
+	return []field{
+		{"environment", "ENVIRONMENT", "development, staging or production", (*stringValue)(&c.Environment)},
+
+		{"server.port", "PORT", "HTTP port", (*intValue)(&c.Server.Port)},
+		{"server.read_timeout", "SERVER_READ_TIMEOUT", "Request read timeout", (*durationValue)(&c.Server.ReadTimeout)},
+		{"server.write_timeout", "SERVER_WRITE_TIMEOUT", "Response write timeout, 0 for none", (*durationValue)(&c.Server.WriteTimeout)},
+		{"server.shutdown_timeout", "SERVER_SHUTDOWN_TIMEOUT", "Time allowed for graceful shutdown", (*durationValue)(&c.Server.ShutdownTimeout)},
+		{"server.body_limit", "SERVER_BODY_LIMIT", "Largest request body in bytes", (*intValue)(&c.Server.BodyLimit)},
+		{"server.cors_origins", "CORS_ORIGINS", "Allowed CORS origins, comma separated", (*stringValue)(&c.Server.CORSOrigins)},
+		{"server.metrics", "METRICS_ENABLED", "Serve Prometheus metrics at /metrics", (*boolValue)(&c.Server.Metrics)},
+
+		{"database.backend", "DB_BACKEND", "postgres, or memory for development", (*stringValue)(&c.Database.Backend)},
+		{"database.host", "DB_HOST", "PostgreSQL host", (*stringValue)(&c.Database.Host)},
+		{"database.port", "DB_PORT", "PostgreSQL port", (*intValue)(&c.Database.Port)},
+		{"database.user", "DB_USER", "PostgreSQL user", (*stringValue)(&c.Database.User)},
+		{"database.password", "DB_PASSWORD", "PostgreSQL password", (*secretValue)(&c.Database.Password)},
+		{"database.name", "DB_NAME", "PostgreSQL database", (*stringValue)(&c.Database.Name)},
+		{"database.sslmode", "DB_SSLMODE", "PostgreSQL sslmode", (*stringValue)(&c.Database.SSLMode)},
+		{"database.max_conns", "DB_MAX_CONNS", "Connection pool size", (*intValue)(&c.Database.MaxConns)},
+
+		{"redis.host", "REDIS_HOST", "Redis host for the event stream, empty to disable", (*stringValue)(&c.Redis.Host)},
+		{"redis.port", "REDIS_PORT", "Redis port", (*intValue)(&c.Redis.Port)},
+		{"redis.password", "REDIS_PASSWORD", "Redis password", (*secretValue)(&c.Redis.Password)},
+		{"redis.db", "REDIS_DB", "Redis database number", (*intValue)(&c.Redis.DB)},
+		{"redis.stream", "REDIS_STREAM", "Redis stream domain events are added to", (*stringValue)(&c.Redis.Stream)},
+
+		{"auth.jwt_secret", "JWT_SECRET", "Secret signing access tokens", (*secretValue)(&c.Auth.JWTSecret)},
+		{"auth.token_ttl", "JWT_TOKEN_TTL", "Access token lifetime", (*durationValue)(&c.Auth.TokenTTL)},
+
+		{"workers.count", "WORKER_COUNT", "Background workers", (*intValue)(&c.Workers.Count)},
+		{"workers.queue_size", "WORKER_QUEUE_SIZE", "Jobs queued before submitters wait", (*intValue)(&c.Workers.QueueSize)},
+
+		{"logging.level", "LOG_LEVEL", "trace, debug, info, warn or error", (*stringValue)(&c.Logging.Level)},
+		{"logging.pretty", "LOG_PRETTY", "Console output instead of JSON", (*boolValue)(&c.Logging.Pretty)},
+		{"logging.health_sample", "LOG_HEALTH_SAMPLE", "Log one in N successful health checks, 0 for none", (*intValue)(&c.Logging.HealthSample)},
+
+		{"mail.transport", "MAIL_TRANSPORT", "none, smtp or file", (*stringValue)(&c.Mail.Transport)},
+		{"mail.from", "MAIL_FROM", "Sender address of notification emails", (*stringValue)(&c.Mail.From)},
+		{"mail.host", "SMTP_HOST", "SMTP server host", (*stringValue)(&c.Mail.Host)},
+		{"mail.port", "SMTP_PORT", "SMTP server port", (*intValue)(&c.Mail.Port)},
+		{"mail.username", "SMTP_USERNAME", "SMTP user", (*stringValue)(&c.Mail.Username)},
+		{"mail.password", "SMTP_PASSWORD", "SMTP password", (*secretValue)(&c.Mail.Password)},
+		{"mail.dir", "MAIL_DIR", "Directory the file transport writes to", (*stringValue)(&c.Mail.Dir)},
+	}
+}
+
+func flagName(key string) string {
+	return strings.NewReplacer(".", "-", "_", "-").Replace(key)
+}
+
+// recorder keeps a flag's raw value so flags can be applied after the file and environment
+type recorder struct {
+	key      string
+	values   map[string]string
+	boolFlag bool
+}
+
+func (r *recorder) String() string { return "" }
+
+// IsBoolFlag lets a boolean flag such as -logging-pretty stand without a value
+func (r *recorder) IsBoolFlag() bool { return r.boolFlag }
+
+func (r *recorder) Set(raw string) error {
+	r.values[r.key] = raw
+	return nil
+}
+
+type stringValue string
+
+func (v *stringValue) String() string { return string(*v) }
+
+func (v *stringValue) Set(raw string) error {
+	*v = stringValue(raw)
+	return nil
+}
+
+type secretValue Secret
+
+func (v *secretValue) String() string { return Secret(*v).String() }
+
+func (v *secretValue) Set(raw string) error {
+	*v = secretValue(raw)
+	return nil
+}
+
+type intValue int
+
+func (v *intValue) String() string { return strconv.Itoa(int(*v)) }
+
+func (v *intValue) Set(raw string) error {
+	n, err := strconv.Atoi(strings.TrimSpace(raw))
+	if err != nil {
+		return err
+	}
+	*v = intValue(n)
+	return nil
+}
+
+type boolValue bool
+
+func (v *boolValue) String() string { return strconv.FormatBool(bool(*v)) }
+
+func (v *boolValue) Set(raw string) error {
+	b, err := strconv.ParseBool(strings.TrimSpace(raw))
+	if err != nil {
+		return err
+	}
+	*v = boolValue(b)
+	return nil
+}
+
+type durationValue time.Duration
+
+func (v *durationValue) String() string { return time.Duration(*v).String() }
+
+func (v *durationValue) Set(raw string) error {
+	d, err := time.ParseDuration(strings.TrimSpace(raw))
+	if err != nil {
+		return err
+	}
+	*v = durationValue(d)
+	return nil
+}
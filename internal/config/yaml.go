@@ -0,0 +1,154 @@
+// Copyright (c) 2026 Muhammet Ali Büyük. All rights reserved.
+// This source code is proprietary. Confidential and private.
+// Unauthorized copying or distribution is strictly prohibited.
+// Contact: iletisim@alibuyuk.net | Website: alibuyuk.net
+
+package config
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// parseYAML reads the subset of YAML a configuration file needs: nested
+// mappings indented with spaces, scalar values (plain, "double" or 'single'
+// quoted) and # comments. It returns the values by dotted key, e.g.
+// "database.host". Lists, anchors and multi-line scalars are rejected.
+func parseYAML(data []byte) (map[string]string, error) {
+	type section struct {
+		indent      int
+		prefix      string
+		childIndent int // Indentation of the section's keys, -1 until the first one
+	}
+	values := make(map[string]string)
+	stack := []section{{indent: -1, childIndent: -1}}
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		line := strings.TrimRight(scanner.Text(), " \r")
+		if lineNo == 1 {
+			line = strings.TrimPrefix(line, "\ufeff")
+		}
+		content := strings.TrimLeft(line, " ")
+		if content == "" || strings.HasPrefix(content, "#") || content == "---" {
+			continue
+		}
+		if strings.HasPrefix(content, "\t") {
+			return nil, fmt.Errorf("line %d: indent with spaces, not tabs", lineNo)
+		}
+		if strings.HasPrefix(content, "- ") || content == "-" {
+			return nil, fmt.Errorf("line %d: lists are not supported", lineNo)
+		}
+		indent := len(line) - len(content)
+
+		for len(stack) > 1 && indent <= stack[len(stack)-1].indent {
+			stack = stack[:len(stack)-1]
+		}
+		top := &stack[len(stack)-1]
+		if top.childIndent < 0 {
+			top.childIndent = indent
+		} else if indent != top.childIndent {
+			return nil, fmt.Errorf("line %d: unexpected indentation", lineNo)
+		}
+
+		colon := strings.Index(content, ":")
+		if colon <= 0 || (colon+1 < len(content) && content[colon+1] != ' ') {
+			return nil, fmt.Errorf("line %d: expected \"key: value\"", lineNo)
+		}
+		key := strings.TrimSpace(content[:colon])
+		if strings.ContainsAny(key, " .\"'") {
+			return nil, fmt.Errorf("line %d: invalid key %q", lineNo, key)
+		}
+		if parent := top.prefix; parent != "" {
+			key = parent + "." + key
+		}
+
+		raw, err := yamlScalar(content[colon+1:])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNo, err)
+		}
+		if _, dup := values[key]; dup {
+			return nil, fmt.Errorf("line %d: %s is set twice", lineNo, key)
+		}
+		if raw == nil {
+			stack = append(stack, section{indent: indent, prefix: key, childIndent: -1})
+			continue
+		}
+		values[key] = *raw
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+// yamlScalar decodes the value after a key's colon; nil means the key opens a section
+func yamlScalar(s string) (*string, error) {
+	s = strings.TrimSpace(s)
+	if s == "" || strings.HasPrefix(s, "#") {
+		return nil, nil
+	}
+
+	var value string
+	switch s[0] {
+	case '"':
+		end := closingQuote(s)
+		if end < 0 {
+			return nil, fmt.Errorf("unterminated string")
+		}
+		unquoted, err := strconv.Unquote(s[:end+1])
+		if err != nil {
+			return nil, err
+		}
+		value, s = unquoted, s[end+1:]
+	case '\'':
+		var sb strings.Builder
+		i := 1
+		for ; i < len(s); i++ {
+			if s[i] == '\'' {
+				if i+1 < len(s) && s[i+1] == '\'' {
+					sb.WriteByte('\'')
+					i++
+					continue
+				}
+				break
+			}
+			sb.WriteByte(s[i])
+		}
+		if i >= len(s) {
+			return nil, fmt.Errorf("unterminated string")
+		}
+		value, s = sb.String(), s[i+1:]
+	case '[', '{', '&', '*', '|', '>':
+		return nil, fmt.Errorf("%q values are not supported", s[:1])
+	default:
+		if i := strings.Index(s, " #"); i >= 0 {
+			s = s[:i]
+		}
+		value, s = strings.TrimSpace(s), ""
+		if value == "~" || value == "null" {
+			value = ""
+		}
+	}
+
+	if rest := strings.TrimSpace(s); rest != "" && !strings.HasPrefix(rest, "#") {
+		return nil, fmt.Errorf("unexpected %q after the value", rest)
+	}
+	return &value, nil
+}
+
+// closingQuote finds the double quote ending the string that s starts with
+func closingQuote(s string) int {
+	for i := 1; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '"':
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,93 @@
+// Copyright (c) 2026 Muhammet Ali Büyük. All rights reserved.
+// This source code is proprietary. Confidential and private.
+// Unauthorized copying or distribution is strictly prohibited.
+// Contact: iletisim@alibuyuk.net | Website: alibuyuk.net
+
+package entity
+
+import (
+	"math/big"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AdvanceRecoveryMethod selects how an advance payment (avans) is recovered from pay applications
+type AdvanceRecoveryMethod string
+
+const (
+	AdvanceRecoveryPercentage      AdvanceRecoveryMethod = "PERCENTAGE"       // Fixed share of the work in every application
+	AdvanceRecoveryCompletionRange AdvanceRecoveryMethod = "COMPLETION_RANGE" // Linearly between two completion percentages
+)
+
+// AdvanceRecoveryRule is the contract's avans mahsubu rule for a project
+type AdvanceRecoveryRule struct {
+	ProjectID    uuid.UUID             `json:"project_id"`
+	Method       AdvanceRecoveryMethod `json:"method"`
+	Rate         int64                 `json:"rate,omitempty"`          // PERCENTAGE: basis points of work completed
+	StartPercent int64                 `json:"start_percent,omitempty"` // COMPLETION_RANGE: basis points of the contract sum
+	EndPercent   int64                 `json:"end_percent,omitempty"`   // COMPLETION_RANGE: advance fully recovered here
+	UpdatedAt    time.Time             `json:"updated_at"`
+	UpdatedBy    uuid.UUID             `json:"updated_by"`
+}
+
+// NewAdvanceRecoveryRule creates a rule for a project; the caller sets the method parameters
+func NewAdvanceRecoveryRule(projectID uuid.UUID, method AdvanceRecoveryMethod, updatedBy uuid.UUID) *AdvanceRecoveryRule {
+	return &AdvanceRecoveryRule{
+		ProjectID: projectID,
+		Method:    method,
+		UpdatedAt: time.Now(),
+		UpdatedBy: updatedBy,
+	}
+}
+
+// Validate checks the parameters of the selected method
+func (r *AdvanceRecoveryRule) Validate() error {
+	switch r.Method {
+	case AdvanceRecoveryPercentage:
+		if r.Rate <= 0 || r.Rate > 10000 {
+			return ErrInvalidAdvanceRule
+		}
+	case AdvanceRecoveryCompletionRange:
+		if r.StartPercent < 0 || r.EndPercent > 10000 || r.StartPercent >= r.EndPercent {
+			return ErrInvalidAdvanceRule
+		}
+	default:
+		return ErrInvalidAdvanceRule
+	}
+	return nil
+}
+
+// RecoveredToDate returns how much of the advance is recovered once workToDate is completed
+// The result is cumulative, rounded down to the cent and never exceeds the advance.
+func (r *AdvanceRecoveryRule) RecoveredToDate(advance, contractSum, workToDate int64) int64 {
+	if advance <= 0 || workToDate <= 0 {
+		return 0
+	}
+
+	var recovered *big.Int
+	switch r.Method {
+	case AdvanceRecoveryPercentage:
+		recovered = new(big.Int).Mul(big.NewInt(workToDate), big.NewInt(r.Rate))
+		recovered.Quo(recovered, big.NewInt(10000))
+	case AdvanceRecoveryCompletionRange:
+		if contractSum <= 0 {
+			return 0
+		}
+		// advance × (workToDate/contractSum − start) / (end − start), percentages in basis points
+		progress := new(big.Int).Mul(big.NewInt(workToDate), big.NewInt(10000))
+		progress.Sub(progress, new(big.Int).Mul(big.NewInt(r.StartPercent), big.NewInt(contractSum)))
+		if progress.Sign() <= 0 {
+			return 0
+		}
+		recovered = new(big.Int).Mul(big.NewInt(advance), progress)
+		recovered.Quo(recovered, new(big.Int).Mul(big.NewInt(r.EndPercent-r.StartPercent), big.NewInt(contractSum)))
+	default:
+		return 0
+	}
+
+	if recovered.Cmp(big.NewInt(advance)) > 0 {
+		return advance
+	}
+	return recovered.Int64()
+}
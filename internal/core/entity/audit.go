@@ -0,0 +1,97 @@
+// Copyright (c) 2026 Muhammet Ali Büyük. All rights reserved.
+// This source code is proprietary. Confidential and private.
+// Unauthorized copying or distribution is strictly prohibited.
+// Contact: iletisim@alibuyuk.net | Website: alibuyuk.net
+
+package entity
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Audited domain operations; API calls are recorded as "http.<method>"
+const (
+	AuditActionTransactionCreate     = "transaction.create"
+	AuditActionTransactionImport     = "transaction.import"
+	AuditActionProjectCreate         = "project.create"
+	AuditActionProjectUpdate         = "project.update"
+	AuditActionProjectDelete         = "project.delete"
+	AuditActionProjectTransition     = "project.transition"
+	AuditActionPayApplicationSubmit  = "pay_application.submit"
+	AuditActionPayApplicationCertify = "pay_application.certify"
+	AuditActionPayApplicationReject  = "pay_application.reject"
+	AuditActionRetainageApprove      = "retainage.approve"
+	AuditActionRetainageReject       = "retainage.reject"
+	AuditActionGuaranteeRegister     = "guarantee.register"
+	AuditActionGuaranteeReduce       = "guarantee.reduce"
+	AuditActionGuaranteeReturn       = "guarantee.return"
+	AuditActionGuaranteeExtend       = "guarantee.extend"
+	AuditActionGuaranteeExpire       = "guarantee.expire"
+	AuditActionDeductionCreate       = "deduction.create"
+	AuditActionDeductionPenalty      = "deduction.penalty"
+	AuditActionDeductionSchedule     = "deduction.schedule"
+	AuditActionDeductionScheduleStop = "deduction.schedule_stop"
+	AuditActionPenaltyRuleSet        = "penalty_rule.set"
+	AuditActionAdvanceRuleSet        = "advance_rule.set"
+	AuditActionComplianceDocument    = "compliance.document"
+	AuditActionComplianceRevoke      = "compliance.revoke"
+	AuditActionComplianceRequirement = "compliance.requirement"
+	AuditActionComplianceWaiverUse   = "compliance.waiver_use"
+	AuditActionComplianceOverride    = "compliance.override"
+	AuditActionReconciliationAccept  = "reconciliation.accept"
+	AuditActionWebhookCreate         = "webhook.create"
+	AuditActionWebhookUpdate         = "webhook.update"
+	AuditActionWebhookReplay         = "webhook.replay"
+)
+
+// AuditLog is one row of the audit trail: who did what to which entity, with
+// JSON snapshots of the entity before and after the change
+type AuditLog struct {
+	ID         uuid.UUID       `json:"id"`
+	TenantID   uuid.UUID       `json:"tenant_id"`
+	ActorID    *uuid.UUID      `json:"actor_id,omitempty"`
+	ActorEmail string          `json:"actor_email,omitempty"`
+	Action     string          `json:"action"`
+	EntityType string          `json:"entity_type"`
+	EntityID   *uuid.UUID      `json:"entity_id,omitempty"`
+	OldValue   json.RawMessage `json:"old_value,omitempty"`
+	NewValue   json.RawMessage `json:"new_value,omitempty"`
+	IPAddress  string          `json:"ip_address,omitempty"`
+	UserAgent  string          `json:"user_agent,omitempty"`
+	CreatedAt  time.Time       `json:"created_at"`
+}
+
+// NewAuditLog creates an audit entry for an action on an entity
+func NewAuditLog(tenantID uuid.UUID, action, entityType string, entityID *uuid.UUID) *AuditLog {
+	return &AuditLog{
+		ID:         uuid.New(),
+		TenantID:   tenantID,
+		Action:     action,
+		EntityType: entityType,
+		EntityID:   entityID,
+		CreatedAt:  time.Now(),
+	}
+}
+
+// SetSnapshots stores the before and after state as JSON; nil leaves a side empty
+func (a *AuditLog) SetSnapshots(before, after any) error {
+	var err error
+	if a.OldValue, err = auditSnapshot(before); err != nil {
+		return err
+	}
+	a.NewValue, err = auditSnapshot(after)
+	return err
+}
+
+func auditSnapshot(v any) (json.RawMessage, error) {
+	if v == nil {
+		return nil, nil
+	}
+	if raw, ok := v.(json.RawMessage); ok {
+		return raw, nil
+	}
+	return json.Marshal(v)
+}
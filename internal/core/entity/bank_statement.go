@@ -0,0 +1,150 @@
+// Copyright (c) 2026 Muhammet Ali Büyük. All rights reserved.
+// This source code is proprietary. Confidential and private.
+// Unauthorized copying or distribution is strictly prohibited.
+// Contact: iletisim@alibuyuk.net | Website: alibuyuk.net
+
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// StatementFormat identifies the bank statement standard a statement was parsed from
+type StatementFormat string
+
+const (
+	StatementFormatMT940   StatementFormat = "MT940"   // SWIFT MT940
+	StatementFormatCAMT053 StatementFormat = "CAMT053" // ISO 20022 camt.053
+)
+
+// EntryDirection tells whether a statement line is money in or money out
+type EntryDirection string
+
+const (
+	EntryDirectionCredit EntryDirection = "CREDIT" // Hesaba giriş
+	EntryDirectionDebit  EntryDirection = "DEBIT"  // Hesaptan çıkış
+)
+
+// StatementLineStatus tracks a line through the reconciliation workflow
+type StatementLineStatus string
+
+const (
+	StatementLineUnmatched StatementLineStatus = "UNMATCHED"
+	StatementLineMatched   StatementLineStatus = "MATCHED"
+	StatementLineIgnored   StatementLineStatus = "IGNORED"
+)
+
+// BankStatement is an imported account statement used for payment reconciliation
+type BankStatement struct {
+	ID             uuid.UUID        `json:"id"`
+	ProjectID      uuid.UUID        `json:"project_id"`
+	Format         StatementFormat  `json:"format"`
+	AccountID      string           `json:"account_id"`   // IBAN or bank account number
+	StatementNo    string           `json:"statement_no"` // MT940 :28C: / camt ElctrncSeqNb
+	Currency       string           `json:"currency"`
+	OpeningBalance int64            `json:"opening_balance"` // Cents, signed
+	ClosingBalance int64            `json:"closing_balance"` // Cents, signed
+	StatementDate  time.Time        `json:"statement_date"`
+	Lines          []*StatementLine `json:"lines"`
+	ImportedAt     time.Time        `json:"imported_at"`
+	ImportedBy     uuid.UUID        `json:"imported_by"`
+}
+
+// StatementLine is a single booked entry on a bank statement
+type StatementLine struct {
+	ID                   uuid.UUID           `json:"id"`
+	StatementID          uuid.UUID           `json:"statement_id"`
+	LineNo               int                 `json:"line_no"`
+	BookingDate          time.Time           `json:"booking_date"`
+	ValueDate            time.Time           `json:"value_date"`
+	Direction            EntryDirection      `json:"direction"`
+	AmountCents          int64               `json:"amount_cents"` // Always positive, see Direction
+	Currency             string              `json:"currency"`
+	Reference            string              `json:"reference"`      // Customer / end-to-end reference
+	BankReference        string              `json:"bank_reference"` // Account servicer reference
+	Description          string              `json:"description"`    // Remittance information
+	CounterpartyName     string              `json:"counterparty_name,omitempty"`
+	CounterpartyAccount  string              `json:"counterparty_account,omitempty"`
+	Status               StatementLineStatus `json:"status"`
+	MatchedTransactionID *uuid.UUID          `json:"matched_transaction_id,omitempty"`
+	MatchedAt            *time.Time          `json:"matched_at,omitempty"`
+}
+
+// NewBankStatement creates an empty statement ready for lines to be appended
+func NewBankStatement(projectID uuid.UUID, format StatementFormat, importedBy uuid.UUID) *BankStatement {
+	return &BankStatement{
+		ID:         uuid.New(),
+		ProjectID:  projectID,
+		Format:     format,
+		ImportedAt: time.Now(),
+		ImportedBy: importedBy,
+	}
+}
+
+// AddLine appends a line, assigning its identity and initial status
+func (s *BankStatement) AddLine(line *StatementLine) {
+	line.ID = uuid.New()
+	line.StatementID = s.ID
+	line.LineNo = len(s.Lines) + 1
+	line.Status = StatementLineUnmatched
+	if line.Currency == "" {
+		line.Currency = s.Currency
+	}
+	s.Lines = append(s.Lines, line)
+}
+
+// Validate checks that the lines reconcile the opening balance to the closing balance
+func (s *BankStatement) Validate() error {
+	balance := s.OpeningBalance
+	for _, line := range s.Lines {
+		if line.AmountCents <= 0 {
+			return ErrInvalidAmount
+		}
+		balance += line.SignedAmount()
+	}
+	if balance != s.ClosingBalance {
+		return ErrStatementBalanceMismatch
+	}
+	return nil
+}
+
+// SignedAmount returns the line amount with debits negative
+func (l *StatementLine) SignedAmount() int64 {
+	if l.Direction == EntryDirectionDebit {
+		return -l.AmountCents
+	}
+	return l.AmountCents
+}
+
+// IsCredit returns true if the line is an incoming payment
+func (l *StatementLine) IsCredit() bool {
+	return l.Direction == EntryDirectionCredit
+}
+
+// IsOpen returns true if the line still needs reconciliation
+func (l *StatementLine) IsOpen() bool {
+	return l.Status == StatementLineUnmatched
+}
+
+// MarkMatched links the line to the ledger transaction that settles it
+func (l *StatementLine) MarkMatched(transactionID uuid.UUID) error {
+	if !l.IsOpen() {
+		return ErrStatementLineReconciled
+	}
+	now := time.Now()
+	l.Status = StatementLineMatched
+	l.MatchedTransactionID = &transactionID
+	l.MatchedAt = &now
+	return nil
+}
+
+// MarkIgnored excludes the line from reconciliation (bank fees, transfers, etc.)
+func (l *StatementLine) MarkIgnored() error {
+	if !l.IsOpen() {
+		return ErrStatementLineReconciled
+	}
+	l.Status = StatementLineIgnored
+	return nil
+}
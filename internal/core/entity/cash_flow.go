@@ -0,0 +1,114 @@
+// Copyright (c) 2026 Muhammet Ali Büyük. All rights reserved.
+// This source code is proprietary. Confidential and private.
+// Unauthorized copying or distribution is strictly prohibited.
+// Contact: iletisim@alibuyuk.net | Website: alibuyuk.net
+
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ProgressCurve describes how planned progress accumulates over the schedule
+type ProgressCurve string
+
+const (
+	ProgressLinear ProgressCurve = "LINEAR"  // Equal progress every month
+	ProgressSCurve ProgressCurve = "S_CURVE" // Slow start, peak mid-project, slow finish
+)
+
+// CumulativeProgress returns the planned share of work complete at t, where t
+// runs from 0 (schedule start) to 1 (schedule end). The S-curve is the
+// smoothstep 3t² - 2t³, the usual approximation of a construction progress curve.
+func (c ProgressCurve) CumulativeProgress(t float64) float64 {
+	if t <= 0 {
+		return 0
+	}
+	if t >= 1 {
+		return 1
+	}
+	if c == ProgressSCurve {
+		return t * t * (3 - 2*t)
+	}
+	return t
+}
+
+// CashFlowScheduleLine is one schedule of values line (G703 column A-C) of a cash flow plan
+type CashFlowScheduleLine struct {
+	ItemNo         string `json:"item_no"`
+	Description    string `json:"description"`
+	ScheduledValue int64  `json:"scheduled_value"` // Cents
+}
+
+// CashFlowPlan holds the schedule, progress curve and payment terms used to
+// forecast a project's cash flow
+type CashFlowPlan struct {
+	ProjectID     uuid.UUID              `json:"project_id"`
+	Curve         ProgressCurve          `json:"curve"`
+	StartDate     time.Time              `json:"start_date"` // Planned progress window
+	EndDate       time.Time              `json:"end_date"`
+	Schedule      []CashFlowScheduleLine `json:"schedule"`       // Empty uses the project contract amount
+	NetDays       int                    `json:"net_days"`       // Owner pays this many days after the monthly invoice
+	RetainageRate int64                  `json:"retainage_rate"` // Basis points held from each billing
+	RetainageDays int                    `json:"retainage_days"` // Retainage is paid this many days after EndDate
+	PayableDays   int                    `json:"payable_days"`   // Subcontractors are paid this many days after their invoice
+	UpdatedAt     time.Time              `json:"updated_at"`
+	UpdatedBy     uuid.UUID              `json:"updated_by"`
+}
+
+// NewCashFlowPlan creates a linear plan over the given window with net 30 terms
+func NewCashFlowPlan(projectID uuid.UUID, start, end time.Time, updatedBy uuid.UUID) *CashFlowPlan {
+	return &CashFlowPlan{
+		ProjectID:   projectID,
+		Curve:       ProgressLinear,
+		StartDate:   start,
+		EndDate:     end,
+		Schedule:    []CashFlowScheduleLine{},
+		NetDays:     30,
+		PayableDays: 30,
+		UpdatedAt:   time.Now(),
+		UpdatedBy:   updatedBy,
+	}
+}
+
+// Validate checks the plan window, curve and terms
+func (p *CashFlowPlan) Validate() error {
+	if p.Curve != ProgressLinear && p.Curve != ProgressSCurve {
+		return ErrInvalidCashFlowPlan
+	}
+	if p.StartDate.IsZero() || !p.EndDate.After(p.StartDate) {
+		return ErrInvalidCashFlowPlan
+	}
+	if p.NetDays < 0 || p.RetainageDays < 0 || p.PayableDays < 0 {
+		return ErrInvalidCashFlowPlan
+	}
+	if p.RetainageRate < 0 || p.RetainageRate > 10000 {
+		return ErrInvalidCashFlowPlan
+	}
+	for _, line := range p.Schedule {
+		if line.ScheduledValue < 0 {
+			return ErrInvalidCashFlowPlan
+		}
+	}
+	return nil
+}
+
+// ScheduledTotal returns the sum of the schedule of values
+func (p *CashFlowPlan) ScheduledTotal() int64 {
+	var total int64
+	for _, line := range p.Schedule {
+		total += line.ScheduledValue
+	}
+	return total
+}
+
+// ProgressAt returns the planned cumulative progress at the given time
+func (p *CashFlowPlan) ProgressAt(at time.Time) float64 {
+	span := p.EndDate.Sub(p.StartDate)
+	if span <= 0 {
+		return 1
+	}
+	return p.Curve.CumulativeProgress(float64(at.Sub(p.StartDate)) / float64(span))
+}
@@ -0,0 +1,160 @@
+// Copyright (c) 2026 Muhammet Ali Büyük. All rights reserved.
+// This source code is proprietary. Confidential and private.
+// Unauthorized copying or distribution is strictly prohibited.
+// Contact: iletisim@alibuyuk.net | Website: alibuyuk.net
+
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ComplianceDocumentType identifies a document a subcontractor must keep on file
+type ComplianceDocumentType string
+
+const (
+	ComplianceConditionalWaiver   ComplianceDocumentType = "CONDITIONAL_LIEN_WAIVER"   // Ödemeye bağlı hak feragatnamesi
+	ComplianceUnconditionalWaiver ComplianceDocumentType = "UNCONDITIONAL_LIEN_WAIVER" // Ödeme sonrası ibraname
+	ComplianceInsurance           ComplianceDocumentType = "INSURANCE_CERTIFICATE"     // Sigorta poliçesi
+	ComplianceTaxClearance        ComplianceDocumentType = "TAX_CLEARANCE"             // Vergi borcu yoktur yazısı
+	ComplianceSGKClearance        ComplianceDocumentType = "SGK_CLEARANCE"             // SGK borcu yoktur yazısı
+)
+
+// IsValid checks if the document type is known
+func (t ComplianceDocumentType) IsValid() bool {
+	switch t {
+	case ComplianceConditionalWaiver,
+		ComplianceUnconditionalWaiver,
+		ComplianceInsurance,
+		ComplianceTaxClearance,
+		ComplianceSGKClearance:
+		return true
+	}
+	return false
+}
+
+// ComplianceDocument is a document received from a subcontractor under a contract
+type ComplianceDocument struct {
+	ID              uuid.UUID              `json:"id"`
+	ProjectID       uuid.UUID              `json:"project_id"`
+	ContractID      uuid.UUID              `json:"contract_id"`
+	Type            ComplianceDocumentType `json:"type"`
+	DocumentNo      string                 `json:"document_no"`
+	Issuer          string                 `json:"issuer"` // Insurer, tax office, SGK directorate or the subcontractor
+	IssueDate       time.Time              `json:"issue_date"`
+	ExpiryDate      *time.Time             `json:"expiry_date,omitempty"`  // nil when the document does not expire
+	Amount          int64                  `json:"amount,omitempty"`       // Lien waivers: amount waived, cents
+	ThroughDate     *time.Time             `json:"through_date,omitempty"` // Unconditional waivers: payments received up to this date
+	PaymentID       *uuid.UUID             `json:"payment_id,omitempty"`   // Conditional waivers: payment the waiver was used for
+	RevokedAt       *time.Time             `json:"revoked_at,omitempty"`
+	ExpiryAlertedAt *time.Time             `json:"expiry_alerted_at,omitempty"`
+	CreatedAt       time.Time              `json:"created_at"`
+	CreatedBy       uuid.UUID              `json:"created_by"`
+}
+
+// NewComplianceDocument creates a document received on issueDate
+func NewComplianceDocument(projectID, contractID uuid.UUID, docType ComplianceDocumentType, documentNo, issuer string, issueDate time.Time, createdBy uuid.UUID) *ComplianceDocument {
+	return &ComplianceDocument{
+		ID:         uuid.New(),
+		ProjectID:  projectID,
+		ContractID: contractID,
+		Type:       docType,
+		DocumentNo: documentNo,
+		Issuer:     issuer,
+		IssueDate:  issueDate,
+		CreatedAt:  time.Now(),
+		CreatedBy:  createdBy,
+	}
+}
+
+// Validate checks the document has what its type needs
+// Conditional waivers state the amount they release, unconditional ones the date they cover.
+func (d *ComplianceDocument) Validate() error {
+	if !d.Type.IsValid() || d.ContractID == uuid.Nil || d.IssueDate.IsZero() {
+		return ErrInvalidComplianceDocument
+	}
+	if d.ExpiryDate != nil && d.ExpiryDate.Before(d.IssueDate) {
+		return ErrInvalidComplianceDocument
+	}
+	switch d.Type {
+	case ComplianceConditionalWaiver:
+		if d.Amount <= 0 {
+			return ErrInvalidComplianceDocument
+		}
+	case ComplianceUnconditionalWaiver:
+		if d.ThroughDate == nil {
+			return ErrInvalidComplianceDocument
+		}
+	}
+	return nil
+}
+
+// IsValidOn returns true if the document is in force on date
+func (d *ComplianceDocument) IsValidOn(date time.Time) bool {
+	if d.RevokedAt != nil || d.IssueDate.After(date) {
+		return false
+	}
+	return d.ExpiryDate == nil || !date.After(*d.ExpiryDate)
+}
+
+// DaysToExpiry returns whole days until the expiry date; ok is false when it does not expire
+func (d *ComplianceDocument) DaysToExpiry(now time.Time) (days int, ok bool) {
+	if d.ExpiryDate == nil {
+		return 0, false
+	}
+	return int(d.ExpiryDate.Sub(now).Hours() / 24), true
+}
+
+// Revoke withdraws the document, e.g. when an insurance policy is cancelled
+func (d *ComplianceDocument) Revoke(at time.Time) error {
+	if d.RevokedAt != nil {
+		return ErrComplianceDocumentRevoked
+	}
+	d.RevokedAt = &at
+	return nil
+}
+
+// ComplianceRequirement makes a document type mandatory before paying a subcontractor
+type ComplianceRequirement struct {
+	ID         uuid.UUID              `json:"id"`
+	ProjectID  uuid.UUID              `json:"project_id"`
+	ContractID *uuid.UUID             `json:"contract_id,omitempty"` // nil applies to every contract of the project
+	Type       ComplianceDocumentType `json:"type"`
+	CreatedAt  time.Time              `json:"created_at"`
+	CreatedBy  uuid.UUID              `json:"created_by"`
+}
+
+// NewComplianceRequirement creates a project-wide requirement
+func NewComplianceRequirement(projectID uuid.UUID, docType ComplianceDocumentType, createdBy uuid.UUID) *ComplianceRequirement {
+	return &ComplianceRequirement{
+		ID:        uuid.New(),
+		ProjectID: projectID,
+		Type:      docType,
+		CreatedAt: time.Now(),
+		CreatedBy: createdBy,
+	}
+}
+
+// AppliesTo returns true if the requirement covers the contract
+func (r *ComplianceRequirement) AppliesTo(contractID uuid.UUID) bool {
+	return r.ContractID == nil || *r.ContractID == contractID
+}
+
+// ComplianceIssue is one unmet requirement
+type ComplianceIssue struct {
+	Type   ComplianceDocumentType `json:"type"`
+	Reason string                 `json:"reason"`
+}
+
+// ComplianceCheck is the outcome of checking a contract before a payment
+type ComplianceCheck struct {
+	ProjectID  uuid.UUID         `json:"project_id"`
+	ContractID uuid.UUID         `json:"contract_id"`
+	Amount     int64             `json:"amount"`
+	Date       time.Time         `json:"date"`
+	Compliant  bool              `json:"compliant"`
+	Issues     []ComplianceIssue `json:"issues,omitempty"`
+	WaiverID   *uuid.UUID        `json:"waiver_id,omitempty"` // Conditional waiver the payment will use
+}
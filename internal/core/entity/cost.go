@@ -0,0 +1,185 @@
+// Copyright (c) 2026 Muhammet Ali Büyük. All rights reserved.
+// This source code is proprietary. Confidential and private.
+// Unauthorized copying or distribution is strictly prohibited.
+// Contact: iletisim@alibuyuk.net | Website: alibuyuk.net
+
+package entity
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CostCode is a node in a project's cost breakdown (iş kalemi), e.g. "03" Beton işleri > "03.20" Kalıp
+// Budget, commitments and actual cost are booked on leaf or parent codes and roll up the tree.
+type CostCode struct {
+	ID        uuid.UUID  `json:"id"`
+	ProjectID uuid.UUID  `json:"project_id"`
+	ParentID  *uuid.UUID `json:"parent_id,omitempty"` // nil for top-level codes
+	Code      string     `json:"code"`                // Unique per project
+	Name      string     `json:"name"`
+	CreatedAt time.Time  `json:"created_at"`
+	CreatedBy uuid.UUID  `json:"created_by"`
+}
+
+// NewCostCode creates a top-level cost code; set ParentID to nest it
+func NewCostCode(projectID uuid.UUID, code, name string, createdBy uuid.UUID) *CostCode {
+	return &CostCode{
+		ID:        uuid.New(),
+		ProjectID: projectID,
+		Code:      strings.TrimSpace(code),
+		Name:      strings.TrimSpace(name),
+		CreatedAt: time.Now(),
+		CreatedBy: createdBy,
+	}
+}
+
+// Validate checks the cost code has a code and a name
+func (c *CostCode) Validate() error {
+	if c.Code == "" || c.Name == "" {
+		return ErrInvalidCostCode
+	}
+	if c.ParentID != nil && *c.ParentID == c.ID {
+		return ErrInvalidCostCode
+	}
+	return nil
+}
+
+// BudgetLine is a budgeted amount for a cost code; a code's budget is the sum of its lines
+// Budget revisions are booked as further lines (negative to reduce) so the original stays visible.
+type BudgetLine struct {
+	ID          uuid.UUID `json:"id"`
+	ProjectID   uuid.UUID `json:"project_id"`
+	CostCodeID  uuid.UUID `json:"cost_code_id"`
+	Description string    `json:"description"`
+	Amount      int64     `json:"amount"` // Cents, may be negative for a revision
+	Currency    string    `json:"currency"`
+	CreatedAt   time.Time `json:"created_at"`
+	CreatedBy   uuid.UUID `json:"created_by"`
+}
+
+// NewBudgetLine creates a budget line for a cost code
+func NewBudgetLine(projectID, costCodeID uuid.UUID, description string, amount int64, currency string, createdBy uuid.UUID) *BudgetLine {
+	return &BudgetLine{
+		ID:          uuid.New(),
+		ProjectID:   projectID,
+		CostCodeID:  costCodeID,
+		Description: description,
+		Amount:      amount,
+		Currency:    currency,
+		CreatedAt:   time.Now(),
+		CreatedBy:   createdBy,
+	}
+}
+
+// Validate checks the budget line
+func (b *BudgetLine) Validate() error {
+	if b.CostCodeID == uuid.Nil || b.Amount == 0 || len(b.Currency) != 3 {
+		return ErrInvalidBudgetLine
+	}
+	return nil
+}
+
+// CommitmentSource identifies where committed cost comes from
+type CommitmentSource string
+
+const (
+	CommitmentSubcontract   CommitmentSource = "SUBCONTRACT"    // Taşeron sözleşmesi
+	CommitmentPurchaseOrder CommitmentSource = "PURCHASE_ORDER" // Satın alma siparişi
+)
+
+// CostCommitment is cost the project is bound to pay: a subcontract or a purchase order
+type CostCommitment struct {
+	ID          uuid.UUID        `json:"id"`
+	ProjectID   uuid.UUID        `json:"project_id"`
+	CostCodeID  uuid.UUID        `json:"cost_code_id"`
+	Source      CommitmentSource `json:"source"`
+	ContractID  *uuid.UUID       `json:"contract_id,omitempty"` // Subcontracts
+	ReferenceNo string           `json:"reference_no"`          // Contract or purchase order number
+	Vendor      string           `json:"vendor"`
+	Description string           `json:"description"`
+	Amount      int64            `json:"amount"` // Cents, may be negative for a deductive change
+	Currency    string           `json:"currency"`
+	Date        time.Time        `json:"date"`
+	CreatedAt   time.Time        `json:"created_at"`
+	CreatedBy   uuid.UUID        `json:"created_by"`
+}
+
+// NewCostCommitment creates a commitment dated today
+func NewCostCommitment(projectID, costCodeID uuid.UUID, source CommitmentSource, referenceNo, vendor string, amount int64, currency string, createdBy uuid.UUID) *CostCommitment {
+	now := time.Now()
+	return &CostCommitment{
+		ID:          uuid.New(),
+		ProjectID:   projectID,
+		CostCodeID:  costCodeID,
+		Source:      source,
+		ReferenceNo: referenceNo,
+		Vendor:      vendor,
+		Amount:      amount,
+		Currency:    currency,
+		Date:        now,
+		CreatedAt:   now,
+		CreatedBy:   createdBy,
+	}
+}
+
+// Validate checks the commitment; subcontracts must point at their contract
+func (c *CostCommitment) Validate() error {
+	if c.CostCodeID == uuid.Nil || c.Amount == 0 || len(c.Currency) != 3 {
+		return ErrInvalidCostCommitment
+	}
+	switch c.Source {
+	case CommitmentSubcontract:
+		if c.ContractID == nil {
+			return ErrInvalidCostCommitment
+		}
+	case CommitmentPurchaseOrder:
+		if c.ReferenceNo == "" {
+			return ErrInvalidCostCommitment
+		}
+	default:
+		return ErrInvalidCostCommitment
+	}
+	return nil
+}
+
+// VendorInvoice is actual cost: an invoice received from a subcontractor or supplier
+type VendorInvoice struct {
+	ID           uuid.UUID  `json:"id"`
+	ProjectID    uuid.UUID  `json:"project_id"`
+	CostCodeID   uuid.UUID  `json:"cost_code_id"`
+	CommitmentID *uuid.UUID `json:"commitment_id,omitempty"` // Subcontract or purchase order invoiced against
+	Vendor       string     `json:"vendor"`
+	InvoiceNo    string     `json:"invoice_no"`
+	InvoiceDate  time.Time  `json:"invoice_date"`
+	Amount       int64      `json:"amount"` // Cents, excluding KDV
+	Currency     string     `json:"currency"`
+	CreatedAt    time.Time  `json:"created_at"`
+	CreatedBy    uuid.UUID  `json:"created_by"`
+}
+
+// NewVendorInvoice creates a vendor invoice
+func NewVendorInvoice(projectID, costCodeID uuid.UUID, vendor, invoiceNo string, invoiceDate time.Time, amount int64, currency string, createdBy uuid.UUID) *VendorInvoice {
+	return &VendorInvoice{
+		ID:          uuid.New(),
+		ProjectID:   projectID,
+		CostCodeID:  costCodeID,
+		Vendor:      vendor,
+		InvoiceNo:   invoiceNo,
+		InvoiceDate: invoiceDate,
+		Amount:      amount,
+		Currency:    currency,
+		CreatedAt:   time.Now(),
+		CreatedBy:   createdBy,
+	}
+}
+
+// Validate checks the vendor invoice
+func (v *VendorInvoice) Validate() error {
+	if v.CostCodeID == uuid.Nil || v.InvoiceNo == "" || v.InvoiceDate.IsZero() || v.Amount == 0 || len(v.Currency) != 3 {
+		return ErrInvalidVendorInvoice
+	}
+	return nil
+}
@@ -0,0 +1,232 @@
+// Copyright (c) 2026 Muhammet Ali Büyük. All rights reserved.
+// This source code is proprietary. Confidential and private.
+// Unauthorized copying or distribution is strictly prohibited.
+// Contact: iletisim@alibuyuk.net | Website: alibuyuk.net
+
+package entity
+
+import (
+	"math/big"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DeductionCategory classifies an amount withheld from a subcontractor's payment
+type DeductionCategory string
+
+const (
+	DeductionCategoryBackCharge    DeductionCategory = "BACK_CHARGE"    // Geri yansıtma
+	DeductionCategoryPenalty       DeductionCategory = "PENALTY"        // Ceza
+	DeductionCategoryOwnerMaterial DeductionCategory = "OWNER_MATERIAL" // İdarece verilen malzeme
+	DeductionCategorySGK           DeductionCategory = "SGK"            // SGK primi
+	DeductionCategoryUtility       DeductionCategory = "UTILITY"        // Şantiye elektrik, su, yemek
+	DeductionCategoryOther         DeductionCategory = "OTHER"          // Diğer kesinti
+)
+
+// IsValid checks if the deduction category is known
+func (c DeductionCategory) IsValid() bool {
+	switch c {
+	case DeductionCategoryBackCharge,
+		DeductionCategoryPenalty,
+		DeductionCategoryOwnerMaterial,
+		DeductionCategorySGK,
+		DeductionCategoryUtility,
+		DeductionCategoryOther:
+		return true
+	}
+	return false
+}
+
+// Deduction is an amount withheld from the subcontractor (kesinti)
+// It is recorded in the ledger when created and taken from the next pay application.
+type Deduction struct {
+	ID               uuid.UUID         `json:"id"`
+	ProjectID        uuid.UUID         `json:"project_id"`
+	ContractID       *uuid.UUID        `json:"contract_id,omitempty"`
+	Category         DeductionCategory `json:"category"`
+	Reason           string            `json:"reason"`
+	Amount           int64             `json:"amount"` // Cents
+	Currency         string            `json:"currency"`
+	Date             time.Time         `json:"date"`
+	ScheduleID       *uuid.UUID        `json:"schedule_id,omitempty"`        // Set when generated by a recurring schedule
+	PenaltyDays      int               `json:"penalty_days,omitempty"`       // Days late covered, for late completion penalties
+	TransactionID    *uuid.UUID        `json:"transaction_id,omitempty"`     // Ledger entry
+	PayApplicationID *uuid.UUID        `json:"pay_application_id,omitempty"` // Application the deduction was taken from
+	CreatedAt        time.Time         `json:"created_at"`
+	CreatedBy        uuid.UUID         `json:"created_by"`
+}
+
+// NewDeduction creates a new deduction dated date
+func NewDeduction(projectID uuid.UUID, category DeductionCategory, reason string, amount int64, currency string, date time.Time, createdBy uuid.UUID) *Deduction {
+	return &Deduction{
+		ID:        uuid.New(),
+		ProjectID: projectID,
+		Category:  category,
+		Reason:    reason,
+		Amount:    amount,
+		Currency:  currency,
+		Date:      date,
+		CreatedAt: time.Now(),
+		CreatedBy: createdBy,
+	}
+}
+
+// Validate checks the deduction has a category, a reason and a positive amount
+func (d *Deduction) Validate() error {
+	if !d.Category.IsValid() || d.Reason == "" || d.Amount <= 0 || d.Currency == "" || d.Date.IsZero() {
+		return ErrInvalidDeduction
+	}
+	return nil
+}
+
+// IsApplied returns true once the deduction has been taken from a pay application
+func (d *Deduction) IsApplied() bool {
+	return d.PayApplicationID != nil
+}
+
+// DeductionSchedule raises the same deduction at a fixed monthly interval,
+// e.g. the SGK premium or the site utility recharge of every month
+type DeductionSchedule struct {
+	ID             uuid.UUID         `json:"id"`
+	ProjectID      uuid.UUID         `json:"project_id"`
+	ContractID     *uuid.UUID        `json:"contract_id,omitempty"`
+	Category       DeductionCategory `json:"category"`
+	Reason         string            `json:"reason"`
+	Amount         int64             `json:"amount"` // Cents per occurrence
+	Currency       string            `json:"currency"`
+	IntervalMonths int               `json:"interval_months"`
+	StartDate      time.Time         `json:"start_date"`
+	EndDate        *time.Time        `json:"end_date,omitempty"` // Last date an occurrence may fall on, nil while open-ended
+	NextDate       time.Time         `json:"next_date"`
+	Active         bool              `json:"active"`
+	CreatedAt      time.Time         `json:"created_at"`
+	CreatedBy      uuid.UUID         `json:"created_by"`
+}
+
+// NewDeductionSchedule creates a monthly schedule whose first occurrence is on startDate
+func NewDeductionSchedule(projectID uuid.UUID, category DeductionCategory, reason string, amount int64, currency string, startDate time.Time, createdBy uuid.UUID) *DeductionSchedule {
+	return &DeductionSchedule{
+		ID:             uuid.New(),
+		ProjectID:      projectID,
+		Category:       category,
+		Reason:         reason,
+		Amount:         amount,
+		Currency:       currency,
+		IntervalMonths: 1,
+		StartDate:      startDate,
+		NextDate:       startDate,
+		Active:         true,
+		CreatedAt:      time.Now(),
+		CreatedBy:      createdBy,
+	}
+}
+
+// Validate checks the schedule can produce valid deductions
+func (s *DeductionSchedule) Validate() error {
+	if !s.Category.IsValid() || s.Reason == "" || s.Amount <= 0 || s.Currency == "" || s.StartDate.IsZero() {
+		return ErrInvalidDeductionSchedule
+	}
+	if s.IntervalMonths < 1 || (s.EndDate != nil && s.EndDate.Before(s.StartDate)) {
+		return ErrInvalidDeductionSchedule
+	}
+	return nil
+}
+
+// Due returns the occurrence dates on or before now and advances NextDate past them
+// The schedule is deactivated once the next occurrence would fall after EndDate.
+func (s *DeductionSchedule) Due(now time.Time) []time.Time {
+	var dates []time.Time
+	for s.Active && !s.NextDate.After(now) {
+		if s.EndDate != nil && s.NextDate.After(*s.EndDate) {
+			s.Active = false
+			break
+		}
+		dates = append(dates, s.NextDate)
+		s.NextDate = s.following()
+	}
+	if s.Active && s.EndDate != nil && s.NextDate.After(*s.EndDate) {
+		s.Active = false
+	}
+	return dates
+}
+
+// following returns the occurrence one interval after NextDate, keeping the day
+// of month of StartDate so a schedule starting on the 31st stays at month end
+func (s *DeductionSchedule) following() time.Time {
+	months := monthsBetween(s.StartDate, s.NextDate) + s.IntervalMonths
+	first := time.Date(s.StartDate.Year(), s.StartDate.Month()+time.Month(months), 1, 0, 0, 0, 0, s.StartDate.Location())
+	day := s.StartDate.Day()
+	if last := first.AddDate(0, 1, -1).Day(); day > last {
+		day = last
+	}
+	return time.Date(first.Year(), first.Month(), day, 0, 0, 0, 0, first.Location())
+}
+
+// Stop ends the schedule; deductions already raised are kept
+func (s *DeductionSchedule) Stop() {
+	s.Active = false
+}
+
+// LatePenaltyRule is the contract's delay penalty (gecikme cezası)
+// Every day past the project's estimated end date costs DailyRate of the contract amount,
+// up to CapRate of the contract amount in total.
+type LatePenaltyRule struct {
+	ProjectID uuid.UUID `json:"project_id"`
+	DailyRate int64     `json:"daily_rate"` // Parts per million of the contract amount per day (600 = binde 0,6)
+	CapRate   int64     `json:"cap_rate"`   // Basis points of the contract amount, 0 for no cap
+	UpdatedAt time.Time `json:"updated_at"`
+	UpdatedBy uuid.UUID `json:"updated_by"`
+}
+
+// NewLatePenaltyRule creates a penalty rule for a project
+func NewLatePenaltyRule(projectID uuid.UUID, dailyRate, capRate int64, updatedBy uuid.UUID) *LatePenaltyRule {
+	return &LatePenaltyRule{
+		ProjectID: projectID,
+		DailyRate: dailyRate,
+		CapRate:   capRate,
+		UpdatedAt: time.Now(),
+		UpdatedBy: updatedBy,
+	}
+}
+
+// Validate checks the rates are within range
+func (r *LatePenaltyRule) Validate() error {
+	if r.DailyRate <= 0 || r.DailyRate > 1000000 || r.CapRate < 0 || r.CapRate > 10000 {
+		return ErrInvalidPenaltyRule
+	}
+	return nil
+}
+
+// DaysLate returns the whole days from the estimated end date to asOf
+func DaysLate(estimatedEnd, asOf time.Time) int {
+	end := time.Date(estimatedEnd.Year(), estimatedEnd.Month(), estimatedEnd.Day(), 0, 0, 0, 0, time.UTC)
+	on := time.Date(asOf.Year(), asOf.Month(), asOf.Day(), 0, 0, 0, 0, time.UTC)
+	if !on.After(end) {
+		return 0
+	}
+	return int(on.Sub(end).Hours() / 24)
+}
+
+// PenaltyToDate returns the total penalty for daysLate days, rounded down to the cent and capped
+func (r *LatePenaltyRule) PenaltyToDate(contractAmount int64, daysLate int) int64 {
+	if daysLate <= 0 || contractAmount <= 0 {
+		return 0
+	}
+	penalty := new(big.Int).Mul(big.NewInt(contractAmount), big.NewInt(r.DailyRate))
+	penalty.Mul(penalty, big.NewInt(int64(daysLate)))
+	penalty.Quo(penalty, big.NewInt(1000000))
+
+	if r.CapRate > 0 {
+		limit := new(big.Int).Mul(big.NewInt(contractAmount), big.NewInt(r.CapRate))
+		limit.Quo(limit, big.NewInt(10000))
+		if penalty.Cmp(limit) > 0 {
+			return limit.Int64()
+		}
+	}
+	return penalty.Int64()
+}
+
+func monthsBetween(from, to time.Time) int {
+	return (to.Year()-from.Year())*12 + int(to.Month()) - int(from.Month())
+}
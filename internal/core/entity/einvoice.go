@@ -0,0 +1,223 @@
+// Copyright (c) 2026 Muhammet Ali Büyük. All rights reserved.
+// This source code is proprietary. Confidential and private.
+// Unauthorized copying or distribution is strictly prohibited.
+// Contact: iletisim@alibuyuk.net | Website: alibuyuk.net
+
+package entity
+
+import (
+	"regexp"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// EInvoiceProfile is the GİB scenario (senaryo) of an e-Fatura
+type EInvoiceProfile string
+
+const (
+	EInvoiceProfileBasic      EInvoiceProfile = "TEMELFATURA"
+	EInvoiceProfileCommercial EInvoiceProfile = "TICARIFATURA"
+)
+
+// EInvoiceType is the UBL-TR InvoiceTypeCode
+type EInvoiceType string
+
+const (
+	EInvoiceTypeSale        EInvoiceType = "SATIS"    // Standart satış faturası
+	EInvoiceTypeWithholding EInvoiceType = "TEVKIFAT" // KDV tevkifatlı fatura
+)
+
+// EInvoiceStatus tracks an e-Fatura through the integrator
+type EInvoiceStatus string
+
+const (
+	EInvoiceStatusGenerated EInvoiceStatus = "GENERATED" // XML created, not sent
+	EInvoiceStatusSent      EInvoiceStatus = "SENT"      // Accepted by integrator
+	EInvoiceStatusAccepted  EInvoiceStatus = "ACCEPTED"  // Delivered / accepted by receiver
+	EInvoiceStatusRejected  EInvoiceStatus = "REJECTED"  // Rejected by GİB or receiver
+)
+
+// WithholdingCode describes a GİB KDV tevkifat code
+type WithholdingCode struct {
+	Code string `json:"code"`
+	Name string `json:"name"`
+	Rate int64  `json:"rate"` // Basis points of KDV withheld (4000 = 4/10)
+}
+
+// WithholdingCodes lists the tevkifat codes used in construction billing
+var WithholdingCodes = map[string]WithholdingCode{
+	"601": {Code: "601", Name: "YAPIM İŞLERİ İLE BU İŞLERLE BİRLİKTE İFA EDİLEN MÜHENDİSLİK-MİMARLIK VE ETÜT-PROJE HİZMETLERİ", Rate: 4000},
+	"602": {Code: "602", Name: "ETÜT, PLAN-PROJE, DANIŞMANLIK, DENETİM VE BENZERİ HİZMETLER", Rate: 9000},
+	"606": {Code: "606", Name: "İŞGÜCÜ TEMİN HİZMETLERİ", Rate: 9000},
+	"612": {Code: "612", Name: "TEMİZLİK HİZMETİ", Rate: 9000},
+	"616": {Code: "616", Name: "DİĞER HİZMETLER", Rate: 5000},
+	"624": {Code: "624", Name: "YÜK TAŞIMACILIĞI HİZMETİ", Rate: 2000},
+	"627": {Code: "627", Name: "DEMİR-ÇELİK ÜRÜNLERİNİN TESLİMİ", Rate: 5000},
+}
+
+// eInvoiceNoRegex matches the GİB invoice number: 3-char prefix, year, 9-digit sequence
+var eInvoiceNoRegex = regexp.MustCompile(`^[A-Z0-9]{3}20\d{2}\d{9}$`)
+
+var taxIDRegex = regexp.MustCompile(`^(\d{10}|\d{11})$`)
+
+// EInvoiceParty is the seller or buyer of an e-Fatura
+type EInvoiceParty struct {
+	Name       string `json:"name"`
+	TaxID      string `json:"tax_id"`     // VKN (10 digits) or TCKN (11 digits)
+	TaxOffice  string `json:"tax_office"` // Vergi dairesi
+	Street     string `json:"street,omitempty"`
+	District   string `json:"district"` // İlçe
+	City       string `json:"city"`     // İl
+	PostalCode string `json:"postal_code,omitempty"`
+	Country    string `json:"country"`
+	Email      string `json:"email,omitempty"`
+}
+
+// IsPerson returns true if the party is identified by a TCKN
+func (p EInvoiceParty) IsPerson() bool {
+	return len(p.TaxID) == 11
+}
+
+// SchemeID returns the UBL-TR identification scheme for the tax ID
+func (p EInvoiceParty) SchemeID() string {
+	if p.IsPerson() {
+		return "TCKN"
+	}
+	return "VKN"
+}
+
+// Validate checks the fields GİB requires for every party
+func (p EInvoiceParty) Validate() error {
+	if p.Name == "" || p.City == "" || p.District == "" || p.Country == "" {
+		return ErrEInvoicePartyIncomplete
+	}
+	if !taxIDRegex.MatchString(p.TaxID) {
+		return ErrInvalidTaxID
+	}
+	if !p.IsPerson() && p.TaxOffice == "" {
+		return ErrEInvoicePartyIncomplete
+	}
+	return nil
+}
+
+// EInvoiceLine is a single invoice line with its KDV and withholding
+type EInvoiceLine struct {
+	LineNo            int    `json:"line_no"`
+	Name              string `json:"name"`
+	Quantity          int64  `json:"quantity"`  // Whole units
+	UnitCode          string `json:"unit_code"` // UN/ECE Rec 20, e.g. C62
+	UnitPrice         int64  `json:"unit_price"`
+	LineExtension     int64  `json:"line_extension"` // Quantity * UnitPrice, cents
+	KDVRate           int64  `json:"kdv_rate"`       // Basis points (2000 = 20%)
+	KDVAmount         int64  `json:"kdv_amount"`
+	WithholdingCode   string `json:"withholding_code,omitempty"`
+	WithholdingRate   int64  `json:"withholding_rate,omitempty"` // Basis points of KDV
+	WithholdingAmount int64  `json:"withholding_amount,omitempty"`
+}
+
+// EInvoice is a generated UBL-TR 1.2 e-Fatura stored alongside its ledger invoice
+type EInvoice struct {
+	ID               uuid.UUID       `json:"id"`
+	ProjectID        uuid.UUID       `json:"project_id"`
+	TransactionID    uuid.UUID       `json:"transaction_id"`
+	PayApplicationID *uuid.UUID      `json:"pay_application_id,omitempty"`
+	InvoiceNo        string          `json:"invoice_no"` // GİB numarası, e.g. SUB2026000000001
+	ETTN             uuid.UUID       `json:"ettn"`       // Evrensel tekil tanımlama numarası
+	Profile          EInvoiceProfile `json:"profile"`
+	Type             EInvoiceType    `json:"type"`
+	IssueDate        time.Time       `json:"issue_date"`
+	Currency         string          `json:"currency"`
+	Note             string          `json:"note,omitempty"`
+	Seller           EInvoiceParty   `json:"seller"`
+	Buyer            EInvoiceParty   `json:"buyer"`
+	Lines            []EInvoiceLine  `json:"lines"`
+
+	// Monetary totals (cents)
+	LineExtensionTotal int64 `json:"line_extension_total"`
+	KDVTotal           int64 `json:"kdv_total"`
+	WithholdingTotal   int64 `json:"withholding_total"`
+	TaxInclusiveTotal  int64 `json:"tax_inclusive_total"`
+	PayableAmount      int64 `json:"payable_amount"`
+
+	XML           []byte         `json:"-"`
+	Status        EInvoiceStatus `json:"status"`
+	IntegratorRef string         `json:"integrator_ref,omitempty"`
+	StatusMessage string         `json:"status_message,omitempty"`
+	CreatedAt     time.Time      `json:"created_at"`
+	UpdatedAt     time.Time      `json:"updated_at"`
+	CreatedBy     uuid.UUID      `json:"created_by"`
+}
+
+// NewEInvoice creates an empty e-Fatura for a ledger invoice
+func NewEInvoice(projectID, transactionID uuid.UUID, invoiceNo string, createdBy uuid.UUID) *EInvoice {
+	now := time.Now()
+	return &EInvoice{
+		ID:            uuid.New(),
+		ProjectID:     projectID,
+		TransactionID: transactionID,
+		InvoiceNo:     invoiceNo,
+		ETTN:          uuid.New(),
+		Profile:       EInvoiceProfileCommercial,
+		Type:          EInvoiceTypeSale,
+		IssueDate:     now,
+		Status:        EInvoiceStatusGenerated,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+		CreatedBy:     createdBy,
+	}
+}
+
+// AddLine appends a line and refreshes the invoice totals
+// KDV and withholding amounts must already be set on the line
+func (e *EInvoice) AddLine(line EInvoiceLine) {
+	line.LineNo = len(e.Lines) + 1
+	e.Lines = append(e.Lines, line)
+	if line.WithholdingCode != "" {
+		e.Type = EInvoiceTypeWithholding
+	}
+
+	e.LineExtensionTotal += line.LineExtension
+	e.KDVTotal += line.KDVAmount
+	e.WithholdingTotal += line.WithholdingAmount
+	e.TaxInclusiveTotal = e.LineExtensionTotal + e.KDVTotal
+	e.PayableAmount = e.TaxInclusiveTotal - e.WithholdingTotal
+}
+
+// Validate checks the invoice against the GİB business rules enforced locally
+func (e *EInvoice) Validate() error {
+	if !eInvoiceNoRegex.MatchString(e.InvoiceNo) {
+		return ErrInvalidEInvoiceNo
+	}
+	if err := e.Seller.Validate(); err != nil {
+		return err
+	}
+	if err := e.Buyer.Validate(); err != nil {
+		return err
+	}
+	if len(e.Lines) == 0 {
+		return ErrEInvoiceNoLines
+	}
+	for _, line := range e.Lines {
+		if line.LineExtension <= 0 {
+			return ErrInvalidAmount
+		}
+		if line.WithholdingCode != "" {
+			if _, ok := WithholdingCodes[line.WithholdingCode]; !ok {
+				return ErrInvalidWithholdingCode
+			}
+		}
+	}
+	return nil
+}
+
+// MarkSent records the integrator's reference after a successful submission
+func (e *EInvoice) MarkSent(ref string) error {
+	if e.Status != EInvoiceStatusGenerated {
+		return ErrEInvoiceAlreadySent
+	}
+	e.Status = EInvoiceStatusSent
+	e.IntegratorRef = ref
+	e.UpdatedAt = time.Now()
+	return nil
+}
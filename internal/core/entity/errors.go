@@ -16,6 +16,11 @@ var (
 	ErrInvalidContractAmount = errors.New("contract amount cannot be negative")
 	ErrProjectNotModifiable  = errors.New("project cannot be modified in current status")
 
+	// Project status errors
+	ErrInvalidStatusTransition       = errors.New("project status transition is not allowed")
+	ErrProjectHasOpenPayApplications = errors.New("project has pay applications awaiting a decision")
+	ErrProjectHasOpenBalance         = errors.New("project has an open balance")
+
 	// Transaction errors
 	ErrTransactionNotFound    = errors.New("transaction not found")
 	ErrInvalidTransactionType = errors.New("invalid transaction type")
@@ -39,4 +44,119 @@ var (
 	// Calculation errors
 	ErrRetainageExceedsTotal = errors.New("retainage cannot exceed total amount")
 	ErrNegativeBalance       = errors.New("operation would result in negative balance")
+
+	// Bank statement / reconciliation errors
+	ErrStatementNotFound        = errors.New("bank statement not found")
+	ErrStatementLineNotFound    = errors.New("bank statement line not found")
+	ErrStatementBalanceMismatch = errors.New("statement lines do not reconcile opening to closing balance")
+	ErrStatementLineReconciled  = errors.New("statement line is already reconciled")
+	ErrInvalidMatchTarget       = errors.New("statement lines can only be matched to invoices or payments")
+	ErrTransactionReconciled    = errors.New("transaction is already matched to a statement line")
+	ErrInvoiceAlreadyPaid       = errors.New("invoice is already referenced by a payment")
+	ErrMatchAmountMismatch      = errors.New("statement line amount differs from the invoice beyond the tolerance")
+
+	// Pay application errors
+	ErrPayApplicationNotFound     = errors.New("pay application not found")
+	ErrPayApplicationNotEditable  = errors.New("pay application can only be changed while in draft")
+	ErrPayApplicationNotSubmitted = errors.New("pay application must be submitted before a decision")
+	ErrPayApplicationNotCertified = errors.New("pay application is not certified")
+
+	// E-invoice errors
+	ErrEInvoiceNotFound        = errors.New("e-invoice not found")
+	ErrEInvoiceExists          = errors.New("an e-invoice already exists for this transaction")
+	ErrEInvoiceNotInvoice      = errors.New("e-invoices can only be generated from invoice transactions")
+	ErrEInvoicePartyIncomplete = errors.New("e-invoice party is missing name, address or tax office")
+	ErrInvalidTaxID            = errors.New("tax ID must be a 10-digit VKN or 11-digit TCKN")
+	ErrInvalidEInvoiceNo       = errors.New("invoice number must be 3 characters, year and 9-digit sequence")
+	ErrEInvoiceNoLines         = errors.New("e-invoice must have at least one line")
+	ErrInvalidWithholdingCode  = errors.New("unknown KDV withholding code")
+	ErrEInvoiceAlreadySent     = errors.New("e-invoice has already been sent")
+
+	// Tax errors
+	ErrInvalidTaxRate  = errors.New("tax rate must have a known kind, a code, a rate between 0 and 100% and a valid date range")
+	ErrTaxRateNotFound = errors.New("no tax rate in force for the code and date")
+	ErrTaxRateOverlap  = errors.New("tax rate overlaps an existing rate for the same code")
+
+	// Price escalation errors
+	ErrEscalationFormulaNotFound = errors.New("escalation formula not found")
+	ErrInvalidEscalationFormula  = errors.New("escalation formula needs a base month, a coefficient and distinct indexed components")
+	ErrEscalationWeights         = errors.New("escalation component weights must sum to 10000 basis points")
+	ErrPriceIndexNotFound        = errors.New("price index value not found for month")
+	ErrEscalationProjectMismatch = errors.New("escalation formula belongs to another project")
+
+	// Advance payment errors
+	ErrInvalidAdvanceRule  = errors.New("advance recovery needs a rate between 0 and 100% or a completion range with start below end")
+	ErrAdvanceRuleNotFound = errors.New("no advance recovery rule for project")
+
+	// Bank guarantee errors
+	ErrGuaranteeNotFound         = errors.New("bank guarantee not found")
+	ErrGuaranteeExists           = errors.New("a guarantee with this bank and letter number already exists")
+	ErrInvalidGuarantee          = errors.New("guarantee needs a known type, bank, letter number, positive amount and an expiry after issue")
+	ErrGuaranteeNotActive        = errors.New("guarantee is no longer held")
+	ErrInvalidGuaranteeReduction = errors.New("reduced amount must be positive and below the current amount")
+
+	// Notification errors
+	ErrNotificationNotFound           = errors.New("notification not found")
+	ErrNotificationExists             = errors.New("notification already exists")
+	ErrNotificationPreferenceNotFound = errors.New("notification preference not found")
+	ErrInvalidNotificationPreference  = errors.New("preference needs locale tr or en, digest IMMEDIATE, DAILY or OFF and known notification kinds")
+
+	// Deduction errors
+	ErrDeductionNotFound         = errors.New("deduction not found")
+	ErrInvalidDeduction          = errors.New("deduction needs a known category, a reason, a positive amount, a currency and a date")
+	ErrDeductionScheduleNotFound = errors.New("deduction schedule not found")
+	ErrInvalidDeductionSchedule  = errors.New("deduction schedule needs a valid deduction, an interval of at least one month and an end after its start")
+	ErrInvalidPenaltyRule        = errors.New("late penalty needs a daily rate above zero and a cap between 0 and 100%")
+	ErrPenaltyRuleNotFound       = errors.New("no late penalty rule for project")
+
+	// Compliance errors
+	ErrComplianceDocumentNotFound    = errors.New("compliance document not found")
+	ErrInvalidComplianceDocument     = errors.New("compliance document needs a known type, a contract, an issue date, an expiry after issue and the amount or date its waiver covers")
+	ErrComplianceDocumentRevoked     = errors.New("compliance document is already revoked")
+	ErrComplianceRequirementNotFound = errors.New("compliance requirement not found")
+	ErrComplianceRequirementExists   = errors.New("document type is already required for this scope")
+	ErrInvalidComplianceRequirement  = errors.New("compliance requirement needs a project and a known document type")
+	ErrSubcontractorNonCompliant     = errors.New("subcontractor is not compliant, payment requires an override reason")
+
+	// Retainage errors
+	ErrRetainageReleaseNotFound        = errors.New("retainage release not found")
+	ErrInvalidRetainageRelease         = errors.New("retainage release needs a kind, a positive amount and a currency")
+	ErrRetainageReleaseNotPending      = errors.New("retainage release is no longer pending")
+	ErrRetainageSelfApproval           = errors.New("retainage release cannot be approved by its requester")
+	ErrRetainageDuplicateApproval      = errors.New("approver has already signed off this retainage release")
+	ErrProjectNotSubstantiallyComplete = errors.New("final retainage release requires a completed project or a recorded substantial completion date")
+
+	// Job cost errors
+	ErrCostCodeNotFound        = errors.New("cost code not found")
+	ErrCostCodeExists          = errors.New("cost code already exists in this project")
+	ErrInvalidCostCode         = errors.New("cost code requires a code and a name")
+	ErrCostCodeProjectMismatch = errors.New("cost code belongs to another project")
+	ErrInvalidBudgetLine       = errors.New("invalid budget line")
+	ErrCostCommitmentNotFound  = errors.New("cost commitment not found")
+	ErrInvalidCostCommitment   = errors.New("invalid cost commitment")
+	ErrInvalidVendorInvoice    = errors.New("invalid vendor invoice")
+	ErrVendorInvoiceExists     = errors.New("vendor invoice already recorded")
+
+	// Cash flow errors
+	ErrCashFlowPlanNotFound = errors.New("cash flow plan not found")
+	ErrInvalidCashFlowPlan  = errors.New("cash flow plan needs a known curve, a start before the end and non-negative terms")
+
+	// Webhook errors
+	ErrWebhookEndpointNotFound  = errors.New("webhook endpoint not found")
+	ErrInvalidWebhookEndpoint   = errors.New("webhook endpoint needs an http(s) URL and at least one known event")
+	ErrWebhookAddressNotAllowed = errors.New("webhook URL must resolve to public addresses only")
+	ErrWebhookDeliveryNotFound  = errors.New("webhook delivery not found")
+	ErrWebhookDeliveryExists    = errors.New("webhook delivery already exists for this event")
+
+	// Outbox errors
+	ErrOutboxEntryNotFound = errors.New("outbox entry not found")
+
+	// Audit errors
+	ErrAuditTenantRequired = errors.New("audit log needs a tenant")
+	ErrInvalidAuditFilter  = errors.New("audit log filter is invalid")
+
+	// Import errors
+	ErrImportEmpty         = errors.New("import file contains no data rows")
+	ErrImportMissingColumn = errors.New("import file is missing a required column")
+	ErrImportRowsInvalid   = errors.New("import contains invalid rows, nothing was saved")
 )
@@ -0,0 +1,132 @@
+// Copyright (c) 2026 Muhammet Ali Büyük. All rights reserved.
+// This source code is proprietary. Confidential and private.
+// Unauthorized copying or distribution is strictly prohibited.
+// Contact: iletisim@alibuyuk.net | Website: alibuyuk.net
+
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PnScale is the precision of the escalation factor Pn (six decimal places, 1000000 = 1.000000)
+const PnScale int64 = 1000000
+
+// PriceIndex is one monthly value of a published price index (TÜİK Yİ-ÜFE, sub-indices, ...)
+type PriceIndex struct {
+	ID         uuid.UUID `json:"id"`
+	Code       string    `json:"code"`   // e.g. YI-UFE, CIMENTO, DEMIR, MOTORIN
+	Period     time.Time `json:"period"` // First day of the month
+	Value      int64     `json:"value"`  // Hundredths (1234.56 = 123456)
+	Source     string    `json:"source,omitempty"`
+	ImportedAt time.Time `json:"imported_at"`
+}
+
+// NewPriceIndex creates an index value for the month containing period
+func NewPriceIndex(code string, period time.Time, value int64, source string) *PriceIndex {
+	return &PriceIndex{
+		ID:         uuid.New(),
+		Code:       code,
+		Period:     MonthOf(period),
+		Value:      value,
+		Source:     source,
+		ImportedAt: time.Now(),
+	}
+}
+
+// EscalationComponent is one weighted term of the formula, e.g. a(İn/İo)
+type EscalationComponent struct {
+	Name      string `json:"name"`       // İşçilik, Çimento, Demir, Akaryakıt, ...
+	IndexCode string `json:"index_code"` // PriceIndex.Code
+	Weight    int64  `json:"weight"`     // Basis points; all weights sum to 10000
+}
+
+// EscalationFormula is the contract's price adjustment formula (fiyat farkı)
+// F = An × B × (Pn − 1), Pn = Σ weight × (index of the period month / index of the base month)
+type EscalationFormula struct {
+	ID             uuid.UUID             `json:"id"`
+	ProjectID      uuid.UUID             `json:"project_id"`
+	Name           string                `json:"name"`
+	BaseMonth      time.Time             `json:"base_month"`       // Month of the base indices (Io), usually the month before the tender
+	Coefficient    int64                 `json:"coefficient"`      // B in basis points, 9000 = 0.90
+	IndexLagMonths int                   `json:"index_lag_months"` // Indices of this many months before the period end are used
+	Components     []EscalationComponent `json:"components"`
+	CreatedAt      time.Time             `json:"created_at"`
+	CreatedBy      uuid.UUID             `json:"created_by"`
+}
+
+// NewEscalationFormula creates a formula with the customary B = 0.90 and a one month index lag
+func NewEscalationFormula(projectID uuid.UUID, name string, baseMonth time.Time, components []EscalationComponent, createdBy uuid.UUID) *EscalationFormula {
+	return &EscalationFormula{
+		ID:             uuid.New(),
+		ProjectID:      projectID,
+		Name:           name,
+		BaseMonth:      MonthOf(baseMonth),
+		Coefficient:    9000,
+		IndexLagMonths: 1,
+		Components:     components,
+		CreatedAt:      time.Now(),
+		CreatedBy:      createdBy,
+	}
+}
+
+// Validate checks the weights add up to one and every term names an index
+func (f *EscalationFormula) Validate() error {
+	if len(f.Components) == 0 || f.BaseMonth.IsZero() || f.Coefficient <= 0 || f.Coefficient > 10000 || f.IndexLagMonths < 0 {
+		return ErrInvalidEscalationFormula
+	}
+
+	var total int64
+	seen := make(map[string]bool)
+	for _, c := range f.Components {
+		if c.IndexCode == "" || c.Weight <= 0 || seen[c.IndexCode] {
+			return ErrInvalidEscalationFormula
+		}
+		seen[c.IndexCode] = true
+		total += c.Weight
+	}
+	if total != 10000 {
+		return ErrEscalationWeights
+	}
+	return nil
+}
+
+// IndexMonth returns the month whose indices apply to a period ending on periodEnd
+func (f *EscalationFormula) IndexMonth(periodEnd time.Time) time.Time {
+	return MonthOf(periodEnd).AddDate(0, -f.IndexLagMonths, 0)
+}
+
+// EscalationIndexUsed records one term of a calculation for audit
+type EscalationIndexUsed struct {
+	Name         string    `json:"name"`
+	IndexCode    string    `json:"index_code"`
+	Weight       int64     `json:"weight"`
+	BaseMonth    time.Time `json:"base_month"`
+	BaseValue    int64     `json:"base_value"`
+	CurrentMonth time.Time `json:"current_month"`
+	CurrentValue int64     `json:"current_value"`
+	Term         int64     `json:"term"` // weight × In/Io in PnScale
+}
+
+// EscalationResult is the price adjustment computed for a pay application
+type EscalationResult struct {
+	ID               uuid.UUID             `json:"id"`
+	ProjectID        uuid.UUID             `json:"project_id"`
+	PayApplicationID uuid.UUID             `json:"pay_application_id"`
+	FormulaID        uuid.UUID             `json:"formula_id"`
+	WorkAmount       int64                 `json:"work_amount"` // An, cents at contract prices
+	Coefficient      int64                 `json:"coefficient"` // B, basis points
+	IndexMonth       time.Time             `json:"index_month"`
+	Pn               int64                 `json:"pn"`     // PnScale
+	Amount           int64                 `json:"amount"` // F, cents; negative when prices fell
+	Indices          []EscalationIndexUsed `json:"indices"`
+	CalculatedAt     time.Time             `json:"calculated_at"`
+	CreatedBy        uuid.UUID             `json:"created_by"`
+}
+
+// MonthOf returns the first day of the month containing t
+func MonthOf(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
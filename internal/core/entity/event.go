@@ -0,0 +1,84 @@
+// Copyright (c) 2026 Muhammet Ali Büyük. All rights reserved.
+// This source code is proprietary. Confidential and private.
+// Unauthorized copying or distribution is strictly prohibited.
+// Contact: iletisim@alibuyuk.net | Website: alibuyuk.net
+
+package entity
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// EventType names a domain event announced to subscribers
+type EventType string
+
+const (
+	EventTransactionCreated      EventType = "transaction.created"
+	EventPayApplicationSubmitted EventType = "pay_application.submitted"
+	EventPayApplicationCertified EventType = "pay_application.certified"
+	EventRetainageReleased       EventType = "retainage.released"
+	EventProjectStatusChanged    EventType = "project.status_changed"
+)
+
+// EventTypes lists every event type in a stable order
+var EventTypes = []EventType{
+	EventTransactionCreated,
+	EventPayApplicationSubmitted,
+	EventPayApplicationCertified,
+	EventRetainageReleased,
+	EventProjectStatusChanged,
+}
+
+// IsValid returns true for a known event type
+func (t EventType) IsValid() bool {
+	for _, known := range EventTypes {
+		if t == known {
+			return true
+		}
+	}
+	return false
+}
+
+// Event is something that happened to a project, as announced to subscribers
+type Event struct {
+	ID         uuid.UUID       `json:"id"`
+	Type       EventType       `json:"type"`
+	ProjectID  uuid.UUID       `json:"project_id"`
+	OccurredAt time.Time       `json:"occurred_at"`
+	Data       json.RawMessage `json:"data"` // The entity the event is about, as the API returns it
+}
+
+// NewEvent creates an event carrying a JSON snapshot of data
+func NewEvent(eventType EventType, projectID uuid.UUID, data any) (*Event, error) {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	return &Event{
+		ID:         uuid.New(),
+		Type:       eventType,
+		ProjectID:  projectID,
+		OccurredAt: time.Now(),
+		Data:       raw,
+	}, nil
+}
+
+// OutboxEntry is an event waiting in the transactional outbox to be published
+type OutboxEntry struct {
+	Event
+	Attempts      int        `json:"attempts"`
+	NextAttemptAt time.Time  `json:"next_attempt_at"`
+	PublishedAt   *time.Time `json:"published_at,omitempty"`
+	LastError     string     `json:"last_error,omitempty"`
+}
+
+// NewOutboxEntry queues an event for publishing as soon as possible
+func NewOutboxEntry(event *Event) *OutboxEntry {
+	return &OutboxEntry{
+		Event:         *event,
+		NextAttemptAt: event.OccurredAt,
+	}
+}
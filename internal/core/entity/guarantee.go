@@ -0,0 +1,162 @@
+// Copyright (c) 2026 Muhammet Ali Büyük. All rights reserved.
+// This source code is proprietary. Confidential and private.
+// Unauthorized copying or distribution is strictly prohibited.
+// Contact: iletisim@alibuyuk.net | Website: alibuyuk.net
+
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// GuaranteeType is the purpose of a bank guarantee letter
+type GuaranteeType string
+
+const (
+	GuaranteeTypeBid         GuaranteeType = "BID"         // Geçici teminat
+	GuaranteeTypePerformance GuaranteeType = "PERFORMANCE" // Kesin teminat
+	GuaranteeTypeAdvance     GuaranteeType = "ADVANCE"     // Avans teminatı
+)
+
+// GuaranteeStatus represents where a letter is in its lifecycle
+type GuaranteeStatus string
+
+const (
+	GuaranteeStatusActive   GuaranteeStatus = "ACTIVE"   // Held by the employer
+	GuaranteeStatusReturned GuaranteeStatus = "RETURNED" // Given back to the bank
+	GuaranteeStatusExpired  GuaranteeStatus = "EXPIRED"  // Expiry date passed while still held
+)
+
+// GuaranteeReduction records a partial release of a letter's amount
+type GuaranteeReduction struct {
+	PreviousAmount int64     `json:"previous_amount"`
+	NewAmount      int64     `json:"new_amount"`
+	Reason         string    `json:"reason"`
+	ReducedAt      time.Time `json:"reduced_at"`
+	ReducedBy      uuid.UUID `json:"reduced_by"`
+}
+
+// BankGuarantee is a bank guarantee letter (teminat mektubu) given as security on a project
+type BankGuarantee struct {
+	ID              uuid.UUID            `json:"id"`
+	ProjectID       uuid.UUID            `json:"project_id"`
+	ContractID      *uuid.UUID           `json:"contract_id,omitempty"`
+	Bank            string               `json:"bank"`
+	LetterNo        string               `json:"letter_no"`
+	Type            GuaranteeType        `json:"type"`
+	OriginalAmount  int64                `json:"original_amount"` // Cents, as issued
+	Amount          int64                `json:"amount"`          // Cents, after reductions
+	Currency        string               `json:"currency"`
+	IssueDate       time.Time            `json:"issue_date"`
+	ExpiryDate      *time.Time           `json:"expiry_date,omitempty"` // Nil for open-ended (süresiz) letters
+	Status          GuaranteeStatus      `json:"status"`
+	Reductions      []GuaranteeReduction `json:"reductions"`
+	ReturnedAt      *time.Time           `json:"returned_at,omitempty"`
+	ExpiryAlertedAt *time.Time           `json:"expiry_alerted_at,omitempty"` // Set once the pre-expiry alert is raised
+	CreatedAt       time.Time            `json:"created_at"`
+	UpdatedAt       time.Time            `json:"updated_at"`
+	CreatedBy       uuid.UUID            `json:"created_by"`
+}
+
+// NewBankGuarantee creates an active guarantee letter
+func NewBankGuarantee(projectID uuid.UUID, guaranteeType GuaranteeType, bank, letterNo string, amount int64, currency string, issueDate time.Time, expiryDate *time.Time, createdBy uuid.UUID) *BankGuarantee {
+	now := time.Now()
+	return &BankGuarantee{
+		ID:             uuid.New(),
+		ProjectID:      projectID,
+		Bank:           bank,
+		LetterNo:       letterNo,
+		Type:           guaranteeType,
+		OriginalAmount: amount,
+		Amount:         amount,
+		Currency:       currency,
+		IssueDate:      issueDate,
+		ExpiryDate:     expiryDate,
+		Status:         GuaranteeStatusActive,
+		Reductions:     []GuaranteeReduction{},
+		CreatedAt:      now,
+		UpdatedAt:      now,
+		CreatedBy:      createdBy,
+	}
+}
+
+// Validate checks the letter data integrity
+func (g *BankGuarantee) Validate() error {
+	switch g.Type {
+	case GuaranteeTypeBid, GuaranteeTypePerformance, GuaranteeTypeAdvance:
+	default:
+		return ErrInvalidGuarantee
+	}
+	if g.Bank == "" || g.LetterNo == "" || g.Amount <= 0 || g.IssueDate.IsZero() {
+		return ErrInvalidGuarantee
+	}
+	if g.ExpiryDate != nil && !g.ExpiryDate.After(g.IssueDate) {
+		return ErrInvalidGuarantee
+	}
+	return nil
+}
+
+// IsActive returns true while the employer still holds the letter
+func (g *BankGuarantee) IsActive() bool {
+	return g.Status == GuaranteeStatusActive
+}
+
+// DaysToExpiry returns the whole days left until expiry, negative once expired
+// The second result is false for open-ended letters.
+func (g *BankGuarantee) DaysToExpiry(now time.Time) (int, bool) {
+	if g.ExpiryDate == nil {
+		return 0, false
+	}
+	return int(truncateDay(*g.ExpiryDate).Sub(truncateDay(now)).Hours() / 24), true
+}
+
+// Reduce lowers the guaranteed amount, e.g. after provisional acceptance
+func (g *BankGuarantee) Reduce(newAmount int64, reason string, reducedBy uuid.UUID) error {
+	if !g.IsActive() {
+		return ErrGuaranteeNotActive
+	}
+	if newAmount <= 0 || newAmount >= g.Amount {
+		return ErrInvalidGuaranteeReduction
+	}
+	now := time.Now()
+	g.Reductions = append(g.Reductions, GuaranteeReduction{
+		PreviousAmount: g.Amount,
+		NewAmount:      newAmount,
+		Reason:         reason,
+		ReducedAt:      now,
+		ReducedBy:      reducedBy,
+	})
+	g.Amount = newAmount
+	g.UpdatedAt = now
+	return nil
+}
+
+// Return marks the letter as given back to the contractor's bank
+func (g *BankGuarantee) Return() error {
+	if g.Status == GuaranteeStatusReturned {
+		return ErrGuaranteeNotActive
+	}
+	now := time.Now()
+	g.Status = GuaranteeStatusReturned
+	g.ReturnedAt = &now
+	g.UpdatedAt = now
+	return nil
+}
+
+// Extend moves the expiry date after the bank renews the letter
+// An expired letter becomes active again and the next expiry is alerted afresh.
+func (g *BankGuarantee) Extend(expiryDate time.Time) error {
+	if g.Status == GuaranteeStatusReturned {
+		return ErrGuaranteeNotActive
+	}
+	if g.ExpiryDate == nil || !expiryDate.After(*g.ExpiryDate) {
+		return ErrInvalidGuarantee
+	}
+	g.ExpiryDate = &expiryDate
+	g.Status = GuaranteeStatusActive
+	g.ExpiryAlertedAt = nil
+	g.UpdatedAt = time.Now()
+	return nil
+}
@@ -0,0 +1,193 @@
+// Copyright (c) 2026 Muhammet Ali Büyük. All rights reserved.
+// This source code is proprietary. Confidential and private.
+// Unauthorized copying or distribution is strictly prohibited.
+// Contact: iletisim@alibuyuk.net | Website: alibuyuk.net
+
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// NotificationKind identifies the event a notification reports
+type NotificationKind string
+
+const (
+	NotificationGuaranteeExpiring  NotificationKind = "GUARANTEE_EXPIRING"        // Teminat mektubu süresi doluyor
+	NotificationGuaranteeExpired   NotificationKind = "GUARANTEE_EXPIRED"         // Teminat mektubu süresi doldu
+	NotificationComplianceExpiring NotificationKind = "COMPLIANCE_EXPIRING"       // Taşeron belgesinin süresi doluyor
+	NotificationComplianceOverride NotificationKind = "COMPLIANCE_OVERRIDE"       // Eksik belgeye rağmen ödeme yapıldı
+	NotificationPayAppSubmitted    NotificationKind = "PAY_APPLICATION_SUBMITTED" // Hakediş onaya sunuldu
+	NotificationPayAppCertified    NotificationKind = "PAY_APPLICATION_CERTIFIED" // Hakediş onaylandı
+)
+
+// NotificationKinds lists every notification kind in a stable order
+var NotificationKinds = []NotificationKind{
+	NotificationGuaranteeExpiring,
+	NotificationGuaranteeExpired,
+	NotificationComplianceExpiring,
+	NotificationComplianceOverride,
+	NotificationPayAppSubmitted,
+	NotificationPayAppCertified,
+}
+
+// IsValid returns true for a known notification kind
+func (k NotificationKind) IsValid() bool {
+	for _, known := range NotificationKinds {
+		if k == known {
+			return true
+		}
+	}
+	return false
+}
+
+// NotificationSeverity orders notifications for display
+type NotificationSeverity string
+
+const (
+	NotificationInfo     NotificationSeverity = "INFO"
+	NotificationWarning  NotificationSeverity = "WARNING"
+	NotificationCritical NotificationSeverity = "CRITICAL"
+)
+
+// Notification is an alert raised by the system for the users of a project
+type Notification struct {
+	ID          uuid.UUID            `json:"id"`
+	ProjectID   uuid.UUID            `json:"project_id"`
+	Kind        NotificationKind     `json:"kind"`
+	Severity    NotificationSeverity `json:"severity"`
+	Subject     string               `json:"subject"`
+	Body        string               `json:"body"`
+	ReferenceID *uuid.UUID           `json:"reference_id,omitempty"` // Entity the alert is about
+	Params      map[string]string    `json:"params,omitempty"`       // Values for the localized email templates
+	CreatedAt   time.Time            `json:"created_at"`
+	ReadAt      *time.Time           `json:"read_at,omitempty"`
+}
+
+// NewNotification creates an unread notification
+func NewNotification(projectID uuid.UUID, kind NotificationKind, severity NotificationSeverity, subject, body string) *Notification {
+	return &Notification{
+		ID:        uuid.New(),
+		ProjectID: projectID,
+		Kind:      kind,
+		Severity:  severity,
+		Subject:   subject,
+		Body:      body,
+		CreatedAt: time.Now(),
+	}
+}
+
+// NotificationLocale is the language of the emails a user receives
+type NotificationLocale string
+
+const (
+	LocaleTurkish NotificationLocale = "tr"
+	LocaleEnglish NotificationLocale = "en"
+)
+
+// DigestMode decides when a user's notification emails are sent
+type DigestMode string
+
+const (
+	DigestImmediate DigestMode = "IMMEDIATE" // One email per notification
+	DigestDaily     DigestMode = "DAILY"     // One summary email per day
+	DigestOff       DigestMode = "OFF"       // Inbox only, no email
+)
+
+// NotificationPreference is how a user wants to be emailed about the tenant's projects
+type NotificationPreference struct {
+	UserID    uuid.UUID          `json:"user_id"`
+	TenantID  uuid.UUID          `json:"tenant_id"`
+	Email     string             `json:"email"`
+	Locale    NotificationLocale `json:"locale"`
+	Digest    DigestMode         `json:"digest"`
+	Kinds     []NotificationKind `json:"kinds"`              // Kinds the user is emailed about
+	Projects  []uuid.UUID        `json:"projects,omitempty"` // Limits emails to these projects; empty for all
+	UpdatedAt time.Time          `json:"updated_at"`
+}
+
+// DefaultNotificationKinds are the kinds a user is emailed about until they choose:
+// managers hear about everything, accountants about money and guarantees
+func DefaultNotificationKinds(role UserRole) []NotificationKind {
+	switch role {
+	case UserRoleAdmin, UserRoleManager:
+		return append([]NotificationKind(nil), NotificationKinds...)
+	case UserRoleAccountant:
+		return []NotificationKind{NotificationGuaranteeExpiring, NotificationGuaranteeExpired, NotificationPayAppCertified}
+	}
+	return nil
+}
+
+// NewNotificationPreference creates the default preference of a user: immediate Turkish emails
+func NewNotificationPreference(user *User) *NotificationPreference {
+	return &NotificationPreference{
+		UserID:    user.ID,
+		TenantID:  user.TenantID,
+		Email:     user.Email,
+		Locale:    LocaleTurkish,
+		Digest:    DigestImmediate,
+		Kinds:     DefaultNotificationKinds(user.Role),
+		UpdatedAt: time.Now(),
+	}
+}
+
+// Validate checks the preference can be acted on
+func (p *NotificationPreference) Validate() error {
+	if !emailRegex.MatchString(p.Email) {
+		return ErrInvalidEmail
+	}
+	if p.Locale != LocaleTurkish && p.Locale != LocaleEnglish {
+		return ErrInvalidNotificationPreference
+	}
+	switch p.Digest {
+	case DigestImmediate, DigestDaily, DigestOff:
+	default:
+		return ErrInvalidNotificationPreference
+	}
+	for _, k := range p.Kinds {
+		if !k.IsValid() {
+			return ErrInvalidNotificationPreference
+		}
+	}
+	return nil
+}
+
+// Wants returns true when the user is to be emailed about the notification
+func (p *NotificationPreference) Wants(n *Notification) bool {
+	if p.Digest == DigestOff {
+		return false
+	}
+	if len(p.Projects) > 0 {
+		found := false
+		for _, id := range p.Projects {
+			if id == n.ProjectID {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	for _, k := range p.Kinds {
+		if k == n.Kind {
+			return true
+		}
+	}
+	return false
+}
+
+// EmailDigestEntry is a rendered notification email waiting for the recipient's digest
+type EmailDigestEntry struct {
+	ID             uuid.UUID          `json:"id"`
+	UserID         uuid.UUID          `json:"user_id"`
+	Email          string             `json:"email"`
+	Locale         NotificationLocale `json:"locale"`
+	NotificationID uuid.UUID          `json:"notification_id"`
+	Subject        string             `json:"subject"`
+	Body           string             `json:"body"`
+	CreatedAt      time.Time          `json:"created_at"`
+	SentAt         *time.Time         `json:"sent_at,omitempty"`
+}
@@ -0,0 +1,133 @@
+// Copyright (c) 2026 Muhammet Ali Büyük. All rights reserved.
+// This source code is proprietary. Confidential and private.
+// Unauthorized copying or distribution is strictly prohibited.
+// Contact: iletisim@alibuyuk.net | Website: alibuyuk.net
+
+package entity
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PayApplicationStatus represents the approval state of a pay application (hakediş)
+type PayApplicationStatus string
+
+const (
+	PayApplicationStatusDraft     PayApplicationStatus = "DRAFT"     // Hazırlanıyor
+	PayApplicationStatusSubmitted PayApplicationStatus = "SUBMITTED" // Onaya sunuldu
+	PayApplicationStatusCertified PayApplicationStatus = "CERTIFIED" // Onaylandı
+	PayApplicationStatusRejected  PayApplicationStatus = "REJECTED"  // Reddedildi
+)
+
+// PayApplication is a periodic progress billing (AIA G702 / hakediş)
+// Amounts are a snapshot of the G702 calculation at the time it was prepared
+type PayApplication struct {
+	ID            uuid.UUID            `json:"id"`
+	ProjectID     uuid.UUID            `json:"project_id"`
+	ApplicationNo int                  `json:"application_no"` // Sequential per project
+	PeriodStart   time.Time            `json:"period_start"`
+	PeriodEnd     time.Time            `json:"period_end"`
+	Currency      string               `json:"currency"`
+	Status        PayApplicationStatus `json:"status"`
+
+	// G702 snapshot (cents)
+	ContractSum             int64 `json:"contract_sum"`
+	PreviousWorkCompleted   int64 `json:"previous_work_completed"`
+	CurrentWorkCompleted    int64 `json:"current_work_completed"`
+	StoredMaterials         int64 `json:"stored_materials"`
+	TotalCompletedAndStored int64 `json:"total_completed_and_stored"`
+	TotalRetainage          int64 `json:"total_retainage"`
+	TotalEarned             int64 `json:"total_earned"`
+	PreviousCertificates    int64 `json:"previous_certificates"`
+	CurrentPaymentDue       int64 `json:"current_payment_due"`
+	PriceEscalation         int64 `json:"price_escalation"` // Fiyat farkı for this period, included in total earned
+	AdvanceRecovery         int64 `json:"advance_recovery"` // Avans mahsubu for this period, deducted from total earned
+	Deductions              int64 `json:"deductions"`       // Kesintiler taken from this payment
+	NetPaymentDue           int64 `json:"net_payment_due"`  // Current payment due less deductions
+
+	// Taxes on the current payment due, resolved for the period end
+	Tax *TaxBreakdown `json:"tax,omitempty"`
+
+	// Invoice recorded in the ledger on certification
+	InvoiceTransactionID *uuid.UUID `json:"invoice_transaction_id,omitempty"`
+
+	SubmittedAt     *time.Time `json:"submitted_at,omitempty"`
+	CertifiedAt     *time.Time `json:"certified_at,omitempty"`
+	CertifiedBy     *uuid.UUID `json:"certified_by,omitempty"`
+	RejectionReason string     `json:"rejection_reason,omitempty"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+	CreatedBy uuid.UUID `json:"created_by"`
+}
+
+// NewPayApplication creates a draft pay application for a billing period
+func NewPayApplication(projectID uuid.UUID, applicationNo int, periodStart, periodEnd time.Time, currency string, createdBy uuid.UUID) *PayApplication {
+	now := time.Now()
+	return &PayApplication{
+		ID:            uuid.New(),
+		ProjectID:     projectID,
+		ApplicationNo: applicationNo,
+		PeriodStart:   periodStart,
+		PeriodEnd:     periodEnd,
+		Currency:      currency,
+		Status:        PayApplicationStatusDraft,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+		CreatedBy:     createdBy,
+	}
+}
+
+// InvoiceNo returns the ledger invoice number used for this application
+func (p *PayApplication) InvoiceNo() string {
+	return fmt.Sprintf("HKD-%s-%03d", p.PeriodEnd.Format("2006"), p.ApplicationNo)
+}
+
+// IsCertified returns true once the application has been approved
+func (p *PayApplication) IsCertified() bool {
+	return p.Status == PayApplicationStatusCertified
+}
+
+// IsOpen returns true while the application still awaits a decision
+func (p *PayApplication) IsOpen() bool {
+	return p.Status == PayApplicationStatusDraft || p.Status == PayApplicationStatusSubmitted
+}
+
+// Submit sends a draft application for approval
+func (p *PayApplication) Submit() error {
+	if p.Status != PayApplicationStatusDraft {
+		return ErrPayApplicationNotEditable
+	}
+	now := time.Now()
+	p.Status = PayApplicationStatusSubmitted
+	p.SubmittedAt = &now
+	p.UpdatedAt = now
+	return nil
+}
+
+// Certify approves a submitted application
+func (p *PayApplication) Certify(certifiedBy uuid.UUID) error {
+	if p.Status != PayApplicationStatusSubmitted {
+		return ErrPayApplicationNotSubmitted
+	}
+	now := time.Now()
+	p.Status = PayApplicationStatusCertified
+	p.CertifiedAt = &now
+	p.CertifiedBy = &certifiedBy
+	p.UpdatedAt = now
+	return nil
+}
+
+// Reject returns a submitted application with a reason
+func (p *PayApplication) Reject(reason string) error {
+	if p.Status != PayApplicationStatusSubmitted {
+		return ErrPayApplicationNotSubmitted
+	}
+	p.Status = PayApplicationStatusRejected
+	p.RejectionReason = reason
+	p.UpdatedAt = time.Now()
+	return nil
+}
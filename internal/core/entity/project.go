@@ -33,10 +33,11 @@ type Project struct {
 	Status      ProjectStatus `json:"status"`
 
 	// Contract details
-	ContractAmount   int64     `json:"contract_amount"`   // Amount in cents (BigInt)
-	Currency         string    `json:"currency"`          // ISO 4217 (TRY, USD, EUR)
-	StartDate        time.Time `json:"start_date"`
-	EstimatedEndDate time.Time `json:"estimated_end_date"`
+	ContractAmount            int64      `json:"contract_amount"` // Amount in cents (BigInt)
+	Currency                  string     `json:"currency"`        // ISO 4217 (TRY, USD, EUR)
+	StartDate                 time.Time  `json:"start_date"`
+	EstimatedEndDate          time.Time  `json:"estimated_end_date"`
+	SubstantialCompletionDate *time.Time `json:"substantial_completion_date,omitempty"` // Geçici kabul tarihi
 
 	// Retainage settings
 	LaborRetainageRate    float64 `json:"labor_retainage_rate"`    // e.g., 0.10 for 10%
@@ -88,3 +89,85 @@ func (p *Project) IsActive() bool {
 func (p *Project) CanBeModified() bool {
 	return p.Status == ProjectStatusDraft || p.Status == ProjectStatusActive
 }
+
+// projectStatusTransitions lists the statuses each status may move to
+// Completed and cancelled projects are closed for good
+var projectStatusTransitions = map[ProjectStatus][]ProjectStatus{
+	ProjectStatusDraft:  {ProjectStatusActive, ProjectStatusCancelled},
+	ProjectStatusActive: {ProjectStatusOnHold, ProjectStatusCompleted, ProjectStatusCancelled},
+	ProjectStatusOnHold: {ProjectStatusActive, ProjectStatusCancelled},
+}
+
+// IsValid checks if the project status is known
+func (s ProjectStatus) IsValid() bool {
+	switch s {
+	case ProjectStatusDraft, ProjectStatusActive, ProjectStatusOnHold, ProjectStatusCompleted, ProjectStatusCancelled:
+		return true
+	}
+	return false
+}
+
+// CanTransitionTo returns true if the project may move to the given status
+func (p *Project) CanTransitionTo(to ProjectStatus) bool {
+	for _, next := range projectStatusTransitions[p.Status] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+// TransitionTo moves the project to a new status and returns the history record
+// Business guards (open pay applications, open balance) are checked by the caller
+func (p *Project) TransitionTo(to ProjectStatus, reason string, by uuid.UUID, at time.Time) (*ProjectStatusChange, error) {
+	if !p.CanTransitionTo(to) {
+		return nil, ErrInvalidStatusTransition
+	}
+	change := &ProjectStatusChange{
+		ID:         uuid.New(),
+		ProjectID:  p.ID,
+		FromStatus: p.Status,
+		ToStatus:   to,
+		Reason:     reason,
+		ChangedAt:  at,
+		ChangedBy:  by,
+	}
+	p.Status = to
+	p.UpdatedAt = at
+	return change, nil
+}
+
+// AllowsTransaction returns true if the ledger accepts entries of the given type.
+// Draft and cancelled projects take no entries; on-hold and completed projects
+// may only settle what was already billed.
+func (p *Project) AllowsTransaction(txType TransactionType) bool {
+	switch p.Status {
+	case ProjectStatusActive:
+		return true
+	case ProjectStatusOnHold, ProjectStatusCompleted:
+		return txType == TransactionTypePayment ||
+			txType == TransactionTypeRetainageRelease ||
+			txType == TransactionTypeDeduction
+	}
+	return false
+}
+
+// ProjectStatusChange is one entry of a project's status history
+type ProjectStatusChange struct {
+	ID         uuid.UUID     `json:"id"`
+	ProjectID  uuid.UUID     `json:"project_id"`
+	FromStatus ProjectStatus `json:"from_status"`
+	ToStatus   ProjectStatus `json:"to_status"`
+	Reason     string        `json:"reason,omitempty"`
+	ChangedAt  time.Time     `json:"changed_at"`
+	ChangedBy  uuid.UUID     `json:"changed_by"`
+}
+
+// IsSubstantiallyComplete returns true if the project is completed or reached
+// substantial completion (geçici kabul) on or before asOf
+func (p *Project) IsSubstantiallyComplete(asOf time.Time) bool {
+	if p.Status == ProjectStatusCompleted {
+		return true
+	}
+	return p.SubstantialCompletionDate != nil && !p.SubstantialCompletionDate.After(asOf)
+}
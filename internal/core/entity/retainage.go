@@ -0,0 +1,142 @@
+// Copyright (c) 2026 Muhammet Ali Büyük. All rights reserved.
+// This source code is proprietary. Confidential and private.
+// Unauthorized copying or distribution is strictly prohibited.
+// Contact: iletisim@alibuyuk.net | Website: alibuyuk.net
+
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RetainageReleaseKind distinguishes partial releases from the final one
+type RetainageReleaseKind string
+
+const (
+	RetainageReleasePartial RetainageReleaseKind = "PARTIAL" // Kısmi teminat iadesi
+	RetainageReleaseFinal   RetainageReleaseKind = "FINAL"   // Geçici kabul sonrası kalan teminatın iadesi
+)
+
+// RetainageReleaseStatus represents the approval state of a release request
+type RetainageReleaseStatus string
+
+const (
+	RetainageReleasePending  RetainageReleaseStatus = "PENDING"
+	RetainageReleaseReleased RetainageReleaseStatus = "RELEASED"
+	RetainageReleaseRejected RetainageReleaseStatus = "REJECTED"
+)
+
+// RetainageApproval is one sign-off on a release request
+type RetainageApproval struct {
+	ApprovedBy uuid.UUID `json:"approved_by"`
+	ApprovedAt time.Time `json:"approved_at"`
+	Note       string    `json:"note,omitempty"`
+}
+
+// RetainageInvoice is the release invoice issued when retainage is paid out
+// It is a snapshot of the retainage position at release time
+type RetainageInvoice struct {
+	InvoiceNo          string    `json:"invoice_no"`
+	IssueDate          time.Time `json:"issue_date"`
+	ProjectCode        string    `json:"project_code"`
+	ProjectName        string    `json:"project_name"`
+	Description        string    `json:"description"`
+	TotalHeld          int64     `json:"total_held"`
+	PreviouslyReleased int64     `json:"previously_released"`
+	ThisRelease        int64     `json:"this_release"`
+	Remaining          int64     `json:"remaining"`
+	Currency           string    `json:"currency"`
+}
+
+// RetainageRelease is a request to pay out retained amounts, executed once approved
+type RetainageRelease struct {
+	ID                uuid.UUID              `json:"id"`
+	ProjectID         uuid.UUID              `json:"project_id"`
+	ContractID        *uuid.UUID             `json:"contract_id,omitempty"` // nil releases from the whole project
+	Kind              RetainageReleaseKind   `json:"kind"`
+	Amount            int64                  `json:"amount"` // Cents
+	Currency          string                 `json:"currency"`
+	Reason            string                 `json:"reason"`
+	Status            RetainageReleaseStatus `json:"status"`
+	RequiredApprovals int                    `json:"required_approvals"`
+	Approvals         []RetainageApproval    `json:"approvals"`
+	RejectedBy        *uuid.UUID             `json:"rejected_by,omitempty"`
+	RejectionReason   string                 `json:"rejection_reason,omitempty"`
+	TransactionID     *uuid.UUID             `json:"transaction_id,omitempty"` // RETAINAGE_RELEASE ledger entry
+	Invoice           *RetainageInvoice      `json:"invoice,omitempty"`
+	RequestedAt       time.Time              `json:"requested_at"`
+	RequestedBy       uuid.UUID              `json:"requested_by"`
+	ClosedAt          *time.Time             `json:"closed_at,omitempty"` // Released or rejected
+}
+
+// NewRetainageRelease creates a pending release request
+func NewRetainageRelease(projectID uuid.UUID, kind RetainageReleaseKind, amount int64, currency, reason string, requestedBy uuid.UUID) *RetainageRelease {
+	return &RetainageRelease{
+		ID:          uuid.New(),
+		ProjectID:   projectID,
+		Kind:        kind,
+		Amount:      amount,
+		Currency:    currency,
+		Reason:      reason,
+		Status:      RetainageReleasePending,
+		Approvals:   []RetainageApproval{},
+		RequestedAt: time.Now(),
+		RequestedBy: requestedBy,
+	}
+}
+
+// Validate checks the request before it is stored
+func (r *RetainageRelease) Validate() error {
+	if r.Kind != RetainageReleasePartial && r.Kind != RetainageReleaseFinal {
+		return ErrInvalidRetainageRelease
+	}
+	if r.Amount <= 0 || len(r.Currency) != 3 || r.RequiredApprovals < 0 {
+		return ErrInvalidRetainageRelease
+	}
+	return nil
+}
+
+// Approve records a sign-off; the requester cannot approve their own request
+// and each approver counts once
+func (r *RetainageRelease) Approve(by uuid.UUID, note string, at time.Time) error {
+	if r.Status != RetainageReleasePending {
+		return ErrRetainageReleaseNotPending
+	}
+	if by == r.RequestedBy {
+		return ErrRetainageSelfApproval
+	}
+	for _, a := range r.Approvals {
+		if a.ApprovedBy == by {
+			return ErrRetainageDuplicateApproval
+		}
+	}
+	r.Approvals = append(r.Approvals, RetainageApproval{ApprovedBy: by, ApprovedAt: at, Note: note})
+	return nil
+}
+
+// IsApproved returns true once enough approvals have been collected
+func (r *RetainageRelease) IsApproved() bool {
+	return len(r.Approvals) >= r.RequiredApprovals
+}
+
+// Reject closes a pending request without releasing anything
+func (r *RetainageRelease) Reject(by uuid.UUID, reason string, at time.Time) error {
+	if r.Status != RetainageReleasePending {
+		return ErrRetainageReleaseNotPending
+	}
+	r.Status = RetainageReleaseRejected
+	r.RejectedBy = &by
+	r.RejectionReason = reason
+	r.ClosedAt = &at
+	return nil
+}
+
+// MarkReleased records the ledger entry and invoice of an executed release
+func (r *RetainageRelease) MarkReleased(transactionID uuid.UUID, invoice *RetainageInvoice, at time.Time) {
+	r.Status = RetainageReleaseReleased
+	r.TransactionID = &transactionID
+	r.Invoice = invoice
+	r.ClosedAt = &at
+}
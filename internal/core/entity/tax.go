@@ -0,0 +1,164 @@
+// Copyright (c) 2026 Muhammet Ali Büyük. All rights reserved.
+// This source code is proprietary. Confidential and private.
+// Unauthorized copying or distribution is strictly prohibited.
+// Contact: iletisim@alibuyuk.net | Website: alibuyuk.net
+
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TaxKind identifies which tax a rate belongs to
+type TaxKind string
+
+const (
+	TaxKindKDV         TaxKind = "KDV"         // Katma değer vergisi
+	TaxKindWithholding TaxKind = "WITHHOLDING" // KDV tevkifatı, rate is the share of KDV withheld
+	TaxKindStampDuty   TaxKind = "STAMP_DUTY"  // Damga vergisi
+)
+
+// TaxRateScale is the denominator of tax rates
+// Rates are parts per million so per-mille stamp duty (binde 9,48) stays exact
+const TaxRateScale int64 = 1000000
+
+// Default rate codes
+const (
+	KDVCodeGeneral        = "GENEL"
+	KDVCodeReduced        = "INDIRIMLI"
+	StampDutyCodeContract = "SOZLESME"
+)
+
+// TaxRate is one row of a tenant's rate table
+// A rate applies from ValidFrom until ValidTo inclusive; an open ValidTo means still in force
+type TaxRate struct {
+	ID        uuid.UUID  `json:"id"`
+	TenantID  uuid.UUID  `json:"tenant_id"` // uuid.Nil for the statutory defaults
+	Kind      TaxKind    `json:"kind"`
+	Code      string     `json:"code"` // GENEL, tevkifat code such as 601, ...
+	Name      string     `json:"name"`
+	Rate      int64      `json:"rate"` // Parts per million (200000 = 20%, 9480 = binde 9,48)
+	ValidFrom time.Time  `json:"valid_from"`
+	ValidTo   *time.Time `json:"valid_to,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// NewTaxRate creates a rate that is in force from the given date
+func NewTaxRate(tenantID uuid.UUID, kind TaxKind, code, name string, rate int64, validFrom time.Time) *TaxRate {
+	return &TaxRate{
+		ID:        uuid.New(),
+		TenantID:  tenantID,
+		Kind:      kind,
+		Code:      code,
+		Name:      name,
+		Rate:      rate,
+		ValidFrom: truncateDay(validFrom),
+		CreatedAt: time.Now(),
+	}
+}
+
+// Validate checks the rate is usable
+func (r *TaxRate) Validate() error {
+	switch r.Kind {
+	case TaxKindKDV, TaxKindWithholding, TaxKindStampDuty:
+	default:
+		return ErrInvalidTaxRate
+	}
+	if r.Code == "" || r.Rate < 0 || r.Rate > TaxRateScale || r.ValidFrom.IsZero() {
+		return ErrInvalidTaxRate
+	}
+	if r.ValidTo != nil && r.ValidTo.Before(r.ValidFrom) {
+		return ErrInvalidTaxRate
+	}
+	return nil
+}
+
+// AppliesOn reports whether the rate is in force on the given day
+func (r *TaxRate) AppliesOn(date time.Time) bool {
+	day := truncateDay(date)
+	if day.Before(r.ValidFrom) {
+		return false
+	}
+	return r.ValidTo == nil || !day.After(*r.ValidTo)
+}
+
+// Overlaps reports whether two rates for the same tax are in force on a common day
+func (r *TaxRate) Overlaps(other *TaxRate) bool {
+	if r.Kind != other.Kind || r.Code != other.Code {
+		return false
+	}
+	startsBeforeOtherEnds := other.ValidTo == nil || !r.ValidFrom.After(*other.ValidTo)
+	otherStartsBeforeEnd := r.ValidTo == nil || !other.ValidFrom.After(*r.ValidTo)
+	return startsBeforeOtherEnds && otherStartsBeforeEnd
+}
+
+// BasisPoints returns the rate in basis points, as used on invoice lines
+func (r *TaxRate) BasisPoints() int64 {
+	return r.Rate / (TaxRateScale / 10000)
+}
+
+// Apply computes the tax on an amount with half-up rounding to the cent
+func (r *TaxRate) Apply(amount int64) int64 {
+	if amount <= 0 || r.Rate <= 0 {
+		return 0
+	}
+	return (amount*r.Rate + TaxRateScale/2) / TaxRateScale
+}
+
+// TaxBreakdown is the full tax computation for a billed amount
+// It is stored with pay applications and drives invoice lines and reports
+type TaxBreakdown struct {
+	Date time.Time `json:"date"` // Rates were resolved for this day
+	Base int64     `json:"base"` // Cents, KDV matrahı
+
+	KDVCode   string `json:"kdv_code"`
+	KDVRate   int64  `json:"kdv_rate"` // Parts per million
+	KDVAmount int64  `json:"kdv_amount"`
+
+	WithholdingCode   string `json:"withholding_code,omitempty"`
+	WithholdingName   string `json:"withholding_name,omitempty"`
+	WithholdingRate   int64  `json:"withholding_rate,omitempty"` // Share of KDV, parts per million
+	WithholdingAmount int64  `json:"withholding_amount"`
+
+	StampDutyRate   int64 `json:"stamp_duty_rate,omitempty"` // Parts per million
+	StampDutyAmount int64 `json:"stamp_duty_amount"`
+
+	GrossAmount int64 `json:"gross_amount"` // Base + KDV, the invoice total
+	NetPayable  int64 `json:"net_payable"`  // Gross - withholding - stamp duty, what is actually paid
+}
+
+// KDVCollected returns the KDV left for the contractor to declare after withholding
+func (b *TaxBreakdown) KDVCollected() int64 {
+	return b.KDVAmount - b.WithholdingAmount
+}
+
+// DefaultTaxRates returns the statutory rate table used when a tenant has not defined its own
+func DefaultTaxRates() []*TaxRate {
+	kdvChange := time.Date(2023, 7, 10, 0, 0, 0, 0, time.UTC)
+	beforeChange := kdvChange.AddDate(0, 0, -1)
+	epoch := time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	general18 := NewTaxRate(uuid.Nil, TaxKindKDV, KDVCodeGeneral, "Genel oran", 180000, epoch)
+	general18.ValidTo = &beforeChange
+	reduced8 := NewTaxRate(uuid.Nil, TaxKindKDV, KDVCodeReduced, "İndirimli oran", 80000, epoch)
+	reduced8.ValidTo = &beforeChange
+
+	rates := []*TaxRate{
+		general18,
+		NewTaxRate(uuid.Nil, TaxKindKDV, KDVCodeGeneral, "Genel oran", 200000, kdvChange),
+		reduced8,
+		NewTaxRate(uuid.Nil, TaxKindKDV, KDVCodeReduced, "İndirimli oran", 100000, kdvChange),
+		NewTaxRate(uuid.Nil, TaxKindStampDuty, StampDutyCodeContract, "Damga vergisi (binde 9,48)", 9480, epoch),
+	}
+	for _, code := range WithholdingCodes {
+		rates = append(rates, NewTaxRate(uuid.Nil, TaxKindWithholding, code.Code, code.Name, code.Rate*(TaxRateScale/10000), epoch))
+	}
+	return rates
+}
+
+func truncateDay(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
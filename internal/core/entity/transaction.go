@@ -23,6 +23,8 @@ const (
 	TransactionTypeRetainageRelease TransactionType = "RETAINAGE_RELEASE" // Teminat serbest bırakıldı
 	TransactionTypeAdjustment       TransactionType = "ADJUSTMENT"        // Düzeltme
 	TransactionTypeDeduction        TransactionType = "DEDUCTION"         // Kesinti
+	TransactionTypeAdvancePayment   TransactionType = "ADVANCE_PAYMENT"   // Avans ödendi
+	TransactionTypeAdvanceRecovery  TransactionType = "ADVANCE_RECOVERY"  // Avans mahsubu
 )
 
 // Transaction represents an immutable financial event in the ledger
@@ -44,12 +46,14 @@ type Transaction struct {
 
 // TransactionMetadata contains additional context for transactions
 type TransactionMetadata struct {
-	BankReceiptNo     string `json:"bank_receipt_no,omitempty"`
-	InvoiceNo         string `json:"invoice_no,omitempty"`
-	ApplicationPeriod string `json:"application_period,omitempty"` // e.g., "2026-01"
-	Notes             string `json:"notes,omitempty"`
-	VendorName        string `json:"vendor_name,omitempty"`
-	RetainageRate     string `json:"retainage_rate,omitempty"`
+	BankReceiptNo      string `json:"bank_receipt_no,omitempty"`
+	InvoiceNo          string `json:"invoice_no,omitempty"`
+	ApplicationPeriod  string `json:"application_period,omitempty"` // e.g., "2026-01"
+	Notes              string `json:"notes,omitempty"`
+	VendorName         string `json:"vendor_name,omitempty"`
+	RetainageRate      string `json:"retainage_rate,omitempty"`
+	DeductionCategory  string `json:"deduction_category,omitempty"`
+	ComplianceOverride string `json:"compliance_override,omitempty"` // Reason a non-compliant payment was allowed
 }
 
 // NewTransaction creates a new transaction for the ledger
@@ -86,7 +90,9 @@ func (tt TransactionType) IsValid() bool {
 		TransactionTypeRetainageHeld,
 		TransactionTypeRetainageRelease,
 		TransactionTypeAdjustment,
-		TransactionTypeDeduction:
+		TransactionTypeDeduction,
+		TransactionTypeAdvancePayment,
+		TransactionTypeAdvanceRecovery:
 		return true
 	}
 	return false
@@ -94,7 +100,7 @@ func (tt TransactionType) IsValid() bool {
 
 // IsCredit returns true if transaction represents money coming in
 func (t *Transaction) IsCredit() bool {
-	return t.Type == TransactionTypePayment || t.Type == TransactionTypeRetainageRelease
+	return t.Type == TransactionTypePayment || t.Type == TransactionTypeRetainageRelease || t.Type == TransactionTypeAdvancePayment
 }
 
 // IsDebit returns true if transaction represents money going out
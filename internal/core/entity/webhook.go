@@ -0,0 +1,151 @@
+// Copyright (c) 2026 Muhammet Ali Büyük. All rights reserved.
+// This source code is proprietary. Confidential and private.
+// Unauthorized copying or distribution is strictly prohibited.
+// Contact: iletisim@alibuyuk.net | Website: alibuyuk.net
+
+package entity
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"net"
+	"net/url"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// WebhookEndpoint is a tenant's URL subscribed to project events
+type WebhookEndpoint struct {
+	ID          uuid.UUID   `json:"id"`
+	TenantID    uuid.UUID   `json:"tenant_id"`
+	URL         string      `json:"url"`
+	Description string      `json:"description,omitempty"`
+	Events      []EventType `json:"events"`
+	Secret      string      `json:"-"` // HMAC key, shown once when the endpoint is created
+	Active      bool        `json:"active"`
+	CreatedAt   time.Time   `json:"created_at"`
+	CreatedBy   uuid.UUID   `json:"created_by"`
+	UpdatedAt   time.Time   `json:"updated_at"`
+}
+
+// NewWebhookEndpoint creates an active endpoint with a random signing secret
+func NewWebhookEndpoint(tenantID uuid.UUID, rawURL string, events []EventType, createdBy uuid.UUID) (*WebhookEndpoint, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	return &WebhookEndpoint{
+		ID:        uuid.New(),
+		TenantID:  tenantID,
+		URL:       rawURL,
+		Events:    events,
+		Secret:    "whsec_" + hex.EncodeToString(secret),
+		Active:    true,
+		CreatedAt: now,
+		CreatedBy: createdBy,
+		UpdatedAt: now,
+	}, nil
+}
+
+// Validate checks the URL and the subscribed events
+// Where the host resolves to is checked by the webhook service, at registration and at delivery.
+func (e *WebhookEndpoint) Validate() error {
+	u, err := url.Parse(e.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return ErrInvalidWebhookEndpoint
+	}
+	if e.TenantID == uuid.Nil || len(e.Events) == 0 {
+		return ErrInvalidWebhookEndpoint
+	}
+	for _, t := range e.Events {
+		if !t.IsValid() {
+			return ErrInvalidWebhookEndpoint
+		}
+	}
+	return nil
+}
+
+// WebhookAddressAllowed reports whether webhooks may be delivered to the address
+// Loopback, private, link-local, unspecified and multicast addresses lead into
+// the platform's own network rather than to a tenant's receiver.
+func WebhookAddressAllowed(ip net.IP) bool {
+	return !(ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() ||
+		ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsMulticast())
+}
+
+// Subscribes returns true when the endpoint is active and wants the event type
+func (e *WebhookEndpoint) Subscribes(t EventType) bool {
+	if !e.Active {
+		return false
+	}
+	for _, subscribed := range e.Events {
+		if subscribed == t {
+			return true
+		}
+	}
+	return false
+}
+
+// WebhookDeliveryStatus represents the state of a webhook delivery
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryPending   WebhookDeliveryStatus = "PENDING"   // Waiting for its next attempt
+	WebhookDeliverySucceeded WebhookDeliveryStatus = "SUCCEEDED" // Receiver answered 2xx
+	WebhookDeliveryFailed    WebhookDeliveryStatus = "FAILED"    // Attempts exhausted
+)
+
+// WebhookDelivery is one event sent to one endpoint, with the outcome of its last attempt
+type WebhookDelivery struct {
+	ID             uuid.UUID             `json:"id"`
+	EndpointID     uuid.UUID             `json:"endpoint_id"`
+	EventID        uuid.UUID             `json:"event_id"`
+	EventType      EventType             `json:"event_type"`
+	Payload        []byte                `json:"-"` // Exact body sent, so a replay is byte for byte the same
+	Status         WebhookDeliveryStatus `json:"status"`
+	Attempts       int                   `json:"attempts"`
+	NextAttemptAt  *time.Time            `json:"next_attempt_at,omitempty"`
+	LastStatusCode int                   `json:"last_status_code,omitempty"`
+	LastError      string                `json:"last_error,omitempty"`
+	ReplayOf       *uuid.UUID            `json:"replay_of,omitempty"` // Delivery this one replays
+	CreatedAt      time.Time             `json:"created_at"`
+	DeliveredAt    *time.Time            `json:"delivered_at,omitempty"`
+}
+
+// NewWebhookDelivery creates a pending delivery due at the given time
+func NewWebhookDelivery(endpointID uuid.UUID, eventID uuid.UUID, eventType EventType, payload []byte, due time.Time) *WebhookDelivery {
+	return &WebhookDelivery{
+		ID:            uuid.New(),
+		EndpointID:    endpointID,
+		EventID:       eventID,
+		EventType:     eventType,
+		Payload:       payload,
+		Status:        WebhookDeliveryPending,
+		NextAttemptAt: &due,
+		CreatedAt:     time.Now(),
+	}
+}
+
+// MarkSucceeded records a 2xx answer
+func (d *WebhookDelivery) MarkSucceeded(statusCode int, at time.Time) {
+	d.Attempts++
+	d.Status = WebhookDeliverySucceeded
+	d.LastStatusCode = statusCode
+	d.LastError = ""
+	d.NextAttemptAt = nil
+	d.DeliveredAt = &at
+}
+
+// MarkAttemptFailed records a failed attempt; without a next attempt the delivery has failed
+func (d *WebhookDelivery) MarkAttemptFailed(statusCode int, reason string, next *time.Time) {
+	d.Attempts++
+	d.LastStatusCode = statusCode
+	d.LastError = reason
+	d.NextAttemptAt = next
+	if next == nil {
+		d.Status = WebhookDeliveryFailed
+	}
+}
@@ -0,0 +1,143 @@
+// Copyright (c) 2026 Muhammet Ali Büyük. All rights reserved.
+// This source code is proprietary. Confidential and private.
+// Unauthorized copying or distribution is strictly prohibited.
+// Contact: iletisim@alibuyuk.net | Website: alibuyuk.net
+
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/qantesm/subflow/internal/core/entity"
+)
+
+// AdvanceRepository is the port for advance recovery rules
+type AdvanceRepository interface {
+	SaveRule(ctx context.Context, rule *entity.AdvanceRecoveryRule) error // Replaces the project's rule
+	FindRule(ctx context.Context, projectID uuid.UUID) (*entity.AdvanceRecoveryRule, error)
+}
+
+// AdvanceReport shows how much of a project's advance is still to be recovered
+type AdvanceReport struct {
+	ProjectID        uuid.UUID                   `json:"project_id"`
+	Currency         string                      `json:"currency"`
+	Advanced         int64                       `json:"advanced"`          // Sum of advance payments
+	Recovered        int64                       `json:"recovered"`         // Deducted on certified applications
+	Outstanding      int64                       `json:"outstanding"`       // Advanced - Recovered
+	PercentRecovered int64                       `json:"percent_recovered"` // Basis points
+	Rule             *entity.AdvanceRecoveryRule `json:"rule,omitempty"`
+	Payments         []*entity.Transaction       `json:"payments"`
+	Recoveries       []*entity.Transaction       `json:"recoveries"`
+}
+
+// AdvanceService issues advance payments (avans) and tracks their recovery
+type AdvanceService struct {
+	repo      AdvanceRepository
+	ledger    *LedgerService
+	audit     Auditor // Optional: records recovery rule changes in the audit trail
+	architect string
+}
+
+// NewAdvanceService creates a new advance payment service
+func NewAdvanceService(repo AdvanceRepository, ledger *LedgerService) *AdvanceService {
+	return &AdvanceService{
+		repo:      repo,
+		ledger:    ledger,
+		architect: "Muhammet-Ali-Buyuk",
+	}
+}
+
+// SetAuditor records every recovery rule change in the audit trail
+// Advance payments are recorded by the ledger like any other entry.
+func (s *AdvanceService) SetAuditor(audit Auditor) {
+	s.audit = audit
+}
+
+// Issue records an advance payment in the ledger
+func (s *AdvanceService) Issue(ctx context.Context, projectID uuid.UUID, amountCents int64, currency, referenceNo string, effectiveDate time.Time, createdBy uuid.UUID) (*entity.Transaction, error) {
+	return s.ledger.RecordAdvancePayment(ctx, projectID, amountCents, currency, referenceNo, effectiveDate, createdBy)
+}
+
+// SetRule validates and stores the recovery rule of a project
+func (s *AdvanceService) SetRule(ctx context.Context, rule *entity.AdvanceRecoveryRule) error {
+	if err := rule.Validate(); err != nil {
+		return err
+	}
+
+	var before any
+	previous, err := s.repo.FindRule(ctx, rule.ProjectID)
+	switch {
+	case err == nil:
+		before = previous
+	case !errors.Is(err, entity.ErrAdvanceRuleNotFound):
+		return err
+	}
+	if err := s.repo.SaveRule(ctx, rule); err != nil {
+		return err
+	}
+	recordAudit(ctx, s.audit, entity.AuditActionAdvanceRuleSet, "advance_rule", rule.ProjectID, rule.ProjectID, before, rule)
+	return nil
+}
+
+// Rule returns the recovery rule of a project
+func (s *AdvanceService) Rule(ctx context.Context, projectID uuid.UUID) (*entity.AdvanceRecoveryRule, error) {
+	return s.repo.FindRule(ctx, projectID)
+}
+
+// BillingTerms returns the advance paid to date and the rule to apply on a pay application
+// Projects without a rule recover nothing, so the rule is nil and the calculator skips recovery.
+func (s *AdvanceService) BillingTerms(ctx context.Context, projectID uuid.UUID) (int64, *entity.AdvanceRecoveryRule, error) {
+	rule, err := s.repo.FindRule(ctx, projectID)
+	if err == entity.ErrAdvanceRuleNotFound {
+		return 0, nil, nil
+	}
+	if err != nil {
+		return 0, nil, err
+	}
+
+	report, err := s.Report(ctx, projectID)
+	if err != nil {
+		return 0, nil, err
+	}
+	return report.Advanced, rule, nil
+}
+
+// Report summarises advances paid and recovered from the ledger
+func (s *AdvanceService) Report(ctx context.Context, projectID uuid.UUID) (*AdvanceReport, error) {
+	transactions, err := s.ledger.GetTransactionHistory(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &AdvanceReport{
+		ProjectID:  projectID,
+		Currency:   "TRY",
+		Payments:   []*entity.Transaction{},
+		Recoveries: []*entity.Transaction{},
+	}
+	for _, tx := range transactions {
+		switch tx.Type {
+		case entity.TransactionTypeAdvancePayment:
+			report.Advanced += tx.AmountCents
+			report.Currency = tx.Currency
+			report.Payments = append(report.Payments, tx)
+		case entity.TransactionTypeAdvanceRecovery:
+			report.Recovered += tx.AmountCents
+			report.Recoveries = append(report.Recoveries, tx)
+		}
+	}
+	report.Outstanding = report.Advanced - report.Recovered
+	if report.Advanced > 0 {
+		report.PercentRecovered = report.Recovered * 10000 / report.Advanced
+	}
+
+	rule, err := s.repo.FindRule(ctx, projectID)
+	if err != nil && err != entity.ErrAdvanceRuleNotFound {
+		return nil, err
+	}
+	report.Rule = rule
+	return report, nil
+}
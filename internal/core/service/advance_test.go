@@ -0,0 +1,206 @@
+// Copyright (c) 2026 Muhammet Ali Büyük. All rights reserved.
+// This source code is proprietary. Confidential and private.
+// Unauthorized copying or distribution is strictly prohibited.
+// Contact: iletisim@alibuyuk.net | Website: alibuyuk.net
+
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/qantesm/subflow/internal/core/entity"
+)
+
+type stubAdvanceRepository struct {
+	rules map[uuid.UUID]*entity.AdvanceRecoveryRule
+}
+
+func (r *stubAdvanceRepository) SaveRule(ctx context.Context, rule *entity.AdvanceRecoveryRule) error {
+	if r.rules == nil {
+		r.rules = make(map[uuid.UUID]*entity.AdvanceRecoveryRule)
+	}
+	r.rules[rule.ProjectID] = rule
+	return nil
+}
+
+func (r *stubAdvanceRepository) FindRule(ctx context.Context, projectID uuid.UUID) (*entity.AdvanceRecoveryRule, error) {
+	rule, ok := r.rules[projectID]
+	if !ok {
+		return nil, entity.ErrAdvanceRuleNotFound
+	}
+	return rule, nil
+}
+
+func TestAdvanceRecoveredToDate(t *testing.T) {
+	percentage := &entity.AdvanceRecoveryRule{Method: entity.AdvanceRecoveryPercentage, Rate: 1000}
+	completion := &entity.AdvanceRecoveryRule{Method: entity.AdvanceRecoveryCompletionRange, StartPercent: 2000, EndPercent: 8000}
+
+	tests := []struct {
+		name string
+		rule *entity.AdvanceRecoveryRule
+		work int64
+		want int64
+	}{
+		{"10% of work", percentage, 30000000, 3000000},
+		{"capped at the advance", percentage, 200000000, 10000000},
+		{"before the range", completion, 20000000, 0},
+		{"half way through the range", completion, 50000000, 5000000},
+		{"rounded down", completion, 20000059, 9},
+		{"past the range", completion, 90000000, 10000000},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// 100.000,00 advance on a 1.000.000,00 contract
+			if got := tt.rule.RecoveredToDate(10000000, 100000000, tt.work); got != tt.want {
+				t.Errorf("RecoveredToDate() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+
+	for _, invalid := range []*entity.AdvanceRecoveryRule{
+		{Method: entity.AdvanceRecoveryPercentage},
+		{Method: entity.AdvanceRecoveryCompletionRange, StartPercent: 5000, EndPercent: 5000},
+		{Method: "LUMP_SUM", Rate: 1000},
+	} {
+		if err := invalid.Validate(); !errors.Is(err, entity.ErrInvalidAdvanceRule) {
+			t.Errorf("Validate(%+v) = %v", invalid, err)
+		}
+	}
+}
+
+func TestPayApplicationRecoversAdvance(t *testing.T) {
+	ctx := context.Background()
+	projectID := uuid.New()
+	txRepo := &stubTransactionRepository{}
+	ledger := NewLedgerService(txRepo)
+	advances := NewAdvanceService(&stubAdvanceRepository{}, ledger)
+	payApps := NewPayApplicationService(&stubPayApplicationRepository{}, NewCalculator(), NewTaxEngine(&stubTaxRateRepository{}),
+		newTestEscalationService(), advances, newTestDeductionService(ledger), ledger)
+
+	if _, err := advances.Issue(ctx, projectID, 10000000, "TRY", "AVANS-1", time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC), uuid.New()); err != nil {
+		t.Fatalf("Issue() error = %v", err)
+	}
+	rule := entity.NewAdvanceRecoveryRule(projectID, entity.AdvanceRecoveryPercentage, uuid.New())
+	rule.Rate = 2000
+	if err := advances.SetRule(ctx, rule); err != nil {
+		t.Fatalf("SetRule() error = %v", err)
+	}
+
+	certify := func(app *entity.PayApplication) {
+		t.Helper()
+		if _, err := payApps.Submit(ctx, app.ID); err != nil {
+			t.Fatalf("Submit() error = %v", err)
+		}
+		if _, err := payApps.Certify(ctx, app.ID, uuid.New()); err != nil {
+			t.Fatalf("Certify() error = %v", err)
+		}
+	}
+
+	// 200.000,00 of work recovers 20% = 40.000,00
+	first, err := payApps.Create(ctx, uuid.Nil, projectID, PayApplicationRequest{
+		PeriodEnd: time.Date(2026, 1, 31, 0, 0, 0, 0, time.UTC),
+		Currency:  "TRY",
+		Billing:   AIABillingInput{OriginalContractSum: 100000000, CurrentWorkCompleted: 20000000},
+	}, uuid.New())
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if first.AdvanceRecovery != 4000000 || first.CurrentPaymentDue != 16000000 {
+		t.Errorf("first = recovery %d due %d", first.AdvanceRecovery, first.CurrentPaymentDue)
+	}
+	certify(first)
+
+	report, err := advances.Report(ctx, projectID)
+	if err != nil {
+		t.Fatalf("Report() error = %v", err)
+	}
+	if report.Advanced != 10000000 || report.Recovered != 4000000 || report.Outstanding != 6000000 || report.PercentRecovered != 4000 {
+		t.Errorf("report after first = %+v", report)
+	}
+
+	// 20% of 600.000,00 to date would be 120.000,00, so only the remaining 60.000,00 is recovered
+	second, err := payApps.Create(ctx, uuid.Nil, projectID, PayApplicationRequest{
+		PeriodEnd: time.Date(2026, 2, 28, 0, 0, 0, 0, time.UTC),
+		Currency:  "TRY",
+		Billing:   AIABillingInput{OriginalContractSum: 100000000, PreviousWorkCompleted: 20000000, CurrentWorkCompleted: 40000000, PreviousCertificates: first.TotalEarned},
+	}, uuid.New())
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if second.AdvanceRecovery != 6000000 || second.CurrentPaymentDue != 34000000 {
+		t.Errorf("second = recovery %d due %d", second.AdvanceRecovery, second.CurrentPaymentDue)
+	}
+	certify(second)
+
+	summary, _ := ledger.GetProjectFinancialsIn(ctx, projectID, "TRY")
+	if summary.AdvanceOutstanding != 0 {
+		t.Errorf("AdvanceOutstanding = %d, want 0", summary.AdvanceOutstanding)
+	}
+	// 600.000,00 of work, fully recovered advance, nothing paid yet
+	if balance := ledger.CalculateBalance(txRepo.transactions); balance != 60000000-10000000 {
+		t.Errorf("ledger balance = %d, want 50000000", balance)
+	}
+}
+
+func TestPayApplicationStepsRunInUnitsOfWork(t *testing.T) {
+	ctx := context.Background()
+	projects := &stubProjectRepository{}
+	project := entity.NewProject(uuid.New(), "Çiğli Depo", "PRJ-2026-029")
+	project.Status = entity.ProjectStatusActive
+	_ = projects.Create(ctx, project)
+
+	txRepo := &stubTransactionRepository{}
+	ledger := NewLedgerService(txRepo)
+	ledger.SetProjectRepository(projects)
+	appRepo := &stubPayApplicationRepository{}
+	payApps := NewPayApplicationService(appRepo, NewCalculator(), NewTaxEngine(&stubTaxRateRepository{}),
+		newTestEscalationService(), NewAdvanceService(&stubAdvanceRepository{}, ledger), newTestDeductionService(ledger), ledger)
+	transactor := &stubTransactor{}
+	payApps.SetTransactor(transactor)
+	payApps.SetProjectRepository(projects)
+
+	// Numbering continues after the highest number, not the count of applications
+	appRepo.apps = append(appRepo.apps, entity.NewPayApplication(project.ID, 3, day(2026, 1, 1), day(2026, 1, 31), "TRY", uuid.New()))
+	app, err := payApps.Create(ctx, uuid.Nil, project.ID, PayApplicationRequest{
+		PeriodEnd: day(2026, 2, 28),
+		Currency:  "TRY",
+		Billing:   AIABillingInput{OriginalContractSum: 100000000, CurrentWorkCompleted: 20000000},
+	}, uuid.New())
+	if err != nil || app.ApplicationNo != 4 {
+		t.Fatalf("Create() = %+v, %v, want application 4", app, err)
+	}
+	if _, err := payApps.Create(ctx, uuid.Nil, uuid.New(), PayApplicationRequest{Currency: "TRY"}, uuid.New()); !errors.Is(err, entity.ErrProjectNotFound) {
+		t.Errorf("Create() on unknown project error = %v", err)
+	}
+
+	if _, err := payApps.Submit(ctx, app.ID); err != nil {
+		t.Fatalf("Submit() error = %v", err)
+	}
+	// A project on hold takes no invoices, so the certification fails as a whole
+	project.Status = entity.ProjectStatusOnHold
+	if _, err := payApps.Certify(ctx, app.ID, uuid.New()); !errors.Is(err, entity.ErrProjectNotModifiable) {
+		t.Fatalf("Certify() error = %v, want ErrProjectNotModifiable", err)
+	}
+	if len(txRepo.transactions) != 0 {
+		t.Errorf("ledger size = %d, want 0", len(txRepo.transactions))
+	}
+	// Nor does it take new applications, which could never be certified
+	if _, err := payApps.Create(ctx, uuid.Nil, project.ID, PayApplicationRequest{PeriodEnd: day(2026, 3, 31), Currency: "TRY"}, uuid.New()); !errors.Is(err, entity.ErrProjectNotModifiable) {
+		t.Errorf("Create() on a project on hold error = %v, want ErrProjectNotModifiable", err)
+	}
+
+	project.Status = entity.ProjectStatusActive
+	app.Status = entity.PayApplicationStatusSubmitted // The stub keeps the in-memory change of the failed unit
+	certified, err := payApps.Certify(ctx, app.ID, uuid.New())
+	if err != nil || certified.InvoiceTransactionID == nil {
+		t.Fatalf("Certify() = %+v, %v", certified, err)
+	}
+	if transactor.units != 5 {
+		t.Errorf("units of work = %d, want one per Create and Certify", transactor.units)
+	}
+}
@@ -0,0 +1,192 @@
+// Copyright (c) 2026 Muhammet Ali Büyük. All rights reserved.
+// This source code is proprietary. Confidential and private.
+// Unauthorized copying or distribution is strictly prohibited.
+// Contact: iletisim@alibuyuk.net | Website: alibuyuk.net
+
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/qantesm/subflow/internal/core/entity"
+	"github.com/qantesm/subflow/internal/pkg"
+)
+
+const (
+	DefaultAuditPageSize = 100
+	MaxAuditPageSize     = 1000
+	MaxAuditExportRows   = 100000 // Rows in one export; narrow the date range for more
+)
+
+// AuditActor is who performs an operation and from where.
+// The audit middleware puts it in every request context.
+type AuditActor struct {
+	TenantID  uuid.UUID
+	ActorID   *uuid.UUID // Nil until requests are authenticated
+	Email     string
+	IPAddress string
+	UserAgent string
+}
+
+type auditContextKey string
+
+// AuditActorKey is the context key of the AuditActor; Fiber middleware sets it with c.Locals
+const AuditActorKey auditContextKey = "auditActor"
+
+// WithAuditActor returns a context carrying the actor, for callers outside HTTP requests
+func WithAuditActor(ctx context.Context, actor AuditActor) context.Context {
+	return context.WithValue(ctx, AuditActorKey, actor)
+}
+
+// AuditActorFrom returns the actor carried by the context
+func AuditActorFrom(ctx context.Context) (AuditActor, bool) {
+	actor, ok := ctx.Value(AuditActorKey).(AuditActor)
+	return actor, ok
+}
+
+// AuditFilter narrows an audit log query; TenantID is required
+type AuditFilter struct {
+	TenantID   uuid.UUID
+	EntityType string
+	EntityID   *uuid.UUID
+	ActorID    *uuid.UUID
+	Action     string
+	From       *time.Time // Inclusive
+	To         *time.Time // Exclusive
+	Limit      int
+	Offset     int
+}
+
+// AuditRepository is the port for audit log persistence; the log is append-only
+type AuditRepository interface {
+	Save(ctx context.Context, log *entity.AuditLog) error
+	Find(ctx context.Context, filter AuditFilter) ([]*entity.AuditLog, error) // Newest first
+}
+
+// Auditor records domain operations with before and after snapshots; AuditService implements it
+type Auditor interface {
+	Record(ctx context.Context, action, entityType string, entityID, projectID uuid.UUID, before, after any) error
+}
+
+// recordAudit records an operation when an auditor is set. The operation is
+// already stored, so an audit failure is not the caller's error.
+func recordAudit(ctx context.Context, audit Auditor, action, entityType string, entityID, projectID uuid.UUID, before, after any) {
+	if audit == nil {
+		return
+	}
+	if err := audit.Record(ctx, action, entityType, entityID, projectID, before, after); err != nil {
+		pkg.Ctx(ctx).Warn().Err(err).Str("action", action).Str("entity_id", entityID.String()).Msg("audit record failed")
+	}
+}
+
+// tenantAuditContext carries the tenant for operations on tenant-level
+// entities, which have no project to resolve it from outside a request
+func tenantAuditContext(ctx context.Context, tenantID uuid.UUID) context.Context {
+	actor, _ := AuditActorFrom(ctx)
+	if actor.TenantID != uuid.Nil {
+		return ctx
+	}
+	actor.TenantID = tenantID
+	return WithAuditActor(ctx, actor)
+}
+
+// AuditService writes and queries the audit trail
+type AuditService struct {
+	repo      AuditRepository
+	projects  ProjectRepository
+	architect string
+}
+
+// NewAuditService creates a new audit service.
+// Projects resolve the tenant of operations run without an actor, such as scheduled jobs.
+func NewAuditService(repo AuditRepository, projects ProjectRepository) *AuditService {
+	return &AuditService{
+		repo:      repo,
+		projects:  projects,
+		architect: "Muhammet-Ali-Buyuk",
+	}
+}
+
+// Log stores an audit entry, completing the actor fields from the context
+func (s *AuditService) Log(ctx context.Context, log *entity.AuditLog) error {
+	if actor, ok := AuditActorFrom(ctx); ok {
+		if log.TenantID == uuid.Nil {
+			log.TenantID = actor.TenantID
+		}
+		if log.ActorID == nil {
+			log.ActorID = actor.ActorID
+		}
+		if log.ActorEmail == "" {
+			log.ActorEmail = actor.Email
+		}
+		if log.IPAddress == "" {
+			log.IPAddress = actor.IPAddress
+		}
+		if log.UserAgent == "" {
+			log.UserAgent = actor.UserAgent
+		}
+	}
+	if log.TenantID == uuid.Nil {
+		return entity.ErrAuditTenantRequired
+	}
+	return s.repo.Save(ctx, log)
+}
+
+// Record stores a domain operation on an entity of a project with its before and after state
+func (s *AuditService) Record(ctx context.Context, action, entityType string, entityID, projectID uuid.UUID, before, after any) error {
+	log := entity.NewAuditLog(uuid.Nil, action, entityType, &entityID)
+	if err := log.SetSnapshots(before, after); err != nil {
+		return err
+	}
+
+	if actor, ok := AuditActorFrom(ctx); ok {
+		log.TenantID = actor.TenantID
+	}
+	if log.TenantID == uuid.Nil && s.projects != nil {
+		project, err := s.projects.FindByID(ctx, projectID)
+		if err != nil {
+			return err
+		}
+		log.TenantID = project.TenantID
+	}
+	return s.Log(ctx, log)
+}
+
+// Query returns a page of the tenant's audit trail, newest first
+func (s *AuditService) Query(ctx context.Context, filter AuditFilter) ([]*entity.AuditLog, error) {
+	if err := validateAuditFilter(filter); err != nil {
+		return nil, err
+	}
+	if filter.Limit <= 0 {
+		filter.Limit = DefaultAuditPageSize
+	}
+	if filter.Limit > MaxAuditPageSize {
+		filter.Limit = MaxAuditPageSize
+	}
+	if filter.Offset < 0 {
+		filter.Offset = 0
+	}
+	return s.repo.Find(ctx, filter)
+}
+
+// Export returns every matching entry for auditors, up to MaxAuditExportRows
+func (s *AuditService) Export(ctx context.Context, filter AuditFilter) ([]*entity.AuditLog, error) {
+	if err := validateAuditFilter(filter); err != nil {
+		return nil, err
+	}
+	filter.Limit, filter.Offset = MaxAuditExportRows, 0
+	return s.repo.Find(ctx, filter)
+}
+
+func validateAuditFilter(filter AuditFilter) error {
+	if filter.TenantID == uuid.Nil {
+		return entity.ErrAuditTenantRequired
+	}
+	if filter.From != nil && filter.To != nil && !filter.From.Before(*filter.To) {
+		return fmt.Errorf("%w: from must be before to", entity.ErrInvalidAuditFilter)
+	}
+	return nil
+}
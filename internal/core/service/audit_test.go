@@ -0,0 +1,189 @@
+// Copyright (c) 2026 Muhammet Ali Büyük. All rights reserved.
+// This source code is proprietary. Confidential and private.
+// Unauthorized copying or distribution is strictly prohibited.
+// Contact: iletisim@alibuyuk.net | Website: alibuyuk.net
+
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/qantesm/subflow/internal/core/entity"
+)
+
+type stubAuditRepository struct {
+	logs    []*entity.AuditLog
+	filters []AuditFilter
+}
+
+func (r *stubAuditRepository) Save(ctx context.Context, log *entity.AuditLog) error {
+	r.logs = append(r.logs, log)
+	return nil
+}
+
+func (r *stubAuditRepository) Find(ctx context.Context, filter AuditFilter) ([]*entity.AuditLog, error) {
+	r.filters = append(r.filters, filter)
+	return r.logs, nil
+}
+
+func TestAuditTrail(t *testing.T) {
+	ctx := context.Background()
+	projects := &stubProjectRepository{}
+	repo := &stubAuditRepository{}
+	audit := NewAuditService(repo, projects)
+	tenant := uuid.New()
+	actorID := uuid.New()
+
+	project := entity.NewProject(tenant, "Karşıyaka Konut", "PRJ-2026-043")
+	_ = projects.Create(ctx, project)
+
+	ledger := NewLedgerService(&stubTransactionRepository{})
+	ledger.SetAuditor(audit)
+	s := NewProjectService(projects, &stubPayApplicationRepository{}, ledger)
+	s.SetAuditor(audit)
+
+	// A request carries the actor; the status change is recorded with both snapshots
+	reqCtx := WithAuditActor(ctx, AuditActor{TenantID: tenant, ActorID: &actorID, IPAddress: "10.0.0.7", UserAgent: "curl/8"})
+	if _, err := s.Transition(reqCtx, project.ID, entity.ProjectStatusActive, "Sözleşme imzalandı", actorID, day(2026, 3, 1)); err != nil {
+		t.Fatalf("Transition() error = %v", err)
+	}
+	if len(repo.logs) != 1 {
+		t.Fatalf("audit entries = %d, want 1", len(repo.logs))
+	}
+	entry := repo.logs[0]
+	if entry.Action != entity.AuditActionProjectTransition || entry.EntityType != "project" || *entry.EntityID != project.ID ||
+		entry.TenantID != tenant || entry.ActorID == nil || *entry.ActorID != actorID || entry.IPAddress != "10.0.0.7" {
+		t.Errorf("transition entry = %+v", entry)
+	}
+	var before, after entity.Project
+	_ = json.Unmarshal(entry.OldValue, &before)
+	_ = json.Unmarshal(entry.NewValue, &after)
+	if before.Status != entity.ProjectStatusDraft || after.Status != entity.ProjectStatusActive {
+		t.Errorf("snapshots = %s -> %s, want DRAFT -> ACTIVE", before.Status, after.Status)
+	}
+
+	// A job runs without an actor; the tenant comes from the project
+	tx, err := ledger.RecordInvoice(ctx, project.ID, 100000, "TRY", "F-1", actorID)
+	if err != nil {
+		t.Fatalf("RecordInvoice() error = %v", err)
+	}
+	if entry := repo.logs[1]; entry.Action != entity.AuditActionTransactionCreate || *entry.EntityID != tx.ID ||
+		entry.TenantID != tenant || entry.ActorID != nil || entry.OldValue != nil || len(entry.NewValue) == 0 {
+		t.Errorf("ledger entry = %+v", entry)
+	}
+
+	if err := audit.Log(ctx, entity.NewAuditLog(uuid.Nil, "http.post", "projects", nil)); !errors.Is(err, entity.ErrAuditTenantRequired) {
+		t.Errorf("Log() without a tenant error = %v", err)
+	}
+
+	// Queries are per tenant with a bounded page
+	if _, err := audit.Query(ctx, AuditFilter{}); !errors.Is(err, entity.ErrAuditTenantRequired) {
+		t.Errorf("Query() without a tenant error = %v", err)
+	}
+	from, to := day(2026, 3, 2), day(2026, 3, 1)
+	if _, err := audit.Query(ctx, AuditFilter{TenantID: tenant, From: &from, To: &to}); !errors.Is(err, entity.ErrInvalidAuditFilter) {
+		t.Errorf("Query() with an inverted range error = %v", err)
+	}
+	if _, err := audit.Query(ctx, AuditFilter{TenantID: tenant, Limit: 1_000_000, Offset: -3}); err != nil {
+		t.Fatalf("Query() error = %v", err)
+	}
+	if _, err := audit.Export(ctx, AuditFilter{TenantID: tenant, Limit: 10, Offset: 20, To: &to}); err != nil {
+		t.Fatalf("Export() error = %v", err)
+	}
+	if f := repo.filters[0]; f.Limit != MaxAuditPageSize || f.Offset != 0 {
+		t.Errorf("query page = %d+%d, want the maximum from 0", f.Limit, f.Offset)
+	}
+	if f := repo.filters[1]; f.Limit != MaxAuditExportRows || f.Offset != 0 || !f.To.Equal(to) {
+		t.Errorf("export filter = %+v", f)
+	}
+}
+
+func TestAuditCoversRegistersAndConfiguration(t *testing.T) {
+	ctx := context.Background()
+	projects := &stubProjectRepository{}
+	repo := &stubAuditRepository{}
+	audit := NewAuditService(repo, projects)
+	tenant := uuid.New()
+	user := uuid.New()
+
+	project := entity.NewProject(tenant, "Bornova Hastane", "PRJ-2026-044")
+	_ = projects.Create(ctx, project)
+	actions := func() []string {
+		var got []string
+		for _, log := range repo.logs {
+			if log.TenantID != tenant {
+				t.Errorf("%s entry tenant = %s, want %s", log.Action, log.TenantID, tenant)
+			}
+			got = append(got, log.Action)
+		}
+		repo.logs = nil
+		return got
+	}
+	expect := func(got []string, want ...string) {
+		t.Helper()
+		if len(got) != len(want) {
+			t.Fatalf("audit actions = %v, want %v", got, want)
+		}
+		for i := range want {
+			if got[i] != want[i] {
+				t.Errorf("audit actions = %v, want %v", got, want)
+				return
+			}
+		}
+	}
+
+	guarantees := NewGuaranteeService(&stubGuaranteeRepository{}, NewNotificationService(&stubNotificationRepository{}), 0)
+	guarantees.SetAuditor(audit)
+	g := entity.NewBankGuarantee(project.ID, entity.GuaranteeTypePerformance, "Ziraat Bankası", "TM-2026-0044", 1000000, "TRY", day(2026, 1, 15), nil, user)
+	_ = guarantees.Register(ctx, g)
+	_, _ = guarantees.Reduce(ctx, g.ID, 500000, "Geçici kabul", user)
+	expect(actions(), entity.AuditActionGuaranteeRegister, entity.AuditActionGuaranteeReduce)
+
+	// Penalties and scheduled deductions are recorded like manual ones, without an actor
+	ledger := NewLedgerService(&stubTransactionRepository{})
+	deductions := NewDeductionService(&stubDeductionRepository{}, projects, ledger)
+	deductions.SetAuditor(audit)
+	if err := deductions.Create(ctx, entity.NewDeduction(project.ID, entity.DeductionCategorySGK, "SGK primi", 150000, "TRY", day(2026, 2, 28), user)); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	_ = deductions.SetPenaltyRule(ctx, entity.NewLatePenaltyRule(project.ID, 10, 1000, user))
+	_ = deductions.SetPenaltyRule(ctx, entity.NewLatePenaltyRule(project.ID, 20, 1000, user))
+	expect(actions(), entity.AuditActionDeductionCreate, entity.AuditActionPenaltyRuleSet, entity.AuditActionPenaltyRuleSet)
+
+	advances := NewAdvanceService(&stubAdvanceRepository{}, ledger)
+	advances.SetAuditor(audit)
+	rule := entity.NewAdvanceRecoveryRule(project.ID, entity.AdvanceRecoveryPercentage, user)
+	rule.Rate = 1000
+	if err := advances.SetRule(ctx, rule); err != nil {
+		t.Fatalf("SetRule() error = %v", err)
+	}
+	expect(actions(), entity.AuditActionAdvanceRuleSet)
+
+	// Endpoints belong to the tenant, not a project
+	webhooks := NewWebhookService(&stubWebhookRepository{}, projects, nil, nil, 0, 0)
+	webhooks.SetAuditor(audit)
+	endpoint, _ := entity.NewWebhookEndpoint(tenant, "https://203.0.113.10/hooks", []entity.EventType{entity.EventTransactionCreated}, user)
+	if err := webhooks.CreateEndpoint(ctx, endpoint); err != nil {
+		t.Fatalf("CreateEndpoint() error = %v", err)
+	}
+	if _, err := webhooks.SetEndpointActive(ctx, tenant, endpoint.ID, false); err != nil {
+		t.Fatalf("SetEndpointActive() error = %v", err)
+	}
+	if len(repo.logs) > 0 && strings.Contains(string(repo.logs[0].NewValue), endpoint.Secret) {
+		t.Error("endpoint snapshot leaks the signing secret")
+	}
+	expect(actions(), entity.AuditActionWebhookCreate, entity.AuditActionWebhookUpdate)
+
+	svc, txRepo, stmt := newReconciliationFixture(t)
+	svc.SetAuditor(audit)
+	reqCtx := WithAuditActor(ctx, AuditActor{TenantID: tenant, ActorID: &user})
+	if _, _, err := svc.Accept(reqCtx, stmt.Lines[0].ID, txRepo.transactions[0].ID, DefaultMatchRules(), user); err != nil {
+		t.Fatalf("Accept() error = %v", err)
+	}
+	expect(actions(), entity.AuditActionReconciliationAccept)
+}
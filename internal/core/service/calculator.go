@@ -21,6 +21,10 @@ type AIABillingInput struct {
 	PreviousCertificates    int64 // Cents - Önceki ödeme sertifikaları
 	LaborRetainageRate      int64 // Basis points (100 = 1%, 1000 = 10%)
 	MaterialRetainageRate   int64 // Basis points
+	PriceEscalation         int64 // Cents - Fiyat farkı to date, not subject to retainage
+	AdvancePaid             int64 // Cents - Avans paid to date
+	AdvanceRecovery         *entity.AdvanceRecoveryRule // Avans mahsubu rule, nil when no advance is recovered
+	Deductions              int64 // Cents - Kesintiler taken from this payment
 }
 
 // AIABillingResult contains calculated values per AIA standards
@@ -37,10 +41,19 @@ type AIABillingResult struct {
 	MaterialRetainage       int64 `json:"material_retainage"`        // Retainage on materials
 	TotalRetainage          int64 `json:"total_retainage"`           // Combined retainage
 	
+	// Price Adjustment
+	PriceEscalation         int64 `json:"price_escalation"`          // Fiyat farkı to date
+
+	// Advance Payment
+	AdvanceRecovered        int64 `json:"advance_recovered"`         // Avans mahsubu to date
+	AdvanceOutstanding      int64 `json:"advance_outstanding"`       // Advance not yet recovered
+
 	// Final Calculation
-	TotalEarned             int64 `json:"total_earned"`              // Completed - Retainage
+	TotalEarned             int64 `json:"total_earned"`              // Completed - Retainage + Escalation - Advance Recovered
 	LessPreviousCerts       int64 `json:"less_previous_certs"`       // Previous payments
 	CurrentPaymentDue       int64 `json:"current_payment_due"`       // Final amount owed
+	Deductions              int64 `json:"deductions"`                // Kesintiler this period
+	NetPaymentDue           int64 `json:"net_payment_due"`           // Current payment due - Deductions
 	
 	// Percentage Complete
 	PercentComplete         int64 `json:"percent_complete"`          // Basis points (5000 = 50%)
@@ -92,8 +105,15 @@ func (c *Calculator) Calculate(input AIABillingInput) (*AIABillingResult, error)
 	// Total retainage
 	result.TotalRetainage = result.LaborRetainage + result.MaterialRetainage
 
-	// 5. Total Earned = Completed - Retainage
-	result.TotalEarned = result.TotalCompletedAndStored - result.TotalRetainage
+	// 5. Total Earned = Completed - Retainage + Price Escalation - Advance Recovered
+	// Advance recovered to date follows the contract rule on work completed
+	if input.AdvanceRecovery != nil {
+		result.AdvanceRecovered = input.AdvanceRecovery.RecoveredToDate(input.AdvancePaid, result.ContractSum, result.TotalWorkCompleted)
+	}
+	result.AdvanceOutstanding = input.AdvancePaid - result.AdvanceRecovered
+
+	result.PriceEscalation = input.PriceEscalation
+	result.TotalEarned = result.TotalCompletedAndStored - result.TotalRetainage + result.PriceEscalation - result.AdvanceRecovered
 
 	// 6. Less Previous Certificates
 	result.LessPreviousCerts = input.PreviousCertificates
@@ -101,6 +121,10 @@ func (c *Calculator) Calculate(input AIABillingInput) (*AIABillingResult, error)
 	// 7. Current Payment Due = Total Earned - Previous Payments
 	result.CurrentPaymentDue = result.TotalEarned - result.LessPreviousCerts
 
+	// Deductions are withheld from the payment, not the invoiced amount
+	result.Deductions = input.Deductions
+	result.NetPaymentDue = result.CurrentPaymentDue - result.Deductions
+
 	// 8. Percentage Complete (in basis points)
 	if result.ContractSum > 0 {
 		result.PercentComplete = (result.TotalCompletedAndStored * 10000) / result.ContractSum
@@ -131,9 +155,14 @@ func (c *Calculator) validateInput(input AIABillingInput) error {
 	if input.PreviousWorkCompleted < 0 || input.CurrentWorkCompleted < 0 {
 		return entity.ErrInvalidAmount
 	}
-	if input.StoredMaterials < 0 {
+	if input.StoredMaterials < 0 || input.AdvancePaid < 0 || input.Deductions < 0 {
 		return entity.ErrInvalidAmount
 	}
+	if input.AdvanceRecovery != nil {
+		if err := input.AdvanceRecovery.Validate(); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -0,0 +1,420 @@
+// Copyright (c) 2026 Muhammet Ali Büyük. All rights reserved.
+// This source code is proprietary. Confidential and private.
+// Unauthorized copying or distribution is strictly prohibited.
+// Contact: iletisim@alibuyuk.net | Website: alibuyuk.net
+
+package service
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/qantesm/subflow/internal/core/entity"
+)
+
+// DefaultForecastMonths is the forecast horizon when none is requested
+const DefaultForecastMonths = 12
+
+// CashFlowPlanRepository is the port for cash flow plan persistence
+type CashFlowPlanRepository interface {
+	Save(ctx context.Context, plan *entity.CashFlowPlan) error // Inserts or replaces the project's plan
+	FindByProjectID(ctx context.Context, projectID uuid.UUID) (*entity.CashFlowPlan, error)
+}
+
+// CashFlowMonth is one month of a cash flow forecast
+// Baseline figures follow the plan from its start; forecast figures start from
+// the progress certified so far and only cover the current and later months.
+type CashFlowMonth struct {
+	Month          string `json:"month"` // YYYY-MM
+	BaselineIn     int64  `json:"baseline_in"`
+	Billing        int64  `json:"billing"`          // Work forecast to be invoiced at month end
+	RetainageHeld  int64  `json:"retainage_held"`   // Held from this month's billing
+	ForecastIn     int64  `json:"forecast_in"`      // Owner receipts, including retainage release
+	ForecastOut    int64  `json:"forecast_out"`     // Subcontractor and supplier payments
+	ForecastNet    int64  `json:"forecast_net"`     // ForecastIn - ForecastOut
+	CumulativeNet  int64  `json:"cumulative_net"`   // Running ForecastNet over the horizon
+	ActualIn       int64  `json:"actual_in"`        // Ledger receipts: payments, retainage releases and advances
+	HasActual      bool   `json:"has_actual"`       // The month has started, so ActualIn is meaningful
+	VarianceToPlan int64  `json:"variance_to_plan"` // ActualIn - BaselineIn for months with actuals
+}
+
+// CashFlowForecast is the monthly cash flow of a project, or of a portfolio in one currency
+type CashFlowForecast struct {
+	ProjectID   *uuid.UUID      `json:"project_id,omitempty"` // nil for a portfolio
+	Currency    string          `json:"currency"`
+	AsOf        time.Time       `json:"as_of"`
+	Months      []CashFlowMonth `json:"months"`
+	TotalIn     int64           `json:"total_in"`
+	TotalOut    int64           `json:"total_out"`
+	TotalNet    int64           `json:"total_net"`
+	TotalActual int64           `json:"total_actual"`
+}
+
+// CashFlowPortfolio is the forecast of every planned project of a tenant, one forecast per currency
+type CashFlowPortfolio struct {
+	TenantID  uuid.UUID           `json:"tenant_id"`
+	AsOf      time.Time           `json:"as_of"`
+	Forecasts []*CashFlowForecast `json:"forecasts"` // Ordered by currency
+	Projects  []uuid.UUID         `json:"projects"`  // Included in a forecast
+	Unplanned []uuid.UUID         `json:"unplanned"` // Skipped, no cash flow plan yet
+}
+
+// CashFlowService forecasts project cash flow from the schedule of values, a
+// progress curve and payment terms, next to the cash actually received
+type CashFlowService struct {
+	plans     CashFlowPlanRepository
+	projects  ProjectRepository
+	payApps   PayApplicationRepository
+	ledger    *LedgerService
+	costs     CostRepository // Optional: subcontractor payables come from job cost commitments
+	architect string
+}
+
+// NewCashFlowService creates a new cash flow service; costs may be nil
+func NewCashFlowService(plans CashFlowPlanRepository, projects ProjectRepository, payApps PayApplicationRepository, ledger *LedgerService, costs CostRepository) *CashFlowService {
+	return &CashFlowService{
+		plans:     plans,
+		projects:  projects,
+		payApps:   payApps,
+		ledger:    ledger,
+		costs:     costs,
+		architect: "Muhammet-Ali-Buyuk",
+	}
+}
+
+// SavePlan validates and stores the cash flow plan of a project
+// A zero retainage rate falls back to the project's labor retainage rate.
+func (s *CashFlowService) SavePlan(ctx context.Context, plan *entity.CashFlowPlan) error {
+	project, err := s.projects.FindByID(ctx, plan.ProjectID)
+	if err != nil {
+		return err
+	}
+	if plan.RetainageRate == 0 {
+		plan.RetainageRate = int64(project.LaborRetainageRate*10000 + 0.5)
+	}
+	if err := plan.Validate(); err != nil {
+		return err
+	}
+	plan.UpdatedAt = time.Now()
+	return s.plans.Save(ctx, plan)
+}
+
+// GetPlan returns the cash flow plan of a project
+func (s *CashFlowService) GetPlan(ctx context.Context, projectID uuid.UUID) (*entity.CashFlowPlan, error) {
+	return s.plans.FindByProjectID(ctx, projectID)
+}
+
+// ProjectForecast returns the monthly cash flow of a project for the months
+// starting with from's month. Cash falling outside the horizon is left out.
+func (s *CashFlowService) ProjectForecast(ctx context.Context, projectID uuid.UUID, from time.Time, months int, now time.Time) (*CashFlowForecast, error) {
+	project, err := s.projects.FindByID(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	plan, err := s.plans.FindByProjectID(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	return s.forecast(ctx, project, plan, from, months, now)
+}
+
+// PortfolioForecast adds up the forecasts of a tenant's planned projects per currency
+func (s *CashFlowService) PortfolioForecast(ctx context.Context, tenantID uuid.UUID, from time.Time, months int, now time.Time) (*CashFlowPortfolio, error) {
+	portfolio := &CashFlowPortfolio{
+		TenantID:  tenantID,
+		AsOf:      now,
+		Forecasts: []*CashFlowForecast{},
+		Projects:  []uuid.UUID{},
+		Unplanned: []uuid.UUID{},
+	}
+	byCurrency := make(map[string]*CashFlowForecast)
+
+	const pageSize = 100
+	for offset := 0; ; offset += pageSize {
+		projects, err := s.projects.FindByTenant(ctx, tenantID, pageSize, offset)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, project := range projects {
+			plan, err := s.plans.FindByProjectID(ctx, project.ID)
+			if errors.Is(err, entity.ErrCashFlowPlanNotFound) {
+				portfolio.Unplanned = append(portfolio.Unplanned, project.ID)
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+
+			f, err := s.forecast(ctx, project, plan, from, months, now)
+			if err != nil {
+				return nil, err
+			}
+			portfolio.Projects = append(portfolio.Projects, project.ID)
+
+			total, ok := byCurrency[f.Currency]
+			if !ok {
+				byCurrency[f.Currency] = f
+				f.ProjectID = nil
+				portfolio.Forecasts = append(portfolio.Forecasts, f)
+				continue
+			}
+			addCashFlow(total, f)
+		}
+
+		if len(projects) < pageSize {
+			break
+		}
+	}
+
+	sort.Slice(portfolio.Forecasts, func(i, j int) bool {
+		return portfolio.Forecasts[i].Currency < portfolio.Forecasts[j].Currency
+	})
+	return portfolio, nil
+}
+
+// cashFlowPosition is where a projection starts from
+type cashFlowPosition struct {
+	anchor     time.Time // First month to bill
+	completed  int64     // Work already billed
+	retained   int64     // Retainage already held
+	receivable int64     // Invoiced but not yet received
+	committed  int64     // Subcontract and purchase order cost not yet invoiced
+}
+
+func (s *CashFlowService) forecast(ctx context.Context, project *entity.Project, plan *entity.CashFlowPlan, from time.Time, months int, now time.Time) (*CashFlowForecast, error) {
+	if months <= 0 {
+		months = DefaultForecastMonths
+	}
+	start := monthStart(from)
+	current := monthStart(now)
+
+	total := plan.ScheduledTotal()
+	if total == 0 {
+		total = project.ContractAmount
+	}
+
+	pos := cashFlowPosition{anchor: current}
+	completed, err := s.certifiedToDate(ctx, project.ID)
+	if err != nil {
+		return nil, err
+	}
+	pos.completed = completed
+
+	// The forecast is in the project currency, so only its ledger counts
+	summary, err := s.ledger.GetProjectFinancialsIn(ctx, project.ID, project.Currency)
+	if err != nil {
+		return nil, err
+	}
+	if summary.CurrentBalance > 0 {
+		pos.receivable = summary.CurrentBalance
+	}
+	pos.retained = summary.TotalRetained
+
+	if s.costs != nil {
+		pos.committed, err = s.uninvoicedCommitments(ctx, project.ID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	f := &CashFlowForecast{
+		ProjectID: &project.ID,
+		Currency:  project.Currency,
+		AsOf:      now,
+		Months:    make([]CashFlowMonth, months),
+	}
+	for i := range f.Months {
+		f.Months[i].Month = start.AddDate(0, i, 0).Format("2006-01")
+	}
+
+	bucket := func(at time.Time) int {
+		if at.Before(start) {
+			return -1
+		}
+		i := (at.Year()-start.Year())*12 + int(at.Month()-start.Month())
+		if i >= months {
+			return -1
+		}
+		return i
+	}
+
+	// Baseline: the plan as if nothing had been billed before its start
+	baseline := cashFlowPosition{anchor: monthStart(plan.StartDate)}
+	projectCashFlow(plan, total, baseline, start.AddDate(0, months, 0), func(at time.Time, in, out, billing, retainage int64) {
+		if i := bucket(at); i >= 0 {
+			f.Months[i].BaselineIn += in
+		}
+	})
+
+	// Forecast: from the certified progress, open receivables and commitments
+	projectCashFlow(plan, total, pos, start.AddDate(0, months, 0), func(at time.Time, in, out, billing, retainage int64) {
+		i := bucket(at)
+		if i < 0 {
+			return
+		}
+		m := &f.Months[i]
+		m.ForecastIn += in
+		m.ForecastOut += out
+		m.Billing += billing
+		m.RetainageHeld += retainage
+	})
+
+	transactions, err := s.ledger.GetTransactionHistory(ctx, project.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, tx := range transactions {
+		switch tx.Type {
+		case entity.TransactionTypePayment, entity.TransactionTypeRetainageRelease, entity.TransactionTypeAdvancePayment:
+			if i := bucket(tx.EffectiveDate); i >= 0 {
+				f.Months[i].ActualIn += tx.AmountCents
+			}
+		}
+	}
+
+	for i := range f.Months {
+		monthFrom := start.AddDate(0, i, 0)
+		f.Months[i].HasActual = !monthFrom.After(now)
+	}
+	finishCashFlow(f)
+	return f, nil
+}
+
+// projectCashFlow bills the remaining work month by month along the plan's
+// curve and reports each cash movement on the day it is expected:
+// receipts NetDays after the month-end invoice less retainage, retainage
+// RetainageDays after the plan end, and payables PayableDays after the
+// invoice in proportion to the work billed. Billing stops once until is reached
+// by the cash it would produce.
+func projectCashFlow(plan *entity.CashFlowPlan, total int64, pos cashFlowPosition, until time.Time, emit func(at time.Time, in, out, billing, retainage int64)) {
+	if pos.receivable > 0 {
+		emit(pos.anchor.AddDate(0, 0, plan.NetDays), pos.receivable, 0, 0, 0)
+	}
+
+	remaining := total - pos.completed
+	billed := pos.completed
+	retained := pos.retained
+	committed := pos.committed
+
+	endMonth := monthStart(plan.EndDate)
+	for month := pos.anchor; !month.After(endMonth) && remaining > 0; month = month.AddDate(0, 1, 0) {
+		invoiceDate := month.AddDate(0, 1, -1)
+		planned := int64(float64(total)*plan.ProgressAt(month.AddDate(0, 1, 0)) + 0.5)
+		if !month.Before(endMonth) {
+			planned = total // Whatever is left is billed in the last month
+		}
+		billing := planned - billed
+		if billing <= 0 {
+			continue
+		}
+		// Held on cumulative billing so monthly rounding does not drift
+		retainage := roundBasisPoints(billed+billing-pos.completed, plan.RetainageRate) - roundBasisPoints(billed-pos.completed, plan.RetainageRate)
+		payable := committed * billing / remaining
+
+		billed += billing
+		retained += retainage
+		committed -= payable
+		remaining -= billing
+
+		emit(invoiceDate, 0, 0, billing, retainage)
+		emit(invoiceDate.AddDate(0, 0, plan.NetDays), billing-retainage, 0, 0, 0)
+		if payable > 0 {
+			emit(invoiceDate.AddDate(0, 0, plan.PayableDays), 0, payable, 0, 0)
+		}
+		if invoiceDate.After(until) {
+			break
+		}
+	}
+
+	if retained > 0 {
+		emit(plan.EndDate.AddDate(0, 0, plan.RetainageDays), retained, 0, 0, 0)
+	}
+}
+
+// certifiedToDate returns the work completed and stored on the latest certified pay application
+func (s *CashFlowService) certifiedToDate(ctx context.Context, projectID uuid.UUID) (int64, error) {
+	apps, err := s.payApps.FindByProjectID(ctx, projectID)
+	if err != nil {
+		return 0, err
+	}
+	var latest *entity.PayApplication
+	for _, app := range apps {
+		if app.IsCertified() && (latest == nil || app.ApplicationNo > latest.ApplicationNo) {
+			latest = app
+		}
+	}
+	if latest == nil {
+		return 0, nil
+	}
+	return latest.TotalCompletedAndStored, nil
+}
+
+// uninvoicedCommitments returns committed cost that has not been invoiced yet
+func (s *CashFlowService) uninvoicedCommitments(ctx context.Context, projectID uuid.UUID) (int64, error) {
+	commitments, err := s.costs.FindCommitmentsByProject(ctx, projectID)
+	if err != nil {
+		return 0, err
+	}
+	invoices, err := s.costs.FindVendorInvoicesByProject(ctx, projectID)
+	if err != nil {
+		return 0, err
+	}
+
+	invoiced := make(map[uuid.UUID]int64)
+	for _, v := range invoices {
+		if v.CommitmentID != nil {
+			invoiced[*v.CommitmentID] += v.Amount
+		}
+	}
+	var open int64
+	for _, c := range commitments {
+		if left := c.Amount - invoiced[c.ID]; left > 0 {
+			open += left
+		}
+	}
+	return open, nil
+}
+
+func addCashFlow(dst, src *CashFlowForecast) {
+	for i := range dst.Months {
+		d, s := &dst.Months[i], src.Months[i]
+		d.BaselineIn += s.BaselineIn
+		d.Billing += s.Billing
+		d.RetainageHeld += s.RetainageHeld
+		d.ForecastIn += s.ForecastIn
+		d.ForecastOut += s.ForecastOut
+		d.ActualIn += s.ActualIn
+	}
+	finishCashFlow(dst)
+}
+
+func finishCashFlow(f *CashFlowForecast) {
+	f.TotalIn, f.TotalOut, f.TotalNet, f.TotalActual = 0, 0, 0, 0
+	var cumulative int64
+	for i := range f.Months {
+		m := &f.Months[i]
+		m.ForecastNet = m.ForecastIn - m.ForecastOut
+		cumulative += m.ForecastNet
+		m.CumulativeNet = cumulative
+		m.VarianceToPlan = 0
+		if m.HasActual {
+			m.VarianceToPlan = m.ActualIn - m.BaselineIn
+		}
+		f.TotalIn += m.ForecastIn
+		f.TotalOut += m.ForecastOut
+		f.TotalActual += m.ActualIn
+	}
+	f.TotalNet = f.TotalIn - f.TotalOut
+}
+
+func roundBasisPoints(amount, bp int64) int64 {
+	return (amount*bp + 5000) / 10000
+}
+
+func monthStart(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location())
+}
@@ -0,0 +1,157 @@
+// Copyright (c) 2026 Muhammet Ali Büyük. All rights reserved.
+// This source code is proprietary. Confidential and private.
+// Unauthorized copying or distribution is strictly prohibited.
+// Contact: iletisim@alibuyuk.net | Website: alibuyuk.net
+
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/qantesm/subflow/internal/core/entity"
+)
+
+type stubCashFlowPlanRepository struct {
+	plans map[uuid.UUID]*entity.CashFlowPlan
+}
+
+func (r *stubCashFlowPlanRepository) Save(ctx context.Context, plan *entity.CashFlowPlan) error {
+	if r.plans == nil {
+		r.plans = make(map[uuid.UUID]*entity.CashFlowPlan)
+	}
+	r.plans[plan.ProjectID] = plan
+	return nil
+}
+
+func (r *stubCashFlowPlanRepository) FindByProjectID(ctx context.Context, projectID uuid.UUID) (*entity.CashFlowPlan, error) {
+	plan, ok := r.plans[projectID]
+	if !ok {
+		return nil, entity.ErrCashFlowPlanNotFound
+	}
+	return plan, nil
+}
+
+func TestCashFlowForecast(t *testing.T) {
+	ctx := context.Background()
+	projects := &stubProjectRepository{}
+	payApps := &stubPayApplicationRepository{}
+	txRepo := &stubTransactionRepository{}
+	costs := &stubCostRepository{}
+	s := NewCashFlowService(&stubCashFlowPlanRepository{}, projects, payApps, NewLedgerService(txRepo), costs)
+	user := uuid.New()
+	tenant := uuid.New()
+
+	project := entity.NewProject(tenant, "Karşıyaka Konut", "PRJ-2026-021")
+	project.ContractAmount = 6000000
+	_ = projects.Create(ctx, project)
+
+	plan := entity.NewCashFlowPlan(project.ID, day(2026, 1, 1), day(2026, 7, 1), user)
+	plan.Curve = entity.ProgressSCurve
+	plan.RetainageDays = 60
+	if err := s.SavePlan(ctx, plan); err != nil {
+		t.Fatalf("SavePlan() error = %v", err)
+	}
+	if plan.RetainageRate != 1000 {
+		t.Errorf("retainage rate = %d, want the project's 1000 bp", plan.RetainageRate)
+	}
+	bad := entity.NewCashFlowPlan(project.ID, day(2026, 7, 1), day(2026, 1, 1), user)
+	if err := s.SavePlan(ctx, bad); err != entity.ErrInvalidCashFlowPlan {
+		t.Errorf("reversed window error = %v", err)
+	}
+
+	// Two months certified; 1.8M invoiced net of 200k retainage, 1M received in February
+	app := entity.NewPayApplication(project.ID, 2, day(2026, 2, 1), day(2026, 2, 28), "TRY", user)
+	app.Status = entity.PayApplicationStatusCertified
+	app.TotalCompletedAndStored = 2000000
+	_ = payApps.Save(ctx, app)
+
+	invoice := entity.NewTransaction(project.ID, entity.TransactionTypeInvoice, 1800000, "TRY", user)
+	held := entity.NewTransaction(project.ID, entity.TransactionTypeRetainageHeld, 200000, "TRY", user)
+	payment := entity.NewTransaction(project.ID, entity.TransactionTypePayment, 1000000, "TRY", user)
+	payment.EffectiveDate = day(2026, 2, 20)
+	txRepo.transactions = append(txRepo.transactions, invoice, held, payment)
+
+	// 3M subcontract with 1M already invoiced leaves 2M to pay
+	commitment := entity.NewCostCommitment(project.ID, uuid.New(), entity.CommitmentPurchaseOrder, "PO-7", "Yapı Market", 3000000, "TRY", user)
+	costs.commitments = append(costs.commitments, commitment)
+	vendorInvoice := entity.NewVendorInvoice(project.ID, commitment.CostCodeID, "Yapı Market", "YM-1", day(2026, 2, 10), 1000000, "TRY", user)
+	vendorInvoice.CommitmentID = &commitment.ID
+	costs.invoices = append(costs.invoices, vendorInvoice)
+
+	f, err := s.ProjectForecast(ctx, project.ID, day(2026, 1, 1), 12, day(2026, 3, 15))
+	if err != nil {
+		t.Fatalf("ProjectForecast() error = %v", err)
+	}
+	if len(f.Months) != 12 || f.Months[0].Month != "2026-01" || f.Months[11].Month != "2026-12" {
+		t.Fatalf("months = %d, %s..%s", len(f.Months), f.Months[0].Month, f.Months[len(f.Months)-1].Month)
+	}
+
+	var billing, retainage, baseline int64
+	for i, m := range f.Months {
+		billing += m.Billing
+		retainage += m.RetainageHeld
+		baseline += m.BaselineIn
+		if i < 2 && (m.Billing != 0 || m.ForecastIn != 0) {
+			t.Errorf("%s forecast before the current month: %+v", m.Month, m)
+		}
+	}
+	if billing != 4000000 || retainage != 400000 {
+		t.Errorf("remaining billing = %d (retainage %d), want 4000000 (400000)", billing, retainage)
+	}
+	if baseline != 6000000 {
+		t.Errorf("baseline receipts = %d, want the whole contract", baseline)
+	}
+
+	// Open receivable arrives net 30 in March, March billing net of retainage in April
+	march, april := f.Months[2], f.Months[3]
+	if march.ForecastIn != 800000 {
+		t.Errorf("March receipts = %d, want the 800000 receivable", march.ForecastIn)
+	}
+	if want := march.Billing - (march.Billing*1000+5000)/10000; april.ForecastIn != want {
+		t.Errorf("April receipts = %d, want %d", april.ForecastIn, want)
+	}
+	// Retainage held so far and forecast is released 60 days after the plan end
+	if f.Months[7].ForecastIn != 600000 {
+		t.Errorf("August receipts = %d, want 600000 retainage release", f.Months[7].ForecastIn)
+	}
+
+	if f.TotalIn != 5000000 || f.TotalOut != 2000000 || f.TotalNet != 3000000 {
+		t.Errorf("totals in/out/net = %d/%d/%d, want 5000000/2000000/3000000", f.TotalIn, f.TotalOut, f.TotalNet)
+	}
+	if f.Months[11].CumulativeNet != f.TotalNet {
+		t.Errorf("cumulative net = %d, want %d", f.Months[11].CumulativeNet, f.TotalNet)
+	}
+
+	feb := f.Months[1]
+	if !feb.HasActual || feb.ActualIn != 1000000 || feb.VarianceToPlan != feb.ActualIn-feb.BaselineIn {
+		t.Errorf("February actuals = %+v", feb)
+	}
+	if f.Months[5].HasActual {
+		t.Errorf("June has actuals before it started")
+	}
+
+	// Portfolio: one forecast per currency, unplanned projects listed
+	usd := entity.NewProject(tenant, "İzmir Liman", "PRJ-2026-022")
+	usd.Currency = "USD"
+	usd.ContractAmount = 1000000
+	_ = projects.Create(ctx, usd)
+	_ = s.SavePlan(ctx, entity.NewCashFlowPlan(usd.ID, day(2026, 3, 1), day(2026, 9, 1), user))
+	unplanned := entity.NewProject(tenant, "Çeşme Otel", "PRJ-2026-023")
+	_ = projects.Create(ctx, unplanned)
+
+	portfolio, err := s.PortfolioForecast(ctx, tenant, day(2026, 1, 1), 12, day(2026, 3, 15))
+	if err != nil {
+		t.Fatalf("PortfolioForecast() error = %v", err)
+	}
+	if len(portfolio.Forecasts) != 2 || portfolio.Forecasts[0].Currency != "TRY" || portfolio.Forecasts[1].Currency != "USD" {
+		t.Fatalf("portfolio forecasts = %+v", portfolio.Forecasts)
+	}
+	if portfolio.Forecasts[0].TotalIn != 5000000 || portfolio.Forecasts[0].ProjectID != nil {
+		t.Errorf("TRY portfolio = %+v", portfolio.Forecasts[0])
+	}
+	if len(portfolio.Projects) != 2 || len(portfolio.Unplanned) != 1 || portfolio.Unplanned[0] != unplanned.ID {
+		t.Errorf("projects = %v, unplanned = %v", portfolio.Projects, portfolio.Unplanned)
+	}
+}
@@ -0,0 +1,383 @@
+// Copyright (c) 2026 Muhammet Ali Büyük. All rights reserved.
+// This source code is proprietary. Confidential and private.
+// Unauthorized copying or distribution is strictly prohibited.
+// Contact: iletisim@alibuyuk.net | Website: alibuyuk.net
+
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/qantesm/subflow/internal/core/entity"
+	"github.com/qantesm/subflow/internal/pkg"
+)
+
+// DefaultComplianceAlertDays is how long before expiry a compliance document alert is raised
+const DefaultComplianceAlertDays = 15
+
+// ComplianceRepository is the port for subcontractor compliance document persistence
+type ComplianceRepository interface {
+	SaveDocument(ctx context.Context, d *entity.ComplianceDocument) error
+	UpdateDocument(ctx context.Context, d *entity.ComplianceDocument) error
+	FindDocumentByID(ctx context.Context, id uuid.UUID) (*entity.ComplianceDocument, error)
+	FindDocumentsByContract(ctx context.Context, contractID uuid.UUID) ([]*entity.ComplianceDocument, error)
+	FindExpiringDocuments(ctx context.Context, before time.Time) ([]*entity.ComplianceDocument, error) // Unrevoked documents of all projects expiring before the date
+
+	SaveRequirement(ctx context.Context, r *entity.ComplianceRequirement) error // ErrComplianceRequirementExists on a duplicate type and scope
+	DeleteRequirement(ctx context.Context, id uuid.UUID) error
+	FindRequirementsByProject(ctx context.Context, projectID uuid.UUID) ([]*entity.ComplianceRequirement, error)
+}
+
+// PaymentGate decides whether a subcontractor payment may be recorded
+// LedgerService consults it for every payment made under a contract
+type PaymentGate interface {
+	CheckPayment(ctx context.Context, projectID, contractID uuid.UUID, amountCents int64, date time.Time) (*entity.ComplianceCheck, error)
+	PaymentRecorded(ctx context.Context, check *entity.ComplianceCheck, payment *entity.Transaction) error
+}
+
+// ComplianceService keeps lien waivers, insurance certificates and clearance
+// letters on file and blocks payments to subcontractors missing them
+type ComplianceService struct {
+	repo         ComplianceRepository
+	transactions TransactionRepository
+	notifier     Notifier
+	alertDays    int
+	audit        Auditor // Optional: records documents, requirements, waiver use and overrides in the audit trail
+	architect    string
+}
+
+// NewComplianceService creates a new compliance service alerting alertDays before a document expires
+func NewComplianceService(repo ComplianceRepository, transactions TransactionRepository, notifier Notifier, alertDays int) *ComplianceService {
+	if alertDays <= 0 {
+		alertDays = DefaultComplianceAlertDays
+	}
+	return &ComplianceService{
+		repo:         repo,
+		transactions: transactions,
+		notifier:     notifier,
+		alertDays:    alertDays,
+		architect:    "Muhammet-Ali-Buyuk",
+	}
+}
+
+// SetAuditor records every document, requirement, waiver use and payment override in the audit trail
+func (s *ComplianceService) SetAuditor(audit Auditor) {
+	s.audit = audit
+}
+
+// AddDocument validates and stores a document received from a subcontractor
+func (s *ComplianceService) AddDocument(ctx context.Context, d *entity.ComplianceDocument) error {
+	if err := d.Validate(); err != nil {
+		return err
+	}
+	if err := s.repo.SaveDocument(ctx, d); err != nil {
+		return err
+	}
+	recordAudit(ctx, s.audit, entity.AuditActionComplianceDocument, "compliance_document", d.ID, d.ProjectID, nil, d)
+	return nil
+}
+
+// GetDocument returns a single document
+func (s *ComplianceService) GetDocument(ctx context.Context, id uuid.UUID) (*entity.ComplianceDocument, error) {
+	return s.repo.FindDocumentByID(ctx, id)
+}
+
+// ListDocuments returns the documents on file for a contract
+func (s *ComplianceService) ListDocuments(ctx context.Context, contractID uuid.UUID) ([]*entity.ComplianceDocument, error) {
+	return s.repo.FindDocumentsByContract(ctx, contractID)
+}
+
+// RevokeDocument withdraws a document so it no longer satisfies requirements
+func (s *ComplianceService) RevokeDocument(ctx context.Context, id uuid.UUID, at time.Time) (*entity.ComplianceDocument, error) {
+	d, err := s.repo.FindDocumentByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	before := *d
+	if err := d.Revoke(at); err != nil {
+		return nil, err
+	}
+	if err := s.repo.UpdateDocument(ctx, d); err != nil {
+		return nil, err
+	}
+	recordAudit(ctx, s.audit, entity.AuditActionComplianceRevoke, "compliance_document", d.ID, d.ProjectID, &before, d)
+	return d, nil
+}
+
+// AddRequirement makes a document type mandatory for a project or a single contract
+func (s *ComplianceService) AddRequirement(ctx context.Context, r *entity.ComplianceRequirement) error {
+	if !r.Type.IsValid() || r.ProjectID == uuid.Nil {
+		return entity.ErrInvalidComplianceRequirement
+	}
+	if err := s.repo.SaveRequirement(ctx, r); err != nil {
+		return err
+	}
+	recordAudit(ctx, s.audit, entity.AuditActionComplianceRequirement, "compliance_requirement", r.ID, r.ProjectID, nil, r)
+	return nil
+}
+
+// RemoveRequirement drops a requirement
+func (s *ComplianceService) RemoveRequirement(ctx context.Context, id uuid.UUID) error {
+	return s.repo.DeleteRequirement(ctx, id)
+}
+
+// ListRequirements returns the requirements of a project
+func (s *ComplianceService) ListRequirements(ctx context.Context, projectID uuid.UUID) ([]*entity.ComplianceRequirement, error) {
+	return s.repo.FindRequirementsByProject(ctx, projectID)
+}
+
+// CheckPayment evaluates every requirement covering the contract for a payment on date.
+// A conditional waiver must be unused and cover the amount; an unconditional waiver
+// must cover the previous payment; other documents must be in force on the date.
+func (s *ComplianceService) CheckPayment(ctx context.Context, projectID, contractID uuid.UUID, amountCents int64, date time.Time) (*entity.ComplianceCheck, error) {
+	requirements, err := s.repo.FindRequirementsByProject(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	documents, err := s.repo.FindDocumentsByContract(ctx, contractID)
+	if err != nil {
+		return nil, err
+	}
+
+	check := &entity.ComplianceCheck{
+		ProjectID:  projectID,
+		ContractID: contractID,
+		Amount:     amountCents,
+		Date:       date,
+	}
+
+	seen := make(map[entity.ComplianceDocumentType]bool)
+	for _, r := range requirements {
+		if !r.AppliesTo(contractID) || seen[r.Type] {
+			continue
+		}
+		seen[r.Type] = true
+
+		var reason string
+		switch r.Type {
+		case entity.ComplianceConditionalWaiver:
+			reason = s.checkConditionalWaiver(documents, amountCents, date, check)
+		case entity.ComplianceUnconditionalWaiver:
+			reason, err = s.checkUnconditionalWaiver(ctx, projectID, contractID, documents, date)
+			if err != nil {
+				return nil, err
+			}
+		default:
+			reason = checkDocumentInForce(documents, r.Type, date)
+		}
+		if reason != "" {
+			check.Issues = append(check.Issues, entity.ComplianceIssue{Type: r.Type, Reason: reason})
+		}
+	}
+
+	check.Compliant = len(check.Issues) == 0
+	return check, nil
+}
+
+// PaymentRecorded marks the conditional waiver as used and raises a
+// notification when a non-compliant payment went through on an override.
+// It runs with the payment's unit of work; only the waiver can fail it, as a
+// notification that is not delivered must not undo the payment.
+func (s *ComplianceService) PaymentRecorded(ctx context.Context, check *entity.ComplianceCheck, payment *entity.Transaction) error {
+	if check.WaiverID != nil {
+		waiver, err := s.repo.FindDocumentByID(ctx, *check.WaiverID)
+		if err != nil {
+			return err
+		}
+		before := *waiver
+		waiver.PaymentID = &payment.ID
+		if err := s.repo.UpdateDocument(ctx, waiver); err != nil {
+			return err
+		}
+		recordAudit(ctx, s.audit, entity.AuditActionComplianceWaiverUse, "compliance_document", waiver.ID, waiver.ProjectID, &before, waiver)
+	}
+
+	if check.Compliant {
+		return nil
+	}
+
+	meta, err := payment.GetMetadata()
+	if err != nil {
+		return err
+	}
+	recordAudit(ctx, s.audit, entity.AuditActionComplianceOverride, "transaction", payment.ID, payment.ProjectID, nil, map[string]any{
+		"check":  check,
+		"reason": meta.ComplianceOverride,
+	})
+	missing := make([]string, 0, len(check.Issues))
+	for _, issue := range check.Issues {
+		missing = append(missing, string(issue.Type))
+	}
+	n := entity.NewNotification(payment.ProjectID, entity.NotificationComplianceOverride, entity.NotificationWarning,
+		fmt.Sprintf("Eksik belgeye rağmen ödeme yapıldı: %s", FormatCurrency(payment.AmountCents, payment.Currency)),
+		fmt.Sprintf("Payment %s under contract %s was recorded without %s. Override reason: %s",
+			payment.ReferenceNo, check.ContractID, strings.Join(missing, ", "), meta.ComplianceOverride))
+	n.ReferenceID = &payment.ID
+	n.Params = map[string]string{
+		"amount":       FormatCurrency(payment.AmountCents, payment.Currency),
+		"reference_no": payment.ReferenceNo,
+		"contract_id":  check.ContractID.String(),
+		"missing":      strings.Join(missing, ", "),
+		"reason":       meta.ComplianceOverride,
+	}
+	if err := s.notifier.Notify(ctx, n); err != nil {
+		pkg.Ctx(ctx).Warn().Err(err).Str("payment_id", payment.ID.String()).Msg("Compliance override notification failed")
+	}
+	return nil
+}
+
+// Expiring returns unrevoked documents expiring within the given number of days, soonest first
+func (s *ComplianceService) Expiring(ctx context.Context, now time.Time, days int) ([]*entity.ComplianceDocument, error) {
+	documents, err := s.repo.FindExpiringDocuments(ctx, now.AddDate(0, 0, days+1))
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(documents, func(i, j int) bool {
+		return documents[i].ExpiryDate.Before(*documents[j].ExpiryDate)
+	})
+	return documents, nil
+}
+
+// CheckExpiries raises one alert per document entering the alert window. Safe to run repeatedly.
+func (s *ComplianceService) CheckExpiries(ctx context.Context, now time.Time) (*ExpiryCheckResult, error) {
+	documents, err := s.repo.FindExpiringDocuments(ctx, now.AddDate(0, 0, s.alertDays+1))
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ExpiryCheckResult{Checked: len(documents)}
+	for _, d := range documents {
+		left, ok := d.DaysToExpiry(now)
+		if !ok || left > s.alertDays || d.ExpiryAlertedAt != nil {
+			continue
+		}
+		// A used conditional waiver has done its job, its expiry no longer matters
+		if d.PaymentID != nil {
+			continue
+		}
+
+		alertedAt := now
+		d.ExpiryAlertedAt = &alertedAt
+		if err := s.repo.UpdateDocument(ctx, d); err != nil {
+			return result, err
+		}
+
+		severity := entity.NotificationWarning
+		if left < 0 {
+			severity = entity.NotificationCritical
+			result.Expired++
+		} else {
+			result.Alerted++
+		}
+		n := entity.NewNotification(d.ProjectID, entity.NotificationComplianceExpiring, severity,
+			fmt.Sprintf("Taşeron belgesinin süresi doluyor: %s %s", d.Type, d.DocumentNo),
+			fmt.Sprintf("%s %s issued by %s for contract %s expires on %s. Payments under the contract will be blocked without a renewed document.",
+				d.Type, d.DocumentNo, d.Issuer, d.ContractID, d.ExpiryDate.Format("2006-01-02")))
+		n.ReferenceID = &d.ID
+		n.Params = map[string]string{
+			"type":        string(d.Type),
+			"document_no": d.DocumentNo,
+			"issuer":      d.Issuer,
+			"contract_id": d.ContractID.String(),
+			"expiry_date": d.ExpiryDate.Format("2006-01-02"),
+			"days_left":   strconv.Itoa(left),
+		}
+		if err := s.notifier.Notify(ctx, n); err != nil {
+			return result, err
+		}
+	}
+	return result, nil
+}
+
+func (s *ComplianceService) checkConditionalWaiver(documents []*entity.ComplianceDocument, amountCents int64, date time.Time, check *entity.ComplianceCheck) string {
+	var best *entity.ComplianceDocument
+	for _, d := range documents {
+		if d.Type != entity.ComplianceConditionalWaiver || d.PaymentID != nil || !d.IsValidOn(date) {
+			continue
+		}
+		if d.Amount >= amountCents && (best == nil || d.Amount < best.Amount) {
+			best = d
+		}
+	}
+	if best == nil {
+		return fmt.Sprintf("no unused conditional lien waiver covering %d", amountCents)
+	}
+	check.WaiverID = &best.ID
+	return ""
+}
+
+func (s *ComplianceService) checkUnconditionalWaiver(ctx context.Context, projectID, contractID uuid.UUID, documents []*entity.ComplianceDocument, date time.Time) (string, error) {
+	transactions, err := s.transactions.FindByProjectID(ctx, projectID)
+	if err != nil {
+		return "", err
+	}
+
+	var lastPayment time.Time
+	for _, tx := range transactions {
+		if tx.Type != entity.TransactionTypePayment || tx.ContractID == nil || *tx.ContractID != contractID {
+			continue
+		}
+		if tx.EffectiveDate.After(lastPayment) {
+			lastPayment = tx.EffectiveDate
+		}
+	}
+	// The first payment under a contract has nothing to waive yet
+	if lastPayment.IsZero() {
+		return "", nil
+	}
+
+	for _, d := range documents {
+		if d.Type == entity.ComplianceUnconditionalWaiver && d.IsValidOn(date) && !d.ThroughDate.Before(truncateDay(lastPayment)) {
+			return "", nil
+		}
+	}
+	return fmt.Sprintf("no unconditional lien waiver for the payment of %s", lastPayment.Format("2006-01-02")), nil
+}
+
+func checkDocumentInForce(documents []*entity.ComplianceDocument, docType entity.ComplianceDocumentType, date time.Time) string {
+	expired := false
+	for _, d := range documents {
+		if d.Type != docType {
+			continue
+		}
+		if d.IsValidOn(date) {
+			return ""
+		}
+		if d.RevokedAt == nil && d.ExpiryDate != nil && date.After(*d.ExpiryDate) {
+			expired = true
+		}
+	}
+	if expired {
+		return "document on file has expired"
+	}
+	return "document not on file"
+}
+
+func truncateDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+// ComplianceExpiryJob runs the daily compliance document expiry check
+type ComplianceExpiryJob struct {
+	compliance *ComplianceService
+}
+
+// NewComplianceExpiryJob creates the scheduled compliance expiry check job
+func NewComplianceExpiryJob(compliance *ComplianceService) *ComplianceExpiryJob {
+	return &ComplianceExpiryJob{compliance: compliance}
+}
+
+func (j *ComplianceExpiryJob) ID() string {
+	return "compliance-expiry-check"
+}
+
+func (j *ComplianceExpiryJob) Execute(ctx context.Context) error {
+	_, err := j.compliance.CheckExpiries(ctx, time.Now())
+	return err
+}
@@ -0,0 +1,212 @@
+// Copyright (c) 2026 Muhammet Ali Büyük. All rights reserved.
+// This source code is proprietary. Confidential and private.
+// Unauthorized copying or distribution is strictly prohibited.
+// Contact: iletisim@alibuyuk.net | Website: alibuyuk.net
+
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/qantesm/subflow/internal/core/entity"
+)
+
+type stubComplianceRepository struct {
+	documents    []*entity.ComplianceDocument
+	requirements []*entity.ComplianceRequirement
+}
+
+func (r *stubComplianceRepository) SaveDocument(ctx context.Context, d *entity.ComplianceDocument) error {
+	r.documents = append(r.documents, d)
+	return nil
+}
+
+func (r *stubComplianceRepository) UpdateDocument(ctx context.Context, d *entity.ComplianceDocument) error {
+	return nil
+}
+
+func (r *stubComplianceRepository) FindDocumentByID(ctx context.Context, id uuid.UUID) (*entity.ComplianceDocument, error) {
+	for _, d := range r.documents {
+		if d.ID == id {
+			return d, nil
+		}
+	}
+	return nil, entity.ErrComplianceDocumentNotFound
+}
+
+func (r *stubComplianceRepository) FindDocumentsByContract(ctx context.Context, contractID uuid.UUID) ([]*entity.ComplianceDocument, error) {
+	var result []*entity.ComplianceDocument
+	for _, d := range r.documents {
+		if d.ContractID == contractID {
+			result = append(result, d)
+		}
+	}
+	return result, nil
+}
+
+func (r *stubComplianceRepository) FindExpiringDocuments(ctx context.Context, before time.Time) ([]*entity.ComplianceDocument, error) {
+	var result []*entity.ComplianceDocument
+	for _, d := range r.documents {
+		if d.RevokedAt == nil && d.ExpiryDate != nil && d.ExpiryDate.Before(before) {
+			result = append(result, d)
+		}
+	}
+	return result, nil
+}
+
+func (r *stubComplianceRepository) SaveRequirement(ctx context.Context, req *entity.ComplianceRequirement) error {
+	r.requirements = append(r.requirements, req)
+	return nil
+}
+
+func (r *stubComplianceRepository) DeleteRequirement(ctx context.Context, id uuid.UUID) error {
+	return nil
+}
+
+func (r *stubComplianceRepository) FindRequirementsByProject(ctx context.Context, projectID uuid.UUID) ([]*entity.ComplianceRequirement, error) {
+	return r.requirements, nil
+}
+
+func TestComplianceGateBlocksPayments(t *testing.T) {
+	ctx := context.Background()
+	txRepo := &stubTransactionRepository{}
+	inbox := &stubNotificationRepository{}
+	// An undelivered email must not undo an override payment
+	compliance := NewComplianceService(&stubComplianceRepository{}, txRepo, NewNotificationService(inbox, failingNotifier{}), 0)
+	ledger := NewLedgerService(txRepo)
+	ledger.SetPaymentGate(compliance)
+	transactor := &stubTransactor{}
+	ledger.SetTransactor(transactor)
+	audits := &stubAuditRepository{}
+	compliance.SetAuditor(NewAuditService(audits, nil))
+
+	projectID, contractID := uuid.New(), uuid.New()
+	ctx = WithAuditActor(ctx, AuditActor{TenantID: uuid.New()})
+	for _, docType := range []entity.ComplianceDocumentType{
+		entity.ComplianceInsurance,
+		entity.ComplianceConditionalWaiver,
+		entity.ComplianceUnconditionalWaiver,
+	} {
+		if err := compliance.AddRequirement(ctx, entity.NewComplianceRequirement(projectID, docType, uuid.New())); err != nil {
+			t.Fatalf("AddRequirement(%s) error = %v", docType, err)
+		}
+	}
+	pay := func(date time.Time, amount int64, override string) (*entity.Transaction, error) {
+		return ledger.RecordPaymentWithMetadata(ctx, projectID, &contractID, amount, "TRY", date, entity.TransactionMetadata{
+			BankReceiptNo:      "DEK-" + date.Format("0102"),
+			ComplianceOverride: override,
+		}, uuid.New())
+	}
+
+	if _, err := pay(day(2026, 3, 10), 500000, ""); !errors.Is(err, entity.ErrSubcontractorNonCompliant) {
+		t.Fatalf("payment without documents error = %v", err)
+	}
+
+	policyEnd := day(2026, 12, 31)
+	policy := entity.NewComplianceDocument(projectID, contractID, entity.ComplianceInsurance, "POL-88", "Anadolu Sigorta", day(2026, 1, 1), uuid.New())
+	policy.ExpiryDate = &policyEnd
+	waiver := entity.NewComplianceDocument(projectID, contractID, entity.ComplianceConditionalWaiver, "HF-1", "Yılmaz İnşaat", day(2026, 3, 1), uuid.New())
+	waiver.Amount = 500000
+	for _, d := range []*entity.ComplianceDocument{policy, waiver} {
+		if err := compliance.AddDocument(ctx, d); err != nil {
+			t.Fatalf("AddDocument(%s) error = %v", d.Type, err)
+		}
+	}
+
+	// First payment: nothing paid before, so no unconditional waiver is due yet
+	first, err := pay(day(2026, 3, 10), 500000, "")
+	if err != nil {
+		t.Fatalf("compliant payment error = %v", err)
+	}
+	if waiver.PaymentID == nil || *waiver.PaymentID != first.ID || *first.ContractID != contractID {
+		t.Errorf("waiver not consumed by payment: %+v", waiver)
+	}
+
+	// Second payment: the waiver is used and the first payment has no ibraname
+	check, err := compliance.CheckPayment(ctx, projectID, contractID, 300000, day(2026, 4, 10))
+	if err != nil {
+		t.Fatalf("CheckPayment() error = %v", err)
+	}
+	if check.Compliant || len(check.Issues) != 2 {
+		t.Errorf("second payment check = %+v", check)
+	}
+	if _, err := pay(day(2026, 4, 10), 300000, ""); !errors.Is(err, entity.ErrSubcontractorNonCompliant) {
+		t.Errorf("second payment error = %v", err)
+	}
+
+	// An override lets the payment through and leaves a trace in the inbox
+	if _, err := pay(day(2026, 4, 10), 300000, "Şantiye durmasın, belgeler yolda"); err != nil {
+		t.Fatalf("override payment error = %v", err)
+	}
+	if len(inbox.notifications) != 1 || inbox.notifications[0].Kind != entity.NotificationComplianceOverride {
+		t.Errorf("override notifications = %+v", inbox.notifications)
+	}
+	counts := make(map[string]int)
+	for _, log := range audits.logs {
+		counts[log.Action]++
+	}
+	if counts[entity.AuditActionComplianceRequirement] != 3 || counts[entity.AuditActionComplianceDocument] != 2 ||
+		counts[entity.AuditActionComplianceWaiverUse] != 1 || counts[entity.AuditActionComplianceOverride] != 1 {
+		t.Errorf("compliance audit actions = %v", counts)
+	}
+
+	// Payments outside a contract are not gated
+	if _, err := ledger.RecordPayment(ctx, projectID, nil, 1000, "TRY", "DEK-X", uuid.New()); err != nil {
+		t.Errorf("payment without contract error = %v", err)
+	}
+	// Every gated payment was checked, saved and its waiver marked in one unit of work
+	if transactor.units != 4 {
+		t.Errorf("units of work = %d, want one per contract payment", transactor.units)
+	}
+}
+
+func TestComplianceExpiryAlerts(t *testing.T) {
+	ctx := context.Background()
+	inbox := &stubNotificationRepository{}
+	repo := &stubComplianceRepository{}
+	s := NewComplianceService(repo, &stubTransactionRepository{}, NewNotificationService(inbox), 10)
+	projectID, contractID := uuid.New(), uuid.New()
+
+	expiry := day(2026, 6, 30)
+	clearance := entity.NewComplianceDocument(projectID, contractID, entity.ComplianceTaxClearance, "VD-301", "Kadıköy Vergi Dairesi", day(2026, 5, 1), uuid.New())
+	clearance.ExpiryDate = &expiry
+	if err := s.AddDocument(ctx, clearance); err != nil {
+		t.Fatalf("AddDocument() error = %v", err)
+	}
+	if err := s.AddRequirement(ctx, entity.NewComplianceRequirement(projectID, entity.ComplianceTaxClearance, uuid.New())); err != nil {
+		t.Fatalf("AddRequirement() error = %v", err)
+	}
+
+	for _, step := range []struct {
+		now       time.Time
+		wantInbox int
+	}{
+		{day(2026, 6, 19), 0}, // 11 days left
+		{day(2026, 6, 20), 1}, // Inside the window
+		{day(2026, 6, 25), 1}, // Already alerted
+	} {
+		if _, err := s.CheckExpiries(ctx, step.now); err != nil {
+			t.Fatalf("CheckExpiries() error = %v", err)
+		}
+		if len(inbox.notifications) != step.wantInbox {
+			t.Errorf("%s: inbox %d, want %d", step.now.Format("2006-01-02"), len(inbox.notifications), step.wantInbox)
+		}
+	}
+
+	check, err := s.CheckPayment(ctx, projectID, contractID, 1000, day(2026, 7, 1))
+	if err != nil {
+		t.Fatalf("CheckPayment() error = %v", err)
+	}
+	if check.Compliant || check.Issues[0].Reason != "document on file has expired" {
+		t.Errorf("check after expiry = %+v", check)
+	}
+
+	invalid := entity.NewComplianceDocument(projectID, contractID, entity.ComplianceUnconditionalWaiver, "IB-1", "", day(2026, 5, 1), uuid.New())
+	if err := s.AddDocument(ctx, invalid); !errors.Is(err, entity.ErrInvalidComplianceDocument) {
+		t.Errorf("waiver without through date error = %v", err)
+	}
+}
@@ -0,0 +1,90 @@
+// Copyright (c) 2026 Muhammet Ali Büyük. All rights reserved.
+// This source code is proprietary. Confidential and private.
+// Unauthorized copying or distribution is strictly prohibited.
+// Contact: iletisim@alibuyuk.net | Website: alibuyuk.net
+
+package service
+
+import (
+	"github.com/qantesm/subflow/internal/core/entity"
+)
+
+// G703LineItem is one schedule of values row on the AIA G703 continuation sheet
+type G703LineItem struct {
+	ItemNo          string `json:"item_no"`          // Column A
+	Description     string `json:"description"`      // Column B - İş kalemi
+	ScheduledValue  int64  `json:"scheduled_value"`  // Column C - Cents
+	PreviousWork    int64  `json:"previous_work"`    // Column D - Cents
+	CurrentWork     int64  `json:"current_work"`     // Column E - Cents
+	StoredMaterials int64  `json:"stored_materials"` // Column F - Cents
+}
+
+// G703LineResult contains the calculated columns for a continuation sheet row
+type G703LineResult struct {
+	G703LineItem
+	TotalCompletedAndStored int64 `json:"total_completed_and_stored"` // Column G = D + E + F
+	PercentComplete         int64 `json:"percent_complete"`           // G / C in basis points
+	BalanceToFinish         int64 `json:"balance_to_finish"`          // Column H = C - G
+	Retainage               int64 `json:"retainage"`                  // Column I
+}
+
+// G703ContinuationSheet is the full AIA G703 continuation sheet with grand totals
+type G703ContinuationSheet struct {
+	Lines                 []G703LineResult `json:"lines"`
+	GrandTotal            G703LineResult   `json:"grand_total"`
+	LaborRetainageRate    int64            `json:"labor_retainage_rate"`    // Basis points
+	MaterialRetainageRate int64            `json:"material_retainage_rate"` // Basis points
+}
+
+// CalculateContinuation performs the AIA G703 continuation sheet calculations
+// Retainage per line mirrors the G702 rules: labor rate on work, material rate on stored materials
+func (c *Calculator) CalculateContinuation(lines []G703LineItem, laborRetainageRate, materialRetainageRate int64) (*G703ContinuationSheet, error) {
+	sheet := &G703ContinuationSheet{
+		Lines:                 make([]G703LineResult, 0, len(lines)),
+		LaborRetainageRate:    laborRetainageRate,
+		MaterialRetainageRate: materialRetainageRate,
+	}
+	sheet.GrandTotal.Description = "GRAND TOTAL"
+
+	for _, line := range lines {
+		if line.ScheduledValue < 0 {
+			return nil, entity.ErrInvalidContractAmount
+		}
+		if line.PreviousWork < 0 || line.CurrentWork < 0 || line.StoredMaterials < 0 {
+			return nil, entity.ErrInvalidAmount
+		}
+
+		result := c.calculateLine(line, laborRetainageRate, materialRetainageRate)
+		sheet.Lines = append(sheet.Lines, result)
+
+		total := &sheet.GrandTotal
+		total.ScheduledValue += result.ScheduledValue
+		total.PreviousWork += result.PreviousWork
+		total.CurrentWork += result.CurrentWork
+		total.StoredMaterials += result.StoredMaterials
+		total.TotalCompletedAndStored += result.TotalCompletedAndStored
+		total.BalanceToFinish += result.BalanceToFinish
+		total.Retainage += result.Retainage
+	}
+
+	if sheet.GrandTotal.ScheduledValue > 0 {
+		sheet.GrandTotal.PercentComplete = (sheet.GrandTotal.TotalCompletedAndStored * 10000) / sheet.GrandTotal.ScheduledValue
+	}
+
+	return sheet, nil
+}
+
+func (c *Calculator) calculateLine(line G703LineItem, laborRate, materialRate int64) G703LineResult {
+	result := G703LineResult{G703LineItem: line}
+
+	result.TotalCompletedAndStored = line.PreviousWork + line.CurrentWork + line.StoredMaterials
+	result.BalanceToFinish = line.ScheduledValue - result.TotalCompletedAndStored
+	result.Retainage = c.calculatePercentage(line.PreviousWork+line.CurrentWork, laborRate) +
+		c.calculatePercentage(line.StoredMaterials, materialRate)
+
+	if line.ScheduledValue > 0 {
+		result.PercentComplete = (result.TotalCompletedAndStored * 10000) / line.ScheduledValue
+	}
+
+	return result
+}
@@ -0,0 +1,106 @@
+// Copyright (c) 2026 Muhammet Ali Büyük. All rights reserved.
+// This source code is proprietary. Confidential and private.
+// Unauthorized copying or distribution is strictly prohibited.
+// Contact: iletisim@alibuyuk.net | Website: alibuyuk.net
+
+package service
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/qantesm/subflow/internal/core/entity"
+)
+
+// TestCalculator_Continuation tests the G703 continuation sheet totals
+func TestCalculator_Continuation(t *testing.T) {
+	calc := NewCalculator()
+
+	lines := []G703LineItem{
+		{ItemNo: "1", Description: "Excavation", ScheduledValue: 20000000, PreviousWork: 10000000, CurrentWork: 5000000},
+		{ItemNo: "2", Description: "Concrete", ScheduledValue: 30000000, CurrentWork: 6000000, StoredMaterials: 2000000},
+	}
+
+	sheet, err := calc.CalculateContinuation(lines, 1000, 500)
+	if err != nil {
+		t.Fatalf("CalculateContinuation returned error: %v", err)
+	}
+
+	if len(sheet.Lines) != 2 {
+		t.Fatalf("len(Lines) = %d, want 2", len(sheet.Lines))
+	}
+
+	// Line 1: G = 15000000, 75% complete, retainage = 15000000 * 10%
+	first := sheet.Lines[0]
+	if first.TotalCompletedAndStored != 15000000 {
+		t.Errorf("Line 1 TotalCompletedAndStored = %d, want 15000000", first.TotalCompletedAndStored)
+	}
+	if first.PercentComplete != 7500 {
+		t.Errorf("Line 1 PercentComplete = %d, want 7500", first.PercentComplete)
+	}
+	if first.Retainage != 1500000 {
+		t.Errorf("Line 1 Retainage = %d, want 1500000", first.Retainage)
+	}
+
+	// Line 2 retainage = 6000000 * 10% + 2000000 * 5%
+	if sheet.Lines[1].Retainage != 700000 {
+		t.Errorf("Line 2 Retainage = %d, want 700000", sheet.Lines[1].Retainage)
+	}
+
+	total := sheet.GrandTotal
+	if total.ScheduledValue != 50000000 {
+		t.Errorf("GrandTotal ScheduledValue = %d, want 50000000", total.ScheduledValue)
+	}
+	if total.TotalCompletedAndStored != 23000000 {
+		t.Errorf("GrandTotal TotalCompletedAndStored = %d, want 23000000", total.TotalCompletedAndStored)
+	}
+	if total.BalanceToFinish != 27000000 {
+		t.Errorf("GrandTotal BalanceToFinish = %d, want 27000000", total.BalanceToFinish)
+	}
+	if total.Retainage != 2200000 {
+		t.Errorf("GrandTotal Retainage = %d, want 2200000", total.Retainage)
+	}
+	if total.PercentComplete != 4600 {
+		t.Errorf("GrandTotal PercentComplete = %d, want 4600", total.PercentComplete)
+	}
+}
+
+// TestCalculator_ContinuationNegativeValues tests validation of line items
+func TestCalculator_ContinuationNegativeValues(t *testing.T) {
+	calc := NewCalculator()
+
+	_, err := calc.CalculateContinuation([]G703LineItem{{ScheduledValue: 1000, CurrentWork: -1}}, 1000, 500)
+	if err != entity.ErrInvalidAmount {
+		t.Errorf("error = %v, want %v", err, entity.ErrInvalidAmount)
+	}
+}
+
+// TestBalanceEffect tests that running balances match CalculateBalance and the summary
+func TestBalanceEffect(t *testing.T) {
+	projectID := uuid.New()
+	userID := uuid.New()
+
+	txs := []*entity.Transaction{
+		entity.NewTransaction(projectID, entity.TransactionTypeInvoice, 100000, "TRY", userID),
+		entity.NewTransaction(projectID, entity.TransactionTypeRetainageHeld, 10000, "TRY", userID),
+		entity.NewTransaction(projectID, entity.TransactionTypePayment, 60000, "TRY", userID),
+		entity.NewTransaction(projectID, entity.TransactionTypeDeduction, 5000, "TRY", userID),
+		entity.NewTransaction(projectID, entity.TransactionTypeAdvancePayment, 20000, "TRY", userID),
+		entity.NewTransaction(projectID, entity.TransactionTypeAdvanceRecovery, 8000, "TRY", userID),
+		entity.NewTransaction(projectID, entity.TransactionTypeRetainageRelease, 4000, "TRY", userID),
+	}
+
+	var running int64
+	for _, tx := range txs {
+		running += BalanceEffect(tx)
+	}
+
+	ledger := NewLedgerService(nil)
+	if got := ledger.CalculateBalance(txs); got != running || got != 19000 {
+		t.Errorf("CalculateBalance = %d, running = %d, want 19000", got, running)
+	}
+	// The summary must agree with the running balance for every transaction type
+	if summaries := SummarizeTransactions(projectID, txs); len(summaries) != 1 || summaries[0].CurrentBalance != running {
+		t.Errorf("SummarizeTransactions = %+v, running = %d", summaries, running)
+	}
+}
@@ -0,0 +1,327 @@
+// Copyright (c) 2026 Muhammet Ali Büyük. All rights reserved.
+// This source code is proprietary. Confidential and private.
+// Unauthorized copying or distribution is strictly prohibited.
+// Contact: iletisim@alibuyuk.net | Website: alibuyuk.net
+
+package service
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/qantesm/subflow/internal/core/entity"
+)
+
+// CostRepository is the port for job cost persistence
+type CostRepository interface {
+	SaveCostCode(ctx context.Context, c *entity.CostCode) error // ErrCostCodeExists on a duplicate code
+	FindCostCodeByID(ctx context.Context, id uuid.UUID) (*entity.CostCode, error)
+	FindCostCodesByProject(ctx context.Context, projectID uuid.UUID) ([]*entity.CostCode, error)
+
+	SaveBudgetLine(ctx context.Context, b *entity.BudgetLine) error
+	FindBudgetLinesByProject(ctx context.Context, projectID uuid.UUID) ([]*entity.BudgetLine, error)
+
+	SaveCommitment(ctx context.Context, c *entity.CostCommitment) error
+	FindCommitmentByID(ctx context.Context, id uuid.UUID) (*entity.CostCommitment, error)
+	FindCommitmentsByProject(ctx context.Context, projectID uuid.UUID) ([]*entity.CostCommitment, error)
+
+	SaveVendorInvoice(ctx context.Context, v *entity.VendorInvoice) error // ErrVendorInvoiceExists on a duplicate vendor/invoice number
+	FindVendorInvoicesByProject(ctx context.Context, projectID uuid.UUID) ([]*entity.VendorInvoice, error)
+}
+
+// JobCostLine is one cost code of the job cost report; parent codes include their children
+type JobCostLine struct {
+	CostCodeID           uuid.UUID  `json:"cost_code_id"`
+	ParentID             *uuid.UUID `json:"parent_id,omitempty"`
+	Code                 string     `json:"code"`
+	Name                 string     `json:"name"`
+	Level                int        `json:"level"` // 0 for top-level codes
+	Budget               int64      `json:"budget"`
+	Committed            int64      `json:"committed"`
+	Actual               int64      `json:"actual"`
+	ForecastToComplete   int64      `json:"forecast_to_complete"`   // ForecastAtCompletion - Actual
+	ForecastAtCompletion int64      `json:"forecast_at_completion"` // The larger of budget, committed and actual
+	Variance             int64      `json:"variance"`               // Budget - ForecastAtCompletion; negative is an overrun
+	PercentSpent         int64      `json:"percent_spent"`          // Actual / Budget, basis points
+}
+
+// JobCostReport compares budget, committed and actual cost per cost code
+// and projects the margin against the contract amount
+type JobCostReport struct {
+	ProjectID           uuid.UUID     `json:"project_id"`
+	ProjectCode         string        `json:"project_code"`
+	ProjectName         string        `json:"project_name"`
+	Currency            string        `json:"currency"`
+	AsOf                time.Time     `json:"as_of"`
+	Lines               []JobCostLine `json:"lines"` // Depth-first, siblings ordered by code
+	Total               JobCostLine   `json:"total"`
+	ContractAmount      int64         `json:"contract_amount"`
+	ProjectedMargin     int64         `json:"projected_margin"`      // ContractAmount - Total.ForecastAtCompletion
+	ProjectedMarginRate int64         `json:"projected_margin_rate"` // ProjectedMargin / ContractAmount, basis points
+}
+
+// JobCostService tracks budget, committed and actual cost per cost code
+type JobCostService struct {
+	repo      CostRepository
+	projects  ProjectRepository
+	architect string
+}
+
+// NewJobCostService creates a new job cost service
+func NewJobCostService(repo CostRepository, projects ProjectRepository) *JobCostService {
+	return &JobCostService{
+		repo:      repo,
+		projects:  projects,
+		architect: "Muhammet-Ali-Buyuk",
+	}
+}
+
+// AddCostCode stores a cost code; a parent must belong to the same project
+func (s *JobCostService) AddCostCode(ctx context.Context, c *entity.CostCode) error {
+	if _, err := s.projects.FindByID(ctx, c.ProjectID); err != nil {
+		return err
+	}
+	if err := c.Validate(); err != nil {
+		return err
+	}
+	if c.ParentID != nil {
+		if err := s.checkCostCode(ctx, c.ProjectID, *c.ParentID); err != nil {
+			return err
+		}
+	}
+	return s.repo.SaveCostCode(ctx, c)
+}
+
+// ListCostCodes returns the cost codes of a project
+func (s *JobCostService) ListCostCodes(ctx context.Context, projectID uuid.UUID) ([]*entity.CostCode, error) {
+	return s.repo.FindCostCodesByProject(ctx, projectID)
+}
+
+// AddBudgetLine stores a budget line in the project currency
+func (s *JobCostService) AddBudgetLine(ctx context.Context, b *entity.BudgetLine) error {
+	if err := s.checkCurrency(ctx, b.ProjectID, &b.Currency); err != nil {
+		return err
+	}
+	if err := b.Validate(); err != nil {
+		return err
+	}
+	if err := s.checkCostCode(ctx, b.ProjectID, b.CostCodeID); err != nil {
+		return err
+	}
+	return s.repo.SaveBudgetLine(ctx, b)
+}
+
+// ListBudgetLines returns the budget lines of a project
+func (s *JobCostService) ListBudgetLines(ctx context.Context, projectID uuid.UUID) ([]*entity.BudgetLine, error) {
+	return s.repo.FindBudgetLinesByProject(ctx, projectID)
+}
+
+// AddCommitment stores a subcontract or purchase order commitment in the project currency
+func (s *JobCostService) AddCommitment(ctx context.Context, c *entity.CostCommitment) error {
+	if err := s.checkCurrency(ctx, c.ProjectID, &c.Currency); err != nil {
+		return err
+	}
+	if err := c.Validate(); err != nil {
+		return err
+	}
+	if err := s.checkCostCode(ctx, c.ProjectID, c.CostCodeID); err != nil {
+		return err
+	}
+	return s.repo.SaveCommitment(ctx, c)
+}
+
+// ListCommitments returns the commitments of a project
+func (s *JobCostService) ListCommitments(ctx context.Context, projectID uuid.UUID) ([]*entity.CostCommitment, error) {
+	return s.repo.FindCommitmentsByProject(ctx, projectID)
+}
+
+// RecordVendorInvoice stores actual cost. An invoice against a commitment
+// takes the commitment's cost code and vendor when they are not given.
+func (s *JobCostService) RecordVendorInvoice(ctx context.Context, v *entity.VendorInvoice) error {
+	if v.CommitmentID != nil {
+		c, err := s.repo.FindCommitmentByID(ctx, *v.CommitmentID)
+		if err != nil {
+			return err
+		}
+		if c.ProjectID != v.ProjectID {
+			return entity.ErrInvalidVendorInvoice
+		}
+		if v.CostCodeID == uuid.Nil {
+			v.CostCodeID = c.CostCodeID
+		}
+		if v.Vendor == "" {
+			v.Vendor = c.Vendor
+		}
+	}
+
+	if err := s.checkCurrency(ctx, v.ProjectID, &v.Currency); err != nil {
+		return err
+	}
+	if err := v.Validate(); err != nil {
+		return err
+	}
+	if err := s.checkCostCode(ctx, v.ProjectID, v.CostCodeID); err != nil {
+		return err
+	}
+	return s.repo.SaveVendorInvoice(ctx, v)
+}
+
+// ListVendorInvoices returns the vendor invoices of a project
+func (s *JobCostService) ListVendorInvoices(ctx context.Context, projectID uuid.UUID) ([]*entity.VendorInvoice, error) {
+	return s.repo.FindVendorInvoicesByProject(ctx, projectID)
+}
+
+// Report builds the job cost report of a project.
+// Each code is forecast at the larger of its budget, committed and actual
+// cost; parent codes and the total add up the forecasts of their children.
+func (s *JobCostService) Report(ctx context.Context, projectID uuid.UUID, now time.Time) (*JobCostReport, error) {
+	project, err := s.projects.FindByID(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	codes, err := s.repo.FindCostCodesByProject(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	budget, err := s.repo.FindBudgetLinesByProject(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	commitments, err := s.repo.FindCommitmentsByProject(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	invoices, err := s.repo.FindVendorInvoicesByProject(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	own := make(map[uuid.UUID]*JobCostLine, len(codes))
+	children := make(map[uuid.UUID][]*entity.CostCode)
+	var roots []*entity.CostCode
+	for _, c := range codes {
+		own[c.ID] = &JobCostLine{CostCodeID: c.ID, ParentID: c.ParentID, Code: c.Code, Name: c.Name}
+	}
+	for _, c := range codes {
+		if c.ParentID != nil && own[*c.ParentID] != nil {
+			children[*c.ParentID] = append(children[*c.ParentID], c)
+		} else {
+			roots = append(roots, c)
+		}
+	}
+	for _, b := range budget {
+		if line := own[b.CostCodeID]; line != nil {
+			line.Budget += b.Amount
+		}
+	}
+	for _, c := range commitments {
+		if line := own[c.CostCodeID]; line != nil {
+			line.Committed += c.Amount
+		}
+	}
+	for _, v := range invoices {
+		if line := own[v.CostCodeID]; line != nil {
+			line.Actual += v.Amount
+		}
+	}
+
+	report := &JobCostReport{
+		ProjectID:      project.ID,
+		ProjectCode:    project.Code,
+		ProjectName:    project.Name,
+		Currency:       project.Currency,
+		AsOf:           now,
+		ContractAmount: project.ContractAmount,
+		Total:          JobCostLine{Code: "TOTAL", Name: "Toplam"},
+	}
+
+	// Depth-first so each parent precedes its children; the parent's line is
+	// filled in after its subtree has been added up
+	var walk func(c *entity.CostCode, level int) JobCostLine
+	walk = func(c *entity.CostCode, level int) JobCostLine {
+		line := *own[c.ID]
+		line.Level = level
+		line.ForecastAtCompletion = maxInt64(line.Budget, line.Committed, line.Actual)
+
+		index := len(report.Lines)
+		report.Lines = append(report.Lines, line)
+		for _, child := range sortedCostCodes(children[c.ID]) {
+			sub := walk(child, level+1)
+			addJobCost(&line, sub)
+		}
+		finishJobCostLine(&line)
+		report.Lines[index] = line
+		return line
+	}
+	for _, root := range sortedCostCodes(roots) {
+		addJobCost(&report.Total, walk(root, 0))
+	}
+	finishJobCostLine(&report.Total)
+
+	report.ProjectedMargin = report.ContractAmount - report.Total.ForecastAtCompletion
+	if report.ContractAmount != 0 {
+		report.ProjectedMarginRate = report.ProjectedMargin * 10000 / report.ContractAmount
+	}
+	return report, nil
+}
+
+// checkCostCode makes sure a cost code exists in the given project
+func (s *JobCostService) checkCostCode(ctx context.Context, projectID, costCodeID uuid.UUID) error {
+	c, err := s.repo.FindCostCodeByID(ctx, costCodeID)
+	if err != nil {
+		return err
+	}
+	if c.ProjectID != projectID {
+		return entity.ErrCostCodeProjectMismatch
+	}
+	return nil
+}
+
+// checkCurrency defaults an empty currency to the project's and refuses any other
+func (s *JobCostService) checkCurrency(ctx context.Context, projectID uuid.UUID, currency *string) error {
+	project, err := s.projects.FindByID(ctx, projectID)
+	if err != nil {
+		return err
+	}
+	if *currency == "" {
+		*currency = project.Currency
+	}
+	if *currency != project.Currency {
+		return entity.ErrCurrencyMismatch
+	}
+	return nil
+}
+
+func addJobCost(dst *JobCostLine, src JobCostLine) {
+	dst.Budget += src.Budget
+	dst.Committed += src.Committed
+	dst.Actual += src.Actual
+	dst.ForecastAtCompletion += src.ForecastAtCompletion
+}
+
+func finishJobCostLine(line *JobCostLine) {
+	line.ForecastToComplete = line.ForecastAtCompletion - line.Actual
+	line.Variance = line.Budget - line.ForecastAtCompletion
+	if line.Budget != 0 {
+		line.PercentSpent = line.Actual * 10000 / line.Budget
+	}
+}
+
+func sortedCostCodes(codes []*entity.CostCode) []*entity.CostCode {
+	sorted := append([]*entity.CostCode(nil), codes...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Code < sorted[j].Code })
+	return sorted
+}
+
+func maxInt64(values ...int64) int64 {
+	m := values[0]
+	for _, v := range values[1:] {
+		if v > m {
+			m = v
+		}
+	}
+	return m
+}
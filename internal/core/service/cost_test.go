@@ -0,0 +1,181 @@
+// Copyright (c) 2026 Muhammet Ali Büyük. All rights reserved.
+// This source code is proprietary. Confidential and private.
+// Unauthorized copying or distribution is strictly prohibited.
+// Contact: iletisim@alibuyuk.net | Website: alibuyuk.net
+
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/qantesm/subflow/internal/core/entity"
+)
+
+type stubCostRepository struct {
+	codes       []*entity.CostCode
+	budget      []*entity.BudgetLine
+	commitments []*entity.CostCommitment
+	invoices    []*entity.VendorInvoice
+}
+
+func (r *stubCostRepository) SaveCostCode(ctx context.Context, c *entity.CostCode) error {
+	for _, existing := range r.codes {
+		if existing.ProjectID == c.ProjectID && existing.Code == c.Code {
+			return entity.ErrCostCodeExists
+		}
+	}
+	r.codes = append(r.codes, c)
+	return nil
+}
+
+func (r *stubCostRepository) FindCostCodeByID(ctx context.Context, id uuid.UUID) (*entity.CostCode, error) {
+	for _, c := range r.codes {
+		if c.ID == id {
+			return c, nil
+		}
+	}
+	return nil, entity.ErrCostCodeNotFound
+}
+
+func (r *stubCostRepository) FindCostCodesByProject(ctx context.Context, projectID uuid.UUID) ([]*entity.CostCode, error) {
+	return r.codes, nil
+}
+
+func (r *stubCostRepository) SaveBudgetLine(ctx context.Context, b *entity.BudgetLine) error {
+	r.budget = append(r.budget, b)
+	return nil
+}
+
+func (r *stubCostRepository) FindBudgetLinesByProject(ctx context.Context, projectID uuid.UUID) ([]*entity.BudgetLine, error) {
+	return r.budget, nil
+}
+
+func (r *stubCostRepository) SaveCommitment(ctx context.Context, c *entity.CostCommitment) error {
+	r.commitments = append(r.commitments, c)
+	return nil
+}
+
+func (r *stubCostRepository) FindCommitmentByID(ctx context.Context, id uuid.UUID) (*entity.CostCommitment, error) {
+	for _, c := range r.commitments {
+		if c.ID == id {
+			return c, nil
+		}
+	}
+	return nil, entity.ErrCostCommitmentNotFound
+}
+
+func (r *stubCostRepository) FindCommitmentsByProject(ctx context.Context, projectID uuid.UUID) ([]*entity.CostCommitment, error) {
+	return r.commitments, nil
+}
+
+func (r *stubCostRepository) SaveVendorInvoice(ctx context.Context, v *entity.VendorInvoice) error {
+	r.invoices = append(r.invoices, v)
+	return nil
+}
+
+func (r *stubCostRepository) FindVendorInvoicesByProject(ctx context.Context, projectID uuid.UUID) ([]*entity.VendorInvoice, error) {
+	return r.invoices, nil
+}
+
+func TestJobCostReport(t *testing.T) {
+	ctx := context.Background()
+	projects := &stubProjectRepository{}
+	s := NewJobCostService(&stubCostRepository{}, projects)
+	user := uuid.New()
+
+	project := entity.NewProject(uuid.New(), "Bornova Hastane", "PRJ-2026-015")
+	project.ContractAmount = 100000000
+	_ = projects.Create(ctx, project)
+
+	concrete := entity.NewCostCode(project.ID, "03", "Beton işleri", user)
+	formwork := entity.NewCostCode(project.ID, "03.10", "Kalıp", user)
+	formwork.ParentID = &concrete.ID
+	pour := entity.NewCostCode(project.ID, "03.20", "Beton dökümü", user)
+	pour.ParentID = &concrete.ID
+	electrical := entity.NewCostCode(project.ID, "16", "Elektrik", user)
+	for _, code := range []*entity.CostCode{electrical, concrete, pour, formwork} {
+		if err := s.AddCostCode(ctx, code); err != nil {
+			t.Fatalf("AddCostCode(%s) error = %v", code.Code, err)
+		}
+	}
+	if err := s.AddCostCode(ctx, entity.NewCostCode(project.ID, "16", "Tekrar", user)); !errors.Is(err, entity.ErrCostCodeExists) {
+		t.Errorf("duplicate cost code error = %v", err)
+	}
+
+	for _, b := range []*entity.BudgetLine{
+		entity.NewBudgetLine(project.ID, formwork.ID, "Kalıp", 20000000, "", user),
+		entity.NewBudgetLine(project.ID, pour.ID, "C30 beton", 30000000, "", user),
+		entity.NewBudgetLine(project.ID, electrical.ID, "Elektrik tesisatı", 25000000, "", user),
+	} {
+		if err := s.AddBudgetLine(ctx, b); err != nil {
+			t.Fatalf("AddBudgetLine() error = %v", err)
+		}
+	}
+	if err := s.AddBudgetLine(ctx, entity.NewBudgetLine(project.ID, pour.ID, "", 100, "USD", user)); !errors.Is(err, entity.ErrCurrencyMismatch) {
+		t.Errorf("foreign currency budget error = %v", err)
+	}
+
+	// Formwork subcontract overruns its budget; concrete is bought on a purchase order
+	contractID := uuid.New()
+	sub := entity.NewCostCommitment(project.ID, formwork.ID, entity.CommitmentSubcontract, "TS-7", "Kalıpçı Ltd", 24000000, "", user)
+	sub.ContractID = &contractID
+	po := entity.NewCostCommitment(project.ID, pour.ID, entity.CommitmentPurchaseOrder, "PO-101", "Hazır Beton AŞ", 18000000, "", user)
+	for _, c := range []*entity.CostCommitment{sub, po} {
+		if err := s.AddCommitment(ctx, c); err != nil {
+			t.Fatalf("AddCommitment() error = %v", err)
+		}
+	}
+	if err := s.AddCommitment(ctx, entity.NewCostCommitment(project.ID, formwork.ID, entity.CommitmentSubcontract, "", "", 1, "", user)); !errors.Is(err, entity.ErrInvalidCostCommitment) {
+		t.Errorf("subcontract without contract error = %v", err)
+	}
+
+	invoice := entity.NewVendorInvoice(project.ID, uuid.Nil, "", "KLP-1", day(2026, 5, 31), 12000000, "", user)
+	invoice.CommitmentID = &sub.ID
+	if err := s.RecordVendorInvoice(ctx, invoice); err != nil {
+		t.Fatalf("RecordVendorInvoice() error = %v", err)
+	}
+	if invoice.CostCodeID != formwork.ID || invoice.Vendor != "Kalıpçı Ltd" {
+		t.Errorf("invoice did not inherit from the commitment: %+v", invoice)
+	}
+	if err := s.RecordVendorInvoice(ctx, entity.NewVendorInvoice(project.ID, electrical.ID, "Elektrikçi", "E-1", day(2026, 6, 15), 5000000, "", user)); err != nil {
+		t.Fatalf("RecordVendorInvoice() error = %v", err)
+	}
+
+	report, err := s.Report(ctx, project.ID, day(2026, 6, 30))
+	if err != nil {
+		t.Fatalf("Report() error = %v", err)
+	}
+
+	order := []string{"03", "03.10", "03.20", "16"}
+	if len(report.Lines) != len(order) {
+		t.Fatalf("report has %d lines, want %d", len(report.Lines), len(order))
+	}
+	for i, code := range order {
+		if report.Lines[i].Code != code {
+			t.Errorf("line %d = %s, want %s", i, report.Lines[i].Code, code)
+		}
+	}
+
+	// 03.10: forecast at the committed 240k, 40k over budget
+	if l := report.Lines[1]; l.Level != 1 || l.ForecastAtCompletion != 24000000 || l.ForecastToComplete != 12000000 || l.Variance != -4000000 || l.PercentSpent != 6000 {
+		t.Errorf("formwork = %+v", l)
+	}
+	// 03.20: nothing invoiced yet, forecast stays at budget
+	if l := report.Lines[2]; l.ForecastAtCompletion != 30000000 || l.Variance != 0 {
+		t.Errorf("pour = %+v", l)
+	}
+	// 03 rolls up its children
+	if l := report.Lines[0]; l.Level != 0 || l.Budget != 50000000 || l.Committed != 42000000 || l.Actual != 12000000 || l.ForecastAtCompletion != 54000000 {
+		t.Errorf("concrete = %+v", l)
+	}
+
+	if report.Total.Budget != 75000000 || report.Total.ForecastAtCompletion != 79000000 || report.Total.Actual != 17000000 {
+		t.Errorf("total = %+v", report.Total)
+	}
+	if report.ProjectedMargin != 21000000 || report.ProjectedMarginRate != 2100 {
+		t.Errorf("margin = %d (%d bp), want 21000000 (2100 bp)", report.ProjectedMargin, report.ProjectedMarginRate)
+	}
+}
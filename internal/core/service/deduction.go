@@ -0,0 +1,386 @@
+// Copyright (c) 2026 Muhammet Ali Büyük. All rights reserved.
+// This source code is proprietary. Confidential and private.
+// Unauthorized copying or distribution is strictly prohibited.
+// Contact: iletisim@alibuyuk.net | Website: alibuyuk.net
+
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/qantesm/subflow/internal/core/entity"
+	"github.com/qantesm/subflow/internal/pkg"
+)
+
+// DeductionRepository is the port for deductions, recurring schedules and penalty rules
+type DeductionRepository interface {
+	Save(ctx context.Context, d *entity.Deduction) error
+	Update(ctx context.Context, d *entity.Deduction) error
+	FindByID(ctx context.Context, id uuid.UUID) (*entity.Deduction, error)
+	FindByProjectID(ctx context.Context, projectID uuid.UUID) ([]*entity.Deduction, error) // Ordered by date
+	FindByPayApplication(ctx context.Context, payApplicationID uuid.UUID) ([]*entity.Deduction, error)
+
+	SaveSchedule(ctx context.Context, s *entity.DeductionSchedule) error
+	UpdateSchedule(ctx context.Context, s *entity.DeductionSchedule) error
+	FindScheduleByID(ctx context.Context, id uuid.UUID) (*entity.DeductionSchedule, error)
+	FindSchedulesByProject(ctx context.Context, projectID uuid.UUID) ([]*entity.DeductionSchedule, error)
+	FindDueSchedules(ctx context.Context, asOf time.Time) ([]*entity.DeductionSchedule, error) // Active with NextDate on or before asOf
+
+	SavePenaltyRule(ctx context.Context, rule *entity.LatePenaltyRule) error // Replaces the project's previous rule
+	FindPenaltyRule(ctx context.Context, projectID uuid.UUID) (*entity.LatePenaltyRule, error)
+	FindPenaltyRules(ctx context.Context) ([]*entity.LatePenaltyRule, error)
+}
+
+// DeductionService records kesintiler in the ledger and hands them to pay applications
+type DeductionService struct {
+	repo       DeductionRepository
+	projects   ProjectRepository
+	ledger     *LedgerService
+	transactor Transactor // Optional: stores a deduction with its ledger entry all-or-nothing
+	audit      Auditor    // Optional: records deductions, schedules and penalty rules in the audit trail
+	architect  string
+}
+
+// NewDeductionService creates a new deduction service
+func NewDeductionService(repo DeductionRepository, projects ProjectRepository, ledger *LedgerService) *DeductionService {
+	return &DeductionService{
+		repo:      repo,
+		projects:  projects,
+		ledger:    ledger,
+		architect: "Muhammet-Ali-Buyuk",
+	}
+}
+
+// SetTransactor stores each deduction with its ledger entry, and raises each
+// schedule and penalty assessment, in one unit of work
+func (s *DeductionService) SetTransactor(transactor Transactor) {
+	s.transactor = transactor
+}
+
+// SetAuditor records every deduction, penalty, schedule change and penalty rule in the audit trail
+func (s *DeductionService) SetAuditor(audit Auditor) {
+	s.audit = audit
+}
+
+// Create validates a deduction, records it in the ledger and stores it
+// The ledger entry, referenced by the deduction ID, and the deduction are stored together.
+func (s *DeductionService) Create(ctx context.Context, d *entity.Deduction) error {
+	if err := d.Validate(); err != nil {
+		return err
+	}
+
+	err := inTx(ctx, s.transactor, func(ctx context.Context) error {
+		tx, err := s.ledger.RecordDeduction(ctx, d)
+		if err != nil {
+			return err
+		}
+		d.TransactionID = &tx.ID
+		return s.repo.Save(ctx, d)
+	})
+	if err != nil {
+		return err
+	}
+
+	action := entity.AuditActionDeductionCreate
+	if d.PenaltyDays > 0 {
+		action = entity.AuditActionDeductionPenalty
+	}
+	recordAudit(ctx, s.audit, action, "deduction", d.ID, d.ProjectID, nil, d)
+	return nil
+}
+
+// Get returns a single deduction
+func (s *DeductionService) Get(ctx context.Context, id uuid.UUID) (*entity.Deduction, error) {
+	return s.repo.FindByID(ctx, id)
+}
+
+// ListByProject returns every deduction of a project
+func (s *DeductionService) ListByProject(ctx context.Context, projectID uuid.UUID) ([]*entity.Deduction, error) {
+	return s.repo.FindByProjectID(ctx, projectID)
+}
+
+// CreateSchedule validates and stores a recurring deduction
+// Occurrences are raised by RunSchedules, including any already due.
+func (s *DeductionService) CreateSchedule(ctx context.Context, schedule *entity.DeductionSchedule) error {
+	if err := schedule.Validate(); err != nil {
+		return err
+	}
+	if err := s.repo.SaveSchedule(ctx, schedule); err != nil {
+		return err
+	}
+	recordAudit(ctx, s.audit, entity.AuditActionDeductionSchedule, "deduction_schedule", schedule.ID, schedule.ProjectID, nil, schedule)
+	return nil
+}
+
+// ListSchedules returns the recurring deductions of a project
+func (s *DeductionService) ListSchedules(ctx context.Context, projectID uuid.UUID) ([]*entity.DeductionSchedule, error) {
+	return s.repo.FindSchedulesByProject(ctx, projectID)
+}
+
+// StopSchedule ends a recurring deduction
+func (s *DeductionService) StopSchedule(ctx context.Context, id uuid.UUID) (*entity.DeductionSchedule, error) {
+	schedule, err := s.repo.FindScheduleByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	before := *schedule
+	schedule.Stop()
+	if err := s.repo.UpdateSchedule(ctx, schedule); err != nil {
+		return nil, err
+	}
+	recordAudit(ctx, s.audit, entity.AuditActionDeductionScheduleStop, "deduction_schedule", schedule.ID, schedule.ProjectID, &before, schedule)
+	return schedule, nil
+}
+
+// RunSchedules raises every occurrence due on or before now
+// Missed occurrences are caught up, each dated on its own due date. Each schedule is
+// raised and advanced in its own unit of work: one that fails is logged and retried
+// on the next run without holding up the others. Only listing the schedules fails the run.
+func (s *DeductionService) RunSchedules(ctx context.Context, now time.Time) ([]*entity.Deduction, error) {
+	schedules, err := s.repo.FindDueSchedules(ctx, now)
+	if err != nil {
+		return nil, err
+	}
+
+	var raised []*entity.Deduction
+	for _, schedule := range schedules {
+		var deductions []*entity.Deduction
+		err := inTx(ctx, s.transactor, func(ctx context.Context) error {
+			var err error
+			deductions, err = s.runSchedule(ctx, schedule, now)
+			return err
+		})
+		if err != nil {
+			pkg.Ctx(ctx).Warn().Err(err).
+				Str("schedule_id", schedule.ID.String()).
+				Str("project_id", schedule.ProjectID.String()).
+				Msg("Recurring deduction not raised")
+			continue
+		}
+		raised = append(raised, deductions...)
+	}
+	return raised, nil
+}
+
+// runSchedule raises the due occurrences of one schedule and advances it
+// Schedules of cancelled or deleted projects are stopped; those of draft
+// projects are left due and caught up once the project starts.
+func (s *DeductionService) runSchedule(ctx context.Context, schedule *entity.DeductionSchedule, now time.Time) ([]*entity.Deduction, error) {
+	project, err := s.projects.FindByID(ctx, schedule.ProjectID)
+	switch {
+	case errors.Is(err, entity.ErrProjectNotFound):
+		schedule.Stop()
+		return nil, s.repo.UpdateSchedule(ctx, schedule)
+	case err != nil:
+		return nil, err
+	case project.Status == entity.ProjectStatusCancelled:
+		schedule.Stop()
+		return nil, s.repo.UpdateSchedule(ctx, schedule)
+	case project.Status == entity.ProjectStatusDraft:
+		return nil, nil
+	}
+
+	var raised []*entity.Deduction
+	for _, date := range schedule.Due(now) {
+		d := entity.NewDeduction(schedule.ProjectID, schedule.Category, schedule.Reason, schedule.Amount, schedule.Currency, date, schedule.CreatedBy)
+		d.ContractID = schedule.ContractID
+		d.ScheduleID = &schedule.ID
+		if err := s.Create(ctx, d); err != nil {
+			return nil, err
+		}
+		raised = append(raised, d)
+	}
+	if err := s.repo.UpdateSchedule(ctx, schedule); err != nil {
+		return nil, err
+	}
+	return raised, nil
+}
+
+// SetPenaltyRule validates and stores the late completion penalty of a project
+func (s *DeductionService) SetPenaltyRule(ctx context.Context, rule *entity.LatePenaltyRule) error {
+	if err := rule.Validate(); err != nil {
+		return err
+	}
+	if _, err := s.projects.FindByID(ctx, rule.ProjectID); err != nil {
+		return err
+	}
+	var before any
+	previous, err := s.repo.FindPenaltyRule(ctx, rule.ProjectID)
+	switch {
+	case err == nil:
+		before = previous
+	case !errors.Is(err, entity.ErrPenaltyRuleNotFound):
+		return err
+	}
+	if err := s.repo.SavePenaltyRule(ctx, rule); err != nil {
+		return err
+	}
+	recordAudit(ctx, s.audit, entity.AuditActionPenaltyRuleSet, "penalty_rule", rule.ProjectID, rule.ProjectID, before, rule)
+	return nil
+}
+
+// PenaltyRule returns the late completion penalty of a project
+func (s *DeductionService) PenaltyRule(ctx context.Context, projectID uuid.UUID) (*entity.LatePenaltyRule, error) {
+	return s.repo.FindPenaltyRule(ctx, projectID)
+}
+
+// AssessLatePenalty raises the penalty accrued since the last assessment
+// The penalty to date runs per day from the project's estimated end date until the
+// substantial completion date, if any; only the part not already deducted is raised,
+// so repeated assessments never charge a day twice. Completed and cancelled projects
+// are not assessed. Returns nil when nothing new is due.
+func (s *DeductionService) AssessLatePenalty(ctx context.Context, projectID uuid.UUID, asOf time.Time, createdBy uuid.UUID) (*entity.Deduction, error) {
+	var d *entity.Deduction
+	err := inTx(ctx, s.transactor, func(ctx context.Context) error {
+		var err error
+		d, err = s.assessLatePenalty(ctx, projectID, asOf, createdBy)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+func (s *DeductionService) assessLatePenalty(ctx context.Context, projectID uuid.UUID, asOf time.Time, createdBy uuid.UUID) (*entity.Deduction, error) {
+	rule, err := s.repo.FindPenaltyRule(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	// Locked so that concurrent assessments see each other's penalties
+	project, err := s.projects.Lock(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case project.Status == entity.ProjectStatusCompleted, project.Status == entity.ProjectStatusCancelled:
+		return nil, nil
+	case project.EstimatedEndDate.IsZero():
+		return nil, nil
+	}
+
+	end := asOf
+	if sc := project.SubstantialCompletionDate; sc != nil && sc.Before(end) {
+		end = *sc
+	}
+	days := entity.DaysLate(project.EstimatedEndDate, end)
+	total := rule.PenaltyToDate(project.ContractAmount, days)
+
+	existing, err := s.repo.FindByProjectID(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	var assessed int64
+	for _, d := range existing {
+		if d.Category == entity.DeductionCategoryPenalty && d.PenaltyDays > 0 {
+			assessed += d.Amount
+		}
+	}
+	if total <= assessed {
+		return nil, nil
+	}
+
+	reason := fmt.Sprintf("Gecikme cezası: %d gün (süre bitimi %s)", days, project.EstimatedEndDate.Format("02.01.2006"))
+	d := entity.NewDeduction(projectID, entity.DeductionCategoryPenalty, reason, total-assessed, project.Currency, asOf, createdBy)
+	d.PenaltyDays = days
+	if err := s.Create(ctx, d); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// AssessLatePenalties runs AssessLatePenalty for every project with a penalty rule
+// A project that cannot be assessed is logged and skipped; only listing the rules fails the run.
+func (s *DeductionService) AssessLatePenalties(ctx context.Context, now time.Time) ([]*entity.Deduction, error) {
+	rules, err := s.repo.FindPenaltyRules(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var raised []*entity.Deduction
+	for _, rule := range rules {
+		d, err := s.AssessLatePenalty(ctx, rule.ProjectID, now, rule.UpdatedBy)
+		if errors.Is(err, entity.ErrProjectNotFound) {
+			continue
+		}
+		if err != nil {
+			pkg.Ctx(ctx).Warn().Err(err).Str("project_id", rule.ProjectID.String()).Msg("Late penalty not assessed")
+			continue
+		}
+		if d != nil {
+			raised = append(raised, d)
+		}
+	}
+	return raised, nil
+}
+
+// Pending returns the deductions dated up to periodEnd not yet taken from a pay application
+func (s *DeductionService) Pending(ctx context.Context, projectID uuid.UUID, periodEnd time.Time) ([]*entity.Deduction, int64, error) {
+	deductions, err := s.repo.FindByProjectID(ctx, projectID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var pending []*entity.Deduction
+	var total int64
+	for _, d := range deductions {
+		if !d.IsApplied() && !d.Date.After(periodEnd) {
+			pending = append(pending, d)
+			total += d.Amount
+		}
+	}
+	return pending, total, nil
+}
+
+// Apply marks deductions as taken from a pay application
+func (s *DeductionService) Apply(ctx context.Context, deductions []*entity.Deduction, payApplicationID uuid.UUID) error {
+	for _, d := range deductions {
+		d.PayApplicationID = &payApplicationID
+		if err := s.repo.Update(ctx, d); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Release returns the deductions of a rejected pay application to the pending pool
+func (s *DeductionService) Release(ctx context.Context, payApplicationID uuid.UUID) error {
+	deductions, err := s.repo.FindByPayApplication(ctx, payApplicationID)
+	if err != nil {
+		return err
+	}
+	for _, d := range deductions {
+		d.PayApplicationID = nil
+		if err := s.repo.Update(ctx, d); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DeductionJob raises due recurring deductions and late completion penalties
+type DeductionJob struct {
+	deductions *DeductionService
+}
+
+// NewDeductionJob creates the scheduled deduction job
+func NewDeductionJob(deductions *DeductionService) *DeductionJob {
+	return &DeductionJob{deductions: deductions}
+}
+
+func (j *DeductionJob) ID() string {
+	return "deduction-run"
+}
+
+func (j *DeductionJob) Execute(ctx context.Context) error {
+	now := time.Now()
+	if _, err := j.deductions.RunSchedules(ctx, now); err != nil {
+		return err
+	}
+	_, err := j.deductions.AssessLatePenalties(ctx, now)
+	return err
+}
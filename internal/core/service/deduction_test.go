@@ -0,0 +1,409 @@
+// Copyright (c) 2026 Muhammet Ali Büyük. All rights reserved.
+// This source code is proprietary. Confidential and private.
+// Unauthorized copying or distribution is strictly prohibited.
+// Contact: iletisim@alibuyuk.net | Website: alibuyuk.net
+
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/qantesm/subflow/internal/core/entity"
+)
+
+type stubProjectRepository struct {
+	projects map[uuid.UUID]*entity.Project
+	history  []*entity.ProjectStatusChange
+}
+
+func (r *stubProjectRepository) Create(ctx context.Context, project *entity.Project) error {
+	if r.projects == nil {
+		r.projects = make(map[uuid.UUID]*entity.Project)
+	}
+	r.projects[project.ID] = project
+	return nil
+}
+
+func (r *stubProjectRepository) FindByID(ctx context.Context, id uuid.UUID) (*entity.Project, error) {
+	project, ok := r.projects[id]
+	if !ok {
+		return nil, entity.ErrProjectNotFound
+	}
+	return project, nil
+}
+
+func (r *stubProjectRepository) Lock(ctx context.Context, id uuid.UUID) (*entity.Project, error) {
+	return r.FindByID(ctx, id)
+}
+
+func (r *stubProjectRepository) FindByTenant(ctx context.Context, tenantID uuid.UUID, limit, offset int) ([]*entity.Project, error) {
+	var projects []*entity.Project
+	for _, project := range r.projects {
+		if project.TenantID == tenantID {
+			projects = append(projects, project)
+		}
+	}
+	return projects, nil
+}
+
+func (r *stubProjectRepository) Update(ctx context.Context, project *entity.Project) error {
+	if _, ok := r.projects[project.ID]; !ok {
+		return entity.ErrProjectNotFound
+	}
+	r.projects[project.ID] = project
+	return nil
+}
+
+func (r *stubProjectRepository) SoftDelete(ctx context.Context, id uuid.UUID) error {
+	delete(r.projects, id)
+	return nil
+}
+
+func (r *stubProjectRepository) ChangeStatus(ctx context.Context, change *entity.ProjectStatusChange, events ...*entity.Event) error {
+	project, ok := r.projects[change.ProjectID]
+	if !ok {
+		return entity.ErrProjectNotFound
+	}
+	project.Status = change.ToStatus
+	r.history = append(r.history, change)
+	return nil
+}
+
+func (r *stubProjectRepository) FindStatusHistory(ctx context.Context, projectID uuid.UUID) ([]*entity.ProjectStatusChange, error) {
+	var history []*entity.ProjectStatusChange
+	for _, change := range r.history {
+		if change.ProjectID == projectID {
+			history = append(history, change)
+		}
+	}
+	return history, nil
+}
+
+type stubDeductionRepository struct {
+	deductions []*entity.Deduction
+	schedules  []*entity.DeductionSchedule
+	rules      map[uuid.UUID]*entity.LatePenaltyRule
+}
+
+func (r *stubDeductionRepository) Save(ctx context.Context, d *entity.Deduction) error {
+	r.deductions = append(r.deductions, d)
+	return nil
+}
+
+func (r *stubDeductionRepository) Update(ctx context.Context, d *entity.Deduction) error {
+	return nil
+}
+
+func (r *stubDeductionRepository) FindByID(ctx context.Context, id uuid.UUID) (*entity.Deduction, error) {
+	for _, d := range r.deductions {
+		if d.ID == id {
+			return d, nil
+		}
+	}
+	return nil, entity.ErrDeductionNotFound
+}
+
+func (r *stubDeductionRepository) FindByProjectID(ctx context.Context, projectID uuid.UUID) ([]*entity.Deduction, error) {
+	var result []*entity.Deduction
+	for _, d := range r.deductions {
+		if d.ProjectID == projectID {
+			result = append(result, d)
+		}
+	}
+	return result, nil
+}
+
+func (r *stubDeductionRepository) FindByPayApplication(ctx context.Context, payApplicationID uuid.UUID) ([]*entity.Deduction, error) {
+	var result []*entity.Deduction
+	for _, d := range r.deductions {
+		if d.PayApplicationID != nil && *d.PayApplicationID == payApplicationID {
+			result = append(result, d)
+		}
+	}
+	return result, nil
+}
+
+func (r *stubDeductionRepository) SaveSchedule(ctx context.Context, s *entity.DeductionSchedule) error {
+	r.schedules = append(r.schedules, s)
+	return nil
+}
+
+func (r *stubDeductionRepository) UpdateSchedule(ctx context.Context, s *entity.DeductionSchedule) error {
+	return nil
+}
+
+func (r *stubDeductionRepository) FindScheduleByID(ctx context.Context, id uuid.UUID) (*entity.DeductionSchedule, error) {
+	for _, s := range r.schedules {
+		if s.ID == id {
+			return s, nil
+		}
+	}
+	return nil, entity.ErrDeductionScheduleNotFound
+}
+
+func (r *stubDeductionRepository) FindSchedulesByProject(ctx context.Context, projectID uuid.UUID) ([]*entity.DeductionSchedule, error) {
+	var result []*entity.DeductionSchedule
+	for _, s := range r.schedules {
+		if s.ProjectID == projectID {
+			result = append(result, s)
+		}
+	}
+	return result, nil
+}
+
+func (r *stubDeductionRepository) FindDueSchedules(ctx context.Context, asOf time.Time) ([]*entity.DeductionSchedule, error) {
+	var result []*entity.DeductionSchedule
+	for _, s := range r.schedules {
+		if s.Active && !s.NextDate.After(asOf) {
+			result = append(result, s)
+		}
+	}
+	return result, nil
+}
+
+func (r *stubDeductionRepository) SavePenaltyRule(ctx context.Context, rule *entity.LatePenaltyRule) error {
+	if r.rules == nil {
+		r.rules = make(map[uuid.UUID]*entity.LatePenaltyRule)
+	}
+	r.rules[rule.ProjectID] = rule
+	return nil
+}
+
+func (r *stubDeductionRepository) FindPenaltyRule(ctx context.Context, projectID uuid.UUID) (*entity.LatePenaltyRule, error) {
+	rule, ok := r.rules[projectID]
+	if !ok {
+		return nil, entity.ErrPenaltyRuleNotFound
+	}
+	return rule, nil
+}
+
+func (r *stubDeductionRepository) FindPenaltyRules(ctx context.Context) ([]*entity.LatePenaltyRule, error) {
+	var rules []*entity.LatePenaltyRule
+	for _, rule := range r.rules {
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+func newTestDeductionService(ledger *LedgerService) *DeductionService {
+	return NewDeductionService(&stubDeductionRepository{}, &stubProjectRepository{}, ledger)
+}
+
+func TestDeductionScheduleDue(t *testing.T) {
+	end := day(2026, 4, 30)
+	schedule := entity.NewDeductionSchedule(uuid.New(), entity.DeductionCategorySGK, "SGK primi", 150000, "TRY", day(2026, 1, 31), uuid.New())
+	schedule.EndDate = &end
+
+	// Month-end start stays at month end, missed months are caught up
+	got := schedule.Due(day(2026, 3, 31))
+	want := []time.Time{day(2026, 1, 31), day(2026, 2, 28), day(2026, 3, 31)}
+	if len(got) != len(want) {
+		t.Fatalf("Due() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if !got[i].Equal(want[i]) {
+			t.Errorf("occurrence %d = %s, want %s", i, got[i].Format("2006-01-02"), want[i].Format("2006-01-02"))
+		}
+	}
+	if !schedule.Active || !schedule.NextDate.Equal(day(2026, 4, 30)) {
+		t.Errorf("after March: active %v next %s", schedule.Active, schedule.NextDate.Format("2006-01-02"))
+	}
+
+	// April is the last occurrence before the end date
+	if got := schedule.Due(day(2026, 12, 31)); len(got) != 1 || schedule.Active {
+		t.Errorf("final run = %v, active %v", got, schedule.Active)
+	}
+}
+
+func TestDeductionServiceSchedulesAndPenalty(t *testing.T) {
+	ctx := context.Background()
+	txRepo := &stubTransactionRepository{}
+	ledger := NewLedgerService(txRepo)
+	projects := &stubProjectRepository{}
+	deductions := NewDeductionService(&stubDeductionRepository{}, projects, ledger)
+
+	project := entity.NewProject(uuid.New(), "Konut Blokları", "PRJ-2026-001")
+	project.Status = entity.ProjectStatusActive
+	project.ContractAmount = 100000000
+	project.EstimatedEndDate = day(2026, 3, 31)
+	projects.Create(ctx, project)
+
+	schedule := entity.NewDeductionSchedule(project.ID, entity.DeductionCategoryUtility, "Şantiye elektriği", 250000, "TRY", day(2026, 1, 15), uuid.New())
+	if err := deductions.CreateSchedule(ctx, schedule); err != nil {
+		t.Fatalf("CreateSchedule() error = %v", err)
+	}
+	raised, err := deductions.RunSchedules(ctx, day(2026, 2, 20))
+	if err != nil || len(raised) != 2 {
+		t.Fatalf("RunSchedules() = %d deductions, %v", len(raised), err)
+	}
+	if raised[1].ScheduleID == nil || !raised[1].Date.Equal(day(2026, 2, 15)) || raised[1].TransactionID == nil {
+		t.Errorf("second occurrence = %+v", raised[1])
+	}
+
+	if err := deductions.SetPenaltyRule(ctx, entity.NewLatePenaltyRule(project.ID, 0, 0, uuid.New())); !errors.Is(err, entity.ErrInvalidPenaltyRule) {
+		t.Errorf("zero daily rate error = %v", err)
+	}
+	// Binde 0,6 per day, capped at 10% of the contract
+	if err := deductions.SetPenaltyRule(ctx, entity.NewLatePenaltyRule(project.ID, 600, 1000, uuid.New())); err != nil {
+		t.Fatalf("SetPenaltyRule() error = %v", err)
+	}
+
+	if d, err := deductions.AssessLatePenalty(ctx, project.ID, day(2026, 3, 31), uuid.New()); d != nil || err != nil {
+		t.Errorf("on time = %v, %v", d, err)
+	}
+
+	// 10 days × 1.000.000,00 × 0,0006 = 6.000,00
+	first, err := deductions.AssessLatePenalty(ctx, project.ID, day(2026, 4, 10), uuid.New())
+	if err != nil || first == nil || first.Amount != 600000 || first.PenaltyDays != 10 {
+		t.Fatalf("first assessment = %+v, %v", first, err)
+	}
+	// Only the 5 further days are charged
+	second, err := deductions.AssessLatePenalty(ctx, project.ID, day(2026, 4, 15), uuid.New())
+	if err != nil || second == nil || second.Amount != 300000 {
+		t.Fatalf("second assessment = %+v, %v", second, err)
+	}
+	// The cap stops the penalty at 100.000,00 in total
+	third, err := deductions.AssessLatePenalty(ctx, project.ID, day(2027, 1, 1), uuid.New())
+	if err != nil || third == nil || third.Amount != 10000000-900000 {
+		t.Fatalf("capped assessment = %+v, %v", third, err)
+	}
+	if d, _ := deductions.AssessLatePenalty(ctx, project.ID, day(2027, 6, 1), uuid.New()); d != nil {
+		t.Errorf("assessment past the cap = %+v", d)
+	}
+
+	summary := SummarizeTransactions(project.ID, txRepo.transactions)[0]
+	if summary.TotalDeducted != 500000+10000000 || summary.CurrentBalance != -summary.TotalDeducted {
+		t.Errorf("ledger summary = %+v", summary)
+	}
+}
+
+func TestDeductionRunsSkipBlockedProjects(t *testing.T) {
+	ctx := context.Background()
+	txRepo := &stubTransactionRepository{}
+	ledger := NewLedgerService(txRepo)
+	projects := &stubProjectRepository{}
+	ledger.SetProjectRepository(projects)
+	repo := &stubDeductionRepository{}
+	deductions := NewDeductionService(repo, projects, ledger)
+
+	newProject := func(code string, status entity.ProjectStatus) *entity.Project {
+		project := entity.NewProject(uuid.New(), "Proje "+code, code)
+		project.Status = status
+		project.ContractAmount = 100000000
+		project.EstimatedEndDate = day(2026, 3, 31)
+		projects.Create(ctx, project)
+
+		schedule := entity.NewDeductionSchedule(project.ID, entity.DeductionCategoryUtility, "Şantiye suyu", 10000, "TRY", day(2026, 1, 15), uuid.New())
+		if err := deductions.CreateSchedule(ctx, schedule); err != nil {
+			t.Fatalf("CreateSchedule() error = %v", err)
+		}
+		if err := deductions.SetPenaltyRule(ctx, entity.NewLatePenaltyRule(project.ID, 600, 0, uuid.New())); err != nil {
+			t.Fatalf("SetPenaltyRule() error = %v", err)
+		}
+		return project
+	}
+	draft := newProject("PRJ-D", entity.ProjectStatusDraft)
+	cancelled := newProject("PRJ-C", entity.ProjectStatusCancelled)
+	active := newProject("PRJ-A", entity.ProjectStatusActive)
+	completion := day(2026, 4, 5)
+	active.SubstantialCompletionDate = &completion
+
+	// Blocked projects hold up neither the run nor the other schedules
+	raised, err := deductions.RunSchedules(ctx, day(2026, 1, 20))
+	if err != nil || len(raised) != 1 || raised[0].ProjectID != active.ID {
+		t.Fatalf("RunSchedules() = %+v, %v, want the active project's occurrence", raised, err)
+	}
+	for _, schedule := range repo.schedules {
+		switch schedule.ProjectID {
+		case cancelled.ID:
+			if schedule.Active {
+				t.Error("schedule of a cancelled project should stop")
+			}
+		case draft.ID:
+			if !schedule.Active || !schedule.NextDate.Equal(day(2026, 1, 15)) {
+				t.Errorf("schedule of a draft project should wait, next %s", schedule.NextDate.Format("2006-01-02"))
+			}
+		}
+	}
+
+	// Days stop at substantial completion: 5 days, not 31
+	penalties, err := deductions.AssessLatePenalties(ctx, day(2026, 5, 1))
+	if err != nil || len(penalties) != 1 || penalties[0].ProjectID != active.ID || penalties[0].PenaltyDays != 5 {
+		t.Fatalf("AssessLatePenalties() = %+v, %v", penalties, err)
+	}
+
+	active.SubstantialCompletionDate = nil
+	active.Status = entity.ProjectStatusCompleted
+	if d, err := deductions.AssessLatePenalty(ctx, active.ID, day(2026, 6, 1), uuid.New()); d != nil || err != nil {
+		t.Errorf("completed project assessment = %+v, %v", d, err)
+	}
+}
+
+func TestPayApplicationWithholdsDeductions(t *testing.T) {
+	ctx := context.Background()
+	projectID := uuid.New()
+	txRepo := &stubTransactionRepository{}
+	ledger := NewLedgerService(txRepo)
+	deductions := newTestDeductionService(ledger)
+	payApps := NewPayApplicationService(&stubPayApplicationRepository{}, NewCalculator(), NewTaxEngine(&stubTaxRateRepository{}),
+		newTestEscalationService(), NewAdvanceService(&stubAdvanceRepository{}, ledger), deductions, ledger)
+
+	backCharge := entity.NewDeduction(projectID, entity.DeductionCategoryBackCharge, "Hasarlı kalıp", 500000, "TRY", day(2026, 3, 12), uuid.New())
+	if err := deductions.Create(ctx, backCharge); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	later := entity.NewDeduction(projectID, entity.DeductionCategorySGK, "Nisan SGK primi", 200000, "TRY", day(2026, 4, 30), uuid.New())
+	if err := deductions.Create(ctx, later); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if err := deductions.Create(ctx, entity.NewDeduction(projectID, "FINE", "?", 1, "TRY", day(2026, 3, 1), uuid.New())); !errors.Is(err, entity.ErrInvalidDeduction) {
+		t.Errorf("unknown category error = %v", err)
+	}
+
+	request := PayApplicationRequest{
+		PeriodEnd: day(2026, 3, 31),
+		Currency:  "TRY",
+		Billing:   AIABillingInput{OriginalContractSum: 100000000, CurrentWorkCompleted: 10000000},
+	}
+
+	// Only the March deduction falls in the period; it is withheld from the payment, not the invoice
+	rejected, err := payApps.Create(ctx, uuid.Nil, projectID, request, uuid.New())
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if rejected.Deductions != 500000 || rejected.CurrentPaymentDue != 10000000 || rejected.NetPaymentDue != 9500000 {
+		t.Errorf("application = deductions %d due %d net %d", rejected.Deductions, rejected.CurrentPaymentDue, rejected.NetPaymentDue)
+	}
+	if _, err := payApps.Submit(ctx, rejected.ID); err != nil {
+		t.Fatalf("Submit() error = %v", err)
+	}
+	if _, err := payApps.Reject(ctx, rejected.ID, "Metraj hatalı"); err != nil {
+		t.Fatalf("Reject() error = %v", err)
+	}
+	if backCharge.IsApplied() {
+		t.Error("rejecting the application should release its deductions")
+	}
+
+	app, err := payApps.Create(ctx, uuid.Nil, projectID, request, uuid.New())
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if app.Deductions != 500000 || backCharge.PayApplicationID == nil || *backCharge.PayApplicationID != app.ID || later.IsApplied() {
+		t.Errorf("resubmitted application deductions = %d", app.Deductions)
+	}
+	if _, err := payApps.Submit(ctx, app.ID); err != nil {
+		t.Fatalf("Submit() error = %v", err)
+	}
+	if _, err := payApps.Certify(ctx, app.ID, uuid.New()); err != nil {
+		t.Fatalf("Certify() error = %v", err)
+	}
+
+	// Invoice 100.000,00 less both deductions recorded in the ledger
+	if balance := ledger.CalculateBalance(txRepo.transactions); balance != 10000000-700000 {
+		t.Errorf("balance = %d, want %d", balance, 10000000-700000)
+	}
+}
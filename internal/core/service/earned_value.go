@@ -0,0 +1,293 @@
+// Copyright (c) 2026 Muhammet Ali Büyük. All rights reserved.
+// This source code is proprietary. Confidential and private.
+// Unauthorized copying or distribution is strictly prohibited.
+// Contact: iletisim@alibuyuk.net | Website: alibuyuk.net
+
+package service
+
+import (
+	"context"
+	"math/big"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/qantesm/subflow/internal/core/entity"
+)
+
+// EarnedValue holds the earned value measures at one point in time (cents)
+// Indices are in basis points (10000 = 1.00) and zero while undefined.
+type EarnedValue struct {
+	BAC int64 `json:"bac"` // Budget at completion
+	PV  int64 `json:"pv"`  // Planned value: budgeted cost of work scheduled
+	EV  int64 `json:"ev"`  // Earned value: budgeted cost of work certified
+	AC  int64 `json:"ac"`  // Actual cost: vendor invoices
+	SV  int64 `json:"sv"`  // EV - PV
+	CV  int64 `json:"cv"`  // EV - AC
+	SPI int64 `json:"spi"` // EV / PV
+	CPI int64 `json:"cpi"` // EV / AC
+	EAC int64 `json:"eac"` // Estimate at completion, BAC / CPI
+	ETC int64 `json:"etc"` // Estimate to complete, EAC - AC
+	VAC int64 `json:"vac"` // Variance at completion, BAC - EAC
+}
+
+// EarnedValueLine is one cost code of the earned value report; parent codes include their children
+type EarnedValueLine struct {
+	CostCodeID uuid.UUID  `json:"cost_code_id"`
+	ParentID   *uuid.UUID `json:"parent_id,omitempty"`
+	Code       string     `json:"code"`
+	Name       string     `json:"name"`
+	Level      int        `json:"level"` // Depth in the cost code tree, 0 for top-level codes
+	EarnedValue
+}
+
+// EarnedValuePeriod is the project's earned value at the end of a certified pay application
+type EarnedValuePeriod struct {
+	ApplicationNo   int       `json:"application_no"`
+	PeriodEnd       time.Time `json:"period_end"`
+	PercentComplete int64     `json:"percent_complete"` // Basis points certified
+	PercentPlanned  int64     `json:"percent_planned"`  // Basis points scheduled by the baseline
+	EarnedValue
+}
+
+// EarnedValueReport measures schedule and cost performance of a project
+// against its baseline, overall, per cost code and per billing period
+type EarnedValueReport struct {
+	ProjectID       uuid.UUID           `json:"project_id"`
+	ProjectCode     string              `json:"project_code"`
+	ProjectName     string              `json:"project_name"`
+	Currency        string              `json:"currency"`
+	AsOf            time.Time           `json:"as_of"`
+	PercentComplete int64               `json:"percent_complete"` // Basis points certified
+	PercentPlanned  int64               `json:"percent_planned"`  // Basis points scheduled by the baseline
+	Total           EarnedValue         `json:"total"`
+	Lines           []EarnedValueLine   `json:"lines"`
+	History         []EarnedValuePeriod `json:"history"` // Oldest first, for trend charts
+}
+
+// EarnedValueService measures earned value against the project's cash flow
+// plan: the plan's schedule and progress curve spread the budget over time
+// (planned value), certified pay applications give the share of work done
+// (earned value) and vendor invoices give the actual cost.
+type EarnedValueService struct {
+	plans     CashFlowPlanRepository
+	projects  ProjectRepository
+	payApps   PayApplicationRepository
+	costs     CostRepository
+	architect string
+}
+
+// NewEarnedValueService creates a new earned value service
+func NewEarnedValueService(plans CashFlowPlanRepository, projects ProjectRepository, payApps PayApplicationRepository, costs CostRepository) *EarnedValueService {
+	return &EarnedValueService{
+		plans:     plans,
+		projects:  projects,
+		payApps:   payApps,
+		costs:     costs,
+		architect: "Muhammet-Ali-Buyuk",
+	}
+}
+
+// Report builds the earned value report of a project as of now.
+// The budget at completion is the job cost budget; a project without budget
+// lines is measured against its scheduled contract value instead.
+func (s *EarnedValueService) Report(ctx context.Context, projectID uuid.UUID, now time.Time) (*EarnedValueReport, error) {
+	project, err := s.projects.FindByID(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	plan, err := s.plans.FindByProjectID(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	codes, err := s.costs.FindCostCodesByProject(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	budget, err := s.costs.FindBudgetLinesByProject(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	invoices, err := s.costs.FindVendorInvoicesByProject(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	apps, err := s.payApps.FindByProjectID(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	contractValue := plan.ScheduledTotal()
+	if contractValue == 0 {
+		contractValue = project.ContractAmount
+	}
+
+	var bac int64
+	for _, b := range budget {
+		bac += b.Amount
+	}
+	if bac == 0 {
+		bac = contractValue
+	}
+
+	var certified []*entity.PayApplication
+	for _, app := range apps {
+		if app.IsCertified() {
+			certified = append(certified, app)
+		}
+	}
+	sort.Slice(certified, func(i, j int) bool {
+		return certified[i].ApplicationNo < certified[j].ApplicationNo
+	})
+
+	// completed and total give the certified share of the work
+	completed, total := int64(0), contractValue
+	report := &EarnedValueReport{
+		ProjectID:   project.ID,
+		ProjectCode: project.Code,
+		ProjectName: project.Name,
+		Currency:    project.Currency,
+		AsOf:        now,
+		Lines:       []EarnedValueLine{},
+		History:     []EarnedValuePeriod{},
+	}
+
+	for _, app := range certified {
+		completed, total = app.TotalCompletedAndStored, app.ContractSum
+		if total == 0 {
+			total = contractValue
+		}
+		period := EarnedValuePeriod{
+			ApplicationNo:   app.ApplicationNo,
+			PeriodEnd:       app.PeriodEnd,
+			PercentComplete: ratioBasisPoints(completed, total),
+			PercentPlanned:  progressBasisPoints(plan, app.PeriodEnd),
+		}
+		period.EarnedValue = measureEarnedValue(bac, plan.ProgressAt(app.PeriodEnd), completed, total, actualCostTo(invoices, nil, app.PeriodEnd))
+		report.History = append(report.History, period)
+	}
+
+	report.PercentComplete = ratioBasisPoints(completed, total)
+	report.PercentPlanned = progressBasisPoints(plan, now)
+	report.Total = measureEarnedValue(bac, plan.ProgressAt(now), completed, total, actualCostTo(invoices, nil, now))
+
+	// Cost codes share the project's certified progress; their own budget and
+	// invoices set the planned, earned and actual amounts
+	own := make(map[uuid.UUID]*EarnedValueLine, len(codes))
+	children := make(map[uuid.UUID][]*entity.CostCode)
+	var roots []*entity.CostCode
+	for _, c := range codes {
+		own[c.ID] = &EarnedValueLine{CostCodeID: c.ID, ParentID: c.ParentID, Code: c.Code, Name: c.Name}
+	}
+	for _, c := range codes {
+		if c.ParentID != nil && own[*c.ParentID] != nil {
+			children[*c.ParentID] = append(children[*c.ParentID], c)
+		} else {
+			roots = append(roots, c)
+		}
+	}
+	for _, b := range budget {
+		if line := own[b.CostCodeID]; line != nil {
+			line.BAC += b.Amount
+		}
+	}
+
+	var walk func(c *entity.CostCode, level int) EarnedValue
+	walk = func(c *entity.CostCode, level int) EarnedValue {
+		line := *own[c.ID]
+		line.Level = level
+		codeID := c.ID
+		ev := measureEarnedValue(line.BAC, plan.ProgressAt(now), completed, total, actualCostTo(invoices, &codeID, now))
+
+		index := len(report.Lines)
+		report.Lines = append(report.Lines, line)
+		for _, child := range sortedCostCodes(children[c.ID]) {
+			sub := walk(child, level+1)
+			ev.BAC += sub.BAC
+			ev.PV += sub.PV
+			ev.EV += sub.EV
+			ev.AC += sub.AC
+		}
+		finishEarnedValue(&ev)
+		line.EarnedValue = ev
+		report.Lines[index] = line
+		return ev
+	}
+	for _, root := range sortedCostCodes(roots) {
+		walk(root, 0)
+	}
+
+	return report, nil
+}
+
+// measureEarnedValue spreads bac by the planned progress and the certified share of the work
+func measureEarnedValue(bac int64, planned float64, completed, total, actual int64) EarnedValue {
+	ev := EarnedValue{
+		BAC: bac,
+		PV:  int64(float64(bac)*planned + 0.5),
+		AC:  actual,
+	}
+	if total > 0 {
+		ev.EV = mulDiv(bac, completed, total)
+	}
+	finishEarnedValue(&ev)
+	return ev
+}
+
+// finishEarnedValue derives the variances, indices and estimates from BAC, PV, EV and AC.
+// Without cost performance yet the estimate is the budget; cost with nothing
+// earned adds the whole budget to what has been spent.
+func finishEarnedValue(ev *EarnedValue) {
+	ev.SV = ev.EV - ev.PV
+	ev.CV = ev.EV - ev.AC
+	ev.SPI, ev.CPI = 0, 0
+	if ev.PV > 0 {
+		ev.SPI = mulDiv(ev.EV, 10000, ev.PV)
+	}
+
+	switch {
+	case ev.AC == 0:
+		ev.EAC = ev.BAC
+	case ev.EV > 0:
+		ev.CPI = mulDiv(ev.EV, 10000, ev.AC)
+		ev.EAC = mulDiv(ev.BAC, ev.AC, ev.EV)
+	default:
+		ev.EAC = ev.BAC + ev.AC
+	}
+	ev.ETC = ev.EAC - ev.AC
+	ev.VAC = ev.BAC - ev.EAC
+}
+
+// actualCostTo adds up vendor invoices dated on or before at, optionally for one cost code
+func actualCostTo(invoices []*entity.VendorInvoice, costCodeID *uuid.UUID, at time.Time) int64 {
+	var total int64
+	for _, v := range invoices {
+		if costCodeID != nil && v.CostCodeID != *costCodeID {
+			continue
+		}
+		if !v.InvoiceDate.After(at) {
+			total += v.Amount
+		}
+	}
+	return total
+}
+
+func progressBasisPoints(plan *entity.CashFlowPlan, at time.Time) int64 {
+	return int64(plan.ProgressAt(at)*10000 + 0.5)
+}
+
+func ratioBasisPoints(part, whole int64) int64 {
+	if whole == 0 {
+		return 0
+	}
+	return mulDiv(part, 10000, whole)
+}
+
+// mulDiv returns a*b/c rounded to the nearest integer without overflowing
+func mulDiv(a, b, c int64) int64 {
+	return roundRat(new(big.Rat).SetFrac(
+		new(big.Int).Mul(big.NewInt(a), big.NewInt(b)),
+		big.NewInt(c),
+	))
+}
@@ -0,0 +1,106 @@
+// Copyright (c) 2026 Muhammet Ali Büyük. All rights reserved.
+// This source code is proprietary. Confidential and private.
+// Unauthorized copying or distribution is strictly prohibited.
+// Contact: iletisim@alibuyuk.net | Website: alibuyuk.net
+
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/qantesm/subflow/internal/core/entity"
+)
+
+func TestEarnedValueReport(t *testing.T) {
+	ctx := context.Background()
+	projects := &stubProjectRepository{}
+	plans := &stubCashFlowPlanRepository{}
+	payApps := &stubPayApplicationRepository{}
+	costs := &stubCostRepository{}
+	s := NewEarnedValueService(plans, projects, payApps, costs)
+	user := uuid.New()
+
+	project := entity.NewProject(uuid.New(), "Buca Okul", "PRJ-2026-031")
+	project.ContractAmount = 20000000
+	_ = projects.Create(ctx, project)
+
+	if _, err := s.Report(ctx, project.ID, day(2026, 4, 1)); !errors.Is(err, entity.ErrCashFlowPlanNotFound) {
+		t.Fatalf("Report() without a baseline error = %v", err)
+	}
+
+	// Linear baseline over 200 days, measured half way through
+	start := day(2026, 1, 1)
+	_ = plans.Save(ctx, entity.NewCashFlowPlan(project.ID, start, start.AddDate(0, 0, 200), user))
+	now := start.AddDate(0, 0, 100)
+
+	site := entity.NewCostCode(project.ID, "01", "Şantiye", user)
+	earthworks := entity.NewCostCode(project.ID, "01.10", "Hafriyat", user)
+	earthworks.ParentID = &site.ID
+	structure := entity.NewCostCode(project.ID, "02", "Kaba inşaat", user)
+	costs.codes = append(costs.codes, structure, earthworks, site)
+	costs.budget = append(costs.budget,
+		entity.NewBudgetLine(project.ID, earthworks.ID, "", 4000000, "TRY", user),
+		entity.NewBudgetLine(project.ID, structure.ID, "", 6000000, "TRY", user),
+	)
+	costs.invoices = append(costs.invoices,
+		entity.NewVendorInvoice(project.ID, earthworks.ID, "Kazıcı", "K-1", start.AddDate(0, 0, 40), 2000000, "TRY", user),
+		entity.NewVendorInvoice(project.ID, structure.ID, "Yapıcı", "Y-1", start.AddDate(0, 0, 90), 3000000, "TRY", user),
+		entity.NewVendorInvoice(project.ID, structure.ID, "Yapıcı", "Y-2", start.AddDate(0, 0, 150), 1000000, "TRY", user),
+	)
+
+	for i, completed := range []int64{4000000, 8000000, 12000000} {
+		app := entity.NewPayApplication(project.ID, i+1, start, start.AddDate(0, 0, 50*(i+1)), "TRY", user)
+		app.ContractSum = 20000000
+		app.TotalCompletedAndStored = completed
+		if i < 2 {
+			app.Status = entity.PayApplicationStatusCertified
+		}
+		_ = payApps.Save(ctx, app)
+	}
+
+	report, err := s.Report(ctx, project.ID, now)
+	if err != nil {
+		t.Fatalf("Report() error = %v", err)
+	}
+
+	if report.PercentComplete != 4000 || report.PercentPlanned != 5000 {
+		t.Errorf("percent complete/planned = %d/%d, want 4000/5000", report.PercentComplete, report.PercentPlanned)
+	}
+	want := EarnedValue{BAC: 10000000, PV: 5000000, EV: 4000000, AC: 5000000, SV: -1000000, CV: -1000000, SPI: 8000, CPI: 8000, EAC: 12500000, ETC: 7500000, VAC: -2500000}
+	if report.Total != want {
+		t.Errorf("total = %+v, want %+v", report.Total, want)
+	}
+
+	// The draft third application is not part of the history
+	if len(report.History) != 2 {
+		t.Fatalf("history has %d periods, want 2", len(report.History))
+	}
+	if p := report.History[0]; p.ApplicationNo != 1 || p.PercentComplete != 2000 || p.PV != 2500000 || p.EV != 2000000 || p.AC != 2000000 || p.CPI != 10000 || p.SPI != 8000 {
+		t.Errorf("period 1 = %+v", p)
+	}
+	if report.History[1].EarnedValue != want {
+		t.Errorf("period 2 = %+v, want %+v", report.History[1].EarnedValue, want)
+	}
+
+	order := []string{"01", "01.10", "02"}
+	if len(report.Lines) != len(order) {
+		t.Fatalf("report has %d lines, want %d", len(report.Lines), len(order))
+	}
+	for i, code := range order {
+		if report.Lines[i].Code != code {
+			t.Errorf("line %d = %s, want %s", i, report.Lines[i].Code, code)
+		}
+	}
+	if l := report.Lines[0]; l.Level != 0 || l.BAC != 4000000 || l.EV != 1600000 || l.AC != 2000000 || l.CPI != 8000 {
+		t.Errorf("01 = %+v", l)
+	}
+	if l := report.Lines[1]; l.Level != 1 || l.EarnedValue != report.Lines[0].EarnedValue {
+		t.Errorf("01.10 = %+v", l)
+	}
+	if l := report.Lines[2]; l.BAC != 6000000 || l.PV != 3000000 || l.EV != 2400000 || l.AC != 3000000 || l.EAC != 7500000 {
+		t.Errorf("02 = %+v", l)
+	}
+}
@@ -0,0 +1,265 @@
+// Copyright (c) 2026 Muhammet Ali Büyük. All rights reserved.
+// This source code is proprietary. Confidential and private.
+// Unauthorized copying or distribution is strictly prohibited.
+// Contact: iletisim@alibuyuk.net | Website: alibuyuk.net
+
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/qantesm/subflow/internal/core/entity"
+)
+
+// EInvoiceRepository is the port for generated e-Fatura documents
+type EInvoiceRepository interface {
+	Save(ctx context.Context, inv *entity.EInvoice) error
+	Update(ctx context.Context, inv *entity.EInvoice) error
+	FindByID(ctx context.Context, id uuid.UUID) (*entity.EInvoice, error)
+	FindByTransactionID(ctx context.Context, transactionID uuid.UUID) (*entity.EInvoice, error)
+	NextSequence(ctx context.Context, prefix string, year int) (int64, error) // Gapless per prefix and year
+}
+
+// EInvoiceRenderer turns an e-Fatura into its UBL-TR XML document
+type EInvoiceRenderer interface {
+	Render(inv *entity.EInvoice) ([]byte, error)
+}
+
+// EInvoiceIntegrator submits documents to a GİB special integrator (özel entegratör)
+// Implementations wrap a provider's API; a local fake is used in development
+type EInvoiceIntegrator interface {
+	Send(ctx context.Context, inv *entity.EInvoice) (ref string, err error)
+	Status(ctx context.Context, ref string) (entity.EInvoiceStatus, string, error)
+}
+
+// EInvoiceRequest carries the data not held in the ledger
+type EInvoiceRequest struct {
+	Seller          entity.EInvoiceParty   `json:"seller"`
+	Buyer           entity.EInvoiceParty   `json:"buyer"`
+	Profile         entity.EInvoiceProfile `json:"profile"`          // Default TICARIFATURA
+	Prefix          string                 `json:"prefix"`           // 3-char series, default SUB
+	KDVCode         string                 `json:"kdv_code"`         // Rate table code, default GENEL
+	WithholdingCode string                 `json:"withholding_code"` // Optional tevkifat code, e.g. 601
+	ItemName        string                 `json:"item_name"`
+	Note            string                 `json:"note"`
+	IssueDate       time.Time              `json:"issue_date"`
+}
+
+// EInvoiceService generates UBL-TR e-Fatura documents from ledger invoices
+type EInvoiceService struct {
+	repo       EInvoiceRepository
+	txRepo     TransactionRepository
+	payApps    PayApplicationRepository
+	taxes      *TaxEngine
+	renderer   EInvoiceRenderer
+	integrator EInvoiceIntegrator
+	architect  string
+}
+
+// NewEInvoiceService creates a new e-invoice service
+func NewEInvoiceService(repo EInvoiceRepository, txRepo TransactionRepository, payApps PayApplicationRepository, taxes *TaxEngine, renderer EInvoiceRenderer, integrator EInvoiceIntegrator) *EInvoiceService {
+	return &EInvoiceService{
+		repo:       repo,
+		txRepo:     txRepo,
+		payApps:    payApps,
+		taxes:      taxes,
+		renderer:   renderer,
+		integrator: integrator,
+		architect:  "Muhammet-Ali-Buyuk",
+	}
+}
+
+// GenerateForTransaction builds and stores the e-Fatura for an invoice transaction
+// Taxes are resolved from the tenant's rate table for the issue date
+func (s *EInvoiceService) GenerateForTransaction(ctx context.Context, tenantID, transactionID uuid.UUID, req EInvoiceRequest, createdBy uuid.UUID) (*entity.EInvoice, error) {
+	tx, err := s.txRepo.FindByID(ctx, transactionID)
+	if err != nil {
+		return nil, err
+	}
+	if req.IssueDate.IsZero() {
+		req.IssueDate = time.Now()
+	}
+
+	tax, err := s.taxes.Compute(ctx, tenantID, req.IssueDate, tx.AmountCents, TaxOptions{
+		KDVCode:         req.KDVCode,
+		WithholdingCode: req.WithholdingCode,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	itemName := req.ItemName
+	if itemName == "" {
+		itemName = tx.Description
+	}
+	if itemName == "" {
+		itemName = "Hakediş bedeli " + tx.ReferenceNo
+	}
+
+	return s.generate(ctx, tx, nil, itemName, tax, req, createdBy)
+}
+
+// GenerateForPayApplication builds the e-Fatura for a certified pay application's invoice
+// The tax breakdown stored with the application is reused so the invoice matches the hakediş
+func (s *EInvoiceService) GenerateForPayApplication(ctx context.Context, tenantID, payApplicationID uuid.UUID, req EInvoiceRequest, createdBy uuid.UUID) (*entity.EInvoice, error) {
+	app, err := s.payApps.FindByID(ctx, payApplicationID)
+	if err != nil {
+		return nil, err
+	}
+	if !app.IsCertified() || app.InvoiceTransactionID == nil {
+		return nil, entity.ErrPayApplicationNotCertified
+	}
+
+	tx, err := s.txRepo.FindByID(ctx, *app.InvoiceTransactionID)
+	if err != nil {
+		return nil, err
+	}
+
+	itemName := req.ItemName
+	if itemName == "" {
+		itemName = fmt.Sprintf("%d No'lu hakediş bedeli (%s - %s)", app.ApplicationNo,
+			app.PeriodStart.Format("02.01.2006"), app.PeriodEnd.Format("02.01.2006"))
+	}
+
+	tax := app.Tax
+	if tax == nil || tax.Base != tx.AmountCents {
+		tax, err = s.taxes.Compute(ctx, tenantID, app.PeriodEnd, tx.AmountCents, TaxOptions{
+			KDVCode:         req.KDVCode,
+			WithholdingCode: req.WithholdingCode,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return s.generate(ctx, tx, &app.ID, itemName, tax, req, createdBy)
+}
+
+// Get returns a stored e-Fatura including its XML
+func (s *EInvoiceService) Get(ctx context.Context, id uuid.UUID) (*entity.EInvoice, error) {
+	return s.repo.FindByID(ctx, id)
+}
+
+// Send submits a generated e-Fatura through the integrator
+func (s *EInvoiceService) Send(ctx context.Context, id uuid.UUID) (*entity.EInvoice, error) {
+	inv, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if inv.Status != entity.EInvoiceStatusGenerated {
+		return nil, entity.ErrEInvoiceAlreadySent
+	}
+
+	ref, err := s.integrator.Send(ctx, inv)
+	if err != nil {
+		return nil, fmt.Errorf("integrator send: %w", err)
+	}
+	if err := inv.MarkSent(ref); err != nil {
+		return nil, err
+	}
+	if err := s.repo.Update(ctx, inv); err != nil {
+		return nil, err
+	}
+	return inv, nil
+}
+
+// RefreshStatus polls the integrator for the delivery status of a sent e-Fatura
+func (s *EInvoiceService) RefreshStatus(ctx context.Context, id uuid.UUID) (*entity.EInvoice, error) {
+	inv, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if inv.IntegratorRef == "" {
+		return inv, nil
+	}
+
+	status, message, err := s.integrator.Status(ctx, inv.IntegratorRef)
+	if err != nil {
+		return nil, fmt.Errorf("integrator status: %w", err)
+	}
+	inv.Status = status
+	inv.StatusMessage = message
+	inv.UpdatedAt = time.Now()
+	if err := s.repo.Update(ctx, inv); err != nil {
+		return nil, err
+	}
+	return inv, nil
+}
+
+func (s *EInvoiceService) generate(ctx context.Context, tx *entity.Transaction, payApplicationID *uuid.UUID, itemName string, tax *entity.TaxBreakdown, req EInvoiceRequest, createdBy uuid.UUID) (*entity.EInvoice, error) {
+	if tx.Type != entity.TransactionTypeInvoice {
+		return nil, entity.ErrEInvoiceNotInvoice
+	}
+
+	existing, err := s.repo.FindByTransactionID(ctx, tx.ID)
+	if err != nil && !errors.Is(err, entity.ErrEInvoiceNotFound) {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, entity.ErrEInvoiceExists
+	}
+
+	if req.Prefix == "" {
+		req.Prefix = "SUB"
+	}
+	if req.IssueDate.IsZero() {
+		req.IssueDate = time.Now()
+	}
+
+	bpScale := entity.TaxRateScale / 10000
+	line := entity.EInvoiceLine{
+		Name:              itemName,
+		Quantity:          1,
+		UnitCode:          "C62",
+		UnitPrice:         tx.AmountCents,
+		LineExtension:     tx.AmountCents,
+		KDVRate:           tax.KDVRate / bpScale,
+		KDVAmount:         tax.KDVAmount,
+		WithholdingCode:   tax.WithholdingCode,
+		WithholdingRate:   tax.WithholdingRate / bpScale,
+		WithholdingAmount: tax.WithholdingAmount,
+	}
+
+	// Validate parties before consuming a sequence number
+	if err := req.Seller.Validate(); err != nil {
+		return nil, err
+	}
+	if err := req.Buyer.Validate(); err != nil {
+		return nil, err
+	}
+
+	seq, err := s.repo.NextSequence(ctx, req.Prefix, req.IssueDate.Year())
+	if err != nil {
+		return nil, err
+	}
+
+	inv := entity.NewEInvoice(tx.ProjectID, tx.ID, fmt.Sprintf("%s%d%09d", req.Prefix, req.IssueDate.Year(), seq), createdBy)
+	inv.PayApplicationID = payApplicationID
+	inv.IssueDate = req.IssueDate
+	inv.Currency = tx.Currency
+	inv.Seller = req.Seller
+	inv.Buyer = req.Buyer
+	inv.Note = req.Note
+	if req.Profile != "" {
+		inv.Profile = req.Profile
+	}
+	inv.AddLine(line)
+
+	if err := inv.Validate(); err != nil {
+		return nil, err
+	}
+
+	inv.XML, err = s.renderer.Render(inv)
+	if err != nil {
+		return nil, fmt.Errorf("render e-invoice: %w", err)
+	}
+
+	if err := s.repo.Save(ctx, inv); err != nil {
+		return nil, err
+	}
+	return inv, nil
+}
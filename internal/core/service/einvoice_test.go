@@ -0,0 +1,246 @@
+// Copyright (c) 2026 Muhammet Ali Büyük. All rights reserved.
+// This source code is proprietary. Confidential and private.
+// Unauthorized copying or distribution is strictly prohibited.
+// Contact: iletisim@alibuyuk.net | Website: alibuyuk.net
+
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/qantesm/subflow/internal/core/entity"
+)
+
+type stubPayApplicationRepository struct {
+	apps []*entity.PayApplication
+}
+
+func (r *stubPayApplicationRepository) Save(ctx context.Context, app *entity.PayApplication) error {
+	r.apps = append(r.apps, app)
+	return nil
+}
+
+func (r *stubPayApplicationRepository) Update(ctx context.Context, app *entity.PayApplication, events ...*entity.Event) error {
+	return nil
+}
+
+func (r *stubPayApplicationRepository) FindByID(ctx context.Context, id uuid.UUID) (*entity.PayApplication, error) {
+	for _, app := range r.apps {
+		if app.ID == id {
+			return app, nil
+		}
+	}
+	return nil, entity.ErrPayApplicationNotFound
+}
+
+func (r *stubPayApplicationRepository) FindByProjectID(ctx context.Context, projectID uuid.UUID) ([]*entity.PayApplication, error) {
+	var result []*entity.PayApplication
+	for _, app := range r.apps {
+		if app.ProjectID == projectID {
+			result = append(result, app)
+		}
+	}
+	return result, nil
+}
+
+type stubEInvoiceRepository struct {
+	invoices []*entity.EInvoice
+	seq      int64
+}
+
+func (r *stubEInvoiceRepository) Save(ctx context.Context, inv *entity.EInvoice) error {
+	r.invoices = append(r.invoices, inv)
+	return nil
+}
+
+func (r *stubEInvoiceRepository) Update(ctx context.Context, inv *entity.EInvoice) error {
+	return nil
+}
+
+func (r *stubEInvoiceRepository) FindByID(ctx context.Context, id uuid.UUID) (*entity.EInvoice, error) {
+	for _, inv := range r.invoices {
+		if inv.ID == id {
+			return inv, nil
+		}
+	}
+	return nil, entity.ErrEInvoiceNotFound
+}
+
+func (r *stubEInvoiceRepository) FindByTransactionID(ctx context.Context, transactionID uuid.UUID) (*entity.EInvoice, error) {
+	for _, inv := range r.invoices {
+		if inv.TransactionID == transactionID {
+			return inv, nil
+		}
+	}
+	return nil, entity.ErrEInvoiceNotFound
+}
+
+func (r *stubEInvoiceRepository) NextSequence(ctx context.Context, prefix string, year int) (int64, error) {
+	r.seq++
+	return r.seq, nil
+}
+
+type stubTaxRateRepository struct {
+	rates []*entity.TaxRate
+}
+
+func (r *stubTaxRateRepository) Save(ctx context.Context, rate *entity.TaxRate) error {
+	r.rates = append(r.rates, rate)
+	return nil
+}
+
+func (r *stubTaxRateRepository) FindByTenant(ctx context.Context, tenantID uuid.UUID) ([]*entity.TaxRate, error) {
+	var result []*entity.TaxRate
+	for _, rate := range r.rates {
+		if rate.TenantID == tenantID {
+			result = append(result, rate)
+		}
+	}
+	return result, nil
+}
+
+type stubRenderer struct{}
+
+func (stubRenderer) Render(inv *entity.EInvoice) ([]byte, error) {
+	return []byte(fmt.Sprintf("<Invoice>%s</Invoice>", inv.InvoiceNo)), nil
+}
+
+type stubIntegrator struct{ sent int }
+
+func (s *stubIntegrator) Send(ctx context.Context, inv *entity.EInvoice) (string, error) {
+	s.sent++
+	return "REF-" + inv.InvoiceNo, nil
+}
+
+func (s *stubIntegrator) Status(ctx context.Context, ref string) (entity.EInvoiceStatus, string, error) {
+	return entity.EInvoiceStatusAccepted, "ok", nil
+}
+
+func testParties() (entity.EInvoiceParty, entity.EInvoiceParty) {
+	seller := entity.EInvoiceParty{Name: "Taşeron A.Ş.", TaxID: "1234567890", TaxOffice: "Kadıköy", District: "Kadıköy", City: "İstanbul", Country: "Türkiye"}
+	buyer := entity.EInvoiceParty{Name: "İşveren A.Ş.", TaxID: "9876543210", TaxOffice: "Çankaya", District: "Çankaya", City: "Ankara", Country: "Türkiye"}
+	return seller, buyer
+}
+
+func TestPayApplicationWorkflowAndEInvoice(t *testing.T) {
+	ctx := context.Background()
+	projectID := uuid.New()
+	txRepo := &stubTransactionRepository{}
+	ledger := NewLedgerService(txRepo)
+	payRepo := &stubPayApplicationRepository{}
+	taxes := NewTaxEngine(&stubTaxRateRepository{})
+	payApps := NewPayApplicationService(payRepo, NewCalculator(), taxes, newTestEscalationService(), NewAdvanceService(&stubAdvanceRepository{}, ledger), newTestDeductionService(ledger), ledger)
+	integrator := &stubIntegrator{}
+	einvoices := NewEInvoiceService(&stubEInvoiceRepository{}, txRepo, payRepo, taxes, stubRenderer{}, integrator)
+	tenantID := uuid.New()
+
+	app, err := payApps.Create(ctx, tenantID, projectID, PayApplicationRequest{
+		PeriodStart: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+		PeriodEnd:   time.Date(2026, 1, 31, 0, 0, 0, 0, time.UTC),
+		Currency:    "TRY",
+		Billing:     AIABillingInput{OriginalContractSum: 100000000, CurrentWorkCompleted: 10000000, LaborRetainageRate: 1000},
+		Tax:         TaxOptions{WithholdingCode: "601", StampDuty: true},
+	}, uuid.New())
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if app.ApplicationNo != 1 || app.CurrentPaymentDue != 9000000 || app.Status != entity.PayApplicationStatusDraft {
+		t.Fatalf("draft = no %d due %d status %s", app.ApplicationNo, app.CurrentPaymentDue, app.Status)
+	}
+	// 90.000,00 base: KDV 18.000,00, 4/10 withheld 7.200,00, stamp duty binde 9,48 = 853,20
+	if app.Tax == nil || app.Tax.KDVAmount != 1800000 || app.Tax.WithholdingAmount != 720000 ||
+		app.Tax.StampDutyAmount != 85320 || app.Tax.NetPayable != 9994680 {
+		t.Fatalf("tax breakdown = %+v", app.Tax)
+	}
+
+	seller, buyer := testParties()
+	req := EInvoiceRequest{Seller: seller, Buyer: buyer, IssueDate: time.Date(2026, 2, 3, 0, 0, 0, 0, time.UTC)}
+
+	if _, err := einvoices.GenerateForPayApplication(ctx, tenantID, app.ID, req, uuid.New()); !errors.Is(err, entity.ErrPayApplicationNotCertified) {
+		t.Errorf("e-invoice before certification error = %v", err)
+	}
+	if _, err := payApps.Certify(ctx, app.ID, uuid.New()); !errors.Is(err, entity.ErrPayApplicationNotSubmitted) {
+		t.Errorf("Certify(draft) error = %v", err)
+	}
+
+	if _, err := payApps.Submit(ctx, app.ID); err != nil {
+		t.Fatalf("Submit() error = %v", err)
+	}
+	app, err = payApps.Certify(ctx, app.ID, uuid.New())
+	if err != nil {
+		t.Fatalf("Certify() error = %v", err)
+	}
+	if app.InvoiceTransactionID == nil || len(txRepo.transactions) != 1 || txRepo.transactions[0].ReferenceNo != "HKD-2026-001" {
+		t.Fatalf("certification should record invoice HKD-2026-001")
+	}
+
+	inv, err := einvoices.GenerateForPayApplication(ctx, tenantID, app.ID, req, uuid.New())
+	if err != nil {
+		t.Fatalf("GenerateForPayApplication() error = %v", err)
+	}
+	if inv.InvoiceNo != "SUB2026000000001" || inv.Type != entity.EInvoiceTypeWithholding {
+		t.Errorf("invoice = %s %s", inv.InvoiceNo, inv.Type)
+	}
+	// Withholding comes from the application's breakdown; stamp duty is not an invoice tax
+	if inv.KDVTotal != 1800000 || inv.WithholdingTotal != 720000 || inv.PayableAmount != 10080000 {
+		t.Errorf("totals = kdv %d wh %d payable %d", inv.KDVTotal, inv.WithholdingTotal, inv.PayableAmount)
+	}
+	if string(inv.XML) != "<Invoice>SUB2026000000001</Invoice>" {
+		t.Errorf("XML not stored: %s", inv.XML)
+	}
+
+	if _, err := einvoices.GenerateForTransaction(ctx, tenantID, *app.InvoiceTransactionID, req, uuid.New()); !errors.Is(err, entity.ErrEInvoiceExists) {
+		t.Errorf("duplicate generation error = %v", err)
+	}
+
+	sent, err := einvoices.Send(ctx, inv.ID)
+	if err != nil || sent.Status != entity.EInvoiceStatusSent || sent.IntegratorRef != "REF-SUB2026000000001" {
+		t.Fatalf("Send() = %+v, %v", sent, err)
+	}
+	if _, err := einvoices.Send(ctx, inv.ID); !errors.Is(err, entity.ErrEInvoiceAlreadySent) || integrator.sent != 1 {
+		t.Errorf("second Send() error = %v, sent %d", err, integrator.sent)
+	}
+
+	refreshed, err := einvoices.RefreshStatus(ctx, inv.ID)
+	if err != nil || refreshed.Status != entity.EInvoiceStatusAccepted {
+		t.Errorf("RefreshStatus() = %v, %v", refreshed.Status, err)
+	}
+}
+
+func TestEInvoiceValidation(t *testing.T) {
+	ctx := context.Background()
+	txRepo := &stubTransactionRepository{}
+	einvoices := NewEInvoiceService(&stubEInvoiceRepository{}, txRepo, &stubPayApplicationRepository{}, NewTaxEngine(&stubTaxRateRepository{}), stubRenderer{}, &stubIntegrator{})
+
+	payment, _ := NewLedgerService(txRepo).RecordPayment(ctx, uuid.New(), nil, 1000, "TRY", "DEK-1", uuid.New())
+	invoice, _ := NewLedgerService(txRepo).RecordInvoice(ctx, uuid.New(), 1000, "TRY", "F-1", uuid.New())
+	seller, buyer := testParties()
+
+	tests := []struct {
+		name    string
+		txID    uuid.UUID
+		mutate  func(*EInvoiceRequest)
+		wantErr error
+	}{
+		{"payment transaction", payment.ID, func(*EInvoiceRequest) {}, entity.ErrEInvoiceNotInvoice},
+		{"bad tax id", invoice.ID, func(r *EInvoiceRequest) { r.Buyer.TaxID = "123" }, entity.ErrInvalidTaxID},
+		{"missing tax office", invoice.ID, func(r *EInvoiceRequest) { r.Seller.TaxOffice = "" }, entity.ErrEInvoicePartyIncomplete},
+		{"unknown withholding", invoice.ID, func(r *EInvoiceRequest) { r.WithholdingCode = "999" }, entity.ErrInvalidWithholdingCode},
+		{"bad prefix", invoice.ID, func(r *EInvoiceRequest) { r.Prefix = "ab" }, entity.ErrInvalidEInvoiceNo},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := EInvoiceRequest{Seller: seller, Buyer: buyer}
+			tt.mutate(&req)
+			if _, err := einvoices.GenerateForTransaction(ctx, uuid.Nil, tt.txID, req, uuid.New()); !errors.Is(err, tt.wantErr) {
+				t.Errorf("error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,192 @@
+// Copyright (c) 2026 Muhammet Ali Büyük. All rights reserved.
+// This source code is proprietary. Confidential and private.
+// Unauthorized copying or distribution is strictly prohibited.
+// Contact: iletisim@alibuyuk.net | Website: alibuyuk.net
+
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/qantesm/subflow/internal/core/entity"
+)
+
+// EmailMessage is one plain text email to a single recipient
+type EmailMessage struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// MailTransport is the port that delivers emails: SMTP in production, a file or memory sink in tests
+type MailTransport interface {
+	Send(ctx context.Context, msg *EmailMessage) error
+}
+
+// NotificationPreferenceRepository is the port for users' email preferences
+type NotificationPreferenceRepository interface {
+	Save(ctx context.Context, p *entity.NotificationPreference) error // Creates or replaces the user's preference
+	FindByUser(ctx context.Context, tenantID, userID uuid.UUID) (*entity.NotificationPreference, error)
+	FindByTenant(ctx context.Context, tenantID uuid.UUID) ([]*entity.NotificationPreference, error)
+}
+
+// EmailDigestRepository is the port for emails held back for digests
+type EmailDigestRepository interface {
+	Add(ctx context.Context, entry *entity.EmailDigestEntry) error
+	FindPending(ctx context.Context) ([]*entity.EmailDigestEntry, error) // Oldest first
+	MarkSent(ctx context.Context, ids []uuid.UUID, sentAt time.Time) error
+}
+
+// EmailNotifier is the email channel of the notification service. It emails
+// the users of the project's tenant who want the notification, in their
+// language, at once or in their next digest.
+type EmailNotifier struct {
+	projects    ProjectRepository
+	preferences NotificationPreferenceRepository
+	digests     EmailDigestRepository
+	transport   MailTransport
+	templates   *EmailTemplates
+	architect   string
+}
+
+// NewEmailNotifier creates the email channel with the built-in templates
+func NewEmailNotifier(projects ProjectRepository, preferences NotificationPreferenceRepository, digests EmailDigestRepository, transport MailTransport) *EmailNotifier {
+	return &EmailNotifier{
+		projects:    projects,
+		preferences: preferences,
+		digests:     digests,
+		transport:   transport,
+		templates:   NewEmailTemplates(),
+		architect:   "Muhammet-Ali-Buyuk",
+	}
+}
+
+// SetTemplates replaces the built-in templates
+func (e *EmailNotifier) SetTemplates(templates *EmailTemplates) {
+	e.templates = templates
+}
+
+// Notify emails the notification to every user who wants it. A failed
+// recipient does not stop the others.
+func (e *EmailNotifier) Notify(ctx context.Context, n *entity.Notification) error {
+	project, err := e.projects.FindByID(ctx, n.ProjectID)
+	if err != nil {
+		return err
+	}
+	preferences, err := e.preferences.FindByTenant(ctx, project.TenantID)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, p := range preferences {
+		if !p.Wants(n) {
+			continue
+		}
+		subject, body, err := e.templates.Render(p.Locale, n, project)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		if p.Digest == entity.DigestDaily {
+			err = e.digests.Add(ctx, &entity.EmailDigestEntry{
+				ID:             uuid.New(),
+				UserID:         p.UserID,
+				Email:          p.Email,
+				Locale:         p.Locale,
+				NotificationID: n.ID,
+				Subject:        subject,
+				Body:           body,
+				CreatedAt:      n.CreatedAt,
+			})
+		} else {
+			err = e.transport.Send(ctx, &EmailMessage{To: p.Email, Subject: subject, Body: body + e.templates.Footer(p.Locale)})
+		}
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// SendDigests sends each user one email with the notifications held for them
+// and returns how many digests went out. Run it once a day.
+func (e *EmailNotifier) SendDigests(ctx context.Context, now time.Time) (int, error) {
+	pending, err := e.digests.FindPending(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	var users []uuid.UUID
+	byUser := make(map[uuid.UUID][]*entity.EmailDigestEntry)
+	for _, entry := range pending {
+		if _, ok := byUser[entry.UserID]; !ok {
+			users = append(users, entry.UserID)
+		}
+		byUser[entry.UserID] = append(byUser[entry.UserID], entry)
+	}
+
+	sent := 0
+	var errs []error
+	for _, userID := range users {
+		entries := byUser[userID]
+		latest := entries[len(entries)-1] // The address and language the user chose last
+		subject, body, err := e.templates.RenderDigest(latest.Locale, entries)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if err := e.transport.Send(ctx, &EmailMessage{To: latest.Email, Subject: subject, Body: body}); err != nil {
+			errs = append(errs, err) // Stays pending for the next run
+			continue
+		}
+
+		ids := make([]uuid.UUID, len(entries))
+		for i, entry := range entries {
+			ids[i] = entry.ID
+		}
+		if err := e.digests.MarkSent(ctx, ids, now); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		sent++
+	}
+	return sent, errors.Join(errs...)
+}
+
+// Preference returns a user's email preference
+func (e *EmailNotifier) Preference(ctx context.Context, tenantID, userID uuid.UUID) (*entity.NotificationPreference, error) {
+	return e.preferences.FindByUser(ctx, tenantID, userID)
+}
+
+// SavePreference validates and stores a user's email preference
+func (e *EmailNotifier) SavePreference(ctx context.Context, p *entity.NotificationPreference) error {
+	if err := p.Validate(); err != nil {
+		return err
+	}
+	p.UpdatedAt = time.Now()
+	return e.preferences.Save(ctx, p)
+}
+
+// EmailDigestJob sends the pending notification digests; run it once a day with RunEvery
+type EmailDigestJob struct {
+	notifier *EmailNotifier
+}
+
+// NewEmailDigestJob creates the scheduled digest job
+func NewEmailDigestJob(notifier *EmailNotifier) *EmailDigestJob {
+	return &EmailDigestJob{notifier: notifier}
+}
+
+func (j *EmailDigestJob) ID() string {
+	return "email-digest"
+}
+
+func (j *EmailDigestJob) Execute(ctx context.Context) error {
+	_, err := j.notifier.SendDigests(ctx, time.Now())
+	return err
+}
@@ -0,0 +1,205 @@
+// Copyright (c) 2026 Muhammet Ali Büyük. All rights reserved.
+// This source code is proprietary. Confidential and private.
+// Unauthorized copying or distribution is strictly prohibited.
+// Contact: iletisim@alibuyuk.net | Website: alibuyuk.net
+
+package service
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/qantesm/subflow/internal/core/entity"
+)
+
+// Each template defines a "subject" and a "body". Notification templates see
+// .Project, .Notification and .P, the notification's params; the digest
+// template sees .Entries.
+const (
+	emailDefaultTemplate = "default" // Notifications without a template of their own
+	emailDigestTemplate  = "digest"
+)
+
+var builtinEmailTemplates = map[entity.NotificationLocale]map[string]string{
+	entity.LocaleTurkish: {
+		emailDefaultTemplate: `{{define "subject"}}[{{.Project.Code}}] {{.Notification.Subject}}{{end}}
+{{define "body"}}{{.Project.Name}} projesi için yeni bir bildirim var.
+
+{{.Notification.Body}}{{end}}`,
+
+		string(entity.NotificationGuaranteeExpiring): `{{define "subject"}}[{{.Project.Code}}] Teminat mektubu {{.P.days_left}} gün içinde sona eriyor: {{.P.bank}} {{.P.letter_no}}{{end}}
+{{define "body"}}{{.Project.Name}} projesindeki {{.P.bank}} tarafından düzenlenen {{.P.letter_no}} numaralı {{.P.amount}} tutarındaki teminat mektubunun süresi {{.P.expiry_date}} tarihinde doluyor.
+
+Bankadan süre uzatımı isteyin ya da mektubun iadesini planlayın.{{end}}`,
+
+		string(entity.NotificationGuaranteeExpired): `{{define "subject"}}[{{.Project.Code}}] Teminat mektubu süresi doldu: {{.P.bank}} {{.P.letter_no}}{{end}}
+{{define "body"}}{{.Project.Name}} projesindeki {{.P.bank}} tarafından düzenlenen {{.P.letter_no}} numaralı {{.P.amount}} tutarındaki teminat mektubunun süresi {{.P.expiry_date}} tarihinde doldu.{{end}}`,
+
+		string(entity.NotificationComplianceExpiring): `{{define "subject"}}[{{.Project.Code}}] Taşeron belgesinin süresi doluyor: {{.P.type}} {{.P.document_no}}{{end}}
+{{define "body"}}{{.P.issuer}} tarafından {{.P.contract_id}} sözleşmesi için düzenlenen {{.P.document_no}} numaralı {{.P.type}} belgesinin süresi {{.P.expiry_date}} tarihinde doluyor.
+
+Yenilenmiş belge gelmeden bu sözleşme kapsamındaki ödemeler engellenecek.{{end}}`,
+
+		string(entity.NotificationComplianceOverride): `{{define "subject"}}[{{.Project.Code}}] Eksik belgeye rağmen ödeme yapıldı: {{.P.amount}}{{end}}
+{{define "body"}}{{.P.contract_id}} sözleşmesi kapsamındaki {{.P.reference_no}} referanslı ödeme şu belgeler olmadan kaydedildi: {{.P.missing}}.
+
+Gerekçe: {{.P.reason}}{{end}}`,
+
+		string(entity.NotificationPayAppSubmitted): `{{define "subject"}}[{{.Project.Code}}] {{.P.application_no}} numaralı hakediş onaya sunuldu{{end}}
+{{define "body"}}{{.Project.Name}} projesinin {{.P.period_end}} tarihinde biten döneme ait {{.P.application_no}} numaralı hakedişi {{.P.amount}} talep ediyor ve onayınızı bekliyor.{{end}}`,
+
+		string(entity.NotificationPayAppCertified): `{{define "subject"}}[{{.Project.Code}}] {{.P.application_no}} numaralı hakediş onaylandı{{end}}
+{{define "body"}}{{.Project.Name}} projesinin {{.P.period_end}} tarihinde biten döneme ait {{.P.application_no}} numaralı hakedişi onaylandı.
+
+Ödenecek tutar: {{.P.amount}}
+Kesintiler sonrası net: {{.P.net_amount}}{{end}}`,
+
+		emailDigestTemplate: `{{define "subject"}}Subflow günlük özet: {{len .Entries}} bildirim{{end}}
+{{define "body"}}Son özetten bu yana gelen bildirimler:
+{{range .Entries}}
+* {{.Subject}}
+
+{{.Body}}
+{{end}}{{end}}`,
+	},
+
+	entity.LocaleEnglish: {
+		emailDefaultTemplate: `{{define "subject"}}[{{.Project.Code}}] {{.Notification.Subject}}{{end}}
+{{define "body"}}There is a new notification for project {{.Project.Name}}.
+
+{{.Notification.Body}}{{end}}`,
+
+		string(entity.NotificationGuaranteeExpiring): `{{define "subject"}}[{{.Project.Code}}] Guarantee expires in {{.P.days_left}} days: {{.P.bank}} {{.P.letter_no}}{{end}}
+{{define "body"}}The {{.P.type}} guarantee {{.P.letter_no}} from {{.P.bank}} for {{.P.amount}} on project {{.Project.Name}} expires on {{.P.expiry_date}}.
+
+Ask the bank for an extension or plan its return.{{end}}`,
+
+		string(entity.NotificationGuaranteeExpired): `{{define "subject"}}[{{.Project.Code}}] Guarantee expired: {{.P.bank}} {{.P.letter_no}}{{end}}
+{{define "body"}}The {{.P.type}} guarantee {{.P.letter_no}} from {{.P.bank}} for {{.P.amount}} on project {{.Project.Name}} expired on {{.P.expiry_date}}.{{end}}`,
+
+		string(entity.NotificationComplianceExpiring): `{{define "subject"}}[{{.Project.Code}}] Subcontractor document expiring: {{.P.type}} {{.P.document_no}}{{end}}
+{{define "body"}}{{.P.type}} {{.P.document_no}} issued by {{.P.issuer}} for contract {{.P.contract_id}} expires on {{.P.expiry_date}}.
+
+Payments under the contract will be blocked without a renewed document.{{end}}`,
+
+		string(entity.NotificationComplianceOverride): `{{define "subject"}}[{{.Project.Code}}] Payment recorded despite missing documents: {{.P.amount}}{{end}}
+{{define "body"}}Payment {{.P.reference_no}} under contract {{.P.contract_id}} was recorded without {{.P.missing}}.
+
+Override reason: {{.P.reason}}{{end}}`,
+
+		string(entity.NotificationPayAppSubmitted): `{{define "subject"}}[{{.Project.Code}}] Pay application #{{.P.application_no}} submitted{{end}}
+{{define "body"}}Pay application #{{.P.application_no}} of project {{.Project.Name}} for the period ending {{.P.period_end}} requests {{.P.amount}} and awaits your certification.{{end}}`,
+
+		string(entity.NotificationPayAppCertified): `{{define "subject"}}[{{.Project.Code}}] Pay application #{{.P.application_no}} certified{{end}}
+{{define "body"}}Pay application #{{.P.application_no}} of project {{.Project.Name}} for the period ending {{.P.period_end}} was certified.
+
+Payment due: {{.P.amount}}
+Net of deductions: {{.P.net_amount}}{{end}}`,
+
+		emailDigestTemplate: `{{define "subject"}}Subflow daily digest: {{len .Entries}} notifications{{end}}
+{{define "body"}}Notifications since your last digest:
+{{range .Entries}}
+* {{.Subject}}
+
+{{.Body}}
+{{end}}{{end}}`,
+	},
+}
+
+var emailFooters = map[entity.NotificationLocale]string{
+	entity.LocaleTurkish: "\n\n--\nSubflow · Bildirim tercihlerinizi uygulamadan değiştirebilirsiniz.\n",
+	entity.LocaleEnglish: "\n\n--\nSubflow · You can change your notification preferences in the app.\n",
+}
+
+// EmailTemplates renders notification emails per locale and notification kind
+type EmailTemplates struct {
+	templates map[entity.NotificationLocale]map[string]*template.Template
+}
+
+// NewEmailTemplates creates the built-in Turkish and English templates
+func NewEmailTemplates() *EmailTemplates {
+	t := &EmailTemplates{templates: make(map[entity.NotificationLocale]map[string]*template.Template)}
+	for locale, texts := range builtinEmailTemplates {
+		for name, text := range texts {
+			if err := t.Add(locale, name, text); err != nil {
+				panic(err) // The built-in templates are fixed at compile time
+			}
+		}
+	}
+	return t
+}
+
+// Add parses a template for a notification kind, "default" or "digest",
+// replacing the one the locale had
+func (t *EmailTemplates) Add(locale entity.NotificationLocale, name, text string) error {
+	tmpl, err := template.New(name).Option("missingkey=zero").Parse(text)
+	if err != nil {
+		return fmt.Errorf("email template %s/%s: %w", locale, name, err)
+	}
+	for _, part := range []string{"subject", "body"} {
+		if tmpl.Lookup(part) == nil {
+			return fmt.Errorf("email template %s/%s: %q is not defined", locale, name, part)
+		}
+	}
+	if t.templates[locale] == nil {
+		t.templates[locale] = make(map[string]*template.Template)
+	}
+	t.templates[locale][name] = tmpl
+	return nil
+}
+
+// Render produces the subject and body of a notification, without the footer
+func (t *EmailTemplates) Render(locale entity.NotificationLocale, n *entity.Notification, project *entity.Project) (subject, body string, err error) {
+	tmpl := t.lookup(locale, string(n.Kind))
+	if tmpl == nil {
+		tmpl = t.lookup(locale, emailDefaultTemplate)
+	}
+	if tmpl == nil {
+		return n.Subject, n.Body, nil
+	}
+	return t.execute(tmpl, map[string]any{
+		"Project":      project,
+		"Notification": n,
+		"P":            n.Params,
+	})
+}
+
+// RenderDigest produces one email listing the held notifications
+func (t *EmailTemplates) RenderDigest(locale entity.NotificationLocale, entries []*entity.EmailDigestEntry) (subject, body string, err error) {
+	tmpl := t.lookup(locale, emailDigestTemplate)
+	if tmpl == nil {
+		return "", "", fmt.Errorf("email template %s/%s is not defined", locale, emailDigestTemplate)
+	}
+	subject, body, err = t.execute(tmpl, map[string]any{"Entries": entries})
+	return subject, body + t.Footer(locale), err
+}
+
+// Footer closes every email sent in the locale
+func (t *EmailTemplates) Footer(locale entity.NotificationLocale) string {
+	if footer, ok := emailFooters[locale]; ok {
+		return footer
+	}
+	return emailFooters[entity.LocaleTurkish]
+}
+
+// lookup finds a template, falling back to Turkish for a locale without it
+func (t *EmailTemplates) lookup(locale entity.NotificationLocale, name string) *template.Template {
+	if tmpl, ok := t.templates[locale][name]; ok {
+		return tmpl
+	}
+	return t.templates[entity.LocaleTurkish][name]
+}
+
+func (t *EmailTemplates) execute(tmpl *template.Template, data any) (subject, body string, err error) {
+	var sb, bb strings.Builder
+	if err := tmpl.ExecuteTemplate(&sb, "subject", data); err != nil {
+		return "", "", err
+	}
+	if err := tmpl.ExecuteTemplate(&bb, "body", data); err != nil {
+		return "", "", err
+	}
+	// Subjects are a single line whatever the template's layout
+	subject = strings.Join(strings.Fields(sb.String()), " ")
+	return subject, strings.TrimSpace(bb.String()), nil
+}
@@ -0,0 +1,194 @@
+// Copyright (c) 2026 Muhammet Ali Büyük. All rights reserved.
+// This source code is proprietary. Confidential and private.
+// Unauthorized copying or distribution is strictly prohibited.
+// Contact: iletisim@alibuyuk.net | Website: alibuyuk.net
+
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/qantesm/subflow/internal/core/entity"
+)
+
+type stubNotificationPreferenceRepository struct {
+	preferences []*entity.NotificationPreference
+}
+
+func (r *stubNotificationPreferenceRepository) Save(ctx context.Context, p *entity.NotificationPreference) error {
+	r.preferences = append(r.preferences, p)
+	return nil
+}
+
+func (r *stubNotificationPreferenceRepository) FindByUser(ctx context.Context, tenantID, userID uuid.UUID) (*entity.NotificationPreference, error) {
+	for _, p := range r.preferences {
+		if p.TenantID == tenantID && p.UserID == userID {
+			return p, nil
+		}
+	}
+	return nil, entity.ErrNotificationPreferenceNotFound
+}
+
+func (r *stubNotificationPreferenceRepository) FindByTenant(ctx context.Context, tenantID uuid.UUID) ([]*entity.NotificationPreference, error) {
+	var result []*entity.NotificationPreference
+	for _, p := range r.preferences {
+		if p.TenantID == tenantID {
+			result = append(result, p)
+		}
+	}
+	return result, nil
+}
+
+type stubEmailDigestRepository struct {
+	entries []*entity.EmailDigestEntry
+}
+
+func (r *stubEmailDigestRepository) Add(ctx context.Context, entry *entity.EmailDigestEntry) error {
+	r.entries = append(r.entries, entry)
+	return nil
+}
+
+func (r *stubEmailDigestRepository) FindPending(ctx context.Context) ([]*entity.EmailDigestEntry, error) {
+	var result []*entity.EmailDigestEntry
+	for _, entry := range r.entries {
+		if entry.SentAt == nil {
+			result = append(result, entry)
+		}
+	}
+	return result, nil
+}
+
+func (r *stubEmailDigestRepository) MarkSent(ctx context.Context, ids []uuid.UUID, sentAt time.Time) error {
+	for _, entry := range r.entries {
+		for _, id := range ids {
+			if entry.ID == id {
+				entry.SentAt = &sentAt
+			}
+		}
+	}
+	return nil
+}
+
+type stubMailTransport struct {
+	sent []*EmailMessage
+}
+
+func (t *stubMailTransport) Send(ctx context.Context, msg *EmailMessage) error {
+	t.sent = append(t.sent, msg)
+	return nil
+}
+
+func TestEmailNotifier(t *testing.T) {
+	ctx := context.Background()
+	tenantID := uuid.New()
+	projects := &stubProjectRepository{}
+	project := entity.NewProject(tenantID, "Kadıköy Konut", "PRJ-7")
+	projects.Create(ctx, project)
+
+	owner := &entity.User{ID: uuid.New(), TenantID: tenantID, Email: "owner@example.com", Role: entity.UserRoleAdmin}
+	pm := &entity.User{ID: uuid.New(), TenantID: tenantID, Email: "pm@example.com", Role: entity.UserRoleManager}
+	viewer := &entity.User{ID: uuid.New(), TenantID: tenantID, Email: "viewer@example.com", Role: entity.UserRoleViewer}
+	outsider := &entity.User{ID: uuid.New(), TenantID: uuid.New(), Email: "other@example.com", Role: entity.UserRoleAdmin}
+
+	preferences := &stubNotificationPreferenceRepository{}
+	digests := &stubEmailDigestRepository{}
+	transport := &stubMailTransport{}
+	emails := NewEmailNotifier(projects, preferences, digests, transport)
+
+	ownerPref := entity.NewNotificationPreference(owner) // Turkish, immediately
+	pmPref := entity.NewNotificationPreference(pm)
+	pmPref.Locale = entity.LocaleEnglish
+	pmPref.Digest = entity.DigestDaily
+	for _, p := range []*entity.NotificationPreference{ownerPref, pmPref, entity.NewNotificationPreference(viewer), entity.NewNotificationPreference(outsider)} {
+		if err := emails.SavePreference(ctx, p); err != nil {
+			t.Fatalf("SavePreference returned error: %v", err)
+		}
+	}
+	invalid := entity.NewNotificationPreference(owner)
+	invalid.Locale = "de"
+	if err := emails.SavePreference(ctx, invalid); err != entity.ErrInvalidNotificationPreference {
+		t.Errorf("SavePreference(locale de) error = %v, want ErrInvalidNotificationPreference", err)
+	}
+
+	// A submitted pay application reaches the inbox and the email channel through its event
+	notifications := NewNotificationService(&stubNotificationRepository{}, emails)
+	app := &entity.PayApplication{ID: uuid.New(), ProjectID: project.ID, ApplicationNo: 3, Currency: "TRY",
+		PeriodEnd: time.Date(2026, 2, 28, 0, 0, 0, 0, time.UTC), CurrentPaymentDue: 125000000}
+	event, _ := entity.NewEvent(entity.EventPayApplicationSubmitted, project.ID, app)
+	for i := 0; i < 2; i++ { // Redelivered by the outbox
+		if err := notifications.HandleEvent(ctx, event); err != nil {
+			t.Fatalf("HandleEvent returned error: %v", err)
+		}
+	}
+
+	if len(transport.sent) != 1 {
+		t.Fatalf("sent %d emails, want 1 to the owner", len(transport.sent))
+	}
+	msg := transport.sent[0]
+	if msg.To != "owner@example.com" || msg.Subject != "[PRJ-7] 3 numaralı hakediş onaya sunuldu" {
+		t.Errorf("email = %s %q", msg.To, msg.Subject)
+	}
+	if !strings.Contains(msg.Body, "Kadıköy Konut projesinin 2026-02-28") || !strings.Contains(msg.Body, "Bildirim tercihlerinizi") {
+		t.Errorf("body = %q", msg.Body)
+	}
+
+	// The manager's English email waits for the digest, with a guarantee alert after it
+	guarantee := entity.NewNotification(project.ID, entity.NotificationGuaranteeExpiring, entity.NotificationWarning, "Teminat", "")
+	guarantee.Params = map[string]string{"bank": "Ziraat", "letter_no": "TM-1", "days_left": "10", "amount": "₺500.000,00", "expiry_date": "2026-03-15", "type": "PERFORMANCE"}
+	if err := emails.Notify(ctx, guarantee); err != nil {
+		t.Fatalf("Notify returned error: %v", err)
+	}
+	if len(digests.entries) != 2 {
+		t.Fatalf("held %d digest entries, want 2", len(digests.entries))
+	}
+
+	transport.sent = nil
+	sent, err := emails.SendDigests(ctx, time.Now())
+	if err != nil || sent != 1 {
+		t.Fatalf("SendDigests = %d, %v; want 1 digest", sent, err)
+	}
+	digest := transport.sent[0]
+	if digest.To != "pm@example.com" || digest.Subject != "Subflow daily digest: 2 notifications" {
+		t.Errorf("digest = %s %q", digest.To, digest.Subject)
+	}
+	for _, want := range []string{"Pay application #3 submitted", "Guarantee expires in 10 days: Ziraat TM-1"} {
+		if !strings.Contains(digest.Body, want) {
+			t.Errorf("digest body lacks %q:\n%s", want, digest.Body)
+		}
+	}
+	if strings.Count(digest.Body, "notification preferences") != 1 {
+		t.Errorf("digest body should end with one footer:\n%s", digest.Body)
+	}
+
+	if sent, _ := emails.SendDigests(ctx, time.Now()); sent != 0 {
+		t.Errorf("second SendDigests sent %d, want 0", sent)
+	}
+}
+
+func TestEmailTemplatesFallback(t *testing.T) {
+	templates := NewEmailTemplates()
+	project := entity.NewProject(uuid.New(), "Ankara Ofis", "PRJ-9")
+	n := entity.NewNotification(project.ID, "CUSTOM", entity.NotificationInfo, "Özel uyarı", "Detaylar")
+
+	subject, body, err := templates.Render(entity.LocaleEnglish, n, project)
+	if err != nil {
+		t.Fatalf("Render returned error: %v", err)
+	}
+	if subject != "[PRJ-9] Özel uyarı" || !strings.Contains(body, "Detaylar") {
+		t.Errorf("default template rendered %q / %q", subject, body)
+	}
+
+	if err := templates.Add(entity.LocaleEnglish, "CUSTOM", `{{define "subject"}}Custom {{.P.code}}{{end}}`); err == nil {
+		t.Error("Add accepted a template without a body")
+	}
+	if err := templates.Add(entity.LocaleEnglish, "CUSTOM", `{{define "subject"}}Custom {{.P.code}}{{end}}{{define "body"}}Body{{end}}`); err != nil {
+		t.Fatalf("Add returned error: %v", err)
+	}
+	if subject, _, _ := templates.Render(entity.LocaleEnglish, n, project); subject != "Custom" {
+		t.Errorf("missing param rendered %q, want it empty", subject)
+	}
+}
@@ -0,0 +1,250 @@
+// Copyright (c) 2026 Muhammet Ali Büyük. All rights reserved.
+// This source code is proprietary. Confidential and private.
+// Unauthorized copying or distribution is strictly prohibited.
+// Contact: iletisim@alibuyuk.net | Website: alibuyuk.net
+
+package service
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/qantesm/subflow/internal/core/entity"
+)
+
+// PriceIndexRepository is the port for monthly price index values
+type PriceIndexRepository interface {
+	SaveIndices(ctx context.Context, indices []*entity.PriceIndex) error // Replaces existing values for the same code and month
+	FindIndex(ctx context.Context, code string, period time.Time) (*entity.PriceIndex, error)
+	ListIndices(ctx context.Context, code string) ([]*entity.PriceIndex, error) // Ordered by period
+}
+
+// EscalationRepository is the port for contract formulas and calculated adjustments
+type EscalationRepository interface {
+	SaveFormula(ctx context.Context, formula *entity.EscalationFormula) error
+	FindFormulaByID(ctx context.Context, id uuid.UUID) (*entity.EscalationFormula, error)
+	FindFormulasByProject(ctx context.Context, projectID uuid.UUID) ([]*entity.EscalationFormula, error)
+	SaveResult(ctx context.Context, result *entity.EscalationResult) error
+	FindResultsByProject(ctx context.Context, projectID uuid.UUID) ([]*entity.EscalationResult, error)
+}
+
+// IndexImportResult reports the outcome of a price index import
+type IndexImportResult struct {
+	Codes    []string         `json:"codes"`
+	Months   int              `json:"months"`
+	Imported int              `json:"imported"`
+	Errors   []ImportRowError `json:"errors,omitempty"`
+}
+
+// EscalationService maintains index tables and computes price escalation (fiyat farkı)
+type EscalationService struct {
+	indices   PriceIndexRepository
+	repo      EscalationRepository
+	architect string
+}
+
+// NewEscalationService creates a new escalation service
+func NewEscalationService(indices PriceIndexRepository, repo EscalationRepository) *EscalationService {
+	return &EscalationService{
+		indices:   indices,
+		repo:      repo,
+		architect: "Muhammet-Ali-Buyuk",
+	}
+}
+
+// ImportIndices stores index values from a decoded CSV/XLSX table
+// The first column is the month (YYYY-MM, MM.YYYY or a date) and every other
+// header is an index code, as in TÜİK's published tables. Empty cells are skipped.
+// Nothing is saved unless every row is valid.
+func (s *EscalationService) ImportIndices(ctx context.Context, records [][]string, source string) (*IndexImportResult, error) {
+	if len(records) < 2 || len(records[0]) < 2 {
+		return nil, entity.ErrImportEmpty
+	}
+
+	codes := make([]string, len(records[0]))
+	result := &IndexImportResult{}
+	for i, header := range records[0][1:] {
+		code := strings.ToUpper(strings.TrimSpace(header))
+		if code == "" {
+			return nil, entity.ErrImportMissingColumn
+		}
+		codes[i+1] = code
+		result.Codes = append(result.Codes, code)
+	}
+
+	var indices []*entity.PriceIndex
+	for i, record := range records[1:] {
+		rowNo := i + 2
+		if isBlankRecord(record) {
+			continue
+		}
+
+		period, err := ParseIndexMonth(record[0])
+		if err != nil {
+			result.Errors = append(result.Errors, ImportRowError{Row: rowNo, Column: records[0][0], Message: err.Error()})
+			continue
+		}
+		result.Months++
+
+		for col := 1; col < len(record) && col < len(codes); col++ {
+			raw := strings.TrimSpace(record[col])
+			if raw == "" {
+				continue
+			}
+			value, err := ParseAmountCents(raw)
+			if err != nil || value <= 0 {
+				result.Errors = append(result.Errors, ImportRowError{Row: rowNo, Column: codes[col], Message: fmt.Sprintf("invalid index value %q", raw)})
+				continue
+			}
+			indices = append(indices, entity.NewPriceIndex(codes[col], period, value, source))
+		}
+	}
+
+	if len(result.Errors) > 0 {
+		return result, entity.ErrImportRowsInvalid
+	}
+	if err := s.indices.SaveIndices(ctx, indices); err != nil {
+		return nil, err
+	}
+	result.Imported = len(indices)
+	return result, nil
+}
+
+// ListIndices returns the stored values of an index
+func (s *EscalationService) ListIndices(ctx context.Context, code string) ([]*entity.PriceIndex, error) {
+	return s.indices.ListIndices(ctx, strings.ToUpper(code))
+}
+
+// CreateFormula validates and stores a contract formula
+func (s *EscalationService) CreateFormula(ctx context.Context, formula *entity.EscalationFormula) error {
+	for i := range formula.Components {
+		formula.Components[i].IndexCode = strings.ToUpper(formula.Components[i].IndexCode)
+	}
+	if err := formula.Validate(); err != nil {
+		return err
+	}
+	return s.repo.SaveFormula(ctx, formula)
+}
+
+// GetFormula returns a single formula
+func (s *EscalationService) GetFormula(ctx context.Context, id uuid.UUID) (*entity.EscalationFormula, error) {
+	return s.repo.FindFormulaByID(ctx, id)
+}
+
+// ListFormulas returns the formulas defined for a project
+func (s *EscalationService) ListFormulas(ctx context.Context, projectID uuid.UUID) ([]*entity.EscalationFormula, error) {
+	return s.repo.FindFormulasByProject(ctx, projectID)
+}
+
+// ResultsByProject returns every recorded escalation of a project with the indices used
+func (s *EscalationService) ResultsByProject(ctx context.Context, projectID uuid.UUID) ([]*entity.EscalationResult, error) {
+	return s.repo.FindResultsByProject(ctx, projectID)
+}
+
+// Calculate computes F = An × B × (Pn − 1) for work done in a period ending on periodEnd
+// Pn is rounded to six decimals and F to the cent, half away from zero. The result is not stored.
+func (s *EscalationService) Calculate(ctx context.Context, formulaID uuid.UUID, periodEnd time.Time, workAmount int64, createdBy uuid.UUID) (*entity.EscalationResult, error) {
+	formula, err := s.repo.FindFormulaByID(ctx, formulaID)
+	if err != nil {
+		return nil, err
+	}
+
+	indexMonth := formula.IndexMonth(periodEnd)
+	result := &entity.EscalationResult{
+		ID:           uuid.New(),
+		ProjectID:    formula.ProjectID,
+		FormulaID:    formula.ID,
+		WorkAmount:   workAmount,
+		Coefficient:  formula.Coefficient,
+		IndexMonth:   indexMonth,
+		CalculatedAt: time.Now(),
+		CreatedBy:    createdBy,
+	}
+
+	pn := new(big.Rat)
+	for _, component := range formula.Components {
+		base, err := s.findIndex(ctx, component.IndexCode, formula.BaseMonth)
+		if err != nil {
+			return nil, err
+		}
+		current, err := s.findIndex(ctx, component.IndexCode, indexMonth)
+		if err != nil {
+			return nil, err
+		}
+
+		// weight/10000 × In/Io
+		term := new(big.Rat).SetFrac(
+			big.NewInt(0).Mul(big.NewInt(component.Weight), big.NewInt(current.Value)),
+			big.NewInt(0).Mul(big.NewInt(10000), big.NewInt(base.Value)),
+		)
+		pn.Add(pn, term)
+
+		result.Indices = append(result.Indices, entity.EscalationIndexUsed{
+			Name:         component.Name,
+			IndexCode:    component.IndexCode,
+			Weight:       component.Weight,
+			BaseMonth:    base.Period,
+			BaseValue:    base.Value,
+			CurrentMonth: current.Period,
+			CurrentValue: current.Value,
+			Term:         roundRat(new(big.Rat).Mul(term, new(big.Rat).SetInt64(entity.PnScale))),
+		})
+	}
+	result.Pn = roundRat(pn.Mul(pn, new(big.Rat).SetInt64(entity.PnScale)))
+
+	// An × B/10000 × (Pn − 1)
+	f := new(big.Rat).SetFrac(
+		big.NewInt(0).Mul(big.NewInt(workAmount), big.NewInt(formula.Coefficient*(result.Pn-entity.PnScale))),
+		big.NewInt(10000*entity.PnScale),
+	)
+	result.Amount = roundRat(f)
+	return result, nil
+}
+
+// Record stores a calculated escalation against its pay application
+func (s *EscalationService) Record(ctx context.Context, result *entity.EscalationResult, payApplicationID uuid.UUID) error {
+	result.PayApplicationID = payApplicationID
+	return s.repo.SaveResult(ctx, result)
+}
+
+func (s *EscalationService) findIndex(ctx context.Context, code string, month time.Time) (*entity.PriceIndex, error) {
+	index, err := s.indices.FindIndex(ctx, code, month)
+	if err == entity.ErrPriceIndexNotFound {
+		return nil, fmt.Errorf("%w: %s %s", err, code, month.Format("2006-01"))
+	}
+	return index, err
+}
+
+// indexMonthLayouts lists the accepted month formats of index tables
+var indexMonthLayouts = []string{"2006-01", "2006/01", "01.2006", "01/2006", "1.2006", "1/2006"}
+
+// ParseIndexMonth parses the month column of an index table
+func ParseIndexMonth(raw string) (time.Time, error) {
+	raw = strings.TrimSpace(raw)
+	for _, layout := range indexMonthLayouts {
+		if t, err := time.Parse(layout, raw); err == nil {
+			return entity.MonthOf(t), nil
+		}
+	}
+	if t, err := ParseImportDate(raw); err == nil {
+		return entity.MonthOf(t), nil
+	}
+	return time.Time{}, fmt.Errorf("invalid month %q, expected YYYY-MM or MM.YYYY", raw)
+}
+
+// roundRat rounds to the nearest integer, halves away from zero
+func roundRat(r *big.Rat) int64 {
+	num := new(big.Int).Abs(r.Num())
+	q, rem := new(big.Int).QuoRem(num, r.Denom(), new(big.Int))
+	if rem.Lsh(rem, 1).Cmp(r.Denom()) >= 0 {
+		q.Add(q, big.NewInt(1))
+	}
+	if r.Sign() < 0 {
+		q.Neg(q)
+	}
+	return q.Int64()
+}
@@ -0,0 +1,245 @@
+// Copyright (c) 2026 Muhammet Ali Büyük. All rights reserved.
+// This source code is proprietary. Confidential and private.
+// Unauthorized copying or distribution is strictly prohibited.
+// Contact: iletisim@alibuyuk.net | Website: alibuyuk.net
+
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/qantesm/subflow/internal/core/entity"
+)
+
+type stubPriceIndexRepository struct {
+	indices []*entity.PriceIndex
+}
+
+func (r *stubPriceIndexRepository) SaveIndices(ctx context.Context, indices []*entity.PriceIndex) error {
+	r.indices = append(r.indices, indices...)
+	return nil
+}
+
+func (r *stubPriceIndexRepository) FindIndex(ctx context.Context, code string, period time.Time) (*entity.PriceIndex, error) {
+	for i := len(r.indices) - 1; i >= 0; i-- {
+		if r.indices[i].Code == code && r.indices[i].Period.Equal(entity.MonthOf(period)) {
+			return r.indices[i], nil
+		}
+	}
+	return nil, entity.ErrPriceIndexNotFound
+}
+
+func (r *stubPriceIndexRepository) ListIndices(ctx context.Context, code string) ([]*entity.PriceIndex, error) {
+	var result []*entity.PriceIndex
+	for _, index := range r.indices {
+		if index.Code == code {
+			result = append(result, index)
+		}
+	}
+	return result, nil
+}
+
+type stubEscalationRepository struct {
+	formulas []*entity.EscalationFormula
+	results  []*entity.EscalationResult
+}
+
+func (r *stubEscalationRepository) SaveFormula(ctx context.Context, formula *entity.EscalationFormula) error {
+	r.formulas = append(r.formulas, formula)
+	return nil
+}
+
+func (r *stubEscalationRepository) FindFormulaByID(ctx context.Context, id uuid.UUID) (*entity.EscalationFormula, error) {
+	for _, formula := range r.formulas {
+		if formula.ID == id {
+			return formula, nil
+		}
+	}
+	return nil, entity.ErrEscalationFormulaNotFound
+}
+
+func (r *stubEscalationRepository) FindFormulasByProject(ctx context.Context, projectID uuid.UUID) ([]*entity.EscalationFormula, error) {
+	var result []*entity.EscalationFormula
+	for _, formula := range r.formulas {
+		if formula.ProjectID == projectID {
+			result = append(result, formula)
+		}
+	}
+	return result, nil
+}
+
+func (r *stubEscalationRepository) SaveResult(ctx context.Context, result *entity.EscalationResult) error {
+	r.results = append(r.results, result)
+	return nil
+}
+
+func (r *stubEscalationRepository) FindResultsByProject(ctx context.Context, projectID uuid.UUID) ([]*entity.EscalationResult, error) {
+	var results []*entity.EscalationResult
+	for _, result := range r.results {
+		if result.ProjectID == projectID {
+			results = append(results, result)
+		}
+	}
+	return results, nil
+}
+
+func newTestEscalationService() *EscalationService {
+	return NewEscalationService(&stubPriceIndexRepository{}, &stubEscalationRepository{})
+}
+
+// importTestIndices loads a base month (2025-06) and two period months
+func importTestIndices(t *testing.T, s *EscalationService) {
+	t.Helper()
+	records := [][]string{
+		{"Ay", "Iscilik", "cimento", "DEMIR"},
+		{"2025-06", "100,00", "200,00", "400,00"},
+		{"02.2026", "120,00", "250,00", "380,00"},
+		{"2026/03", "130,00", "260,00", "400,00"},
+	}
+	result, err := s.ImportIndices(context.Background(), records, "TÜİK")
+	if err != nil {
+		t.Fatalf("ImportIndices() error = %v", err)
+	}
+	if result.Imported != 9 || result.Months != 3 || len(result.Codes) != 3 || result.Codes[1] != "CIMENTO" {
+		t.Fatalf("import result = %+v", result)
+	}
+}
+
+func testFormula(projectID uuid.UUID) *entity.EscalationFormula {
+	return entity.NewEscalationFormula(projectID, "Sözleşme formülü", time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC), []entity.EscalationComponent{
+		{Name: "İşçilik", IndexCode: "iscilik", Weight: 5000},
+		{Name: "Çimento", IndexCode: "CIMENTO", Weight: 3000},
+		{Name: "Demir", IndexCode: "DEMIR", Weight: 2000},
+	}, uuid.New())
+}
+
+func TestEscalationCalculate(t *testing.T) {
+	ctx := context.Background()
+	s := newTestEscalationService()
+	importTestIndices(t, s)
+
+	formula := testFormula(uuid.New())
+	if err := s.CreateFormula(ctx, formula); err != nil {
+		t.Fatalf("CreateFormula() error = %v", err)
+	}
+
+	// March work uses February indices: Pn = 0.5×1.20 + 0.3×1.25 + 0.2×0.95 = 1.165
+	// F = 1.000.000,00 × 0.90 × 0.165 = 148.500,00
+	result, err := s.Calculate(ctx, formula.ID, time.Date(2026, 3, 31, 0, 0, 0, 0, time.UTC), 100000000, uuid.New())
+	if err != nil {
+		t.Fatalf("Calculate() error = %v", err)
+	}
+	if result.Pn != 1165000 || result.Amount != 14850000 {
+		t.Errorf("Pn = %d, F = %d, want 1165000 and 14850000", result.Pn, result.Amount)
+	}
+	if len(result.Indices) != 3 || result.Indices[2].CurrentValue != 38000 || result.Indices[2].Term != 190000 ||
+		!result.IndexMonth.Equal(time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("indices used = %+v, month %s", result.Indices, result.IndexMonth)
+	}
+
+	// Pn rounds to six decimals before F: 0.5×1.30 + 0.3×1.30 + 0.2×1.00 = 1.24
+	formula.IndexLagMonths = 0
+	result, err = s.Calculate(ctx, formula.ID, time.Date(2026, 3, 31, 0, 0, 0, 0, time.UTC), 12345, uuid.New())
+	if err != nil || result.Pn != 1240000 || result.Amount != 2667 { // 123,45 × 0.90 × 0.24 = 26.6652
+		t.Errorf("Calculate(no lag) = %+v, %v", result, err)
+	}
+
+	// April indices were never imported
+	if _, err := s.Calculate(ctx, formula.ID, time.Date(2026, 4, 30, 0, 0, 0, 0, time.UTC), 100, uuid.New()); !errors.Is(err, entity.ErrPriceIndexNotFound) {
+		t.Errorf("missing index error = %v", err)
+	}
+	if _, err := s.Calculate(ctx, uuid.New(), time.Now(), 100, uuid.New()); !errors.Is(err, entity.ErrEscalationFormulaNotFound) {
+		t.Errorf("unknown formula error = %v", err)
+	}
+}
+
+func TestEscalationFormulaValidation(t *testing.T) {
+	ctx := context.Background()
+	s := newTestEscalationService()
+
+	formula := testFormula(uuid.New())
+	formula.Components[0].Weight = 4000
+	if err := s.CreateFormula(ctx, formula); !errors.Is(err, entity.ErrEscalationWeights) {
+		t.Errorf("weights summing to 0.90 error = %v", err)
+	}
+
+	formula = testFormula(uuid.New())
+	formula.Components[1].IndexCode = "ISCILIK"
+	if err := s.CreateFormula(ctx, formula); !errors.Is(err, entity.ErrInvalidEscalationFormula) {
+		t.Errorf("duplicate index error = %v", err)
+	}
+
+	records := [][]string{
+		{"Ay", "YI-UFE"},
+		{"2026-13", "3.100,50"},
+		{"2026-01", "abc"},
+	}
+	result, err := s.ImportIndices(ctx, records, "")
+	if !errors.Is(err, entity.ErrImportRowsInvalid) || len(result.Errors) != 2 {
+		t.Errorf("invalid import = %+v, %v", result, err)
+	}
+}
+
+func TestPayApplicationCarriesEscalation(t *testing.T) {
+	ctx := context.Background()
+	projectID := uuid.New()
+	escalation := newTestEscalationService()
+	importTestIndices(t, escalation)
+
+	formula := testFormula(projectID)
+	if err := escalation.CreateFormula(ctx, formula); err != nil {
+		t.Fatalf("CreateFormula() error = %v", err)
+	}
+
+	ledger := NewLedgerService(&stubTransactionRepository{})
+	payApps := NewPayApplicationService(&stubPayApplicationRepository{}, NewCalculator(), NewTaxEngine(&stubTaxRateRepository{}),
+		escalation, NewAdvanceService(&stubAdvanceRepository{}, ledger), newTestDeductionService(ledger), ledger)
+
+	first, err := payApps.Create(ctx, uuid.Nil, projectID, PayApplicationRequest{
+		PeriodStart:         time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC),
+		PeriodEnd:           time.Date(2026, 3, 31, 0, 0, 0, 0, time.UTC),
+		Currency:            "TRY",
+		Billing:             AIABillingInput{OriginalContractSum: 500000000, CurrentWorkCompleted: 100000000},
+		EscalationFormulaID: &formula.ID,
+	}, uuid.New())
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if first.PriceEscalation != 14850000 || first.CurrentPaymentDue != 114850000 {
+		t.Errorf("first = escalation %d due %d", first.PriceEscalation, first.CurrentPaymentDue)
+	}
+
+	// April uses March indices: Pn = 0.5×1.30 + 0.3×1.30 + 0.2×1.00 = 1.24, F = 500.000,00 × 0.90 × 0.24
+	second, err := payApps.Create(ctx, uuid.Nil, projectID, PayApplicationRequest{
+		PeriodStart:         time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC),
+		PeriodEnd:           time.Date(2026, 4, 30, 0, 0, 0, 0, time.UTC),
+		Currency:            "TRY",
+		Billing:             AIABillingInput{OriginalContractSum: 500000000, PreviousWorkCompleted: 100000000, CurrentWorkCompleted: 50000000, PreviousCertificates: first.TotalEarned},
+		EscalationFormulaID: &formula.ID,
+	}, uuid.New())
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if second.PriceEscalation != 10800000 || second.TotalEarned != 150000000+14850000+10800000 || second.CurrentPaymentDue != 60800000 {
+		t.Errorf("second = escalation %d earned %d due %d", second.PriceEscalation, second.TotalEarned, second.CurrentPaymentDue)
+	}
+
+	audit, _ := escalation.ResultsByProject(ctx, projectID)
+	if len(audit) != 2 || audit[1].PayApplicationID != second.ID {
+		t.Errorf("audit trail = %+v", audit)
+	}
+
+	other := testFormula(uuid.New())
+	_ = escalation.CreateFormula(ctx, other)
+	if _, err := payApps.Create(ctx, uuid.Nil, projectID, PayApplicationRequest{
+		PeriodEnd:           time.Date(2026, 3, 31, 0, 0, 0, 0, time.UTC),
+		Billing:             AIABillingInput{OriginalContractSum: 500000000},
+		EscalationFormulaID: &other.ID,
+	}, uuid.New()); !errors.Is(err, entity.ErrEscalationProjectMismatch) {
+		t.Errorf("foreign formula error = %v", err)
+	}
+}
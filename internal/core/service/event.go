@@ -0,0 +1,91 @@
+// Copyright (c) 2026 Muhammet Ali Büyük. All rights reserved.
+// This source code is proprietary. Confidential and private.
+// Unauthorized copying or distribution is strictly prohibited.
+// Contact: iletisim@alibuyuk.net | Website: alibuyuk.net
+
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/qantesm/subflow/internal/core/entity"
+)
+
+// EventPublisher is the port the outbox dispatcher hands domain events to.
+// Events are delivered at least once; the event ID is the deduplication key.
+type EventPublisher interface {
+	Publish(ctx context.Context, event *entity.Event) error
+}
+
+// EventHandler receives the events an in-process subscriber asked for
+type EventHandler func(ctx context.Context, event *entity.Event) error
+
+// EventBus fans events out to in-process subscribers
+type EventBus struct {
+	mu          sync.RWMutex
+	subscribers map[int]eventSubscriber
+	next        int
+}
+
+type eventSubscriber struct {
+	handler EventHandler
+	types   []entity.EventType // Empty for every event
+}
+
+// NewEventBus creates an event bus without subscribers
+func NewEventBus() *EventBus {
+	return &EventBus{
+		subscribers: make(map[int]eventSubscriber),
+	}
+}
+
+// Subscribe calls handler for events of the given types, or every event when none are given.
+// The returned function removes the subscription.
+func (b *EventBus) Subscribe(handler EventHandler, types ...entity.EventType) func() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	id := b.next
+	b.next++
+	b.subscribers[id] = eventSubscriber{handler: handler, types: types}
+	return func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		delete(b.subscribers, id)
+	}
+}
+
+// Publish calls every matching subscriber. All of them run even when one fails;
+// the failures are returned together so the outbox retries the event.
+func (b *EventBus) Publish(ctx context.Context, event *entity.Event) error {
+	b.mu.RLock()
+	handlers := make([]EventHandler, 0, len(b.subscribers))
+	for _, sub := range b.subscribers {
+		if sub.wants(event.Type) {
+			handlers = append(handlers, sub.handler)
+		}
+	}
+	b.mu.RUnlock()
+
+	var errs []error
+	for _, handler := range handlers {
+		if err := handler(ctx, event); err != nil {
+			errs = append(errs, fmt.Errorf("subscriber: %w", err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (s eventSubscriber) wants(t entity.EventType) bool {
+	if len(s.types) == 0 {
+		return true
+	}
+	for _, want := range s.types {
+		if want == t {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,220 @@
+// Copyright (c) 2026 Muhammet Ali Büyük. All rights reserved.
+// This source code is proprietary. Confidential and private.
+// Unauthorized copying or distribution is strictly prohibited.
+// Contact: iletisim@alibuyuk.net | Website: alibuyuk.net
+
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/qantesm/subflow/internal/core/entity"
+)
+
+// DefaultGuaranteeAlertDays is how long before expiry a guarantee alert is raised
+const DefaultGuaranteeAlertDays = 30
+
+// GuaranteeRepository is the port for bank guarantee letter persistence
+type GuaranteeRepository interface {
+	Save(ctx context.Context, g *entity.BankGuarantee) error // ErrGuaranteeExists on a duplicate bank and letter number
+	Update(ctx context.Context, g *entity.BankGuarantee) error
+	FindByID(ctx context.Context, id uuid.UUID) (*entity.BankGuarantee, error)
+	FindByProjectID(ctx context.Context, projectID uuid.UUID) ([]*entity.BankGuarantee, error)
+	FindActive(ctx context.Context) ([]*entity.BankGuarantee, error) // Active letters of all projects
+}
+
+// ExpiryCheckResult reports what a guarantee expiry run did
+type ExpiryCheckResult struct {
+	Checked int `json:"checked"`
+	Alerted int `json:"alerted"` // Pre-expiry alerts raised
+	Expired int `json:"expired"` // Letters moved to EXPIRED
+}
+
+// GuaranteeService maintains the bank guarantee letter register
+type GuaranteeService struct {
+	repo      GuaranteeRepository
+	notifier  Notifier
+	alertDays int
+	audit     Auditor // Optional: records changes to the register in the audit trail
+	architect string
+}
+
+// NewGuaranteeService creates a new guarantee service alerting alertDays before expiry
+func NewGuaranteeService(repo GuaranteeRepository, notifier Notifier, alertDays int) *GuaranteeService {
+	if alertDays <= 0 {
+		alertDays = DefaultGuaranteeAlertDays
+	}
+	return &GuaranteeService{
+		repo:      repo,
+		notifier:  notifier,
+		alertDays: alertDays,
+		architect: "Muhammet-Ali-Buyuk",
+	}
+}
+
+// SetAuditor records every registration, reduction, return, extension and expiry in the audit trail
+func (s *GuaranteeService) SetAuditor(audit Auditor) {
+	s.audit = audit
+}
+
+// Register validates and stores a new guarantee letter
+func (s *GuaranteeService) Register(ctx context.Context, g *entity.BankGuarantee) error {
+	if err := g.Validate(); err != nil {
+		return err
+	}
+	if err := s.repo.Save(ctx, g); err != nil {
+		return err
+	}
+	recordAudit(ctx, s.audit, entity.AuditActionGuaranteeRegister, "guarantee", g.ID, g.ProjectID, nil, g)
+	return nil
+}
+
+// Get returns a single guarantee
+func (s *GuaranteeService) Get(ctx context.Context, id uuid.UUID) (*entity.BankGuarantee, error) {
+	return s.repo.FindByID(ctx, id)
+}
+
+// ListByProject returns the guarantees given on a project
+func (s *GuaranteeService) ListByProject(ctx context.Context, projectID uuid.UUID) ([]*entity.BankGuarantee, error) {
+	return s.repo.FindByProjectID(ctx, projectID)
+}
+
+// Reduce lowers the amount of an active guarantee
+func (s *GuaranteeService) Reduce(ctx context.Context, id uuid.UUID, newAmount int64, reason string, reducedBy uuid.UUID) (*entity.BankGuarantee, error) {
+	return s.update(ctx, id, entity.AuditActionGuaranteeReduce, func(g *entity.BankGuarantee) error {
+		return g.Reduce(newAmount, reason, reducedBy)
+	})
+}
+
+// Return marks a guarantee as returned to the bank
+func (s *GuaranteeService) Return(ctx context.Context, id uuid.UUID) (*entity.BankGuarantee, error) {
+	return s.update(ctx, id, entity.AuditActionGuaranteeReturn, func(g *entity.BankGuarantee) error {
+		return g.Return()
+	})
+}
+
+// Extend records a renewed expiry date
+func (s *GuaranteeService) Extend(ctx context.Context, id uuid.UUID, expiryDate time.Time) (*entity.BankGuarantee, error) {
+	return s.update(ctx, id, entity.AuditActionGuaranteeExtend, func(g *entity.BankGuarantee) error {
+		return g.Extend(expiryDate)
+	})
+}
+
+// Expiring returns active guarantees expiring within the given number of days, soonest first
+func (s *GuaranteeService) Expiring(ctx context.Context, now time.Time, days int) ([]*entity.BankGuarantee, error) {
+	active, err := s.repo.FindActive(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var expiring []*entity.BankGuarantee
+	for _, g := range active {
+		if left, ok := g.DaysToExpiry(now); ok && left <= days {
+			expiring = append(expiring, g)
+		}
+	}
+	sort.Slice(expiring, func(i, j int) bool {
+		return expiring[i].ExpiryDate.Before(*expiring[j].ExpiryDate)
+	})
+	return expiring, nil
+}
+
+// CheckExpiries raises one alert per letter entering the alert window and
+// expires letters whose date has passed. Safe to run repeatedly.
+func (s *GuaranteeService) CheckExpiries(ctx context.Context, now time.Time) (*ExpiryCheckResult, error) {
+	active, err := s.repo.FindActive(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ExpiryCheckResult{Checked: len(active)}
+	for _, g := range active {
+		left, ok := g.DaysToExpiry(now)
+		if !ok || left > s.alertDays {
+			continue
+		}
+
+		before := *g
+		var n *entity.Notification
+		switch {
+		case left < 0:
+			g.Status = entity.GuaranteeStatusExpired
+			n = entity.NewNotification(g.ProjectID, entity.NotificationGuaranteeExpired, entity.NotificationCritical,
+				fmt.Sprintf("Teminat mektubu süresi doldu: %s %s", g.Bank, g.LetterNo),
+				fmt.Sprintf("%s guarantee %s from %s for %s expired on %s.",
+					g.Type, g.LetterNo, g.Bank, FormatCurrency(g.Amount, g.Currency), g.ExpiryDate.Format("2006-01-02")))
+			result.Expired++
+		case g.ExpiryAlertedAt == nil:
+			alertedAt := now
+			g.ExpiryAlertedAt = &alertedAt
+			n = entity.NewNotification(g.ProjectID, entity.NotificationGuaranteeExpiring, entity.NotificationWarning,
+				fmt.Sprintf("Teminat mektubu %d gün içinde sona eriyor: %s %s", left, g.Bank, g.LetterNo),
+				fmt.Sprintf("%s guarantee %s from %s for %s expires on %s. Ask the bank for an extension or plan its return.",
+					g.Type, g.LetterNo, g.Bank, FormatCurrency(g.Amount, g.Currency), g.ExpiryDate.Format("2006-01-02")))
+			result.Alerted++
+		default:
+			continue
+		}
+
+		n.ReferenceID = &g.ID
+		n.Params = map[string]string{
+			"type":        string(g.Type),
+			"bank":        g.Bank,
+			"letter_no":   g.LetterNo,
+			"amount":      FormatCurrency(g.Amount, g.Currency),
+			"expiry_date": g.ExpiryDate.Format("2006-01-02"),
+			"days_left":   strconv.Itoa(left),
+		}
+		g.UpdatedAt = now
+		if err := s.repo.Update(ctx, g); err != nil {
+			return result, err
+		}
+		if g.Status == entity.GuaranteeStatusExpired {
+			recordAudit(ctx, s.audit, entity.AuditActionGuaranteeExpire, "guarantee", g.ID, g.ProjectID, &before, g)
+		}
+		if err := s.notifier.Notify(ctx, n); err != nil {
+			return result, err
+		}
+	}
+	return result, nil
+}
+
+func (s *GuaranteeService) update(ctx context.Context, id uuid.UUID, action string, change func(g *entity.BankGuarantee) error) (*entity.BankGuarantee, error) {
+	g, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	before := *g
+	if err := change(g); err != nil {
+		return nil, err
+	}
+	if err := s.repo.Update(ctx, g); err != nil {
+		return nil, err
+	}
+	recordAudit(ctx, s.audit, action, "guarantee", g.ID, g.ProjectID, &before, g)
+	return g, nil
+}
+
+// GuaranteeExpiryJob runs the daily guarantee expiry check
+type GuaranteeExpiryJob struct {
+	guarantees *GuaranteeService
+}
+
+// NewGuaranteeExpiryJob creates the scheduled expiry check job
+func NewGuaranteeExpiryJob(guarantees *GuaranteeService) *GuaranteeExpiryJob {
+	return &GuaranteeExpiryJob{guarantees: guarantees}
+}
+
+func (j *GuaranteeExpiryJob) ID() string {
+	return "guarantee-expiry-check"
+}
+
+func (j *GuaranteeExpiryJob) Execute(ctx context.Context) error {
+	_, err := j.guarantees.CheckExpiries(ctx, time.Now())
+	return err
+}
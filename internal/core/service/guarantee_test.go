@@ -0,0 +1,198 @@
+// Copyright (c) 2026 Muhammet Ali Büyük. All rights reserved.
+// This source code is proprietary. Confidential and private.
+// Unauthorized copying or distribution is strictly prohibited.
+// Contact: iletisim@alibuyuk.net | Website: alibuyuk.net
+
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/qantesm/subflow/internal/core/entity"
+)
+
+type stubGuaranteeRepository struct {
+	guarantees []*entity.BankGuarantee
+}
+
+func (r *stubGuaranteeRepository) Save(ctx context.Context, g *entity.BankGuarantee) error {
+	for _, existing := range r.guarantees {
+		if existing.Bank == g.Bank && existing.LetterNo == g.LetterNo {
+			return entity.ErrGuaranteeExists
+		}
+	}
+	r.guarantees = append(r.guarantees, g)
+	return nil
+}
+
+func (r *stubGuaranteeRepository) Update(ctx context.Context, g *entity.BankGuarantee) error {
+	return nil
+}
+
+func (r *stubGuaranteeRepository) FindByID(ctx context.Context, id uuid.UUID) (*entity.BankGuarantee, error) {
+	for _, g := range r.guarantees {
+		if g.ID == id {
+			return g, nil
+		}
+	}
+	return nil, entity.ErrGuaranteeNotFound
+}
+
+func (r *stubGuaranteeRepository) FindByProjectID(ctx context.Context, projectID uuid.UUID) ([]*entity.BankGuarantee, error) {
+	var result []*entity.BankGuarantee
+	for _, g := range r.guarantees {
+		if g.ProjectID == projectID {
+			result = append(result, g)
+		}
+	}
+	return result, nil
+}
+
+func (r *stubGuaranteeRepository) FindActive(ctx context.Context) ([]*entity.BankGuarantee, error) {
+	var result []*entity.BankGuarantee
+	for _, g := range r.guarantees {
+		if g.IsActive() {
+			result = append(result, g)
+		}
+	}
+	return result, nil
+}
+
+type stubNotificationRepository struct {
+	notifications []*entity.Notification
+}
+
+func (r *stubNotificationRepository) Save(ctx context.Context, n *entity.Notification) error {
+	for _, saved := range r.notifications {
+		if saved.ID == n.ID {
+			return entity.ErrNotificationExists
+		}
+	}
+	r.notifications = append(r.notifications, n)
+	return nil
+}
+
+func (r *stubNotificationRepository) MarkRead(ctx context.Context, id uuid.UUID, readAt time.Time) error {
+	return nil
+}
+
+func (r *stubNotificationRepository) FindByProjectID(ctx context.Context, projectID uuid.UUID, unreadOnly bool) ([]*entity.Notification, error) {
+	return r.notifications, nil
+}
+
+type failingNotifier struct{}
+
+func (failingNotifier) Notify(ctx context.Context, n *entity.Notification) error {
+	return errors.New("smtp unavailable")
+}
+
+func TestGuaranteeReductionAndReturn(t *testing.T) {
+	ctx := context.Background()
+	s := NewGuaranteeService(&stubGuaranteeRepository{}, NewNotificationService(&stubNotificationRepository{}), 0)
+	projectID := uuid.New()
+
+	expiry := day(2027, 6, 30)
+	g := entity.NewBankGuarantee(projectID, entity.GuaranteeTypePerformance, "Ziraat Bankası", "TM-2026-0042", 60000000, "TRY", day(2026, 1, 15), &expiry, uuid.New())
+	if err := s.Register(ctx, g); err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+	dup := entity.NewBankGuarantee(projectID, entity.GuaranteeTypeBid, "Ziraat Bankası", "TM-2026-0042", 1000, "TRY", day(2026, 1, 15), nil, uuid.New())
+	if err := s.Register(ctx, dup); !errors.Is(err, entity.ErrGuaranteeExists) {
+		t.Errorf("duplicate letter error = %v", err)
+	}
+	backdated := day(2026, 1, 1)
+	invalid := entity.NewBankGuarantee(projectID, entity.GuaranteeTypeBid, "Halkbank", "X-1", 1000, "TRY", day(2026, 1, 15), &backdated, uuid.New())
+	if err := s.Register(ctx, invalid); !errors.Is(err, entity.ErrInvalidGuarantee) {
+		t.Errorf("expiry before issue error = %v", err)
+	}
+
+	// Half of the kesin teminat is released after provisional acceptance
+	if _, err := s.Reduce(ctx, g.ID, 70000000, "", uuid.New()); !errors.Is(err, entity.ErrInvalidGuaranteeReduction) {
+		t.Errorf("increase via Reduce() error = %v", err)
+	}
+	g, err := s.Reduce(ctx, g.ID, 30000000, "Geçici kabul", uuid.New())
+	if err != nil {
+		t.Fatalf("Reduce() error = %v", err)
+	}
+	if g.Amount != 30000000 || g.OriginalAmount != 60000000 || len(g.Reductions) != 1 || g.Reductions[0].PreviousAmount != 60000000 {
+		t.Errorf("after reduction = %+v", g)
+	}
+
+	if g, err = s.Return(ctx, g.ID); err != nil || g.Status != entity.GuaranteeStatusReturned || g.ReturnedAt == nil {
+		t.Fatalf("Return() = %+v, %v", g, err)
+	}
+	if _, err := s.Reduce(ctx, g.ID, 1000, "", uuid.New()); !errors.Is(err, entity.ErrGuaranteeNotActive) {
+		t.Errorf("reduce returned letter error = %v", err)
+	}
+}
+
+func TestGuaranteeExpiryAlerts(t *testing.T) {
+	ctx := context.Background()
+	inbox := &stubNotificationRepository{}
+	s := NewGuaranteeService(&stubGuaranteeRepository{}, NewNotificationService(inbox), 15)
+	projectID := uuid.New()
+
+	expiry := day(2026, 5, 31)
+	g := entity.NewBankGuarantee(projectID, entity.GuaranteeTypeAdvance, "Vakıfbank", "AV-7", 25000000, "TRY", day(2026, 1, 5), &expiry, uuid.New())
+	openEnded := entity.NewBankGuarantee(projectID, entity.GuaranteeTypePerformance, "Vakıfbank", "KT-1", 50000000, "TRY", day(2026, 1, 5), nil, uuid.New())
+	for _, letter := range []*entity.BankGuarantee{g, openEnded} {
+		if err := s.Register(ctx, letter); err != nil {
+			t.Fatalf("Register() error = %v", err)
+		}
+	}
+
+	steps := []struct {
+		now                      time.Time
+		wantAlerted, wantExpired int
+		wantInbox                int
+	}{
+		{day(2026, 5, 15), 0, 0, 0}, // 16 days left, outside the window
+		{day(2026, 5, 16), 1, 0, 1}, // 15 days left
+		{day(2026, 5, 20), 0, 0, 1}, // Already alerted
+		{day(2026, 5, 31), 0, 0, 1}, // Last valid day
+		{day(2026, 6, 1), 0, 1, 2},  // Expired
+		{day(2026, 6, 2), 0, 0, 2},  // No longer active
+	}
+	for _, step := range steps {
+		result, err := s.CheckExpiries(ctx, step.now)
+		if err != nil {
+			t.Fatalf("CheckExpiries(%s) error = %v", step.now.Format("2006-01-02"), err)
+		}
+		if result.Alerted != step.wantAlerted || result.Expired != step.wantExpired || len(inbox.notifications) != step.wantInbox {
+			t.Errorf("%s: result %+v, inbox %d", step.now.Format("2006-01-02"), result, len(inbox.notifications))
+		}
+	}
+	if inbox.notifications[0].Kind != entity.NotificationGuaranteeExpiring || *inbox.notifications[0].ReferenceID != g.ID ||
+		inbox.notifications[1].Severity != entity.NotificationCritical || g.Status != entity.GuaranteeStatusExpired {
+		t.Errorf("notifications = %+v %+v", inbox.notifications[0], inbox.notifications[1])
+	}
+
+	// The bank renews the letter: it is active again and will be alerted before the new date
+	if _, err := s.Extend(ctx, g.ID, day(2026, 12, 31)); err != nil {
+		t.Fatalf("Extend() error = %v", err)
+	}
+	if g.Status != entity.GuaranteeStatusActive || g.ExpiryAlertedAt != nil {
+		t.Errorf("after extension = %s, alerted %v", g.Status, g.ExpiryAlertedAt)
+	}
+	expiring, _ := s.Expiring(ctx, day(2026, 12, 1), 30)
+	if len(expiring) != 1 || expiring[0].ID != g.ID {
+		t.Errorf("Expiring() = %v", expiring)
+	}
+}
+
+func TestNotificationChannelFailureKeepsInbox(t *testing.T) {
+	inbox := &stubNotificationRepository{}
+	s := NewNotificationService(inbox, failingNotifier{})
+
+	n := entity.NewNotification(uuid.New(), entity.NotificationGuaranteeExpiring, entity.NotificationWarning, "subject", "body")
+	if err := s.Notify(context.Background(), n); err == nil {
+		t.Error("Notify() should report the failing channel")
+	}
+	if len(inbox.notifications) != 1 {
+		t.Errorf("inbox has %d notifications, want 1", len(inbox.notifications))
+	}
+}
@@ -0,0 +1,534 @@
+// Copyright (c) 2026 Muhammet Ali Büyük. All rights reserved.
+// This source code is proprietary. Confidential and private.
+// Unauthorized copying or distribution is strictly prohibited.
+// Contact: iletisim@alibuyuk.net | Website: alibuyuk.net
+
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/qantesm/subflow/internal/core/entity"
+	"github.com/qantesm/subflow/internal/pkg"
+)
+
+// Import target fields - column headers are mapped onto these names
+const (
+	ImportFieldType              = "type"
+	ImportFieldAmount            = "amount"       // Major units, e.g. "1.234,56" or "1234.56"
+	ImportFieldAmountCents       = "amount_cents" // Integer cents
+	ImportFieldCurrency          = "currency"
+	ImportFieldEffectiveDate     = "effective_date"
+	ImportFieldDescription       = "description"
+	ImportFieldReferenceNo       = "reference_no"
+	ImportFieldContractID        = "contract_id"
+	ImportFieldBankReceiptNo     = "bank_receipt_no"
+	ImportFieldInvoiceNo         = "invoice_no"
+	ImportFieldApplicationPeriod = "application_period"
+	ImportFieldNotes             = "notes"
+	ImportFieldVendorName        = "vendor_name"
+	ImportFieldRetainageRate     = "retainage_rate"
+)
+
+// ImportMapping maps source column headers to import target fields
+type ImportMapping map[string]string
+
+// DefaultImportMapping matches headers named after the target fields plus common aliases
+func DefaultImportMapping() ImportMapping {
+	mapping := ImportMapping{
+		"date":      ImportFieldEffectiveDate,
+		"tarih":     ImportFieldEffectiveDate,
+		"tutar":     ImportFieldAmount,
+		"tür":       ImportFieldType,
+		"açıklama":  ImportFieldDescription,
+		"fatura no": ImportFieldInvoiceNo,
+		"dekont no": ImportFieldBankReceiptNo,
+	}
+	for _, field := range []string{
+		ImportFieldType, ImportFieldAmount, ImportFieldAmountCents, ImportFieldCurrency,
+		ImportFieldEffectiveDate, ImportFieldDescription, ImportFieldReferenceNo,
+		ImportFieldContractID, ImportFieldBankReceiptNo, ImportFieldInvoiceNo,
+		ImportFieldApplicationPeriod, ImportFieldNotes, ImportFieldVendorName,
+		ImportFieldRetainageRate,
+	} {
+		mapping[field] = field
+	}
+	return mapping
+}
+
+// ImportOptions controls how a bulk import is processed
+type ImportOptions struct {
+	ProjectID       uuid.UUID
+	CreatedBy       uuid.UUID
+	Mapping         ImportMapping // Nil uses DefaultImportMapping
+	DefaultCurrency string        // Used when the currency column is missing or empty
+	DryRun          bool          // Validate and preview the summary without saving
+}
+
+// ImportRowError describes a validation failure on a single source row
+type ImportRowError struct {
+	Row     int    `json:"row"` // 1-based row number in the source file, header is row 1
+	Column  string `json:"column,omitempty"`
+	Message string `json:"message"`
+}
+
+// ImportResult reports the outcome of a bulk import
+type ImportResult struct {
+	ProjectID uuid.UUID        `json:"project_id"`
+	DryRun    bool             `json:"dry_run"`
+	TotalRows int              `json:"total_rows"`
+	ValidRows int              `json:"valid_rows"`
+	Imported  int              `json:"imported"`
+	Errors    []ImportRowError `json:"errors,omitempty"`
+	Summaries []*LedgerSummary `json:"summaries,omitempty"` // Resulting ledger state per currency (projected on dry run)
+}
+
+// ImportService migrates historical transactions from spreadsheet exports
+type ImportService struct {
+	repo      TransactionRepository
+	progress  ProgressReporter  // Optional: receives progress of imports that are saved
+	projects  ProjectRepository // Optional: refuses rows the project status forbids
+	gate      PaymentGate       // Optional: checks subcontractor compliance of contract payments
+	audit     Auditor           // Optional: records every saved import in the audit trail
+	architect string
+}
+
+// NewImportService creates a new import service
+func NewImportService(repo TransactionRepository) *ImportService {
+	return &ImportService{
+		repo:      repo,
+		architect: "Muhammet-Ali-Buyuk",
+	}
+}
+
+// importProgressStep is how many rows are validated between two progress reports
+const importProgressStep = 500
+
+// SetProgressReporter reports the progress of every import that is saved, dry runs aside
+func (s *ImportService) SetProgressReporter(progress ProgressReporter) {
+	s.progress = progress
+}
+
+// SetProjectRepository rejects rows whose type the project status does not accept
+func (s *ImportService) SetProjectRepository(projects ProjectRepository) {
+	s.projects = projects
+}
+
+// SetPaymentGate makes payments under a contract pass the gate, as LedgerService does
+func (s *ImportService) SetPaymentGate(gate PaymentGate) {
+	s.gate = gate
+}
+
+// SetAuditor records every saved import in the audit trail
+func (s *ImportService) SetAuditor(audit Auditor) {
+	s.audit = audit
+}
+
+// Import maps, validates and records every row of a decoded CSV/XLSX file
+// The first record is the header row. Nothing is saved unless every row is valid,
+// and all rows are committed atomically through TransactionRepository.SaveBatch
+func (s *ImportService) Import(ctx context.Context, records [][]string, opts ImportOptions) (*ImportResult, error) {
+	if len(records) < 2 {
+		return nil, entity.ErrImportEmpty
+	}
+
+	mapping := opts.Mapping
+	if mapping == nil {
+		mapping = DefaultImportMapping()
+	}
+	if opts.DefaultCurrency == "" {
+		opts.DefaultCurrency = "TRY"
+	}
+
+	columns, err := resolveColumns(records[0], mapping)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ImportResult{
+		ProjectID: opts.ProjectID,
+		DryRun:    opts.DryRun,
+	}
+
+	importID := uuid.New()
+	job := JobProgress{JobID: importID.String(), ProjectID: opts.ProjectID, Kind: "import", Total: len(records) - 1}
+	report := func(stage string, done int, message string) {
+		if s.progress == nil || opts.DryRun {
+			return
+		}
+		job.Stage, job.Done, job.Message = stage, done, message
+		s.progress.ReportProgress(ctx, job)
+	}
+	report("validating", 0, "")
+
+	var project *entity.Project
+	if s.projects != nil {
+		if project, err = s.projects.FindByID(ctx, opts.ProjectID); err != nil {
+			return nil, err
+		}
+	}
+
+	var (
+		transactions []*entity.Transaction
+		checks       = make(map[*entity.Transaction]*entity.ComplianceCheck)
+		waivers      = make(map[uuid.UUID]int) // Conditional waiver -> row that uses it
+	)
+	for i, record := range records[1:] {
+		rowNo := i + 2
+		if i > 0 && i%importProgressStep == 0 {
+			report("validating", i, "")
+		}
+		if isBlankRecord(record) {
+			continue
+		}
+		result.TotalRows++
+
+		tx, rowErr := buildImportTransaction(record, columns, opts)
+		if rowErr != nil {
+			rowErr.Row = rowNo
+			result.Errors = append(result.Errors, *rowErr)
+			continue
+		}
+		if project != nil && !project.AllowsTransaction(tx.Type) {
+			result.Errors = append(result.Errors, ImportRowError{Row: rowNo, Column: ImportFieldType,
+				Message: fmt.Sprintf("%s project does not accept %s entries", project.Status, tx.Type)})
+			continue
+		}
+		if s.gate != nil && tx.Type == entity.TransactionTypePayment && tx.ContractID != nil {
+			check, rowErr, err := s.checkPayment(ctx, tx, waivers)
+			if err != nil {
+				return nil, err
+			}
+			if rowErr != nil {
+				rowErr.Row = rowNo
+				result.Errors = append(result.Errors, *rowErr)
+				continue
+			}
+			if check.WaiverID != nil {
+				waivers[*check.WaiverID] = rowNo
+			}
+			checks[tx] = check
+		}
+		transactions = append(transactions, tx)
+	}
+	result.ValidRows = len(transactions)
+
+	if len(result.Errors) > 0 {
+		report("failed", job.Total, entity.ErrImportRowsInvalid.Error())
+		return result, entity.ErrImportRowsInvalid
+	}
+
+	existing, err := s.repo.FindByProjectID(ctx, opts.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.DryRun {
+		result.Summaries = SummarizeTransactions(opts.ProjectID, append(existing, transactions...))
+		return result, nil
+	}
+
+	events := make([]*entity.Event, len(transactions))
+	for i, tx := range transactions {
+		event, err := entity.NewEvent(entity.EventTransactionCreated, tx.ProjectID, tx)
+		if err != nil {
+			return nil, err
+		}
+		events[i] = event
+	}
+	report("saving", job.Total, "")
+	if err := s.repo.SaveBatch(ctx, transactions, events...); err != nil {
+		report("failed", job.Total, err.Error())
+		return nil, err
+	}
+	result.Imported = len(transactions)
+	report("completed", job.Total, "")
+
+	for tx, check := range checks {
+		// The rows are saved; a waiver left unmarked must not turn the import into a failure
+		if err := s.gate.PaymentRecorded(ctx, check, tx); err != nil {
+			pkg.Ctx(ctx).Warn().Err(err).Str("transaction_id", tx.ID.String()).Msg("Imported payment not reported to the compliance gate")
+		}
+	}
+
+	summaries, err := s.repo.GetProjectSummaries(ctx, opts.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+	result.Summaries = summaries
+
+	recordAudit(ctx, s.audit, entity.AuditActionTransactionImport, "import", importID, opts.ProjectID, nil, result)
+
+	return result, nil
+}
+
+// checkPayment runs an imported contract payment through the payment gate
+// A row is refused when it lacks compliance, which a file cannot override, or when its
+// conditional waiver was already claimed by an earlier row of the same file
+func (s *ImportService) checkPayment(ctx context.Context, tx *entity.Transaction, waivers map[uuid.UUID]int) (*entity.ComplianceCheck, *ImportRowError, error) {
+	check, err := s.gate.CheckPayment(ctx, tx.ProjectID, *tx.ContractID, tx.AmountCents, tx.EffectiveDate)
+	if err != nil {
+		return nil, nil, err
+	}
+	if check.WaiverID != nil {
+		if row, ok := waivers[*check.WaiverID]; ok {
+			return nil, &ImportRowError{Column: ImportFieldContractID,
+				Message: fmt.Sprintf("conditional lien waiver already used by row %d", row)}, nil
+		}
+	}
+	if check.Compliant {
+		return check, nil, nil
+	}
+	reasons := make([]string, 0, len(check.Issues))
+	for _, issue := range check.Issues {
+		reasons = append(reasons, fmt.Sprintf("%s: %s", issue.Type, issue.Reason))
+	}
+	return nil, &ImportRowError{Column: ImportFieldContractID,
+		Message: fmt.Sprintf("%s: %s", entity.ErrSubcontractorNonCompliant, strings.Join(reasons, "; "))}, nil
+}
+
+// resolveColumns maps target fields to column indexes using case-insensitive headers
+func resolveColumns(header []string, mapping ImportMapping) (map[string]int, error) {
+	normalized := make(map[string]string, len(mapping))
+	for source, field := range mapping {
+		normalized[normalizeHeader(source)] = field
+	}
+
+	columns := make(map[string]int)
+	for i, name := range header {
+		field, ok := normalized[normalizeHeader(name)]
+		if !ok {
+			continue
+		}
+		if _, dup := columns[field]; !dup {
+			columns[field] = i
+		}
+	}
+
+	if _, ok := columns[ImportFieldType]; !ok {
+		return nil, fmt.Errorf("%w: %s", entity.ErrImportMissingColumn, ImportFieldType)
+	}
+	_, hasAmount := columns[ImportFieldAmount]
+	_, hasCents := columns[ImportFieldAmountCents]
+	if !hasAmount && !hasCents {
+		return nil, fmt.Errorf("%w: %s", entity.ErrImportMissingColumn, ImportFieldAmount)
+	}
+
+	return columns, nil
+}
+
+func buildImportTransaction(record []string, columns map[string]int, opts ImportOptions) (*entity.Transaction, *ImportRowError) {
+	value := func(field string) string {
+		i, ok := columns[field]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	txType := entity.TransactionType(strings.ToUpper(value(ImportFieldType)))
+
+	var amount int64
+	var err error
+	if raw := value(ImportFieldAmountCents); raw != "" {
+		amount, err = strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return nil, &ImportRowError{Column: ImportFieldAmountCents, Message: "invalid integer amount"}
+		}
+	} else {
+		amount, err = ParseAmountCents(value(ImportFieldAmount))
+		if err != nil {
+			return nil, &ImportRowError{Column: ImportFieldAmount, Message: err.Error()}
+		}
+	}
+
+	currency := strings.ToUpper(value(ImportFieldCurrency))
+	if currency == "" {
+		currency = opts.DefaultCurrency
+	}
+	if len(currency) != 3 {
+		return nil, &ImportRowError{Column: ImportFieldCurrency, Message: "currency must be an ISO 4217 code"}
+	}
+
+	tx := entity.NewTransaction(opts.ProjectID, txType, amount, currency, opts.CreatedBy)
+	tx.Description = value(ImportFieldDescription)
+	tx.ReferenceNo = value(ImportFieldReferenceNo)
+
+	if raw := value(ImportFieldEffectiveDate); raw != "" {
+		date, err := ParseImportDate(raw)
+		if err != nil {
+			return nil, &ImportRowError{Column: ImportFieldEffectiveDate, Message: err.Error()}
+		}
+		tx.EffectiveDate = date
+	}
+
+	if raw := value(ImportFieldContractID); raw != "" {
+		contractID, err := uuid.Parse(raw)
+		if err != nil {
+			return nil, &ImportRowError{Column: ImportFieldContractID, Message: "invalid contract ID"}
+		}
+		tx.ContractID = &contractID
+	}
+
+	meta := entity.TransactionMetadata{
+		BankReceiptNo:     value(ImportFieldBankReceiptNo),
+		InvoiceNo:         value(ImportFieldInvoiceNo),
+		ApplicationPeriod: value(ImportFieldApplicationPeriod),
+		Notes:             value(ImportFieldNotes),
+		VendorName:        value(ImportFieldVendorName),
+		RetainageRate:     value(ImportFieldRetainageRate),
+	}
+	if tx.ReferenceNo == "" {
+		// Keep reference_no consistent with RecordInvoice / RecordPayment
+		if meta.InvoiceNo != "" {
+			tx.ReferenceNo = meta.InvoiceNo
+		} else {
+			tx.ReferenceNo = meta.BankReceiptNo
+		}
+	}
+	if meta != (entity.TransactionMetadata{}) {
+		if err := tx.SetMetadata(meta); err != nil {
+			return nil, &ImportRowError{Message: err.Error()}
+		}
+	}
+
+	if err := tx.Validate(); err != nil {
+		column := ""
+		switch err {
+		case entity.ErrInvalidAmount:
+			column = ImportFieldAmount
+		case entity.ErrInvalidTransactionType:
+			column = ImportFieldType
+		}
+		return nil, &ImportRowError{Column: column, Message: err.Error()}
+	}
+
+	return tx, nil
+}
+
+// ParseAmountCents parses a major-unit amount into cents without floating point
+// Both "1,234.56" and Turkish "1.234,56" are accepted; the last separator is the decimal mark
+// unless it repeats ("1.234.567") or is alone before exactly three digits ("1,234")
+func ParseAmountCents(raw string) (int64, error) {
+	s := strings.TrimSpace(raw)
+	for _, symbol := range []string{"₺", "$", "€", "TRY", "TL", "USD", "EUR", " "} {
+		s = strings.ReplaceAll(s, symbol, "")
+	}
+	if s == "" {
+		return 0, fmt.Errorf("amount is required")
+	}
+
+	negative := strings.HasPrefix(s, "-")
+	s = strings.TrimPrefix(s, "-")
+
+	decimalMark := strings.LastIndexAny(s, ".,")
+	intPart, fracPart := s, ""
+	if decimalMark >= 0 {
+		mark := string(s[decimalMark])
+		switch {
+		case strings.Count(s, mark) > 1:
+			// A decimal mark appears once, so a repeated separator groups thousands
+		case len(s)-decimalMark-1 == 3 && !strings.ContainsAny(s[:decimalMark], ".,"):
+			// A lone separator followed by exactly three digits is a thousands separator
+		default:
+			intPart, fracPart = s[:decimalMark], s[decimalMark+1:]
+		}
+	}
+	if !validDigitGroups(intPart) {
+		return 0, fmt.Errorf("invalid amount %q: misplaced thousands separator", raw)
+	}
+	intPart = strings.NewReplacer(".", "", ",", "").Replace(intPart)
+
+	if len(fracPart) > 2 {
+		return 0, fmt.Errorf("invalid amount %q: more than two decimal places", raw)
+	}
+	for len(fracPart) < 2 {
+		fracPart += "0"
+	}
+
+	major, err := strconv.ParseInt(intPart, 10, 64)
+	if err != nil || intPart == "" {
+		return 0, fmt.Errorf("invalid amount %q", raw)
+	}
+	minor, err := strconv.ParseInt(fracPart, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid amount %q", raw)
+	}
+	if major > (math.MaxInt64-minor)/100 {
+		return 0, fmt.Errorf("invalid amount %q: out of range", raw)
+	}
+
+	cents := major*100 + minor
+	if negative {
+		cents = -cents
+	}
+	return cents, nil
+}
+
+// validDigitGroups reports whether the thousands separators of an integer part,
+// if any, are all the same and split it into groups of three digits
+func validDigitGroups(intPart string) bool {
+	sep := strings.IndexAny(intPart, ".,")
+	if sep < 0 {
+		return true
+	}
+	if strings.ContainsAny(intPart, strings.Trim(".,", string(intPart[sep]))) {
+		return false // "." and "," mixed before the decimal mark
+	}
+	groups := strings.Split(intPart, string(intPart[sep]))
+	if len(groups[0]) < 1 || len(groups[0]) > 3 {
+		return false
+	}
+	for _, group := range groups[1:] {
+		if len(group) != 3 {
+			return false
+		}
+	}
+	return true
+}
+
+// importDateLayouts lists the accepted date formats, ISO first
+var importDateLayouts = []string{
+	"2006-01-02",
+	time.RFC3339,
+	"2006-01-02 15:04:05",
+	"02.01.2006",
+	"02/01/2006",
+	"2.1.2006",
+}
+
+// ParseImportDate parses ISO, Turkish (dd.mm.yyyy) dates or Excel serial numbers
+func ParseImportDate(raw string) (time.Time, error) {
+	for _, layout := range importDateLayouts {
+		if t, err := time.Parse(layout, raw); err == nil {
+			return t, nil
+		}
+	}
+
+	// XLSX date cells arrive as serial day numbers (1900 date system)
+	if serial, err := strconv.ParseFloat(raw, 64); err == nil && serial > 0 && serial < 2958466 {
+		epoch := time.Date(1899, 12, 30, 0, 0, 0, 0, time.UTC)
+		return epoch.AddDate(0, 0, int(serial)), nil
+	}
+
+	return time.Time{}, fmt.Errorf("invalid date %q, expected YYYY-MM-DD or DD.MM.YYYY", raw)
+}
+
+func normalizeHeader(s string) string {
+	return strings.ToLower(strings.TrimSpace(s))
+}
+
+func isBlankRecord(record []string) bool {
+	for _, v := range record {
+		if strings.TrimSpace(v) != "" {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,333 @@
+// Copyright (c) 2026 Muhammet Ali Büyük. All rights reserved.
+// This source code is proprietary. Confidential and private.
+// Unauthorized copying or distribution is strictly prohibited.
+// Contact: iletisim@alibuyuk.net | Website: alibuyuk.net
+
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/qantesm/subflow/internal/core/entity"
+)
+
+// stubTransactionRepository is a minimal in-package TransactionRepository for service tests
+type stubTransactionRepository struct {
+	transactions []*entity.Transaction
+	batches      int
+	events       []*entity.Event // Outbox
+}
+
+func (r *stubTransactionRepository) Save(ctx context.Context, tx *entity.Transaction, events ...*entity.Event) error {
+	r.transactions = append(r.transactions, tx)
+	r.events = append(r.events, events...)
+	return nil
+}
+
+func (r *stubTransactionRepository) SaveBatch(ctx context.Context, txs []*entity.Transaction, events ...*entity.Event) error {
+	r.batches++
+	r.transactions = append(r.transactions, txs...)
+	r.events = append(r.events, events...)
+	return nil
+}
+
+func (r *stubTransactionRepository) FindByID(ctx context.Context, id uuid.UUID) (*entity.Transaction, error) {
+	for _, tx := range r.transactions {
+		if tx.ID == id {
+			return tx, nil
+		}
+	}
+	return nil, entity.ErrTransactionNotFound
+}
+
+func (r *stubTransactionRepository) FindByProjectID(ctx context.Context, projectID uuid.UUID) ([]*entity.Transaction, error) {
+	var result []*entity.Transaction
+	for _, tx := range r.transactions {
+		if tx.ProjectID == projectID {
+			result = append(result, tx)
+		}
+	}
+	return result, nil
+}
+
+func (r *stubTransactionRepository) GetProjectSummaries(ctx context.Context, projectID uuid.UUID) ([]*LedgerSummary, error) {
+	txs, _ := r.FindByProjectID(ctx, projectID)
+	return SummarizeTransactions(projectID, txs), nil
+}
+
+func TestParseAmountCents(t *testing.T) {
+	tests := []struct {
+		raw     string
+		want    int64
+		wantErr bool
+	}{
+		{"1234.56", 123456, false},
+		{"1,234.56", 123456, false},
+		{"1.234,56", 123456, false},
+		{"₺1.234.567,89", 123456789, false},
+		{"1,234", 123400, false},
+		{"1.5", 150, false},
+		{"100", 10000, false},
+		{"-25,00", -2500, false},
+		{"1.234.567", 123456700, false},
+		{"1,234,567", 123456700, false},
+		{"1,234,567.89", 123456789, false},
+		{"-1.000.000", -100000000, false},
+		{"12.345,678", 0, true},
+		{"1.23.456", 0, true},
+		{"1,234.567.890", 0, true},
+		{"1.234,567,890", 0, true},
+		{"abc", 0, true},
+		{"", 0, true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseAmountCents(tt.raw)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseAmountCents(%q) error = %v, wantErr %v", tt.raw, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseAmountCents(%q) = %d, want %d", tt.raw, got, tt.want)
+		}
+	}
+}
+
+func TestParseImportDate(t *testing.T) {
+	want := time.Date(2026, 3, 15, 0, 0, 0, 0, time.UTC)
+
+	for _, raw := range []string{"2026-03-15", "15.03.2026", "15/03/2026", "46096"} {
+		got, err := ParseImportDate(raw)
+		if err != nil {
+			t.Errorf("ParseImportDate(%q) returned error: %v", raw, err)
+			continue
+		}
+		if !got.Equal(want) {
+			t.Errorf("ParseImportDate(%q) = %v, want %v", raw, got, want)
+		}
+	}
+}
+
+func TestImportService_DryRun(t *testing.T) {
+	projectID := uuid.New()
+	repo := &stubTransactionRepository{}
+	repo.Save(context.Background(), entity.NewTransaction(projectID, entity.TransactionTypeInvoice, 100000, "TRY", uuid.New()))
+	repo.Save(context.Background(), entity.NewTransaction(projectID, entity.TransactionTypeInvoice, 900000, "USD", uuid.New()))
+
+	records := [][]string{
+		{"Tarih", "Type", "Tutar", "Invoice No", "Vendor Name"},
+		{"15.01.2026", "invoice", "2.500,00", "FTR-001", "Yapı A.Ş."},
+		{"20.01.2026", "PAYMENT", "1500", "", ""},
+		{"", "", "", "", ""},
+	}
+
+	importer := NewImportService(repo)
+	result, err := importer.Import(context.Background(), records, ImportOptions{
+		ProjectID: projectID,
+		CreatedBy: uuid.New(),
+		DryRun:    true,
+	})
+	if err != nil {
+		t.Fatalf("Import returned error: %v", err)
+	}
+
+	if result.TotalRows != 2 || result.ValidRows != 2 {
+		t.Errorf("TotalRows = %d, ValidRows = %d, want 2 and 2", result.TotalRows, result.ValidRows)
+	}
+	if result.Imported != 0 {
+		t.Errorf("Imported = %d, want 0 on dry run", result.Imported)
+	}
+	if len(repo.transactions) != 2 {
+		t.Errorf("dry run saved %d transactions, want none", len(repo.transactions)-2)
+	}
+
+	// Projected summary includes the existing invoice and the two new rows; the USD invoice stays apart
+	if len(result.Summaries) != 2 || result.Summaries[0].Currency != "TRY" || result.Summaries[1].Currency != "USD" {
+		t.Fatalf("Summaries = %+v, want TRY and USD", result.Summaries)
+	}
+	try := result.Summaries[0]
+	if try.TotalInvoiced != 350000 {
+		t.Errorf("TRY TotalInvoiced = %d, want 350000", try.TotalInvoiced)
+	}
+	if try.TotalPaid != 150000 || try.CurrentBalance != 200000 {
+		t.Errorf("TRY TotalPaid = %d, CurrentBalance = %d, want 150000 and 200000", try.TotalPaid, try.CurrentBalance)
+	}
+	if usd := result.Summaries[1]; usd.TotalInvoiced != 900000 || usd.TransactionCount != 1 {
+		t.Errorf("USD summary = %+v", usd)
+	}
+}
+
+func TestImportService_InvalidRowsSaveNothing(t *testing.T) {
+	projectID := uuid.New()
+	repo := &stubTransactionRepository{}
+
+	records := [][]string{
+		{"type", "amount", "effective_date"},
+		{"INVOICE", "1000", "2026-01-01"},
+		{"REFUND", "500", "2026-01-02"},
+		{"PAYMENT", "0", "2026-01-03"},
+		{"PAYMENT", "10", "not-a-date"},
+	}
+
+	result, err := NewImportService(repo).Import(context.Background(), records, ImportOptions{
+		ProjectID: projectID,
+		CreatedBy: uuid.New(),
+	})
+	if !errors.Is(err, entity.ErrImportRowsInvalid) {
+		t.Fatalf("error = %v, want ErrImportRowsInvalid", err)
+	}
+
+	if len(result.Errors) != 3 {
+		t.Fatalf("len(Errors) = %d, want 3", len(result.Errors))
+	}
+	wantRows := []int{3, 4, 5}
+	wantColumns := []string{ImportFieldType, ImportFieldAmount, ImportFieldEffectiveDate}
+	for i, rowErr := range result.Errors {
+		if rowErr.Row != wantRows[i] || rowErr.Column != wantColumns[i] {
+			t.Errorf("Errors[%d] = row %d column %s, want row %d column %s", i, rowErr.Row, rowErr.Column, wantRows[i], wantColumns[i])
+		}
+	}
+
+	if repo.batches != 0 || len(repo.transactions) != 0 {
+		t.Error("no transactions should be saved when any row is invalid")
+	}
+}
+
+func TestImportService_CommitUsesSingleBatch(t *testing.T) {
+	projectID := uuid.New()
+	repo := &stubTransactionRepository{}
+
+	records := [][]string{
+		{"Kind", "Cents", "Receipt"},
+		{"PAYMENT", "12345", "DEK-9"},
+		{"DEDUCTION", "100", ""},
+	}
+	mapping := ImportMapping{"Kind": ImportFieldType, "Cents": ImportFieldAmountCents, "Receipt": ImportFieldBankReceiptNo}
+
+	result, err := NewImportService(repo).Import(context.Background(), records, ImportOptions{
+		ProjectID: projectID,
+		CreatedBy: uuid.New(),
+		Mapping:   mapping,
+	})
+	if err != nil {
+		t.Fatalf("Import returned error: %v", err)
+	}
+
+	if result.Imported != 2 || repo.batches != 1 {
+		t.Errorf("Imported = %d in %d batches, want 2 in 1", result.Imported, repo.batches)
+	}
+
+	payment := repo.transactions[0]
+	meta, err := payment.GetMetadata()
+	if err != nil {
+		t.Fatalf("GetMetadata returned error: %v", err)
+	}
+	if meta.BankReceiptNo != "DEK-9" || payment.ReferenceNo != "DEK-9" {
+		t.Errorf("bank receipt = %q, reference = %q, want DEK-9", meta.BankReceiptNo, payment.ReferenceNo)
+	}
+}
+
+func TestImportService_ProjectStatusAndAudit(t *testing.T) {
+	ctx := context.Background()
+	projects := &stubProjectRepository{}
+	project := entity.NewProject(uuid.New(), "Bornova Okul", "PRJ-2026-027")
+	project.Status = entity.ProjectStatusOnHold
+	_ = projects.Create(ctx, project)
+	audits := &stubAuditRepository{}
+	repo := &stubTransactionRepository{}
+
+	importer := NewImportService(repo)
+	importer.SetProjectRepository(projects)
+	importer.SetAuditor(NewAuditService(audits, projects))
+
+	records := [][]string{
+		{"type", "amount"},
+		{"PAYMENT", "1000"},
+		{"INVOICE", "2000"},
+	}
+	// An on-hold project only settles what was billed: the invoice row is refused
+	result, err := importer.Import(ctx, records, ImportOptions{ProjectID: project.ID, CreatedBy: uuid.New()})
+	if !errors.Is(err, entity.ErrImportRowsInvalid) {
+		t.Fatalf("error = %v, want ErrImportRowsInvalid", err)
+	}
+	if len(result.Errors) != 1 || result.Errors[0].Row != 3 || result.Errors[0].Column != ImportFieldType {
+		t.Errorf("Errors = %+v, want the invoice on row 3", result.Errors)
+	}
+	if len(repo.transactions) != 0 || len(audits.logs) != 0 {
+		t.Error("a refused import should save and audit nothing")
+	}
+
+	project.Status = entity.ProjectStatusActive
+	result, err = importer.Import(ctx, records, ImportOptions{ProjectID: project.ID, CreatedBy: uuid.New()})
+	if err != nil || result.Imported != 2 {
+		t.Fatalf("Import() = %+v, %v", result, err)
+	}
+	if len(audits.logs) != 1 || audits.logs[0].Action != entity.AuditActionTransactionImport || audits.logs[0].TenantID != project.TenantID {
+		t.Errorf("audit entries = %+v, want one import entry", audits.logs)
+	}
+
+	if _, err := importer.Import(ctx, records, ImportOptions{ProjectID: uuid.New()}); !errors.Is(err, entity.ErrProjectNotFound) {
+		t.Errorf("unknown project error = %v", err)
+	}
+}
+
+// stubPaymentGate blocks payments under the listed contracts and counts recorded ones
+type stubPaymentGate struct {
+	blocked  map[uuid.UUID]bool
+	recorded int
+}
+
+func (g *stubPaymentGate) CheckPayment(ctx context.Context, projectID, contractID uuid.UUID, amountCents int64, date time.Time) (*entity.ComplianceCheck, error) {
+	check := &entity.ComplianceCheck{ProjectID: projectID, ContractID: contractID, Amount: amountCents, Date: date, Compliant: !g.blocked[contractID]}
+	if !check.Compliant {
+		check.Issues = []entity.ComplianceIssue{{Type: entity.ComplianceInsurance, Reason: "expired"}}
+	}
+	return check, nil
+}
+
+func (g *stubPaymentGate) PaymentRecorded(ctx context.Context, check *entity.ComplianceCheck, payment *entity.Transaction) error {
+	g.recorded++
+	return nil
+}
+
+func TestImportService_PaymentGate(t *testing.T) {
+	blocked, cleared := uuid.New(), uuid.New()
+	gate := &stubPaymentGate{blocked: map[uuid.UUID]bool{blocked: true}}
+	importer := NewImportService(&stubTransactionRepository{})
+	importer.SetPaymentGate(gate)
+
+	result, err := importer.Import(context.Background(), [][]string{
+		{"type", "amount", "contract_id"},
+		{"PAYMENT", "1000", cleared.String()},
+		{"PAYMENT", "1000", blocked.String()},
+		{"INVOICE", "1000", blocked.String()},
+	}, ImportOptions{ProjectID: uuid.New()})
+	if !errors.Is(err, entity.ErrImportRowsInvalid) {
+		t.Fatalf("error = %v, want ErrImportRowsInvalid", err)
+	}
+	if len(result.Errors) != 1 || result.Errors[0].Row != 3 || result.Errors[0].Column != ImportFieldContractID {
+		t.Errorf("Errors = %+v, want the blocked payment on row 3", result.Errors)
+	}
+
+	if _, err := importer.Import(context.Background(), [][]string{
+		{"type", "amount", "contract_id"},
+		{"PAYMENT", "1000", cleared.String()},
+	}, ImportOptions{ProjectID: uuid.New()}); err != nil || gate.recorded != 1 {
+		t.Errorf("Import() error = %v, recorded = %d, want the payment reported to the gate", err, gate.recorded)
+	}
+}
+
+func TestImportService_MissingColumn(t *testing.T) {
+	_, err := NewImportService(&stubTransactionRepository{}).Import(context.Background(), [][]string{
+		{"type", "description"},
+		{"INVOICE", "no amount column"},
+	}, ImportOptions{ProjectID: uuid.New()})
+
+	if !errors.Is(err, entity.ErrImportMissingColumn) {
+		t.Errorf("error = %v, want ErrImportMissingColumn", err)
+	}
+}
@@ -7,37 +7,51 @@ package service
 
 import (
 	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/qantesm/subflow/internal/core/entity"
 )
 
-// LedgerSummary represents the financial state of a project
+// LedgerSummary represents the financial state of a project in one currency
+// Amounts in different currencies are never added up; a project with entries in
+// several currencies has one summary per currency.
 type LedgerSummary struct {
-	ProjectID        uuid.UUID `json:"project_id"`
-	TotalInvoiced    int64     `json:"total_invoiced"`    // Sum of all invoices
-	TotalPaid        int64     `json:"total_paid"`        // Sum of all payments
-	TotalRetained    int64     `json:"total_retained"`    // Current retainage held
-	CurrentBalance   int64     `json:"current_balance"`   // Invoiced - Paid
-	Currency         string    `json:"currency"`
-	TransactionCount int       `json:"transaction_count"`
+	ProjectID          uuid.UUID `json:"project_id"`
+	TotalInvoiced      int64     `json:"total_invoiced"`      // Sum of all invoices
+	TotalPaid          int64     `json:"total_paid"`          // Sum of all payments
+	TotalRetained      int64     `json:"total_retained"`      // Current retainage held
+	TotalDeducted      int64     `json:"total_deducted"`      // Kesintiler withheld from the subcontractor
+	AdvanceOutstanding int64     `json:"advance_outstanding"` // Avans paid but not yet recovered
+	CurrentBalance     int64     `json:"current_balance"`     // Sum of BalanceEffect over all transactions
+	Currency           string    `json:"currency"`
+	TransactionCount   int       `json:"transaction_count"`
 }
 
 // TransactionRepository is the port (interface) for transaction persistence
 // This follows the Hexagonal Architecture pattern - domain defines the interface,
 // infrastructure adapters implement it
+// Events passed to Save and SaveBatch are written to the outbox in the same transaction.
 type TransactionRepository interface {
-	Save(ctx context.Context, tx *entity.Transaction) error
+	Save(ctx context.Context, tx *entity.Transaction, events ...*entity.Event) error
+	SaveBatch(ctx context.Context, txs []*entity.Transaction, events ...*entity.Event) error // All-or-nothing
 	FindByID(ctx context.Context, id uuid.UUID) (*entity.Transaction, error)
 	FindByProjectID(ctx context.Context, projectID uuid.UUID) ([]*entity.Transaction, error)
-	GetProjectSummary(ctx context.Context, projectID uuid.UUID) (*LedgerSummary, error)
+	GetProjectSummaries(ctx context.Context, projectID uuid.UUID) ([]*LedgerSummary, error) // One per currency, by currency code
 }
 
 // LedgerService handles all financial ledger operations
 // Immutable append-only ledger - transactions are never modified or deleted
 type LedgerService struct {
-	repo      TransactionRepository
-	architect string
+	repo       TransactionRepository
+	gate       PaymentGate       // Optional: checks subcontractor compliance before contract payments
+	projects   ProjectRepository // Optional: refuses entries the project status forbids
+	audit      Auditor           // Optional: records every entry in the audit trail
+	transactor Transactor        // Optional: stores a payment with its compliance bookkeeping
+	architect  string
 }
 
 // NewLedgerService creates a new ledger service
@@ -48,6 +62,49 @@ func NewLedgerService(repo TransactionRepository) *LedgerService {
 	}
 }
 
+// SetPaymentGate makes payments under a contract pass the gate before they are recorded
+func (s *LedgerService) SetPaymentGate(gate PaymentGate) {
+	s.gate = gate
+}
+
+// SetProjectRepository makes every entry check that the project status allows it
+func (s *LedgerService) SetProjectRepository(projects ProjectRepository) {
+	s.projects = projects
+}
+
+// SetAuditor records every entry in the audit trail
+func (s *LedgerService) SetAuditor(audit Auditor) {
+	s.audit = audit
+}
+
+// SetTransactor stores a contract payment and marks its conditional waiver used in one unit of work
+func (s *LedgerService) SetTransactor(transactor Transactor) {
+	s.transactor = transactor
+}
+
+// save checks the project status and appends the entry with its transaction.created event
+// The project is locked so that, within a unit of work, the entry and a status change run one after the other.
+func (s *LedgerService) save(ctx context.Context, tx *entity.Transaction) error {
+	if s.projects != nil {
+		project, err := s.projects.Lock(ctx, tx.ProjectID)
+		if err != nil {
+			return err
+		}
+		if !project.AllowsTransaction(tx.Type) {
+			return fmt.Errorf("%w: %s project does not accept %s entries", entity.ErrProjectNotModifiable, project.Status, tx.Type)
+		}
+	}
+	event, err := entity.NewEvent(entity.EventTransactionCreated, tx.ProjectID, tx)
+	if err != nil {
+		return err
+	}
+	if err := s.repo.Save(ctx, tx, event); err != nil {
+		return err
+	}
+	recordAudit(ctx, s.audit, entity.AuditActionTransactionCreate, "transaction", tx.ID, tx.ProjectID, nil, tx)
+	return nil
+}
+
 // RecordInvoice creates an invoice transaction in the ledger
 func (s *LedgerService) RecordInvoice(ctx context.Context, projectID uuid.UUID, amountCents int64, currency, invoiceNo string, createdBy uuid.UUID) (*entity.Transaction, error) {
 	tx := entity.NewTransaction(projectID, entity.TransactionTypeInvoice, amountCents, currency, createdBy)
@@ -63,7 +120,7 @@ func (s *LedgerService) RecordInvoice(ctx context.Context, projectID uuid.UUID,
 		return nil, err
 	}
 
-	if err := s.repo.Save(ctx, tx); err != nil {
+	if err := s.save(ctx, tx); err != nil {
 		return nil, err
 	}
 
@@ -71,13 +128,25 @@ func (s *LedgerService) RecordInvoice(ctx context.Context, projectID uuid.UUID,
 }
 
 // RecordPayment creates a payment transaction in the ledger
-func (s *LedgerService) RecordPayment(ctx context.Context, projectID uuid.UUID, amountCents int64, currency, bankReceiptNo string, createdBy uuid.UUID) (*entity.Transaction, error) {
+// contractID is set for subcontractor payments, which must pass the payment gate
+func (s *LedgerService) RecordPayment(ctx context.Context, projectID uuid.UUID, contractID *uuid.UUID, amountCents int64, currency, bankReceiptNo string, createdBy uuid.UUID) (*entity.Transaction, error) {
+	return s.RecordPaymentWithMetadata(ctx, projectID, contractID, amountCents, currency, time.Now(), entity.TransactionMetadata{
+		BankReceiptNo: bankReceiptNo,
+	}, createdBy)
+}
+
+// RecordPaymentWithMetadata creates a payment with an explicit value date and metadata
+// Used when the payment originates from an external source such as a bank statement.
+// A non-compliant contract payment is refused unless meta.ComplianceOverride gives a reason.
+func (s *LedgerService) RecordPaymentWithMetadata(ctx context.Context, projectID uuid.UUID, contractID *uuid.UUID, amountCents int64, currency string, effectiveDate time.Time, meta entity.TransactionMetadata, createdBy uuid.UUID) (*entity.Transaction, error) {
 	tx := entity.NewTransaction(projectID, entity.TransactionTypePayment, amountCents, currency, createdBy)
-	tx.ReferenceNo = bankReceiptNo
+	tx.ContractID = contractID
+	tx.ReferenceNo = meta.BankReceiptNo
+	if !effectiveDate.IsZero() {
+		tx.EffectiveDate = effectiveDate
+	}
 
-	if err := tx.SetMetadata(entity.TransactionMetadata{
-		BankReceiptNo: bankReceiptNo,
-	}); err != nil {
+	if err := tx.SetMetadata(meta); err != nil {
 		return nil, err
 	}
 
@@ -85,23 +154,49 @@ func (s *LedgerService) RecordPayment(ctx context.Context, projectID uuid.UUID,
 		return nil, err
 	}
 
-	if err := s.repo.Save(ctx, tx); err != nil {
-		return nil, err
+	if s.gate == nil || contractID == nil {
+		if err := s.save(ctx, tx); err != nil {
+			return nil, err
+		}
+		return tx, nil
 	}
 
+	// The waiver the check picks is marked used with the payment, so a failure
+	// leaves neither behind and two payments cannot claim the same waiver
+	err := inTx(ctx, s.transactor, func(ctx context.Context) error {
+		check, err := s.gate.CheckPayment(ctx, projectID, *contractID, amountCents, tx.EffectiveDate)
+		if err != nil {
+			return err
+		}
+		if !check.Compliant && strings.TrimSpace(meta.ComplianceOverride) == "" {
+			reasons := make([]string, 0, len(check.Issues))
+			for _, issue := range check.Issues {
+				reasons = append(reasons, fmt.Sprintf("%s: %s", issue.Type, issue.Reason))
+			}
+			return fmt.Errorf("%w: %s", entity.ErrSubcontractorNonCompliant, strings.Join(reasons, "; "))
+		}
+		if err := s.save(ctx, tx); err != nil {
+			return err
+		}
+		return s.gate.PaymentRecorded(ctx, check, tx)
+	})
+	if err != nil {
+		return nil, err
+	}
 	return tx, nil
 }
 
 // RecordRetainageHeld records retainage being held from a payment
-func (s *LedgerService) RecordRetainageHeld(ctx context.Context, projectID uuid.UUID, amountCents int64, currency string, rate float64, createdBy uuid.UUID) (*entity.Transaction, error) {
+func (s *LedgerService) RecordRetainageHeld(ctx context.Context, projectID uuid.UUID, contractID *uuid.UUID, amountCents int64, currency string, rate float64, createdBy uuid.UUID) (*entity.Transaction, error) {
 	tx := entity.NewTransaction(projectID, entity.TransactionTypeRetainageHeld, amountCents, currency, createdBy)
+	tx.ContractID = contractID
 	tx.Description = "Retainage withheld"
 
 	if err := tx.Validate(); err != nil {
 		return nil, err
 	}
 
-	if err := s.repo.Save(ctx, tx); err != nil {
+	if err := s.save(ctx, tx); err != nil {
 		return nil, err
 	}
 
@@ -109,24 +204,161 @@ func (s *LedgerService) RecordRetainageHeld(ctx context.Context, projectID uuid.
 }
 
 // RecordRetainageRelease records retainage being released
-func (s *LedgerService) RecordRetainageRelease(ctx context.Context, projectID uuid.UUID, amountCents int64, currency string, createdBy uuid.UUID) (*entity.Transaction, error) {
+// The amount cannot exceed the retainage still held in its currency for the project, or for the contract when given.
+// The project row is locked while the balance is checked so concurrent releases cannot both pass
+func (s *LedgerService) RecordRetainageRelease(ctx context.Context, projectID uuid.UUID, contractID *uuid.UUID, amountCents int64, currency, referenceNo string, createdBy uuid.UUID) (*entity.Transaction, error) {
 	tx := entity.NewTransaction(projectID, entity.TransactionTypeRetainageRelease, amountCents, currency, createdBy)
+	tx.ContractID = contractID
+	tx.ReferenceNo = referenceNo
 	tx.Description = "Retainage released"
 
 	if err := tx.Validate(); err != nil {
 		return nil, err
 	}
 
-	if err := s.repo.Save(ctx, tx); err != nil {
+	err := inTx(ctx, s.transactor, func(ctx context.Context) error {
+		if s.projects != nil {
+			if _, err := s.projects.Lock(ctx, projectID); err != nil {
+				return err
+			}
+		}
+
+		held, released, err := s.RetainageBalanceIn(ctx, projectID, contractID, currency)
+		if err != nil {
+			return err
+		}
+		if amountCents > held-released {
+			return fmt.Errorf("%w: %s still held", entity.ErrRetainageExceedsTotal, FormatCurrency(held-released, currency))
+		}
+
+		return s.save(ctx, tx)
+	})
+	if err != nil {
 		return nil, err
 	}
 
 	return tx, nil
 }
 
-// GetProjectFinancials calculates the current financial state from the ledger
-func (s *LedgerService) GetProjectFinancials(ctx context.Context, projectID uuid.UUID) (*LedgerSummary, error) {
-	return s.repo.GetProjectSummary(ctx, projectID)
+// RetainageBalance returns the retainage held and released so far for a project,
+// or for a single contract when contractID is given
+func (s *LedgerService) RetainageBalance(ctx context.Context, projectID uuid.UUID, contractID *uuid.UUID) (held, released int64, err error) {
+	return s.RetainageBalanceIn(ctx, projectID, contractID, "")
+}
+
+// RetainageBalanceIn is RetainageBalance restricted to one currency.
+// It returns ErrCurrencyMismatch when retainage is held only in other currencies
+func (s *LedgerService) RetainageBalanceIn(ctx context.Context, projectID uuid.UUID, contractID *uuid.UUID, currency string) (held, released int64, err error) {
+	transactions, err := s.repo.FindByProjectID(ctx, projectID)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	heldElsewhere := false
+	for _, tx := range transactions {
+		if contractID != nil && (tx.ContractID == nil || *tx.ContractID != *contractID) {
+			continue
+		}
+		if currency != "" && tx.Currency != currency {
+			if tx.Type == entity.TransactionTypeRetainageHeld {
+				heldElsewhere = true
+			}
+			continue
+		}
+		switch tx.Type {
+		case entity.TransactionTypeRetainageHeld:
+			held += tx.AmountCents
+		case entity.TransactionTypeRetainageRelease:
+			released += tx.AmountCents
+		}
+	}
+	if held == 0 && heldElsewhere {
+		return 0, 0, entity.ErrCurrencyMismatch
+	}
+	return held, released, nil
+}
+
+// RecordAdvancePayment records an advance (avans) paid ahead of any work
+func (s *LedgerService) RecordAdvancePayment(ctx context.Context, projectID uuid.UUID, amountCents int64, currency, referenceNo string, effectiveDate time.Time, createdBy uuid.UUID) (*entity.Transaction, error) {
+	tx := entity.NewTransaction(projectID, entity.TransactionTypeAdvancePayment, amountCents, currency, createdBy)
+	tx.ReferenceNo = referenceNo
+	tx.Description = "Advance payment"
+	if !effectiveDate.IsZero() {
+		tx.EffectiveDate = effectiveDate
+	}
+
+	if err := tx.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := s.save(ctx, tx); err != nil {
+		return nil, err
+	}
+
+	return tx, nil
+}
+
+// RecordAdvanceRecovery records the part of the advance deducted from a certified pay application
+func (s *LedgerService) RecordAdvanceRecovery(ctx context.Context, projectID uuid.UUID, amountCents int64, currency, invoiceNo string, createdBy uuid.UUID) (*entity.Transaction, error) {
+	tx := entity.NewTransaction(projectID, entity.TransactionTypeAdvanceRecovery, amountCents, currency, createdBy)
+	tx.ReferenceNo = invoiceNo
+	tx.Description = "Advance recovered"
+
+	if err := tx.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := s.save(ctx, tx); err != nil {
+		return nil, err
+	}
+
+	return tx, nil
+}
+
+// RecordDeduction records a deduction (kesinti) withheld from the amount owed
+func (s *LedgerService) RecordDeduction(ctx context.Context, d *entity.Deduction) (*entity.Transaction, error) {
+	tx := entity.NewTransaction(d.ProjectID, entity.TransactionTypeDeduction, d.Amount, d.Currency, d.CreatedBy)
+	tx.ContractID = d.ContractID
+	tx.ReferenceNo = d.ID.String()
+	tx.Description = d.Reason
+	tx.EffectiveDate = d.Date
+
+	if err := tx.SetMetadata(entity.TransactionMetadata{
+		DeductionCategory: string(d.Category),
+	}); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := s.save(ctx, tx); err != nil {
+		return nil, err
+	}
+
+	return tx, nil
+}
+
+// GetProjectFinancials calculates the current financial state from the ledger, one summary per currency
+// A project without entries has no summaries.
+func (s *LedgerService) GetProjectFinancials(ctx context.Context, projectID uuid.UUID) ([]*LedgerSummary, error) {
+	return s.repo.GetProjectSummaries(ctx, projectID)
+}
+
+// GetProjectFinancialsIn returns the financial state of a project in one currency
+// The summary is empty when nothing was recorded in that currency.
+func (s *LedgerService) GetProjectFinancialsIn(ctx context.Context, projectID uuid.UUID, currency string) (*LedgerSummary, error) {
+	summaries, err := s.repo.GetProjectSummaries(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	for _, summary := range summaries {
+		if summary.Currency == currency {
+			return summary, nil
+		}
+	}
+	return SummarizeTotals(projectID, nil, 0, currency), nil
 }
 
 // GetTransactionHistory retrieves all transactions for a project
@@ -140,19 +372,75 @@ func (s *LedgerService) CalculateBalance(transactions []*entity.Transaction) int
 	var balance int64 = 0
 	
 	for _, tx := range transactions {
-		switch tx.Type {
-		case entity.TransactionTypeInvoice:
-			balance += tx.AmountCents // Money owed to us
-		case entity.TransactionTypePayment:
-			balance -= tx.AmountCents // Money received
-		case entity.TransactionTypeRetainageHeld:
-			// Retainage doesn't affect immediate balance
-		case entity.TransactionTypeRetainageRelease:
-			balance -= tx.AmountCents // Released retainage = payment
-		case entity.TransactionTypeDeduction:
-			balance -= tx.AmountCents // Deduction reduces amount owed
-		}
+		balance += BalanceEffect(tx)
 	}
 	
 	return balance
 }
+
+// SummarizeTransactions builds one ledger summary per currency from a transaction list, by currency code
+// Repositories without SQL aggregation and import dry runs share this logic
+func SummarizeTransactions(projectID uuid.UUID, transactions []*entity.Transaction) []*LedgerSummary {
+	totals := make(map[string]map[entity.TransactionType]int64)
+	counts := make(map[string]int)
+	for _, tx := range transactions {
+		if totals[tx.Currency] == nil {
+			totals[tx.Currency] = make(map[entity.TransactionType]int64)
+		}
+		totals[tx.Currency][tx.Type] += tx.AmountCents
+		counts[tx.Currency]++
+	}
+
+	currencies := make([]string, 0, len(totals))
+	for currency := range totals {
+		currencies = append(currencies, currency)
+	}
+	sort.Strings(currencies)
+
+	summaries := make([]*LedgerSummary, 0, len(currencies))
+	for _, currency := range currencies {
+		summaries = append(summaries, SummarizeTotals(projectID, totals[currency], counts[currency], currency))
+	}
+	return summaries
+}
+
+// SummarizeTotals builds a summary from the amount recorded per transaction type in one currency
+// Repositories that aggregate in the database use it so every summary shares BalanceEffect
+func SummarizeTotals(projectID uuid.UUID, totals map[entity.TransactionType]int64, count int, currency string) *LedgerSummary {
+	summary := &LedgerSummary{
+		ProjectID:          projectID,
+		TotalInvoiced:      totals[entity.TransactionTypeInvoice],
+		TotalPaid:          totals[entity.TransactionTypePayment],
+		TotalRetained:      totals[entity.TransactionTypeRetainageHeld] - totals[entity.TransactionTypeRetainageRelease],
+		TotalDeducted:      totals[entity.TransactionTypeDeduction],
+		AdvanceOutstanding: totals[entity.TransactionTypeAdvancePayment] - totals[entity.TransactionTypeAdvanceRecovery],
+		Currency:           currency,
+		TransactionCount:   count,
+	}
+	for txType, amount := range totals {
+		summary.CurrentBalance += BalanceEffect(&entity.Transaction{Type: txType, AmountCents: amount})
+	}
+	return summary
+}
+
+// BalanceEffect returns the signed effect of a single transaction on the project balance
+// Positive values increase the amount owed to us, negative values reduce it
+func BalanceEffect(tx *entity.Transaction) int64 {
+	switch tx.Type {
+	case entity.TransactionTypeInvoice:
+		return tx.AmountCents // Money owed to us
+	case entity.TransactionTypePayment:
+		return -tx.AmountCents // Money received
+	case entity.TransactionTypeRetainageHeld:
+		return 0 // Retainage doesn't affect immediate balance
+	case entity.TransactionTypeRetainageRelease:
+		return -tx.AmountCents // Released retainage = payment
+	case entity.TransactionTypeDeduction:
+		return -tx.AmountCents // Deduction reduces amount owed
+	case entity.TransactionTypeAdvancePayment:
+		return -tx.AmountCents // Received ahead of the work
+	case entity.TransactionTypeAdvanceRecovery:
+		return tx.AmountCents // Settled against invoiced work instead of cash
+	}
+	return 0
+}
@@ -0,0 +1,244 @@
+// Copyright (c) 2026 Muhammet Ali Büyük. All rights reserved.
+// This source code is proprietary. Confidential and private.
+// Unauthorized copying or distribution is strictly prohibited.
+// Contact: iletisim@alibuyuk.net | Website: alibuyuk.net
+
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/qantesm/subflow/internal/core/entity"
+)
+
+// Live message kinds pushed to dashboard clients
+const (
+	LiveTransaction = "transaction" // A new ledger entry
+	LiveSummary     = "summary"     // The project's ledger summaries, one per currency, after a change
+	LiveJob         = "job"         // Progress of a background job on the project
+)
+
+const (
+	DefaultLiveHistory = 256 // Messages kept per project for Last-Event-ID resume
+	liveSubscriberBuf  = 64  // Messages a slow client may fall behind before it is dropped
+)
+
+// LiveMessage is one server-sent event for the clients watching a project
+type LiveMessage struct {
+	ID        string          `json:"id"`
+	ProjectID uuid.UUID       `json:"project_id"`
+	Kind      string          `json:"kind"`
+	Data      json.RawMessage `json:"data,omitempty"` // Empty when too large for the broker; clients refetch
+	At        time.Time       `json:"at"`
+}
+
+// JobProgress reports how far a background job on a project has come
+type JobProgress struct {
+	JobID     string    `json:"job_id"`
+	ProjectID uuid.UUID `json:"project_id"`
+	Kind      string    `json:"kind"`  // e.g. "import"
+	Stage     string    `json:"stage"` // e.g. validating, saving, completed, failed
+	Done      int       `json:"done"`
+	Total     int       `json:"total"`
+	Message   string    `json:"message,omitempty"`
+}
+
+// ProgressReporter receives job progress; LiveHub implements it
+type ProgressReporter interface {
+	ReportProgress(ctx context.Context, progress JobProgress)
+}
+
+// LiveBroker carries live messages between API instances, e.g. over Postgres LISTEN/NOTIFY.
+// Every instance, the publishing one included, receives each message through Listen.
+type LiveBroker interface {
+	Publish(ctx context.Context, msg *LiveMessage) error
+	Listen(ctx context.Context, deliver func(*LiveMessage)) error // Blocks until ctx is done
+}
+
+// LiveSubscription is one client's feed of a project's messages.
+// C is closed when the client falls too far behind; it should reconnect
+// with the last ID it saw.
+type LiveSubscription struct {
+	C         <-chan *LiveMessage
+	ch        chan *LiveMessage
+	projectID uuid.UUID
+	hub       *LiveHub
+	once      sync.Once
+}
+
+// Close ends the subscription
+func (s *LiveSubscription) Close() {
+	s.hub.unsubscribe(s)
+}
+
+// LiveHub fans live messages out to the clients watching each project and
+// keeps a short history per project so reconnecting clients can resume.
+// Without a broker it works within one process; with one, messages go
+// through the broker and reach the clients of every instance.
+type LiveHub struct {
+	mu          sync.Mutex
+	broker      LiveBroker
+	historySize int
+	history     map[uuid.UUID][]*LiveMessage
+	subscribers map[uuid.UUID]map[*LiveSubscription]struct{}
+	architect   string
+}
+
+// NewLiveHub creates a hub keeping historySize messages per project
+func NewLiveHub(historySize int) *LiveHub {
+	if historySize <= 0 {
+		historySize = DefaultLiveHistory
+	}
+	return &LiveHub{
+		historySize: historySize,
+		history:     make(map[uuid.UUID][]*LiveMessage),
+		subscribers: make(map[uuid.UUID]map[*LiveSubscription]struct{}),
+		architect:   "Muhammet-Ali-Buyuk",
+	}
+}
+
+// SetBroker sends messages through the broker; Run must then be running to receive them
+func (h *LiveHub) SetBroker(broker LiveBroker) {
+	h.broker = broker
+}
+
+// Run delivers the broker's messages to local clients until ctx is done
+func (h *LiveHub) Run(ctx context.Context) error {
+	if h.broker == nil {
+		<-ctx.Done()
+		return nil
+	}
+	return h.broker.Listen(ctx, h.deliver)
+}
+
+// Publish sends a message to every client watching its project
+func (h *LiveHub) Publish(ctx context.Context, msg *LiveMessage) error {
+	if msg.ID == "" {
+		msg.ID = uuid.NewString()
+	}
+	if msg.At.IsZero() {
+		msg.At = time.Now()
+	}
+	if h.broker != nil {
+		return h.broker.Publish(ctx, msg)
+	}
+	h.deliver(msg)
+	return nil
+}
+
+// ReportProgress pushes job progress to the project's clients
+func (h *LiveHub) ReportProgress(ctx context.Context, progress JobProgress) {
+	data, err := json.Marshal(progress)
+	if err != nil {
+		return
+	}
+	_ = h.Publish(ctx, &LiveMessage{ProjectID: progress.ProjectID, Kind: LiveJob, Data: data})
+}
+
+// Subscribe starts a feed of a project's messages. With the ID of the last
+// message a client saw, the messages after it are returned as backlog; resync
+// is true when that ID has left the history and the client should reload.
+func (h *LiveHub) Subscribe(projectID uuid.UUID, lastEventID string) (sub *LiveSubscription, backlog []*LiveMessage, resync bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	ch := make(chan *LiveMessage, liveSubscriberBuf)
+	sub = &LiveSubscription{C: ch, ch: ch, projectID: projectID, hub: h}
+	if h.subscribers[projectID] == nil {
+		h.subscribers[projectID] = make(map[*LiveSubscription]struct{})
+	}
+	h.subscribers[projectID][sub] = struct{}{}
+
+	if lastEventID == "" {
+		return sub, nil, false
+	}
+	history := h.history[projectID]
+	for i, msg := range history {
+		if msg.ID == lastEventID {
+			return sub, append([]*LiveMessage(nil), history[i+1:]...), false
+		}
+	}
+	return sub, nil, true
+}
+
+func (h *LiveHub) unsubscribe(sub *LiveSubscription) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.removeLocked(sub)
+}
+
+// removeLocked drops a subscriber and closes its channel; h.mu must be held
+func (h *LiveHub) removeLocked(sub *LiveSubscription) {
+	sub.once.Do(func() {
+		delete(h.subscribers[sub.projectID], sub)
+		if len(h.subscribers[sub.projectID]) == 0 {
+			delete(h.subscribers, sub.projectID)
+		}
+		close(sub.ch)
+	})
+}
+
+// deliver records a message in the project's history and hands it to the
+// local clients. A message already in the history is ignored, so events the
+// outbox delivers twice reach clients once.
+func (h *LiveHub) deliver(msg *LiveMessage) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	history := h.history[msg.ProjectID]
+	for _, seen := range history {
+		if seen.ID == msg.ID {
+			return
+		}
+	}
+	history = append(history, msg)
+	if len(history) > h.historySize {
+		history = append([]*LiveMessage(nil), history[len(history)-h.historySize:]...)
+	}
+	h.history[msg.ProjectID] = history
+
+	for sub := range h.subscribers[msg.ProjectID] {
+		select {
+		case sub.ch <- msg:
+		default:
+			h.removeLocked(sub) // Too slow; it resumes from its last ID
+		}
+	}
+}
+
+// LedgerLiveFeed turns outbox events into live messages: each new ledger
+// entry is pushed, followed by the project's updated summary
+type LedgerLiveFeed struct {
+	hub    *LiveHub
+	ledger *LedgerService
+}
+
+// NewLedgerLiveFeed creates the feed; subscribe Handle to the event bus
+func NewLedgerLiveFeed(hub *LiveHub, ledger *LedgerService) *LedgerLiveFeed {
+	return &LedgerLiveFeed{hub: hub, ledger: ledger}
+}
+
+// Handle pushes a transaction.created event and the summary after it
+func (f *LedgerLiveFeed) Handle(ctx context.Context, event *entity.Event) error {
+	if event.Type != entity.EventTransactionCreated {
+		return nil
+	}
+	// The event ID keeps a redelivered event from reaching clients twice
+	if err := f.hub.Publish(ctx, &LiveMessage{ID: event.ID.String(), ProjectID: event.ProjectID, Kind: LiveTransaction, Data: event.Data, At: event.OccurredAt}); err != nil {
+		return err
+	}
+
+	summary, err := f.ledger.GetProjectFinancials(ctx, event.ProjectID)
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(summary)
+	if err != nil {
+		return err
+	}
+	return f.hub.Publish(ctx, &LiveMessage{ProjectID: event.ProjectID, Kind: LiveSummary, Data: data})
+}
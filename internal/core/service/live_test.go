@@ -0,0 +1,166 @@
+// Copyright (c) 2026 Muhammet Ali Büyük. All rights reserved.
+// This source code is proprietary. Confidential and private.
+// Unauthorized copying or distribution is strictly prohibited.
+// Contact: iletisim@alibuyuk.net | Website: alibuyuk.net
+
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/qantesm/subflow/internal/core/entity"
+)
+
+// stubProgressReporter records job progress for service tests
+type stubProgressReporter struct {
+	reports []JobProgress
+}
+
+func (r *stubProgressReporter) ReportProgress(ctx context.Context, progress JobProgress) {
+	r.reports = append(r.reports, progress)
+}
+
+func TestLiveHub(t *testing.T) {
+	ctx := context.Background()
+	hub := NewLiveHub(2)
+	projectID, otherID := uuid.New(), uuid.New()
+
+	sub, backlog, resync := hub.Subscribe(projectID, "")
+	defer sub.Close()
+	if len(backlog) != 0 || resync {
+		t.Fatalf("new subscription got backlog %d, resync %v", len(backlog), resync)
+	}
+
+	first := &LiveMessage{ID: "1", ProjectID: projectID, Kind: LiveTransaction}
+	hub.Publish(ctx, first)
+	hub.Publish(ctx, &LiveMessage{ID: "1", ProjectID: projectID, Kind: LiveTransaction}) // Redelivered
+	hub.Publish(ctx, &LiveMessage{ProjectID: otherID, Kind: LiveSummary})
+	hub.Publish(ctx, &LiveMessage{ID: "2", ProjectID: projectID, Kind: LiveSummary})
+
+	if got := (<-sub.C).ID; got != "1" {
+		t.Errorf("first message = %q, want 1", got)
+	}
+	if got := (<-sub.C).ID; got != "2" {
+		t.Errorf("second message = %q, want 2; duplicates and other projects are not delivered", got)
+	}
+	if first.At.IsZero() {
+		t.Error("Publish did not stamp the message time")
+	}
+
+	// Resume after the first message
+	resumed, backlog, resync := hub.Subscribe(projectID, "1")
+	resumed.Close()
+	if resync || len(backlog) != 1 || backlog[0].ID != "2" {
+		t.Errorf("resume from 1: backlog %d, resync %v, want message 2", len(backlog), resync)
+	}
+
+	// The first message leaves the two-message history
+	hub.Publish(ctx, &LiveMessage{ID: "3", ProjectID: projectID, Kind: LiveSummary})
+	<-sub.C
+	stale, backlog, resync := hub.Subscribe(projectID, "1")
+	stale.Close()
+	if !resync || len(backlog) != 0 {
+		t.Errorf("resume from evicted ID: backlog %d, resync %v, want resync", len(backlog), resync)
+	}
+}
+
+func TestLiveHub_SlowSubscriber(t *testing.T) {
+	ctx := context.Background()
+	hub := NewLiveHub(0)
+	projectID := uuid.New()
+
+	sub, _, _ := hub.Subscribe(projectID, "")
+	for i := 0; i <= liveSubscriberBuf; i++ {
+		hub.Publish(ctx, &LiveMessage{ProjectID: projectID, Kind: LiveJob})
+	}
+
+	received := 0
+	for range sub.C {
+		received++
+	}
+	if received != liveSubscriberBuf {
+		t.Errorf("slow subscriber received %d messages before being dropped, want %d", received, liveSubscriberBuf)
+	}
+	sub.Close() // Closing a dropped subscription is harmless
+}
+
+func TestLedgerLiveFeed(t *testing.T) {
+	ctx := context.Background()
+	projectID := uuid.New()
+	repo := &stubTransactionRepository{}
+	tx := entity.NewTransaction(projectID, entity.TransactionTypeInvoice, 250000, "TRY", uuid.New())
+	repo.Save(ctx, tx)
+
+	hub := NewLiveHub(0)
+	feed := NewLedgerLiveFeed(hub, NewLedgerService(repo))
+	sub, _, _ := hub.Subscribe(projectID, "")
+	defer sub.Close()
+
+	event, err := entity.NewEvent(entity.EventTransactionCreated, projectID, tx)
+	if err != nil {
+		t.Fatalf("NewEvent returned error: %v", err)
+	}
+	for i := 0; i < 2; i++ { // The outbox may deliver an event twice
+		if err := feed.Handle(ctx, event); err != nil {
+			t.Fatalf("Handle returned error: %v", err)
+		}
+	}
+
+	msg := <-sub.C
+	if msg.Kind != LiveTransaction || msg.ID != event.ID.String() {
+		t.Errorf("first message = %s %s, want the transaction with the event ID", msg.Kind, msg.ID)
+	}
+	msg = <-sub.C
+	if msg.Kind != LiveSummary {
+		t.Fatalf("second message kind = %s, want summary", msg.Kind)
+	}
+	var summaries []LedgerSummary
+	if err := json.Unmarshal(msg.Data, &summaries); err != nil || len(summaries) != 1 {
+		t.Fatalf("summary data: %d summaries, %v", len(summaries), err)
+	}
+	if summary := summaries[0]; summary.ProjectID != projectID {
+		t.Errorf("summary project = %s, want %s", summary.ProjectID, projectID)
+	}
+
+	// Only the redelivery's summary follows; its transaction is deduplicated
+	if msg = <-sub.C; msg.Kind != LiveSummary {
+		t.Errorf("redelivery pushed %s, want only a summary", msg.Kind)
+	}
+	select {
+	case msg := <-sub.C:
+		t.Errorf("unexpected %s message", msg.Kind)
+	default:
+	}
+}
+
+func TestImportService_Progress(t *testing.T) {
+	projectID := uuid.New()
+	reporter := &stubProgressReporter{}
+	importer := NewImportService(&stubTransactionRepository{})
+	importer.SetProgressReporter(reporter)
+
+	records := [][]string{
+		{"Date", "Type", "Amount"},
+		{"15.01.2026", "invoice", "2.500,00"},
+		{"20.01.2026", "payment", "1500"},
+	}
+	if _, err := importer.Import(context.Background(), records, ImportOptions{ProjectID: projectID, CreatedBy: uuid.New()}); err != nil {
+		t.Fatalf("Import returned error: %v", err)
+	}
+
+	if len(reporter.reports) == 0 {
+		t.Fatal("no progress reported")
+	}
+	last := reporter.reports[len(reporter.reports)-1]
+	if last.Stage != "completed" || last.Done != 2 || last.Total != 2 || last.ProjectID != projectID {
+		t.Errorf("last report = %+v, want completed 2 of 2", last)
+	}
+	for _, r := range reporter.reports {
+		if r.JobID != last.JobID || r.Kind != "import" {
+			t.Errorf("report %+v does not belong to the import job", r)
+		}
+	}
+}
@@ -0,0 +1,113 @@
+// Copyright (c) 2026 Muhammet Ali Büyük. All rights reserved.
+// This source code is proprietary. Confidential and private.
+// Unauthorized copying or distribution is strictly prohibited.
+// Contact: iletisim@alibuyuk.net | Website: alibuyuk.net
+
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/qantesm/subflow/internal/core/entity"
+)
+
+// Notifier is the port other services use to raise alerts
+type Notifier interface {
+	Notify(ctx context.Context, n *entity.Notification) error
+}
+
+// NotificationRepository is the port for the notification inbox
+type NotificationRepository interface {
+	Save(ctx context.Context, n *entity.Notification) error // ErrNotificationExists when the ID is taken
+	MarkRead(ctx context.Context, id uuid.UUID, readAt time.Time) error
+	FindByProjectID(ctx context.Context, projectID uuid.UUID, unreadOnly bool) ([]*entity.Notification, error) // Newest first
+}
+
+// NotificationService stores notifications and forwards them to delivery channels
+// The stored inbox is the source of truth; a failing channel does not lose the notification.
+type NotificationService struct {
+	repo      NotificationRepository
+	channels  []Notifier
+	architect string
+}
+
+// NewNotificationService creates a notification service delivering to the given channels
+func NewNotificationService(repo NotificationRepository, channels ...Notifier) *NotificationService {
+	return &NotificationService{
+		repo:      repo,
+		channels:  channels,
+		architect: "Muhammet-Ali-Buyuk",
+	}
+}
+
+// Notify stores the notification and sends it on every channel
+func (s *NotificationService) Notify(ctx context.Context, n *entity.Notification) error {
+	if err := s.repo.Save(ctx, n); err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, channel := range s.channels {
+		if err := channel.Notify(ctx, n); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// ListByProject returns the notifications of a project, newest first
+func (s *NotificationService) ListByProject(ctx context.Context, projectID uuid.UUID, unreadOnly bool) ([]*entity.Notification, error) {
+	return s.repo.FindByProjectID(ctx, projectID, unreadOnly)
+}
+
+// MarkRead marks a notification as read
+func (s *NotificationService) MarkRead(ctx context.Context, id uuid.UUID) error {
+	return s.repo.MarkRead(ctx, id, time.Now())
+}
+
+// HandleEvent raises the notifications a domain event calls for; subscribe it to the event bus.
+// The notification takes the event's ID, so an event the outbox delivers twice notifies once.
+func (s *NotificationService) HandleEvent(ctx context.Context, event *entity.Event) error {
+	var app entity.PayApplication
+	switch event.Type {
+	case entity.EventPayApplicationSubmitted, entity.EventPayApplicationCertified:
+		if err := json.Unmarshal(event.Data, &app); err != nil {
+			return err
+		}
+	default:
+		return nil
+	}
+
+	var n *entity.Notification
+	if event.Type == entity.EventPayApplicationSubmitted {
+		n = entity.NewNotification(app.ProjectID, entity.NotificationPayAppSubmitted, entity.NotificationInfo,
+			fmt.Sprintf("Hakediş #%d onaya sunuldu", app.ApplicationNo),
+			fmt.Sprintf("Pay application #%d for the period ending %s requests %s and awaits certification.",
+				app.ApplicationNo, app.PeriodEnd.Format("2006-01-02"), FormatCurrency(app.CurrentPaymentDue, app.Currency)))
+	} else {
+		n = entity.NewNotification(app.ProjectID, entity.NotificationPayAppCertified, entity.NotificationInfo,
+			fmt.Sprintf("Hakediş #%d onaylandı", app.ApplicationNo),
+			fmt.Sprintf("Pay application #%d for the period ending %s was certified: %s due, %s net of deductions.",
+				app.ApplicationNo, app.PeriodEnd.Format("2006-01-02"), FormatCurrency(app.CurrentPaymentDue, app.Currency), FormatCurrency(app.NetPaymentDue, app.Currency)))
+	}
+	n.ID = event.ID
+	n.ReferenceID = &app.ID
+	n.CreatedAt = event.OccurredAt
+	n.Params = map[string]string{
+		"application_no": strconv.Itoa(app.ApplicationNo),
+		"period_end":     app.PeriodEnd.Format("2006-01-02"),
+		"amount":         FormatCurrency(app.CurrentPaymentDue, app.Currency),
+		"net_amount":     FormatCurrency(app.NetPaymentDue, app.Currency),
+	}
+
+	if err := s.Notify(ctx, n); err != nil && !errors.Is(err, entity.ErrNotificationExists) {
+		return err
+	}
+	return nil
+}
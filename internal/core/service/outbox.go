@@ -0,0 +1,131 @@
+// Copyright (c) 2026 Muhammet Ali Büyük. All rights reserved.
+// This source code is proprietary. Confidential and private.
+// Unauthorized copying or distribution is strictly prohibited.
+// Contact: iletisim@alibuyuk.net | Website: alibuyuk.net
+
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/qantesm/subflow/internal/core/entity"
+)
+
+const (
+	DefaultOutboxBackoff = 5 * time.Second // Doubles after every failed attempt
+	maxOutboxBackoff     = time.Hour       // Longest wait between two attempts
+	outboxLease          = time.Minute     // How long a claimed entry is held before another dispatcher may take it
+	outboxBatch          = 100             // Entries claimed per dispatch run
+)
+
+// OutboxRepository is the port for the transactional outbox.
+// Entries are written by the entity repositories in the same transaction as
+// the change they announce; the dispatcher only reads and settles them.
+type OutboxRepository interface {
+	// ClaimPending returns unpublished entries due at now, oldest first, after
+	// counting an attempt and moving their next attempt to now+lease so that
+	// concurrent dispatchers do not take them as well
+	ClaimPending(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*entity.OutboxEntry, error)
+	MarkPublished(ctx context.Context, id uuid.UUID, at time.Time) error
+	MarkFailed(ctx context.Context, id uuid.UUID, reason string, next time.Time) error
+}
+
+// OutboxDispatcher publishes outbox entries to its sinks: webhooks, the
+// in-process event bus, a Redis stream. An entry is published once every sink
+// has taken it; otherwise it is retried with backoff and every sink sees it
+// again, so delivery is at least once and sinks dedupe on the event ID.
+// Entries are never dropped.
+type OutboxDispatcher struct {
+	repo      OutboxRepository
+	sinks     []EventPublisher
+	backoff   time.Duration
+	architect string
+}
+
+// NewOutboxDispatcher creates a dispatcher publishing to the given sinks
+func NewOutboxDispatcher(repo OutboxRepository, sinks ...EventPublisher) *OutboxDispatcher {
+	return &OutboxDispatcher{
+		repo:      repo,
+		sinks:     sinks,
+		backoff:   DefaultOutboxBackoff,
+		architect: "Muhammet-Ali-Buyuk",
+	}
+}
+
+// SetBackoff changes the wait after the first failed attempt; zero or less keeps the default
+func (d *OutboxDispatcher) SetBackoff(backoff time.Duration) {
+	if backoff > 0 {
+		d.backoff = backoff
+	}
+}
+
+// Dispatch publishes the entries due at now and returns how many were published
+func (d *OutboxDispatcher) Dispatch(ctx context.Context, now time.Time) (int, error) {
+	entries, err := d.repo.ClaimPending(ctx, now, outboxLease, outboxBatch)
+	if err != nil {
+		return 0, err
+	}
+
+	published := 0
+	var errs []error
+	for _, entry := range entries {
+		if err := d.publish(ctx, &entry.Event); err != nil {
+			if err := d.repo.MarkFailed(ctx, entry.ID, err.Error(), now.Add(d.backoffAfter(entry.Attempts))); err != nil {
+				errs = append(errs, err)
+			}
+			continue
+		}
+		if err := d.repo.MarkPublished(ctx, entry.ID, now); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		published++
+	}
+	return published, errors.Join(errs...)
+}
+
+// publish hands the event to every sink, even after one has failed
+func (d *OutboxDispatcher) publish(ctx context.Context, event *entity.Event) error {
+	var errs []error
+	for i, sink := range d.sinks {
+		if err := sink.Publish(ctx, event); err != nil {
+			errs = append(errs, fmt.Errorf("sink %d: %w", i, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// backoffAfter is the wait before the next attempt once attempts have failed
+func (d *OutboxDispatcher) backoffAfter(attempts int) time.Duration {
+	wait := d.backoff
+	for i := 1; i < attempts && wait < maxOutboxBackoff; i++ {
+		wait *= 2
+	}
+	if wait > maxOutboxBackoff {
+		wait = maxOutboxBackoff
+	}
+	return wait
+}
+
+// OutboxDispatchJob publishes due outbox entries; run it with RunEvery
+type OutboxDispatchJob struct {
+	dispatcher *OutboxDispatcher
+}
+
+// NewOutboxDispatchJob creates the scheduled outbox dispatch job
+func NewOutboxDispatchJob(dispatcher *OutboxDispatcher) *OutboxDispatchJob {
+	return &OutboxDispatchJob{dispatcher: dispatcher}
+}
+
+func (j *OutboxDispatchJob) ID() string {
+	return "outbox-dispatch"
+}
+
+func (j *OutboxDispatchJob) Execute(ctx context.Context) error {
+	_, err := j.dispatcher.Dispatch(ctx, time.Now())
+	return err
+}
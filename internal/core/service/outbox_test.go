@@ -0,0 +1,158 @@
+// Copyright (c) 2026 Muhammet Ali Büyük. All rights reserved.
+// This source code is proprietary. Confidential and private.
+// Unauthorized copying or distribution is strictly prohibited.
+// Contact: iletisim@alibuyuk.net | Website: alibuyuk.net
+
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/qantesm/subflow/internal/core/entity"
+)
+
+type stubOutboxRepository struct {
+	entries []*entity.OutboxEntry
+}
+
+func (r *stubOutboxRepository) append(events ...*entity.Event) {
+	for _, event := range events {
+		r.entries = append(r.entries, entity.NewOutboxEntry(event))
+	}
+}
+
+func (r *stubOutboxRepository) ClaimPending(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*entity.OutboxEntry, error) {
+	var claimed []*entity.OutboxEntry
+	for _, e := range r.entries {
+		if e.PublishedAt == nil && !e.NextAttemptAt.After(now) && len(claimed) < limit {
+			e.Attempts++
+			e.NextAttemptAt = now.Add(lease)
+			c := *e
+			claimed = append(claimed, &c)
+		}
+	}
+	return claimed, nil
+}
+
+func (r *stubOutboxRepository) find(id uuid.UUID) *entity.OutboxEntry {
+	for _, e := range r.entries {
+		if e.ID == id {
+			return e
+		}
+	}
+	return nil
+}
+
+func (r *stubOutboxRepository) MarkPublished(ctx context.Context, id uuid.UUID, at time.Time) error {
+	r.find(id).PublishedAt = &at
+	return nil
+}
+
+func (r *stubOutboxRepository) MarkFailed(ctx context.Context, id uuid.UUID, reason string, next time.Time) error {
+	e := r.find(id)
+	e.LastError, e.NextAttemptAt = reason, next
+	return nil
+}
+
+// flakySink fails the first n events it is handed
+type flakySink struct {
+	failures int
+	received []uuid.UUID
+}
+
+func (s *flakySink) Publish(ctx context.Context, event *entity.Event) error {
+	s.received = append(s.received, event.ID)
+	if s.failures > 0 {
+		s.failures--
+		return errors.New("stream unavailable")
+	}
+	return nil
+}
+
+func TestOutboxDispatch(t *testing.T) {
+	ctx := context.Background()
+	transactions := &stubTransactionRepository{}
+	ledger := NewLedgerService(transactions)
+	projectID := uuid.New()
+	user := uuid.New()
+
+	if _, err := ledger.RecordInvoice(ctx, projectID, 150000, "TRY", "HKD-1", user); err != nil {
+		t.Fatalf("RecordInvoice() error = %v", err)
+	}
+	if _, err := ledger.RecordPayment(ctx, projectID, nil, 50000, "TRY", "DK-1", user); err != nil {
+		t.Fatalf("RecordPayment() error = %v", err)
+	}
+	if len(transactions.events) != 2 || transactions.events[0].Type != entity.EventTransactionCreated {
+		t.Fatalf("outbox events = %+v, want one per entry", transactions.events)
+	}
+
+	outbox := &stubOutboxRepository{}
+	outbox.append(transactions.events...)
+
+	bus := NewEventBus()
+	var seen []uuid.UUID
+	bus.Subscribe(func(ctx context.Context, event *entity.Event) error {
+		seen = append(seen, event.ID)
+		return nil
+	}, entity.EventTransactionCreated)
+	unsubscribe := bus.Subscribe(func(ctx context.Context, event *entity.Event) error {
+		t.Errorf("unsubscribed handler got %s", event.Type)
+		return nil
+	})
+	unsubscribe()
+	bus.Subscribe(func(ctx context.Context, event *entity.Event) error {
+		t.Errorf("retainage subscriber got %s", event.Type)
+		return nil
+	}, entity.EventRetainageReleased)
+
+	stream := &flakySink{failures: 1}
+	dispatcher := NewOutboxDispatcher(outbox, bus, stream)
+	dispatcher.SetBackoff(time.Second)
+	now := time.Now()
+
+	// The first event fails on the stream and waits for its backoff
+	n, err := dispatcher.Dispatch(ctx, now)
+	if err != nil || n != 1 {
+		t.Fatalf("Dispatch() = %d, %v, want 1 published", n, err)
+	}
+	first := outbox.entries[0]
+	if first.PublishedAt != nil || first.Attempts != 1 || first.LastError == "" || !first.NextAttemptAt.Equal(now.Add(time.Second)) {
+		t.Fatalf("failed entry = %+v", first)
+	}
+	if n, _ := dispatcher.Dispatch(ctx, now.Add(500*time.Millisecond)); n != 0 {
+		t.Errorf("Dispatch() before the backoff = %d, want 0", n)
+	}
+
+	// The retry hands the event to every sink again; subscribers dedupe on the ID
+	if n, err := dispatcher.Dispatch(ctx, now.Add(time.Second)); err != nil || n != 1 {
+		t.Fatalf("retry Dispatch() = %d, %v", n, err)
+	}
+	if first.PublishedAt == nil || first.Attempts != 2 {
+		t.Errorf("retried entry = %+v", first)
+	}
+	want := []uuid.UUID{transactions.events[0].ID, transactions.events[1].ID, transactions.events[0].ID}
+	if len(seen) != len(want) || len(stream.received) != len(want) {
+		t.Fatalf("bus saw %v, stream got %v, want %v", seen, stream.received, want)
+	}
+	for i := range want {
+		if seen[i] != want[i] || stream.received[i] != want[i] {
+			t.Errorf("delivery %d = %s/%s, want %s", i, seen[i], stream.received[i], want[i])
+		}
+	}
+	if n, _ := dispatcher.Dispatch(ctx, now.Add(time.Hour)); n != 0 {
+		t.Errorf("Dispatch() with nothing pending = %d", n)
+	}
+}
+
+func TestOutboxBackoff(t *testing.T) {
+	d := NewOutboxDispatcher(nil)
+	for attempts, want := range map[int]time.Duration{1: DefaultOutboxBackoff, 3: 4 * DefaultOutboxBackoff, 40: maxOutboxBackoff} {
+		if got := d.backoffAfter(attempts); got != want {
+			t.Errorf("backoffAfter(%d) = %v, want %v", attempts, got, want)
+		}
+	}
+}
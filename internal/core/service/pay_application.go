@@ -0,0 +1,310 @@
+// Copyright (c) 2026 Muhammet Ali Büyük. All rights reserved.
+// This source code is proprietary. Confidential and private.
+// Unauthorized copying or distribution is strictly prohibited.
+// Contact: iletisim@alibuyuk.net | Website: alibuyuk.net
+
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/qantesm/subflow/internal/core/entity"
+)
+
+// PayApplicationRepository is the port for pay application persistence
+type PayApplicationRepository interface {
+	Save(ctx context.Context, app *entity.PayApplication) error
+	Update(ctx context.Context, app *entity.PayApplication, events ...*entity.Event) error // Events go to the outbox atomically
+	FindByID(ctx context.Context, id uuid.UUID) (*entity.PayApplication, error)
+	FindByProjectID(ctx context.Context, projectID uuid.UUID) ([]*entity.PayApplication, error) // Ordered by application number
+}
+
+// PayApplicationRequest holds the period and billing input of a new application
+type PayApplicationRequest struct {
+	PeriodStart time.Time
+	PeriodEnd   time.Time
+	Currency    string
+	Billing     AIABillingInput
+	Tax         TaxOptions
+
+	// Optional fiyat farkı formula applied to the work completed this period
+	EscalationFormulaID *uuid.UUID
+}
+
+// PayApplicationService prepares, approves and invoices pay applications (hakediş)
+type PayApplicationService struct {
+	repo       PayApplicationRepository
+	calculator *Calculator
+	taxes      *TaxEngine
+	escalation *EscalationService
+	advances   *AdvanceService
+	deductions *DeductionService
+	ledger     *LedgerService
+	audit      Auditor           // Optional: records workflow steps in the audit trail
+	transactor Transactor        // Optional: makes every workflow step all-or-nothing
+	projects   ProjectRepository // Optional: locks the project while a step runs
+	architect  string
+}
+
+// NewPayApplicationService creates a new pay application service
+func NewPayApplicationService(repo PayApplicationRepository, calculator *Calculator, taxes *TaxEngine, escalation *EscalationService, advances *AdvanceService, deductions *DeductionService, ledger *LedgerService) *PayApplicationService {
+	return &PayApplicationService{
+		repo:       repo,
+		calculator: calculator,
+		taxes:      taxes,
+		escalation: escalation,
+		advances:   advances,
+		deductions: deductions,
+		ledger:     ledger,
+		architect:  "Muhammet-Ali-Buyuk",
+	}
+}
+
+// SetAuditor records every submission, certification and rejection in the audit trail
+func (s *PayApplicationService) SetAuditor(audit Auditor) {
+	s.audit = audit
+}
+
+// SetTransactor runs each workflow step, ledger entries included, in one unit of work
+func (s *PayApplicationService) SetTransactor(transactor Transactor) {
+	s.transactor = transactor
+}
+
+// SetProjectRepository locks the project while an application is numbered,
+// certified or rejected, so concurrent steps on a project run one after the other
+func (s *PayApplicationService) SetProjectRepository(projects ProjectRepository) {
+	s.projects = projects
+}
+
+// Create calculates the G702 figures, price escalation, advance recovery, deductions and taxes and stores a draft application
+// The application number continues the project's sequence; tax rates are those in force at period end.
+// Escalation to date is carried from earlier applications so total earned stays cumulative, and the
+// advance paid so far is recovered under the project's rule. Deductions dated up to the period end
+// that no other application has taken are withheld from this payment.
+// The application, its escalation and its deductions are stored in one unit of work.
+// Only projects that accept invoices, that is active ones, take new applications.
+func (s *PayApplicationService) Create(ctx context.Context, tenantID, projectID uuid.UUID, req PayApplicationRequest, createdBy uuid.UUID) (*entity.PayApplication, error) {
+	var app *entity.PayApplication
+	err := inTx(ctx, s.transactor, func(ctx context.Context) error {
+		if s.projects != nil {
+			project, err := s.projects.Lock(ctx, projectID)
+			if err != nil {
+				return err
+			}
+			// Certifying records the invoice, so a project that takes none cannot bill
+			if !project.AllowsTransaction(entity.TransactionTypeInvoice) {
+				return fmt.Errorf("%w: %s project does not accept pay applications", entity.ErrProjectNotModifiable, project.Status)
+			}
+		}
+		var err error
+		app, err = s.create(ctx, tenantID, projectID, req, createdBy)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return app, nil
+}
+
+func (s *PayApplicationService) create(ctx context.Context, tenantID, projectID uuid.UUID, req PayApplicationRequest, createdBy uuid.UUID) (*entity.PayApplication, error) {
+	existing, err := s.repo.FindByProjectID(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	var escalation *entity.EscalationResult
+	if req.EscalationFormulaID != nil {
+		escalation, err = s.escalation.Calculate(ctx, *req.EscalationFormulaID, req.PeriodEnd, req.Billing.CurrentWorkCompleted, createdBy)
+		if err != nil {
+			return nil, err
+		}
+		if escalation.ProjectID != projectID {
+			return nil, entity.ErrEscalationProjectMismatch
+		}
+	}
+
+	input := req.Billing
+	input.AdvancePaid, input.AdvanceRecovery, err = s.advances.BillingTerms(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	deductions, deducted, err := s.deductions.Pending(ctx, projectID, req.PeriodEnd)
+	if err != nil {
+		return nil, err
+	}
+	input.Deductions = deducted
+
+	var previousRecovery int64
+	var lastNo int
+	input.PriceEscalation = 0
+	for _, previous := range existing {
+		if previous.ApplicationNo > lastNo {
+			lastNo = previous.ApplicationNo
+		}
+		if previous.Status != entity.PayApplicationStatusRejected {
+			input.PriceEscalation += previous.PriceEscalation
+			previousRecovery += previous.AdvanceRecovery
+		}
+	}
+	if escalation != nil {
+		input.PriceEscalation += escalation.Amount
+	}
+
+	result, err := s.calculator.Calculate(input)
+	if err != nil {
+		return nil, err
+	}
+
+	tax, err := s.taxes.ComputeForBilling(ctx, tenantID, req.PeriodEnd, result, req.Tax)
+	if err != nil {
+		return nil, err
+	}
+
+	// The project lock keeps the number free; UNIQUE(project_id, application_no) backs it up
+	app := entity.NewPayApplication(projectID, lastNo+1, req.PeriodStart, req.PeriodEnd, req.Currency, createdBy)
+	app.ContractSum = result.ContractSum
+	app.PreviousWorkCompleted = input.PreviousWorkCompleted
+	app.CurrentWorkCompleted = input.CurrentWorkCompleted
+	app.StoredMaterials = input.StoredMaterials
+	app.TotalCompletedAndStored = result.TotalCompletedAndStored
+	app.TotalRetainage = result.TotalRetainage
+	app.TotalEarned = result.TotalEarned
+	app.PreviousCertificates = result.LessPreviousCerts
+	app.CurrentPaymentDue = result.CurrentPaymentDue
+	app.AdvanceRecovery = result.AdvanceRecovered - previousRecovery
+	app.Deductions = result.Deductions
+	app.NetPaymentDue = result.NetPaymentDue
+	app.Tax = tax
+	if escalation != nil {
+		app.PriceEscalation = escalation.Amount
+	}
+
+	if err := s.repo.Save(ctx, app); err != nil {
+		return nil, err
+	}
+	if escalation != nil {
+		if err := s.escalation.Record(ctx, escalation, app.ID); err != nil {
+			return nil, err
+		}
+	}
+	if err := s.deductions.Apply(ctx, deductions, app.ID); err != nil {
+		return nil, err
+	}
+	return app, nil
+}
+
+// Get returns a single pay application
+func (s *PayApplicationService) Get(ctx context.Context, id uuid.UUID) (*entity.PayApplication, error) {
+	return s.repo.FindByID(ctx, id)
+}
+
+// ListByProject returns all applications of a project in order
+func (s *PayApplicationService) ListByProject(ctx context.Context, projectID uuid.UUID) ([]*entity.PayApplication, error) {
+	return s.repo.FindByProjectID(ctx, projectID)
+}
+
+// Submit sends a draft application for approval
+func (s *PayApplicationService) Submit(ctx context.Context, id uuid.UUID) (*entity.PayApplication, error) {
+	app, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	before := *app
+	if err := app.Submit(); err != nil {
+		return nil, err
+	}
+	event, err := entity.NewEvent(entity.EventPayApplicationSubmitted, app.ProjectID, app)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.Update(ctx, app, event); err != nil {
+		return nil, err
+	}
+	recordAudit(ctx, s.audit, entity.AuditActionPayApplicationSubmit, "pay_application", app.ID, app.ProjectID, &before, app)
+	return app, nil
+}
+
+// Certify approves a submitted application and records its invoice in the ledger
+// The ledger entries and the certified application are stored in one unit of work.
+func (s *PayApplicationService) Certify(ctx context.Context, id, certifiedBy uuid.UUID) (*entity.PayApplication, error) {
+	var app, before *entity.PayApplication
+	err := inTx(ctx, s.transactor, func(ctx context.Context) error {
+		var err error
+		if app, err = s.lockApplication(ctx, id); err != nil {
+			return err
+		}
+		snapshot := *app
+		before = &snapshot
+		if err := app.Certify(certifiedBy); err != nil {
+			return err
+		}
+
+		// Nothing to invoice when previous certificates already cover the period
+		if app.CurrentPaymentDue > 0 {
+			tx, err := s.ledger.RecordInvoice(ctx, app.ProjectID, app.CurrentPaymentDue, app.Currency, app.InvoiceNo(), certifiedBy)
+			if err != nil {
+				return err
+			}
+			app.InvoiceTransactionID = &tx.ID
+		}
+		if app.AdvanceRecovery > 0 {
+			if _, err := s.ledger.RecordAdvanceRecovery(ctx, app.ProjectID, app.AdvanceRecovery, app.Currency, app.InvoiceNo(), certifiedBy); err != nil {
+				return err
+			}
+		}
+
+		event, err := entity.NewEvent(entity.EventPayApplicationCertified, app.ProjectID, app)
+		if err != nil {
+			return err
+		}
+		return s.repo.Update(ctx, app, event)
+	})
+	if err != nil {
+		return nil, err
+	}
+	recordAudit(ctx, s.audit, entity.AuditActionPayApplicationCertify, "pay_application", app.ID, app.ProjectID, before, app)
+	return app, nil
+}
+
+// Reject returns a submitted application to the contractor
+// Its deductions go back to the pending pool for the next application.
+func (s *PayApplicationService) Reject(ctx context.Context, id uuid.UUID, reason string) (*entity.PayApplication, error) {
+	var app, before *entity.PayApplication
+	err := inTx(ctx, s.transactor, func(ctx context.Context) error {
+		var err error
+		if app, err = s.lockApplication(ctx, id); err != nil {
+			return err
+		}
+		snapshot := *app
+		before = &snapshot
+		if err := app.Reject(reason); err != nil {
+			return err
+		}
+		if err := s.repo.Update(ctx, app); err != nil {
+			return err
+		}
+		return s.deductions.Release(ctx, app.ID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	recordAudit(ctx, s.audit, entity.AuditActionPayApplicationReject, "pay_application", app.ID, app.ProjectID, before, app)
+	return app, nil
+}
+
+// lockApplication loads an application once its project is locked, so that
+// its status cannot change before the unit of work ends
+func (s *PayApplicationService) lockApplication(ctx context.Context, id uuid.UUID) (*entity.PayApplication, error) {
+	app, err := s.repo.FindByID(ctx, id)
+	if err != nil || s.projects == nil {
+		return app, err
+	}
+	if _, err := s.projects.Lock(ctx, app.ProjectID); err != nil {
+		return nil, err
+	}
+	return s.repo.FindByID(ctx, id)
+}
@@ -0,0 +1,321 @@
+// Copyright (c) 2026 Muhammet Ali Büyük. All rights reserved.
+// This source code is proprietary. Confidential and private.
+// Unauthorized copying or distribution is strictly prohibited.
+// Contact: iletisim@alibuyuk.net | Website: alibuyuk.net
+
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/qantesm/subflow/internal/core/entity"
+)
+
+// ProjectRepository is the port for project persistence
+type ProjectRepository interface {
+	Create(ctx context.Context, project *entity.Project) error
+	FindByID(ctx context.Context, id uuid.UUID) (*entity.Project, error) // Deleted projects are not found
+	Lock(ctx context.Context, id uuid.UUID) (*entity.Project, error)     // FindByID locking the project for the unit of work
+	FindByTenant(ctx context.Context, tenantID uuid.UUID, limit, offset int) ([]*entity.Project, error)
+	Update(ctx context.Context, project *entity.Project) error // Does not change the status
+	SoftDelete(ctx context.Context, id uuid.UUID) error
+
+	// ChangeStatus moves the project from change.FromStatus to change.ToStatus and
+	// appends the history record atomically. It fails with ErrInvalidStatusTransition
+	// if the stored status is no longer change.FromStatus. The events are written
+	// to the outbox in the same transaction.
+	ChangeStatus(ctx context.Context, change *entity.ProjectStatusChange, events ...*entity.Event) error
+	FindStatusHistory(ctx context.Context, projectID uuid.UUID) ([]*entity.ProjectStatusChange, error) // Oldest first
+}
+
+// ProjectService manages projects of a tenant and runs the project status state machine
+type ProjectService struct {
+	repo       ProjectRepository
+	payApps    PayApplicationRepository
+	ledger     *LedgerService
+	audit      Auditor    // Optional: records status changes in the audit trail
+	transactor Transactor // Optional: checks the guards and changes the status in one unit of work
+	architect  string
+}
+
+// NewProjectService creates a new project service
+func NewProjectService(repo ProjectRepository, payApps PayApplicationRepository, ledger *LedgerService) *ProjectService {
+	return &ProjectService{
+		repo:      repo,
+		payApps:   payApps,
+		ledger:    ledger,
+		architect: "Muhammet-Ali-Buyuk",
+	}
+}
+
+// SetAuditor records every status change in the audit trail
+func (s *ProjectService) SetAuditor(audit Auditor) {
+	s.audit = audit
+}
+
+// SetTransactor checks the guards of a transition and changes the status in one
+// unit of work, with the project locked against concurrent ledger and pay application writes
+func (s *ProjectService) SetTransactor(transactor Transactor) {
+	s.transactor = transactor
+}
+
+// ProjectChanges holds the fields an update may set; nil fields are left as they are
+type ProjectChanges struct {
+	Name                  *string    `json:"name"`
+	Description           *string    `json:"description"`
+	ContractAmount        *int64     `json:"contract_amount"` // Cents
+	Currency              *string    `json:"currency"`
+	StartDate             *time.Time `json:"start_date"`
+	EstimatedEndDate      *time.Time `json:"estimated_end_date"`
+	LaborRetainageRate    *float64   `json:"labor_retainage_rate"`
+	MaterialRetainageRate *float64   `json:"material_retainage_rate"`
+}
+
+// touchesFinancials reports whether the changes alter the contract terms
+func (c ProjectChanges) touchesFinancials() bool {
+	return c.ContractAmount != nil || c.Currency != nil || c.LaborRetainageRate != nil || c.MaterialRetainageRate != nil
+}
+
+// apply copies the set fields onto the project
+func (c ProjectChanges) apply(p *entity.Project) {
+	if c.Name != nil {
+		p.Name = *c.Name
+	}
+	if c.Description != nil {
+		p.Description = *c.Description
+	}
+	if c.ContractAmount != nil {
+		p.ContractAmount = *c.ContractAmount
+	}
+	if c.Currency != nil {
+		p.Currency = strings.ToUpper(*c.Currency)
+	}
+	if c.StartDate != nil {
+		p.StartDate = *c.StartDate
+	}
+	if c.EstimatedEndDate != nil {
+		p.EstimatedEndDate = *c.EstimatedEndDate
+	}
+	if c.LaborRetainageRate != nil {
+		p.LaborRetainageRate = *c.LaborRetainageRate
+	}
+	if c.MaterialRetainageRate != nil {
+		p.MaterialRetainageRate = *c.MaterialRetainageRate
+	}
+}
+
+// Create validates and stores a new project
+func (s *ProjectService) Create(ctx context.Context, project *entity.Project) error {
+	if err := project.Validate(); err != nil {
+		return err
+	}
+	if err := s.repo.Create(ctx, project); err != nil {
+		return err
+	}
+	recordAudit(ctx, s.audit, entity.AuditActionProjectCreate, "project", project.ID, project.ID, nil, project)
+	return nil
+}
+
+// Get returns a project of the tenant
+// Another tenant's project is not found
+func (s *ProjectService) Get(ctx context.Context, tenantID, id uuid.UUID) (*entity.Project, error) {
+	project, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if project.TenantID != tenantID {
+		return nil, entity.ErrProjectNotFound
+	}
+	return project, nil
+}
+
+// List returns the projects of a tenant, newest first
+func (s *ProjectService) List(ctx context.Context, tenantID uuid.UUID, limit, offset int) ([]*entity.Project, error) {
+	return s.repo.FindByTenant(ctx, tenantID, limit, offset)
+}
+
+// Update applies changes to a project of the tenant
+// The contract terms can only change while the project is draft or active;
+// the status is changed through Transition.
+func (s *ProjectService) Update(ctx context.Context, tenantID, id uuid.UUID, changes ProjectChanges) (*entity.Project, error) {
+	var before, project *entity.Project
+	err := inTx(ctx, s.transactor, func(ctx context.Context) error {
+		var err error
+		before, err = s.lock(ctx, tenantID, id)
+		if err != nil {
+			return err
+		}
+		if changes.touchesFinancials() && !before.CanBeModified() {
+			return fmt.Errorf("%w: contract terms of a %s project are fixed", entity.ErrProjectNotModifiable, before.Status)
+		}
+
+		updated := *before
+		changes.apply(&updated)
+		if err := updated.Validate(); err != nil {
+			return err
+		}
+		if err := s.repo.Update(ctx, &updated); err != nil {
+			return err
+		}
+		project = &updated
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	recordAudit(ctx, s.audit, entity.AuditActionProjectUpdate, "project", id, id, before, project)
+	return project, nil
+}
+
+// Delete soft-deletes a project of the tenant
+// Only draft and cancelled projects can be deleted, so no open work disappears with them.
+func (s *ProjectService) Delete(ctx context.Context, tenantID, id uuid.UUID) error {
+	var before entity.Project
+	err := inTx(ctx, s.transactor, func(ctx context.Context) error {
+		project, err := s.lock(ctx, tenantID, id)
+		if err != nil {
+			return err
+		}
+		before = *project
+		if project.Status != entity.ProjectStatusDraft && project.Status != entity.ProjectStatusCancelled {
+			return fmt.Errorf("%w: a %s project cannot be deleted", entity.ErrProjectNotModifiable, project.Status)
+		}
+		return s.repo.SoftDelete(ctx, id)
+	})
+	if err != nil {
+		return err
+	}
+	recordAudit(ctx, s.audit, entity.AuditActionProjectDelete, "project", id, id, &before, nil)
+	return nil
+}
+
+// Financials returns the ledger summary of a project of the tenant, one per currency
+func (s *ProjectService) Financials(ctx context.Context, tenantID, id uuid.UUID) ([]*LedgerSummary, error) {
+	if _, err := s.Get(ctx, tenantID, id); err != nil {
+		return nil, err
+	}
+	return s.ledger.GetProjectFinancials(ctx, id)
+}
+
+// lock locks a project of the tenant for the unit of work
+func (s *ProjectService) lock(ctx context.Context, tenantID, id uuid.UUID) (*entity.Project, error) {
+	project, err := s.repo.Lock(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if project.TenantID != tenantID {
+		return nil, entity.ErrProjectNotFound
+	}
+	return project, nil
+}
+
+// Transition moves a project to a new status after checking the guards:
+// a project is activated only with valid data, completed only when no pay
+// application awaits a decision, and cancelled only with nothing left open
+// in the ledger in any currency. The project is locked while the guards are checked.
+func (s *ProjectService) Transition(ctx context.Context, projectID uuid.UUID, to entity.ProjectStatus, reason string, by uuid.UUID, now time.Time) (*entity.ProjectStatusChange, error) {
+	var (
+		before  entity.Project
+		project *entity.Project
+		change  *entity.ProjectStatusChange
+	)
+	err := inTx(ctx, s.transactor, func(ctx context.Context) error {
+		var err error
+		project, err = s.repo.Lock(ctx, projectID)
+		if err != nil {
+			return err
+		}
+		before = *project
+		change, err = s.transition(ctx, project, to, reason, by, now)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	recordAudit(ctx, s.audit, entity.AuditActionProjectTransition, "project", projectID, projectID, &before, project)
+	return change, nil
+}
+
+func (s *ProjectService) transition(ctx context.Context, project *entity.Project, to entity.ProjectStatus, reason string, by uuid.UUID, now time.Time) (*entity.ProjectStatusChange, error) {
+	projectID := project.ID
+	if !to.IsValid() || !project.CanTransitionTo(to) {
+		return nil, fmt.Errorf("%w: %s to %s", entity.ErrInvalidStatusTransition, project.Status, to)
+	}
+
+	switch to {
+	case entity.ProjectStatusActive:
+		if err := project.Validate(); err != nil {
+			return nil, err
+		}
+	case entity.ProjectStatusCompleted:
+		if err := s.checkPayApplications(ctx, projectID); err != nil {
+			return nil, err
+		}
+	case entity.ProjectStatusCancelled:
+		if err := s.checkBalance(ctx, project); err != nil {
+			return nil, err
+		}
+	}
+
+	change, err := project.TransitionTo(to, reason, by, now)
+	if err != nil {
+		return nil, err
+	}
+	event, err := entity.NewEvent(entity.EventProjectStatusChanged, projectID, change)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.ChangeStatus(ctx, change, event); err != nil {
+		return nil, err
+	}
+	return change, nil
+}
+
+// History returns the status changes of a project, oldest first
+func (s *ProjectService) History(ctx context.Context, projectID uuid.UUID) ([]*entity.ProjectStatusChange, error) {
+	if _, err := s.repo.FindByID(ctx, projectID); err != nil {
+		return nil, err
+	}
+	return s.repo.FindStatusHistory(ctx, projectID)
+}
+
+func (s *ProjectService) checkPayApplications(ctx context.Context, projectID uuid.UUID) error {
+	apps, err := s.payApps.FindByProjectID(ctx, projectID)
+	if err != nil {
+		return err
+	}
+	var open []string
+	for _, app := range apps {
+		if app.IsOpen() {
+			open = append(open, app.InvoiceNo())
+		}
+	}
+	if len(open) > 0 {
+		return fmt.Errorf("%w: %s", entity.ErrProjectHasOpenPayApplications, strings.Join(open, ", "))
+	}
+	return nil
+}
+
+// checkBalance refuses to close a project with anything open in any currency
+func (s *ProjectService) checkBalance(ctx context.Context, project *entity.Project) error {
+	summaries, err := s.ledger.GetProjectFinancials(ctx, project.ID)
+	if err != nil {
+		return err
+	}
+	for _, summary := range summaries {
+		if summary.CurrentBalance != 0 {
+			return fmt.Errorf("%w: %s outstanding", entity.ErrProjectHasOpenBalance, FormatCurrency(summary.CurrentBalance, summary.Currency))
+		}
+		if summary.TotalRetained != 0 {
+			return fmt.Errorf("%w: %s retainage held", entity.ErrProjectHasOpenBalance, FormatCurrency(summary.TotalRetained, summary.Currency))
+		}
+		if summary.AdvanceOutstanding != 0 {
+			return fmt.Errorf("%w: %s advance not recovered", entity.ErrProjectHasOpenBalance, FormatCurrency(summary.AdvanceOutstanding, summary.Currency))
+		}
+	}
+	return nil
+}
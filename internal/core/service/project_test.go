@@ -0,0 +1,201 @@
+// Copyright (c) 2026 Muhammet Ali Büyük. All rights reserved.
+// This source code is proprietary. Confidential and private.
+// Unauthorized copying or distribution is strictly prohibited.
+// Contact: iletisim@alibuyuk.net | Website: alibuyuk.net
+
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/qantesm/subflow/internal/core/entity"
+)
+
+func TestProjectStatusTransitions(t *testing.T) {
+	ctx := context.Background()
+	projects := &stubProjectRepository{}
+	payApps := &stubPayApplicationRepository{}
+	ledger := NewLedgerService(&stubTransactionRepository{})
+	ledger.SetProjectRepository(projects)
+	s := NewProjectService(projects, payApps, ledger)
+
+	project := entity.NewProject(uuid.New(), "Kartal Okul", "PRJ-2026-011")
+	_ = projects.Create(ctx, project)
+	user := uuid.New()
+
+	// Draft projects take no ledger entries
+	if _, err := ledger.RecordInvoice(ctx, project.ID, 100000, "TRY", "F-1", user); !errors.Is(err, entity.ErrProjectNotModifiable) {
+		t.Errorf("invoice on draft project error = %v", err)
+	}
+	if _, err := s.Transition(ctx, project.ID, entity.ProjectStatusCompleted, "", user, day(2026, 3, 1)); !errors.Is(err, entity.ErrInvalidStatusTransition) {
+		t.Errorf("draft to completed error = %v", err)
+	}
+	if _, err := s.Transition(ctx, project.ID, entity.ProjectStatusActive, "Sözleşme imzalandı", user, day(2026, 3, 1)); err != nil {
+		t.Fatalf("activate error = %v", err)
+	}
+	if _, err := ledger.RecordInvoice(ctx, project.ID, 100000, "TRY", "F-1", user); err != nil {
+		t.Fatalf("invoice on active project error = %v", err)
+	}
+
+	// On hold: no new billing, but what was billed can still be paid
+	if _, err := s.Transition(ctx, project.ID, entity.ProjectStatusOnHold, "Ruhsat bekleniyor", user, day(2026, 4, 1)); err != nil {
+		t.Fatalf("hold error = %v", err)
+	}
+	if _, err := ledger.RecordInvoice(ctx, project.ID, 50000, "TRY", "F-2", user); !errors.Is(err, entity.ErrProjectNotModifiable) {
+		t.Errorf("invoice on held project error = %v", err)
+	}
+
+	// Cannot cancel while the invoice is unpaid
+	if _, err := s.Transition(ctx, project.ID, entity.ProjectStatusCancelled, "", user, day(2026, 4, 2)); !errors.Is(err, entity.ErrProjectHasOpenBalance) {
+		t.Errorf("cancel with open balance error = %v", err)
+	}
+	if _, err := ledger.RecordPayment(ctx, project.ID, nil, 100000, "TRY", "DEK-1", user); err != nil {
+		t.Fatalf("payment on held project error = %v", err)
+	}
+
+	// Cannot complete while a pay application awaits a decision
+	if _, err := s.Transition(ctx, project.ID, entity.ProjectStatusActive, "", user, day(2026, 5, 1)); err != nil {
+		t.Fatalf("resume error = %v", err)
+	}
+	app := entity.NewPayApplication(project.ID, 3, day(2026, 4, 1), day(2026, 4, 30), "TRY", user)
+	_ = payApps.Save(ctx, app)
+	if _, err := s.Transition(ctx, project.ID, entity.ProjectStatusCompleted, "", user, day(2026, 5, 2)); !errors.Is(err, entity.ErrProjectHasOpenPayApplications) {
+		t.Errorf("complete with open pay application error = %v", err)
+	}
+	app.Status = entity.PayApplicationStatusRejected
+	if _, err := s.Transition(ctx, project.ID, entity.ProjectStatusCompleted, "Kesin kabul", user, day(2026, 5, 3)); err != nil {
+		t.Fatalf("complete error = %v", err)
+	}
+	if _, err := s.Transition(ctx, project.ID, entity.ProjectStatusActive, "", user, day(2026, 5, 4)); !errors.Is(err, entity.ErrInvalidStatusTransition) {
+		t.Errorf("reopen completed project error = %v", err)
+	}
+
+	history, _ := s.History(ctx, project.ID)
+	want := []entity.ProjectStatus{entity.ProjectStatusActive, entity.ProjectStatusOnHold, entity.ProjectStatusActive, entity.ProjectStatusCompleted}
+	if len(history) != len(want) {
+		t.Fatalf("history has %d entries, want %d", len(history), len(want))
+	}
+	for i, change := range history {
+		if change.ToStatus != want[i] {
+			t.Errorf("history[%d] = %s, want %s", i, change.ToStatus, want[i])
+		}
+	}
+	if history[0].FromStatus != entity.ProjectStatusDraft || history[0].Reason != "Sözleşme imzalandı" {
+		t.Errorf("first change = %+v", history[0])
+	}
+}
+
+func TestProjectCancellationChecksEveryCurrency(t *testing.T) {
+	ctx := context.Background()
+	projects := &stubProjectRepository{}
+	ledger := NewLedgerService(&stubTransactionRepository{})
+	ledger.SetProjectRepository(projects)
+	s := NewProjectService(projects, &stubPayApplicationRepository{}, ledger)
+	transactor := &stubTransactor{}
+	s.SetTransactor(transactor)
+
+	project := entity.NewProject(uuid.New(), "Tuzla Tersane", "PRJ-2026-037")
+	project.Status = entity.ProjectStatusActive
+	_ = projects.Create(ctx, project)
+	user := uuid.New()
+
+	// A USD invoice is not settled by the same figure paid in TRY
+	if _, err := ledger.RecordInvoice(ctx, project.ID, 100000, "USD", "F-1", user); err != nil {
+		t.Fatalf("RecordInvoice() error = %v", err)
+	}
+	if _, err := ledger.RecordPayment(ctx, project.ID, nil, 100000, "TRY", "DEK-1", user); err != nil {
+		t.Fatalf("RecordPayment() error = %v", err)
+	}
+	if _, err := s.Transition(ctx, project.ID, entity.ProjectStatusCancelled, "", user, day(2026, 6, 1)); !errors.Is(err, entity.ErrProjectHasOpenBalance) {
+		t.Fatalf("cancel with a USD balance error = %v", err)
+	}
+	if project.Status != entity.ProjectStatusActive {
+		t.Errorf("refused cancellation left status %s", project.Status)
+	}
+	if transactor.units != 1 {
+		t.Errorf("units of work = %d, want one per transition", transactor.units)
+	}
+}
+
+func TestProjectCrudIsScopedToTenant(t *testing.T) {
+	ctx := context.Background()
+	projects := &stubProjectRepository{}
+	txRepo := &stubTransactionRepository{}
+	ledger := NewLedgerService(txRepo)
+	ledger.SetProjectRepository(projects)
+	transactor := &stubTransactor{}
+	audit := &stubAuditRepository{}
+	s := NewProjectService(projects, &stubPayApplicationRepository{}, ledger)
+	s.SetTransactor(transactor)
+	s.SetAuditor(NewAuditService(audit, projects))
+
+	tenant, other := uuid.New(), uuid.New()
+	if err := s.Create(ctx, entity.NewProject(tenant, "", "PRJ-2026-012")); !errors.Is(err, entity.ErrProjectNameRequired) {
+		t.Errorf("Create() without name error = %v", err)
+	}
+	project := entity.NewProject(tenant, "Pendik Hastane", "PRJ-2026-012")
+	if err := s.Create(ctx, project); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	// Another tenant sees nothing of the project
+	if _, err := s.Get(ctx, other, project.ID); !errors.Is(err, entity.ErrProjectNotFound) {
+		t.Errorf("Get(other tenant) error = %v, want ErrProjectNotFound", err)
+	}
+	if listed, _ := s.List(ctx, other, 10, 0); len(listed) != 0 {
+		t.Errorf("List(other tenant) = %d projects", len(listed))
+	}
+	name := "Kaçırılmış"
+	if _, err := s.Update(ctx, other, project.ID, ProjectChanges{Name: &name}); !errors.Is(err, entity.ErrProjectNotFound) {
+		t.Errorf("Update(other tenant) error = %v", err)
+	}
+	if err := s.Delete(ctx, other, project.ID); !errors.Is(err, entity.ErrProjectNotFound) {
+		t.Errorf("Delete(other tenant) error = %v", err)
+	}
+	if _, err := s.Financials(ctx, other, project.ID); !errors.Is(err, entity.ErrProjectNotFound) {
+		t.Errorf("Financials(other tenant) error = %v", err)
+	}
+
+	amount := int64(500000000)
+	updated, err := s.Update(ctx, tenant, project.ID, ProjectChanges{ContractAmount: &amount})
+	if err != nil || updated.ContractAmount != amount || updated.Name != project.Name {
+		t.Fatalf("Update() = %+v, %v", updated, err)
+	}
+
+	user := uuid.New()
+	if _, err := s.Transition(ctx, project.ID, entity.ProjectStatusActive, "", user, day(2026, 6, 1)); err != nil {
+		t.Fatalf("activate error = %v", err)
+	}
+	if _, err := ledger.RecordInvoice(ctx, project.ID, 100000, "TRY", "F-1", user); err != nil {
+		t.Fatalf("RecordInvoice() error = %v", err)
+	}
+	summaries, err := s.Financials(ctx, tenant, project.ID)
+	if err != nil || len(summaries) != 1 || summaries[0].TotalInvoiced != 100000 {
+		t.Errorf("Financials() = %v, %v", summaries, err)
+	}
+
+	// Active projects keep their ledger, so they cannot be deleted
+	if err := s.Delete(ctx, tenant, project.ID); !errors.Is(err, entity.ErrProjectNotModifiable) {
+		t.Errorf("Delete(active) error = %v, want ErrProjectNotModifiable", err)
+	}
+	if _, err := s.Transition(ctx, project.ID, entity.ProjectStatusOnHold, "", user, day(2026, 6, 2)); err != nil {
+		t.Fatalf("hold error = %v", err)
+	}
+	if _, err := s.Update(ctx, tenant, project.ID, ProjectChanges{ContractAmount: &amount}); !errors.Is(err, entity.ErrProjectNotModifiable) {
+		t.Errorf("Update(held contract) error = %v, want ErrProjectNotModifiable", err)
+	}
+	description := "Ruhsat bekleniyor"
+	if _, err := s.Update(ctx, tenant, project.ID, ProjectChanges{Description: &description}); err != nil {
+		t.Errorf("Update(held description) error = %v", err)
+	}
+
+	if transactor.units != 8 {
+		t.Errorf("units of work = %d, want one per update, delete and transition", transactor.units)
+	}
+	if len(audit.logs) != 5 {
+		t.Errorf("audit entries = %d, want create, updates and transitions", len(audit.logs))
+	}
+}
@@ -0,0 +1,428 @@
+// Copyright (c) 2026 Muhammet Ali Büyük. All rights reserved.
+// This source code is proprietary. Confidential and private.
+// Unauthorized copying or distribution is strictly prohibited.
+// Contact: iletisim@alibuyuk.net | Website: alibuyuk.net
+
+package service
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/google/uuid"
+	"github.com/qantesm/subflow/internal/core/entity"
+)
+
+// BankStatementRepository is the port for imported bank statements
+type BankStatementRepository interface {
+	SaveStatement(ctx context.Context, stmt *entity.BankStatement) error // Statement and lines, all-or-nothing
+	FindStatementByID(ctx context.Context, id uuid.UUID) (*entity.BankStatement, error)
+	FindLineByID(ctx context.Context, id uuid.UUID) (*entity.StatementLine, error)
+	LockLine(ctx context.Context, id uuid.UUID) (*entity.StatementLine, error) // FindLineByID locking the line for the unit of work
+	UpdateLine(ctx context.Context, line *entity.StatementLine) error
+	FindMatchedTransactionIDs(ctx context.Context, projectID uuid.UUID) (map[uuid.UUID]bool, error)
+}
+
+// MatchRules tunes how statement lines are paired with ledger transactions
+type MatchRules struct {
+	DateWindowDays       int   `json:"date_window_days"`       // Max distance between value date and a recorded payment
+	InvoiceWindowDays    int   `json:"invoice_window_days"`    // Max age of an open invoice at value date
+	AmountToleranceCents int64 `json:"amount_tolerance_cents"` // Accepted difference for bank charges
+	MinScore             int   `json:"min_score"`              // Suggestions below this score are dropped
+}
+
+// DefaultMatchRules returns the rules used when the caller does not override them
+func DefaultMatchRules() MatchRules {
+	return MatchRules{
+		DateWindowDays:    7,
+		InvoiceWindowDays: 120,
+		MinScore:          50,
+	}
+}
+
+// MatchSuggestion proposes a ledger transaction for a statement line
+type MatchSuggestion struct {
+	LineID          uuid.UUID              `json:"line_id"`
+	LineNo          int                    `json:"line_no"`
+	TransactionID   uuid.UUID              `json:"transaction_id"`
+	TransactionType entity.TransactionType `json:"transaction_type"`
+	ReferenceNo     string                 `json:"reference_no"`
+	AmountCents     int64                  `json:"amount_cents"`
+	Score           int                    `json:"score"` // 0-100
+	Reasons         []string               `json:"reasons"`
+}
+
+// ReconciliationService imports bank statements and matches them against the ledger
+// Incoming credits are paired with recorded payments, or with open invoices
+// in which case accepting the match records the payment
+type ReconciliationService struct {
+	statements BankStatementRepository
+	txRepo     TransactionRepository
+	ledger     *LedgerService
+	transactor Transactor // Optional: makes accepting a match all-or-nothing
+	audit      Auditor    // Optional: records accepted matches in the audit trail
+	architect  string
+}
+
+// NewReconciliationService creates a new reconciliation service
+func NewReconciliationService(statements BankStatementRepository, txRepo TransactionRepository, ledger *LedgerService) *ReconciliationService {
+	return &ReconciliationService{
+		statements: statements,
+		txRepo:     txRepo,
+		ledger:     ledger,
+		architect:  "Muhammet-Ali-Buyuk",
+	}
+}
+
+// SetTransactor records the payment and marks the line matched in one unit of work
+func (s *ReconciliationService) SetTransactor(transactor Transactor) {
+	s.transactor = transactor
+}
+
+// SetAuditor records every accepted match in the audit trail
+func (s *ReconciliationService) SetAuditor(audit Auditor) {
+	s.audit = audit
+}
+
+// ImportStatement validates and stores a parsed statement
+func (s *ReconciliationService) ImportStatement(ctx context.Context, stmt *entity.BankStatement) error {
+	if err := stmt.Validate(); err != nil {
+		return err
+	}
+	return s.statements.SaveStatement(ctx, stmt)
+}
+
+// GetStatement returns a statement with its lines
+func (s *ReconciliationService) GetStatement(ctx context.Context, id uuid.UUID) (*entity.BankStatement, error) {
+	return s.statements.FindStatementByID(ctx, id)
+}
+
+// Suggest returns ranked match candidates for every open credit line of a statement
+func (s *ReconciliationService) Suggest(ctx context.Context, statementID uuid.UUID, rules MatchRules) ([]MatchSuggestion, error) {
+	stmt, err := s.statements.FindStatementByID(ctx, statementID)
+	if err != nil {
+		return nil, err
+	}
+
+	defaults := DefaultMatchRules()
+	if rules.DateWindowDays <= 0 {
+		rules.DateWindowDays = defaults.DateWindowDays
+	}
+	if rules.InvoiceWindowDays <= 0 {
+		rules.InvoiceWindowDays = defaults.InvoiceWindowDays
+	}
+	if rules.MinScore <= 0 {
+		rules.MinScore = defaults.MinScore
+	}
+
+	payments, invoices, err := s.candidates(ctx, stmt.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+
+	suggestions := make([]MatchSuggestion, 0)
+	for _, line := range stmt.Lines {
+		if !line.IsOpen() || !line.IsCredit() {
+			continue
+		}
+
+		var lineSuggestions []MatchSuggestion
+		for _, tx := range payments {
+			if sug, ok := scoreMatch(line, tx, rules.DateWindowDays, rules); ok {
+				lineSuggestions = append(lineSuggestions, sug)
+			}
+		}
+		for _, tx := range invoices {
+			if sug, ok := scoreMatch(line, tx, rules.InvoiceWindowDays, rules); ok {
+				lineSuggestions = append(lineSuggestions, sug)
+			}
+		}
+
+		sort.SliceStable(lineSuggestions, func(i, j int) bool {
+			return lineSuggestions[i].Score > lineSuggestions[j].Score
+		})
+		suggestions = append(suggestions, lineSuggestions...)
+	}
+
+	return suggestions, nil
+}
+
+// Accept reconciles a statement line with a ledger transaction
+// Matching an invoice records the corresponding payment first; matching a payment only links it.
+// The line is locked, and the payment and the match are stored together.
+// An invoice is only matched while unpaid and within the rules' amount tolerance.
+func (s *ReconciliationService) Accept(ctx context.Context, lineID, transactionID uuid.UUID, rules MatchRules, createdBy uuid.UUID) (*entity.StatementLine, *entity.Transaction, error) {
+	var (
+		line    *entity.StatementLine
+		before  entity.StatementLine
+		payment *entity.Transaction
+	)
+	err := inTx(ctx, s.transactor, func(ctx context.Context) error {
+		var err error
+		line, err = s.statements.LockLine(ctx, lineID)
+		if err != nil {
+			return err
+		}
+		before = *line
+		payment, err = s.accept(ctx, line, transactionID, rules, createdBy)
+		return err
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	recordAudit(ctx, s.audit, entity.AuditActionReconciliationAccept, "statement_line", line.ID, payment.ProjectID, &before, line)
+	return line, payment, nil
+}
+
+// accept matches a locked line, recording the payment when the target is an invoice
+func (s *ReconciliationService) accept(ctx context.Context, line *entity.StatementLine, transactionID uuid.UUID, rules MatchRules, createdBy uuid.UUID) (*entity.Transaction, error) {
+	if !line.IsOpen() {
+		return nil, entity.ErrStatementLineReconciled
+	}
+	if !line.IsCredit() {
+		return nil, entity.ErrInvalidMatchTarget
+	}
+
+	stmt, err := s.statements.FindStatementByID(ctx, line.StatementID)
+	if err != nil {
+		return nil, err
+	}
+
+	target, err := s.txRepo.FindByID(ctx, transactionID)
+	if err != nil {
+		return nil, err
+	}
+	if target.ProjectID != stmt.ProjectID {
+		return nil, entity.ErrInvalidMatchTarget
+	}
+	if target.Currency != line.Currency {
+		return nil, entity.ErrCurrencyMismatch
+	}
+
+	matched, err := s.statements.FindMatchedTransactionIDs(ctx, stmt.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+
+	var payment *entity.Transaction
+	switch target.Type {
+	case entity.TransactionTypePayment:
+		if matched[target.ID] {
+			return nil, entity.ErrTransactionReconciled
+		}
+		payment = target
+	case entity.TransactionTypeInvoice:
+		diff := line.AmountCents - target.AmountCents
+		if diff < 0 {
+			diff = -diff
+		}
+		if diff > rules.AmountToleranceCents {
+			return nil, entity.ErrMatchAmountMismatch
+		}
+
+		// Lock the project so two lines cannot both pay the same invoice
+		if s.ledger.projects != nil {
+			if _, err := s.ledger.projects.Lock(ctx, stmt.ProjectID); err != nil {
+				return nil, err
+			}
+		}
+		txs, err := s.txRepo.FindByProjectID(ctx, stmt.ProjectID)
+		if err != nil {
+			return nil, err
+		}
+		invoiceNo := invoiceNumber(target)
+		if paidInvoices(txs)[invoiceNo] {
+			return nil, entity.ErrInvoiceAlreadyPaid
+		}
+
+		receiptNo := line.BankReference
+		if receiptNo == "" {
+			receiptNo = line.Reference
+		}
+
+		// A payment under a contract passes the compliance gate like any other
+		payment, err = s.ledger.RecordPaymentWithMetadata(ctx, stmt.ProjectID, target.ContractID, line.AmountCents, line.Currency, line.ValueDate, entity.TransactionMetadata{
+			BankReceiptNo: receiptNo,
+			InvoiceNo:     invoiceNo,
+			Notes:         line.Description,
+		}, createdBy)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, entity.ErrInvalidMatchTarget
+	}
+
+	if err := line.MarkMatched(payment.ID); err != nil {
+		return nil, err
+	}
+	if err := s.statements.UpdateLine(ctx, line); err != nil {
+		return nil, err
+	}
+	return payment, nil
+}
+
+// Ignore excludes a line from reconciliation
+func (s *ReconciliationService) Ignore(ctx context.Context, lineID uuid.UUID) (*entity.StatementLine, error) {
+	line, err := s.statements.FindLineByID(ctx, lineID)
+	if err != nil {
+		return nil, err
+	}
+	if err := line.MarkIgnored(); err != nil {
+		return nil, err
+	}
+	if err := s.statements.UpdateLine(ctx, line); err != nil {
+		return nil, err
+	}
+	return line, nil
+}
+
+// candidates returns unreconciled payments and invoices that no payment references yet
+func (s *ReconciliationService) candidates(ctx context.Context, projectID uuid.UUID) ([]*entity.Transaction, []*entity.Transaction, error) {
+	txs, err := s.txRepo.FindByProjectID(ctx, projectID)
+	if err != nil {
+		return nil, nil, err
+	}
+	matched, err := s.statements.FindMatchedTransactionIDs(ctx, projectID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	paid := paidInvoices(txs)
+	var payments, invoices []*entity.Transaction
+	for _, tx := range txs {
+		switch tx.Type {
+		case entity.TransactionTypePayment:
+			if !matched[tx.ID] {
+				payments = append(payments, tx)
+			}
+		case entity.TransactionTypeInvoice:
+			if !paid[invoiceNumber(tx)] {
+				invoices = append(invoices, tx)
+			}
+		}
+	}
+	return payments, invoices, nil
+}
+
+// paidInvoices returns the invoice numbers referenced by a payment
+func paidInvoices(txs []*entity.Transaction) map[string]bool {
+	paid := make(map[string]bool)
+	for _, tx := range txs {
+		if tx.Type != entity.TransactionTypePayment {
+			continue
+		}
+		if meta, err := tx.GetMetadata(); err == nil && meta.InvoiceNo != "" {
+			paid[meta.InvoiceNo] = true
+		}
+	}
+	return paid
+}
+
+// invoiceNumber returns the number payments use to reference an invoice
+func invoiceNumber(tx *entity.Transaction) string {
+	if meta, err := tx.GetMetadata(); err == nil && meta.InvoiceNo != "" {
+		return meta.InvoiceNo
+	}
+	return tx.ReferenceNo
+}
+
+// scoreMatch rates a line/transaction pair
+// Amount must agree within tolerance; reference and date proximity add confidence
+func scoreMatch(line *entity.StatementLine, tx *entity.Transaction, windowDays int, rules MatchRules) (MatchSuggestion, bool) {
+	if tx.Currency != line.Currency {
+		return MatchSuggestion{}, false
+	}
+
+	sug := MatchSuggestion{
+		LineID:          line.ID,
+		LineNo:          line.LineNo,
+		TransactionID:   tx.ID,
+		TransactionType: tx.Type,
+		ReferenceNo:     tx.ReferenceNo,
+		AmountCents:     tx.AmountCents,
+	}
+
+	diff := line.AmountCents - tx.AmountCents
+	if diff < 0 {
+		diff = -diff
+	}
+	switch {
+	case diff == 0:
+		sug.Score += 50
+		sug.Reasons = append(sug.Reasons, "exact amount")
+	case diff <= rules.AmountToleranceCents:
+		sug.Score += 35
+		sug.Reasons = append(sug.Reasons, "amount within tolerance")
+	default:
+		return MatchSuggestion{}, false
+	}
+
+	if referenceMatches(line, tx) {
+		sug.Score += 30
+		sug.Reasons = append(sug.Reasons, "reference found in statement line")
+	}
+
+	days := daysBetween(tx.EffectiveDate, line.ValueDate)
+	if tx.Type == entity.TransactionTypeInvoice {
+		// Invoices are paid after issue, never before
+		if days < 0 || days > windowDays {
+			return MatchSuggestion{}, false
+		}
+	} else {
+		if days < 0 {
+			days = -days
+		}
+		if days > windowDays {
+			return MatchSuggestion{}, false
+		}
+	}
+	switch {
+	case days <= 1:
+		sug.Score += 20
+		sug.Reasons = append(sug.Reasons, "same value date")
+	case days <= windowDays/2:
+		sug.Score += 10
+		sug.Reasons = append(sug.Reasons, "close value date")
+	}
+
+	return sug, sug.Score >= rules.MinScore
+}
+
+// referenceMatches checks whether the transaction's invoice or receipt number appears on the line
+func referenceMatches(line *entity.StatementLine, tx *entity.Transaction) bool {
+	refs := []string{tx.ReferenceNo}
+	if meta, err := tx.GetMetadata(); err == nil {
+		refs = append(refs, meta.InvoiceNo, meta.BankReceiptNo)
+	}
+
+	haystack := normalizeReference(line.Reference + " " + line.BankReference + " " + line.Description)
+	for _, ref := range refs {
+		if n := normalizeReference(ref); len(n) >= 3 && strings.Contains(haystack, n) {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizeReference keeps only upper-cased letters and digits
+// Banks routinely drop dashes and slashes from references
+func normalizeReference(s string) string {
+	var b strings.Builder
+	for _, r := range strings.ToUpper(s) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// daysBetween returns whole days from "from" to "to", ignoring time of day
+func daysBetween(from, to time.Time) int {
+	t := time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, time.UTC)
+	f := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC)
+	return int(t.Sub(f).Hours() / 24)
+}
@@ -0,0 +1,302 @@
+// Copyright (c) 2026 Muhammet Ali Büyük. All rights reserved.
+// This source code is proprietary. Confidential and private.
+// Unauthorized copying or distribution is strictly prohibited.
+// Contact: iletisim@alibuyuk.net | Website: alibuyuk.net
+
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/qantesm/subflow/internal/core/entity"
+)
+
+// stubStatementRepository is a minimal in-package BankStatementRepository for service tests
+type stubStatementRepository struct {
+	statements map[uuid.UUID]*entity.BankStatement
+}
+
+func (r *stubStatementRepository) SaveStatement(ctx context.Context, stmt *entity.BankStatement) error {
+	if r.statements == nil {
+		r.statements = make(map[uuid.UUID]*entity.BankStatement)
+	}
+	r.statements[stmt.ID] = stmt
+	return nil
+}
+
+func (r *stubStatementRepository) FindStatementByID(ctx context.Context, id uuid.UUID) (*entity.BankStatement, error) {
+	if stmt, ok := r.statements[id]; ok {
+		return stmt, nil
+	}
+	return nil, entity.ErrStatementNotFound
+}
+
+func (r *stubStatementRepository) FindLineByID(ctx context.Context, id uuid.UUID) (*entity.StatementLine, error) {
+	for _, stmt := range r.statements {
+		for _, line := range stmt.Lines {
+			if line.ID == id {
+				return line, nil
+			}
+		}
+	}
+	return nil, entity.ErrStatementLineNotFound
+}
+
+func (r *stubStatementRepository) LockLine(ctx context.Context, id uuid.UUID) (*entity.StatementLine, error) {
+	return r.FindLineByID(ctx, id)
+}
+
+func (r *stubStatementRepository) UpdateLine(ctx context.Context, line *entity.StatementLine) error {
+	return nil // Lines are shared pointers
+}
+
+func (r *stubStatementRepository) FindMatchedTransactionIDs(ctx context.Context, projectID uuid.UUID) (map[uuid.UUID]bool, error) {
+	matched := make(map[uuid.UUID]bool)
+	for _, stmt := range r.statements {
+		for _, line := range stmt.Lines {
+			if stmt.ProjectID == projectID && line.MatchedTransactionID != nil {
+				matched[*line.MatchedTransactionID] = true
+			}
+		}
+	}
+	return matched, nil
+}
+
+func newReconciliationFixture(t *testing.T) (*ReconciliationService, *stubTransactionRepository, *entity.BankStatement) {
+	t.Helper()
+
+	projectID := uuid.New()
+	valueDate := time.Date(2026, 1, 31, 0, 0, 0, 0, time.UTC)
+
+	txRepo := &stubTransactionRepository{}
+	ledger := NewLedgerService(txRepo)
+	svc := NewReconciliationService(&stubStatementRepository{}, txRepo, ledger)
+
+	invoice, err := ledger.RecordInvoice(context.Background(), projectID, 25000000, "TRY", "HKD-2026-004", uuid.New())
+	if err != nil {
+		t.Fatalf("RecordInvoice() error = %v", err)
+	}
+	invoice.EffectiveDate = valueDate.AddDate(0, 0, -20)
+
+	stmt := entity.NewBankStatement(projectID, entity.StatementFormatMT940, uuid.New())
+	stmt.Currency = "TRY"
+	stmt.OpeningBalance = 0
+	stmt.ClosingBalance = 25000000 - 125050
+	stmt.AddLine(&entity.StatementLine{
+		ValueDate:     valueDate,
+		BookingDate:   valueDate,
+		Direction:     entity.EntryDirectionCredit,
+		AmountCents:   25000000,
+		Reference:     "HKD2026004",
+		BankReference: "BNK7781",
+	})
+	stmt.AddLine(&entity.StatementLine{
+		ValueDate:   valueDate,
+		BookingDate: valueDate,
+		Direction:   entity.EntryDirectionDebit,
+		AmountCents: 125050,
+	})
+
+	if err := svc.ImportStatement(context.Background(), stmt); err != nil {
+		t.Fatalf("ImportStatement() error = %v", err)
+	}
+	return svc, txRepo, stmt
+}
+
+func TestReconciliationImportRejectsUnbalancedStatement(t *testing.T) {
+	svc := NewReconciliationService(&stubStatementRepository{}, &stubTransactionRepository{}, nil)
+
+	stmt := entity.NewBankStatement(uuid.New(), entity.StatementFormatCAMT053, uuid.New())
+	stmt.Currency = "TRY"
+	stmt.ClosingBalance = 500
+	stmt.AddLine(&entity.StatementLine{Direction: entity.EntryDirectionCredit, AmountCents: 400})
+
+	if err := svc.ImportStatement(context.Background(), stmt); !errors.Is(err, entity.ErrStatementBalanceMismatch) {
+		t.Errorf("ImportStatement() error = %v, want ErrStatementBalanceMismatch", err)
+	}
+}
+
+func TestReconciliationSuggestAndAcceptInvoice(t *testing.T) {
+	ctx := context.Background()
+	svc, txRepo, stmt := newReconciliationFixture(t)
+
+	suggestions, err := svc.Suggest(ctx, stmt.ID, MatchRules{})
+	if err != nil {
+		t.Fatalf("Suggest() error = %v", err)
+	}
+	if len(suggestions) != 1 {
+		t.Fatalf("suggestions = %d, want 1 (debit line skipped)", len(suggestions))
+	}
+
+	sug := suggestions[0]
+	if sug.TransactionType != entity.TransactionTypeInvoice || sug.Score != 90 {
+		t.Errorf("suggestion = %s score %d (%v)", sug.TransactionType, sug.Score, sug.Reasons)
+	}
+
+	line, payment, err := svc.Accept(ctx, sug.LineID, sug.TransactionID, DefaultMatchRules(), uuid.New())
+	if err != nil {
+		t.Fatalf("Accept() error = %v", err)
+	}
+	if line.Status != entity.StatementLineMatched || *line.MatchedTransactionID != payment.ID {
+		t.Errorf("line not matched to new payment: %+v", line)
+	}
+	if payment.Type != entity.TransactionTypePayment || payment.AmountCents != 25000000 {
+		t.Errorf("payment = %s %d", payment.Type, payment.AmountCents)
+	}
+	if !payment.EffectiveDate.Equal(line.ValueDate) {
+		t.Errorf("payment effective date = %v, want value date", payment.EffectiveDate)
+	}
+	meta, _ := payment.GetMetadata()
+	if meta.InvoiceNo != "HKD-2026-004" || meta.BankReceiptNo != "BNK7781" {
+		t.Errorf("payment metadata = %+v", meta)
+	}
+	if len(txRepo.transactions) != 2 {
+		t.Errorf("ledger size = %d, want 2", len(txRepo.transactions))
+	}
+
+	// Invoice is now paid and the line closed - nothing left to suggest
+	suggestions, _ = svc.Suggest(ctx, stmt.ID, MatchRules{})
+	if len(suggestions) != 0 {
+		t.Errorf("suggestions after accept = %d, want 0", len(suggestions))
+	}
+
+	if _, _, err := svc.Accept(ctx, line.ID, sug.TransactionID, DefaultMatchRules(), uuid.New()); !errors.Is(err, entity.ErrStatementLineReconciled) {
+		t.Errorf("second Accept() error = %v, want ErrStatementLineReconciled", err)
+	}
+}
+
+// stubTransactor counts units of work and runs them directly
+type stubTransactor struct {
+	units int
+}
+
+func (t *stubTransactor) InTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	t.units++
+	return fn(ctx)
+}
+
+func TestReconciliationAcceptInvoicePassesPaymentGate(t *testing.T) {
+	ctx := context.Background()
+	svc, txRepo, stmt := newReconciliationFixture(t)
+	transactor := &stubTransactor{}
+	svc.SetTransactor(transactor)
+
+	contractID := uuid.New()
+	invoice := txRepo.transactions[0]
+	invoice.ContractID = &contractID
+	gate := &stubPaymentGate{blocked: map[uuid.UUID]bool{contractID: true}}
+	svc.ledger.SetPaymentGate(gate)
+
+	line := stmt.Lines[0]
+	if _, _, err := svc.Accept(ctx, line.ID, invoice.ID, DefaultMatchRules(), uuid.New()); !errors.Is(err, entity.ErrSubcontractorNonCompliant) {
+		t.Fatalf("Accept() error = %v, want ErrSubcontractorNonCompliant", err)
+	}
+	if !line.IsOpen() || len(txRepo.transactions) != 1 {
+		t.Errorf("blocked match changed state: line %s, ledger size %d", line.Status, len(txRepo.transactions))
+	}
+
+	gate.blocked[contractID] = false
+	_, payment, err := svc.Accept(ctx, line.ID, invoice.ID, DefaultMatchRules(), uuid.New())
+	if err != nil {
+		t.Fatalf("Accept() error = %v", err)
+	}
+	if payment.ContractID == nil || *payment.ContractID != contractID || gate.recorded != 1 {
+		t.Errorf("payment contract = %v, gate recorded %d", payment.ContractID, gate.recorded)
+	}
+	if transactor.units != 2 {
+		t.Errorf("units of work = %d, want one per Accept", transactor.units)
+	}
+}
+
+func TestReconciliationAcceptInvoiceOnceWithinTolerance(t *testing.T) {
+	ctx := context.Background()
+	svc, txRepo, stmt := newReconciliationFixture(t)
+	invoice := txRepo.transactions[0]
+
+	// The same invoice arrives again, short by a bank charge
+	charged := entity.NewBankStatement(stmt.ProjectID, entity.StatementFormatMT940, uuid.New())
+	charged.Currency = "TRY"
+	charged.ClosingBalance = 25000000 - 1000
+	charged.AddLine(&entity.StatementLine{
+		ValueDate:   stmt.Lines[0].ValueDate,
+		BookingDate: stmt.Lines[0].ValueDate,
+		Direction:   entity.EntryDirectionCredit,
+		AmountCents: 25000000 - 1000,
+	})
+	if err := svc.ImportStatement(ctx, charged); err != nil {
+		t.Fatalf("ImportStatement() error = %v", err)
+	}
+	line := charged.Lines[0]
+
+	if _, _, err := svc.Accept(ctx, line.ID, invoice.ID, DefaultMatchRules(), uuid.New()); !errors.Is(err, entity.ErrMatchAmountMismatch) {
+		t.Fatalf("Accept() error = %v, want ErrMatchAmountMismatch", err)
+	}
+	if _, _, err := svc.Accept(ctx, line.ID, invoice.ID, MatchRules{AmountToleranceCents: 1000}, uuid.New()); err != nil {
+		t.Fatalf("Accept(within tolerance) error = %v", err)
+	}
+
+	if _, _, err := svc.Accept(ctx, stmt.Lines[0].ID, invoice.ID, DefaultMatchRules(), uuid.New()); !errors.Is(err, entity.ErrInvoiceAlreadyPaid) {
+		t.Errorf("Accept(paid invoice) error = %v, want ErrInvoiceAlreadyPaid", err)
+	}
+	if !stmt.Lines[0].IsOpen() || len(txRepo.transactions) != 2 {
+		t.Errorf("paid invoice must not be paid twice: line %s, ledger size %d", stmt.Lines[0].Status, len(txRepo.transactions))
+	}
+}
+
+func TestReconciliationAcceptExistingPayment(t *testing.T) {
+	ctx := context.Background()
+	svc, txRepo, stmt := newReconciliationFixture(t)
+
+	payment, err := svc.ledger.RecordPayment(ctx, stmt.ProjectID, nil, 25000000, "TRY", "BNK7781", uuid.New())
+	if err != nil {
+		t.Fatalf("RecordPayment() error = %v", err)
+	}
+	payment.EffectiveDate = stmt.Lines[0].ValueDate
+
+	suggestions, err := svc.Suggest(ctx, stmt.ID, MatchRules{})
+	if err != nil {
+		t.Fatalf("Suggest() error = %v", err)
+	}
+	if len(suggestions) != 2 || suggestions[0].TransactionID != payment.ID {
+		t.Fatalf("best suggestion should be the recorded payment: %+v", suggestions)
+	}
+
+	line, matched, err := svc.Accept(ctx, stmt.Lines[0].ID, payment.ID, DefaultMatchRules(), uuid.New())
+	if err != nil {
+		t.Fatalf("Accept() error = %v", err)
+	}
+	if matched.ID != payment.ID || line.Status != entity.StatementLineMatched {
+		t.Errorf("line should link to existing payment")
+	}
+	if len(txRepo.transactions) != 2 {
+		t.Errorf("matching a payment must not create transactions, ledger size = %d", len(txRepo.transactions))
+	}
+
+	// The debit line cannot settle anything, but it can be ignored
+	if _, _, err := svc.Accept(ctx, stmt.Lines[1].ID, payment.ID, DefaultMatchRules(), uuid.New()); !errors.Is(err, entity.ErrInvalidMatchTarget) {
+		t.Errorf("Accept(debit) error = %v, want ErrInvalidMatchTarget", err)
+	}
+	ignored, err := svc.Ignore(ctx, stmt.Lines[1].ID)
+	if err != nil || ignored.Status != entity.StatementLineIgnored {
+		t.Errorf("Ignore() = %v, %v", ignored, err)
+	}
+}
+
+func TestScoreMatchAmountTolerance(t *testing.T) {
+	date := time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC)
+	line := &entity.StatementLine{ValueDate: date, AmountCents: 99500, Currency: "TRY", Direction: entity.EntryDirectionCredit}
+	tx := &entity.Transaction{Type: entity.TransactionTypePayment, AmountCents: 100000, Currency: "TRY", EffectiveDate: date}
+
+	if _, ok := scoreMatch(line, tx, 7, MatchRules{MinScore: 50}); ok {
+		t.Error("amount outside tolerance should not match")
+	}
+
+	sug, ok := scoreMatch(line, tx, 7, MatchRules{MinScore: 50, AmountToleranceCents: 500})
+	if !ok || sug.Score != 55 {
+		t.Errorf("scoreMatch() = %d, %v, want 55", sug.Score, ok)
+	}
+}
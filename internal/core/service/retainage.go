@@ -0,0 +1,315 @@
+// Copyright (c) 2026 Muhammet Ali Büyük. All rights reserved.
+// This source code is proprietary. Confidential and private.
+// Unauthorized copying or distribution is strictly prohibited.
+// Contact: iletisim@alibuyuk.net | Website: alibuyuk.net
+
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/qantesm/subflow/internal/core/entity"
+)
+
+// DefaultRetainageApprovals is how many sign-offs a release needs before it is paid out
+const DefaultRetainageApprovals = 1
+
+// RetainageRepository is the port for retainage release persistence
+type RetainageRepository interface {
+	Save(ctx context.Context, r *entity.RetainageRelease) error
+	Update(ctx context.Context, r *entity.RetainageRelease, events ...*entity.Event) error // Events go to the outbox atomically
+	FindByID(ctx context.Context, id uuid.UUID) (*entity.RetainageRelease, error)
+	FindByProjectID(ctx context.Context, projectID uuid.UUID) ([]*entity.RetainageRelease, error)
+	NextInvoiceSequence(ctx context.Context, year int) (int64, error) // Gapless per year
+}
+
+// RetainagePosition is the retainage held for a project or contract
+type RetainagePosition struct {
+	ProjectID  uuid.UUID  `json:"project_id"`
+	ContractID *uuid.UUID `json:"contract_id,omitempty"`
+	Held       int64      `json:"held"`
+	Released   int64      `json:"released"`
+	Pending    int64      `json:"pending"`   // Requested but not yet approved
+	Available  int64      `json:"available"` // Held - Released - Pending
+}
+
+// RetainageService runs the retainage release workflow: requests are checked
+// against the retained balance, approved, then paid out with a release invoice
+type RetainageService struct {
+	repo              RetainageRepository
+	projects          ProjectRepository
+	ledger            *LedgerService
+	requiredApprovals int
+	audit             Auditor    // Optional: records approvals and rejections in the audit trail
+	transactor        Transactor // Optional: makes every workflow step all-or-nothing
+	architect         string
+}
+
+// NewRetainageService creates a new retainage service requiring the given number of approvals per release
+func NewRetainageService(repo RetainageRepository, projects ProjectRepository, ledger *LedgerService, requiredApprovals int) *RetainageService {
+	if requiredApprovals <= 0 {
+		requiredApprovals = DefaultRetainageApprovals
+	}
+	return &RetainageService{
+		repo:              repo,
+		projects:          projects,
+		ledger:            ledger,
+		requiredApprovals: requiredApprovals,
+		architect:         "Muhammet-Ali-Buyuk",
+	}
+}
+
+// SetAuditor records every approval and rejection in the audit trail
+func (s *RetainageService) SetAuditor(audit Auditor) {
+	s.audit = audit
+}
+
+// SetTransactor runs each workflow step, the ledger entry and invoice number
+// of a release included, in one unit of work with the project locked
+func (s *RetainageService) SetTransactor(transactor Transactor) {
+	s.transactor = transactor
+}
+
+// Position returns the retainage held for a project, or a single contract when contractID is given
+func (s *RetainageService) Position(ctx context.Context, projectID uuid.UUID, contractID *uuid.UUID) (*RetainagePosition, error) {
+	return s.position(ctx, projectID, contractID, "")
+}
+
+// position is Position restricted to one currency when currency is given
+func (s *RetainageService) position(ctx context.Context, projectID uuid.UUID, contractID *uuid.UUID, currency string) (*RetainagePosition, error) {
+	held, released, err := s.ledger.RetainageBalanceIn(ctx, projectID, contractID, currency)
+	if err != nil {
+		return nil, err
+	}
+
+	releases, err := s.repo.FindByProjectID(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	pos := &RetainagePosition{
+		ProjectID:  projectID,
+		ContractID: contractID,
+		Held:       held,
+		Released:   released,
+	}
+	for _, r := range releases {
+		if r.Status != entity.RetainageReleasePending {
+			continue
+		}
+		if contractID != nil && (r.ContractID == nil || *r.ContractID != *contractID) {
+			continue
+		}
+		if currency != "" && r.Currency != currency {
+			continue
+		}
+		pos.Pending += r.Amount
+	}
+	pos.Available = pos.Held - pos.Released - pos.Pending
+	return pos, nil
+}
+
+// RecordSubstantialCompletion stores the substantial completion (geçici kabul) date of a project
+func (s *RetainageService) RecordSubstantialCompletion(ctx context.Context, projectID uuid.UUID, date time.Time) (*entity.Project, error) {
+	project, err := s.projects.FindByID(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	project.SubstantialCompletionDate = &date
+	if err := s.projects.Update(ctx, project); err != nil {
+		return nil, err
+	}
+	return project, nil
+}
+
+// RequestRelease validates and stores a release request.
+// A partial release may not exceed the available retainage; a final release
+// takes everything still available and needs the project substantially complete.
+// The project is locked so concurrent requests cannot commit the same retainage twice.
+func (s *RetainageService) RequestRelease(ctx context.Context, r *entity.RetainageRelease, now time.Time) error {
+	return inTx(ctx, s.transactor, func(ctx context.Context) error {
+		return s.requestRelease(ctx, r, now)
+	})
+}
+
+func (s *RetainageService) requestRelease(ctx context.Context, r *entity.RetainageRelease, now time.Time) error {
+	project, err := s.projects.Lock(ctx, r.ProjectID)
+	if err != nil {
+		return err
+	}
+	if r.Currency == "" {
+		r.Currency = project.Currency
+	}
+
+	pos, err := s.position(ctx, r.ProjectID, r.ContractID, r.Currency)
+	if err != nil {
+		return err
+	}
+
+	switch r.Kind {
+	case entity.RetainageReleaseFinal:
+		if !project.IsSubstantiallyComplete(now) {
+			return entity.ErrProjectNotSubstantiallyComplete
+		}
+		r.Amount = pos.Available
+		if r.Amount <= 0 {
+			return fmt.Errorf("%w: no retainage left to release", entity.ErrRetainageExceedsTotal)
+		}
+	case entity.RetainageReleasePartial:
+		if r.Amount > pos.Available {
+			return fmt.Errorf("%w: %s available", entity.ErrRetainageExceedsTotal, FormatCurrency(pos.Available, r.Currency))
+		}
+	}
+
+	r.RequiredApprovals = s.requiredApprovals
+	if err := r.Validate(); err != nil {
+		return err
+	}
+	return s.repo.Save(ctx, r)
+}
+
+// Get returns a single release request
+func (s *RetainageService) Get(ctx context.Context, id uuid.UUID) (*entity.RetainageRelease, error) {
+	return s.repo.FindByID(ctx, id)
+}
+
+// ListByProject returns the release requests of a project
+func (s *RetainageService) ListByProject(ctx context.Context, projectID uuid.UUID) ([]*entity.RetainageRelease, error) {
+	return s.repo.FindByProjectID(ctx, projectID)
+}
+
+// Approve records a sign-off and pays the retainage out once enough approvals are in
+// The sign-off, the ledger entry, the invoice number and the request are stored in one unit of work.
+func (s *RetainageService) Approve(ctx context.Context, id, approvedBy uuid.UUID, note string, now time.Time) (*entity.RetainageRelease, error) {
+	var (
+		r      *entity.RetainageRelease
+		before entity.RetainageRelease
+	)
+	err := inTx(ctx, s.transactor, func(ctx context.Context) error {
+		var err error
+		if r, err = s.lockRelease(ctx, id); err != nil {
+			return err
+		}
+		before = *r
+		before.Approvals = append([]entity.RetainageApproval(nil), r.Approvals...)
+		if err := r.Approve(approvedBy, note, now); err != nil {
+			return err
+		}
+
+		if r.IsApproved() {
+			if err := s.release(ctx, r, approvedBy, now); err != nil {
+				return err
+			}
+		}
+
+		var events []*entity.Event
+		if r.Status == entity.RetainageReleaseReleased {
+			event, err := entity.NewEvent(entity.EventRetainageReleased, r.ProjectID, r)
+			if err != nil {
+				return err
+			}
+			events = append(events, event)
+		}
+		return s.repo.Update(ctx, r, events...)
+	})
+	if err != nil {
+		return nil, err
+	}
+	recordAudit(ctx, s.audit, entity.AuditActionRetainageApprove, "retainage_release", r.ID, r.ProjectID, &before, r)
+	return r, nil
+}
+
+// Reject closes a pending request
+func (s *RetainageService) Reject(ctx context.Context, id, rejectedBy uuid.UUID, reason string, now time.Time) (*entity.RetainageRelease, error) {
+	var (
+		r      *entity.RetainageRelease
+		before entity.RetainageRelease
+	)
+	err := inTx(ctx, s.transactor, func(ctx context.Context) error {
+		var err error
+		if r, err = s.lockRelease(ctx, id); err != nil {
+			return err
+		}
+		before = *r
+		if err := r.Reject(rejectedBy, reason, now); err != nil {
+			return err
+		}
+		return s.repo.Update(ctx, r)
+	})
+	if err != nil {
+		return nil, err
+	}
+	recordAudit(ctx, s.audit, entity.AuditActionRetainageReject, "retainage_release", r.ID, r.ProjectID, &before, r)
+	return r, nil
+}
+
+// lockRelease loads a request once its project is locked, so that
+// its status cannot change before the unit of work ends
+func (s *RetainageService) lockRelease(ctx context.Context, id uuid.UUID) (*entity.RetainageRelease, error) {
+	r, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.projects.Lock(ctx, r.ProjectID); err != nil {
+		return nil, err
+	}
+	return s.repo.FindByID(ctx, id)
+}
+
+// release records the ledger entry and issues the release invoice
+func (s *RetainageService) release(ctx context.Context, r *entity.RetainageRelease, releasedBy uuid.UUID, now time.Time) error {
+	project, err := s.projects.Lock(ctx, r.ProjectID)
+	if err != nil {
+		return err
+	}
+	if r.Kind == entity.RetainageReleaseFinal && !project.IsSubstantiallyComplete(now) {
+		return entity.ErrProjectNotSubstantiallyComplete
+	}
+
+	// Check the balance before consuming an invoice number
+	held, released, err := s.ledger.RetainageBalanceIn(ctx, r.ProjectID, r.ContractID, r.Currency)
+	if err != nil {
+		return err
+	}
+	if r.Amount > held-released {
+		return fmt.Errorf("%w: %s still held", entity.ErrRetainageExceedsTotal, FormatCurrency(held-released, r.Currency))
+	}
+
+	seq, err := s.repo.NextInvoiceSequence(ctx, now.Year())
+	if err != nil {
+		return err
+	}
+	invoice := &entity.RetainageInvoice{
+		InvoiceNo:          fmt.Sprintf("TMI%d%06d", now.Year(), seq),
+		IssueDate:          now,
+		ProjectCode:        project.Code,
+		ProjectName:        project.Name,
+		Description:        retainageInvoiceDescription(r),
+		TotalHeld:          held,
+		PreviouslyReleased: released,
+		ThisRelease:        r.Amount,
+		Remaining:          held - released - r.Amount,
+		Currency:           r.Currency,
+	}
+
+	tx, err := s.ledger.RecordRetainageRelease(ctx, r.ProjectID, r.ContractID, r.Amount, r.Currency, invoice.InvoiceNo, releasedBy)
+	if err != nil {
+		return err
+	}
+	r.MarkReleased(tx.ID, invoice, now)
+	return nil
+}
+
+func retainageInvoiceDescription(r *entity.RetainageRelease) string {
+	description := "Kısmi teminat kesintisi iadesi"
+	if r.Kind == entity.RetainageReleaseFinal {
+		description = "Geçici kabul sonrası teminat kesintisi iadesi"
+	}
+	if r.Reason != "" {
+		description += ": " + r.Reason
+	}
+	return description
+}
@@ -0,0 +1,185 @@
+// Copyright (c) 2026 Muhammet Ali Büyük. All rights reserved.
+// This source code is proprietary. Confidential and private.
+// Unauthorized copying or distribution is strictly prohibited.
+// Contact: iletisim@alibuyuk.net | Website: alibuyuk.net
+
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/qantesm/subflow/internal/core/entity"
+)
+
+type stubRetainageRepository struct {
+	releases []*entity.RetainageRelease
+	sequence int64
+}
+
+func (r *stubRetainageRepository) Save(ctx context.Context, release *entity.RetainageRelease) error {
+	r.releases = append(r.releases, release)
+	return nil
+}
+
+func (r *stubRetainageRepository) Update(ctx context.Context, release *entity.RetainageRelease, events ...*entity.Event) error {
+	return nil
+}
+
+func (r *stubRetainageRepository) FindByID(ctx context.Context, id uuid.UUID) (*entity.RetainageRelease, error) {
+	for _, release := range r.releases {
+		if release.ID == id {
+			return release, nil
+		}
+	}
+	return nil, entity.ErrRetainageReleaseNotFound
+}
+
+func (r *stubRetainageRepository) FindByProjectID(ctx context.Context, projectID uuid.UUID) ([]*entity.RetainageRelease, error) {
+	var result []*entity.RetainageRelease
+	for _, release := range r.releases {
+		if release.ProjectID == projectID {
+			result = append(result, release)
+		}
+	}
+	return result, nil
+}
+
+func (r *stubRetainageRepository) NextInvoiceSequence(ctx context.Context, year int) (int64, error) {
+	r.sequence++
+	return r.sequence, nil
+}
+
+func TestLedgerRejectsReleaseAboveHeld(t *testing.T) {
+	ctx := context.Background()
+	ledger := NewLedgerService(&stubTransactionRepository{})
+	projectID, contractA, contractB := uuid.New(), uuid.New(), uuid.New()
+
+	if _, err := ledger.RecordRetainageHeld(ctx, projectID, &contractA, 100000, "TRY", 0.10, uuid.New()); err != nil {
+		t.Fatalf("RecordRetainageHeld() error = %v", err)
+	}
+	if _, err := ledger.RecordRetainageHeld(ctx, projectID, &contractB, 50000, "TRY", 0.10, uuid.New()); err != nil {
+		t.Fatalf("RecordRetainageHeld() error = %v", err)
+	}
+
+	if _, err := ledger.RecordRetainageRelease(ctx, projectID, &contractB, 60000, "TRY", "", uuid.New()); !errors.Is(err, entity.ErrRetainageExceedsTotal) {
+		t.Errorf("release above contract retainage error = %v", err)
+	}
+	if _, err := ledger.RecordRetainageRelease(ctx, projectID, nil, 150000, "TRY", "", uuid.New()); err != nil {
+		t.Errorf("release of project retainage error = %v", err)
+	}
+	if _, err := ledger.RecordRetainageRelease(ctx, projectID, nil, 1, "TRY", "", uuid.New()); !errors.Is(err, entity.ErrRetainageExceedsTotal) {
+		t.Errorf("release after everything was released error = %v", err)
+	}
+}
+
+func TestLedgerRetainageReleaseLocksProjectAndChecksCurrency(t *testing.T) {
+	ctx := context.Background()
+	projects := &stubProjectRepository{}
+	transactor := &stubTransactor{}
+	ledger := NewLedgerService(&stubTransactionRepository{})
+	ledger.SetProjectRepository(projects)
+	ledger.SetTransactor(transactor)
+
+	project := entity.NewProject(uuid.New(), "Kartal Ofis", "PRJ-2026-011")
+	project.Status = entity.ProjectStatusActive
+	_ = projects.Create(ctx, project)
+	if _, err := ledger.RecordRetainageHeld(ctx, project.ID, nil, 100000, "TRY", 0.10, uuid.New()); err != nil {
+		t.Fatalf("RecordRetainageHeld() error = %v", err)
+	}
+
+	if _, err := ledger.RecordRetainageRelease(ctx, project.ID, nil, 50000, "USD", "", uuid.New()); !errors.Is(err, entity.ErrCurrencyMismatch) {
+		t.Errorf("release in another currency error = %v", err)
+	}
+	if _, err := ledger.RecordRetainageRelease(ctx, project.ID, nil, 50000, "TRY", "", uuid.New()); err != nil {
+		t.Fatalf("release in held currency error = %v", err)
+	}
+	if _, err := ledger.RecordRetainageRelease(ctx, uuid.New(), nil, 1, "TRY", "", uuid.New()); !errors.Is(err, entity.ErrProjectNotFound) {
+		t.Errorf("release without a project row to lock error = %v", err)
+	}
+	if transactor.units != 3 {
+		t.Errorf("releases ran in %d units of work, want 3", transactor.units)
+	}
+}
+
+func TestRetainageReleaseWorkflow(t *testing.T) {
+	ctx := context.Background()
+	projects := &stubProjectRepository{}
+	ledger := NewLedgerService(&stubTransactionRepository{})
+	s := NewRetainageService(&stubRetainageRepository{}, projects, ledger, 2)
+	transactor := &stubTransactor{}
+	s.SetTransactor(transactor)
+
+	project := entity.NewProject(uuid.New(), "Ataşehir Konut", "PRJ-2026-007")
+	project.Status = entity.ProjectStatusActive
+	_ = projects.Create(ctx, project)
+	if _, err := ledger.RecordRetainageHeld(ctx, project.ID, nil, 1000000, "TRY", 0.05, uuid.New()); err != nil {
+		t.Fatalf("RecordRetainageHeld() error = %v", err)
+	}
+
+	// Partial release needs two approvers other than the requester
+	requester := uuid.New()
+	partial := entity.NewRetainageRelease(project.ID, entity.RetainageReleasePartial, 400000, "", "Kaba inşaat tamamlandı", requester)
+	if err := s.RequestRelease(ctx, partial, day(2026, 6, 1)); err != nil {
+		t.Fatalf("RequestRelease() error = %v", err)
+	}
+	tooMuch := entity.NewRetainageRelease(project.ID, entity.RetainageReleasePartial, 700000, "TRY", "", requester)
+	if err := s.RequestRelease(ctx, tooMuch, day(2026, 6, 1)); !errors.Is(err, entity.ErrRetainageExceedsTotal) {
+		t.Errorf("request above available retainage error = %v", err)
+	}
+
+	if _, err := s.Approve(ctx, partial.ID, requester, "", day(2026, 6, 2)); !errors.Is(err, entity.ErrRetainageSelfApproval) {
+		t.Errorf("self approval error = %v", err)
+	}
+	approver := uuid.New()
+	if _, err := s.Approve(ctx, partial.ID, approver, "Uygun", day(2026, 6, 2)); err != nil || partial.Status != entity.RetainageReleasePending {
+		t.Fatalf("first approval = %s, %v", partial.Status, err)
+	}
+	if _, err := s.Approve(ctx, partial.ID, approver, "", day(2026, 6, 2)); !errors.Is(err, entity.ErrRetainageDuplicateApproval) {
+		t.Errorf("duplicate approval error = %v", err)
+	}
+	if _, err := s.Approve(ctx, partial.ID, uuid.New(), "", day(2026, 6, 3)); err != nil {
+		t.Fatalf("second approval error = %v", err)
+	}
+	if partial.Status != entity.RetainageReleaseReleased || partial.TransactionID == nil || partial.Invoice == nil {
+		t.Fatalf("after approvals = %+v", partial)
+	}
+	if inv := partial.Invoice; inv.InvoiceNo != "TMI2026000001" || inv.TotalHeld != 1000000 || inv.ThisRelease != 400000 || inv.Remaining != 600000 {
+		t.Errorf("invoice = %+v", inv)
+	}
+
+	// Final release is locked until substantial completion, then takes the rest
+	final := entity.NewRetainageRelease(project.ID, entity.RetainageReleaseFinal, 0, "", "", requester)
+	if err := s.RequestRelease(ctx, final, day(2026, 9, 1)); !errors.Is(err, entity.ErrProjectNotSubstantiallyComplete) {
+		t.Errorf("final release before completion error = %v", err)
+	}
+	if _, err := s.RecordSubstantialCompletion(ctx, project.ID, day(2026, 8, 15)); err != nil {
+		t.Fatalf("RecordSubstantialCompletion() error = %v", err)
+	}
+	if err := s.RequestRelease(ctx, final, day(2026, 9, 1)); err != nil {
+		t.Fatalf("final RequestRelease() error = %v", err)
+	}
+	if final.Amount != 600000 || final.Currency != "TRY" {
+		t.Errorf("final release = %d %s, want the remaining 600000 TRY", final.Amount, final.Currency)
+	}
+	pos, _ := s.Position(ctx, project.ID, nil)
+	if pos.Released != 400000 || pos.Pending != 600000 || pos.Available != 0 {
+		t.Errorf("position = %+v", pos)
+	}
+
+	if _, err := s.Reject(ctx, final.ID, uuid.New(), "Eksik iş listesi kapanmadı", day(2026, 9, 2)); err != nil {
+		t.Fatalf("Reject() error = %v", err)
+	}
+	if _, err := s.Approve(ctx, final.ID, uuid.New(), "", day(2026, 9, 3)); !errors.Is(err, entity.ErrRetainageReleaseNotPending) {
+		t.Errorf("approve rejected release error = %v", err)
+	}
+	if pos, _ = s.Position(ctx, project.ID, nil); pos.Available != 600000 {
+		t.Errorf("available after rejection = %d", pos.Available)
+	}
+	// Four requests, five approvals and a rejection, each with the project locked
+	if transactor.units != 10 {
+		t.Errorf("units of work = %d, want one per workflow step", transactor.units)
+	}
+}
@@ -0,0 +1,32 @@
+// Copyright (c) 2026 Muhammet Ali Büyük. All rights reserved.
+// This source code is proprietary. Confidential and private.
+// Unauthorized copying or distribution is strictly prohibited.
+// Contact: iletisim@alibuyuk.net | Website: alibuyuk.net
+
+package service
+
+import (
+	"context"
+	"time"
+)
+
+// RunEvery executes a job immediately and then once per interval until ctx is cancelled
+// Each outcome is passed to report, which may be nil. Runs never overlap.
+func RunEvery(ctx context.Context, interval time.Duration, job Job, report func(JobResult)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		start := time.Now()
+		err := job.Execute(ctx)
+		if report != nil {
+			report(JobResult{JobID: job.ID(), Kind: JobKind(job), Duration: time.Since(start), Error: err})
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
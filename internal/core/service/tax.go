@@ -0,0 +1,168 @@
+// Copyright (c) 2026 Muhammet Ali Büyük. All rights reserved.
+// This source code is proprietary. Confidential and private.
+// Unauthorized copying or distribution is strictly prohibited.
+// Contact: iletisim@alibuyuk.net | Website: alibuyuk.net
+
+package service
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/qantesm/subflow/internal/core/entity"
+)
+
+// TaxRateRepository is the port for tenant-specific tax rate tables
+type TaxRateRepository interface {
+	Save(ctx context.Context, rate *entity.TaxRate) error
+	FindByTenant(ctx context.Context, tenantID uuid.UUID) ([]*entity.TaxRate, error)
+}
+
+// TaxOptions selects which taxes apply to a billed amount
+type TaxOptions struct {
+	KDVCode         string `json:"kdv_code"`         // Default GENEL
+	WithholdingCode string `json:"withholding_code"` // Optional tevkifat code, e.g. 601
+	StampDuty       bool   `json:"stamp_duty"`       // Deduct damga vergisi from the payment
+	StampDutyCode   string `json:"stamp_duty_code"`  // Default SOZLESME
+}
+
+// TaxEngine computes KDV, tevkifat and stamp duty from per-tenant rate tables
+// A tenant rate replaces the statutory default for the same kind and code
+type TaxEngine struct {
+	rates     TaxRateRepository
+	defaults  []*entity.TaxRate
+	architect string
+}
+
+// NewTaxEngine creates a tax engine backed by the statutory default table
+func NewTaxEngine(rates TaxRateRepository) *TaxEngine {
+	return &TaxEngine{
+		rates:     rates,
+		defaults:  entity.DefaultTaxRates(),
+		architect: "Muhammet-Ali-Buyuk",
+	}
+}
+
+// Rates returns the effective rate table of a tenant ordered by kind, code and date
+func (e *TaxEngine) Rates(ctx context.Context, tenantID uuid.UUID) ([]*entity.TaxRate, error) {
+	own, err := e.rates.FindByTenant(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	overridden := make(map[string]bool)
+	for _, rate := range own {
+		overridden[string(rate.Kind)+"/"+rate.Code] = true
+	}
+
+	table := append([]*entity.TaxRate{}, own...)
+	for _, rate := range e.defaults {
+		if !overridden[string(rate.Kind)+"/"+rate.Code] {
+			table = append(table, rate)
+		}
+	}
+
+	sort.Slice(table, func(i, j int) bool {
+		if table[i].Kind != table[j].Kind {
+			return table[i].Kind < table[j].Kind
+		}
+		if table[i].Code != table[j].Code {
+			return table[i].Code < table[j].Code
+		}
+		return table[i].ValidFrom.Before(table[j].ValidFrom)
+	})
+	return table, nil
+}
+
+// AddRate stores a tenant rate after checking it does not overlap the tenant's existing rates
+func (e *TaxEngine) AddRate(ctx context.Context, tenantID uuid.UUID, rate *entity.TaxRate) error {
+	rate.TenantID = tenantID
+	if err := rate.Validate(); err != nil {
+		return err
+	}
+
+	own, err := e.rates.FindByTenant(ctx, tenantID)
+	if err != nil {
+		return err
+	}
+	for _, existing := range own {
+		if existing.Overlaps(rate) {
+			return entity.ErrTaxRateOverlap
+		}
+	}
+
+	return e.rates.Save(ctx, rate)
+}
+
+// Resolve returns the rate in force on a date
+func (e *TaxEngine) Resolve(ctx context.Context, tenantID uuid.UUID, kind entity.TaxKind, code string, date time.Time) (*entity.TaxRate, error) {
+	table, err := e.Rates(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	for _, rate := range table {
+		if rate.Kind == kind && rate.Code == code && rate.AppliesOn(date) {
+			return rate, nil
+		}
+	}
+	return nil, entity.ErrTaxRateNotFound
+}
+
+// Compute calculates the tax breakdown for a base amount on a date
+// Withholding is a share of KDV; stamp duty is charged on the base
+func (e *TaxEngine) Compute(ctx context.Context, tenantID uuid.UUID, date time.Time, base int64, opts TaxOptions) (*entity.TaxBreakdown, error) {
+	if opts.KDVCode == "" {
+		opts.KDVCode = entity.KDVCodeGeneral
+	}
+	if opts.StampDutyCode == "" {
+		opts.StampDutyCode = entity.StampDutyCodeContract
+	}
+
+	kdv, err := e.Resolve(ctx, tenantID, entity.TaxKindKDV, opts.KDVCode, date)
+	if err != nil {
+		return nil, err
+	}
+
+	b := &entity.TaxBreakdown{
+		Date:      date,
+		Base:      base,
+		KDVCode:   kdv.Code,
+		KDVRate:   kdv.Rate,
+		KDVAmount: kdv.Apply(base),
+	}
+
+	if opts.WithholdingCode != "" {
+		withholding, err := e.Resolve(ctx, tenantID, entity.TaxKindWithholding, opts.WithholdingCode, date)
+		if err == entity.ErrTaxRateNotFound {
+			return nil, entity.ErrInvalidWithholdingCode
+		}
+		if err != nil {
+			return nil, err
+		}
+		b.WithholdingCode = withholding.Code
+		b.WithholdingName = withholding.Name
+		b.WithholdingRate = withholding.Rate
+		b.WithholdingAmount = withholding.Apply(b.KDVAmount)
+	}
+
+	if opts.StampDuty {
+		stamp, err := e.Resolve(ctx, tenantID, entity.TaxKindStampDuty, opts.StampDutyCode, date)
+		if err != nil {
+			return nil, err
+		}
+		b.StampDutyRate = stamp.Rate
+		b.StampDutyAmount = stamp.Apply(base)
+	}
+
+	b.GrossAmount = b.Base + b.KDVAmount
+	b.NetPayable = b.GrossAmount - b.WithholdingAmount - b.StampDutyAmount
+	return b, nil
+}
+
+// ComputeForBilling applies taxes on top of a G702 result
+// The amount certified for the period (current payment due) is the tax base
+func (e *TaxEngine) ComputeForBilling(ctx context.Context, tenantID uuid.UUID, date time.Time, result *AIABillingResult, opts TaxOptions) (*entity.TaxBreakdown, error) {
+	return e.Compute(ctx, tenantID, date, result.CurrentPaymentDue, opts)
+}
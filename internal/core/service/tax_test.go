@@ -0,0 +1,106 @@
+// Copyright (c) 2026 Muhammet Ali Büyük. All rights reserved.
+// This source code is proprietary. Confidential and private.
+// Unauthorized copying or distribution is strictly prohibited.
+// Contact: iletisim@alibuyuk.net | Website: alibuyuk.net
+
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/qantesm/subflow/internal/core/entity"
+)
+
+func day(y int, m time.Month, d int) time.Time {
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+func TestTaxEngineStatutoryDefaults(t *testing.T) {
+	ctx := context.Background()
+	engine := NewTaxEngine(&stubTaxRateRepository{})
+
+	tests := []struct {
+		name    string
+		date    time.Time
+		base    int64
+		opts    TaxOptions
+		wantKDV int64
+		wantWH  int64
+		wantSD  int64
+		wantNet int64
+	}{
+		{"18% before July 2023", day(2023, 7, 9), 1000000, TaxOptions{}, 180000, 0, 0, 1180000},
+		{"20% from 10 July 2023", day(2023, 7, 10), 1000000, TaxOptions{}, 200000, 0, 0, 1200000},
+		{"construction 4/10 tevkifat", day(2026, 3, 31), 1000000, TaxOptions{WithholdingCode: "601"}, 200000, 80000, 0, 1120000},
+		{"stamp duty binde 9,48", day(2026, 3, 31), 1000000, TaxOptions{StampDuty: true}, 200000, 0, 9480, 1190520},
+		{"reduced rate", day(2026, 3, 31), 1000000, TaxOptions{KDVCode: entity.KDVCodeReduced}, 100000, 0, 0, 1100000},
+		{"half-up rounding", day(2026, 3, 31), 333, TaxOptions{WithholdingCode: "616"}, 67, 34, 0, 366}, // 66.6 -> 67, 33.5 -> 34
+		{"no tax on credit", day(2026, 3, 31), -5000, TaxOptions{}, 0, 0, 0, -5000},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := engine.Compute(ctx, uuid.New(), tt.date, tt.base, tt.opts)
+			if err != nil {
+				t.Fatalf("Compute() error = %v", err)
+			}
+			if b.KDVAmount != tt.wantKDV || b.WithholdingAmount != tt.wantWH || b.StampDutyAmount != tt.wantSD || b.NetPayable != tt.wantNet {
+				t.Errorf("breakdown = kdv %d wh %d sd %d net %d", b.KDVAmount, b.WithholdingAmount, b.StampDutyAmount, b.NetPayable)
+			}
+			if b.GrossAmount != tt.base+tt.wantKDV {
+				t.Errorf("GrossAmount = %d, want %d", b.GrossAmount, tt.base+tt.wantKDV)
+			}
+		})
+	}
+}
+
+func TestTaxEngineTenantRates(t *testing.T) {
+	ctx := context.Background()
+	engine := NewTaxEngine(&stubTaxRateRepository{})
+	tenantID := uuid.New()
+
+	// Tenant works under a special regime: 10% KDV until the end of 2026, then 1%
+	first := entity.NewTaxRate(uuid.Nil, entity.TaxKindKDV, entity.KDVCodeGeneral, "Özel oran", 100000, day(2026, 1, 1))
+	end := day(2026, 12, 31)
+	first.ValidTo = &end
+	if err := engine.AddRate(ctx, tenantID, first); err != nil {
+		t.Fatalf("AddRate() error = %v", err)
+	}
+	if err := engine.AddRate(ctx, tenantID, entity.NewTaxRate(uuid.Nil, entity.TaxKindKDV, entity.KDVCodeGeneral, "Özel oran", 10000, day(2027, 1, 1))); err != nil {
+		t.Fatalf("AddRate() error = %v", err)
+	}
+
+	overlapping := entity.NewTaxRate(uuid.Nil, entity.TaxKindKDV, entity.KDVCodeGeneral, "Çakışan", 150000, day(2026, 6, 1))
+	if err := engine.AddRate(ctx, tenantID, overlapping); !errors.Is(err, entity.ErrTaxRateOverlap) {
+		t.Errorf("overlapping rate error = %v", err)
+	}
+	if err := engine.AddRate(ctx, tenantID, entity.NewTaxRate(uuid.Nil, entity.TaxKindKDV, "X", "", 2000000, day(2026, 1, 1))); !errors.Is(err, entity.ErrInvalidTaxRate) {
+		t.Errorf("rate above 100%% error = %v", err)
+	}
+
+	b, err := engine.Compute(ctx, tenantID, day(2026, 6, 30), 100000, TaxOptions{})
+	if err != nil || b.KDVAmount != 10000 {
+		t.Errorf("2026 KDV = %v, %v", b, err)
+	}
+	b, err = engine.Compute(ctx, tenantID, day(2027, 2, 1), 100000, TaxOptions{})
+	if err != nil || b.KDVAmount != 1000 {
+		t.Errorf("2027 KDV = %v, %v", b, err)
+	}
+
+	// The tenant table replaces the default for GENEL entirely, so 2025 has no rate
+	if _, err := engine.Compute(ctx, tenantID, day(2025, 6, 30), 100000, TaxOptions{}); !errors.Is(err, entity.ErrTaxRateNotFound) {
+		t.Errorf("uncovered date error = %v", err)
+	}
+	// Other tenants still get the statutory rate
+	if b, _ := engine.Compute(ctx, uuid.New(), day(2026, 6, 30), 100000, TaxOptions{}); b.KDVAmount != 20000 {
+		t.Errorf("other tenant KDV = %d, want 20000", b.KDVAmount)
+	}
+
+	if _, err := engine.Compute(ctx, tenantID, day(2026, 6, 30), 100000, TaxOptions{WithholdingCode: "999"}); !errors.Is(err, entity.ErrInvalidWithholdingCode) {
+		t.Errorf("unknown withholding error = %v", err)
+	}
+}
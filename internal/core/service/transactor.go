@@ -0,0 +1,23 @@
+// Copyright (c) 2026 Muhammet Ali Büyük. All rights reserved.
+// This source code is proprietary. Confidential and private.
+// Unauthorized copying or distribution is strictly prohibited.
+// Contact: iletisim@alibuyuk.net | Website: alibuyuk.net
+
+package service
+
+import "context"
+
+// Transactor is the port for units of work that span several repositories.
+// Repository calls made with the context handed to fn commit or roll back
+// together; a nested InTx joins the outer unit.
+type Transactor interface {
+	InTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+// inTx runs fn in the transactor's unit of work, or directly when none is set
+func inTx(ctx context.Context, t Transactor, fn func(ctx context.Context) error) error {
+	if t == nil {
+		return fn(ctx)
+	}
+	return t.InTx(ctx, fn)
+}
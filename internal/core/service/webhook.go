@@ -0,0 +1,440 @@
+// Copyright (c) 2026 Muhammet Ali Büyük. All rights reserved.
+// This source code is proprietary. Confidential and private.
+// Unauthorized copying or distribution is strictly prohibited.
+// Contact: iletisim@alibuyuk.net | Website: alibuyuk.net
+
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/qantesm/subflow/internal/core/entity"
+	"github.com/qantesm/subflow/internal/pkg"
+)
+
+const (
+	DefaultWebhookMaxAttempts = 8                // First try plus seven retries
+	DefaultWebhookBackoff     = time.Minute      // Doubles after every failed attempt
+	maxWebhookBackoff         = 12 * time.Hour   // Longest wait between two attempts
+	webhookAttemptTimeout     = 10 * time.Second // Per request
+	webhookRetryBatch         = 100              // Due deliveries picked up per retry run
+)
+
+// Headers sent with every webhook request
+const (
+	WebhookSignatureHeader = "X-Subflow-Signature" // t=<unix seconds>,v1=<hex HMAC-SHA256>
+	WebhookEventHeader     = "X-Subflow-Event"
+	WebhookEventIDHeader   = "X-Subflow-Event-Id" // Same for replays, receivers dedupe on it
+	WebhookDeliveryHeader  = "X-Subflow-Delivery"
+)
+
+// WebhookRepository is the port for webhook endpoint and delivery persistence
+type WebhookRepository interface {
+	SaveEndpoint(ctx context.Context, e *entity.WebhookEndpoint) error
+	UpdateEndpoint(ctx context.Context, e *entity.WebhookEndpoint) error
+	FindEndpointByID(ctx context.Context, id uuid.UUID) (*entity.WebhookEndpoint, error)
+	FindEndpointsByTenant(ctx context.Context, tenantID uuid.UUID) ([]*entity.WebhookEndpoint, error)
+	SaveDelivery(ctx context.Context, d *entity.WebhookDelivery) error // ErrWebhookDeliveryExists if the endpoint already has the event, replays aside
+	UpdateDelivery(ctx context.Context, d *entity.WebhookDelivery) error
+	FindDeliveryByID(ctx context.Context, id uuid.UUID) (*entity.WebhookDelivery, error)
+	FindDeliveriesByEndpoint(ctx context.Context, endpointID uuid.UUID, limit int) ([]*entity.WebhookDelivery, error) // Newest first
+	FindDueDeliveries(ctx context.Context, now time.Time, limit int) ([]*entity.WebhookDelivery, error)               // Pending with the next attempt due, oldest first
+}
+
+// JobSubmitter queues jobs for background execution; WorkerPool implements it
+type JobSubmitter interface {
+	Submit(job Job) error
+}
+
+// WebhookPayload is the JSON body POSTed to an endpoint
+type WebhookPayload struct {
+	ID         uuid.UUID        `json:"id"` // Event ID
+	Type       entity.EventType `json:"type"`
+	TenantID   uuid.UUID        `json:"tenant_id"`
+	ProjectID  uuid.UUID        `json:"project_id"`
+	OccurredAt time.Time        `json:"occurred_at"`
+	Data       json.RawMessage  `json:"data"`
+}
+
+// WebhookService fans project events out to the tenant's webhook endpoints.
+// Every event becomes one delivery per subscribed endpoint; deliveries run on
+// the worker pool and failed attempts are retried with exponential backoff by
+// WebhookRetryJob. Delivery is at least once, so receivers should dedupe on
+// the event ID header.
+type WebhookService struct {
+	repo        WebhookRepository
+	projects    ProjectRepository
+	pool        JobSubmitter
+	client      *http.Client
+	maxAttempts int
+	backoff     time.Duration
+	audit       Auditor           // Optional: records endpoint changes and replays in the audit trail
+	allowIP     func(net.IP) bool // Addresses endpoints may reach, entity.WebhookAddressAllowed
+	architect   string
+}
+
+// NewWebhookService creates a webhook service; zero attempts or backoff use the defaults
+// A nil client gets a client with a per-request timeout that only connects to public addresses.
+func NewWebhookService(repo WebhookRepository, projects ProjectRepository, pool JobSubmitter, client *http.Client, maxAttempts int, backoff time.Duration) *WebhookService {
+	if maxAttempts <= 0 {
+		maxAttempts = DefaultWebhookMaxAttempts
+	}
+	if backoff <= 0 {
+		backoff = DefaultWebhookBackoff
+	}
+	s := &WebhookService{
+		repo:        repo,
+		projects:    projects,
+		pool:        pool,
+		client:      client,
+		maxAttempts: maxAttempts,
+		backoff:     backoff,
+		allowIP:     entity.WebhookAddressAllowed,
+		architect:   "Muhammet-Ali-Buyuk",
+	}
+	if s.client == nil {
+		s.client = &http.Client{Timeout: webhookAttemptTimeout, Transport: s.guardedTransport()}
+	}
+	return s
+}
+
+// guardedTransport checks every address the client connects to, so neither a
+// host that resolves differently at delivery than at registration nor a
+// redirect reaches the internal network. It connects directly, without the
+// environment's proxy, so the check sees the receiver's address.
+func (s *WebhookService) guardedTransport() http.RoundTripper {
+	dialer := &net.Dialer{
+		Timeout: webhookAttemptTimeout,
+		Control: func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if ip := net.ParseIP(host); ip == nil || !s.allowIP(ip) {
+				return fmt.Errorf("%w: connection to %s refused", entity.ErrWebhookAddressNotAllowed, host)
+			}
+			return nil
+		},
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+	return transport
+}
+
+// checkAddress resolves the host of an endpoint URL and refuses it unless every address is public
+func (s *WebhookService) checkAddress(ctx context.Context, rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return entity.ErrInvalidWebhookEndpoint
+	}
+	host := u.Hostname()
+	addrs, err := net.DefaultResolver.LookupIPAddr(ctx, host)
+	if err != nil {
+		return fmt.Errorf("%w: %s does not resolve", entity.ErrInvalidWebhookEndpoint, host)
+	}
+	for _, addr := range addrs {
+		if !s.allowIP(addr.IP) {
+			return fmt.Errorf("%w: %s resolves to %s", entity.ErrWebhookAddressNotAllowed, host, addr.IP)
+		}
+	}
+	return nil
+}
+
+// SetAuditor records every endpoint change and replay in the audit trail
+func (s *WebhookService) SetAuditor(audit Auditor) {
+	s.audit = audit
+}
+
+// CreateEndpoint validates and stores a new endpoint
+// The URL must resolve to public addresses only.
+func (s *WebhookService) CreateEndpoint(ctx context.Context, e *entity.WebhookEndpoint) error {
+	if err := e.Validate(); err != nil {
+		return err
+	}
+	if err := s.checkAddress(ctx, e.URL); err != nil {
+		return err
+	}
+	if err := s.repo.SaveEndpoint(ctx, e); err != nil {
+		return err
+	}
+	recordAudit(tenantAuditContext(ctx, e.TenantID), s.audit, entity.AuditActionWebhookCreate, "webhook_endpoint", e.ID, uuid.Nil, nil, e)
+	return nil
+}
+
+// GetEndpoint returns an endpoint of the tenant
+func (s *WebhookService) GetEndpoint(ctx context.Context, tenantID, id uuid.UUID) (*entity.WebhookEndpoint, error) {
+	e, err := s.repo.FindEndpointByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if e.TenantID != tenantID {
+		return nil, entity.ErrWebhookEndpointNotFound
+	}
+	return e, nil
+}
+
+// ListEndpoints returns the endpoints of a tenant
+func (s *WebhookService) ListEndpoints(ctx context.Context, tenantID uuid.UUID) ([]*entity.WebhookEndpoint, error) {
+	return s.repo.FindEndpointsByTenant(ctx, tenantID)
+}
+
+// SetEndpointActive pauses or resumes deliveries to an endpoint
+func (s *WebhookService) SetEndpointActive(ctx context.Context, tenantID, id uuid.UUID, active bool) (*entity.WebhookEndpoint, error) {
+	e, err := s.GetEndpoint(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+	before := *e
+	e.Active = active
+	e.UpdatedAt = time.Now()
+	if err := s.repo.UpdateEndpoint(ctx, e); err != nil {
+		return nil, err
+	}
+	recordAudit(tenantAuditContext(ctx, tenantID), s.audit, entity.AuditActionWebhookUpdate, "webhook_endpoint", e.ID, uuid.Nil, &before, e)
+	return e, nil
+}
+
+// ListDeliveries returns the delivery log of an endpoint, newest first
+func (s *WebhookService) ListDeliveries(ctx context.Context, tenantID, endpointID uuid.UUID, limit int) ([]*entity.WebhookDelivery, error) {
+	if _, err := s.GetEndpoint(ctx, tenantID, endpointID); err != nil {
+		return nil, err
+	}
+	return s.repo.FindDeliveriesByEndpoint(ctx, endpointID, limit)
+}
+
+// Publish creates a delivery for every endpoint of the project's tenant that
+// subscribes to the event and queues it on the worker pool. The outbox may hand
+// the same event over again; endpoints that already have it are skipped.
+func (s *WebhookService) Publish(ctx context.Context, event *entity.Event) error {
+	project, err := s.projects.FindByID(ctx, event.ProjectID)
+	if err != nil {
+		return err
+	}
+	endpoints, err := s.repo.FindEndpointsByTenant(ctx, project.TenantID)
+	if err != nil {
+		return err
+	}
+
+	payload, err := json.Marshal(WebhookPayload{
+		ID:         event.ID,
+		Type:       event.Type,
+		TenantID:   project.TenantID,
+		ProjectID:  event.ProjectID,
+		OccurredAt: event.OccurredAt,
+		Data:       event.Data,
+	})
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, e := range endpoints {
+		if !e.Subscribes(event.Type) {
+			continue
+		}
+		d := entity.NewWebhookDelivery(e.ID, event.ID, event.Type, payload, s.leaseUntil(time.Now()))
+		if err := s.repo.SaveDelivery(ctx, d); err != nil {
+			if !errors.Is(err, entity.ErrWebhookDeliveryExists) {
+				errs = append(errs, err)
+			}
+			continue
+		}
+		// A delivery the pool does not take is picked up by the retry job once its lease runs out
+		if err := s.pool.Submit(&webhookDeliveryJob{webhooks: s, deliveryID: d.ID}); err != nil {
+			pkg.Ctx(ctx).Debug().Err(err).Str("delivery_id", d.ID.String()).Msg("webhook delivery left to the retry job")
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Replay sends a delivery's payload to its endpoint again as a new delivery
+func (s *WebhookService) Replay(ctx context.Context, tenantID, deliveryID uuid.UUID) (*entity.WebhookDelivery, error) {
+	original, err := s.repo.FindDeliveryByID(ctx, deliveryID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.GetEndpoint(ctx, tenantID, original.EndpointID); err != nil {
+		return nil, entity.ErrWebhookDeliveryNotFound
+	}
+
+	d := entity.NewWebhookDelivery(original.EndpointID, original.EventID, original.EventType, original.Payload, s.leaseUntil(time.Now()))
+	d.ReplayOf = &original.ID
+	if err := s.repo.SaveDelivery(ctx, d); err != nil {
+		return nil, err
+	}
+	recordAudit(tenantAuditContext(ctx, tenantID), s.audit, entity.AuditActionWebhookReplay, "webhook_delivery", d.ID, uuid.Nil, nil, d)
+	if err := s.pool.Submit(&webhookDeliveryJob{webhooks: s, deliveryID: d.ID}); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// RetryDue queues every pending delivery whose next attempt is due
+func (s *WebhookService) RetryDue(ctx context.Context, now time.Time) (int, error) {
+	due, err := s.repo.FindDueDeliveries(ctx, now, webhookRetryBatch)
+	if err != nil {
+		return 0, err
+	}
+	for i, d := range due {
+		if err := s.pool.Submit(&webhookDeliveryJob{webhooks: s, deliveryID: d.ID}); err != nil {
+			return i, err
+		}
+	}
+	return len(due), nil
+}
+
+// Deliver makes one attempt at a pending delivery and records the outcome.
+// The attempt is leased first so the retry job does not pick it up meanwhile.
+func (s *WebhookService) Deliver(ctx context.Context, deliveryID uuid.UUID) error {
+	d, err := s.repo.FindDeliveryByID(ctx, deliveryID)
+	if err != nil {
+		return err
+	}
+	if d.Status != entity.WebhookDeliveryPending {
+		return nil
+	}
+	e, err := s.repo.FindEndpointByID(ctx, d.EndpointID)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	lease := s.leaseUntil(now)
+	d.NextAttemptAt = &lease
+	if err := s.repo.UpdateDelivery(ctx, d); err != nil {
+		return err
+	}
+
+	statusCode, sendErr := s.send(ctx, e, d, now)
+	if sendErr == nil {
+		d.MarkSucceeded(statusCode, time.Now())
+		return s.repo.UpdateDelivery(ctx, d)
+	}
+
+	var next *time.Time
+	if e.Active && d.Attempts+1 < s.maxAttempts {
+		at := time.Now().Add(s.backoffAfter(d.Attempts + 1))
+		next = &at
+	}
+	d.MarkAttemptFailed(statusCode, sendErr.Error(), next)
+	if err := s.repo.UpdateDelivery(ctx, d); err != nil {
+		return err
+	}
+	return fmt.Errorf("webhook delivery %s: %w", d.ID, sendErr)
+}
+
+// send POSTs the signed payload; any non-2xx answer is an error
+func (s *WebhookService) send(ctx context.Context, e *entity.WebhookEndpoint, d *entity.WebhookDelivery, now time.Time) (int, error) {
+	if !e.Active {
+		return 0, errors.New("endpoint is disabled")
+	}
+	// The host may resolve elsewhere than when the endpoint was registered
+	if err := s.checkAddress(ctx, e.URL); err != nil {
+		return 0, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.URL, bytes.NewReader(d.Payload))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "SubFlow-Webhooks/1.0")
+	req.Header.Set(WebhookEventHeader, string(d.EventType))
+	req.Header.Set(WebhookEventIDHeader, d.EventID.String())
+	req.Header.Set(WebhookDeliveryHeader, d.ID.String())
+	req.Header.Set(WebhookSignatureHeader, SignWebhookPayload(e.Secret, now.Unix(), d.Payload))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("receiver answered %s", resp.Status)
+	}
+	return resp.StatusCode, nil
+}
+
+// backoffAfter returns the wait after the given number of failed attempts
+func (s *WebhookService) backoffAfter(attempts int) time.Duration {
+	wait := s.backoff
+	for i := 1; i < attempts && wait < maxWebhookBackoff; i++ {
+		wait *= 2
+	}
+	if wait > maxWebhookBackoff {
+		wait = maxWebhookBackoff
+	}
+	return wait
+}
+
+// leaseUntil is when an attempt started at now is considered lost
+func (s *WebhookService) leaseUntil(now time.Time) time.Time {
+	return now.Add(2 * webhookAttemptTimeout)
+}
+
+// SignWebhookPayload returns the signature header value for a payload:
+// t=<timestamp>,v1=<hex HMAC-SHA256 of "<timestamp>.<payload>" keyed by the secret>
+func SignWebhookPayload(secret string, timestamp int64, payload []byte) string {
+	ts := strconv.FormatInt(timestamp, 10)
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(ts))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+	return "t=" + ts + ",v1=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// webhookDeliveryJob makes one delivery attempt on the worker pool
+type webhookDeliveryJob struct {
+	webhooks   *WebhookService
+	deliveryID uuid.UUID
+}
+
+func (j *webhookDeliveryJob) ID() string {
+	return "webhook-delivery-" + j.deliveryID.String()
+}
+
+// Kind groups the per-delivery jobs in metrics
+func (j *webhookDeliveryJob) Kind() string {
+	return "webhook-delivery"
+}
+
+func (j *webhookDeliveryJob) Execute(ctx context.Context) error {
+	return j.webhooks.Deliver(ctx, j.deliveryID)
+}
+
+// WebhookRetryJob queues due webhook retries; run it with RunEvery
+type WebhookRetryJob struct {
+	webhooks *WebhookService
+}
+
+// NewWebhookRetryJob creates the scheduled webhook retry job
+func NewWebhookRetryJob(webhooks *WebhookService) *WebhookRetryJob {
+	return &WebhookRetryJob{webhooks: webhooks}
+}
+
+func (j *WebhookRetryJob) ID() string {
+	return "webhook-retry"
+}
+
+func (j *WebhookRetryJob) Execute(ctx context.Context) error {
+	_, err := j.webhooks.RetryDue(ctx, time.Now())
+	return err
+}
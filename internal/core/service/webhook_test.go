@@ -0,0 +1,336 @@
+// Copyright (c) 2026 Muhammet Ali Büyük. All rights reserved.
+// This source code is proprietary. Confidential and private.
+// Unauthorized copying or distribution is strictly prohibited.
+// Contact: iletisim@alibuyuk.net | Website: alibuyuk.net
+
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/qantesm/subflow/internal/core/entity"
+)
+
+type stubWebhookRepository struct {
+	mu         sync.Mutex
+	endpoints  []*entity.WebhookEndpoint
+	deliveries map[uuid.UUID]entity.WebhookDelivery
+}
+
+func (r *stubWebhookRepository) SaveEndpoint(ctx context.Context, e *entity.WebhookEndpoint) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.endpoints = append(r.endpoints, e)
+	return nil
+}
+
+func (r *stubWebhookRepository) UpdateEndpoint(ctx context.Context, e *entity.WebhookEndpoint) error {
+	return nil
+}
+
+func (r *stubWebhookRepository) FindEndpointByID(ctx context.Context, id uuid.UUID) (*entity.WebhookEndpoint, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, e := range r.endpoints {
+		if e.ID == id {
+			return e, nil
+		}
+	}
+	return nil, entity.ErrWebhookEndpointNotFound
+}
+
+func (r *stubWebhookRepository) FindEndpointsByTenant(ctx context.Context, tenantID uuid.UUID) ([]*entity.WebhookEndpoint, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var endpoints []*entity.WebhookEndpoint
+	for _, e := range r.endpoints {
+		if e.TenantID == tenantID {
+			endpoints = append(endpoints, e)
+		}
+	}
+	return endpoints, nil
+}
+
+func (r *stubWebhookRepository) SaveDelivery(ctx context.Context, d *entity.WebhookDelivery) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, existing := range r.deliveries {
+		if d.ReplayOf == nil && existing.ReplayOf == nil && existing.EndpointID == d.EndpointID && existing.EventID == d.EventID {
+			return entity.ErrWebhookDeliveryExists
+		}
+	}
+	if r.deliveries == nil {
+		r.deliveries = make(map[uuid.UUID]entity.WebhookDelivery)
+	}
+	r.deliveries[d.ID] = *d
+	return nil
+}
+
+func (r *stubWebhookRepository) UpdateDelivery(ctx context.Context, d *entity.WebhookDelivery) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.deliveries[d.ID] = *d
+	return nil
+}
+
+func (r *stubWebhookRepository) FindDeliveryByID(ctx context.Context, id uuid.UUID) (*entity.WebhookDelivery, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	d, ok := r.deliveries[id]
+	if !ok {
+		return nil, entity.ErrWebhookDeliveryNotFound
+	}
+	return &d, nil
+}
+
+func (r *stubWebhookRepository) FindDeliveriesByEndpoint(ctx context.Context, endpointID uuid.UUID, limit int) ([]*entity.WebhookDelivery, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var deliveries []*entity.WebhookDelivery
+	for _, d := range r.deliveries {
+		if d.EndpointID == endpointID {
+			d := d
+			deliveries = append(deliveries, &d)
+		}
+	}
+	return deliveries, nil
+}
+
+func (r *stubWebhookRepository) FindDueDeliveries(ctx context.Context, now time.Time, limit int) ([]*entity.WebhookDelivery, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var due []*entity.WebhookDelivery
+	for _, d := range r.deliveries {
+		if d.Status == entity.WebhookDeliveryPending && d.NextAttemptAt != nil && !d.NextAttemptAt.After(now) {
+			d := d
+			due = append(due, &d)
+		}
+	}
+	return due, nil
+}
+
+// webhookReceiver answers with the queued status codes, then 200
+type webhookReceiver struct {
+	mu       sync.Mutex
+	statuses []int
+	requests []*http.Request
+	bodies   [][]byte
+}
+
+func (rc *webhookReceiver) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	rc.requests = append(rc.requests, r)
+	rc.bodies = append(rc.bodies, body)
+	status := http.StatusOK
+	if len(rc.statuses) > 0 {
+		status, rc.statuses = rc.statuses[0], rc.statuses[1:]
+	}
+	w.WriteHeader(status)
+}
+
+func TestWebhookDelivery(t *testing.T) {
+	ctx := context.Background()
+	receiver := &webhookReceiver{statuses: []int{http.StatusInternalServerError}}
+	server := httptest.NewServer(receiver)
+	defer server.Close()
+
+	pool := NewWorkerPool(2)
+	pool.Start()
+	defer pool.Stop()
+
+	projects := &stubProjectRepository{}
+	repo := &stubWebhookRepository{}
+	webhooks := NewWebhookService(repo, projects, pool, server.Client(), 3, time.Millisecond)
+	webhooks.allowIP = func(net.IP) bool { return true } // The receiver listens on loopback
+	user := uuid.New()
+	tenant := uuid.New()
+
+	project := entity.NewProject(tenant, "Alsancak Ofis", "PRJ-2026-041")
+	_ = projects.Create(ctx, project)
+
+	endpoint, _ := entity.NewWebhookEndpoint(tenant, server.URL+"/hooks", []entity.EventType{entity.EventTransactionCreated}, user)
+	if err := webhooks.CreateEndpoint(ctx, endpoint); err != nil {
+		t.Fatalf("CreateEndpoint() error = %v", err)
+	}
+	other, _ := entity.NewWebhookEndpoint(tenant, server.URL+"/other", []entity.EventType{entity.EventPayApplicationCertified}, user)
+	_ = webhooks.CreateEndpoint(ctx, other)
+	invalid, _ := entity.NewWebhookEndpoint(tenant, "ftp://example.com", []entity.EventType{"ledger.exploded"}, user)
+	if err := webhooks.CreateEndpoint(ctx, invalid); !errors.Is(err, entity.ErrInvalidWebhookEndpoint) {
+		t.Errorf("invalid endpoint error = %v", err)
+	}
+
+	transactions := &stubTransactionRepository{}
+	ledger := NewLedgerService(transactions)
+	tx, err := ledger.RecordInvoice(ctx, project.ID, 150000, "TRY", "HKD-2026-001", user)
+	if err != nil {
+		t.Fatalf("RecordInvoice() error = %v", err)
+	}
+	// The outbox hands the event over twice; the second time adds no delivery
+	event := transactions.events[len(transactions.events)-1]
+	if err := webhooks.Publish(ctx, event); err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+	if err := webhooks.Publish(ctx, event); err != nil {
+		t.Fatalf("Publish() again error = %v", err)
+	}
+
+	// First attempt gets a 500 and is scheduled for a retry
+	if results := pool.WaitForCompletion(1); len(results) != 1 || results[0].Error == nil {
+		t.Fatalf("first attempt results = %+v, want a failure", results)
+	}
+	deliveries, _ := webhooks.ListDeliveries(ctx, tenant, endpoint.ID, 10)
+	if len(deliveries) != 1 {
+		t.Fatalf("deliveries = %d, want 1 (the other endpoint is not subscribed)", len(deliveries))
+	}
+	d := deliveries[0]
+	if d.Status != entity.WebhookDeliveryPending || d.Attempts != 1 || d.LastStatusCode != 500 || d.NextAttemptAt == nil {
+		t.Fatalf("after a failed attempt = %+v", d)
+	}
+
+	if n, err := webhooks.RetryDue(ctx, time.Now().Add(time.Second)); err != nil || n != 1 {
+		t.Fatalf("RetryDue() = %d, %v", n, err)
+	}
+	if results := pool.WaitForCompletion(1); len(results) != 1 || results[0].Error != nil {
+		t.Fatalf("retry results = %+v, want success", results)
+	}
+	d, _ = webhooks.repo.FindDeliveryByID(ctx, d.ID)
+	if d.Status != entity.WebhookDeliverySucceeded || d.Attempts != 2 || d.DeliveredAt == nil {
+		t.Errorf("after the retry = %+v", d)
+	}
+
+	// The request is signed over its timestamp and body
+	req, body := receiver.requests[1], receiver.bodies[1]
+	signature := req.Header.Get(WebhookSignatureHeader)
+	ts := strings.TrimPrefix(strings.Split(signature, ",")[0], "t=")
+	var unix int64
+	_ = json.Unmarshal([]byte(ts), &unix)
+	if signature != SignWebhookPayload(endpoint.Secret, unix, body) {
+		t.Errorf("signature %q does not match the body", signature)
+	}
+	if req.Header.Get(WebhookEventHeader) != string(entity.EventTransactionCreated) || req.URL.Path != "/hooks" {
+		t.Errorf("request %s with event %q", req.URL.Path, req.Header.Get(WebhookEventHeader))
+	}
+	var payload WebhookPayload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		t.Fatalf("payload is not JSON: %v", err)
+	}
+	var sent entity.Transaction
+	_ = json.Unmarshal(payload.Data, &sent)
+	if payload.TenantID != tenant || payload.ProjectID != project.ID || sent.ID != tx.ID {
+		t.Errorf("payload = %+v", payload)
+	}
+
+	// A replay resends the same body under a new delivery with the same event ID
+	replay, err := webhooks.Replay(ctx, tenant, d.ID)
+	if err != nil {
+		t.Fatalf("Replay() error = %v", err)
+	}
+	pool.WaitForCompletion(1)
+	if replay.ReplayOf == nil || *replay.ReplayOf != d.ID || string(receiver.bodies[2]) != string(body) {
+		t.Errorf("replay = %+v", replay)
+	}
+	if receiver.requests[2].Header.Get(WebhookEventIDHeader) != req.Header.Get(WebhookEventIDHeader) {
+		t.Errorf("replay changed the event ID")
+	}
+	if _, err := webhooks.Replay(ctx, uuid.New(), d.ID); !errors.Is(err, entity.ErrWebhookDeliveryNotFound) {
+		t.Errorf("replay from another tenant error = %v", err)
+	}
+
+	// A receiver that keeps failing exhausts the attempts
+	receiver.mu.Lock()
+	receiver.statuses = []int{502, 502, 502}
+	receiver.mu.Unlock()
+	if _, err := ledger.RecordPayment(ctx, project.ID, nil, 50000, "TRY", "DK-1", user); err != nil {
+		t.Fatalf("RecordPayment() error = %v", err)
+	}
+	_ = webhooks.Publish(ctx, transactions.events[len(transactions.events)-1])
+	pool.WaitForCompletion(1)
+	for i := 0; i < 2; i++ {
+		_, _ = webhooks.RetryDue(ctx, time.Now().Add(time.Hour))
+		pool.WaitForCompletion(1)
+	}
+	if n, _ := webhooks.RetryDue(ctx, time.Now().Add(time.Hour)); n != 0 {
+		t.Errorf("RetryDue() after exhaustion = %d, want 0", n)
+	}
+	var failed int
+	for _, d := range repo.deliveries {
+		if d.Status == entity.WebhookDeliveryFailed && d.Attempts == 3 && d.LastStatusCode == 502 && d.NextAttemptAt == nil {
+			failed++
+		}
+	}
+	if failed != 1 {
+		t.Errorf("failed deliveries = %d, want 1", failed)
+	}
+}
+
+func TestWebhookRefusesInternalAddresses(t *testing.T) {
+	ctx := context.Background()
+	receiver := &webhookReceiver{}
+	server := httptest.NewServer(receiver)
+	defer server.Close()
+
+	repo := &stubWebhookRepository{}
+	webhooks := NewWebhookService(repo, nil, nil, nil, 0, 0)
+	tenant, user := uuid.New(), uuid.New()
+	events := []entity.EventType{entity.EventTransactionCreated}
+
+	for _, rawURL := range []string{
+		server.URL + "/hooks",
+		"http://10.1.2.3/hooks",
+		"https://192.168.1.20/hooks",
+		"http://[::1]:8080/hooks",
+		"http://169.254.169.254/latest/meta-data",
+		"http://0.0.0.0/hooks",
+	} {
+		endpoint, _ := entity.NewWebhookEndpoint(tenant, rawURL, events, user)
+		if err := webhooks.CreateEndpoint(ctx, endpoint); !errors.Is(err, entity.ErrWebhookAddressNotAllowed) {
+			t.Errorf("CreateEndpoint(%s) error = %v, want ErrWebhookAddressNotAllowed", rawURL, err)
+		}
+	}
+	public, _ := entity.NewWebhookEndpoint(tenant, "https://203.0.113.10/hooks", events, user)
+	if err := webhooks.CreateEndpoint(ctx, public); err != nil {
+		t.Errorf("CreateEndpoint(public) error = %v", err)
+	}
+
+	// An endpoint that resolves internally by the time of delivery is not called
+	internal, _ := entity.NewWebhookEndpoint(tenant, server.URL+"/hooks", events, user)
+	_ = repo.SaveEndpoint(ctx, internal)
+	d := entity.NewWebhookDelivery(internal.ID, uuid.New(), entity.EventTransactionCreated, []byte(`{}`), time.Now())
+	_ = repo.SaveDelivery(ctx, d)
+	if err := webhooks.Deliver(ctx, d.ID); !errors.Is(err, entity.ErrWebhookAddressNotAllowed) {
+		t.Errorf("Deliver() error = %v, want ErrWebhookAddressNotAllowed", err)
+	}
+
+	// The default client refuses the connection itself, which also covers redirects
+	if _, err := webhooks.client.Get(server.URL); !errors.Is(err, entity.ErrWebhookAddressNotAllowed) {
+		t.Errorf("client.Get() error = %v, want ErrWebhookAddressNotAllowed", err)
+	}
+	if len(receiver.requests) != 0 {
+		t.Errorf("receiver got %d requests, want none", len(receiver.requests))
+	}
+}
+
+func TestWebhookBackoff(t *testing.T) {
+	s := NewWebhookService(nil, nil, nil, nil, 0, time.Minute)
+	if s.maxAttempts != DefaultWebhookMaxAttempts {
+		t.Errorf("max attempts = %d, want the default", s.maxAttempts)
+	}
+	for attempts, want := range map[int]time.Duration{1: time.Minute, 2: 2 * time.Minute, 4: 8 * time.Minute, 20: maxWebhookBackoff} {
+		if got := s.backoffAfter(attempts); got != want {
+			t.Errorf("backoffAfter(%d) = %v, want %v", attempts, got, want)
+		}
+	}
+}
@@ -8,6 +8,7 @@ package service
 import (
 	"context"
 	"sync"
+	"time"
 )
 
 // Job represents a unit of work for the worker pool
@@ -18,8 +19,19 @@ type Job interface {
 
 // JobResult contains the outcome of a job execution
 type JobResult struct {
-	JobID string
-	Error error
+	JobID    string
+	Kind     string // See JobKind
+	Duration time.Duration
+	Error    error
+}
+
+// JobKind is the name a job is counted under in metrics: its Kind when it has
+// one, for jobs whose ID is unique per run, else its ID
+func JobKind(job Job) string {
+	if k, ok := job.(interface{ Kind() string }); ok {
+		return k.Kind()
+	}
+	return job.ID()
 }
 
 // WorkerPool manages concurrent job execution using Go routines
@@ -36,12 +48,18 @@ type WorkerPool struct {
 
 // NewWorkerPool creates a new worker pool with specified worker count
 func NewWorkerPool(workerCount int) *WorkerPool {
+	return NewWorkerPoolWithQueue(workerCount, workerCount*10) // Buffer for 10x workers
+}
+
+// NewWorkerPoolWithQueue creates a worker pool queueing up to queueSize jobs
+// before Submit waits for a free worker
+func NewWorkerPoolWithQueue(workerCount, queueSize int) *WorkerPool {
 	ctx, cancel := context.WithCancel(context.Background())
 	
 	return &WorkerPool{
 		workerCount: workerCount,
-		jobQueue:    make(chan Job, workerCount*10), // Buffer for 10x workers
-		results:     make(chan JobResult, workerCount*10),
+		jobQueue:    make(chan Job, queueSize),
+		results:     make(chan JobResult, queueSize),
 		ctx:         ctx,
 		cancel:      cancel,
 		architect:   "Muhammet-Ali-Buyuk",
@@ -70,11 +88,13 @@ func (wp *WorkerPool) worker(id int) {
 			}
 			
 			// Execute the job
+			start := time.Now()
 			err := job.Execute(wp.ctx)
+			result := JobResult{JobID: job.ID(), Kind: JobKind(job), Duration: time.Since(start), Error: err}
 			
 			// Send result
 			select {
-			case wp.results <- JobResult{JobID: job.ID(), Error: err}:
+			case wp.results <- result:
 			case <-wp.ctx.Done():
 				return
 			}
@@ -83,7 +103,11 @@ func (wp *WorkerPool) worker(id int) {
 }
 
 // Submit adds a job to the queue
+// After Stop it fails with context.Canceled instead of queueing a job no worker will run.
 func (wp *WorkerPool) Submit(job Job) error {
+	if err := wp.ctx.Err(); err != nil {
+		return err
+	}
 	select {
 	case <-wp.ctx.Done():
 		return wp.ctx.Err()
@@ -92,15 +116,26 @@ func (wp *WorkerPool) Submit(job Job) error {
 	}
 }
 
+// QueueDepth returns how many submitted jobs wait for a worker
+func (wp *WorkerPool) QueueDepth() int {
+	return len(wp.jobQueue)
+}
+
+// QueueCapacity returns how many jobs can wait before Submit blocks
+func (wp *WorkerPool) QueueCapacity() int {
+	return cap(wp.jobQueue)
+}
+
 // Results returns the channel for receiving job results
 func (wp *WorkerPool) Results() <-chan JobResult {
 	return wp.results
 }
 
 // Stop gracefully shuts down the worker pool
+// The job queue is never closed, so a Submit racing with Stop cannot panic;
+// workers stop on the cancelled context and queued jobs are dropped.
 func (wp *WorkerPool) Stop() {
 	wp.cancel()
-	close(wp.jobQueue)
 	wp.wg.Wait()
 	close(wp.results)
 }
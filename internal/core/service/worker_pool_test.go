@@ -0,0 +1,43 @@
+// Copyright (c) 2026 Muhammet Ali Büyük. All rights reserved.
+// This source code is proprietary. Confidential and private.
+// Unauthorized copying or distribution is strictly prohibited.
+// Contact: iletisim@alibuyuk.net | Website: alibuyuk.net
+
+package service
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+)
+
+func TestWorkerPoolSubmitRacingStop(t *testing.T) {
+	pool := NewWorkerPoolWithQueue(2, 4)
+	pool.Start()
+
+	// Drain results so workers never block on a full results channel
+	go func() {
+		for range pool.Results() {
+		}
+	}()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				if err := pool.Submit(NewPDFGenerationJob("pdf", "", "", "")); err != nil {
+					return
+				}
+			}
+		}()
+	}
+	pool.Stop()
+	wg.Wait()
+
+	if err := pool.Submit(NewPDFGenerationJob("late", "", "", "")); !errors.Is(err, context.Canceled) {
+		t.Errorf("Submit() after Stop error = %v, want context.Canceled", err)
+	}
+}
@@ -6,6 +6,7 @@
 package pkg
 
 import (
+	"context"
 	"os"
 	"sync"
 	"time"
@@ -46,6 +47,28 @@ func InitLogger(level string, pretty bool) {
 	})
 }
 
+type loggerContextKey string
+
+// LoggerKey is the context key of the request logger; Fiber middleware sets it with c.Locals
+const LoggerKey loggerContextKey = "logger"
+
+// WithLogger returns a context carrying l, for work started outside HTTP requests
+func WithLogger(ctx context.Context, l zerolog.Logger) context.Context {
+	return context.WithValue(ctx, LoggerKey, l)
+}
+
+// Ctx returns the logger carried by ctx, tagged with the request ID of the
+// request being served, or the global logger
+func Ctx(ctx context.Context) *zerolog.Logger {
+	if ctx != nil {
+		if l, ok := ctx.Value(LoggerKey).(zerolog.Logger); ok {
+			return &l
+		}
+	}
+	l := logger
+	return &l
+}
+
 // GetLogger returns the global logger instance
 func GetLogger() zerolog.Logger {
 	return logger
@@ -0,0 +1,52 @@
+-- Migration: 000002_bank_statements
+-- Copyright (c) 2026 Muhammet Ali Büyük. All rights reserved.
+-- Contact: iletisim@alibuyuk.net | Website: alibuyuk.net
+
+-- +goose Up
+-- Bank Statements Table (MT940 / CAMT.053 imports)
+CREATE TABLE bank_statements (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    project_id UUID NOT NULL REFERENCES projects(id),
+    format VARCHAR(20) NOT NULL CHECK (format IN ('MT940', 'CAMT053')),
+    account_id VARCHAR(64) NOT NULL,
+    statement_no VARCHAR(64),
+    currency CHAR(3) NOT NULL,
+    opening_balance_cents BIGINT NOT NULL,
+    closing_balance_cents BIGINT NOT NULL,
+    statement_date DATE NOT NULL,
+    imported_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    imported_by UUID NOT NULL
+);
+
+CREATE INDEX idx_bank_statements_project ON bank_statements(project_id);
+
+-- Bank Statement Lines Table
+-- Booked data is immutable; only the reconciliation columns change
+CREATE TABLE bank_statement_lines (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    statement_id UUID NOT NULL REFERENCES bank_statements(id) ON DELETE CASCADE,
+    line_no INTEGER NOT NULL,
+    booking_date DATE NOT NULL,
+    value_date DATE NOT NULL,
+    direction VARCHAR(10) NOT NULL CHECK (direction IN ('CREDIT', 'DEBIT')),
+    amount_cents BIGINT NOT NULL CHECK (amount_cents > 0),
+    currency CHAR(3) NOT NULL,
+    reference VARCHAR(255),
+    bank_reference VARCHAR(255),
+    description TEXT,
+    counterparty_name VARCHAR(255),
+    counterparty_account VARCHAR(64),
+    status VARCHAR(20) NOT NULL DEFAULT 'UNMATCHED' CHECK (status IN ('UNMATCHED', 'MATCHED', 'IGNORED')),
+    matched_transaction_id UUID REFERENCES transactions(id),
+    matched_at TIMESTAMP WITH TIME ZONE,
+    UNIQUE(statement_id, line_no)
+);
+
+CREATE INDEX idx_statement_lines_statement ON bank_statement_lines(statement_id);
+-- A ledger transaction can settle at most one statement line
+CREATE UNIQUE INDEX idx_statement_lines_matched_tx ON bank_statement_lines(matched_transaction_id)
+    WHERE matched_transaction_id IS NOT NULL;
+
+-- +goose Down
+DROP TABLE IF EXISTS bank_statement_lines;
+DROP TABLE IF EXISTS bank_statements;
@@ -0,0 +1,40 @@
+-- Migration: 000003_pay_applications
+-- Copyright (c) 2026 Muhammet Ali Büyük. All rights reserved.
+-- Contact: iletisim@alibuyuk.net | Website: alibuyuk.net
+
+-- +goose Up
+-- Pay Applications Table (AIA G702 / hakediş)
+-- Amounts are a snapshot of the G702 calculation; only workflow columns change
+CREATE TABLE pay_applications (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    project_id UUID NOT NULL REFERENCES projects(id),
+    application_no INTEGER NOT NULL,
+    period_start DATE NOT NULL,
+    period_end DATE NOT NULL,
+    currency CHAR(3) NOT NULL DEFAULT 'TRY',
+    status VARCHAR(20) NOT NULL DEFAULT 'DRAFT' CHECK (status IN ('DRAFT', 'SUBMITTED', 'CERTIFIED', 'REJECTED')),
+    contract_sum_cents BIGINT NOT NULL DEFAULT 0,
+    previous_work_cents BIGINT NOT NULL DEFAULT 0,
+    current_work_cents BIGINT NOT NULL DEFAULT 0,
+    stored_materials_cents BIGINT NOT NULL DEFAULT 0,
+    total_completed_cents BIGINT NOT NULL DEFAULT 0,
+    total_retainage_cents BIGINT NOT NULL DEFAULT 0,
+    total_earned_cents BIGINT NOT NULL DEFAULT 0,
+    previous_certificates_cents BIGINT NOT NULL DEFAULT 0,
+    current_payment_due_cents BIGINT NOT NULL DEFAULT 0,
+    invoice_transaction_id UUID REFERENCES transactions(id),
+    submitted_at TIMESTAMP WITH TIME ZONE,
+    certified_at TIMESTAMP WITH TIME ZONE,
+    certified_by UUID,
+    rejection_reason TEXT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    created_by UUID NOT NULL,
+    UNIQUE(project_id, application_no)
+);
+
+CREATE INDEX idx_pay_applications_project ON pay_applications(project_id);
+CREATE INDEX idx_pay_applications_status ON pay_applications(status);
+
+-- +goose Down
+DROP TABLE IF EXISTS pay_applications;
@@ -0,0 +1,49 @@
+-- Migration: 000004_einvoices
+-- Copyright (c) 2026 Muhammet Ali Büyük. All rights reserved.
+-- Contact: iletisim@alibuyuk.net | Website: alibuyuk.net
+
+-- +goose Up
+-- E-Invoices Table (UBL-TR 1.2 e-Fatura)
+-- One document per ledger invoice; the rendered XML is kept verbatim
+CREATE TABLE e_invoices (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    project_id UUID NOT NULL REFERENCES projects(id),
+    transaction_id UUID NOT NULL UNIQUE REFERENCES transactions(id),
+    pay_application_id UUID REFERENCES pay_applications(id),
+    invoice_no CHAR(16) NOT NULL UNIQUE,
+    ettn UUID NOT NULL UNIQUE,
+    profile VARCHAR(20) NOT NULL,
+    type VARCHAR(20) NOT NULL,
+    issue_date TIMESTAMP WITH TIME ZONE NOT NULL,
+    currency CHAR(3) NOT NULL,
+    note TEXT,
+    seller JSONB NOT NULL,
+    buyer JSONB NOT NULL,
+    lines JSONB NOT NULL,
+    line_extension_cents BIGINT NOT NULL,
+    kdv_cents BIGINT NOT NULL,
+    withholding_cents BIGINT NOT NULL DEFAULT 0,
+    tax_inclusive_cents BIGINT NOT NULL,
+    payable_cents BIGINT NOT NULL,
+    xml BYTEA NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'GENERATED' CHECK (status IN ('GENERATED', 'SENT', 'ACCEPTED', 'REJECTED')),
+    integrator_ref VARCHAR(255),
+    status_message TEXT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    created_by UUID NOT NULL
+);
+
+CREATE INDEX idx_e_invoices_project ON e_invoices(project_id);
+
+-- Gapless invoice numbering per series and year (GİB requirement)
+CREATE TABLE e_invoice_sequences (
+    prefix CHAR(3) NOT NULL,
+    year INTEGER NOT NULL,
+    last_value BIGINT NOT NULL,
+    PRIMARY KEY (prefix, year)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS e_invoice_sequences;
+DROP TABLE IF EXISTS e_invoices;
@@ -0,0 +1,28 @@
+-- Migration: 000005_tax_rates
+-- Copyright (c) 2026 Muhammet Ali Büyük. All rights reserved.
+-- Contact: iletisim@alibuyuk.net | Website: alibuyuk.net
+
+-- +goose Up
+-- Tax Rates Table (per-tenant KDV, tevkifat and stamp duty rates)
+-- Statutory defaults live in code; a tenant row replaces the default for the same kind and code
+CREATE TABLE tax_rates (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id),
+    kind VARCHAR(20) NOT NULL CHECK (kind IN ('KDV', 'WITHHOLDING', 'STAMP_DUTY')),
+    code VARCHAR(20) NOT NULL,
+    name VARCHAR(255) NOT NULL DEFAULT '',
+    rate_ppm BIGINT NOT NULL CHECK (rate_ppm BETWEEN 0 AND 1000000),
+    valid_from DATE NOT NULL,
+    valid_to DATE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    CHECK (valid_to IS NULL OR valid_to >= valid_from)
+);
+
+CREATE INDEX idx_tax_rates_tenant ON tax_rates(tenant_id, kind, code, valid_from);
+
+-- Tax breakdown computed when the pay application was prepared
+ALTER TABLE pay_applications ADD COLUMN tax JSONB;
+
+-- +goose Down
+ALTER TABLE pay_applications DROP COLUMN IF EXISTS tax;
+DROP TABLE IF EXISTS tax_rates;
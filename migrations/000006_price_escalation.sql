@@ -0,0 +1,57 @@
+-- Migration: 000006_price_escalation
+-- Copyright (c) 2026 Muhammet Ali Büyük. All rights reserved.
+-- Contact: iletisim@alibuyuk.net | Website: alibuyuk.net
+
+-- +goose Up
+-- Price Indices Table (monthly TÜİK Yİ-ÜFE and sub-index values, shared by all tenants)
+CREATE TABLE price_indices (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    code VARCHAR(50) NOT NULL,
+    period DATE NOT NULL,
+    value BIGINT NOT NULL CHECK (value > 0),
+    source VARCHAR(255) NOT NULL DEFAULT '',
+    imported_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    UNIQUE (code, period)
+);
+
+-- Escalation Formulas Table (contract fiyat farkı formula, weights in basis points)
+CREATE TABLE escalation_formulas (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    project_id UUID NOT NULL REFERENCES projects(id),
+    name VARCHAR(255) NOT NULL DEFAULT '',
+    base_month DATE NOT NULL,
+    coefficient_bp BIGINT NOT NULL CHECK (coefficient_bp BETWEEN 1 AND 10000),
+    index_lag_months INTEGER NOT NULL DEFAULT 1 CHECK (index_lag_months >= 0),
+    components JSONB NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    created_by UUID NOT NULL
+);
+
+CREATE INDEX idx_escalation_formulas_project ON escalation_formulas(project_id);
+
+-- Escalation Results Table (audit of every adjustment and the index values it used)
+CREATE TABLE escalation_results (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    project_id UUID NOT NULL REFERENCES projects(id),
+    pay_application_id UUID NOT NULL REFERENCES pay_applications(id),
+    formula_id UUID NOT NULL REFERENCES escalation_formulas(id),
+    work_amount_cents BIGINT NOT NULL,
+    coefficient_bp BIGINT NOT NULL,
+    index_month DATE NOT NULL,
+    pn BIGINT NOT NULL,
+    amount_cents BIGINT NOT NULL,
+    indices JSONB NOT NULL,
+    calculated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    created_by UUID NOT NULL
+);
+
+CREATE INDEX idx_escalation_results_project ON escalation_results(project_id, calculated_at);
+
+-- Escalation amount of the period, shown on its own G702 line
+ALTER TABLE pay_applications ADD COLUMN price_escalation_cents BIGINT NOT NULL DEFAULT 0;
+
+-- +goose Down
+ALTER TABLE pay_applications DROP COLUMN IF EXISTS price_escalation_cents;
+DROP TABLE IF EXISTS escalation_results;
+DROP TABLE IF EXISTS escalation_formulas;
+DROP TABLE IF EXISTS price_indices;
@@ -0,0 +1,35 @@
+-- Migration: 000007_advance_payments
+-- Copyright (c) 2026 Muhammet Ali Büyük. All rights reserved.
+-- Contact: iletisim@alibuyuk.net | Website: alibuyuk.net
+
+-- +goose Up
+-- Advance payments (avans) and their recovery (avans mahsubu) are ledger entries
+ALTER TABLE transactions DROP CONSTRAINT IF EXISTS transactions_type_check;
+ALTER TABLE transactions ADD CONSTRAINT transactions_type_check CHECK (type IN (
+    'INVOICE', 'PAYMENT', 'RETAINAGE_HELD',
+    'RETAINAGE_RELEASE', 'ADJUSTMENT', 'DEDUCTION',
+    'ADVANCE_PAYMENT', 'ADVANCE_RECOVERY'
+));
+
+-- Advance Recovery Rules Table (one contract rule per project, rates in basis points)
+CREATE TABLE advance_recovery_rules (
+    project_id UUID PRIMARY KEY REFERENCES projects(id),
+    method VARCHAR(20) NOT NULL CHECK (method IN ('PERCENTAGE', 'COMPLETION_RANGE')),
+    rate_bp BIGINT NOT NULL DEFAULT 0 CHECK (rate_bp BETWEEN 0 AND 10000),
+    start_percent_bp BIGINT NOT NULL DEFAULT 0 CHECK (start_percent_bp BETWEEN 0 AND 10000),
+    end_percent_bp BIGINT NOT NULL DEFAULT 0 CHECK (end_percent_bp BETWEEN 0 AND 10000),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_by UUID NOT NULL
+);
+
+-- Advance recovered on the period, deducted from total earned
+ALTER TABLE pay_applications ADD COLUMN advance_recovery_cents BIGINT NOT NULL DEFAULT 0;
+
+-- +goose Down
+ALTER TABLE pay_applications DROP COLUMN IF EXISTS advance_recovery_cents;
+DROP TABLE IF EXISTS advance_recovery_rules;
+ALTER TABLE transactions DROP CONSTRAINT IF EXISTS transactions_type_check;
+ALTER TABLE transactions ADD CONSTRAINT transactions_type_check CHECK (type IN (
+    'INVOICE', 'PAYMENT', 'RETAINAGE_HELD',
+    'RETAINAGE_RELEASE', 'ADJUSTMENT', 'DEDUCTION'
+));
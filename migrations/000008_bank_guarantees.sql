@@ -0,0 +1,49 @@
+-- Migration: 000008_bank_guarantees
+-- Copyright (c) 2026 Muhammet Ali Büyük. All rights reserved.
+-- Contact: iletisim@alibuyuk.net | Website: alibuyuk.net
+
+-- +goose Up
+-- Bank Guarantees Table (teminat mektubu register; reductions kept as a JSONB history)
+CREATE TABLE bank_guarantees (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    project_id UUID NOT NULL REFERENCES projects(id),
+    contract_id UUID REFERENCES contracts(id),
+    bank VARCHAR(255) NOT NULL,
+    letter_no VARCHAR(100) NOT NULL,
+    type VARCHAR(20) NOT NULL CHECK (type IN ('BID', 'PERFORMANCE', 'ADVANCE')),
+    original_amount_cents BIGINT NOT NULL CHECK (original_amount_cents > 0),
+    amount_cents BIGINT NOT NULL CHECK (amount_cents > 0),
+    currency CHAR(3) NOT NULL DEFAULT 'TRY',
+    issue_date DATE NOT NULL,
+    expiry_date DATE,
+    status VARCHAR(20) NOT NULL DEFAULT 'ACTIVE' CHECK (status IN ('ACTIVE', 'RETURNED', 'EXPIRED')),
+    reductions JSONB NOT NULL DEFAULT '[]',
+    returned_at TIMESTAMP WITH TIME ZONE,
+    expiry_alerted_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    created_by UUID NOT NULL,
+    CONSTRAINT bank_guarantees_bank_letter_no_key UNIQUE (bank, letter_no)
+);
+
+CREATE INDEX idx_bank_guarantees_project ON bank_guarantees(project_id);
+CREATE INDEX idx_bank_guarantees_active_expiry ON bank_guarantees(expiry_date) WHERE status = 'ACTIVE';
+
+-- Notifications Table (project inbox; delivery channels read from here)
+CREATE TABLE notifications (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    project_id UUID NOT NULL REFERENCES projects(id),
+    kind VARCHAR(50) NOT NULL,
+    severity VARCHAR(20) NOT NULL CHECK (severity IN ('INFO', 'WARNING', 'CRITICAL')),
+    subject VARCHAR(255) NOT NULL,
+    body TEXT NOT NULL DEFAULT '',
+    reference_id UUID,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    read_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX idx_notifications_project ON notifications(project_id, created_at DESC);
+
+-- +goose Down
+DROP TABLE IF EXISTS notifications;
+DROP TABLE IF EXISTS bank_guarantees;
@@ -0,0 +1,64 @@
+-- Migration: 000009_deductions
+-- Copyright (c) 2026 Muhammet Ali Büyük. All rights reserved.
+-- Contact: iletisim@alibuyuk.net | Website: alibuyuk.net
+
+-- +goose Up
+-- Deduction Schedules Table (recurring kesintiler such as SGK premiums or site utilities)
+CREATE TABLE deduction_schedules (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    project_id UUID NOT NULL REFERENCES projects(id),
+    contract_id UUID REFERENCES contracts(id),
+    category VARCHAR(30) NOT NULL CHECK (category IN ('BACK_CHARGE', 'PENALTY', 'OWNER_MATERIAL', 'SGK', 'UTILITY', 'OTHER')),
+    reason VARCHAR(500) NOT NULL,
+    amount_cents BIGINT NOT NULL CHECK (amount_cents > 0),
+    currency CHAR(3) NOT NULL DEFAULT 'TRY',
+    interval_months INTEGER NOT NULL DEFAULT 1 CHECK (interval_months > 0),
+    start_date DATE NOT NULL,
+    end_date DATE,
+    next_date DATE NOT NULL,
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    created_by UUID NOT NULL
+);
+
+CREATE INDEX idx_deduction_schedules_project ON deduction_schedules(project_id);
+CREATE INDEX idx_deduction_schedules_due ON deduction_schedules(next_date) WHERE active;
+
+-- Deductions Table (each row is also a DEDUCTION ledger entry)
+CREATE TABLE deductions (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    project_id UUID NOT NULL REFERENCES projects(id),
+    contract_id UUID REFERENCES contracts(id),
+    category VARCHAR(30) NOT NULL CHECK (category IN ('BACK_CHARGE', 'PENALTY', 'OWNER_MATERIAL', 'SGK', 'UTILITY', 'OTHER')),
+    reason VARCHAR(500) NOT NULL,
+    amount_cents BIGINT NOT NULL CHECK (amount_cents > 0),
+    currency CHAR(3) NOT NULL DEFAULT 'TRY',
+    deduction_date DATE NOT NULL,
+    schedule_id UUID REFERENCES deduction_schedules(id),
+    penalty_days INTEGER NOT NULL DEFAULT 0,
+    transaction_id UUID REFERENCES transactions(id),
+    pay_application_id UUID REFERENCES pay_applications(id),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    created_by UUID NOT NULL
+);
+
+CREATE INDEX idx_deductions_project ON deductions(project_id, deduction_date);
+CREATE INDEX idx_deductions_pay_application ON deductions(pay_application_id);
+
+-- Late Penalty Rules Table (gecikme cezası; daily rate in ppm, cap in basis points)
+CREATE TABLE late_penalty_rules (
+    project_id UUID PRIMARY KEY REFERENCES projects(id),
+    daily_rate_ppm BIGINT NOT NULL CHECK (daily_rate_ppm BETWEEN 1 AND 1000000),
+    cap_rate_bp BIGINT NOT NULL DEFAULT 0 CHECK (cap_rate_bp BETWEEN 0 AND 10000),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_by UUID NOT NULL
+);
+
+-- Deductions withheld from the period's payment
+ALTER TABLE pay_applications ADD COLUMN deductions_cents BIGINT NOT NULL DEFAULT 0;
+
+-- +goose Down
+ALTER TABLE pay_applications DROP COLUMN IF EXISTS deductions_cents;
+DROP TABLE IF EXISTS late_penalty_rules;
+DROP TABLE IF EXISTS deductions;
+DROP TABLE IF EXISTS deduction_schedules;
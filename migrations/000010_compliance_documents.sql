@@ -0,0 +1,44 @@
+-- Migration: 000010_compliance_documents
+-- Copyright (c) 2026 Muhammet Ali Büyük. All rights reserved.
+-- Contact: iletisim@alibuyuk.net | Website: alibuyuk.net
+
+-- +goose Up
+-- Compliance Documents Table (lien waivers, insurance and clearance letters received per contract)
+CREATE TABLE compliance_documents (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    project_id UUID NOT NULL REFERENCES projects(id),
+    contract_id UUID NOT NULL REFERENCES contracts(id),
+    type VARCHAR(40) NOT NULL CHECK (type IN ('CONDITIONAL_LIEN_WAIVER', 'UNCONDITIONAL_LIEN_WAIVER', 'INSURANCE_CERTIFICATE', 'TAX_CLEARANCE', 'SGK_CLEARANCE')),
+    document_no VARCHAR(100) NOT NULL DEFAULT '',
+    issuer VARCHAR(255) NOT NULL DEFAULT '',
+    issue_date DATE NOT NULL,
+    expiry_date DATE,
+    amount_cents BIGINT NOT NULL DEFAULT 0 CHECK (amount_cents >= 0),
+    through_date DATE,
+    payment_id UUID REFERENCES transactions(id),
+    revoked_at TIMESTAMP WITH TIME ZONE,
+    expiry_alerted_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    created_by UUID NOT NULL,
+    CHECK (expiry_date IS NULL OR expiry_date >= issue_date)
+);
+
+CREATE INDEX idx_compliance_documents_contract ON compliance_documents(contract_id, type);
+CREATE INDEX idx_compliance_documents_expiry ON compliance_documents(expiry_date) WHERE revoked_at IS NULL AND expiry_date IS NOT NULL;
+
+-- Compliance Requirements Table (document types required before paying; NULL contract_id covers the whole project)
+CREATE TABLE compliance_requirements (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    project_id UUID NOT NULL REFERENCES projects(id),
+    contract_id UUID REFERENCES contracts(id),
+    type VARCHAR(40) NOT NULL CHECK (type IN ('CONDITIONAL_LIEN_WAIVER', 'UNCONDITIONAL_LIEN_WAIVER', 'INSURANCE_CERTIFICATE', 'TAX_CLEARANCE', 'SGK_CLEARANCE')),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    created_by UUID NOT NULL
+);
+
+CREATE UNIQUE INDEX compliance_requirements_scope_key
+    ON compliance_requirements(project_id, COALESCE(contract_id, '00000000-0000-0000-0000-000000000000'::uuid), type);
+
+-- +goose Down
+DROP TABLE IF EXISTS compliance_requirements;
+DROP TABLE IF EXISTS compliance_documents;
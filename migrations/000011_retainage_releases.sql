@@ -0,0 +1,41 @@
+-- Migration: 000011_retainage_releases
+-- Copyright (c) 2026 Muhammet Ali Büyük. All rights reserved.
+-- Contact: iletisim@alibuyuk.net | Website: alibuyuk.net
+
+-- +goose Up
+-- Substantial completion (geçici kabul) unlocks the final retainage release
+ALTER TABLE projects ADD COLUMN substantial_completion_date DATE;
+
+-- Retainage Releases Table (approval workflow; approvals and the release invoice kept as JSONB)
+CREATE TABLE retainage_releases (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    project_id UUID NOT NULL REFERENCES projects(id),
+    contract_id UUID REFERENCES contracts(id),
+    kind VARCHAR(20) NOT NULL CHECK (kind IN ('PARTIAL', 'FINAL')),
+    amount_cents BIGINT NOT NULL CHECK (amount_cents > 0),
+    currency CHAR(3) NOT NULL DEFAULT 'TRY',
+    reason TEXT NOT NULL DEFAULT '',
+    status VARCHAR(20) NOT NULL DEFAULT 'PENDING' CHECK (status IN ('PENDING', 'RELEASED', 'REJECTED')),
+    required_approvals INTEGER NOT NULL DEFAULT 1 CHECK (required_approvals >= 0),
+    approvals JSONB NOT NULL DEFAULT '[]',
+    rejected_by UUID,
+    rejection_reason TEXT NOT NULL DEFAULT '',
+    transaction_id UUID REFERENCES transactions(id),
+    invoice JSONB,
+    requested_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    requested_by UUID NOT NULL,
+    closed_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX idx_retainage_releases_project ON retainage_releases(project_id, requested_at);
+
+-- Gapless release invoice numbering per year
+CREATE TABLE retainage_invoice_sequences (
+    year INTEGER PRIMARY KEY,
+    last_value BIGINT NOT NULL
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS retainage_invoice_sequences;
+DROP TABLE IF EXISTS retainage_releases;
+ALTER TABLE projects DROP COLUMN IF EXISTS substantial_completion_date;
@@ -0,0 +1,25 @@
+-- Migration: 000012_project_status_history
+-- Copyright (c) 2026 Muhammet Ali Büyük. All rights reserved.
+-- Contact: iletisim@alibuyuk.net | Website: alibuyuk.net
+
+-- +goose Up
+-- Project status is a state machine; only the known statuses are stored
+ALTER TABLE projects ADD CONSTRAINT projects_status_check
+    CHECK (status IN ('DRAFT', 'ACTIVE', 'ON_HOLD', 'COMPLETED', 'CANCELLED'));
+
+-- Project Status History Table (append-only, written together with the status change)
+CREATE TABLE project_status_history (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    project_id UUID NOT NULL REFERENCES projects(id),
+    from_status VARCHAR(50) NOT NULL,
+    to_status VARCHAR(50) NOT NULL,
+    reason TEXT NOT NULL DEFAULT '',
+    changed_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    changed_by UUID NOT NULL
+);
+
+CREATE INDEX idx_project_status_history_project ON project_status_history(project_id, changed_at);
+
+-- +goose Down
+DROP TABLE IF EXISTS project_status_history;
+ALTER TABLE projects DROP CONSTRAINT IF EXISTS projects_status_check;
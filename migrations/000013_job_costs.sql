@@ -0,0 +1,76 @@
+-- Migration: 000013_job_costs
+-- Copyright (c) 2026 Muhammet Ali Büyük. All rights reserved.
+-- Contact: iletisim@alibuyuk.net | Website: alibuyuk.net
+
+-- +goose Up
+-- Cost Codes Table (iş kalemleri; a tree per project)
+CREATE TABLE cost_codes (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    project_id UUID NOT NULL REFERENCES projects(id) ON DELETE CASCADE,
+    parent_id UUID REFERENCES cost_codes(id),
+    code VARCHAR(50) NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    created_by UUID NOT NULL,
+    CONSTRAINT cost_codes_project_id_code_key UNIQUE (project_id, code)
+);
+
+CREATE INDEX idx_cost_codes_parent ON cost_codes(parent_id);
+
+-- Budget Lines Table (revisions are further lines, negative to reduce)
+CREATE TABLE budget_lines (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    project_id UUID NOT NULL REFERENCES projects(id) ON DELETE CASCADE,
+    cost_code_id UUID NOT NULL REFERENCES cost_codes(id),
+    description TEXT NOT NULL DEFAULT '',
+    amount_cents BIGINT NOT NULL CHECK (amount_cents <> 0),
+    currency CHAR(3) NOT NULL DEFAULT 'TRY',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    created_by UUID NOT NULL
+);
+
+CREATE INDEX idx_budget_lines_project ON budget_lines(project_id, cost_code_id);
+
+-- Cost Commitments Table (subcontracts and purchase orders)
+CREATE TABLE cost_commitments (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    project_id UUID NOT NULL REFERENCES projects(id) ON DELETE CASCADE,
+    cost_code_id UUID NOT NULL REFERENCES cost_codes(id),
+    source VARCHAR(20) NOT NULL CHECK (source IN ('SUBCONTRACT', 'PURCHASE_ORDER')),
+    contract_id UUID REFERENCES contracts(id),
+    reference_no VARCHAR(100) NOT NULL DEFAULT '',
+    vendor VARCHAR(255) NOT NULL DEFAULT '',
+    description TEXT NOT NULL DEFAULT '',
+    amount_cents BIGINT NOT NULL CHECK (amount_cents <> 0),
+    currency CHAR(3) NOT NULL DEFAULT 'TRY',
+    commitment_date DATE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    created_by UUID NOT NULL,
+    CHECK (source <> 'SUBCONTRACT' OR contract_id IS NOT NULL)
+);
+
+CREATE INDEX idx_cost_commitments_project ON cost_commitments(project_id, cost_code_id);
+
+-- Vendor Invoices Table (actual cost)
+CREATE TABLE vendor_invoices (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    project_id UUID NOT NULL REFERENCES projects(id) ON DELETE CASCADE,
+    cost_code_id UUID NOT NULL REFERENCES cost_codes(id),
+    commitment_id UUID REFERENCES cost_commitments(id),
+    vendor VARCHAR(255) NOT NULL DEFAULT '',
+    invoice_no VARCHAR(100) NOT NULL,
+    invoice_date DATE NOT NULL,
+    amount_cents BIGINT NOT NULL CHECK (amount_cents <> 0),
+    currency CHAR(3) NOT NULL DEFAULT 'TRY',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    created_by UUID NOT NULL,
+    CONSTRAINT vendor_invoices_project_id_vendor_invoice_no_key UNIQUE (project_id, vendor, invoice_no)
+);
+
+CREATE INDEX idx_vendor_invoices_project ON vendor_invoices(project_id, cost_code_id);
+
+-- +goose Down
+DROP TABLE IF EXISTS vendor_invoices;
+DROP TABLE IF EXISTS cost_commitments;
+DROP TABLE IF EXISTS budget_lines;
+DROP TABLE IF EXISTS cost_codes;
@@ -0,0 +1,23 @@
+-- Migration: 000014_cash_flow_plans
+-- Copyright (c) 2026 Muhammet Ali Büyük. All rights reserved.
+-- Contact: iletisim@alibuyuk.net | Website: alibuyuk.net
+
+-- +goose Up
+-- Cash Flow Plans Table (one per project; schedule of values and payment terms)
+CREATE TABLE cash_flow_plans (
+    project_id UUID PRIMARY KEY REFERENCES projects(id) ON DELETE CASCADE,
+    curve VARCHAR(20) NOT NULL DEFAULT 'LINEAR' CHECK (curve IN ('LINEAR', 'S_CURVE')),
+    start_date DATE NOT NULL,
+    end_date DATE NOT NULL,
+    schedule JSONB NOT NULL DEFAULT '[]',
+    net_days INTEGER NOT NULL DEFAULT 30 CHECK (net_days >= 0),
+    retainage_rate_bp BIGINT NOT NULL DEFAULT 0 CHECK (retainage_rate_bp BETWEEN 0 AND 10000),
+    retainage_days INTEGER NOT NULL DEFAULT 0 CHECK (retainage_days >= 0),
+    payable_days INTEGER NOT NULL DEFAULT 30 CHECK (payable_days >= 0),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_by UUID NOT NULL,
+    CHECK (end_date > start_date)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS cash_flow_plans;
@@ -0,0 +1,26 @@
+-- Migration: 000016_outbox
+-- Copyright (c) 2026 Muhammet Ali Büyük. All rights reserved.
+-- Contact: iletisim@alibuyuk.net | Website: alibuyuk.net
+
+-- +goose Up
+-- Outbox Events Table (domain events written in the same transaction as the change they announce)
+CREATE TABLE outbox_events (
+    id UUID PRIMARY KEY, -- The event ID, subscribers dedupe on it
+    type VARCHAR(50) NOT NULL,
+    project_id UUID NOT NULL,
+    occurred_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    data JSONB NOT NULL,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    published_at TIMESTAMP WITH TIME ZONE,
+    last_error TEXT NOT NULL DEFAULT ''
+);
+
+CREATE INDEX idx_outbox_events_pending ON outbox_events(next_attempt_at, occurred_at) WHERE published_at IS NULL;
+
+-- An event redelivered by the outbox does not create a second webhook delivery
+CREATE UNIQUE INDEX webhook_deliveries_endpoint_event_key ON webhook_deliveries(endpoint_id, event_id) WHERE replay_of IS NULL;
+
+-- +goose Down
+DROP INDEX IF EXISTS webhook_deliveries_endpoint_event_key;
+DROP TABLE IF EXISTS outbox_events;
@@ -0,0 +1,14 @@
+-- Migration: 000017_audit_log_indexes
+-- Copyright (c) 2026 Muhammet Ali Büyük. All rights reserved.
+-- Contact: iletisim@alibuyuk.net | Website: alibuyuk.net
+
+-- +goose Up
+-- Audit log query API filters by entity and actor within a tenant, newest first
+CREATE INDEX IF NOT EXISTS idx_audit_entity ON audit_logs(entity_type, entity_id);
+CREATE INDEX IF NOT EXISTS idx_audit_actor ON audit_logs(actor_id);
+CREATE INDEX IF NOT EXISTS idx_audit_tenant_created ON audit_logs(tenant_id, created_at DESC);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_audit_tenant_created;
+DROP INDEX IF EXISTS idx_audit_actor;
+DROP INDEX IF EXISTS idx_audit_entity;
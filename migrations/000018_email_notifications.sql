@@ -0,0 +1,40 @@
+-- Migration: 000018_email_notifications
+-- Copyright (c) 2026 Muhammet Ali Büyük. All rights reserved.
+-- Contact: iletisim@alibuyuk.net | Website: alibuyuk.net
+
+-- +goose Up
+-- Values the localized email templates fill in
+ALTER TABLE notifications ADD COLUMN IF NOT EXISTS params JSONB;
+
+CREATE TABLE notification_preferences (
+    user_id UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    email VARCHAR(255) NOT NULL,
+    locale VARCHAR(5) NOT NULL DEFAULT 'tr' CHECK (locale IN ('tr', 'en')),
+    digest VARCHAR(20) NOT NULL DEFAULT 'IMMEDIATE' CHECK (digest IN ('IMMEDIATE', 'DAILY', 'OFF')),
+    kinds TEXT[] NOT NULL DEFAULT '{}',
+    projects UUID[] NOT NULL DEFAULT '{}', -- Empty for every project of the tenant
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX idx_notification_preferences_tenant ON notification_preferences(tenant_id);
+
+-- Rendered emails held for the recipient's daily digest
+CREATE TABLE email_digest_entries (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    email VARCHAR(255) NOT NULL,
+    locale VARCHAR(5) NOT NULL,
+    notification_id UUID NOT NULL REFERENCES notifications(id) ON DELETE CASCADE,
+    subject VARCHAR(255) NOT NULL,
+    body TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    sent_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX idx_email_digest_pending ON email_digest_entries(created_at) WHERE sent_at IS NULL;
+
+-- +goose Down
+DROP TABLE IF EXISTS email_digest_entries;
+DROP TABLE IF EXISTS notification_preferences;
+ALTER TABLE notifications DROP COLUMN IF EXISTS params;
@@ -0,0 +1,16 @@
+// Copyright (c) 2026 Muhammet Ali Büyük. All rights reserved.
+// This source code is proprietary. Confidential and private.
+// Unauthorized copying or distribution is strictly prohibited.
+// Contact: iletisim@alibuyuk.net | Website: alibuyuk.net
+
+// Package migrations embeds the versioned SQL migrations in the binary.
+// Each file is NNNNNN_name.sql with a "-- +goose Up" and a "-- +goose Down"
+// section; never edit a file once it has been applied anywhere.
+package migrations
+
+import "embed"
+
+// FS holds every migration file
+//
+//go:embed *.sql
+var FS embed.FS
@@ -103,11 +103,56 @@ export interface FinancialSummary {
     total_invoiced: number;
     total_paid: number;
     total_retained: number;
+    total_deducted: number;
+    advance_outstanding: number;
     current_balance: number;
     currency: string;
     transaction_count: number;
 }
 
+// Earned value measures in cents; indices in basis points (10000 = 1.00)
+export interface EarnedValue {
+    bac: number;
+    pv: number;
+    ev: number;
+    ac: number;
+    sv: number;
+    cv: number;
+    spi: number;
+    cpi: number;
+    eac: number;
+    etc: number;
+    vac: number;
+}
+
+export interface EarnedValueLine extends EarnedValue {
+    cost_code_id: string;
+    parent_id?: string;
+    code: string;
+    name: string;
+    level: number;
+}
+
+export interface EarnedValuePeriod extends EarnedValue {
+    application_no: number;
+    period_end: string;
+    percent_complete: number;
+    percent_planned: number;
+}
+
+export interface EarnedValueReport {
+    project_id: string;
+    project_code: string;
+    project_name: string;
+    currency: string;
+    as_of: string;
+    percent_complete: number;
+    percent_planned: number;
+    total: EarnedValue;
+    lines: EarnedValueLine[];
+    history: EarnedValuePeriod[];
+}
+
 // API Functions
 export const projectsApi = {
     list: () => api.get<{ data: Project[] }>('/projects'),
@@ -117,7 +162,9 @@ export const projectsApi = {
         api.put<Project>(`/projects/${id}`, data),
     delete: (id: string) => api.delete(`/projects/${id}`),
     getFinancials: (id: string) =>
-        api.get<FinancialSummary>(`/projects/${id}/financials/summary`),
+        api.get<{ project_id: string; summaries: FinancialSummary[] }>(
+            `/projects/${id}/financials/summary`,
+        ),
 };
 
 export const transactionsApi = {
@@ -137,6 +184,11 @@ export const transactionsApi = {
     }) => api.post('/transactions/payment', data),
 };
 
+export const earnedValueApi = {
+    getByProject: (projectId: string) =>
+        api.get<EarnedValueReport>(`/earned-value/project/${projectId}`),
+};
+
 export const calculatorApi = {
     calculate: (input: AIABillingInput) =>
         api.post<{ result: AIABillingResult; formatted: Record<string, string> }>(